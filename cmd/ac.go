@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// acceptanceField is the history field recording criteria changes
+const acceptanceField = "acceptance_criteria"
+
+var acBy string
+
+var acCmd = &cobra.Command{
+	Use:   "ac",
+	Short: "Manage a task's acceptance criteria",
+	Long: `Manage a task's acceptance criteria: the conditions it must meet to be done,
+checked off one by one. Unlike gates, which are shared checks like tests or
+reviews, criteria belong to one task.
+
+Criteria are numbered in the order they were added. They show in 'gur show'
+and the agent brief, and as a checklist in the synced GitHub issue. With
+'gur config policy --require-acceptance', a task can't close until all its
+criteria are checked.
+
+Examples:
+  gur ac add gur-abc123 "Login works with SSO" "Session expires after 30 minutes"
+  gur ac list gur-abc123
+  gur ac check gur-abc123 1 --by agent
+  gur ac uncheck gur-abc123 1
+  gur ac remove gur-abc123 2`,
+}
+
+var acAddCmd = &cobra.Command{
+	Use:   "add <task-id> <text>...",
+	Short: "Add acceptance criteria to a task",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runAcAdd,
+}
+
+var acCheckCmd = &cobra.Command{
+	Use:   "check <task-id> <number>...",
+	Short: "Check off acceptance criteria",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setCriteriaDone(args[0], args[1:], true)
+	},
+}
+
+var acUncheckCmd = &cobra.Command{
+	Use:   "uncheck <task-id> <number>...",
+	Short: "Uncheck acceptance criteria",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setCriteriaDone(args[0], args[1:], false)
+	},
+}
+
+var acListCmd = &cobra.Command{
+	Use:         "list <task-id>",
+	Aliases:     []string{"ls"},
+	Short:       "List a task's acceptance criteria",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runAcList,
+}
+
+var acRemoveCmd = &cobra.Command{
+	Use:     "remove <task-id> <number>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove acceptance criteria",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    runAcRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(acCmd)
+	acCmd.AddCommand(acAddCmd)
+	acCmd.AddCommand(acCheckCmd)
+	acCmd.AddCommand(acUncheckCmd)
+	acCmd.AddCommand(acListCmd)
+	acCmd.AddCommand(acRemoveCmd)
+	for _, c := range []*cobra.Command{acAddCmd, acCheckCmd, acUncheckCmd, acRemoveCmd} {
+		c.Flags().StringVar(&acBy, "by", "human", "Who made the change (human/agent/name)")
+	}
+}
+
+// loadAcceptanceCriteria returns a task's criteria in order
+func loadAcceptanceCriteria(database *gorm.DB, taskID string) ([]models.AcceptanceCriterion, error) {
+	var criteria []models.AcceptanceCriterion
+	err := database.Where("task_id = ?", taskID).Order("position ASC, id ASC").Find(&criteria).Error
+	return criteria, err
+}
+
+// attachAcceptanceCriteria loads the criteria of tasks into their Criteria
+func attachAcceptanceCriteria(database *gorm.DB, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]string, len(tasks))
+	index := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+		index[t.ID] = i
+	}
+
+	var criteria []models.AcceptanceCriterion
+	if err := database.Where("task_id IN ?", ids).Order("position ASC, id ASC").Find(&criteria).Error; err != nil {
+		return fmt.Errorf("failed to load acceptance criteria: database error: %w", err)
+	}
+	for _, c := range criteria {
+		t := &tasks[index[c.TaskID]]
+		t.Criteria = append(t.Criteria, c)
+	}
+	return nil
+}
+
+// criteriaDone counts the checked criteria
+func criteriaDone(criteria []models.AcceptanceCriterion) int {
+	done := 0
+	for _, c := range criteria {
+		if c.Done {
+			done++
+		}
+	}
+	return done
+}
+
+// acceptanceRequired reports whether the policy requires every criterion
+// to be checked before close
+func acceptanceRequired() bool {
+	value, _ := db.GetConfig(models.ConfigPolicyRequireAcceptance)
+	return value == "true"
+}
+
+// pickCriteria returns the criteria with the given 1-based numbers
+func pickCriteria(taskID string, criteria []models.AcceptanceCriterion, numbers []string) ([]*models.AcceptanceCriterion, error) {
+	var picked []*models.AcceptanceCriterion
+	for _, arg := range numbers {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > len(criteria) {
+			if len(criteria) == 0 {
+				return nil, fmt.Errorf("task '%s' has no acceptance criteria (add one with 'gur ac add %s \"...\"')", taskID, taskID)
+			}
+			return nil, fmt.Errorf("invalid criterion '%s': task '%s' has criteria 1 to %d (see 'gur ac list %s')", arg, taskID, len(criteria), taskID)
+		}
+		picked = append(picked, &criteria[n-1])
+	}
+	return picked, nil
+}
+
+func runAcAdd(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot add acceptance criteria: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	var texts []string
+	for _, t := range args[1:] {
+		if t = strings.TrimSpace(t); t != "" {
+			texts = append(texts, t)
+		}
+	}
+	if len(texts) == 0 {
+		return fmt.Errorf("cannot add acceptance criteria: the text is empty")
+	}
+
+	database := db.GetDB()
+	var added []models.AcceptanceCriterion
+	err = database.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&models.AcceptanceCriterion{}).Where("task_id = ?", task.ID).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		for i, text := range texts {
+			c := models.AcceptanceCriterion{TaskID: task.ID, Position: last + i + 1, Text: text}
+			if err := tx.Create(&c).Error; err != nil {
+				return err
+			}
+			models.RecordChange(tx, task.ID, acceptanceField, "", c.Checkbox(), acBy)
+			added = append(added, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add acceptance criteria to '%s': database error: %w", task.ID, err)
+	}
+	noteAffected(task.ID)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task_id": task.ID, "added": added})
+		return nil
+	}
+	for _, c := range added {
+		fmt.Printf("Added: %s #%d %s\n", task.ID, c.Position, c.Text)
+	}
+	return nil
+}
+
+// setCriteriaDone checks or unchecks the numbered criteria of a task
+func setCriteriaDone(taskID string, numbers []string, done bool) error {
+	task, err := db.GetTaskByID(taskID)
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot update acceptance criteria: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+	}
+	database := db.GetDB()
+	criteria, err := loadAcceptanceCriteria(database, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load acceptance criteria of '%s': database error: %w", task.ID, err)
+	}
+	picked, err := pickCriteria(task.ID, criteria, numbers)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = database.Transaction(func(tx *gorm.DB) error {
+		for _, c := range picked {
+			if c.Done == done {
+				continue
+			}
+			old := c.Checkbox()
+			c.Done = done
+			c.DoneAt, c.DoneBy = nil, ""
+			if done {
+				c.DoneAt, c.DoneBy = &now, acBy
+			}
+			if err := tx.Save(c).Error; err != nil {
+				return err
+			}
+			models.RecordChange(tx, task.ID, acceptanceField, old, c.Checkbox(), acBy)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update acceptance criteria of '%s': database error: %w", task.ID, err)
+	}
+	noteAffected(task.ID)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task_id": task.ID, "criteria": criteria, "done": criteriaDone(criteria), "total": len(criteria)})
+		return nil
+	}
+	verb := "Checked"
+	if !done {
+		verb = "Unchecked"
+	}
+	for _, c := range picked {
+		fmt.Printf("%s: %s #%d %s\n", verb, task.ID, c.Position, c.Text)
+	}
+	fmt.Printf("%d/%d criteria done\n", criteriaDone(criteria), len(criteria))
+	return nil
+}
+
+func runAcList(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	criteria, err := loadAcceptanceCriteria(db.GetDB(), task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load acceptance criteria of '%s': database error: %w", task.ID, err)
+	}
+
+	if IsJSONOutput() {
+		if criteria == nil {
+			criteria = []models.AcceptanceCriterion{}
+		}
+		OutputJSON(map[string]interface{}{"task_id": task.ID, "criteria": criteria, "done": criteriaDone(criteria), "total": len(criteria)})
+		return nil
+	}
+	if len(criteria) == 0 {
+		fmt.Printf("No acceptance criteria for %s (add with 'gur ac add %s \"...\"')\n", task.ID, task.ID)
+		return nil
+	}
+	fmt.Printf("Acceptance criteria for %s (%d/%d done):\n", task.ID, criteriaDone(criteria), len(criteria))
+	printCriteria(criteria, "  ")
+	return nil
+}
+
+// printCriteria prints numbered checkboxes, with who checked each
+func printCriteria(criteria []models.AcceptanceCriterion, indent string) {
+	for i, c := range criteria {
+		mark := " "
+		if c.Done {
+			mark = "x"
+		}
+		fmt.Printf("%s%d. [%s] %s", indent, i+1, mark, c.Text)
+		if c.Done && c.DoneBy != "" {
+			fmt.Printf(" (by %s)", c.DoneBy)
+		}
+		fmt.Println()
+	}
+}
+
+func runAcRemove(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot remove acceptance criteria: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	database := db.GetDB()
+	criteria, err := loadAcceptanceCriteria(database, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load acceptance criteria of '%s': database error: %w", task.ID, err)
+	}
+	picked, err := pickCriteria(task.ID, criteria, args[1:])
+	if err != nil {
+		return err
+	}
+	removing := make(map[uint]bool)
+	for _, c := range picked {
+		removing[c.ID] = true
+	}
+
+	var removed []models.AcceptanceCriterion
+	err = database.Transaction(func(tx *gorm.DB) error {
+		position := 0
+		for _, c := range criteria {
+			if removing[c.ID] {
+				if err := tx.Delete(&c).Error; err != nil {
+					return err
+				}
+				models.RecordChange(tx, task.ID, acceptanceField, c.Checkbox(), "", acBy)
+				removed = append(removed, c)
+				continue
+			}
+			// Keep the numbers contiguous
+			position++
+			if c.Position != position {
+				if err := tx.Model(&c).Update("position", position).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove acceptance criteria of '%s': database error: %w", task.ID, err)
+	}
+	noteAffected(task.ID)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task_id": task.ID, "removed": removed})
+		return nil
+	}
+	for _, c := range removed {
+		fmt.Printf("Removed: %s %s\n", task.ID, c.Text)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestAcceptanceCriteria(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-ac000001", Title: "SSO login", Status: models.StatusOpen})
+	commandAffected = nil
+	acBy = "agent"
+
+	if err := runAcAdd(acAddCmd, []string{"gur-ac000001", "Login works", " ", "Session expires"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAcAdd(acAddCmd, []string{"gur-ac000001", "Logout works"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := setCriteriaDone("gur-ac000001", []string{"1", "3"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := setCriteriaDone("gur-ac000001", []string{"4"}, true); err == nil {
+		t.Error("checking a criterion past the end succeeded")
+	}
+
+	criteria, _ := loadAcceptanceCriteria(database, "gur-ac000001")
+	if len(criteria) != 3 || criteriaDone(criteria) != 2 || criteria[0].DoneBy != "agent" || criteria[1].Done {
+		t.Fatalf("criteria = %+v, want 3 with 1 and 3 checked by agent", criteria)
+	}
+
+	if err := runAcRemove(acRemoveCmd, []string{"gur-ac000001", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	criteria, _ = loadAcceptanceCriteria(database, "gur-ac000001")
+	if len(criteria) != 2 || criteria[1].Text != "Logout works" || criteria[1].Position != 2 {
+		t.Errorf("after removing #2, criteria = %+v, want Logout works renumbered to 2", criteria)
+	}
+
+	var changes int64
+	database.Model(&models.TaskHistory{}).Where("task_id = ? AND field = ?", "gur-ac000001", acceptanceField).Count(&changes)
+	if changes != 6 {
+		t.Errorf("recorded %d criteria changes, want 6 (3 adds, 2 checks, 1 removal)", changes)
+	}
+	if len(commandAffected) == 0 {
+		t.Error("criteria changes did not mark the task affected")
+	}
+}
+
+func TestAcceptanceCriteriaInIssueBody(t *testing.T) {
+	task := models.Task{ID: "gur-ac000002", Title: "SSO login", Description: "Goal", Status: models.StatusOpen, Criteria: []models.AcceptanceCriterion{
+		{Text: "Login works", Done: true},
+		{Text: "Logout works"},
+	}}
+	body := buildIssueBody(task)
+	want := "## Acceptance Criteria\n\n- [x] Login works\n- [ ] Logout works\n"
+	if !strings.Contains(body, want) {
+		t.Errorf("issue body missing the checklist:\n%s", body)
+	}
+	if strings.Index(body, want) < strings.Index(body, "## Details") {
+		t.Error("checklist comes before the details table, where pull would read it as description")
+	}
+}
+
+func TestCheckGatesBeforeCloseAcceptancePolicy(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-ac000003", Title: "SSO login", Status: models.StatusOpen})
+	database.Create(&models.Gate{ID: "gate-ac000003", Title: "Unit tests", Type: "test"})
+	database.Create(&models.GateTaskLink{GateID: "gate-ac000003", TaskID: "gur-ac000003", Status: models.GateLinkPassed})
+	database.Create(&models.AcceptanceCriterion{TaskID: "gur-ac000003", Position: 1, Text: "Login works"})
+
+	if err := CheckGatesBeforeClose("gur-ac000003"); err != nil {
+		t.Errorf("unchecked criteria blocked close without the policy: %v", err)
+	}
+	if err := setRequireAcceptance(true); err != nil {
+		t.Fatal(err)
+	}
+	err := CheckGatesBeforeClose("gur-ac000003")
+	if errorCodeOf(err) != ErrCodeCriteriaUnmet || !strings.Contains(err.Error(), "Login works") {
+		t.Errorf("CheckGatesBeforeClose() with an unchecked criterion = %v, want %s naming it", err, ErrCodeCriteriaUnmet)
+	}
+	if err := setCriteriaDone("gur-ac000003", []string{"1"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckGatesBeforeClose("gur-ac000003"); err != nil {
+		t.Errorf("CheckGatesBeforeClose() with all criteria checked should pass, got: %v", err)
+	}
+}
@@ -14,8 +14,9 @@ import (
 )
 
 var agentCmd = &cobra.Command{
-	Use:   "agent",
-	Short: "Manage AI agents",
+	Use:     "agent",
+	Aliases: []string{"agents"},
+	Short:   "Manage AI agents",
 	Long: `Manage AI agents that can be linked to tasks.
 
 Agents are defined in files like AGENTS.md, CLAUDE.md, or custom agent
@@ -24,9 +25,10 @@ will be informed which agent to use or delegate to.`,
 }
 
 var agentListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List registered agents",
-	RunE:  runAgentList,
+	Use:         "list",
+	Short:       "List registered agents",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runAgentList,
 }
 
 var agentAddCmd = &cobra.Command{
@@ -45,10 +47,22 @@ var agentRemoveCmd = &cobra.Command{
 }
 
 var agentShowCmd = &cobra.Command{
-	Use:   "show <name>",
-	Short: "Show agent details",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runAgentShow,
+	Use:         "show <name>",
+	Short:       "Show agent details",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runAgentShow,
+}
+
+var agentStatsCmd = &cobra.Command{
+	Use:         "stats",
+	Short:       "Show task outcomes per agent",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Correlate agents with the outcomes of the tasks they are linked to:
+tasks completed, average time-to-close, gate pass rate, and how often
+tasks were force-closed.`,
+	Args: cobra.NoArgs,
+	RunE: runAgentStats,
 }
 
 var agentScanCmd = &cobra.Command{
@@ -71,6 +85,7 @@ func init() {
 	agentCmd.AddCommand(agentRemoveCmd)
 	agentCmd.AddCommand(agentShowCmd)
 	agentCmd.AddCommand(agentScanCmd)
+	agentCmd.AddCommand(agentStatsCmd)
 
 	agentAddCmd.Flags().StringVar(&agentPath, "path", "", "Full path to agent file")
 	agentAddCmd.Flags().StringVar(&agentSource, "source", models.SourceCustom, "Source (claude/cursor/windsurf/copilot/custom)")
@@ -146,7 +161,7 @@ func runAgentRemove(cmd *cobra.Command, args []string) error {
 
 	var agent models.Agent
 	if err := db.GetDB().Where("name = ?", name).First(&agent).Error; err != nil {
-		return fmt.Errorf("cannot remove agent: agent '%s' not found (use 'gur agent list' to see registered agents)", name)
+		return codedErrorf(ErrCodeNotFound, "cannot remove agent: agent '%s' not found (use 'gur agent list' to see registered agents)", name)
 	}
 
 	// Remove task links first
@@ -171,7 +186,7 @@ func runAgentShow(cmd *cobra.Command, args []string) error {
 
 	var agent models.Agent
 	if err := db.GetDB().Where("name = ? OR id = ?", name, name).First(&agent).Error; err != nil {
-		return fmt.Errorf("agent '%s' not found (use 'gur agent list' to see registered agents, or 'gur agent scan' to auto-discover)", name)
+		return codedErrorf(ErrCodeNotFound, "agent '%s' not found (use 'gur agent list' to see registered agents, or 'gur agent scan' to auto-discover)", name)
 	}
 
 	// Get linked tasks
@@ -397,3 +412,18 @@ func registerAgentIfNew(agent models.Agent) (bool, error) {
 	}
 	return true, nil
 }
+
+func runAgentStats(cmd *cobra.Command, args []string) error {
+	stats, err := collectEffectiveness(db.GetDB(), models.TaskAgentLink{}.TableName(), "agent_id", models.Agent{}.TableName())
+	if err != nil {
+		return fmt.Errorf("failed to compute agent stats: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(stats), "agents": stats})
+		return nil
+	}
+
+	printEffectiveness("agent", stats)
+	return nil
+}
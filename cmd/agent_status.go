@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var agentStatusStale string
+
+var agentStatusCmd = &cobra.Command{
+	Use:         "status",
+	Short:       "Show what each agent is working on",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Show who holds claims right now: every in-progress task with an assignee,
+how long it has been claimed and when its holder was last active. Registered
+agents ('gur people add --kind agent' or 'gur agent add') with no claim are
+listed as idle, so a supervisor running several agents against one repo
+sees at a glance who is busy, who is free and who went quiet.
+
+Last activity is the latest command its holder ran (the event log, under
+GUR_ACTOR) or change made by it or to its task. Claims with no activity for
+longer than --stale are flagged.
+
+Examples:
+  gur agents status
+  gur agents status --stale 4h
+  gur agents status --json`,
+	Args: cobra.NoArgs,
+	RunE: runAgentStatus,
+}
+
+func init() {
+	agentCmd.AddCommand(agentStatusCmd)
+	agentStatusCmd.Flags().StringVar(&agentStatusStale, "stale", "2h", "Flag claims inactive for longer than this (e.g., 2h, 1d)")
+}
+
+// agentClaim is a worker's in-progress task, or an idle registered agent
+// when Task is empty
+type agentClaim struct {
+	Agent        string     `json:"agent"`
+	Kind         string     `json:"kind,omitempty"`
+	Task         string     `json:"task_id,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Since        *time.Time `json:"since,omitempty"`
+	LastActivity *time.Time `json:"last_activity,omitempty"`
+	Stale        bool       `json:"stale,omitempty"`
+}
+
+// collectAgentClaims lists in-progress tasks by assignee, then idle
+// registered agents, each with its last activity
+func collectAgentClaims(database *gorm.DB, now time.Time, staleAfter time.Duration) ([]agentClaim, error) {
+	var tasks []models.Task
+	if err := database.Where("status = ? AND assignee != ''", models.StatusInProgress).Order("assignee ASC, id ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	kinds := make(map[string]string)
+	var people []models.Person
+	if err := database.Find(&people).Error; err != nil {
+		return nil, err
+	}
+	idle := make(map[string]bool)
+	for _, p := range people {
+		kinds[p.Name] = p.Kind
+		if p.Kind == models.PersonKindAgent {
+			idle[p.Name] = true
+		}
+	}
+	var agents []models.Agent
+	if err := database.Find(&agents).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range agents {
+		idle[a.Name] = true
+		if kinds[a.Name] == "" {
+			kinds[a.Name] = models.PersonKindAgent
+		}
+	}
+
+	var claims []agentClaim
+	for _, t := range tasks {
+		delete(idle, t.Assignee)
+		c := agentClaim{Agent: t.Assignee, Kind: kinds[t.Assignee], Task: t.ID, Title: t.Title}
+
+		// Claimed when it last went in progress or to this assignee
+		var claimed models.TaskHistory
+		err := database.Where("task_id = ? AND ((field = ? AND new_value = ?) OR (field = ? AND new_value = ?))",
+			t.ID, "status", models.StatusInProgress, "assignee", t.Assignee).
+			Order("changed_at DESC").Limit(1).Find(&claimed).Error
+		if err != nil {
+			return nil, err
+		}
+		since := t.UpdatedAt
+		if claimed.ID != "" {
+			since = claimed.ChangedAt
+		}
+		c.Since = &since
+
+		last, err := lastAgentActivity(database, t.Assignee, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		if last.Before(since) {
+			last = since
+		}
+		c.LastActivity = &last
+		c.Stale = now.Sub(last) > staleAfter
+		claims = append(claims, c)
+	}
+
+	var names []string
+	for name := range idle {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := agentClaim{Agent: name, Kind: kinds[name]}
+		last, err := lastAgentActivity(database, name, "")
+		if err != nil {
+			return nil, err
+		}
+		if !last.IsZero() {
+			c.LastActivity = &last
+		}
+		claims = append(claims, c)
+	}
+	return claims, nil
+}
+
+// lastAgentActivity returns when name last ran a command or changed a task,
+// or when taskID (if given) last changed; zero if never
+func lastAgentActivity(database *gorm.DB, name, taskID string) (time.Time, error) {
+	var latest time.Time
+	var event models.Event
+	if err := database.Where("actor = ?", name).Order("finished_at DESC").Limit(1).Find(&event).Error; err != nil {
+		return latest, err
+	}
+	if event.ID != 0 {
+		latest = event.FinishedAt
+	}
+	var change models.TaskHistory
+	query := database.Where("changed_by = ?", name)
+	if taskID != "" {
+		query = database.Where("changed_by = ? OR task_id = ?", name, taskID)
+	}
+	if err := query.Order("changed_at DESC").Limit(1).Find(&change).Error; err != nil {
+		return latest, err
+	}
+	if change.ID != "" && change.ChangedAt.After(latest) {
+		latest = change.ChangedAt
+	}
+	return latest, nil
+}
+
+// shortElapsed formats a duration to the two largest units, e.g. 3h12m
+func shortElapsed(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
+
+func runAgentStatus(cmd *cobra.Command, args []string) error {
+	staleAfter, err := parseDuration(agentStatusStale)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	claims, err := collectAgentClaims(db.GetDB(), now, staleAfter)
+	if err != nil {
+		return fmt.Errorf("failed to load agent status: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		if claims == nil {
+			claims = []agentClaim{}
+		}
+		OutputJSON(map[string]interface{}{"count": len(claims), "claims": claims})
+		return nil
+	}
+	if len(claims) == 0 {
+		fmt.Println("No tasks in progress and no agents registered.")
+		return nil
+	}
+
+	fmt.Printf("%-16s %-14s %-8s %-10s %s\n", "AGENT", "TASK", "ON IT", "ACTIVE", "TITLE")
+	for _, cl := range claims {
+		task, onIt, active := "(idle)", "-", "-"
+		if cl.Task != "" {
+			task = cl.Task
+			onIt = shortElapsed(now.Sub(*cl.Since))
+		}
+		if cl.LastActivity != nil {
+			active = shortElapsed(now.Sub(*cl.LastActivity)) + " ago"
+		}
+		fmt.Printf("%-16s %-14s %-8s %-10s %s", cl.Agent, task, onIt, active, cl.Title)
+		if cl.Stale {
+			fmt.Print("  [stale]")
+		}
+		fmt.Println()
+	}
+	return nil
+}
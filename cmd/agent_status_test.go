@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestCollectAgentClaims(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	now := time.Now()
+	database.Create(&models.Person{Name: "bot-a", Kind: models.PersonKindAgent})
+	database.Create(&models.Person{Name: "bot-b", Kind: models.PersonKindAgent})
+	database.Create(&models.Task{ID: "gur-a5000001", Title: "Busy", Status: models.StatusInProgress, Assignee: "bot-a"})
+	database.Create(&models.Task{ID: "gur-a5000002", Title: "Waiting", Status: models.StatusOpen, Assignee: "bot-b"})
+	database.Create(&models.TaskHistory{TaskID: "gur-a5000001", Field: "status", OldValue: models.StatusOpen, NewValue: models.StatusInProgress, ChangedBy: "bot-a", ChangedAt: now.Add(-5 * time.Hour)})
+	database.Create(&models.TaskHistory{TaskID: "gur-a5000001", Field: "notes", NewValue: "progress", ChangedBy: "bot-a", ChangedAt: now.Add(-3 * time.Hour)})
+
+	claims, err := collectAgentClaims(database, now, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claims) != 2 {
+		t.Fatalf("claims = %+v, want bot-a's task and idle bot-b", claims)
+	}
+	busy := claims[0]
+	if busy.Agent != "bot-a" || busy.Task != "gur-a5000001" || busy.Kind != models.PersonKindAgent {
+		t.Errorf("first claim = %+v, want bot-a on gur-a5000001", busy)
+	}
+	if d := now.Sub(*busy.Since); d < 5*time.Hour-time.Minute || d > 5*time.Hour+time.Minute {
+		t.Errorf("claimed %s ago, want 5h (when it went in progress)", d)
+	}
+	if d := now.Sub(*busy.LastActivity); d < 3*time.Hour-time.Minute || d > 3*time.Hour+time.Minute || !busy.Stale {
+		t.Errorf("last active %s ago, stale = %v; want 3h and stale past 2h", d, busy.Stale)
+	}
+	if idle := claims[1]; idle.Agent != "bot-b" || idle.Task != "" || idle.LastActivity != nil {
+		t.Errorf("second claim = %+v, want bot-b idle with no activity", idle)
+	}
+}
+
+func TestShortElapsed(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:            "<1m",
+		45 * time.Minute:            "45m",
+		3*time.Hour + 5*time.Minute: "3h05m",
+		50 * time.Hour:              "2d2h",
+	}
+	for d, want := range tests {
+		if got := shortElapsed(d); got != want {
+			t.Errorf("shortElapsed(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage command aliases",
+	Long: `Define aliases that expand to one or more gur commands.
+
+An alias runs each '&&'-separated step as a gur command, stopping at the
+first failure. $1-$9 are replaced by the alias arguments and $@ by all of
+them; if the expansion uses neither, arguments are appended to the last
+step. --json on the alias is passed to every step.
+
+Aliases are stored in the project database and show in 'gur --help'.
+
+Examples:
+  gur alias add done 'close $1 -r "Completed" && sync push $1'
+  gur done gur-abc123
+  gur alias list
+  gur alias remove done`,
+}
+
+var aliasAddCmd = &cobra.Command{
+	Use:   "add <name> <expansion>",
+	Short: "Add or replace an alias",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runAliasAdd,
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List aliases",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runAliasList,
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove an alias",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runAliasRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+	aliasCmd.AddCommand(aliasAddCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasRemoveCmd)
+}
+
+var (
+	aliasNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	aliasArgRegex  = regexp.MustCompile(`\$(@|[1-9])`)
+)
+
+// loadAliases returns all aliases keyed by name
+func loadAliases() (map[string]string, error) {
+	var configs []models.Config
+	if err := db.GetDB().Where("key LIKE ?", models.ConfigAliasPrefix+"%").Find(&configs).Error; err != nil {
+		return nil, err
+	}
+	aliases := make(map[string]string, len(configs))
+	for _, c := range configs {
+		aliases[strings.TrimPrefix(c.Key, models.ConfigAliasPrefix)] = c.Value
+	}
+	return aliases, nil
+}
+
+// splitAliasSteps splits an expansion into its '&&'-separated steps, each
+// tokenized with shell-style quoting. A leading "gur" in a step is dropped.
+func splitAliasSteps(expansion string) ([][]string, error) {
+	var steps [][]string
+	var step []string
+	var token strings.Builder
+	inToken := false
+	var quote rune
+
+	endToken := func() {
+		if inToken {
+			step = append(step, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+	endStep := func() error {
+		endToken()
+		if len(step) > 0 && step[0] == "gur" {
+			step = step[1:]
+		}
+		if len(step) == 0 {
+			return fmt.Errorf("empty command in alias expansion")
+		}
+		steps = append(steps, step)
+		step = nil
+		return nil
+	}
+
+	runes := []rune(expansion)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+				token.WriteRune(runes[i])
+			} else {
+				token.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inToken = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			token.WriteRune(runes[i])
+			inToken = true
+		case r == '&' && i+1 < len(runes) && runes[i+1] == '&':
+			i++
+			if err := endStep(); err != nil {
+				return nil, err
+			}
+		case r == ' ' || r == '\t' || r == '\n':
+			endToken()
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in alias expansion", quote)
+	}
+	if err := endStep(); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// expandAlias substitutes args into an alias expansion and returns the gur
+// argument lists to run in order
+func expandAlias(expansion string, args []string) ([][]string, error) {
+	steps, err := splitAliasSteps(expansion)
+	if err != nil {
+		return nil, err
+	}
+
+	used := false
+	var missing int
+	var out [][]string
+	for _, step := range steps {
+		var expanded []string
+		for _, tok := range step {
+			if tok == "$@" {
+				used = true
+				expanded = append(expanded, args...)
+				continue
+			}
+			expanded = append(expanded, aliasArgRegex.ReplaceAllStringFunc(tok, func(ref string) string {
+				used = true
+				if ref == "$@" {
+					return strings.Join(args, " ")
+				}
+				n, _ := strconv.Atoi(ref[1:])
+				if n > len(args) {
+					missing = max(missing, n)
+					return ""
+				}
+				return args[n-1]
+			}))
+		}
+		out = append(out, expanded)
+	}
+	if missing > 0 {
+		return nil, fmt.Errorf("needs at least %d argument(s), got %d", missing, len(args))
+	}
+	if !used {
+		out[len(out)-1] = append(out[len(out)-1], args...)
+	}
+	return out, nil
+}
+
+// aliasAnnotation marks alias commands; their steps log their own events
+const aliasAnnotation = "gur-alias"
+
+// newAliasCommand returns the root command that runs an alias
+func newAliasCommand(name, expansion string) *cobra.Command {
+	return &cobra.Command{
+		Use:                name + " [args...]",
+		Short:              "Alias for: " + expansion,
+		Annotations:        map[string]string{aliasAnnotation: expansion},
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAlias(cmd, name, expansion, args)
+		},
+	}
+}
+
+// registerAliases adds the project's aliases as root commands so they can be
+// run and show in help. Built-in commands never need them, so the database
+// is only consulted when args don't name one.
+func registerAliases(args []string) {
+	if c, _, err := rootCmd.Find(args); err == nil && c != rootCmd && c.Name() != "help" {
+		return
+	}
+	if db.EnsureInitialized() != nil {
+		return
+	}
+	aliases, err := loadAliases()
+	if err != nil {
+		return
+	}
+	for name, expansion := range aliases {
+		if c, _, err := rootCmd.Find([]string{name}); err == nil && c != rootCmd {
+			continue // Never shadow a built-in command
+		}
+		rootCmd.AddCommand(newAliasCommand(name, expansion))
+	}
+}
+
+func runAlias(cmd *cobra.Command, name, expansion string, args []string) error {
+	var passJSON bool
+	var rest []string
+	for _, a := range args {
+		switch a {
+		case "--json":
+			passJSON = true
+			jsonOutput = true
+		case "-h", "--help":
+			return cmd.Help()
+		default:
+			rest = append(rest, a)
+		}
+	}
+
+	steps, err := expandAlias(expansion, rest)
+	if err != nil {
+		return fmt.Errorf("alias '%s': %w", name, err)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("alias '%s': cannot locate gur executable: %w", name, err)
+	}
+	for i, step := range steps {
+		if passJSON {
+			step = append(step, "--json")
+		}
+		run := exec.Command(self, step...)
+		run.Stdin, run.Stdout, run.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := run.Run(); err != nil {
+			return fmt.Errorf("alias '%s' stopped at step %d (gur %s): %w", name, i+1, strings.Join(step, " "), err)
+		}
+	}
+	return nil
+}
+
+func runAliasAdd(cmd *cobra.Command, args []string) error {
+	name, expansion := args[0], strings.TrimSpace(args[1])
+	if !aliasNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid alias name '%s': use lowercase letters, digits and dashes, starting with a letter", name)
+	}
+	if c, _, err := rootCmd.Find([]string{name}); err == nil && c != rootCmd {
+		return fmt.Errorf("cannot add alias: '%s' is a built-in command", name)
+	}
+	if _, err := splitAliasSteps(expansion); err != nil {
+		return fmt.Errorf("invalid alias expansion: %w", err)
+	}
+
+	if err := db.SetConfig(models.AliasKey(name), expansion); err != nil {
+		return fmt.Errorf("failed to save alias '%s': %w", name, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"name": name, "expansion": expansion})
+		return nil
+	}
+	fmt.Printf("Added alias: %s = %s\n", name, expansion)
+	return nil
+}
+
+func runAliasList(cmd *cobra.Command, args []string) error {
+	aliases, err := loadAliases()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(aliases), "aliases": aliases})
+		return nil
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No aliases defined (use 'gur alias add <name> <expansion>')")
+		return nil
+	}
+	for _, name := range names {
+		fmt.Printf("%-16s %s\n", name, aliases[name])
+	}
+	return nil
+}
+
+func runAliasRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	result := db.GetDB().Where("key = ?", models.AliasKey(name)).Delete(&models.Config{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove alias '%s': %w", name, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return codedErrorf(ErrCodeNotFound, "alias '%s' not found (use 'gur alias list' to see aliases)", name)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"removed": name})
+		return nil
+	}
+	fmt.Printf("Removed alias: %s\n", name)
+	return nil
+}
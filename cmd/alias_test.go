@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestExpandAlias(t *testing.T) {
+	tests := []struct {
+		expansion string
+		args      []string
+		want      [][]string
+	}{
+		{`close $1 -r "Completed" && sync push $1`, []string{"gur-a1"}, [][]string{{"close", "gur-a1", "-r", "Completed"}, {"sync", "push", "gur-a1"}}},
+		{`gur list --status open`, []string{"--limit", "5"}, [][]string{{"list", "--status", "open", "--limit", "5"}}},
+		{`update $@ -s in_progress`, []string{"gur-a1", "gur-b2"}, [][]string{{"update", "gur-a1", "gur-b2", "-s", "in_progress"}}},
+		{`note add $1 'it''s $2'`, []string{"gur-a1", "done now"}, [][]string{{"note", "add", "gur-a1", "its done now"}}},
+	}
+	for _, tt := range tests {
+		got, err := expandAlias(tt.expansion, tt.args)
+		if err != nil {
+			t.Errorf("expandAlias(%q): %v", tt.expansion, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("expandAlias(%q, %v) = %q, want %q", tt.expansion, tt.args, got, tt.want)
+		}
+	}
+
+	if _, err := expandAlias(`close $2`, []string{"one"}); err == nil {
+		t.Error("expected error for missing argument")
+	}
+	for _, bad := range []string{`close "open`, `list &&`, `&& list`} {
+		if _, err := splitAliasSteps(bad); err == nil {
+			t.Errorf("splitAliasSteps(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAliasAddListRemove(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := runAliasAdd(aliasAddCmd, []string{"done", `close $1 -r "Completed"`}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := runAliasAdd(aliasAddCmd, []string{"list", "ready"}); err == nil {
+		t.Error("expected error when shadowing a built-in command")
+	}
+	if err := runAliasAdd(aliasAddCmd, []string{"Bad_Name", "ready"}); err == nil {
+		t.Error("expected error for invalid alias name")
+	}
+
+	aliases, err := loadAliases()
+	if err != nil {
+		t.Fatalf("loadAliases: %v", err)
+	}
+	if want := map[string]string{"done": `close $1 -r "Completed"`}; !reflect.DeepEqual(aliases, want) {
+		t.Errorf("aliases = %v, want %v", aliases, want)
+	}
+
+	if err := runAliasRemove(aliasRemoveCmd, []string{"done"}); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := db.GetConfig(models.AliasKey("done")); err == nil {
+		t.Error("alias still stored after remove")
+	}
+	if err := runAliasRemove(aliasRemoveCmd, []string{"done"}); err == nil {
+		t.Error("expected error removing a missing alias")
+	}
+}
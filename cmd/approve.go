@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+	"golang.org/x/term"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+const (
+	defaultApprovalTTL = 15 * time.Minute
+	maxApprovalTTL     = 24 * time.Hour
+	approvalKeyBytes   = 32
+	approvalNonceBytes = 8
+)
+
+var (
+	approveBy  string
+	approveTTL time.Duration
+)
+
+// Where human confirmations are read from; tests replace these
+var (
+	stdinIsTerminal           = func() bool { return term.IsTerminal(int(os.Stdin.Fd())) }
+	confirmInput    io.Reader = os.Stdin
+)
+
+var approveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Issue signed approvals for actions that need a human",
+}
+
+var approveCloseCmd = &cobra.Command{
+	Use:   "close <task-id>",
+	Short: "Approve force-closing a task, producing a short-lived token",
+	Long: `Approve force-closing a task that doesn't pass its gates.
+
+Prints a signed token that lets 'gur close <id> --force --approval <token>'
+bypass the gates without interactive confirmation, so scripts and agents
+can force close only what a human approved. The token is bound to the
+task, expires after --ttl (default 15m, at most 24h) and can be used once;
+approving the task again revokes the previous token.
+
+Must be run in an interactive terminal. Tokens are signed with a key kept
+in the system keyring.
+
+Examples:
+  gur approve close gur-abc123
+  gur approve close gur-abc123 --by alice --ttl 1h
+  gur close gur-abc123 -r "Hotfix" --force --approval <token>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApproveClose,
+}
+
+func init() {
+	rootCmd.AddCommand(approveCmd)
+	approveCmd.AddCommand(approveCloseCmd)
+	approveCloseCmd.Flags().StringVar(&approveBy, "by", "human", "Who approves (name)")
+	approveCloseCmd.Flags().DurationVar(&approveTTL, "ttl", defaultApprovalTTL, "How long the token is valid (e.g., 15m, 1h)")
+}
+
+// approvalClaims is the signed content of an approval token
+type approvalClaims struct {
+	Task    string `json:"task"`
+	By      string `json:"by"`
+	Expires int64  `json:"exp"` // Unix seconds
+	Nonce   string `json:"nonce"`
+}
+
+// approvalSigningKey returns the keyring's approval signing key, creating
+// one if create is set and none exists
+func approvalSigningKey(create bool) ([]byte, error) {
+	stored, err := keyring.Get(models.KeyringServiceName, models.KeyringApprovalKey)
+	if err == nil {
+		return hex.DecodeString(stored)
+	}
+	if !errors.Is(err, keyring.ErrNotFound) || !create {
+		return nil, fmt.Errorf("cannot read approval signing key from keyring: %w", err)
+	}
+	key := make([]byte, approvalKeyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := keyring.Set(models.KeyringServiceName, models.KeyringApprovalKey, hex.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("cannot store approval signing key in keyring: %w", err)
+	}
+	return key, nil
+}
+
+// confirmAsHuman asks whoever is at the terminal to type 'yes' to what,
+// e.g. "approve gate-abc123 for gur-def456 as alice". Scripts and agents
+// have no terminal on stdin, so they can't confirm.
+func confirmAsHuman(what string) error {
+	if !stdinIsTerminal() {
+		return fmt.Errorf("cannot %s: this requires interactive confirmation and can't be done from a non-interactive terminal (e.g., scripts or AI agents)", what)
+	}
+	fmt.Fprintf(os.Stderr, "Type 'yes' to %s: ", what)
+	confirmation, _ := bufio.NewReader(confirmInput).ReadString('\n')
+	if strings.TrimSpace(strings.ToLower(confirmation)) != "yes" {
+		return fmt.Errorf("cancelled: did not %s", what)
+	}
+	return nil
+}
+
+func signApproval(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// issueCloseApproval signs a force-close approval for the task and records
+// its nonce, revoking any earlier approval for the task
+func issueCloseApproval(database *gorm.DB, taskID, by string, ttl time.Duration, now time.Time) (string, *approvalClaims, error) {
+	key, err := approvalSigningKey(true)
+	if err != nil {
+		return "", nil, err
+	}
+	nonce := make([]byte, approvalNonceBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	claims := &approvalClaims{Task: taskID, By: by, Expires: now.Add(ttl).Unix(), Nonce: hex.EncodeToString(nonce)}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := database.Save(&models.Config{Key: models.ApprovalCloseKey(taskID), Value: claims.Nonce}).Error; err != nil {
+		return "", nil, fmt.Errorf("failed to record approval: database error: %w", err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(signApproval(key, payload)), claims, nil
+}
+
+// verifyCloseApproval checks that token is a valid, unexpired and unused
+// force-close approval for the task
+func verifyCloseApproval(database *gorm.DB, token, taskID string, now time.Time) (*approvalClaims, error) {
+	invalid := fmt.Errorf("invalid approval token (get one with 'gur approve close %s')", taskID)
+	payloadPart, sigPart, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return nil, invalid
+	}
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(payloadPart)
+	if err != nil {
+		return nil, invalid
+	}
+	sig, err := enc.DecodeString(sigPart)
+	if err != nil {
+		return nil, invalid
+	}
+	key, err := approvalSigningKey(false)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(sig, signApproval(key, payload)) {
+		return nil, invalid
+	}
+
+	var claims approvalClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, invalid
+	}
+	if claims.Task != taskID {
+		return nil, fmt.Errorf("approval token is for task '%s', not '%s'", claims.Task, taskID)
+	}
+	if expires := time.Unix(claims.Expires, 0); !now.Before(expires) {
+		return nil, fmt.Errorf("approval token expired at %s", expires.Format(models.DateTimeShortFormat))
+	}
+	var outstanding models.Config
+	if err := database.Where("key = ?", models.ApprovalCloseKey(taskID)).First(&outstanding).Error; err != nil || outstanding.Value != claims.Nonce {
+		return nil, fmt.Errorf("approval token was already used or has been replaced")
+	}
+	return &claims, nil
+}
+
+// consumeCloseApproval marks the task's outstanding approval as used
+func consumeCloseApproval(database *gorm.DB, taskID string) error {
+	return database.Where("key = ?", models.ApprovalCloseKey(taskID)).Delete(&models.Config{}).Error
+}
+
+func runApproveClose(cmd *cobra.Command, args []string) error {
+	if approveTTL <= 0 || approveTTL > maxApprovalTTL {
+		return fmt.Errorf("invalid --ttl %s: must be positive and at most %s", approveTTL, maxApprovalTTL)
+	}
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot approve close: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	if task.IsClosed() {
+		return fmt.Errorf("cannot approve close: task '%s' is already closed", task.ID)
+	}
+	if !stdinIsTerminal() {
+		// Refuse scripts and agents before showing the task
+		return confirmAsHuman(fmt.Sprintf("approve force-closing %s", task.ID))
+	}
+
+	fmt.Fprintf(os.Stderr, "Task: %s - %s\n", task.ID, task.Title)
+	if gateErr := CheckGatesBeforeClose(task.ID); gateErr != nil {
+		fmt.Fprintf(os.Stderr, "\n%s\n", gateErr)
+	} else {
+		fmt.Fprintln(os.Stderr, "\nAll gates pass; a plain 'gur close' will work without approval.")
+	}
+	fmt.Fprintln(os.Stderr)
+	if err := confirmAsHuman(fmt.Sprintf("approve force-closing %s (as %s, valid for %s)", task.ID, approveBy, approveTTL)); err != nil {
+		return err
+	}
+
+	token, claims, err := issueCloseApproval(db.GetDB(), task.ID, approveBy, approveTTL, time.Now())
+	if err != nil {
+		return err
+	}
+	expires := time.Unix(claims.Expires, 0)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task": task.ID, "by": claims.By, "expires_at": expires, "token": token})
+	} else {
+		fmt.Printf("Approved force close of %s until %s\n", task.ID, expires.Format(models.DateTimeShortFormat))
+		fmt.Println(token)
+		fmt.Printf("\nUse with: gur close %s -r \"<reason>\" --force --approval <token>\n", task.ID)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zalando/go-keyring"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestCloseApprovalTokens(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	keyring.MockInit()
+
+	database := db.GetDB()
+	now := time.Now()
+	token, claims, err := issueCloseApproval(database, "gur-appr0001", "alice", 15*time.Minute, now)
+	if err != nil {
+		t.Fatalf("issueCloseApproval() error: %v", err)
+	}
+	if claims.By != "alice" || claims.Task != "gur-appr0001" {
+		t.Errorf("claims = %+v, want alice for gur-appr0001", claims)
+	}
+
+	if got, err := verifyCloseApproval(database, token, "gur-appr0001", now); err != nil || got.By != "alice" {
+		t.Errorf("verifyCloseApproval() = %+v, %v; want alice's approval", got, err)
+	}
+	if _, err := verifyCloseApproval(database, token, "gur-appr0002", now); err == nil {
+		t.Error("verifyCloseApproval() should reject a token for another task")
+	}
+	if _, err := verifyCloseApproval(database, token, "gur-appr0001", now.Add(16*time.Minute)); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("verifyCloseApproval() after ttl = %v, want expired", err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+	if _, err := verifyCloseApproval(database, payload+"x."+sig, "gur-appr0001", now); err == nil {
+		t.Error("verifyCloseApproval() should reject a tampered token")
+	}
+
+	// Approving again replaces the earlier token
+	newer, _, err := issueCloseApproval(database, "gur-appr0001", "bob", 15*time.Minute, now)
+	if err != nil {
+		t.Fatalf("issueCloseApproval() error: %v", err)
+	}
+	if _, err := verifyCloseApproval(database, token, "gur-appr0001", now); err == nil {
+		t.Error("verifyCloseApproval() should reject a replaced token")
+	}
+
+	// Tokens are single use
+	if err := consumeCloseApproval(database, "gur-appr0001"); err != nil {
+		t.Fatalf("consumeCloseApproval() error: %v", err)
+	}
+	if _, err := verifyCloseApproval(database, newer, "gur-appr0001", now); err == nil {
+		t.Error("verifyCloseApproval() should reject a used token")
+	}
+}
+
+func TestForceCloseWithApproval(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	keyring.MockInit()
+	defer func() { closeForce, closeApproval, closeReason = false, "", "" }()
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-appr0003", Title: "Gated", Status: models.StatusOpen}
+	database.Create(task)
+
+	// Without a terminal or approval, force close is refused
+	closeForce, closeReason = true, "Hotfix"
+	if err := runClose(closeCmd, []string{task.ID}); err == nil || !strings.Contains(err.Error(), "gur approve close") {
+		t.Fatalf("runClose() without approval = %v, want a hint to get approval", err)
+	}
+
+	token, _, err := issueCloseApproval(database, task.ID, "alice", time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("issueCloseApproval() error: %v", err)
+	}
+	closeApproval = token
+	if err := runClose(closeCmd, []string{task.ID}); err != nil {
+		t.Fatalf("runClose() with approval error: %v", err)
+	}
+	closed, _ := db.GetTaskByID(task.ID)
+	if !closed.IsClosed() || closed.CloseReason != models.ForceClosePrefix+"Hotfix (approved by alice)" {
+		t.Errorf("task = %s %q, want force closed with the approver", closed.Status, closed.CloseReason)
+	}
+
+	var used int64
+	database.Model(&models.Config{}).Where("key = ?", models.ApprovalCloseKey(task.ID)).Count(&used)
+	if used != 0 {
+		t.Error("approval should be consumed by the close")
+	}
+}
+
+func TestApproveCloseConfirmation(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	keyring.MockInit()
+	isTerminal := stdinIsTerminal
+	defer func() {
+		stdinIsTerminal, confirmInput = isTerminal, os.Stdin
+		approveBy, approveTTL = "human", defaultApprovalTTL
+	}()
+	approveBy, approveTTL = "alice", defaultApprovalTTL
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-appr0004", Title: "Gated", Status: models.StatusOpen})
+	approved := func() bool {
+		var n int64
+		database.Model(&models.Config{}).Where("key = ?", models.ApprovalCloseKey("gur-appr0004")).Count(&n)
+		return n == 1
+	}
+
+	stdinIsTerminal = func() bool { return false }
+	if err := runApproveClose(approveCloseCmd, []string{"gur-appr0004"}); err == nil || !strings.Contains(err.Error(), "interactive") {
+		t.Fatalf("approval without a terminal = %v, want refused", err)
+	}
+	stdinIsTerminal = func() bool { return true }
+	confirmInput = strings.NewReader("no\n")
+	if err := runApproveClose(approveCloseCmd, []string{"gur-appr0004"}); err == nil || approved() {
+		t.Fatalf("declined approval = %v, want cancelled with no approval stored", err)
+	}
+	confirmInput = strings.NewReader("yes\n")
+	if err := runApproveClose(approveCloseCmd, []string{"gur-appr0004"}); err != nil || !approved() {
+		t.Fatalf("confirmed approval = %v, want an approval stored", err)
+	}
+}
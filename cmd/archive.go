@@ -65,13 +65,26 @@ func parseDuration(s string) (time.Duration, error) {
 	}
 }
 
+// parseAnyDuration accepts d/w/h durations (see parseDuration) and Go
+// durations such as 90s or 15m
+func parseAnyDuration(s string) (time.Duration, error) {
+	if d, err := parseDuration(s); err == nil {
+		return d, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s': use a duration like 30s, 15m, 24h or 7d", s)
+	}
+	return d, nil
+}
+
 func runArchive(cmd *cobra.Command, args []string) error {
 	// Archive specific task
 	if len(args) == 1 {
 		taskID := args[0]
 		var task models.Task
 		if err := db.GetDB().First(&task, "id = ?", taskID).Error; err != nil {
-			return fmt.Errorf("cannot archive task: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+			return codedErrorf(ErrCodeNotFound, "cannot archive task: task '%s' not found (use 'gur list' to see available tasks)", taskID)
 		}
 		if task.Status != models.StatusClosed {
 			return fmt.Errorf("cannot archive task '%s': only closed tasks can be archived (current status: %s, close it first with 'gur close %s')",
@@ -122,7 +135,7 @@ func runUnarchive(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 	var task models.Task
 	if err := db.GetDB().First(&task, "id = ?", taskID).Error; err != nil {
-		return fmt.Errorf("cannot unarchive task: task '%s' not found (use 'gur list --archived' to see archived tasks)", taskID)
+		return codedErrorf(ErrCodeNotFound, "cannot unarchive task: task '%s' not found (use 'gur list --archived' to see archived tasks)", taskID)
 	}
 	if task.Status != models.StatusArchived {
 		return fmt.Errorf("cannot unarchive task '%s': task is not archived (current status: %s)", taskID, task.Status)
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var artifactCmd = &cobra.Command{
+	Use:   "artifact",
+	Short: "Store code changes as task artifacts",
+	Long: `Preserve an agent's actual code changes with the task, even before a pull
+request exists. Patches are captured with 'git diff' in the current directory.`,
+}
+
+var artifactAddCmd = &cobra.Command{
+	Use:   "add <task-id>",
+	Short: "Capture a git diff as a task artifact",
+	Long: `Capture the output of 'git diff <range>' and store it with the task.
+
+A range like HEAD~1..HEAD captures committed changes; a single revision like
+HEAD captures uncommitted changes against it.
+
+Examples:
+  gur artifact add gur-abc123 --from-git HEAD~1..HEAD
+  gur artifact add gur-abc123 --from-git main..feature --by agent-7
+  gur artifact add gur-abc123 --from-git HEAD`,
+	Args: cobra.ExactArgs(1),
+	RunE: runArtifactAdd,
+}
+
+var artifactListCmd = &cobra.Command{
+	Use:         "list <task-id>",
+	Short:       "List a task's artifacts",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runArtifactList,
+}
+
+var artifactShowCmd = &cobra.Command{
+	Use:         "show <artifact-id>",
+	Short:       "Print a stored artifact",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Print a stored artifact. Patches are printed as-is, so they can be
+re-applied:
+
+  gur artifact show 3 | git apply`,
+	Args: cobra.ExactArgs(1),
+	RunE: runArtifactShow,
+}
+
+var (
+	artifactFromGit string
+	artifactBy      string
+)
+
+func init() {
+	rootCmd.AddCommand(artifactCmd)
+	artifactCmd.AddCommand(artifactAddCmd)
+	artifactCmd.AddCommand(artifactListCmd)
+	artifactCmd.AddCommand(artifactShowCmd)
+
+	artifactAddCmd.Flags().StringVar(&artifactFromGit, "from-git", "", "Git revision or range to diff (e.g., HEAD~1..HEAD)")
+	artifactAddCmd.Flags().StringVar(&artifactBy, "by", "agent", "Who captured the artifact")
+	artifactAddCmd.MarkFlagRequired("from-git")
+}
+
+// gitDiff returns the patch for a revision or range
+func gitDiff(rev string) (string, error) {
+	if strings.HasPrefix(rev, "-") {
+		return "", fmt.Errorf("invalid git range '%s'", rev)
+	}
+	out, err := exec.Command("git", "diff", "--no-color", "--no-ext-diff", rev).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git diff %s failed: %s", rev, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git diff %s failed: %w", rev, err)
+	}
+	return string(out), nil
+}
+
+// addArtifact stores a patch for the task and records it in task history so
+// it can be undone
+func addArtifact(database *gorm.DB, taskID, source, patch, by string) (*models.Artifact, error) {
+	artifact := &models.Artifact{
+		TaskID:    taskID,
+		Kind:      models.ArtifactKindPatch,
+		Source:    source,
+		Content:   patch,
+		Size:      len(patch),
+		CreatedBy: by,
+	}
+	err := database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(artifact).Error; err != nil {
+			return err
+		}
+		return models.RecordChange(tx, taskID, "artifact_added", "", strconv.FormatUint(uint64(artifact.ID), 10), by)
+	})
+	return artifact, err
+}
+
+func runArtifactAdd(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot add artifact: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	patch, err := gitDiff(artifactFromGit)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(patch) == "" {
+		return fmt.Errorf("nothing to capture: git diff %s is empty", artifactFromGit)
+	}
+
+	artifact, err := addArtifact(db.GetDB(), task.ID, "git:"+artifactFromGit, patch, artifactBy)
+	if err != nil {
+		return fmt.Errorf("failed to save artifact for task '%s': database error: %w", task.ID, err)
+	}
+
+	files := models.PatchFiles(patch)
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"id": artifact.ID, "task_id": task.ID, "source": artifact.Source, "size": artifact.Size, "files": files})
+		return nil
+	}
+	fmt.Printf("Added artifact %d to %s: %s (%d file(s), %d bytes)\n", artifact.ID, task.ID, artifact.Source, len(files), artifact.Size)
+	return nil
+}
+
+func runArtifactList(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	var artifacts []models.Artifact
+	if err := db.GetDB().Where("task_id = ?", task.ID).Order("id ASC").Find(&artifacts).Error; err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		type artifactSummary struct {
+			models.Artifact
+			Files []string `json:"files"`
+		}
+		out := make([]artifactSummary, 0, len(artifacts))
+		for _, a := range artifacts {
+			files := models.PatchFiles(a.Content)
+			a.Content = ""
+			out = append(out, artifactSummary{Artifact: a, Files: files})
+		}
+		OutputJSON(map[string]interface{}{"task_id": task.ID, "count": len(out), "artifacts": out})
+		return nil
+	}
+
+	if len(artifacts) == 0 {
+		fmt.Printf("No artifacts for %s\n", task.ID)
+		return nil
+	}
+	for _, a := range artifacts {
+		fmt.Printf("%-4d %s %-6s %-24s %d file(s), %d bytes\n", a.ID, a.CreatedAt.Format(models.DateTimeShortFormat),
+			a.Kind, a.Source, len(models.PatchFiles(a.Content)), a.Size)
+	}
+	return nil
+}
+
+func runArtifactShow(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid artifact id '%s': must be a number (see 'gur artifact list <task-id>')", args[0])
+	}
+	var artifact models.Artifact
+	if err := db.GetDB().First(&artifact, id).Error; err != nil {
+		return codedErrorf(ErrCodeNotFound, "artifact %d not found", id)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(artifact)
+		return nil
+	}
+	fmt.Print(artifact.Content)
+	return nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestAddArtifactAndUndo(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-artf0001", Title: "Patch me", Status: models.StatusInProgress})
+
+	patch := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-x\n+y\n"
+	artifact, err := addArtifact(database, "gur-artf0001", "git:HEAD~1..HEAD", patch, "agent")
+	if err != nil {
+		t.Fatalf("addArtifact: %v", err)
+	}
+	if artifact.ID == 0 || artifact.Size != len(patch) || artifact.Kind != models.ArtifactKindPatch {
+		t.Errorf("artifact = %+v", artifact)
+	}
+
+	var changes []models.TaskHistory
+	database.Where("task_id = ? AND field = ?", "gur-artf0001", "artifact_added").Find(&changes)
+	if len(changes) != 1 {
+		t.Fatalf("expected one artifact_added history entry, got %d", len(changes))
+	}
+	if err := revertChanges(database, changes); err != nil {
+		t.Fatalf("revertChanges: %v", err)
+	}
+
+	var count int64
+	database.Model(&models.Artifact{}).Where("task_id = ?", "gur-artf0001").Count(&count)
+	if count != 0 {
+		t.Errorf("artifact should be deleted by undo, %d remain", count)
+	}
+}
+
+func TestGitDiffRejectsOptions(t *testing.T) {
+	if _, err := gitDiff("--output=/tmp/x"); err == nil {
+		t.Error("gitDiff should reject ranges that look like options")
+	}
+}
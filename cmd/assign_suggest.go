@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// Assignment scoring weights; capability only counts for registered agents
+const (
+	assignWeightAffinity   = 0.4
+	assignWeightCapability = 0.3
+	assignWeightCapacity   = 0.3
+)
+
+var (
+	assignSuggestLimit int
+	assignSuggestKind  string
+	assignSuggestApply bool
+	assignSuggestBy    string
+)
+
+var assignSuggestCmd = &cobra.Command{
+	Use:   "assign-suggest <task-id>",
+	Short: "Recommend an assignee for a task",
+	Long: `Recommend who should take a task, from the registered people and agents
+and anyone who has been assigned tasks before. Each candidate is scored on:
+
+  affinity    share of the task's labels found on tasks they closed
+  capability  for registered agents, how the task's skills and labels match
+              their capabilities (as 'gur ready --agent' matches them)
+  capacity    how little open work they hold: open tasks plus estimated
+              days of work (1 / (1 + load))
+
+Candidates that aren't registered agents are scored on affinity and
+capacity alone. With --apply the best candidate becomes the assignee, so
+an orchestrator can spread work across several agents with one command per
+task.
+
+Examples:
+  gur assign-suggest gur-abc123
+  gur assign-suggest gur-abc123 --kind agent --apply
+  gur assign-suggest gur-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAssignSuggest,
+}
+
+func init() {
+	rootCmd.AddCommand(assignSuggestCmd)
+	assignSuggestCmd.Flags().IntVarP(&assignSuggestLimit, "limit", "n", 3, "Maximum candidates to show")
+	assignSuggestCmd.Flags().StringVar(&assignSuggestKind, "kind", "", "Only suggest this kind of assignee (human/agent)")
+	assignSuggestCmd.Flags().BoolVar(&assignSuggestApply, "apply", false, "Assign the task to the best candidate")
+	assignSuggestCmd.Flags().StringVar(&assignSuggestBy, "by", "user", "Who is assigning, for the history")
+}
+
+// assignCandidate is a possible assignee scored for a task
+type assignCandidate struct {
+	Name        string   `json:"name"`
+	Kind        string   `json:"kind,omitempty"`
+	Score       float64  `json:"score"`
+	Affinity    float64  `json:"affinity"`
+	Capability  *float64 `json:"capability,omitempty"` // Only for registered agents
+	Capacity    float64  `json:"capacity"`
+	OpenTasks   int      `json:"open_tasks"`
+	OpenMinutes int      `json:"open_estimate_minutes,omitempty"`
+	Labels      []string `json:"matched_labels,omitempty"` // Task labels found on their closed tasks
+	Matched     []string `json:"matched_capabilities,omitempty"`
+	Current     bool     `json:"current,omitempty"` // Already the task's assignee
+}
+
+// rankAssignees scores every candidate assignee for a task, best first
+func rankAssignees(database *gorm.DB, task models.Task, kind string) ([]assignCandidate, error) {
+	kinds := make(map[string]string)
+	var people []models.Person
+	if err := database.Find(&people).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range people {
+		kinds[p.Name] = p.Kind
+	}
+	agents := make(map[string]models.Agent)
+	var registered []models.Agent
+	if err := database.Find(&registered).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range registered {
+		agents[a.Name] = a
+		if kinds[a.Name] == "" {
+			kinds[a.Name] = models.PersonKindAgent
+		}
+	}
+	var assignees []string
+	if err := database.Model(&models.Task{}).Where("assignee != ''").Distinct("assignee").Pluck("assignee", &assignees).Error; err != nil {
+		return nil, err
+	}
+	for _, name := range assignees {
+		if _, ok := kinds[name]; !ok {
+			kinds[name] = ""
+		}
+	}
+
+	// Open workload and closed-task labels per assignee
+	var open []models.Task
+	if err := database.Select("id", "assignee", "estimate").Where("assignee != '' AND status NOT IN ?",
+		[]string{models.StatusClosed, models.StatusArchived}).Find(&open).Error; err != nil {
+		return nil, err
+	}
+	var closed []models.Task
+	if err := database.Select("assignee", "labels").Where("assignee != '' AND status = ?", models.StatusClosed).Find(&closed).Error; err != nil {
+		return nil, err
+	}
+	closedLabels := make(map[string]map[string]bool)
+	for _, t := range closed {
+		if closedLabels[t.Assignee] == nil {
+			closedLabels[t.Assignee] = make(map[string]bool)
+		}
+		for _, l := range t.Labels {
+			closedLabels[t.Assignee][l] = true
+		}
+	}
+
+	var candidates []assignCandidate
+	for name, k := range kinds {
+		if kind != "" && k != kind {
+			continue
+		}
+		c := assignCandidate{Name: name, Kind: k, Current: name == task.Assignee}
+		for _, t := range open {
+			if t.Assignee == name && t.ID != task.ID {
+				c.OpenTasks++
+				c.OpenMinutes += t.Estimate
+			}
+		}
+		load := float64(c.OpenTasks) + float64(c.OpenMinutes)/float64(models.MinutesPerWorkDay)
+		c.Capacity = 1 / (1 + load)
+
+		for _, l := range task.Labels {
+			if closedLabels[name][l] {
+				c.Labels = append(c.Labels, l)
+			}
+		}
+		if len(task.Labels) > 0 {
+			c.Affinity = float64(len(c.Labels)) / float64(len(task.Labels))
+		}
+
+		weights := assignWeightAffinity + assignWeightCapacity
+		c.Score = c.Affinity*assignWeightAffinity + c.Capacity*assignWeightCapacity
+		if agent, ok := agents[name]; ok {
+			matches, err := matchTasksToAgent(database, agent, []models.Task{task})
+			if err != nil {
+				return nil, err
+			}
+			m := matches[0]
+			capability := 0.5 // No requirements to match
+			switch {
+			case m.Linked:
+				capability = 1
+			case m.OtherPrimary != "", m.Unmatched:
+				capability = 0
+			case len(m.Matched) > 0:
+				capability = 0.75
+			}
+			c.Capability = &capability
+			c.Matched = m.Matched
+			weights += assignWeightCapability
+			c.Score += capability * assignWeightCapability
+		}
+		c.Score /= weights
+		candidates = append(candidates, c)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates, nil
+}
+
+func runAssignSuggest(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot suggest an assignee: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	if assignSuggestKind != "" && assignSuggestKind != models.PersonKindHuman && assignSuggestKind != models.PersonKindAgent {
+		return fmt.Errorf("invalid kind '%s': must be human or agent", assignSuggestKind)
+	}
+	database := db.GetDB()
+
+	candidates, err := rankAssignees(database, *task, assignSuggestKind)
+	if err != nil {
+		return fmt.Errorf("failed to rank assignees for task '%s': database error: %w", task.ID, err)
+	}
+	if assignSuggestLimit > 0 && len(candidates) > assignSuggestLimit {
+		candidates = candidates[:assignSuggestLimit]
+	}
+
+	applied := ""
+	if assignSuggestApply && len(candidates) > 0 && !candidates[0].Current {
+		best := candidates[0].Name
+		if err := checkAssignee(database, best); err != nil {
+			return err
+		}
+		err := database.Transaction(func(tx *gorm.DB) error {
+			if err := models.RecordChange(tx, task.ID, "assignee", task.Assignee, best, assignSuggestBy); err != nil {
+				return err
+			}
+			return tx.Model(task).Update("assignee", best).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to assign task '%s': database error: %w", task.ID, err)
+		}
+		applied = best
+	}
+
+	if IsJSONOutput() {
+		if candidates == nil {
+			candidates = []assignCandidate{}
+		}
+		result := map[string]interface{}{"task_id": task.ID, "candidates": candidates}
+		if applied != "" {
+			result["assigned"] = applied
+		}
+		OutputJSON(result)
+		return nil
+	}
+	if len(candidates) == 0 {
+		fmt.Println("No candidates: register people with 'gur people add' or agents with 'gur agent add'.")
+		return nil
+	}
+	fmt.Printf("Suggested assignees for %s:\n", task.ID)
+	for _, c := range candidates {
+		fmt.Printf("  %-20s %3.0f%%  %d open", c.Name, c.Score*100, c.OpenTasks)
+		if c.OpenMinutes > 0 {
+			fmt.Printf(" (%s)", models.FormatEffort(c.OpenMinutes))
+		}
+		var why []string
+		if len(c.Labels) > 0 {
+			why = append(why, "labels: "+strings.Join(c.Labels, ", "))
+		}
+		if len(c.Matched) > 0 {
+			why = append(why, "capabilities: "+strings.Join(c.Matched, ", "))
+		}
+		if c.Current {
+			why = append(why, "current assignee")
+		}
+		if len(why) > 0 {
+			fmt.Printf("  [%s]", strings.Join(why, "; "))
+		}
+		fmt.Println()
+	}
+	if applied != "" {
+		fmt.Printf("Assigned %s to %s\n", task.ID, applied)
+	} else if assignSuggestApply {
+		fmt.Printf("%s is already assigned to %s\n", task.ID, candidates[0].Name)
+	}
+	return nil
+}
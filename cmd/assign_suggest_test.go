@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestRankAssignees(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Person{Name: "alice", Kind: models.PersonKindHuman})
+	database.Create(&models.Person{Name: "busy-bot", Kind: models.PersonKindAgent})
+	database.Create(&models.Agent{Name: "web-bot", Capabilities: "frontend css"})
+	database.Create(&models.Task{ID: "gur-a5000001", Title: "Old CSS fix", Status: models.StatusClosed, Assignee: "web-bot", Labels: models.StringSlice{"frontend"}})
+	database.Create(&models.Task{ID: "gur-a5000002", Title: "Busy 1", Status: models.StatusOpen, Assignee: "busy-bot", Estimate: 2 * models.MinutesPerWorkDay})
+	database.Create(&models.Task{ID: "gur-a5000003", Title: "Busy 2", Status: models.StatusInProgress, Assignee: "busy-bot"})
+	task := models.Task{ID: "gur-a5000004", Title: "Fix button", Status: models.StatusOpen, Labels: models.StringSlice{"frontend"}}
+	database.Create(&task)
+
+	candidates, err := rankAssignees(database, task, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 3 || candidates[0].Name != "web-bot" || candidates[len(candidates)-1].Name != "busy-bot" {
+		t.Fatalf("candidates = %+v, want web-bot first (affinity, capability) and busy-bot last (workload)", candidates)
+	}
+	if busy := candidates[2]; busy.OpenTasks != 2 || busy.OpenMinutes != 2*models.MinutesPerWorkDay {
+		t.Errorf("busy-bot workload = %d tasks, %d minutes, want 2 tasks, 2 days", busy.OpenTasks, busy.OpenMinutes)
+	}
+	if candidates[0].Capability == nil || candidates[1].Capability != nil {
+		t.Error("capability should only be scored for registered agents")
+	}
+
+	humans, _ := rankAssignees(database, task, models.PersonKindHuman)
+	if len(humans) != 1 || humans[0].Name != "alice" {
+		t.Errorf("--kind human candidates = %+v, want alice", humans)
+	}
+
+	assignSuggestApply = true
+	defer func() { assignSuggestApply = false }()
+	if err := runAssignSuggest(assignSuggestCmd, []string{task.ID}); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ := db.GetTaskByID(task.ID)
+	if updated.Assignee != "web-bot" {
+		t.Errorf("--apply assigned %q, want web-bot", updated.Assignee)
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var blockReason string
+
+var blockCmd = &cobra.Command{
+	Use:   "block <id>",
+	Short: "Mark a task as blocked",
+	Long: `Mark a task as blocked on something outside the dependency graph
+(e.g., waiting on infrastructure, a vendor, or a decision).
+
+Blocked tasks are excluded from 'gur ready' until unblocked and are
+pushed to GitHub with a "blocked" label.
+
+Examples:
+  gur block gur-abc123 --reason "waiting on infra"
+  gur unblock gur-abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBlock,
+}
+
+var unblockCmd = &cobra.Command{
+	Use:   "unblock <id>",
+	Short: "Return a blocked task to open",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUnblock,
+}
+
+func init() {
+	rootCmd.AddCommand(blockCmd)
+	rootCmd.AddCommand(unblockCmd)
+	blockCmd.Flags().StringVarP(&blockReason, "reason", "r", "", "Why the task is blocked")
+	blockCmd.MarkFlagRequired("reason")
+}
+
+func runBlock(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot block task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	if task.IsClosed() || task.IsArchived() {
+		return fmt.Errorf("cannot block task '%s': task is %s", task.ID, task.Status)
+	}
+
+	database := db.GetDB()
+	models.RecordChange(database, task.ID, "status", task.Status, models.StatusBlocked, "user")
+	models.RecordChange(database, task.ID, "block_reason", task.BlockReason, blockReason, "user")
+	task.Block(blockReason)
+	if err := database.Save(&task).Error; err != nil {
+		return fmt.Errorf("failed to block task '%s': database error: %w", task.ID, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task": task})
+	} else {
+		fmt.Printf("Blocked: %s (%s)\n", task.ID, task.BlockReason)
+	}
+	return nil
+}
+
+func runUnblock(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot unblock task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	if !task.IsBlocked() {
+		return fmt.Errorf("cannot unblock task '%s': task is not blocked (current status: %s)", task.ID, task.Status)
+	}
+
+	database := db.GetDB()
+	models.RecordChange(database, task.ID, "status", task.Status, models.StatusOpen, "user")
+	models.RecordChange(database, task.ID, "block_reason", task.BlockReason, "", "user")
+	task.Unblock()
+	if err := database.Save(&task).Error; err != nil {
+		return fmt.Errorf("failed to unblock task '%s': database error: %w", task.ID, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task": task})
+	} else {
+		fmt.Printf("Unblocked: %s\n", task.ID)
+	}
+	return nil
+}
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var (
+	briefFormat string
+	briefOutput string
+)
+
+var briefCmd = &cobra.Command{
+	Use:         "brief <id>",
+	Short:       "Generate a self-contained task brief for handoff",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Generate a printable brief for a task, suitable for pasting into a design
+doc or sending to a stakeholder.
+
+The brief includes the description, dependency context, subtasks, gate
+evidence, notes, linked GitHub issue and pull requests, and the change history.
+
+Examples:
+  gur brief gur-abc123                       # Markdown to stdout
+  gur brief gur-abc123 --format html -o brief.html`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBrief,
+}
+
+func init() {
+	rootCmd.AddCommand(briefCmd)
+	briefCmd.Flags().StringVarP(&briefFormat, "format", "f", "markdown", "Output format (markdown/html)")
+	briefCmd.Flags().StringVarP(&briefOutput, "output", "o", "", "Write to file instead of stdout")
+}
+
+// pullRequestPattern matches GitHub pull request URLs in free text
+var pullRequestPattern = regexp.MustCompile(`https://github\.com/[\w.-]+/[\w.-]+/pull/\d+`)
+
+// taskBrief gathers everything needed to render a brief
+type taskBrief struct {
+	Task         *models.Task            `json:"task"`
+	BlockedBy    []models.Task           `json:"blocked_by"`
+	Blocks       []models.Task           `json:"blocks"`
+	Subtasks     []models.Task           `json:"subtasks"`
+	Gates        []GateLinkInfo          `json:"gates"`
+	Issue        *models.GitHubIssueLink `json:"github_issue,omitempty"`
+	PullRequests []string                `json:"pull_requests"`
+	References   []taskReference         `json:"references"`
+	History      []models.TaskHistory    `json:"history"`
+	GeneratedAt  time.Time               `json:"generated_at"`
+}
+
+func collectTaskBrief(taskID string) (*taskBrief, error) {
+	database := db.GetDB()
+	task, err := db.GetTaskByID(taskID)
+	if err != nil {
+		return nil, codedErrorf(ErrCodeNotFound, "cannot generate brief: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+	}
+
+	b := &taskBrief{Task: task, GeneratedAt: time.Now()}
+	if task.Criteria, err = loadAcceptanceCriteria(database, task.ID); err != nil {
+		return nil, fmt.Errorf("failed to load acceptance criteria for task '%s': %w", task.ID, err)
+	}
+
+	// Only dependencies that hold a task back; related and parent-child
+	// links aren't blockers
+	blocking := []string{models.DepTypeBlocks, models.DepTypeFinishStart}
+	if err := database.Joins("JOIN dependencies ON dependencies.parent_id = tasks.id").
+		Where("dependencies.child_id = ? AND dependencies.type IN ? AND dependencies.deleted_at IS NULL", task.ID, blocking).
+		Find(&b.BlockedBy).Error; err != nil {
+		return nil, fmt.Errorf("failed to load blockers for task '%s': database error: %w", task.ID, err)
+	}
+	if err := database.Joins("JOIN dependencies ON dependencies.child_id = tasks.id").
+		Where("dependencies.parent_id = ? AND dependencies.type IN ? AND dependencies.deleted_at IS NULL", task.ID, blocking).
+		Find(&b.Blocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load blocked tasks for task '%s': database error: %w", task.ID, err)
+	}
+	if err := database.Where("parent_id = ?", task.ID).Order("id ASC").Find(&b.Subtasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load subtasks for task '%s': database error: %w", task.ID, err)
+	}
+	if err := database.Where("task_id = ?", task.ID).Order("changed_at ASC").Find(&b.History).Error; err != nil {
+		return nil, fmt.Errorf("failed to load history for task '%s': database error: %w", task.ID, err)
+	}
+
+	gates, err := GetGateLinksForTask(task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gates for task '%s': %w", task.ID, err)
+	}
+	b.Gates = gates
+
+	var link models.GitHubIssueLink
+	if database.Where("task_id = ?", task.ID).First(&link).Error == nil {
+		b.Issue = &link
+	}
+
+	if b.References, err = loadTaskReferences(database, task.ID); err != nil {
+		return nil, fmt.Errorf("failed to load references for task '%s': %w", task.ID, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, pr := range pullRequestPattern.FindAllString(task.Description+"\n"+task.Notes, -1) {
+		if !seen[pr] {
+			seen[pr] = true
+			b.PullRequests = append(b.PullRequests, pr)
+		}
+	}
+
+	return b, nil
+}
+
+func runBrief(cmd *cobra.Command, args []string) error {
+	b, err := collectTaskBrief(args[0])
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(b)
+		return nil
+	}
+
+	var content string
+	switch briefFormat {
+	case "markdown", "md":
+		content = renderBriefMarkdown(b)
+	case "html":
+		content, err = renderBriefHTML(b)
+		if err != nil {
+			return fmt.Errorf("failed to render brief: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid format '%s': must be markdown or html", briefFormat)
+	}
+
+	if briefOutput != "" {
+		if err := os.WriteFile(briefOutput, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write brief to %s: %w", briefOutput, err)
+		}
+		fmt.Printf("Wrote brief for %s to %s\n", b.Task.ID, briefOutput)
+		return nil
+	}
+
+	fmt.Print(content)
+	return nil
+}
+
+// describeHistory renders a history entry as a single sentence
+func describeHistory(h models.TaskHistory) string {
+	var s string
+	switch {
+	case h.OldValue == "":
+		s = fmt.Sprintf("%s set to \"%s\"", h.Field, h.NewValue)
+	case h.NewValue == "":
+		s = fmt.Sprintf("%s removed \"%s\"", h.Field, h.OldValue)
+	default:
+		s = fmt.Sprintf("%s: \"%s\" → \"%s\"", h.Field, h.OldValue, h.NewValue)
+	}
+	if h.ChangedBy != "" {
+		s += " (by " + h.ChangedBy + ")"
+	}
+	return s
+}
+
+func gateEvidence(info GateLinkInfo) string {
+	status := info.Status
+	if status == "" {
+		status = models.GateLinkPending
+	}
+	s := status
+	if info.Link.VerifiedBy != "" {
+		s += " by " + info.Link.VerifiedBy
+	}
+	if info.Link.VerifiedAt != nil {
+		s += " on " + info.Link.VerifiedAt.Format(models.DateTimeShortFormat)
+	}
+	if info.Status == models.GateLinkWaived && info.Link.ExpiresAt != nil {
+		verb := "expires"
+		if info.Link.WaiverExpired(time.Now()) {
+			verb = "expired"
+		}
+		s += fmt.Sprintf(" (%s %s)", verb, info.Link.ExpiresAt.Format(models.DateTimeShortFormat))
+	}
+	return s
+}
+
+func renderBriefMarkdown(b *taskBrief) string {
+	t := b.Task
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# %s\n\n", t.Title))
+	sb.WriteString("| Field | Value |\n")
+	sb.WriteString("| ----- | ----- |\n")
+	sb.WriteString(fmt.Sprintf("| ID | `%s` |\n", t.ID))
+	sb.WriteString(fmt.Sprintf("| Status | %s |\n", t.Status))
+	sb.WriteString(fmt.Sprintf("| Priority | %s |\n", t.PriorityString()))
+	sb.WriteString(fmt.Sprintf("| Type | %s |\n", t.Type))
+	if t.Assignee != "" {
+		sb.WriteString(fmt.Sprintf("| Assignee | %s |\n", t.Assignee))
+	}
+	if len(t.Labels) > 0 {
+		sb.WriteString(fmt.Sprintf("| Labels | %s |\n", strings.Join(t.Labels, ", ")))
+	}
+	sb.WriteString(fmt.Sprintf("| Created | %s |\n", t.CreatedAt.Format(models.DateTimeShortFormat)))
+	if t.ClosedAt != nil {
+		sb.WriteString(fmt.Sprintf("| Closed | %s (%s) |\n", t.ClosedAt.Format(models.DateTimeShortFormat), t.CloseReason))
+	}
+
+	if t.Description != "" {
+		sb.WriteString("\n## Description\n\n")
+		sb.WriteString(t.Description)
+		sb.WriteString("\n")
+	} else if t.Summary != "" {
+		sb.WriteString("\n## Summary\n\n")
+		sb.WriteString(t.Summary)
+		sb.WriteString("\n")
+	}
+
+	if len(t.Criteria) > 0 {
+		sb.WriteString(fmt.Sprintf("\n## Acceptance Criteria (%d/%d done)\n\n", criteriaDone(t.Criteria), len(t.Criteria)))
+		for _, c := range t.Criteria {
+			sb.WriteString(c.Checkbox() + "\n")
+		}
+	}
+
+	if len(b.BlockedBy) > 0 || len(b.Blocks) > 0 || len(b.Subtasks) > 0 {
+		sb.WriteString("\n## Dependencies\n\n")
+		for _, d := range b.BlockedBy {
+			sb.WriteString(fmt.Sprintf("- Blocked by `%s` %s (%s)\n", d.ID, d.Title, d.Status))
+		}
+		for _, d := range b.Blocks {
+			sb.WriteString(fmt.Sprintf("- Blocks `%s` %s (%s)\n", d.ID, d.Title, d.Status))
+		}
+		for _, s := range b.Subtasks {
+			sb.WriteString(fmt.Sprintf("- Subtask `%s` %s (%s)\n", s.ID, s.Title, s.Status))
+		}
+	}
+
+	if len(b.Gates) > 0 {
+		sb.WriteString("\n## Gate Evidence\n\n")
+		for _, g := range b.Gates {
+			sb.WriteString(fmt.Sprintf("- **%s** (`%s`, %s): %s\n", g.Gate.Title, g.Gate.ID, g.Gate.TypeString(), gateEvidence(g)))
+			if g.Link.Notes != "" {
+				sb.WriteString(fmt.Sprintf("  - %s\n", g.Link.Notes))
+			}
+		}
+	}
+
+	if t.Notes != "" {
+		sb.WriteString("\n## Notes\n\n```\n")
+		sb.WriteString(strings.TrimRight(t.Notes, "\n"))
+		sb.WriteString("\n```\n")
+	}
+
+	if b.Issue != nil || len(b.PullRequests) > 0 {
+		sb.WriteString("\n## Links\n\n")
+		if b.Issue != nil {
+			sb.WriteString(fmt.Sprintf("- Issue #%d: %s\n", b.Issue.IssueNumber, b.Issue.IssueURL))
+		}
+		for _, pr := range b.PullRequests {
+			sb.WriteString(fmt.Sprintf("- Pull request: %s\n", pr))
+		}
+	}
+
+	if len(b.References) > 0 {
+		sb.WriteString("\n## References\n\n")
+		for _, r := range b.References {
+			if r.Link != "" && r.Link != r.URL {
+				sb.WriteString(fmt.Sprintf("- [%s](%s) (%s)\n", r.String(), r.Link, r.Source))
+			} else {
+				sb.WriteString(fmt.Sprintf("- %s (%s)\n", r.String(), r.Source))
+			}
+		}
+	}
+
+	if len(b.History) > 0 {
+		sb.WriteString("\n## History\n\n")
+		for _, h := range b.History {
+			sb.WriteString(fmt.Sprintf("- %s — %s\n", h.ChangedAt.Format(models.DateTimeShortFormat), describeHistory(h)))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n---\n*Generated by gur on %s*\n", b.GeneratedAt.Format(models.DateTimeShortFormat)))
+	return sb.String()
+}
+
+var briefHTMLTemplate = template.Must(template.New("brief").Funcs(template.FuncMap{
+	"fmtTime":  func(t time.Time) string { return t.Format(models.DateTimeShortFormat) },
+	"history":  describeHistory,
+	"evidence": gateEvidence,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Task.ID}}: {{.Task.Title}}</title>
+<style>
+body { font-family: -apple-system, Helvetica, Arial, sans-serif; max-width: 800px; margin: 2em auto; color: #222; }
+table { border-collapse: collapse; } td, th { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+pre { background: #f6f8fa; padding: 1em; white-space: pre-wrap; }
+footer { color: #888; font-size: small; margin-top: 2em; }
+</style>
+</head>
+<body>
+<h1>{{.Task.Title}}</h1>
+<table>
+<tr><th>ID</th><td><code>{{.Task.ID}}</code></td></tr>
+<tr><th>Status</th><td>{{.Task.Status}}</td></tr>
+<tr><th>Priority</th><td>{{.Task.PriorityString}}</td></tr>
+<tr><th>Type</th><td>{{.Task.Type}}</td></tr>
+{{if .Task.Assignee}}<tr><th>Assignee</th><td>{{.Task.Assignee}}</td></tr>{{end}}
+{{if .Task.Labels}}<tr><th>Labels</th><td>{{range $i, $l := .Task.Labels}}{{if $i}}, {{end}}{{$l}}{{end}}</td></tr>{{end}}
+<tr><th>Created</th><td>{{fmtTime .Task.CreatedAt}}</td></tr>
+{{if .Task.ClosedAt}}<tr><th>Closed</th><td>{{fmtTime .Task.ClosedAt}} ({{.Task.CloseReason}})</td></tr>{{end}}
+</table>
+{{if .Task.Description}}<h2>Description</h2>
+<pre>{{.Task.Description}}</pre>{{else if .Task.Summary}}<h2>Summary</h2>
+<p>{{.Task.Summary}}</p>{{end}}
+{{if .Task.Criteria}}<h2>Acceptance Criteria</h2>
+<ul>
+{{range .Task.Criteria}}<li><input type="checkbox" disabled{{if .Done}} checked{{end}}> {{.Text}}</li>
+{{end}}</ul>{{end}}
+{{if or .BlockedBy .Blocks .Subtasks}}<h2>Dependencies</h2>
+<ul>
+{{range .BlockedBy}}<li>Blocked by <code>{{.ID}}</code> {{.Title}} ({{.Status}})</li>
+{{end}}{{range .Blocks}}<li>Blocks <code>{{.ID}}</code> {{.Title}} ({{.Status}})</li>
+{{end}}{{range .Subtasks}}<li>Subtask <code>{{.ID}}</code> {{.Title}} ({{.Status}})</li>
+{{end}}</ul>{{end}}
+{{if .Gates}}<h2>Gate Evidence</h2>
+<ul>
+{{range .Gates}}<li><strong>{{.Gate.Title}}</strong> (<code>{{.Gate.ID}}</code>, {{.Gate.TypeString}}): {{evidence .}}{{if .Link.Notes}}<br><em>{{.Link.Notes}}</em>{{end}}</li>
+{{end}}</ul>{{end}}
+{{if .Task.Notes}}<h2>Notes</h2>
+<pre>{{.Task.Notes}}</pre>{{end}}
+{{if or .Issue .PullRequests}}<h2>Links</h2>
+<ul>
+{{with .Issue}}<li>Issue <a href="{{.IssueURL}}">#{{.IssueNumber}}</a></li>
+{{end}}{{range .PullRequests}}<li>Pull request <a href="{{.}}">{{.}}</a></li>
+{{end}}</ul>{{end}}
+{{if .References}}<h2>References</h2>
+<ul>
+{{range .References}}<li>{{if .Link}}<a href="{{.Link}}">{{.String}}</a>{{else}}{{.String}}{{end}} ({{.Source}})</li>
+{{end}}</ul>{{end}}
+{{if .History}}<h2>History</h2>
+<ul>
+{{range .History}}<li>{{fmtTime .ChangedAt}} — {{history .}}</li>
+{{end}}</ul>{{end}}
+<footer>Generated by gur on {{fmtTime .GeneratedAt}}</footer>
+</body>
+</html>
+`))
+
+func renderBriefHTML(b *taskBrief) (string, error) {
+	var sb strings.Builder
+	if err := briefHTMLTemplate.Execute(&sb, b); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestTaskBrief(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	for _, task := range []models.Task{
+		{ID: "gur-b1000001", Title: "Checkout flow", Status: models.StatusOpen, Notes: "Talked to payments\nsee https://github.com/acme/shop/pull/12"},
+		{ID: "gur-b1000002", Title: "Payments API", Status: models.StatusInProgress},
+		{ID: "gur-b1000003", Title: "Design notes", Status: models.StatusOpen},
+		{ID: "gur-b1000004", Title: "Receipts", Status: models.StatusOpen},
+		{ID: "gur-b1000005", Title: "Analytics", Status: models.StatusOpen},
+	} {
+		database.Create(&task)
+	}
+	for _, d := range []models.Dependency{
+		{ParentID: "gur-b1000002", ChildID: "gur-b1000001", Type: models.DepTypeBlocks},
+		{ParentID: "gur-b1000003", ChildID: "gur-b1000001", Type: models.DepTypeRelated},
+		{ParentID: "gur-b1000001", ChildID: "gur-b1000004", Type: models.DepTypeBlocks},
+		{ParentID: "gur-b1000001", ChildID: "gur-b1000005", Type: models.DepTypeSoftBlocks},
+	} {
+		database.Create(&d)
+	}
+
+	b, err := collectTaskBrief("gur-b1000001")
+	if err != nil {
+		t.Fatalf("collectTaskBrief() error: %v", err)
+	}
+	if len(b.BlockedBy) != 1 || b.BlockedBy[0].ID != "gur-b1000002" {
+		t.Errorf("BlockedBy = %v, want only the blocking task, not the related one", b.BlockedBy)
+	}
+	if len(b.Blocks) != 1 || b.Blocks[0].ID != "gur-b1000004" {
+		t.Errorf("Blocks = %v, want only the blocked task, not the soft-blocked one", b.Blocks)
+	}
+	if len(b.PullRequests) != 1 {
+		t.Errorf("PullRequests = %v, want the one in the notes", b.PullRequests)
+	}
+
+	md := renderBriefMarkdown(b)
+	if strings.Contains(md, "Design notes") || strings.Contains(md, "Analytics") {
+		t.Errorf("markdown lists a non-blocking link as a dependency:\n%s", md)
+	}
+	if !strings.Contains(md, "- Blocked by `gur-b1000002` Payments API (in_progress)\n") {
+		t.Errorf("markdown is missing the blocker:\n%s", md)
+	}
+	if !strings.Contains(md, "## Notes\n\n```\nTalked to payments\nsee https://github.com/acme/shop/pull/12\n```\n") {
+		t.Errorf("notes are not fenced on their own lines:\n%s", md)
+	}
+
+	if _, err := collectTaskBrief("gur-b1000009"); errorCodeOf(err) != ErrCodeNotFound {
+		t.Errorf("brief of a missing task = %v, want not found", err)
+	}
+}
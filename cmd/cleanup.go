@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gorm.io/gorm"
@@ -11,7 +14,8 @@ import (
 )
 
 var (
-	cleanupDryRun bool
+	cleanupDryRun       bool
+	cleanupDetachRemote bool
 )
 
 var cleanupCmd = &cobra.Command{
@@ -22,18 +26,31 @@ var cleanupCmd = &cobra.Command{
 This is useful for database maintenance after tasks have been deleted.
 The cleanup is performed in a transaction to ensure data consistency.
 
+Links of deleted tasks to GitHub issues are kept, since their issues would
+stay open with nothing behind them. With --detach-remote each issue gets a
+comment saying the task was deleted and is closed, then its link is removed.
+
 Examples:
-  gur cleanup            # Clean up all orphaned records
-  gur cleanup --dry-run  # Show what would be cleaned without making changes`,
+  gur cleanup                  # Clean up all orphaned records
+  gur cleanup --dry-run        # Show what would be cleaned without making changes
+  gur cleanup --detach-remote  # Also close the issues of deleted tasks`,
 	RunE: runCleanup,
 }
 
 func init() {
 	rootCmd.AddCommand(cleanupCmd)
 	cleanupCmd.Flags().BoolVar(&cleanupDryRun, "dry-run", false, "Show what would be cleaned without making changes")
+	cleanupCmd.Flags().BoolVar(&cleanupDetachRemote, "detach-remote", false, "Comment on and close the GitHub issues of deleted tasks, then remove their links")
 }
 
 func runCleanup(cmd *cobra.Command, args []string) error {
+	if !cleanupDryRun {
+		release, err := lockWorkspace("gur cleanup")
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
 	database := db.GetDB()
 
 	// Count orphaned records before cleanup
@@ -69,17 +86,25 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		Where("task_id NOT IN (SELECT id FROM tasks WHERE deleted_at IS NULL)").
 		Count(&orphanedGitHubLinks)
 
+	// GitHub issue links are only removed with --detach-remote
+	var keptGitHubLinks int64
+	if !cleanupDetachRemote {
+		keptGitHubLinks, orphanedGitHubLinks = orphanedGitHubLinks, 0
+	}
+
 	totalOrphaned := orphanedDeps + orphanedGateLinks + orphanedSkillLinks + orphanedAgentLinks + orphanedGitHubLinks
 
 	if totalOrphaned == 0 {
 		if IsJSONOutput() {
 			OutputJSON(map[string]interface{}{
-				"message":        "No orphaned records found",
-				"cleaned_counts": map[string]int64{},
+				"message":           "No orphaned records found",
+				"cleaned_counts":    map[string]int64{},
+				"kept_github_links": keptGitHubLinks,
 			})
 			return nil
 		}
 		fmt.Println("No orphaned records found")
+		printKeptGitHubLinks(keptGitHubLinks)
 		return nil
 	}
 
@@ -95,6 +120,7 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 					"github_links":     orphanedGitHubLinks,
 					"total":            totalOrphaned,
 				},
+				"kept_github_links": keptGitHubLinks,
 			})
 			return nil
 		}
@@ -106,10 +132,19 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  GitHub Issue Links: %d\n", orphanedGitHubLinks)
 		fmt.Printf("  ---\n")
 		fmt.Printf("  Total:              %d\n", totalOrphaned)
+		printKeptGitHubLinks(keptGitHubLinks)
 		fmt.Println("\nRun without --dry-run to remove these records")
 		return nil
 	}
 
+	var detachedTaskIDs []string
+	if orphanedGitHubLinks > 0 {
+		var err error
+		if detachedTaskIDs, err = detachOrphanedGitHubLinks(database); err != nil {
+			return err
+		}
+	}
+
 	// Perform cleanup in a transaction
 	var cleanedDeps, cleanedGateLinks, cleanedSkillLinks, cleanedAgentLinks, cleanedGitHubLinks int64
 
@@ -147,13 +182,14 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		}
 		cleanedAgentLinks = result.RowsAffected
 
-		// Clean orphaned GitHub issue links
-		result = tx.Where("task_id NOT IN (SELECT id FROM tasks WHERE deleted_at IS NULL)").
-			Delete(&models.GitHubIssueLink{})
-		if result.Error != nil {
-			return result.Error
+		// Clean orphaned GitHub issue links whose issues were detached
+		if len(detachedTaskIDs) > 0 {
+			result = tx.Where("task_id IN ?", detachedTaskIDs).Delete(&models.GitHubIssueLink{})
+			if result.Error != nil {
+				return result.Error
+			}
+			cleanedGitHubLinks = result.RowsAffected
 		}
-		cleanedGitHubLinks = result.RowsAffected
 
 		return nil
 	})
@@ -175,6 +211,7 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 				"github_links":     cleanedGitHubLinks,
 				"total":            totalCleaned,
 			},
+			"kept_github_links": keptGitHubLinks,
 		})
 		return nil
 	}
@@ -187,6 +224,44 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  GitHub Issue Links: %d removed\n", cleanedGitHubLinks)
 	fmt.Printf("  ---\n")
 	fmt.Printf("  Total:              %d removed\n", totalCleaned)
+	printKeptGitHubLinks(keptGitHubLinks)
 
 	return nil
 }
+
+// printKeptGitHubLinks notes the links of deleted tasks that cleanup kept
+func printKeptGitHubLinks(kept int64) {
+	if kept > 0 {
+		fmt.Printf("\nKept %d GitHub issue link(s) of deleted tasks (use --detach-remote to close their issues and remove them)\n", kept)
+	}
+}
+
+// detachOrphanedGitHubLinks comments on and closes the issues linked to
+// deleted tasks, returning the tasks whose issues were detached. An issue
+// that fails keeps its link for the next cleanup.
+func detachOrphanedGitHubLinks(database *gorm.DB) ([]string, error) {
+	var links []models.GitHubIssueLink
+	if err := database.Where("task_id NOT IN (SELECT id FROM tasks WHERE deleted_at IS NULL)").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to load orphaned GitHub issue links: database error: %w", err)
+	}
+	token, err := GetGitHubToken()
+	if err != nil {
+		return nil, err
+	}
+	client, err := newGitHubClient(token)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var detached []string
+	for _, link := range links {
+		if err := detachRemoteIssue(ctx, client, link, "gur cleanup --detach-remote"); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: keeping link of %s to issue %s#%d: %v\n", link.TaskID, link.Repository, link.IssueNumber, err)
+			continue
+		}
+		detached = append(detached, link.TaskID)
+	}
+	return detached, nil
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <id>",
+	Short: "Duplicate a task, optionally with its subtasks and gates",
+	Long: `Create a copy of a task for repeated work such as release checklists.
+
+The copy is a new open task with the original's title, description, type,
+priority, labels, assignee, custom fields, and skill/agent links. Notes,
+history, due dates and sync state are not copied. A subtask is cloned
+under the same parent unless --into says otherwise.
+
+  --with-subtasks  also clone the subtask tree, keeping dependencies
+                   between cloned tasks
+  --with-gates     link the same gates to the copies, reset to pending
+
+Examples:
+  gur clone gur-abc123
+  gur clone gur-abc123 --with-subtasks --with-gates --title "Release 1.5"
+  gur clone gur-abc123.2 --into gur-def456`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClone,
+}
+
+var (
+	cloneInto         string
+	cloneWithGates    bool
+	cloneWithSubtasks bool
+	cloneTitle        string
+)
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+	cloneCmd.Flags().StringVar(&cloneInto, "into", "", "Create the copy as a subtask of this task")
+	cloneCmd.Flags().BoolVar(&cloneWithGates, "with-gates", false, "Link the same gates to the copies (reset to pending)")
+	cloneCmd.Flags().BoolVar(&cloneWithSubtasks, "with-subtasks", false, "Clone the subtask tree too")
+	cloneCmd.Flags().StringVar(&cloneTitle, "title", "", "Title for the copy (default: the original's)")
+}
+
+// cloneResult summarizes a clone
+type cloneResult struct {
+	Root      string            `json:"root"`
+	Tasks     map[string]string `json:"tasks"` // original ID -> copy ID
+	GateLinks int               `json:"gate_links"`
+	Deps      int               `json:"dependencies"`
+}
+
+// cloner copies tasks within a transaction, remembering original -> copy IDs
+type cloner struct {
+	tx        *gorm.DB
+	withGates bool
+	result    cloneResult
+}
+
+// nextSubtaskID returns the ID for a new subtask of parentID
+func nextSubtaskID(tx *gorm.DB, parentID string) (string, error) {
+	var count int64
+	if err := tx.Model(&models.Task{}).Where("parent_id = ?", parentID).Count(&count).Error; err != nil {
+		return "", err
+	}
+	return models.GenerateSubtaskID(parentID, int(count)+1), nil
+}
+
+// cloneTask copies src (and with subtasks, its descendants) under parentID
+func (c *cloner) cloneTask(src models.Task, parentID, title string, subtasks bool) (string, error) {
+	task := &models.Task{
+		Title:       src.Title,
+		Description: src.Description,
+		Priority:    src.Priority,
+		Type:        src.Type,
+		Labels:      append(models.StringSlice{}, src.Labels...),
+		Assignee:    src.Assignee,
+		Status:      models.StatusOpen,
+		Source:      models.SourceLocal,
+		ParentID:    parentID,
+	}
+	if title != "" {
+		task.Title = title
+	}
+	if parentID != "" {
+		id, err := nextSubtaskID(c.tx, parentID)
+		if err != nil {
+			return "", err
+		}
+		task.ID = id
+	}
+	if err := c.tx.Create(task).Error; err != nil {
+		return "", fmt.Errorf("failed to create copy of '%s': %w", src.ID, err)
+	}
+	c.result.Tasks[src.ID] = task.ID
+
+	if err := c.copyLinks(src.ID, task.ID); err != nil {
+		return "", err
+	}
+
+	if subtasks {
+		var children []models.Task
+		if err := c.tx.Where("parent_id = ?", src.ID).Order("created_at ASC, id ASC").Find(&children).Error; err != nil {
+			return "", err
+		}
+		for _, child := range children {
+			if _, err := c.cloneTask(child, task.ID, "", true); err != nil {
+				return "", err
+			}
+		}
+	}
+	return task.ID, nil
+}
+
+// copyLinks copies custom fields, skill/agent links and (optionally) gate links
+func (c *cloner) copyLinks(srcID, dstID string) error {
+	var fields []models.TaskFieldValue
+	if err := c.tx.Where("task_id = ?", srcID).Find(&fields).Error; err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if err := c.tx.Create(&models.TaskFieldValue{TaskID: dstID, FieldID: f.FieldID, Value: f.Value}).Error; err != nil {
+			return fmt.Errorf("failed to copy custom fields: %w", err)
+		}
+	}
+
+	var skills []models.TaskSkillLink
+	if err := c.tx.Where("task_id = ?", srcID).Find(&skills).Error; err != nil {
+		return err
+	}
+	for _, s := range skills {
+		if err := c.tx.Create(&models.TaskSkillLink{TaskID: dstID, SkillID: s.SkillID}).Error; err != nil {
+			return fmt.Errorf("failed to copy skill links: %w", err)
+		}
+	}
+
+	var agents []models.TaskAgentLink
+	if err := c.tx.Where("task_id = ?", srcID).Find(&agents).Error; err != nil {
+		return err
+	}
+	for _, a := range agents {
+		if err := c.tx.Create(&models.TaskAgentLink{TaskID: dstID, AgentID: a.AgentID, IsPrimary: a.IsPrimary}).Error; err != nil {
+			return fmt.Errorf("failed to copy agent links: %w", err)
+		}
+	}
+
+	if !c.withGates {
+		return nil
+	}
+	var gates []models.GateTaskLink
+	if err := c.tx.Where("task_id = ?", srcID).Find(&gates).Error; err != nil {
+		return err
+	}
+	for _, g := range gates {
+		if err := c.tx.Create(&models.GateTaskLink{GateID: g.GateID, TaskID: dstID, Status: models.GateLinkPending}).Error; err != nil {
+			return fmt.Errorf("failed to copy gate links: %w", err)
+		}
+		c.result.GateLinks++
+	}
+	return nil
+}
+
+// copyDependencies recreates dependencies whose both ends were cloned
+func (c *cloner) copyDependencies() error {
+	if len(c.result.Tasks) < 2 {
+		return nil
+	}
+	ids := make([]string, 0, len(c.result.Tasks))
+	for id := range c.result.Tasks {
+		ids = append(ids, id)
+	}
+	var deps []models.Dependency
+	if err := c.tx.Where("parent_id IN ? AND child_id IN ?", ids, ids).Find(&deps).Error; err != nil {
+		return err
+	}
+	for _, d := range deps {
+		dep := models.Dependency{ParentID: c.result.Tasks[d.ParentID], ChildID: c.result.Tasks[d.ChildID], Type: d.Type}
+		if err := c.tx.Create(&dep).Error; err != nil {
+			return fmt.Errorf("failed to copy dependencies: %w", err)
+		}
+		c.result.Deps++
+	}
+	return nil
+}
+
+// cloneTaskTree clones src under parentID in a single transaction
+func cloneTaskTree(database *gorm.DB, src models.Task, parentID, title string, withSubtasks, withGates bool) (cloneResult, error) {
+	c := &cloner{withGates: withGates, result: cloneResult{Tasks: make(map[string]string)}}
+	err := database.Transaction(func(tx *gorm.DB) error {
+		c.tx = tx
+		root, err := c.cloneTask(src, parentID, title, withSubtasks)
+		if err != nil {
+			return err
+		}
+		c.result.Root = root
+		return c.copyDependencies()
+	})
+	return c.result, err
+}
+
+func runClone(cmd *cobra.Command, args []string) error {
+	src, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot clone task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	database := db.GetDB()
+	parentID := src.ParentID
+	if cloneInto != "" {
+		parent, err := db.GetTaskByID(cloneInto)
+		if err != nil {
+			return codedErrorf(ErrCodeNotFound, "cannot clone task: parent task '%s' not found (use 'gur list' to see available tasks)", cloneInto)
+		}
+		if parent.IsClosed() {
+			return fmt.Errorf("cannot clone task: parent task '%s' is closed (reopen it first with 'gur reopen %s')", parent.ID, parent.ID)
+		}
+		if cloneWithSubtasks && (parent.ID == src.ID || isDescendant(database, parent.ID, src.ID)) {
+			return fmt.Errorf("cannot clone task '%s' with subtasks into its own subtree", src.ID)
+		}
+		parentID = parent.ID
+	}
+
+	result, err := cloneTaskTree(database, *src, parentID, cloneTitle, cloneWithSubtasks, cloneWithGates)
+	if err != nil {
+		return fmt.Errorf("failed to clone task '%s': %w", src.ID, err)
+	}
+	for _, id := range result.Tasks {
+		noteAffected(id)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "source": src.ID, "clone": result})
+		return nil
+	}
+	fmt.Printf("Cloned: %s -> %s", src.ID, result.Root)
+	if len(result.Tasks) > 1 {
+		fmt.Printf(" (%d tasks)", len(result.Tasks))
+	}
+	fmt.Println()
+	if result.GateLinks > 0 {
+		fmt.Printf("Linked %d gate(s), reset to pending\n", result.GateLinks)
+	}
+	if result.Deps > 0 {
+		fmt.Printf("Copied %d dependency(ies) between cloned tasks\n", result.Deps)
+	}
+	return nil
+}
+
+// isDescendant reports whether id is in the subtask tree under ancestorID
+func isDescendant(database *gorm.DB, id, ancestorID string) bool {
+	seen := make(map[string]bool)
+	for id != "" && !seen[id] {
+		seen[id] = true
+		var task models.Task
+		if err := database.Select("id", "parent_id").Where("id = ?", id).First(&task).Error; err != nil {
+			return false
+		}
+		if task.ParentID == ancestorID {
+			return true
+		}
+		id = task.ParentID
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestCloneTaskTree(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	root := models.Task{ID: "gur-c1000000", Title: "Release checklist", Status: models.StatusClosed, Priority: models.PriorityHigh, Labels: models.StringSlice{"release"}, Notes: "old notes"}
+	step1 := models.Task{ID: "gur-c1000000.1", ParentID: root.ID, Title: "Tag", Status: models.StatusClosed}
+	step2 := models.Task{ID: "gur-c1000000.2", ParentID: root.ID, Title: "Publish", Status: models.StatusOpen}
+	for _, task := range []*models.Task{&root, &step1, &step2} {
+		database.Create(task)
+	}
+	database.Create(&models.Dependency{ParentID: step1.ID, ChildID: step2.ID, Type: models.DepTypeBlocks})
+	database.Create(&models.Gate{ID: "gate-c1000000", Title: "Smoke"})
+	database.Create(&models.GateTaskLink{GateID: "gate-c1000000", TaskID: step2.ID, Status: models.GateLinkPassed, VerifiedBy: "alice"})
+
+	result, err := cloneTaskTree(database, root, "", "Release 1.5", true, true)
+	if err != nil {
+		t.Fatalf("cloneTaskTree: %v", err)
+	}
+	if len(result.Tasks) != 3 || result.GateLinks != 1 || result.Deps != 1 {
+		t.Fatalf("result = %+v, want 3 tasks, 1 gate link, 1 dependency", result)
+	}
+
+	var dup models.Task
+	database.First(&dup, "id = ?", result.Root)
+	if dup.Title != "Release 1.5" || dup.Status != models.StatusOpen || dup.Priority != models.PriorityHigh || dup.Notes != "" {
+		t.Errorf("root copy = %+v", dup)
+	}
+	if len(dup.Labels) != 1 || dup.Labels[0] != "release" {
+		t.Errorf("labels = %v", dup.Labels)
+	}
+
+	newStep2 := result.Tasks[step2.ID]
+	if newStep2 != result.Root+".2" {
+		t.Errorf("step 2 copy ID = %s, want %s.2", newStep2, result.Root)
+	}
+	var link models.GateTaskLink
+	if err := database.Where("task_id = ?", newStep2).First(&link).Error; err != nil {
+		t.Fatalf("gate link not copied: %v", err)
+	}
+	if link.Status != models.GateLinkPending || link.VerifiedBy != "" {
+		t.Errorf("gate link = %+v, want pending", link)
+	}
+	var dep models.Dependency
+	if err := database.Where("parent_id = ? AND child_id = ?", result.Tasks[step1.ID], newStep2).First(&dep).Error; err != nil {
+		t.Errorf("dependency not copied: %v", err)
+	}
+
+	// A plain clone of a subtask stays under the same parent
+	single, err := cloneTaskTree(database, step1, step1.ParentID, "", false, false)
+	if err != nil {
+		t.Fatalf("clone subtask: %v", err)
+	}
+	if single.Root != root.ID+".3" || len(single.Tasks) != 1 {
+		t.Errorf("subtask clone = %+v", single)
+	}
+	if !isDescendant(database, single.Root, root.ID) || isDescendant(database, root.ID, single.Root) {
+		t.Error("isDescendant gave the wrong answer")
+	}
+}
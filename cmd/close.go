@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -14,31 +15,57 @@ import (
 )
 
 var (
-	closeReason string
-	closeForce  bool
+	closeReason   string
+	closeForce    bool
+	closeAs       string
+	closeApproval string
 )
 
 var closeCmd = &cobra.Command{
 	Use:   "close <id>",
 	Short: "Close a task",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runClose,
+	Long: `Close a task with a reason.
+
+--as records why the task was closed: completed (the default), wontfix,
+duplicate, invalid or superseded. On sync, completed maps to GitHub's
+"completed" state reason and the rest to "not planned". Filter with
+'gur list --resolution' and see totals in 'gur stats'.
+
+Examples:
+  gur close gur-abc123 -r "Shipped in v1.4"
+  gur close gur-abc123 --as wontfix -r "Out of scope"
+  gur close gur-abc123 --as duplicate -r "Same as gur-def456"
+
+--force bypasses gates after typing "yes". Scripts and agents can't confirm,
+so they need a token from a human: 'gur approve close <id>', then
+  gur close gur-abc123 -r "Hotfix" --force --approval <token>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClose,
 }
 
 func init() {
 	rootCmd.AddCommand(closeCmd)
 	closeCmd.Flags().StringVarP(&closeReason, "reason", "r", "", "Reason for closing")
 	closeCmd.Flags().BoolVarP(&closeForce, "force", "f", false, "Force close")
+	closeCmd.Flags().StringVar(&closeApproval, "approval", "", "Approval token from 'gur approve close' (for --force without a terminal)")
+	markSecretFlags(closeCmd, "approval")
+	closeCmd.Flags().StringVar(&closeAs, "as", models.ResolutionCompleted, "Resolution: "+strings.Join(models.Resolutions, "/"))
 	closeCmd.MarkFlagRequired("reason")
 }
 
 func runClose(cmd *cobra.Command, args []string) error {
+	if err := models.ValidateResolution(closeAs); err != nil {
+		return err
+	}
+	if closeApproval != "" && !closeForce {
+		return fmt.Errorf("--approval is only used with --force")
+	}
 	database := db.GetDB()
 
 	// First, find the task
 	task, err := db.GetTaskByID(args[0])
 	if err != nil {
-		return fmt.Errorf("cannot close task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+		return codedErrorf(ErrCodeNotFound, "cannot close task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
 	}
 
 	if task.IsClosed() {
@@ -48,6 +75,7 @@ func runClose(cmd *cobra.Command, args []string) error {
 
 	// Collect all gate check failures for force confirmation
 	var gateCheckErr error
+	var approval *approvalClaims
 
 	if !closeForce {
 		// Check for open blockers
@@ -59,7 +87,7 @@ func runClose(cmd *cobra.Command, args []string) error {
 			Count(&blockerCount)
 
 		if blockerCount > 0 {
-			return fmt.Errorf("cannot close task '%s': blocked by %d open task(s) (use 'gur show %s' to see blockers, or --force to override)",
+			return codedErrorf(ErrCodeBlocked, "cannot close task '%s': blocked by %d open task(s) (use 'gur show %s' to see blockers, or --force to override)",
 				task.ID, blockerCount, task.ID)
 		}
 
@@ -70,7 +98,7 @@ func runClose(cmd *cobra.Command, args []string) error {
 			Count(&openSubtasks)
 
 		if openSubtasks > 0 {
-			return fmt.Errorf("cannot close task '%s': has %d open subtask(s) (close subtasks first, or use --force to override)",
+			return codedErrorf(ErrCodeBlocked, "cannot close task '%s': has %d open subtask(s) (close subtasks first, or use --force to override)",
 				task.ID, openSubtasks)
 		}
 
@@ -83,10 +111,17 @@ func runClose(cmd *cobra.Command, args []string) error {
 		// First check what we're bypassing
 		gateCheckErr = CheckGatesBeforeClose(task.ID)
 
-		if gateCheckErr != nil {
+		if gateCheckErr != nil && closeApproval != "" {
+			// A human approved this close ahead of time
+			approval, err = verifyCloseApproval(database, closeApproval, task.ID, time.Now())
+			if err != nil {
+				return fmt.Errorf("cannot force close task '%s': %w", task.ID, err)
+			}
+			closeReason = fmt.Sprintf("%s%s (approved by %s)", models.ForceClosePrefix, closeReason, approval.By)
+		} else if gateCheckErr != nil {
 			// Require interactive terminal
 			if !term.IsTerminal(int(os.Stdin.Fd())) {
-				return fmt.Errorf("--force requires interactive confirmation.\nCannot bypass gates from non-interactive terminal (e.g., scripts or AI agents).\n\n%s", gateCheckErr)
+				return withErrorCode(errorCodeOf(gateCheckErr), fmt.Errorf("--force requires interactive confirmation.\nCannot bypass gates from non-interactive terminal (e.g., scripts or AI agents).\nAsk a human to run 'gur approve close %s' and pass the token with --approval.\n\n%s", task.ID, gateCheckErr))
 			}
 
 			fmt.Println("WARNING: You are bypassing gate requirements!")
@@ -109,22 +144,33 @@ func runClose(cmd *cobra.Command, args []string) error {
 			fmt.Println("Force closing task...")
 
 			// Record that this was a force close
-			closeReason = "[FORCE CLOSED] " + closeReason
+			closeReason = models.ForceClosePrefix + closeReason
 		}
 	}
 
 	// Record history and close
 	models.RecordChange(database, task.ID, "status", task.Status, models.StatusClosed, "user")
 	models.RecordChange(database, task.ID, "close_reason", "", closeReason, "user")
-	task.Close(closeReason)
+	models.RecordChange(database, task.ID, "resolution", "", closeAs, "user")
+	task.CloseAs(closeAs, closeReason)
 	if err := database.Save(&task).Error; err != nil {
 		return fmt.Errorf("failed to close task '%s': database error: %w", task.ID, err)
 	}
+	syncParentChecklist(database, task, true, "user")
+	if approval != nil {
+		if err := consumeCloseApproval(database, task.ID); err != nil {
+			return fmt.Errorf("failed to mark approval as used: database error: %w", err)
+		}
+	}
 
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"success": true, "task": task, "forced": closeForce && gateCheckErr != nil})
+		result := map[string]interface{}{"success": true, "task": task, "forced": closeForce && gateCheckErr != nil}
+		if approval != nil {
+			result["approved_by"] = approval.By
+		}
+		OutputJSON(result)
 	} else {
-		fmt.Printf("Closed: %s\n", task.ID)
+		fmt.Printf("Closed: %s (%s)\n", task.ID, task.Resolution)
 	}
-	return nil
+	return fireHook(hookOnClose, hookPayload{Task: task})
 }
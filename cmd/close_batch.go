@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var closeBatchCmd = &cobra.Command{
+	Use:   "close-batch",
+	Short: "Close every task matching a filter, running automated gates first",
+	Long: `Close every open task matching --where, one at a time, with the same checks
+as 'gur close': a task with open blockers, open subtasks or unverified gates
+is skipped and reported, never forced.
+
+--where takes key=value or key!=value conditions; repeat it to require
+several. Keys are status, priority, type, assignee, label, release and
+parent; any other key is matched against the custom field of that name.
+assignee=me matches the current actor, and a label glob like label=area/*
+matches a whole label namespace.
+
+With --run-gates, each task's automated gates (those with a command, see
+'gur gate configure') that aren't satisfied yet are run first and their
+result recorded as with 'gur gate run'. A task closes only if every linked
+gate is then satisfied. Subtasks are closed before their parents.
+
+Examples:
+  gur close-batch --where label=chore --run-gates -r "Cleanup"
+  gur close-batch --where label=chore --where priority=4 --dry-run
+  gur close-batch --where release=v1.2 --run-gates --by ci -r "Released"`,
+	Args: cobra.NoArgs,
+	RunE: runCloseBatch,
+}
+
+var (
+	closeBatchWhere    []string
+	closeBatchRunGates bool
+	closeBatchReason   string
+	closeBatchAs       string
+	closeBatchBy       string
+	closeBatchDryRun   bool
+)
+
+func init() {
+	rootCmd.AddCommand(closeBatchCmd)
+	closeBatchCmd.Flags().StringArrayVar(&closeBatchWhere, "where", nil, "Condition key=value (repeatable; all must match)")
+	closeBatchCmd.Flags().BoolVar(&closeBatchRunGates, "run-gates", false, "Run unsatisfied automated gates before closing")
+	closeBatchCmd.Flags().StringVarP(&closeBatchReason, "reason", "r", "Closed in batch", "Reason for closing")
+	closeBatchCmd.Flags().StringVar(&closeBatchAs, "as", models.ResolutionCompleted, "Resolution: "+strings.Join(models.Resolutions, "/"))
+	closeBatchCmd.Flags().StringVar(&closeBatchBy, "by", "human", "Who ran the gates (human/agent/ci/name)")
+	closeBatchCmd.Flags().BoolVar(&closeBatchDryRun, "dry-run", false, "Show the matching tasks and gates that would run")
+	closeBatchCmd.MarkFlagRequired("where")
+}
+
+// batchTaskColumns maps --where keys to task columns
+var batchTaskColumns = map[string]string{
+	"status":   "status",
+	"type":     "type",
+	"assignee": "assignee",
+	"release":  "release",
+	"parent":   "parent_id",
+}
+
+// whereBatchConditions applies key=value and key!=value conditions to a
+// task query. assignee=me matches the current actor (see 'gur events').
+func whereBatchConditions(database, query *gorm.DB, conditions []string) (*gorm.DB, error) {
+	var fields []string
+	for _, cond := range conditions {
+		key, value, negate := strings.Cut(cond, "!=")
+		ok := negate
+		if !negate {
+			key, value, ok = strings.Cut(cond, "=")
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid condition '%s': expected key=value or key!=value", cond)
+		}
+		op := " = ?"
+		if negate {
+			op = " != ?"
+		}
+		switch key {
+		case "priority":
+			p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(value), "P"))
+			if err != nil || p < 0 || p > 4 {
+				return nil, fmt.Errorf("invalid condition '%s': priority must be 0-4", cond)
+			}
+			query = query.Where("priority"+op, p)
+		case "label":
+			cond, args, err := labelCondition(database, value)
+			if err != nil {
+				return nil, err
+			}
+			if negate {
+				cond = "NOT " + cond
+			}
+			query = query.Where(cond, args...)
+		default:
+			if column, ok := batchTaskColumns[key]; ok {
+				if key == "assignee" && value == "me" {
+					value = eventActor()
+				}
+				query = query.Where(column+op, value)
+			} else if negate {
+				return nil, fmt.Errorf("invalid condition '%s': custom fields only support key=value", cond)
+			} else {
+				fields = append(fields, key+"="+value)
+			}
+		}
+	}
+	if len(fields) > 0 {
+		return whereFieldEquals(database, query, fields)
+	}
+	return query, nil
+}
+
+// batchGateRun is an automated gate run during a batch close
+type batchGateRun struct {
+	GateID string `json:"gate_id"`
+	TaskID string `json:"task_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// batchSkip is a matching task that was left open, and why
+type batchSkip struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// closeBatchResult reports what a batch close did
+type closeBatchResult struct {
+	Closed  []string       `json:"closed"`
+	Skipped []batchSkip    `json:"skipped"`
+	Gates   []batchGateRun `json:"gates"`
+}
+
+// batchBlockReason explains why a task can't close yet because of other
+// tasks, or returns "" if nothing blocks it
+func batchBlockReason(database *gorm.DB, task *models.Task) string {
+	var blockers int64
+	database.Model(&models.Dependency{}).
+		Joins("JOIN tasks ON tasks.id = dependencies.parent_id").
+		Where("dependencies.child_id = ? AND dependencies.type = ? AND tasks.status != ?",
+			task.ID, models.DepTypeBlocks, models.StatusClosed).
+		Count(&blockers)
+	if blockers > 0 {
+		return fmt.Sprintf("blocked by %d open task(s)", blockers)
+	}
+	var subtasks int64
+	database.Model(&models.Task{}).Where("parent_id = ? AND status != ?", task.ID, models.StatusClosed).Count(&subtasks)
+	if subtasks > 0 {
+		return fmt.Sprintf("has %d open subtask(s)", subtasks)
+	}
+	return ""
+}
+
+// runBatchGates runs the task's unsatisfied automated gates and records
+// their results
+func runBatchGates(database *gorm.DB, task *models.Task, root string, dryRun bool) ([]batchGateRun, error) {
+	links, err := GetGateLinksForTask(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	var runs []batchGateRun
+	for _, info := range failingGateLinks(links, time.Now()) {
+		if info.Gate.Command == "" {
+			continue
+		}
+		run := batchGateRun{GateID: info.Gate.ID, TaskID: task.ID}
+		if dryRun {
+			run.Status = "would run"
+			runs = append(runs, run)
+			continue
+		}
+		gate, err := db.GetGateByID(info.Gate.ID)
+		if err != nil {
+			return runs, err
+		}
+		if !IsJSONOutput() {
+			fmt.Printf("Running %s (%s) for %s: %s\n", gate.ID, gate.RunnerString(), task.ID, gate.Command)
+		}
+		result, err := executeGateCommand(gate, root)
+		if err != nil {
+			run.Status, run.Error = models.GateLinkFailed, err.Error()
+			runs = append(runs, run)
+			continue
+		}
+		status, summary := result.status(gate)
+		link := info.Link
+		saved, err := saveGateResult(database, gate, &link, status, closeBatchBy, summary+" (close-batch)", models.GateRun{
+			Output:   result.Output,
+			Duration: int(result.Duration.Milliseconds()),
+			Runner:   gate.RunnerString(),
+		})
+		if errorCodeOf(err) == ErrCodeUntrusted {
+			run.Status, run.Error = "untrusted", err.Error()
+			runs = append(runs, run)
+			continue
+		}
+		if err != nil {
+			return runs, err
+		}
+		run.Status = saved.Result
+		runs = append(runs, run)
+		if saved.Result == models.GateLinkFailed {
+			if err := fireHook(hookOnGateFail, hookPayload{Task: task, Gate: gate, Link: &link, Run: saved}); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+	return runs, nil
+}
+
+// closeBatch closes the tasks in order, deepest subtasks first. Tasks held
+// up by another task in the batch are retried once that task closes.
+func closeBatch(database *gorm.DB, tasks []models.Task, root string, runGates, dryRun bool) (*closeBatchResult, error) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return models.GetDepth(tasks[i].ID) > models.GetDepth(tasks[j].ID)
+	})
+	result := &closeBatchResult{Closed: []string{}, Skipped: []batchSkip{}, Gates: []batchGateRun{}}
+
+	pending := tasks
+	for len(pending) > 0 {
+		var waiting []models.Task
+		var waitReasons []string
+		progressed := false
+		for i := range pending {
+			task := &pending[i]
+			if reason := batchBlockReason(database, task); reason != "" {
+				waiting = append(waiting, *task)
+				waitReasons = append(waitReasons, reason)
+				continue
+			}
+
+			if runGates {
+				runs, err := runBatchGates(database, task, root, dryRun)
+				result.Gates = append(result.Gates, runs...)
+				if err != nil {
+					return result, err
+				}
+			}
+			if dryRun {
+				result.Closed = append(result.Closed, task.ID)
+				continue
+			}
+			if err := CheckGatesBeforeClose(task.ID); err != nil {
+				reason, _, _ := strings.Cut(err.Error(), "\n")
+				reason = strings.TrimSuffix(strings.TrimPrefix(reason, "Cannot close task: "), ":")
+				result.Skipped = append(result.Skipped, batchSkip{ID: task.ID, Title: task.Title, Reason: reason})
+				continue
+			}
+
+			models.RecordChange(database, task.ID, "status", task.Status, models.StatusClosed, "user")
+			models.RecordChange(database, task.ID, "close_reason", "", closeBatchReason, "user")
+			models.RecordChange(database, task.ID, "resolution", "", closeBatchAs, "user")
+			task.CloseAs(closeBatchAs, closeBatchReason)
+			if err := database.Save(task).Error; err != nil {
+				return result, fmt.Errorf("failed to close task '%s': database error: %w", task.ID, err)
+			}
+			syncParentChecklist(database, task, true, "user")
+			noteAffected(task.ID)
+			result.Closed = append(result.Closed, task.ID)
+			progressed = true
+			if err := fireHook(hookOnClose, hookPayload{Task: task}); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+		// A dry run closes nothing, so nothing waiting would be freed
+		if !progressed || dryRun {
+			for i, t := range waiting {
+				result.Skipped = append(result.Skipped, batchSkip{ID: t.ID, Title: t.Title, Reason: waitReasons[i]})
+			}
+			break
+		}
+		pending = waiting
+	}
+	return result, nil
+}
+
+func runCloseBatch(cmd *cobra.Command, args []string) error {
+	if err := models.ValidateResolution(closeBatchAs); err != nil {
+		return err
+	}
+	database := db.GetDB()
+	query := database.Model(&models.Task{}).Where("status NOT IN ?", []string{models.StatusClosed, models.StatusArchived})
+	query, err := whereBatchConditions(database, query, closeBatchWhere)
+	if err != nil {
+		return err
+	}
+	var tasks []models.Task
+	if err := query.Order("id ASC").Find(&tasks).Error; err != nil {
+		return fmt.Errorf("failed to find tasks: database error: %w", err)
+	}
+
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		if root, err = os.Getwd(); err != nil {
+			return err
+		}
+	}
+
+	result, err := closeBatch(database, tasks, root, closeBatchRunGates, closeBatchDryRun)
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"success": len(result.Skipped) == 0,
+			"dry_run": closeBatchDryRun,
+			"matched": len(tasks),
+			"closed":  result.Closed,
+			"skipped": result.Skipped,
+			"gates":   result.Gates,
+		})
+		return nil
+	}
+	if IsQuietOutput() {
+		printQuietIDs(result.Closed...)
+		return nil
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No open tasks match")
+		return nil
+	}
+
+	if closeBatchDryRun {
+		for _, id := range result.Closed {
+			fmt.Printf("Would close: %s\n", id)
+		}
+		for _, g := range result.Gates {
+			fmt.Printf("Would run: %s for %s\n", g.GateID, g.TaskID)
+		}
+		for _, s := range result.Skipped {
+			fmt.Printf("Would skip: %s - %s: %s\n", s.ID, s.Title, s.Reason)
+		}
+		return nil
+	}
+	c := colors()
+	for _, g := range result.Gates {
+		line := fmt.Sprintf("Gate %s for %s: %s", g.GateID, g.TaskID, c.Result(g.Status))
+		if g.Error != "" {
+			line += " (" + g.Error + ")"
+		}
+		fmt.Println(line)
+	}
+	for _, id := range result.Closed {
+		fmt.Printf("Closed: %s (%s)\n", id, closeBatchAs)
+	}
+	for _, s := range result.Skipped {
+		fmt.Printf("Skipped: %s - %s: %s\n", s.ID, s.Title, s.Reason)
+	}
+	fmt.Printf("\nClosed %d of %d matching task(s)", len(result.Closed), len(tasks))
+	if len(result.Skipped) > 0 {
+		fmt.Printf(", %d skipped", len(result.Skipped))
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestCloseBatch(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	chore := models.StringSlice{"chore"}
+	database.Create(&models.Task{ID: "gur-bat00001", Title: "Passes", Status: models.StatusOpen, Labels: chore})
+	database.Create(&models.Task{ID: "gur-bat00002", Title: "Fails", Status: models.StatusOpen, Labels: chore})
+	database.Create(&models.Task{ID: "gur-bat00003", Title: "Parent", Status: models.StatusOpen, Labels: chore})
+	database.Create(&models.Task{ID: "gur-bat00003.1", ParentID: "gur-bat00003", Title: "Child", Status: models.StatusOpen, Labels: chore})
+	database.Create(&models.Task{ID: "gur-bat00004", Title: "Not a chore", Status: models.StatusOpen})
+
+	database.Create(&models.Gate{ID: "gate-bat1", Title: "Build", Command: "true"})
+	database.Create(&models.Gate{ID: "gate-bat2", Title: "Lint", Command: "exit 1"})
+	database.Create(&models.Gate{ID: "gate-bat3", Title: "Review"})
+	database.Create(&models.GateTaskLink{GateID: "gate-bat1", TaskID: "gur-bat00001", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-bat2", TaskID: "gur-bat00002", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-bat3", TaskID: "gur-bat00003", Status: models.GateLinkPassed})
+	database.Create(&models.GateTaskLink{GateID: "gate-bat3", TaskID: "gur-bat00003.1", Status: models.GateLinkPassed})
+
+	query, err := whereBatchConditions(database, database.Model(&models.Task{}), []string{"label=chore"})
+	if err != nil {
+		t.Fatalf("whereBatchConditions() error: %v", err)
+	}
+	var tasks []models.Task
+	query.Order("id ASC").Find(&tasks)
+	if len(tasks) != 4 {
+		t.Fatalf("matched %d tasks, want the 4 chores", len(tasks))
+	}
+
+	closeBatchBy, closeBatchReason, closeBatchAs = "ci", "Cleanup", models.ResolutionCompleted
+	result, err := closeBatch(database, tasks, t.TempDir(), true, false)
+	if err != nil {
+		t.Fatalf("closeBatch() error: %v", err)
+	}
+	if want := []string{"gur-bat00003.1", "gur-bat00001", "gur-bat00003"}; !reflect.DeepEqual(result.Closed, want) {
+		t.Errorf("closed = %v, want %v (subtask before parent)", result.Closed, want)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].ID != "gur-bat00002" {
+		t.Errorf("skipped = %+v, want the task whose gate failed", result.Skipped)
+	}
+	if len(result.Gates) != 2 {
+		t.Errorf("gates = %+v, want the two automated gates run", result.Gates)
+	}
+
+	var link models.GateTaskLink
+	database.Where("gate_id = ? AND task_id = ?", "gate-bat1", "gur-bat00001").First(&link)
+	if link.Status != models.GateLinkPassed || link.VerifiedBy != "ci" {
+		t.Errorf("link = %+v, want passed by ci", link)
+	}
+	if task, _ := db.GetTaskByID("gur-bat00002"); task.IsClosed() {
+		t.Error("task with a failing gate was closed")
+	}
+
+	if _, err := whereBatchConditions(database, database.Model(&models.Task{}), []string{"priority=9"}); err == nil {
+		t.Error("whereBatchConditions accepted priority 9")
+	}
+}
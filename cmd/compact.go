@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -16,6 +15,14 @@ var (
 	compactBefore  string
 	compactAll     bool
 	compactSummary bool
+	compactAuto    bool
+	compactLLM     bool
+
+	compactTargetBytes int64
+
+	summaryLLM       bool
+	summaryDueWithin int
+	summaryStale     string
 )
 
 var compactCmd = &cobra.Command{
@@ -30,22 +37,40 @@ Examples:
   gur compact gur-abc123          # Compact a specific task
   gur compact --all               # Compact all closed tasks
   gur compact --before 7d         # Compact tasks closed more than 7 days ago
-  gur compact --summary           # Show what would be compacted (dry run)`,
+  gur compact --dry-run           # Show what would be compacted
+  gur compact --auto --target-bytes 200000
+                                  # Compact oldest closed tasks until under target
+  gur compact stats               # Show context weight by status and age
+  gur compact --all --llm         # Use the configured summarizer for summaries`,
 	RunE: runCompact,
 }
 
 var summaryCmd = &cobra.Command{
-	Use:   "summary",
-	Short: "Generate a session summary of recent task activity",
-	RunE:  runSummary,
+	Use:         "summary",
+	Short:       "Generate a session summary of recent task activity",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Generate a session summary of recent task activity.
+
+With --llm, the configured summarizer (see 'gur config summarizer') also
+writes a short narrative of the last 24 hours and what to do next.
+
+With --stale, in-progress tasks without activity for that long are listed
+so abandoned work gets noticed (see 'gur stale').`,
+	RunE: runSummary,
 }
 
 func init() {
 	rootCmd.AddCommand(compactCmd)
 	rootCmd.AddCommand(summaryCmd)
+	summaryCmd.Flags().BoolVar(&summaryLLM, "llm", false, "Add a narrative written by the configured summarizer")
+	summaryCmd.Flags().IntVar(&summaryDueWithin, "due-within", 7, "List open tasks due within this many days")
+	summaryCmd.Flags().StringVar(&summaryStale, "stale", "", "List in-progress tasks idle for this long (e.g., 14d)")
 	compactCmd.Flags().StringVar(&compactBefore, "before", "", "Compact tasks closed before duration (e.g., 7d, 30d)")
 	compactCmd.Flags().BoolVar(&compactAll, "all", false, "Compact all closed tasks")
 	compactCmd.Flags().BoolVar(&compactSummary, "dry-run", false, "Show what would be compacted without making changes")
+	compactCmd.Flags().BoolVar(&compactAuto, "auto", false, "Compact oldest closed tasks until text size is under --target-bytes")
+	compactCmd.Flags().BoolVar(&compactLLM, "llm", false, "Summarize description and notes with the configured LLM before clearing them")
+	compactCmd.Flags().Int64Var(&compactTargetBytes, "target-bytes", defaultTargetBytes, "Target total task text size for --auto")
 }
 
 func runCompact(cmd *cobra.Command, args []string) error {
@@ -56,7 +81,7 @@ func runCompact(cmd *cobra.Command, args []string) error {
 		taskID := args[0]
 		var task models.Task
 		if err := database.First(&task, "id = ?", taskID).Error; err != nil {
-			return fmt.Errorf("cannot compact task: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+			return codedErrorf(ErrCodeNotFound, "cannot compact task: task '%s' not found (use 'gur list' to see available tasks)", taskID)
 		}
 		if task.Status != models.StatusClosed && task.Status != models.StatusArchived {
 			return fmt.Errorf("cannot compact task '%s': only closed or archived tasks can be compacted (current status: %s)",
@@ -73,7 +98,16 @@ func runCompact(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 
-		task.Compact()
+		summary := ""
+		if compactLLM {
+			summaries, err := llmTaskSummaries([]models.Task{task})
+			if err != nil {
+				return err
+			}
+			summary = summaries[task.ID]
+		}
+
+		task.CompactWithSummary(summary)
 		if err := database.Save(&task).Error; err != nil {
 			return fmt.Errorf("failed to compact task '%s': database error: %w", taskID, err)
 		}
@@ -87,9 +121,16 @@ func runCompact(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if compactAuto {
+		if compactAll || compactBefore != "" {
+			return fmt.Errorf("--auto cannot be combined with --all or --before")
+		}
+		return runCompactAuto(database)
+	}
+
 	// Bulk compact
 	if !compactAll && compactBefore == "" {
-		return fmt.Errorf("missing argument: specify a task ID, use --all for all closed tasks, or --before <duration> (e.g., --before 7d)")
+		return fmt.Errorf("missing argument: specify a task ID, use --all for all closed tasks, --before <duration> (e.g., --before 7d), or --auto")
 	}
 
 	query := database.Model(&models.Task{}).
@@ -128,8 +169,31 @@ func runCompact(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Compact tasks in a transaction with batch updates
-	compactedCount := 0
+	var summaries map[string]string
+	if compactLLM {
+		var err error
+		if summaries, err = llmTaskSummaries(tasks); err != nil {
+			return err
+		}
+	}
+
+	compactedCount, err := compactTaskBatch(database, tasks, summaries)
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"compacted_count": compactedCount})
+		return nil
+	}
+	fmt.Printf("Compacted %d tasks\n", compactedCount)
+	return nil
+}
+
+// compactTaskBatch compacts tasks in a transaction using batched UPDATEs and
+// returns how many were compacted. summaries optionally overrides the
+// generated summary per task ID.
+func compactTaskBatch(database *gorm.DB, tasks []models.Task, summaries map[string]string) (int, error) {
 	err := database.Transaction(func(tx *gorm.DB) error {
 		// Process in batches for memory efficiency
 		const batchSize = 100
@@ -140,58 +204,38 @@ func runCompact(cmd *cobra.Command, args []string) error {
 			}
 			batch := tasks[i:end]
 
-			// Build batch update using CASE expression for summaries
-			ids := make([]string, len(batch))
-			summaries := make(map[string]string)
-			for j, task := range batch {
-				ids[j] = task.ID
-				// Generate summary
-				summary := task.Title
-				if task.CloseReason != "" {
-					summary += " | Closed: " + task.CloseReason
-				}
-				if task.Type != models.TypeTask {
-					summary = "[" + task.Type + "] " + summary
-				}
-				summaries[task.ID] = summary
-			}
-
-			// Build CASE expression for summary field
+			// Build CASE expression for summary field, using the same
+			// summary Task.Compact would generate. The rest of the UPDATE
+			// is left to gorm so it's quoted for the backend's dialect.
 			caseExpr := "CASE id"
-			args := make([]interface{}, 0, len(batch)*2+len(batch))
-			for _, id := range ids {
+			args := make([]interface{}, 0, len(batch)*2)
+			ids := make([]string, 0, len(batch))
+			for _, task := range batch {
+				task.CompactWithSummary(summaries[task.ID])
 				caseExpr += " WHEN ? THEN ?"
-				args = append(args, id, summaries[id])
+				args = append(args, task.ID, task.Summary)
+				ids = append(ids, task.ID)
 			}
 			caseExpr += " END"
 
-			// Add IDs for WHERE clause
-			for _, id := range ids {
-				args = append(args, id)
-			}
-
 			// Single UPDATE for entire batch
-			sql := fmt.Sprintf(`UPDATE tasks SET summary = %s, description = '', notes = '', compacted = true, updated_at = ? WHERE id IN (?%s)`,
-				caseExpr, strings.Repeat(",?", len(ids)-1))
-			args = append([]interface{}{time.Now()}, args...)
-
-			if err := tx.Exec(sql, args...).Error; err != nil {
+			err := tx.Model(&models.Task{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+				"summary":     gorm.Expr(caseExpr, args...),
+				"description": "",
+				"notes":       "",
+				"compacted":   true,
+				"updated_at":  time.Now(),
+			}).Error
+			if err != nil {
 				return err
 			}
 		}
-		compactedCount = len(tasks)
 		return nil
 	})
 	if err != nil {
-		return err
-	}
-
-	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"compacted_count": compactedCount})
-		return nil
+		return 0, err
 	}
-	fmt.Printf("Compacted %d tasks\n", compactedCount)
-	return nil
+	return len(tasks), nil
 }
 
 func runSummary(cmd *cobra.Command, args []string) error {
@@ -237,14 +281,40 @@ func runSummary(cmd *cobra.Command, args []string) error {
 		Limit(5).
 		Find(&highPriorityTasks)
 
+	// Get overdue and upcoming tasks
+	now := time.Now()
+	dueSoon, err := findTasksDueWithin(database, now, time.Duration(summaryDueWithin)*24*time.Hour)
+	if err != nil {
+		return fmt.Errorf("failed to read due tasks: database error: %w", err)
+	}
+
+	// Get abandoned in-progress tasks
+	var staleTasks []models.Task
+	if summaryStale != "" {
+		threshold, err := parseDuration(summaryStale)
+		if err != nil {
+			return err
+		}
+		if staleTasks, err = findStaleTasks(database, now.Add(-threshold)); err != nil {
+			return fmt.Errorf("failed to read stale tasks: database error: %w", err)
+		}
+	}
+
 	// Get compacted vs uncompacted - combined query
 	var compactedCount, uncompactedCount int64
 	database.Model(&models.Task{}).
 		Select("SUM(CASE WHEN compacted = true THEN 1 ELSE 0 END) as compacted, SUM(CASE WHEN compacted = false AND status IN (?, ?) THEN 1 ELSE 0 END) as uncompacted", models.StatusClosed, models.StatusArchived).
 		Row().Scan(&compactedCount, &uncompactedCount)
 
+	narrative := ""
+	if summaryLLM {
+		if narrative, err = llmSessionSummary(database, yesterday, highPriorityTasks); err != nil {
+			return fmt.Errorf("failed to generate session narrative: %w", err)
+		}
+	}
+
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{
+		result := map[string]interface{}{
 			"status_counts": map[string]int64{
 				"open":        openCount,
 				"in_progress": inProgressCount,
@@ -256,15 +326,26 @@ func runSummary(cmd *cobra.Command, args []string) error {
 				"closed":  recentlyClosed,
 			},
 			"high_priority_tasks": highPriorityTasks,
+			"due_soon":            dueSoon,
 			"compaction": map[string]int64{
 				"compacted":   compactedCount,
 				"uncompacted": uncompactedCount,
 			},
-		})
+		}
+		if summaryStale != "" {
+			result["stale"] = staleTasks
+		}
+		if summaryLLM {
+			result["narrative"] = narrative
+		}
+		OutputJSON(result)
 		return nil
 	}
 
 	fmt.Println("=== Session Summary ===")
+	if narrative != "" {
+		fmt.Printf("%s\n\n", narrative)
+	}
 	fmt.Printf("Task Status:\n")
 	fmt.Printf("  Open:        %d\n", openCount)
 	fmt.Printf("  In Progress: %d\n", inProgressCount)
@@ -282,6 +363,21 @@ func runSummary(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(dueSoon) > 0 {
+		fmt.Printf("\nDue in the Next %d Days:\n", summaryDueWithin)
+		for _, t := range dueSoon {
+			fmt.Printf("  [%s] P%d %s - %s%s\n", t.ID, t.Priority, t.Status, t.Title, dueAnnotation(t, now))
+		}
+	}
+
+	if len(staleTasks) > 0 {
+		fmt.Printf("\nStale In Progress (no activity in %s):\n", summaryStale)
+		for _, t := range staleTasks {
+			fmt.Printf("  [%s] P%d %s\n", t.ID, t.Priority, t.Title)
+		}
+		fmt.Printf("  Run 'gur stale --threshold %s --action ...' to handle them\n", summaryStale)
+	}
+
 	fmt.Printf("\nMemory:\n")
 	fmt.Printf("  Compacted:   %d tasks\n", compactedCount)
 	fmt.Printf("  Uncompacted: %d tasks (run 'gur compact --all' to free space)\n", uncompactedCount)
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// defaultTargetBytes is the text footprint --auto compacts down to by default
+const defaultTargetBytes = 200000
+
+// taskTextBytesExpr measures the text a task contributes to an agent's context
+const taskTextBytesExpr = "LENGTH(CAST(title AS BLOB)) + LENGTH(CAST(COALESCE(description, '') AS BLOB)) + " +
+	"LENGTH(CAST(COALESCE(notes, '') AS BLOB)) + LENGTH(CAST(COALESCE(summary, '') AS BLOB)) + " +
+	"LENGTH(CAST(COALESCE(close_reason, '') AS BLOB))"
+
+// ageBucket groups tasks by time since creation
+type ageBucket struct {
+	Label  string
+	MaxAge time.Duration // zero means unbounded
+}
+
+var contextAgeBuckets = []ageBucket{
+	{"< 1 day", 24 * time.Hour},
+	{"1-7 days", 7 * 24 * time.Hour},
+	{"7-30 days", 30 * 24 * time.Hour},
+	{"30-90 days", 90 * 24 * time.Hour},
+	{"> 90 days", 0},
+}
+
+// contextWeight is a task count and text footprint
+type contextWeight struct {
+	Tasks int64 `json:"tasks"`
+	Bytes int64 `json:"bytes"`
+}
+
+// contextStats breaks down the task text footprint
+type contextStats struct {
+	Total       contextWeight            `json:"total"`
+	Reclaimable contextWeight            `json:"reclaimable"` // Closed/archived tasks not yet compacted
+	ByStatus    map[string]contextWeight `json:"by_status"`
+	ByAge       map[string]contextWeight `json:"by_age"`
+}
+
+var compactStatsCmd = &cobra.Command{
+	Use:         "stats",
+	Short:       "Show context weight by status and age",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Show how much task text (title, description, notes, summary, and close
+reason) is stored, broken down by status and age, and how much could be
+reclaimed by compacting closed tasks.`,
+	Args: cobra.NoArgs,
+	RunE: runCompactStats,
+}
+
+func init() {
+	compactCmd.AddCommand(compactStatsCmd)
+}
+
+// collectContextStats measures the task text footprint
+func collectContextStats(database *gorm.DB, now time.Time) (contextStats, error) {
+	type row struct {
+		Status    string
+		Compacted bool
+		CreatedAt time.Time
+		Bytes     int64
+	}
+	var rows []row
+	if err := database.Model(&models.Task{}).
+		Select("status, compacted, created_at, " + taskTextBytesExpr + " AS bytes").
+		Scan(&rows).Error; err != nil {
+		return contextStats{}, err
+	}
+
+	stats := contextStats{
+		ByStatus: make(map[string]contextWeight),
+		ByAge:    make(map[string]contextWeight),
+	}
+	add := func(m map[string]contextWeight, key string, bytes int64) {
+		w := m[key]
+		w.Tasks++
+		w.Bytes += bytes
+		m[key] = w
+	}
+	for _, r := range rows {
+		stats.Total.Tasks++
+		stats.Total.Bytes += r.Bytes
+		add(stats.ByStatus, r.Status, r.Bytes)
+
+		age := now.Sub(r.CreatedAt)
+		for _, b := range contextAgeBuckets {
+			if b.MaxAge == 0 || age < b.MaxAge {
+				add(stats.ByAge, b.Label, r.Bytes)
+				break
+			}
+		}
+
+		if !r.Compacted && (r.Status == models.StatusClosed || r.Status == models.StatusArchived) {
+			stats.Reclaimable.Tasks++
+			stats.Reclaimable.Bytes += r.Bytes
+		}
+	}
+	return stats, nil
+}
+
+// taskTextBytes is the Go equivalent of taskTextBytesExpr
+func taskTextBytes(t models.Task) int64 {
+	return int64(len(t.Title) + len(t.Description) + len(t.Notes) + len(t.Summary) + len(t.CloseReason))
+}
+
+// selectAutoCompaction picks the oldest closed tasks whose compaction brings
+// the footprint under target. It returns the chosen tasks and the projected
+// footprint after compacting them.
+func selectAutoCompaction(candidates []models.Task, total, target int64) ([]models.Task, int64) {
+	var chosen []models.Task
+	for _, t := range candidates {
+		if total <= target {
+			break
+		}
+		compacted := t
+		compacted.Compact()
+		saved := taskTextBytes(t) - taskTextBytes(compacted)
+		if saved <= 0 {
+			continue
+		}
+		chosen = append(chosen, t)
+		total -= saved
+	}
+	return chosen, total
+}
+
+// runCompactAuto compacts oldest closed tasks until the footprint is under compactTargetBytes
+func runCompactAuto(database *gorm.DB) error {
+	var total int64
+	if err := database.Model(&models.Task{}).
+		Select("COALESCE(SUM(" + taskTextBytesExpr + "), 0)").
+		Row().Scan(&total); err != nil {
+		return fmt.Errorf("failed to measure context size: database error: %w", err)
+	}
+
+	var candidates []models.Task
+	if total > compactTargetBytes {
+		if err := database.
+			Where("status IN ?", []string{models.StatusClosed, models.StatusArchived}).
+			Where("compacted = ?", false).
+			Order("closed_at ASC, created_at ASC").
+			Find(&candidates).Error; err != nil {
+			return err
+		}
+	}
+	chosen, projected := selectAutoCompaction(candidates, total, compactTargetBytes)
+
+	compactedCount := 0
+	if !compactSummary && len(chosen) > 0 {
+		var summaries map[string]string
+		var err error
+		if compactLLM {
+			if summaries, err = llmTaskSummaries(chosen); err != nil {
+				return err
+			}
+		}
+		if compactedCount, err = compactTaskBatch(database, chosen, summaries); err != nil {
+			return err
+		}
+	}
+
+	if IsJSONOutput() {
+		ids := make([]string, len(chosen))
+		for i, t := range chosen {
+			ids[i] = t.ID
+		}
+		OutputJSON(map[string]interface{}{
+			"dry_run":         compactSummary,
+			"target_bytes":    compactTargetBytes,
+			"before_bytes":    total,
+			"after_bytes":     projected,
+			"under_target":    projected <= compactTargetBytes,
+			"compacted_count": compactedCount,
+			"task_ids":        ids,
+		})
+		return nil
+	}
+
+	if total <= compactTargetBytes {
+		fmt.Printf("Context size %d bytes is already under target %d bytes\n", total, compactTargetBytes)
+		return nil
+	}
+	if compactSummary {
+		for _, t := range chosen {
+			fmt.Printf("Would compact: %s - %s\n", t.ID, t.Title)
+		}
+		fmt.Printf("\nWould compact %d tasks: %d -> %d bytes (target %d)\n", len(chosen), total, projected, compactTargetBytes)
+	} else {
+		fmt.Printf("Compacted %d tasks: %d -> %d bytes (target %d)\n", compactedCount, total, projected, compactTargetBytes)
+	}
+	if projected > compactTargetBytes {
+		fmt.Println("Target not reached: remaining text belongs to open or already-compacted tasks")
+	}
+	return nil
+}
+
+func runCompactStats(cmd *cobra.Command, args []string) error {
+	stats, err := collectContextStats(db.GetDB(), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to measure context size: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(stats)
+		return nil
+	}
+
+	fmt.Printf("Context weight: %d bytes across %d tasks\n", stats.Total.Bytes, stats.Total.Tasks)
+	fmt.Printf("Reclaimable:    %d bytes in %d uncompacted closed tasks\n", stats.Reclaimable.Bytes, stats.Reclaimable.Tasks)
+
+	fmt.Println("\nBy status:")
+	statuses := make([]string, 0, len(stats.ByStatus))
+	for s := range stats.ByStatus {
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+	for _, s := range statuses {
+		w := stats.ByStatus[s]
+		fmt.Printf("  %-12s %5d tasks %10d bytes\n", s, w.Tasks, w.Bytes)
+	}
+
+	fmt.Println("\nBy age:")
+	for _, b := range contextAgeBuckets {
+		w := stats.ByAge[b.Label]
+		fmt.Printf("  %-12s %5d tasks %10d bytes\n", b.Label, w.Tasks, w.Bytes)
+	}
+	return nil
+}
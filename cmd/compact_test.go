@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -149,3 +150,88 @@ func TestCompactAlreadyCompacted(t *testing.T) {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestCompactTaskBatch(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	tasks := []models.Task{
+		{ID: "gur-batch001", Title: "First", Description: "long text", Status: models.StatusClosed, CloseReason: "done", ClosedAt: timePtr(time.Now())},
+		{ID: "gur-batch002", Title: "Second", Notes: "notes", Status: models.StatusClosed, Type: models.TypeBug, ClosedAt: timePtr(time.Now())},
+	}
+	for i := range tasks {
+		database.Create(&tasks[i])
+	}
+
+	count, err := compactTaskBatch(database, tasks, map[string]string{"gur-batch002": "Fixed the crash"})
+	if err != nil {
+		t.Fatalf("compactTaskBatch() error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("compactTaskBatch() = %d, want 2", count)
+	}
+
+	want := map[string]string{
+		"gur-batch001": "First | Closed: done",
+		"gur-batch002": "Fixed the crash",
+	}
+	for id, summary := range want {
+		var got models.Task
+		database.First(&got, "id = ?", id)
+		if !got.Compacted || got.Description != "" || got.Notes != "" {
+			t.Errorf("%s not compacted: %+v", id, got)
+		}
+		if got.Summary != summary {
+			t.Errorf("%s summary = %q, want %q", id, got.Summary, summary)
+		}
+	}
+}
+
+func TestSelectAutoCompaction(t *testing.T) {
+	long := strings.Repeat("x", 1000)
+	candidates := []models.Task{
+		{ID: "gur-old00001", Title: "Old", Description: long, Status: models.StatusClosed},
+		{ID: "gur-old00002", Title: "Older", Notes: long, Status: models.StatusClosed},
+		{ID: "gur-old00003", Title: "Newest", Description: long, Status: models.StatusClosed},
+	}
+
+	chosen, projected := selectAutoCompaction(candidates, 5000, 3500)
+	if len(chosen) != 2 || chosen[0].ID != "gur-old00001" || chosen[1].ID != "gur-old00002" {
+		t.Fatalf("selectAutoCompaction() chose %v, want the two oldest tasks", chosen)
+	}
+	if projected > 3500 {
+		t.Errorf("projected = %d, want <= 3500", projected)
+	}
+
+	if chosen, _ := selectAutoCompaction(candidates, 3000, 3500); len(chosen) != 0 {
+		t.Errorf("selectAutoCompaction() under target chose %d tasks, want 0", len(chosen))
+	}
+}
+
+func TestCollectContextStats(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	now := time.Now()
+	database.Create(&models.Task{ID: "gur-ctx00001", Title: "abcd", Description: "123456", Status: models.StatusOpen, CreatedAt: now.Add(-time.Hour)})
+	database.Create(&models.Task{ID: "gur-ctx00002", Title: "ef", Notes: "1234", Status: models.StatusClosed, CreatedAt: now.Add(-10 * 24 * time.Hour)})
+
+	stats, err := collectContextStats(database, now)
+	if err != nil {
+		t.Fatalf("collectContextStats() error: %v", err)
+	}
+	if stats.Total.Tasks != 2 || stats.Total.Bytes != 16 {
+		t.Errorf("total = %+v, want 2 tasks / 16 bytes", stats.Total)
+	}
+	if stats.Reclaimable.Bytes != 6 {
+		t.Errorf("reclaimable bytes = %d, want 6", stats.Reclaimable.Bytes)
+	}
+	if stats.ByStatus[models.StatusOpen].Bytes != 10 {
+		t.Errorf("open bytes = %d, want 10", stats.ByStatus[models.StatusOpen].Bytes)
+	}
+	if stats.ByAge["< 1 day"].Tasks != 1 || stats.ByAge["7-30 days"].Tasks != 1 {
+		t.Errorf("by age = %+v, want one task in '< 1 day' and one in '7-30 days'", stats.ByAge)
+	}
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -29,6 +31,27 @@ This command will prompt you for:
   - GitHub Personal Access Token (stored securely in system keyring)
   - Issue title prefix (default: "[Coding Agent]")
 
+For GitHub Enterprise Server, set the API URL with --base-url; push and pull
+then talk to that server instead of github.com. The upload URL is derived
+from it unless --upload-url is given. Use --base-url github.com to switch
+back.
+
+  gur config github --base-url https://github.mycorp.com/api/v3
+
+Push renders issue bodies from a Go template when one is set: either stored
+with --body-template <file> (--body-template default to remove it) or kept in
+.guardrails/issue_body.tmpl. Templates see .Task (all task fields, including
+.Task.Fields), .Gates, .Blockers, .Blocking, .Subtasks, .Parent, .Default
+(the built-in body) and .Generated (the footer), plus the join, upper, lower,
+trim and date functions. Check the result with --body-preview <task-id>.
+
+  gur config github --body-template .github/gur_issue.tmpl
+  gur config github --body-preview gur-abc123
+
+Use --test to check the stored token: its scopes, Issues write access on the
+configured repository, and the remaining rate limit. Push and pull run the
+same check before syncing.
+
 To create a token:
   1. Go to GitHub Settings → Developer settings → Personal access tokens → Fine-grained tokens
   2. Generate new token with repository access
@@ -41,14 +64,21 @@ var (
 	configGitHubRepo   string
 	configGitHubPrefix string
 	configGitHubToken  string
+	configGitHubBase   string
+	configGitHubUpload string
 	configGitHubShow   bool
 	configGitHubClear  bool
+	configGitHubTest   bool
+
+	configGitHubBodyTemplate string
+	configGitHubBodyPreview  string
 )
 
 var configShowCmd = &cobra.Command{
-	Use:   "show",
-	Short: "Show all configuration",
-	RunE:  runConfigShow,
+	Use:         "show",
+	Short:       "Show all configuration",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runConfigShow,
 }
 
 var configMachineCmd = &cobra.Command{
@@ -88,8 +118,14 @@ func init() {
 	configGitHubCmd.Flags().StringVar(&configGitHubRepo, "repo", "", "GitHub repository (owner/repo)")
 	configGitHubCmd.Flags().StringVar(&configGitHubPrefix, "prefix", "", "Issue title prefix")
 	configGitHubCmd.Flags().StringVar(&configGitHubToken, "token", "", "GitHub token (use stdin for security)")
+	markSecretFlags(configGitHubCmd, "token")
+	configGitHubCmd.Flags().StringVar(&configGitHubBase, "base-url", "", "GitHub Enterprise API URL (github.com to reset)")
+	configGitHubCmd.Flags().StringVar(&configGitHubUpload, "upload-url", "", "GitHub Enterprise upload URL (default: derived from --base-url)")
 	configGitHubCmd.Flags().BoolVar(&configGitHubShow, "show", false, "Show current configuration")
 	configGitHubCmd.Flags().BoolVar(&configGitHubClear, "clear", false, "Clear GitHub configuration")
+	configGitHubCmd.Flags().BoolVar(&configGitHubTest, "test", false, "Verify the token, repository access and rate limit")
+	configGitHubCmd.Flags().StringVar(&configGitHubBodyTemplate, "body-template", "", "Store the issue body template from this file ('default' to remove)")
+	configGitHubCmd.Flags().StringVar(&configGitHubBodyPreview, "body-preview", "", "Render the issue body for this task and exit")
 }
 
 func runConfigMachine(cmd *cobra.Command, args []string) error {
@@ -169,8 +205,20 @@ func runConfigGitHub(cmd *cobra.Command, args []string) error {
 		return clearGitHubConfig()
 	}
 
+	// Handle --test flag
+	if configGitHubTest {
+		return testGitHubConfig()
+	}
+
+	if configGitHubBodyPreview != "" {
+		return previewIssueBody(configGitHubBodyPreview)
+	}
+	if configGitHubBodyTemplate != "" {
+		return setIssueBodyTemplate(configGitHubBodyTemplate)
+	}
+
 	// If flags provided, use non-interactive mode
-	if configGitHubRepo != "" || configGitHubToken != "" || configGitHubPrefix != "" {
+	if configGitHubRepo != "" || configGitHubToken != "" || configGitHubPrefix != "" || configGitHubBase != "" || configGitHubUpload != "" {
 		return configureGitHubNonInteractive()
 	}
 
@@ -180,7 +228,7 @@ func runConfigGitHub(cmd *cobra.Command, args []string) error {
 
 func runConfigShow(cmd *cobra.Command, args []string) error {
 	// Get database path
-	dbPath, _ := db.GetDefaultDBPath()
+	dbPath := db.DescribeDatabase()
 
 	// Get mode
 	mode := "default"
@@ -206,6 +254,7 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	if p, err := db.GetConfig(models.ConfigGitHubIssuePrefix); err == nil {
 		prefix = p
 	}
+	baseURL, _ := db.GetConfig(models.ConfigGitHubBaseURL)
 	_, tokenErr := keyring.Get(models.KeyringServiceName, models.KeyringGitHubTokenKey)
 	tokenSet := tokenErr == nil
 
@@ -218,6 +267,7 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 			"github": map[string]interface{}{
 				"repository":   repo,
 				"issue_prefix": prefix,
+				"base_url":     baseURL,
 				"token_set":    tokenSet,
 			},
 		})
@@ -237,6 +287,9 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	if repo != "" {
 		fmt.Printf("  Repository:   %s\n", repo)
 		fmt.Printf("  Issue Prefix: %s\n", prefix)
+		if baseURL != "" {
+			fmt.Printf("  Server:       %s\n", baseURL)
+		}
 		if tokenSet {
 			fmt.Println("  Token:        (stored in keyring)")
 		} else {
@@ -267,11 +320,25 @@ func showGitHubConfig() error {
 		tokenSet = tokenSetConfig.Value == "true"
 	}
 
+	baseURL, _ := db.GetConfig(models.ConfigGitHubBaseURL)
+	uploadURL, _ := db.GetConfig(models.ConfigGitHubUploadURL)
+	bodyTemplate := "built-in"
+	if text, _ := db.GetConfig(models.ConfigGitHubIssueBodyTemplate); text != "" {
+		bodyTemplate = "custom (stored in config)"
+	} else if root, err := db.FindProjectRoot(); err == nil {
+		if _, err := os.Stat(filepath.Join(root, db.GuardrailsDir, issueBodyTemplateFile)); err == nil {
+			bodyTemplate = "custom (" + filepath.Join(db.GuardrailsDir, issueBodyTemplateFile) + ")"
+		}
+	}
+
 	if IsJSONOutput() {
 		OutputJSON(map[string]interface{}{
-			"repository":   repo,
-			"issue_prefix": prefix,
-			"token_set":    tokenSet,
+			"repository":    repo,
+			"issue_prefix":  prefix,
+			"base_url":      baseURL,
+			"upload_url":    uploadURL,
+			"token_set":     tokenSet,
+			"body_template": bodyTemplate,
 		})
 		return nil
 	}
@@ -283,11 +350,20 @@ func showGitHubConfig() error {
 		fmt.Println("  Repository:   (not configured)")
 	}
 	fmt.Printf("  Issue Prefix: %s\n", prefix)
+	if baseURL != "" {
+		fmt.Printf("  Server:       %s (GitHub Enterprise)\n", baseURL)
+		if uploadURL != "" {
+			fmt.Printf("  Upload URL:   %s\n", uploadURL)
+		}
+	} else {
+		fmt.Println("  Server:       github.com")
+	}
 	if tokenSet {
 		fmt.Println("  Token:        (stored in system keyring)")
 	} else {
 		fmt.Println("  Token:        (not configured)")
 	}
+	fmt.Printf("  Issue Body:   %s\n", bodyTemplate)
 
 	return nil
 }
@@ -297,6 +373,9 @@ func clearGitHubConfig() error {
 	db.GetDB().Where("key = ?", models.ConfigGitHubRepo).Delete(&models.Config{})
 	db.GetDB().Where("key = ?", models.ConfigGitHubIssuePrefix).Delete(&models.Config{})
 	db.GetDB().Where("key = ?", models.ConfigGitHubTokenSet).Delete(&models.Config{})
+	db.GetDB().Where("key = ?", models.ConfigGitHubBaseURL).Delete(&models.Config{})
+	db.GetDB().Where("key = ?", models.ConfigGitHubUploadURL).Delete(&models.Config{})
+	db.GetDB().Where("key = ?", models.ConfigGitHubIssueBodyTemplate).Delete(&models.Config{})
 
 	// Clear from keyring
 	keyring.Delete(models.KeyringServiceName, models.KeyringGitHubTokenKey)
@@ -309,6 +388,65 @@ func clearGitHubConfig() error {
 	return nil
 }
 
+// setIssueBodyTemplate stores the template in path, after checking it parses,
+// or removes the stored template for "default"
+func setIssueBodyTemplate(path string) error {
+	if path == "default" {
+		if err := db.GetDB().Where("key = ?", models.ConfigGitHubIssueBodyTemplate).Delete(&models.Config{}).Error; err != nil {
+			return fmt.Errorf("failed to remove issue body template: %w", err)
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "body_template": "default"})
+		} else {
+			fmt.Printf("Issue body template removed (using %s if present, else the built-in body)\n", filepath.Join(db.GuardrailsDir, issueBodyTemplateFile))
+		}
+		return nil
+	}
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read issue body template: %w", err)
+	}
+	if _, err := parseIssueBodyTemplate(string(text)); err != nil {
+		return err
+	}
+	if err := db.SetConfig(models.ConfigGitHubIssueBodyTemplate, string(text)); err != nil {
+		return fmt.Errorf("failed to save issue body template: %w", err)
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "body_template": path})
+	} else {
+		fmt.Printf("Issue body template set from %s\n", path)
+	}
+	return nil
+}
+
+// previewIssueBody prints the body push would send for a task
+func previewIssueBody(taskID string) error {
+	task, err := db.GetTaskByID(taskID)
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "task '%s' not found (use 'gur list' to see available tasks)", taskID)
+	}
+	tasks := []models.Task{*task}
+	if err := attachFieldValues(db.GetDB(), tasks); err != nil {
+		return err
+	}
+	tmpl, err := loadIssueBodyTemplate()
+	if err != nil {
+		return err
+	}
+	body, err := renderIssueBody(db.GetDB(), tmpl, tasks[0])
+	if err != nil {
+		return err
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"task_id": task.ID, "custom_template": tmpl != nil, "body": body})
+		return nil
+	}
+	fmt.Println(body)
+	return nil
+}
+
 func configureGitHubNonInteractive() error {
 	if configGitHubRepo != "" {
 		if !strings.Contains(configGitHubRepo, "/") {
@@ -325,6 +463,12 @@ func configureGitHubNonInteractive() error {
 		}
 	}
 
+	if configGitHubBase != "" || configGitHubUpload != "" {
+		if err := setGitHubServer(configGitHubBase, configGitHubUpload); err != nil {
+			return err
+		}
+	}
+
 	if configGitHubToken != "" {
 		if err := keyring.Set(models.KeyringServiceName, models.KeyringGitHubTokenKey, configGitHubToken); err != nil {
 			return fmt.Errorf("failed to store token in keyring: %w", err)
@@ -334,6 +478,11 @@ func configureGitHubNonInteractive() error {
 		}
 	}
 
+	// A server change or new token may not match; check them together
+	if configGitHubBase != "" || configGitHubToken != "" {
+		warnIfTokenRejected()
+	}
+
 	if IsJSONOutput() {
 		OutputJSON(map[string]interface{}{"success": true, "message": "GitHub configuration updated"})
 	} else {
@@ -432,6 +581,101 @@ func configureGitHubInteractive() error {
 	return nil
 }
 
+// testGitHubConfig runs the sync preflight against the configured repository
+func testGitHubConfig() error {
+	repo, err := db.GetConfig(models.ConfigGitHubRepo)
+	if err != nil || repo == "" {
+		return codedErrorf(ErrCodeNotConfigured, "GitHub not configured. Run 'gur config github' first")
+	}
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository format '%s': expected 'owner/repo'", repo)
+	}
+
+	token, err := GetGitHubToken()
+	if err != nil {
+		return err
+	}
+	client, err := newGitHubClient(token)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), githubAPITimeout)
+	defer cancel()
+	preflight, err := runGitHubPreflight(ctx, client, parts[0], parts[1])
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "preflight": preflight})
+	} else {
+		printGitHubPreflight(preflight)
+	}
+	return nil
+}
+
+// setGitHubServer saves the GitHub Enterprise API and upload URLs.
+// A base URL of "github.com" switches back to github.com.
+func setGitHubServer(baseURL, uploadURL string) error {
+	if baseURL == "github.com" || baseURL == "https://github.com" || baseURL == "https://api.github.com" {
+		if uploadURL != "" {
+			return fmt.Errorf("--upload-url only applies to GitHub Enterprise")
+		}
+		db.GetDB().Where("key = ?", models.ConfigGitHubBaseURL).Delete(&models.Config{})
+		db.GetDB().Where("key = ?", models.ConfigGitHubUploadURL).Delete(&models.Config{})
+		db.GetDB().Where("key = ?", models.ConfigGitHubIssueBodyTemplate).Delete(&models.Config{})
+		return nil
+	}
+
+	if baseURL != "" {
+		normalized, err := normalizeEnterpriseURL(baseURL)
+		if err != nil {
+			return err
+		}
+		if err := db.SetConfig(models.ConfigGitHubBaseURL, normalized); err != nil {
+			return fmt.Errorf("failed to save base URL: %w", err)
+		}
+	} else if current, _ := db.GetConfig(models.ConfigGitHubBaseURL); current == "" {
+		return fmt.Errorf("--upload-url requires --base-url")
+	}
+
+	if uploadURL != "" {
+		normalized, err := normalizeEnterpriseURL(uploadURL)
+		if err != nil {
+			return err
+		}
+		if err := db.SetConfig(models.ConfigGitHubUploadURL, normalized); err != nil {
+			return fmt.Errorf("failed to save upload URL: %w", err)
+		}
+	} else {
+		// Re-derive from the new base URL
+		db.GetDB().Where("key = ?", models.ConfigGitHubUploadURL).Delete(&models.Config{})
+		db.GetDB().Where("key = ?", models.ConfigGitHubIssueBodyTemplate).Delete(&models.Config{})
+	}
+	return nil
+}
+
+// warnIfTokenRejected checks the stored token against the configured server,
+// warning rather than failing so configuration can be done offline
+func warnIfTokenRejected() {
+	token, err := GetGitHubToken()
+	if err != nil {
+		return
+	}
+	client, err := newGitHubClient(token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), githubAPITimeout)
+	defer cancel()
+	if _, err := checkGitHubToken(ctx, client); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
 // GetGitHubToken retrieves the GitHub token from keyring or environment
 func GetGitHubToken() (string, error) {
 	// First try keyring (secure storage)
@@ -446,5 +690,5 @@ func GetGitHubToken() (string, error) {
 		return token, nil
 	}
 
-	return "", fmt.Errorf("GitHub token not found. Run 'gur config github' or set GUR_GITHUB_TOKEN")
+	return "", codedErrorf(ErrCodeNotConfigured, "GitHub token not found. Run 'gur config github' or set GUR_GITHUB_TOKEN")
 }
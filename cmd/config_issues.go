@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var configGitHubIssuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "Configure GitHub issue types and sub-issues",
+	Long: `Configure how task types and subtasks map to GitHub issue types and
+sub-issues. Both are off until turned on.
+
+--types maps task types to the issue types of the repository's organization
+("default" is ` + models.DefaultIssueTypeMapSpec + `). Push sets each issue's
+type; pull gives new tasks the type their issue type maps to. Issue types
+only exist for organizations: for other owners push warns and skips them.
+
+--sub-issues makes the issue of each subtask a sub-issue of its parent's
+issue on push. Pull creates issues whose parent is linked as subtasks and
+moves linked tasks whose issue got a new parent on GitHub.
+
+Examples:
+  gur config github issues --types default --sub-issues
+  gur config github issues --types "bug=Bug,epic=Initiative"
+  gur config github issues --types off
+  gur config github issues --sub-issues=false
+  gur config github issues --show`,
+	Args: cobra.NoArgs,
+	RunE: runConfigGitHubIssues,
+}
+
+var (
+	configIssuesTypes     string
+	configIssuesSubIssues bool
+	configIssuesShow      bool
+)
+
+func init() {
+	configGitHubCmd.AddCommand(configGitHubIssuesCmd)
+
+	configGitHubIssuesCmd.Flags().StringVar(&configIssuesTypes, "types", "", "Issue type mapping (type=Issue Type,...), 'default' or 'off'")
+	configGitHubIssuesCmd.Flags().BoolVar(&configIssuesSubIssues, "sub-issues", false, "Mirror subtasks as sub-issues (--sub-issues=false to stop)")
+	configGitHubIssuesCmd.Flags().BoolVar(&configIssuesShow, "show", false, "Show current settings")
+}
+
+func runConfigGitHubIssues(cmd *cobra.Command, args []string) error {
+	changed := false
+	if cmd.Flags().Changed("types") {
+		switch configIssuesTypes {
+		case "off", "":
+			db.GetDB().Where("key = ?", models.ConfigGitHubIssueTypes).Delete(&models.Config{})
+		default:
+			spec := configIssuesTypes
+			if spec == "default" {
+				spec = models.DefaultIssueTypeMapSpec
+			}
+			types, err := models.ParseIssueTypeMap(spec)
+			if err != nil {
+				return err
+			}
+			if err := db.SetConfig(models.ConfigGitHubIssueTypes, types.String()); err != nil {
+				return fmt.Errorf("failed to save issue type mapping: %w", err)
+			}
+		}
+		changed = true
+	}
+	if cmd.Flags().Changed("sub-issues") {
+		if err := db.SetConfig(models.ConfigGitHubSubIssues, fmt.Sprintf("%t", configIssuesSubIssues)); err != nil {
+			return fmt.Errorf("failed to save sub-issue setting: %w", err)
+		}
+		changed = true
+	}
+	if !changed && !configIssuesShow {
+		return cmd.Help()
+	}
+
+	hierarchy, err := loadIssueHierarchy()
+	if err != nil {
+		return err
+	}
+	var types models.IssueTypeMap
+	subIssues := false
+	if hierarchy != nil {
+		types, subIssues = hierarchy.types, hierarchy.subIssues
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "types": types.String(), "sub_issues": subIssues})
+		return nil
+	}
+	if changed {
+		fmt.Println("GitHub issue settings updated")
+	}
+	fmt.Println("Issue Types (task type -> GitHub):")
+	if types == nil {
+		fmt.Println("  off")
+	}
+	for _, t := range []string{models.TypeTask, models.TypeBug, models.TypeFeature, models.TypeEpic} {
+		if name, ok := types[t]; ok {
+			fmt.Printf("  %-10s -> %s\n", t, name)
+		}
+	}
+	state := "off"
+	if subIssues {
+		state = "on"
+	}
+	fmt.Printf("Sub-issues: %s\n", state)
+	return nil
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var configGitHubLabelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "Configure label mapping between tasks and GitHub issues",
+	Long: `Configure how task types, priorities, blocked status, and labels map to
+GitHub issue labels. The mapping is applied on both push and pull.
+
+Each entry is local=github, optionally followed by #color (6 hex digits)
+used when push creates a missing label on GitHub. Local keys are:
+  bug, feature, epic, task    Task type
+  P0-P4                       Priority
+  blocked                     Blocked status
+  anything else               A task label (renamed on GitHub)
+  area/*, */*                 A label namespace: area/*=area: * pushes
+                              area/auth as "area: auth" and pulls it back;
+                              exact keys take precedence
+
+Unmapped task labels are pushed and pulled unchanged. The default mapping is:
+  ` + models.DefaultLabelMapSpec + `
+
+Examples:
+  gur config github labels --map "bug=bug,P0=priority: critical#b60205"
+  gur config github labels --map "feature=type: feature,frontend=area: ui"
+  gur config github labels --map "bug=bug,area/*=area: *,team/*=team: *"
+  gur config github labels --show
+  gur config github labels --reset`,
+	Args: cobra.NoArgs,
+	RunE: runConfigGitHubLabels,
+}
+
+var (
+	configLabelsMap   string
+	configLabelsShow  bool
+	configLabelsReset bool
+)
+
+func init() {
+	configGitHubCmd.AddCommand(configGitHubLabelsCmd)
+
+	configGitHubLabelsCmd.Flags().StringVar(&configLabelsMap, "map", "", "Label mapping (local=github[#color],...)")
+	configGitHubLabelsCmd.Flags().BoolVar(&configLabelsShow, "show", false, "Show current mapping")
+	configGitHubLabelsCmd.Flags().BoolVar(&configLabelsReset, "reset", false, "Restore the default mapping")
+}
+
+func runConfigGitHubLabels(cmd *cobra.Command, args []string) error {
+	switch {
+	case configLabelsReset:
+		db.GetDB().Where("key = ?", models.ConfigGitHubLabelMap).Delete(&models.Config{})
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "map": models.DefaultLabelMapSpec})
+		} else {
+			fmt.Println("Label mapping reset to default")
+		}
+		return nil
+
+	case configLabelsMap != "":
+		labelMap, err := models.ParseLabelMap(configLabelsMap)
+		if err != nil {
+			return err
+		}
+		if len(labelMap.Entries) == 0 {
+			return fmt.Errorf("label mapping is empty (use --reset to restore the default)")
+		}
+		if err := db.SetConfig(models.ConfigGitHubLabelMap, labelMap.String()); err != nil {
+			return fmt.Errorf("failed to save label mapping: %w", err)
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "map": labelMap.String(), "entries": labelMap.Entries})
+		} else {
+			fmt.Printf("Label mapping updated (%d entries)\n", len(labelMap.Entries))
+		}
+		return nil
+
+	case configLabelsShow:
+		labelMap, err := loadLabelMap()
+		if err != nil {
+			return err
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"map": labelMap.String(), "entries": labelMap.Entries})
+			return nil
+		}
+		fmt.Println("Label Mapping (local -> GitHub):")
+		for _, e := range labelMap.Entries {
+			color := ""
+			if e.Color != "" {
+				color = " #" + e.Color
+			}
+			fmt.Printf("  %-10s -> %s%s\n", e.Local, e.GitHub, color)
+		}
+		return nil
+	}
+
+	return cmd.Help()
+}
+
+// loadLabelMap returns the configured label mapping, or the default
+func loadLabelMap() (models.LabelMap, error) {
+	spec, err := db.GetConfig(models.ConfigGitHubLabelMap)
+	if err != nil || spec == "" {
+		return models.DefaultLabelMap(), nil
+	}
+	labelMap, err := models.ParseLabelMap(spec)
+	if err != nil {
+		return models.LabelMap{}, fmt.Errorf("invalid label mapping in config (run 'gur config github labels --reset'): %w", err)
+	}
+	return labelMap, nil
+}
+
+// ensureGitHubLabels creates mapped labels that don't exist on GitHub yet,
+// using their configured colors
+func ensureGitHubLabels(ctx context.Context, client *github.Client, owner, repo string, labelMap models.LabelMap) error {
+	for _, e := range labelMap.Entries {
+		if strings.Contains(e.GitHub, "*") {
+			continue // Namespace mappings name no single label
+		}
+		_, resp, err := client.Issues.GetLabel(ctx, owner, repo, e.GitHub)
+		if err == nil {
+			continue
+		}
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("failed to check label '%s': %w", e.GitHub, err)
+		}
+
+		label := &github.Label{Name: github.String(e.GitHub)}
+		if e.Color != "" {
+			label.Color = github.String(e.Color)
+		}
+		if _, _, err := client.Issues.CreateLabel(ctx, owner, repo, label); err != nil {
+			return fmt.Errorf("failed to create label '%s': %w", e.GitHub, err)
+		}
+	}
+	return nil
+}
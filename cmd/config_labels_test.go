@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/models"
+)
+
+func TestEnsureGitHubLabelsCreatesMissing(t *testing.T) {
+	var mu sync.Mutex
+	created := map[string]string{}
+
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/labels/bug"):
+			w.Write([]byte(`{"name":"bug"}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/labels"):
+			var label struct{ Name, Color string }
+			json.NewDecoder(r.Body).Decode(&label)
+			created[label.Name] = label.Color
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	labelMap, _ := models.ParseLabelMap("bug=bug#d73a4a,P0=priority: critical#b60205,frontend=area: ui")
+	if err := ensureGitHubLabels(context.Background(), client, "o", "r", labelMap); err != nil {
+		t.Fatalf("ensureGitHubLabels() error: %v", err)
+	}
+
+	if _, ok := created["bug"]; ok {
+		t.Error("existing label 'bug' should not be recreated")
+	}
+	if created["priority: critical"] != "b60205" {
+		t.Errorf("priority label color = %q, want b60205", created["priority: critical"])
+	}
+	if color, ok := created["area: ui"]; !ok || color != "" {
+		t.Errorf("area label created=%v color=%q, want created without color", ok, color)
+	}
+}
+
+func TestSyncMappedLabelsDiffsCurrent(t *testing.T) {
+	var mu sync.Mutex
+	var added []string
+	var removed []string
+
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&added)
+			w.Write([]byte(`[]`))
+		case http.MethodDelete:
+			name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			removed = append(removed, name)
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	issue := &github.Issue{Number: github.Int(1), Labels: []*github.Label{
+		{Name: github.String("Bug")},
+		{Name: github.String("epic")},
+		{Name: github.String("wontfix")},
+	}}
+	task := models.Task{Type: models.TypeBug, Priority: models.PriorityHigh, Status: models.StatusOpen, Labels: models.StringSlice{"docs"}}
+	if err := syncMappedLabels(context.Background(), client, "o", "r", issue, models.DefaultLabelMap(), task); err != nil {
+		t.Fatalf("syncMappedLabels() error: %v", err)
+	}
+
+	if strings.Join(added, ",") != "priority: high,docs" {
+		t.Errorf("added = %v, want only labels missing from the issue", added)
+	}
+	if strings.Join(removed, ",") != "epic" {
+		t.Errorf("removed = %v, want only the stale label present on the issue", removed)
+	}
+
+	// An issue already in line with the task needs no requests
+	added, removed = nil, nil
+	issue.Labels = []*github.Label{{Name: github.String("bug")}, {Name: github.String("priority: high")}, {Name: github.String("docs")}}
+	if err := syncMappedLabels(context.Background(), client, "o", "r", issue, models.DefaultLabelMap(), task); err != nil {
+		t.Fatalf("syncMappedLabels() error: %v", err)
+	}
+	if added != nil || removed != nil {
+		t.Errorf("in-sync issue sent added=%v removed=%v", added, removed)
+	}
+}
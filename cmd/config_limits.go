@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var configLimitsCmd = &cobra.Command{
+	Use:   "limits",
+	Short: "Limit the size of notes and descriptions",
+	Long: `Limit how large a single note or a task description may be, so a log
+pasted into a note doesn't bloat every 'gur show', brief and sync.
+
+Limits apply to 'gur note add', 'gur update --notes' and other commands
+that add notes, and to descriptions set by 'gur create' and 'gur update'.
+Sizes are bytes, with an optional KB or MB suffix; 0 removes a limit.
+
+--mode decides what happens to text over its limit:
+  warn       keep it and print a warning (default)
+  truncate   keep the start of it, marked with how much was cut
+  reject     refuse the change with ERR_TOO_LARGE
+
+A task's notes can still grow note by note; 'gur trim' drops old ones.
+
+Examples:
+  gur config limits
+  gur config limits --note 8KB --description 32KB --mode truncate
+  gur config limits --note 0`,
+	Args: cobra.NoArgs,
+	RunE: runConfigLimits,
+}
+
+var (
+	configLimitsNote        string
+	configLimitsDescription string
+	configLimitsMode        string
+)
+
+func init() {
+	configCmd.AddCommand(configLimitsCmd)
+	configLimitsCmd.Flags().StringVar(&configLimitsNote, "note", "", "Max size of one note (e.g., 8KB; 0 for no limit)")
+	configLimitsCmd.Flags().StringVar(&configLimitsDescription, "description", "", "Max size of a description (e.g., 32KB; 0 for no limit)")
+	configLimitsCmd.Flags().StringVar(&configLimitsMode, "mode", "", "What text over a limit does: "+strings.Join(models.SizeLimitModes, "/"))
+}
+
+// sizeLimits are the configured note and description limits in bytes,
+// 0 meaning no limit
+type sizeLimits struct {
+	Note        int
+	Description int
+	Mode        string
+}
+
+// loadSizeLimits returns the configured limits, ignoring invalid values
+func loadSizeLimits() sizeLimits {
+	l := sizeLimits{Mode: models.SizeLimitWarn}
+	if v, _ := db.GetConfig(models.ConfigLimitNoteBytes); v != "" {
+		l.Note, _ = strconv.Atoi(v)
+	}
+	if v, _ := db.GetConfig(models.ConfigLimitDescriptionBytes); v != "" {
+		l.Description, _ = strconv.Atoi(v)
+	}
+	if v, _ := db.GetConfig(models.ConfigLimitMode); validSizeLimitMode(v) {
+		l.Mode = v
+	}
+	return l
+}
+
+func validSizeLimitMode(mode string) bool {
+	for _, m := range models.SizeLimitModes {
+		if mode == m {
+			return true
+		}
+	}
+	return false
+}
+
+// enforce applies limit to the text of a task's field ("note" or
+// "description") under the configured mode, returning the text to store.
+// task names the task in messages: its ID, or its title before it has one.
+func (l sizeLimits) enforce(task, field, text string, limit int) (string, error) {
+	if limit <= 0 || len(text) <= limit {
+		return text, nil
+	}
+	switch l.Mode {
+	case models.SizeLimitReject:
+		return "", codedErrorf(ErrCodeTooLarge, "%s for task '%s' is %s, over the %s limit (see 'gur config limits')",
+			field, task, formatByteSize(len(text)), formatByteSize(limit))
+	case models.SizeLimitTruncate:
+		truncated := truncateToSize(text, limit)
+		fmt.Fprintf(os.Stderr, "Warning: %s for task '%s' truncated from %s to the %s limit\n",
+			field, task, formatByteSize(len(text)), formatByteSize(limit))
+		return truncated, nil
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: %s for task '%s' is %s, over the %s limit (see 'gur config limits')\n",
+			field, task, formatByteSize(len(text)), formatByteSize(limit))
+		return text, nil
+	}
+}
+
+// truncateToSize keeps the start of text, cut at a character boundary and
+// followed by a marker saying how much was dropped, within limit bytes
+// where the marker fits
+func truncateToSize(text string, limit int) string {
+	reserve := len(fmt.Sprintf("\n[truncated %d bytes]", len(text))) // The longest the marker can be
+	cut := max(limit-reserve, 0)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + fmt.Sprintf("\n[truncated %d bytes]", len(text)-cut)
+}
+
+// parseByteSize parses a size like 4096, 8KB or 1MB into bytes
+func parseByteSize(s string) (int, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	unit := 1
+	switch {
+	case strings.HasSuffix(v, "MB"):
+		unit, v = 1<<20, strings.TrimSuffix(v, "MB")
+	case strings.HasSuffix(v, "KB"):
+		unit, v = 1<<10, strings.TrimSuffix(v, "KB")
+	case strings.HasSuffix(v, "B"):
+		v = strings.TrimSuffix(v, "B")
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size '%s': use bytes or a KB/MB size like 8KB", s)
+	}
+	return n * unit, nil
+}
+
+// formatByteSize renders a byte count the way parseByteSize reads it
+func formatByteSize(n int) string {
+	switch {
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return fmt.Sprintf("%dMB", n>>20)
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return fmt.Sprintf("%dKB", n>>10)
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
+
+func runConfigLimits(cmd *cobra.Command, args []string) error {
+	sizes := []struct {
+		flag, key, value string
+	}{
+		{"note", models.ConfigLimitNoteBytes, configLimitsNote},
+		{"description", models.ConfigLimitDescriptionBytes, configLimitsDescription},
+	}
+	for _, s := range sizes {
+		if !cmd.Flags().Changed(s.flag) {
+			continue
+		}
+		n, err := parseByteSize(s.value)
+		if err != nil {
+			return err
+		}
+		if err := db.SetConfig(s.key, strconv.Itoa(n)); err != nil {
+			return fmt.Errorf("failed to save %s limit: %w", s.flag, err)
+		}
+	}
+	if configLimitsMode != "" {
+		if !validSizeLimitMode(configLimitsMode) {
+			return fmt.Errorf("invalid limit mode '%s': must be one of %s", configLimitsMode, strings.Join(models.SizeLimitModes, ", "))
+		}
+		if err := db.SetConfig(models.ConfigLimitMode, configLimitsMode); err != nil {
+			return fmt.Errorf("failed to save limit mode: %w", err)
+		}
+	}
+
+	limits := loadSizeLimits()
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"note_bytes": limits.Note, "description_bytes": limits.Description, "mode": limits.Mode})
+		return nil
+	}
+	if cmd.Flags().Changed("note") || cmd.Flags().Changed("description") || configLimitsMode != "" {
+		fmt.Println("Size limits updated")
+	}
+	show := func(n int) string {
+		if n <= 0 {
+			return "none"
+		}
+		return formatByteSize(n)
+	}
+	fmt.Printf("Note:        %s\n", show(limits.Note))
+	fmt.Printf("Description: %s\n", show(limits.Description))
+	fmt.Printf("Mode:        %s\n", limits.Mode)
+	return nil
+}
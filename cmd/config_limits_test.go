@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestParseByteSize(t *testing.T) {
+	for in, want := range map[string]int{"4096": 4096, "8KB": 8 << 10, "1mb": 1 << 20, "512B": 512, "0": 0} {
+		if got, err := parseByteSize(in); err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = %d, %v, want %d", in, got, err, want)
+		}
+		if got, _ := parseByteSize(formatByteSize(want)); want > 0 && got != want {
+			t.Errorf("formatByteSize(%d) = %q doesn't parse back", want, formatByteSize(want))
+		}
+	}
+	for _, bad := range []string{"", "8GB", "-1", "lots"} {
+		if _, err := parseByteSize(bad); err == nil {
+			t.Errorf("parseByteSize(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestTruncateToSize(t *testing.T) {
+	text := strings.Repeat("é", 100) // 200 bytes
+	got := truncateToSize(text, 64)
+	if len(got) > 64 || !utf8.ValidString(got) || !strings.HasSuffix(got, "bytes]") {
+		t.Errorf("truncateToSize = %q (%d bytes), want valid text within 64 bytes ending in the marker", got, len(got))
+	}
+}
+
+func TestNoteSizeLimit(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-5e000001", Title: "Noisy", Status: models.StatusOpen}
+	database.Create(task)
+	db.SetConfig(models.ConfigLimitNoteBytes, "100")
+	log := strings.Repeat("line of build output\n", 20)
+
+	if _, err := addNote(database, task, models.NoteKindLog, "bot", log, "bot"); err != nil {
+		t.Fatalf("warn mode refused the note: %v", err)
+	}
+	if !strings.Contains(task.Notes, log) {
+		t.Error("warn mode changed the note")
+	}
+
+	db.SetConfig(models.ConfigLimitMode, models.SizeLimitTruncate)
+	entry, err := addNote(database, task, models.NoteKindLog, "bot", log, "bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Body) > 100 || !strings.Contains(entry.Body, "[truncated") {
+		t.Errorf("truncated note = %q, want at most 100 bytes with a marker", entry.Body)
+	}
+
+	db.SetConfig(models.ConfigLimitMode, models.SizeLimitReject)
+	if _, err := addNote(database, task, models.NoteKindLog, "bot", log, "bot"); errorCodeOf(err) != ErrCodeTooLarge {
+		t.Errorf("reject mode returned %v, want %s", err, ErrCodeTooLarge)
+	}
+	if _, err := addNote(database, task, models.NoteKindNote, "bot", "short", "bot"); err != nil {
+		t.Errorf("a note within the limit was refused: %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var configPolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Require gates by priority and linked work by type",
+	Long: `Require gates for tasks at a given priority, like protected branch rules.
+
+Tasks at that priority cannot be closed until a gate matching each
+requirement is linked and passed, even if no gate was ever linked by hand.
+A requirement is a gate type (e.g., review, test) matched against linked
+gates, or a specific gate ID.
+
+--require-artifacts names task types (or "all") that must also link the
+work itself before closing: an artifact ('gur artifact add'), or a pull
+request or commit URL in the description or notes, as 'gur pr describe
+--create' records. "none" removes the rule.
+
+--require-acceptance refuses to close a task until all its acceptance
+criteria ('gur ac') are checked. Pass --require-acceptance=false to drop it.
+
+Examples:
+  gur config policy --priority 0 --require-gates review,test
+  gur config policy --priority 1 --require-gates test
+  gur config policy --priority 0 --require-gates gate-a1b2c3d4
+  gur config policy --require-artifacts bug,feature
+  gur config policy --require-artifacts none
+  gur config policy --require-acceptance
+  gur config policy --show
+  gur config policy --priority 1 --clear   # Remove one priority's rule
+  gur config policy --clear                # Remove all rules`,
+	Args: cobra.NoArgs,
+	RunE: runConfigPolicy,
+}
+
+var (
+	configPolicyPriority     int
+	configPolicyRequireGates []string
+	configPolicyRequireWork  []string
+	configPolicyRequireAC    bool
+	configPolicyShow         bool
+	configPolicyClear        bool
+)
+
+func init() {
+	configCmd.AddCommand(configPolicyCmd)
+
+	configPolicyCmd.Flags().IntVar(&configPolicyPriority, "priority", -1, "Priority the rule applies to (0-4)")
+	configPolicyCmd.Flags().StringSliceVar(&configPolicyRequireGates, "require-gates", nil, "Gate types or IDs required to close (comma-separated)")
+	configPolicyCmd.Flags().StringSliceVar(&configPolicyRequireWork, "require-artifacts", nil, "Task types (or all/none) that need a linked commit, PR or artifact to close")
+	configPolicyCmd.Flags().BoolVar(&configPolicyRequireAC, "require-acceptance", false, "Require all acceptance criteria checked to close")
+	configPolicyCmd.Flags().BoolVar(&configPolicyShow, "show", false, "Show current policy")
+	configPolicyCmd.Flags().BoolVar(&configPolicyClear, "clear", false, "Remove the rule for --priority, or all rules")
+}
+
+func runConfigPolicy(cmd *cobra.Command, args []string) error {
+	hasPriority := cmd.Flags().Changed("priority")
+	if hasPriority && (configPolicyPriority < models.PriorityCritical || configPolicyPriority > models.PriorityLowest) {
+		return fmt.Errorf("invalid priority %d: must be 0 (critical) to 4 (lowest)", configPolicyPriority)
+	}
+
+	switch {
+	case configPolicyShow:
+		return showGatePolicy()
+
+	case configPolicyClear:
+		priorities := []int{configPolicyPriority}
+		if !hasPriority {
+			priorities = []int{0, 1, 2, 3, 4}
+		}
+		for _, p := range priorities {
+			db.GetDB().Where("key = ?", models.PolicyRequiredGatesKey(p)).Delete(&models.Config{})
+		}
+		if !hasPriority {
+			db.GetDB().Where("key = ?", models.ConfigPolicyRequireArtifacts).Delete(&models.Config{})
+			db.GetDB().Where("key = ?", models.ConfigPolicyRequireAcceptance).Delete(&models.Config{})
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "cleared": priorities})
+		} else if hasPriority {
+			fmt.Printf("Cleared gate policy for P%d\n", configPolicyPriority)
+		} else {
+			fmt.Println("Cleared all policies")
+		}
+		return nil
+
+	case len(configPolicyRequireWork) > 0:
+		return setRequiredArtifacts(configPolicyRequireWork)
+
+	case cmd.Flags().Changed("require-acceptance"):
+		return setRequireAcceptance(configPolicyRequireAC)
+
+	case len(configPolicyRequireGates) > 0:
+		if !hasPriority {
+			return fmt.Errorf("--require-gates needs --priority (e.g., --priority 0 --require-gates review,test)")
+		}
+		var required []string
+		for _, r := range configPolicyRequireGates {
+			if r = strings.TrimSpace(r); r != "" {
+				required = append(required, r)
+			}
+		}
+		if len(required) == 0 {
+			return fmt.Errorf("--require-gates is empty (use --clear to remove the rule)")
+		}
+		if err := db.SetConfig(models.PolicyRequiredGatesKey(configPolicyPriority), strings.Join(required, ",")); err != nil {
+			return fmt.Errorf("failed to save gate policy: %w", err)
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "priority": configPolicyPriority, "required_gates": required})
+		} else {
+			fmt.Printf("P%d tasks now require gates: %s\n", configPolicyPriority, strings.Join(required, ", "))
+		}
+		return nil
+	}
+
+	return cmd.Help()
+}
+
+// setRequiredArtifacts saves the task types that need linked work to close
+func setRequiredArtifacts(values []string) error {
+	var types []string
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		switch v {
+		case "":
+			continue
+		case "none":
+			if len(values) > 1 {
+				return fmt.Errorf("--require-artifacts none can't be combined with task types")
+			}
+		case "all", models.TypeTask, models.TypeBug, models.TypeFeature, models.TypeEpic:
+			types = append(types, v)
+		default:
+			return fmt.Errorf("invalid task type '%s' for --require-artifacts: must be task, bug, feature, epic, all or none", v)
+		}
+	}
+	if len(types) == 0 {
+		db.GetDB().Where("key = ?", models.ConfigPolicyRequireArtifacts).Delete(&models.Config{})
+	} else if err := db.SetConfig(models.ConfigPolicyRequireArtifacts, strings.Join(types, ",")); err != nil {
+		return fmt.Errorf("failed to save artifact policy: %w", err)
+	}
+
+	if IsJSONOutput() {
+		if types == nil {
+			types = []string{}
+		}
+		OutputJSON(map[string]interface{}{"success": true, "require_artifacts": types})
+	} else if len(types) == 0 {
+		fmt.Println("Closing no longer requires linked work")
+	} else {
+		fmt.Printf("Closing %s tasks now requires a linked commit, PR or artifact\n", strings.Join(types, ", "))
+	}
+	return nil
+}
+
+// setRequireAcceptance turns the acceptance criteria rule on or off
+func setRequireAcceptance(required bool) error {
+	if !required {
+		db.GetDB().Where("key = ?", models.ConfigPolicyRequireAcceptance).Delete(&models.Config{})
+	} else if err := db.SetConfig(models.ConfigPolicyRequireAcceptance, "true"); err != nil {
+		return fmt.Errorf("failed to save acceptance policy: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "require_acceptance": required})
+	} else if required {
+		fmt.Println("Closing now requires all acceptance criteria checked")
+	} else {
+		fmt.Println("Closing no longer requires acceptance criteria checked")
+	}
+	return nil
+}
+
+func showGatePolicy() error {
+	policy := make(map[string][]string)
+	for p := models.PriorityCritical; p <= models.PriorityLowest; p++ {
+		if required := requiredGatesForPriority(p); len(required) > 0 {
+			policy[fmt.Sprintf("P%d", p)] = required
+		}
+	}
+	artifactTypes := requiredArtifactTypes()
+
+	if IsJSONOutput() {
+		if artifactTypes == nil {
+			artifactTypes = []string{}
+		}
+		OutputJSON(map[string]interface{}{"required_gates": policy, "require_artifacts": artifactTypes, "require_acceptance": acceptanceRequired()})
+		return nil
+	}
+
+	fmt.Println("Gate Policy:")
+	if len(policy) == 0 {
+		fmt.Println("  (no rules configured)")
+	}
+	for p := models.PriorityCritical; p <= models.PriorityLowest; p++ {
+		if required, ok := policy[fmt.Sprintf("P%d", p)]; ok {
+			fmt.Printf("  P%d: %s\n", p, strings.Join(required, ", "))
+		}
+	}
+	fmt.Println("\nLinked Work Policy:")
+	if len(artifactTypes) == 0 {
+		fmt.Println("  (no rule configured)")
+	} else {
+		fmt.Printf("  A commit, PR or artifact is required to close: %s\n", strings.Join(artifactTypes, ", "))
+	}
+	fmt.Println("\nAcceptance Policy:")
+	if acceptanceRequired() {
+		fmt.Println("  All acceptance criteria must be checked to close")
+	} else {
+		fmt.Println("  (no rule configured)")
+	}
+	return nil
+}
+
+// requiredArtifactTypes returns the task types (or "all") that need linked
+// work to close
+func requiredArtifactTypes() []string {
+	value, err := db.GetConfig(models.ConfigPolicyRequireArtifacts)
+	if err != nil || value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// artifactsRequired reports whether the policy requires linked work to close
+// a task of taskType
+func artifactsRequired(taskType string) bool {
+	for _, t := range requiredArtifactTypes() {
+		if t == "all" || strings.EqualFold(t, taskType) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLinkedWork reports whether a task has an artifact, or a pull request or
+// commit URL among its references
+func hasLinkedWork(database *gorm.DB, taskID string) (bool, error) {
+	var artifacts int64
+	if err := database.Model(&models.Artifact{}).Where("task_id = ?", taskID).Count(&artifacts).Error; err != nil {
+		return false, err
+	}
+	if artifacts > 0 {
+		return true, nil
+	}
+	var refs []models.Reference
+	if err := database.Where("task_id = ? AND kind = ?", taskID, models.RefKindURL).Find(&refs).Error; err != nil {
+		return false, err
+	}
+	for _, r := range refs {
+		if r.IsChange() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// requiredGatesForPriority returns the gate types/IDs the policy requires
+// for tasks at the given priority
+func requiredGatesForPriority(priority int) []string {
+	value, err := db.GetConfig(models.PolicyRequiredGatesKey(priority))
+	if err != nil || value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// missingPolicyGates returns the policy requirements not met by any linked
+// gate. Pass status is checked separately, like any other linked gate.
+func missingPolicyGates(priority int, links []GateLinkInfo) []string {
+	var missing []string
+	for _, required := range requiredGatesForPriority(priority) {
+		found := false
+		for _, info := range links {
+			if info.Gate.ID == required || strings.EqualFold(info.Gate.TypeString(), required) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, required)
+		}
+	}
+	return missing
+}
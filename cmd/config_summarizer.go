@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+	"guardrails/internal/summarizer"
+)
+
+var configSummarizerCmd = &cobra.Command{
+	Use:   "summarizer",
+	Short: "Configure the LLM backend used for summaries",
+	Long: `Configure the backend used by 'gur compact --llm' and 'gur summary --llm'.
+
+Backends:
+  openai     OpenAI or any OpenAI-compatible chat completions API (--url)
+  anthropic  Anthropic Messages API
+  command    Local command: prompt on stdin, summary on stdout
+
+API keys are stored in the system keyring. Alternatively set
+GUR_SUMMARIZER_API_KEY, OPENAI_API_KEY, or ANTHROPIC_API_KEY.
+
+Examples:
+  gur config summarizer --backend anthropic --api-key sk-ant-...
+  gur config summarizer --backend openai --model gpt-4o-mini
+  gur config summarizer --backend openai --url http://localhost:11434/v1 --model llama3
+  gur config summarizer --backend command --command "ollama run llama3"
+  gur config summarizer --show
+  gur config summarizer --clear`,
+	RunE: runConfigSummarizer,
+}
+
+var (
+	configSummarizerBackend string
+	configSummarizerModel   string
+	configSummarizerURL     string
+	configSummarizerCommand string
+	configSummarizerAPIKey  string
+	configSummarizerShow    bool
+	configSummarizerClear   bool
+)
+
+func init() {
+	configCmd.AddCommand(configSummarizerCmd)
+
+	configSummarizerCmd.Flags().StringVar(&configSummarizerBackend, "backend", "", "Backend (openai/anthropic/command)")
+	configSummarizerCmd.Flags().StringVar(&configSummarizerModel, "model", "", "Model name")
+	configSummarizerCmd.Flags().StringVar(&configSummarizerURL, "url", "", "API base URL override")
+	configSummarizerCmd.Flags().StringVar(&configSummarizerCommand, "command", "", "Shell command for the command backend")
+	configSummarizerCmd.Flags().StringVar(&configSummarizerAPIKey, "api-key", "", "API key (stored in system keyring)")
+	markSecretFlags(configSummarizerCmd, "api-key")
+	configSummarizerCmd.Flags().BoolVar(&configSummarizerShow, "show", false, "Show current configuration")
+	configSummarizerCmd.Flags().BoolVar(&configSummarizerClear, "clear", false, "Clear summarizer configuration")
+}
+
+func runConfigSummarizer(cmd *cobra.Command, args []string) error {
+	if configSummarizerShow {
+		return showSummarizerConfig()
+	}
+	if configSummarizerClear {
+		return clearSummarizerConfig()
+	}
+
+	if configSummarizerBackend == "" && configSummarizerModel == "" && configSummarizerURL == "" &&
+		configSummarizerCommand == "" && configSummarizerAPIKey == "" {
+		return cmd.Help()
+	}
+
+	if configSummarizerBackend != "" {
+		valid := false
+		for _, b := range summarizer.Backends {
+			if b == configSummarizerBackend {
+				valid = true
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid backend '%s': must be one of %s", configSummarizerBackend, strings.Join(summarizer.Backends, ", "))
+		}
+	}
+
+	settings := []struct {
+		key, value string
+	}{
+		{models.ConfigSummarizerBackend, configSummarizerBackend},
+		{models.ConfigSummarizerModel, configSummarizerModel},
+		{models.ConfigSummarizerURL, configSummarizerURL},
+		{models.ConfigSummarizerCommand, configSummarizerCommand},
+	}
+	for _, s := range settings {
+		if s.value == "" {
+			continue
+		}
+		if err := db.SetConfig(s.key, s.value); err != nil {
+			return fmt.Errorf("failed to save %s: %w", s.key, err)
+		}
+	}
+
+	if configSummarizerAPIKey != "" {
+		if err := keyring.Set(models.KeyringServiceName, models.KeyringSummarizerKey, configSummarizerAPIKey); err != nil {
+			return fmt.Errorf("failed to store API key in keyring: %w", err)
+		}
+		if err := db.SetConfig(models.ConfigSummarizerKeySet, "true"); err != nil {
+			return fmt.Errorf("failed to save API key flag: %w", err)
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "message": "Summarizer configuration updated"})
+	} else {
+		fmt.Println("Summarizer configuration updated")
+	}
+	return nil
+}
+
+func showSummarizerConfig() error {
+	backend, _ := db.GetConfig(models.ConfigSummarizerBackend)
+	model, _ := db.GetConfig(models.ConfigSummarizerModel)
+	url, _ := db.GetConfig(models.ConfigSummarizerURL)
+	command, _ := db.GetConfig(models.ConfigSummarizerCommand)
+	keySet, _ := db.GetConfig(models.ConfigSummarizerKeySet)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"backend": backend,
+			"model":   model,
+			"url":     url,
+			"command": command,
+			"key_set": keySet == "true",
+		})
+		return nil
+	}
+
+	fmt.Println("Summarizer Configuration:")
+	if backend == "" {
+		fmt.Println("  Backend: (not configured)")
+		return nil
+	}
+	fmt.Printf("  Backend: %s\n", backend)
+	if model != "" {
+		fmt.Printf("  Model:   %s\n", model)
+	}
+	if url != "" {
+		fmt.Printf("  URL:     %s\n", url)
+	}
+	if command != "" {
+		fmt.Printf("  Command: %s\n", command)
+	}
+	if keySet == "true" {
+		fmt.Println("  API Key: (stored in system keyring)")
+	}
+	return nil
+}
+
+func clearSummarizerConfig() error {
+	for _, key := range []string{
+		models.ConfigSummarizerBackend,
+		models.ConfigSummarizerModel,
+		models.ConfigSummarizerURL,
+		models.ConfigSummarizerCommand,
+		models.ConfigSummarizerKeySet,
+	} {
+		db.GetDB().Where("key = ?", key).Delete(&models.Config{})
+	}
+	keyring.Delete(models.KeyringServiceName, models.KeyringSummarizerKey)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "message": "Summarizer configuration cleared"})
+	} else {
+		fmt.Println("Summarizer configuration cleared")
+	}
+	return nil
+}
+
+// getSummarizerAPIKey retrieves the summarizer API key from keyring or environment
+func getSummarizerAPIKey(backend string) string {
+	if key, err := keyring.Get(models.KeyringServiceName, models.KeyringSummarizerKey); err == nil && key != "" {
+		return key
+	}
+	if key := os.Getenv("GUR_SUMMARIZER_API_KEY"); key != "" {
+		return key
+	}
+	switch backend {
+	case summarizer.BackendOpenAI:
+		return os.Getenv("OPENAI_API_KEY")
+	case summarizer.BackendAnthropic:
+		return os.Getenv("ANTHROPIC_API_KEY")
+	}
+	return ""
+}
+
+// loadSummarizer builds the configured summarizer
+func loadSummarizer() (summarizer.Summarizer, error) {
+	cfg := summarizer.Config{}
+	cfg.Backend, _ = db.GetConfig(models.ConfigSummarizerBackend)
+	if cfg.Backend == "" {
+		return nil, codedErrorf(ErrCodeNotConfigured, "no summarizer configured (run 'gur config summarizer --backend <openai|anthropic|command>')")
+	}
+	cfg.Model, _ = db.GetConfig(models.ConfigSummarizerModel)
+	cfg.BaseURL, _ = db.GetConfig(models.ConfigSummarizerURL)
+	cfg.Command, _ = db.GetConfig(models.ConfigSummarizerCommand)
+	if cfg.Backend != summarizer.BackendCommand {
+		cfg.APIKey = getSummarizerAPIKey(cfg.Backend)
+	}
+	return summarizer.New(cfg)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var configSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Configure automatic pushes to GitHub",
+	Long: `Configure whether commands push the tasks they change to GitHub, so issues
+stay current without running 'gur sync push':
+
+  on_close   Push tasks closed by close, close-batch and approve close
+  on_change  Push tasks changed by any command (update, close, label, ...)
+  off        Only push with 'gur sync push' (default)
+
+Tasks are queued in the outbox and pushed when the command ends, at most
+once a minute per task; failed pushes are retried later. See 'gur sync
+outbox' for what is queued.
+
+Examples:
+  gur config sync --auto-push on_close
+  gur config sync --auto-push off
+  gur config sync --show`,
+	Args: cobra.NoArgs,
+	RunE: runConfigSync,
+}
+
+var (
+	configSyncAutoPush string
+	configSyncShow     bool
+)
+
+func init() {
+	configCmd.AddCommand(configSyncCmd)
+	configSyncCmd.Flags().StringVar(&configSyncAutoPush, "auto-push", "", "When to push changed tasks: on_close, on_change or off")
+	configSyncCmd.Flags().BoolVar(&configSyncShow, "show", false, "Show current settings")
+}
+
+func runConfigSync(cmd *cobra.Command, args []string) error {
+	if configSyncAutoPush != "" {
+		switch configSyncAutoPush {
+		case models.AutoPushOnClose, models.AutoPushOnChange, models.AutoPushOff:
+		default:
+			return fmt.Errorf("invalid auto push mode '%s': must be on_close, on_change or off", configSyncAutoPush)
+		}
+		if configSyncAutoPush != models.AutoPushOff {
+			if repo, _ := db.GetConfig(models.ConfigGitHubRepo); repo == "" {
+				return codedErrorf(ErrCodeNotConfigured, "GitHub sync not configured: repository not set (run 'gur config github' first)")
+			}
+		}
+		if err := db.SetConfig(models.ConfigSyncAutoPush, configSyncAutoPush); err != nil {
+			return fmt.Errorf("failed to save auto push mode: %w", err)
+		}
+	} else if !configSyncShow {
+		return cmd.Help()
+	}
+
+	var queued int64
+	db.GetDB().Model(&models.GitHubOutboxEntry{}).Count(&queued)
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "auto_push": autoPushMode(), "queued": queued})
+		return nil
+	}
+	if configSyncAutoPush != "" {
+		fmt.Printf("Auto push set to %s\n", autoPushMode())
+		return nil
+	}
+	fmt.Printf("Auto push: %s\n", autoPushMode())
+	fmt.Printf("Queued:    %d task(s)\n", queued)
+	return nil
+}
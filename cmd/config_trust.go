@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// trustOverrideField is the history field recording a trust override: the
+// link status before the pass, then the gate ID and reason
+const trustOverrideField = "trust_override"
+
+var configTrustCmd = &cobra.Command{
+	Use:   "trust",
+	Short: "Limit who may pass each gate type",
+	Long: `Limit which verifiers may pass gates of a type. Gate types without a rule
+may be passed by anyone.
+
+The verifier is the actor running 'gur gate pass', 'gur gate run' and the
+like: GUR_ACTOR if set, or else the configured machine name (the name the
+event log records), never the free-form --by.
+
+Each allowed verifier is a name, or a kind matching every name of that kind
+in the assignee registry ('gur people'): human or agent. The literal names
+human, agent and ci match themselves. Gates with designated approvers
+('gur gate approvers') are governed by those approvers instead.
+
+A pass by anyone else is refused. Pass --override-trust "<reason>" from an
+interactive terminal to record it anyway after confirming: the reason is
+prefixed to the gate notes with [TRUST OVERRIDE] and kept in the task
+history, and 'gur undo' withdraws the pass. Scripts and agents can't
+override.
+
+Examples:
+  gur config trust --gate-type review --allow human,alice
+  gur config trust --gate-type approval --allow alice,bob
+  gur config trust --gate-type test --allow agent,ci,human
+  gur config trust --show
+  gur config trust --gate-type review --clear   # Remove one type's rule
+  gur config trust --clear                      # Remove all rules`,
+	Args: cobra.NoArgs,
+	RunE: runConfigTrust,
+}
+
+var (
+	configTrustGateType string
+	configTrustAllow    []string
+	configTrustShow     bool
+	configTrustClear    bool
+)
+
+func init() {
+	configCmd.AddCommand(configTrustCmd)
+
+	configTrustCmd.Flags().StringVar(&configTrustGateType, "gate-type", "", "Gate type the rule applies to (e.g., review, test)")
+	configTrustCmd.Flags().StringSliceVar(&configTrustAllow, "allow", nil, "Verifiers allowed to pass it: names, human, agent or ci (comma-separated)")
+	configTrustCmd.Flags().BoolVar(&configTrustShow, "show", false, "Show current trust rules")
+	configTrustCmd.Flags().BoolVar(&configTrustClear, "clear", false, "Remove the rule for --gate-type, or all rules")
+}
+
+func runConfigTrust(cmd *cobra.Command, args []string) error {
+	gateType := strings.ToLower(strings.TrimSpace(configTrustGateType))
+
+	switch {
+	case configTrustShow:
+		return showTrustRules()
+
+	case configTrustClear:
+		query := db.GetDB().Where("key LIKE ?", models.ConfigTrustGateTypePrefix+"%")
+		if gateType != "" {
+			query = db.GetDB().Where("key = ?", models.TrustGateTypeKey(gateType))
+		}
+		if err := query.Delete(&models.Config{}).Error; err != nil {
+			return fmt.Errorf("failed to clear trust rules: %w", err)
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "cleared": gateType})
+		} else if gateType != "" {
+			fmt.Printf("Cleared trust rule for %s gates\n", gateType)
+		} else {
+			fmt.Println("Cleared all trust rules")
+		}
+		return nil
+
+	case len(configTrustAllow) > 0:
+		if gateType == "" {
+			return fmt.Errorf("--allow needs --gate-type (e.g., --gate-type review --allow human,alice)")
+		}
+		var allowed []string
+		for _, a := range configTrustAllow {
+			if a = strings.TrimSpace(a); a != "" {
+				allowed = append(allowed, a)
+			}
+		}
+		if len(allowed) == 0 {
+			return fmt.Errorf("--allow is empty (use --clear to remove the rule)")
+		}
+		if err := db.SetConfig(models.TrustGateTypeKey(gateType), strings.Join(allowed, ",")); err != nil {
+			return fmt.Errorf("failed to save trust rule: %w", err)
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "gate_type": gateType, "allowed": allowed})
+		} else {
+			fmt.Printf("%s gates may now be passed by: %s\n", gateType, strings.Join(allowed, ", "))
+		}
+		return nil
+	}
+
+	return cmd.Help()
+}
+
+// loadTrustRules returns the allowed verifiers keyed by gate type
+func loadTrustRules(database *gorm.DB) (map[string][]string, error) {
+	var configs []models.Config
+	if err := database.Where("key LIKE ?", models.ConfigTrustGateTypePrefix+"%").Find(&configs).Error; err != nil {
+		return nil, err
+	}
+	rules := make(map[string][]string, len(configs))
+	for _, c := range configs {
+		if c.Value != "" {
+			rules[strings.TrimPrefix(c.Key, models.ConfigTrustGateTypePrefix)] = strings.Split(c.Value, ",")
+		}
+	}
+	return rules, nil
+}
+
+func showTrustRules() error {
+	rules, err := loadTrustRules(db.GetDB())
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"trust": rules})
+		return nil
+	}
+
+	fmt.Println("Gate Trust:")
+	if len(rules) == 0 {
+		fmt.Println("  (no rules configured; anyone may pass any gate)")
+		return nil
+	}
+	types := make([]string, 0, len(rules))
+	for t := range rules {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		fmt.Printf("  %s: %s\n", t, strings.Join(rules[t], ", "))
+	}
+	return nil
+}
+
+// trustedVerifiers returns who may pass gates of the gate's type, or nil if
+// anyone may
+func trustedVerifiers(database *gorm.DB, gate *models.Gate) []string {
+	var config models.Config
+	if err := database.Where("key = ?", models.TrustGateTypeKey(gate.TypeString())).First(&config).Error; err != nil || config.Value == "" {
+		return nil
+	}
+	return strings.Split(config.Value, ",")
+}
+
+// isTrustedVerifier reports whether by is one of the allowed verifiers, by
+// name or by its kind in the assignee registry
+func isTrustedVerifier(database *gorm.DB, allowed []string, by string) bool {
+	var person models.Person
+	registered := database.Where("name = ?", by).First(&person).Error == nil
+	for _, a := range allowed {
+		if strings.EqualFold(a, by) || (registered && strings.EqualFold(a, person.Kind)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkGateTrust refuses a pass by a verifier the trust rules don't allow;
+// by is the actor running the command (eventActor). Gates with designated
+// approvers are governed by their approvers.
+func checkGateTrust(database *gorm.DB, gate *models.Gate, by string) error {
+	if gate.RequiresApproval() {
+		return nil
+	}
+	allowed := trustedVerifiers(database, gate)
+	if allowed == nil || isTrustedVerifier(database, allowed, by) {
+		return nil
+	}
+	return codedErrorf(ErrCodeUntrusted, "cannot pass gate '%s': %s gates may only be passed by %s, not '%s' (see 'gur config trust --show'; a human can record an audited exception with --override-trust \"<reason>\" in a terminal)",
+		gate.ID, gate.TypeString(), strings.Join(allowed, ", "), by)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestGateTrust(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+	isTerminal := stdinIsTerminal
+	defer func() {
+		gateRunBy, gateNotes, gateTrustOverride = "human", "", ""
+		stdinIsTerminal, confirmInput = isTerminal, os.Stdin
+	}()
+	stdinIsTerminal = func() bool { return false }
+
+	database.Create(&models.Task{ID: "gur-trst0001", Title: "Feature", Status: models.StatusOpen})
+	database.Create(&models.Gate{ID: "gate-trst1", Title: "Code review", Type: "review"})
+	database.Create(&models.Gate{ID: "gate-trst2", Title: "Unit tests", Type: "test"})
+	database.Create(&models.Gate{ID: "gate-trst3", Title: "Sign-off", Type: "review", Approvers: models.StringSlice{"carol"}})
+	for _, id := range []string{"gate-trst1", "gate-trst2", "gate-trst3"} {
+		database.Create(&models.GateTaskLink{GateID: id, TaskID: "gur-trst0001", Status: models.GateLinkPending})
+	}
+	database.Create(&models.Person{Name: "alice", Kind: models.PersonKindHuman})
+	database.Create(&models.Person{Name: "claude-backend", Kind: models.PersonKindAgent})
+	db.SetConfig(models.TrustGateTypeKey("review"), "human")
+	db.SetConfig(models.TrustGateTypeKey("test"), "agent,ci")
+
+	// The verifier is the actor; --by is only recorded
+	gateRunBy = "human"
+	pass := func(gateID, actor string) error {
+		t.Setenv(EnvActor, actor)
+		return runGateResult(gateID, "gur-trst0001", models.GateLinkPassed, "")
+	}
+
+	// Agents may pass tests but not reviews
+	if err := pass("gate-trst2", "claude-backend"); err != nil {
+		t.Errorf("agent passing a test gate: %v", err)
+	}
+	err := pass("gate-trst1", "claude-backend")
+	if err == nil || errorCodeOf(err) != ErrCodeUntrusted || !strings.Contains(err.Error(), "review gates may only be passed by human") {
+		t.Fatalf("agent passing a review gate = %v, want an untrusted verifier error", err)
+	}
+	var link models.GateTaskLink
+	database.Where("gate_id = ? AND task_id = ?", "gate-trst1", "gur-trst0001").First(&link)
+	if link.Status != models.GateLinkPending {
+		t.Errorf("refused pass stored status %s", link.Status)
+	}
+
+	// A registered human is trusted through their kind
+	if err := pass("gate-trst1", "alice"); err != nil {
+		t.Errorf("registered human passing a review gate: %v", err)
+	}
+	// Failing is never refused
+	t.Setenv(EnvActor, "claude-backend")
+	if err := runGateResult("gate-trst1", "gur-trst0001", models.GateLinkFailed, ""); err != nil {
+		t.Errorf("agent failing a review gate: %v", err)
+	}
+
+	// Approvers govern gates that have them
+	if err := pass("gate-trst3", "carol"); err != nil {
+		t.Errorf("approver passing their gate: %v", err)
+	}
+
+	// An override needs a human at a terminal, and is recorded in the notes
+	// and the task history
+	gateTrustOverride = "Reviewer on leave"
+	if err := pass("gate-trst1", "claude-backend"); err == nil || !strings.Contains(err.Error(), "interactive") {
+		t.Fatalf("override without a terminal = %v, want refused", err)
+	}
+	stdinIsTerminal = func() bool { return true }
+	confirmInput = strings.NewReader("yes\n")
+	if err := pass("gate-trst1", "claude-backend"); err != nil {
+		t.Fatalf("override pass error: %v", err)
+	}
+	link = models.GateTaskLink{}
+	database.Where("gate_id = ? AND task_id = ?", "gate-trst1", "gur-trst0001").First(&link)
+	if link.Status != models.GateLinkPassed || !strings.HasPrefix(link.Notes, models.TrustOverridePrefix+"Reviewer on leave") {
+		t.Errorf("override link = %s %q, want passed with the override noted", link.Status, link.Notes)
+	}
+	var history models.TaskHistory
+	if err := database.Where("task_id = ? AND field = ?", "gur-trst0001", trustOverrideField).First(&history).Error; err != nil || history.ChangedBy != "claude-backend" {
+		t.Errorf("override history = %+v (%v), want an entry by the verifier", history, err)
+	}
+
+	// Undo withdraws the overridden pass
+	if err := revertChanges(database, []models.TaskHistory{history}); err != nil {
+		t.Fatalf("revertChanges() error: %v", err)
+	}
+	link = models.GateTaskLink{}
+	database.Where("gate_id = ? AND task_id = ?", "gate-trst1", "gur-trst0001").First(&link)
+	if link.Status != models.GateLinkFailed {
+		t.Errorf("link after undo = %s, want the earlier failure", link.Status)
+	}
+}
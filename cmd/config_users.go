@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var configGitHubUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Map GitHub logins to local assignees",
+	Long: `Configure how GitHub logins map to local assignee names.
+
+Each entry is login=assignee. Pull sets a new task's assignee from its
+issue's assignee through the mapping; unmapped logins are used unchanged.
+Push assigns the issue of a task whose assignee is mapped to that login,
+if the login can be assigned issues in the repository (has access to it);
+otherwise the issue is left unassigned with a warning. Tasks whose assignee
+isn't mapped never change the issue's assignees.
+
+Examples:
+  gur config github users --map "octocat=alice,hubot=claude-backend"
+  gur config github users --show
+  gur config github users --reset`,
+	Args: cobra.NoArgs,
+	RunE: runConfigGitHubUsers,
+}
+
+var (
+	configUsersMap   string
+	configUsersShow  bool
+	configUsersReset bool
+)
+
+func init() {
+	configGitHubCmd.AddCommand(configGitHubUsersCmd)
+
+	configGitHubUsersCmd.Flags().StringVar(&configUsersMap, "map", "", "User mapping (login=assignee,...)")
+	configGitHubUsersCmd.Flags().BoolVar(&configUsersShow, "show", false, "Show current mapping")
+	configGitHubUsersCmd.Flags().BoolVar(&configUsersReset, "reset", false, "Remove the mapping")
+}
+
+func runConfigGitHubUsers(cmd *cobra.Command, args []string) error {
+	switch {
+	case configUsersReset:
+		db.GetDB().Where("key = ?", models.ConfigGitHubUserMap).Delete(&models.Config{})
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "map": ""})
+		} else {
+			fmt.Println("User mapping removed")
+		}
+		return nil
+
+	case configUsersMap != "":
+		userMap, err := models.ParseUserMap(configUsersMap)
+		if err != nil {
+			return err
+		}
+		if len(userMap.Entries) == 0 {
+			return fmt.Errorf("user mapping is empty (use --reset to remove it)")
+		}
+		for _, e := range userMap.Entries {
+			if err := checkAssignee(db.GetDB(), e.Local); err != nil {
+				return err
+			}
+		}
+		if err := db.SetConfig(models.ConfigGitHubUserMap, userMap.String()); err != nil {
+			return fmt.Errorf("failed to save user mapping: %w", err)
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "map": userMap.String(), "entries": userMap.Entries})
+		} else {
+			fmt.Printf("User mapping updated (%d entries)\n", len(userMap.Entries))
+		}
+		return nil
+
+	case configUsersShow:
+		userMap, err := loadUserMap()
+		if err != nil {
+			return err
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"map": userMap.String(), "entries": userMap.Entries})
+			return nil
+		}
+		if len(userMap.Entries) == 0 {
+			fmt.Println("No user mapping configured (GitHub logins are used as assignees unchanged)")
+			return nil
+		}
+		fmt.Println("User Mapping (GitHub -> local):")
+		for _, e := range userMap.Entries {
+			fmt.Printf("  %-20s -> %s\n", e.GitHub, e.Local)
+		}
+		return nil
+	}
+
+	return cmd.Help()
+}
+
+// loadUserMap returns the configured user mapping, empty if none
+func loadUserMap() (models.UserMap, error) {
+	spec, err := db.GetConfig(models.ConfigGitHubUserMap)
+	if err != nil || spec == "" {
+		return models.UserMap{}, nil
+	}
+	userMap, err := models.ParseUserMap(spec)
+	if err != nil {
+		return models.UserMap{}, fmt.Errorf("invalid user mapping in config (run 'gur config github users --reset'): %w", err)
+	}
+	return userMap, nil
+}
+
+// issueAssignees returns the GitHub assignees to push for a task: the login
+// mapped to its assignee, if that login can be assigned in the repository
+func issueAssignees(ctx context.Context, client *github.Client, owner, repo string, userMap models.UserMap, task models.Task) []string {
+	login, ok := userMap.ToGitHub(task.Assignee)
+	if !ok {
+		return nil
+	}
+	assignable, _, err := client.Issues.IsAssignee(ctx, owner, repo, login)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s: failed to check whether '%s' can be assigned: %v\n", task.ID, login, err)
+		return nil
+	}
+	if !assignable {
+		fmt.Fprintf(os.Stderr, "Warning: %s: GitHub user '%s' (assignee %s) can't be assigned issues in %s/%s; leaving the issue unassigned\n", task.ID, login, task.Assignee, owner, repo)
+		return nil
+	}
+	return []string{login}
+}
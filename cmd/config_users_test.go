@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestSyncTaskToGitHubPushesMappedAssignee(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	var created string
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/app/assignees/octocat":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/acme/app/assignees/"):
+			http.NotFound(w, r)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/issues":
+			body, _ := io.ReadAll(r.Body)
+			created = string(body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"number": 7, "html_url": "https://github.com/acme/app/issues/7"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	database := db.GetDB()
+	if err := db.SetConfig(models.ConfigGitHubUserMap, "octocat=alice,ghost=bob"); err != nil {
+		t.Fatal(err)
+	}
+	push := func(task models.Task) {
+		t.Helper()
+		database.Create(&task)
+		if _, err := syncTaskToGitHub(context.Background(), client, "acme", "app", "[Agent]", models.DefaultLabelMap(), nil, nil, nil, task); err != nil {
+			t.Fatalf("syncTaskToGitHub(%s) error: %v", task.ID, err)
+		}
+	}
+
+	push(models.Task{ID: "gur-05e00001", Title: "Mapped", Status: models.StatusOpen, Type: models.TypeTask, Assignee: "alice"})
+	if !strings.Contains(created, `"assignees":["octocat"]`) {
+		t.Errorf("issue for a mapped assignee = %s, want octocat assigned", created)
+	}
+	push(models.Task{ID: "gur-05e00002", Title: "No access", Status: models.StatusOpen, Type: models.TypeTask, Assignee: "bob"})
+	if strings.Contains(created, `"assignees"`) {
+		t.Errorf("issue for a login without access = %s, want no assignees", created)
+	}
+	push(models.Task{ID: "gur-05e00003", Title: "Unmapped", Status: models.StatusOpen, Type: models.TypeTask, Assignee: "carol"})
+	if strings.Contains(created, `"assignees"`) {
+		t.Errorf("issue for an unmapped assignee = %s, want no assignees", created)
+	}
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -20,6 +22,12 @@ var (
 	createParent      string
 	createSkills      []string
 	createAgents      []string
+	createSuggest     bool
+	createDue         string
+	createEstimate    string
+	createRelease     string
+	createFields      []string
+	createVars        []string
 )
 
 var createCmd = &cobra.Command{
@@ -40,6 +48,26 @@ func init() {
 	createCmd.Flags().StringVar(&createParent, "parent", "", "Parent task ID (creates subtask)")
 	createCmd.Flags().StringArrayVar(&createSkills, "skill", nil, "Link skill to task")
 	createCmd.Flags().StringArrayVar(&createAgents, "agent", nil, "Link agent to task")
+	createCmd.Flags().BoolVar(&createSuggest, "suggest", false, "Suggest skills and agents to link")
+	createCmd.Flags().StringArrayVar(&createFields, "field", nil, "Set custom field (name=value)")
+	createCmd.Flags().StringArrayVar(&createVars, "var", nil, "Template variable (name=value) for {{name}} placeholders")
+	createCmd.Flags().StringVar(&createDue, "due", "", "Due date (e.g., 2025-07-01) or duration from now (e.g., 7d)")
+	createCmd.Flags().StringVar(&createEstimate, "estimate", "", "Estimated effort in working time (e.g., 3h, 1d = 8h)")
+	createCmd.Flags().StringVar(&createRelease, "release", "", "Target release (see 'gur release')")
+}
+
+// parseTemplateVars parses --var name=value flags
+func parseTemplateVars(assignments []string) (map[string]string, error) {
+	vars := make(map[string]string, len(assignments))
+	for _, a := range assignments {
+		name, value, ok := strings.Cut(a, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var '%s': expected name=value", a)
+		}
+		vars[name] = value
+	}
+	return vars, nil
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
@@ -49,10 +77,29 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if createTemplate != "" {
 		var template models.Template
 		if err := db.GetDB().Where("name = ? OR id = ?", createTemplate, createTemplate).First(&template).Error; err != nil {
-			return fmt.Errorf("cannot create task: template '%s' not found (use 'gur template list' to see available templates)", createTemplate)
+			return codedErrorf(ErrCodeNotFound, "cannot create task: template '%s' not found (use 'gur template list' to see available templates)", createTemplate)
+		}
+		vars, err := parseTemplateVars(createVars)
+		if err != nil {
+			return err
+		}
+		// Fields replaced by flags don't need their placeholders filled
+		if len(args) > 0 {
+			template.Title = ""
+		}
+		if createDescription != "" {
+			template.Description = ""
+		}
+		if len(createLabels) > 0 {
+			template.Labels = nil
+		}
+		if task, err = template.Render(vars); err != nil {
+			return err
 		}
-		task = template.ToTask()
 	} else {
+		if len(createVars) > 0 {
+			return fmt.Errorf("--var requires --template")
+		}
 		task = &models.Task{
 			Status:   models.StatusOpen,
 			Priority: models.PriorityMedium,
@@ -79,11 +126,34 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		task.Description = createDescription
 	}
 	if createAssignee != "" {
+		if err := checkAssignee(db.GetDB(), createAssignee); err != nil {
+			return err
+		}
 		task.Assignee = createAssignee
 	}
 	if len(createLabels) > 0 {
 		task.Labels = createLabels
 	}
+	if createDue != "" {
+		due, err := parseDueDate(createDue, time.Now())
+		if err != nil {
+			return err
+		}
+		task.DueAt = &due
+	}
+	if createEstimate != "" {
+		estimate, err := parseEffort(createEstimate)
+		if err != nil {
+			return err
+		}
+		task.Estimate = estimate
+	}
+	if createRelease != "" {
+		if err := targetRelease(db.GetDB(), createRelease); err != nil {
+			return err
+		}
+		task.Release = createRelease
+	}
 
 	// Validate priority range
 	if task.Priority < 0 || task.Priority > 4 {
@@ -103,11 +173,16 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	database := db.GetDB()
 
+	fields, err := resolveFieldAssignments(database, createFields)
+	if err != nil {
+		return err
+	}
+
 	// Handle subtask creation
 	if createParent != "" {
 		var parent models.Task
 		if err := database.First(&parent, "id = ?", createParent).Error; err != nil {
-			return fmt.Errorf("cannot create subtask: parent task '%s' not found (use 'gur list' to see available tasks)", createParent)
+			return codedErrorf(ErrCodeNotFound, "cannot create subtask: parent task '%s' not found (use 'gur list' to see available tasks)", createParent)
 		}
 		if parent.IsClosed() {
 			return fmt.Errorf("cannot create subtask: parent task '%s' is closed (reopen it first with 'gur reopen %s')", createParent, createParent)
@@ -120,9 +195,19 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		task.ParentID = createParent
 	}
 
+	limits := loadSizeLimits()
+	if task.Description, err = limits.enforce(task.Title, "description", task.Description, limits.Description); err != nil {
+		return err
+	}
+
 	if err := database.Create(task).Error; err != nil {
 		return fmt.Errorf("failed to create task '%s': database error: %w", task.Title, err)
 	}
+	noteAffected(task.ID)
+
+	if err := applyFieldAssignments(database, task.ID, fields, ""); err != nil {
+		return err
+	}
 
 	// Link skills
 	for _, skillName := range createSkills {
@@ -150,10 +235,30 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	var suggestions []suggestion
+	if createSuggest {
+		var err error
+		if suggestions, err = suggestForTask(*task, suggestLimit); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to compute suggestions: %v\n", err)
+		}
+	}
+
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"success": true, "task": task})
+		result := map[string]interface{}{"success": true, "task": task}
+		if createSuggest {
+			result["suggestions"] = suggestions
+		}
+		OutputJSON(result)
+	} else if IsQuietOutput() {
+		printQuietIDs(task.ID)
 	} else {
 		fmt.Printf("Created: %s - %s\n", task.ID, task.Title)
+		if createSuggest {
+			printSuggestions(suggestions)
+			if len(suggestions) > 0 {
+				fmt.Printf("Link them with: gur suggest %s --link\n", task.ID)
+			}
+		}
 	}
-	return nil
+	return fireHook(hookOnCreate, hookPayload{Task: task})
 }
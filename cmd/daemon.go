@@ -0,0 +1,468 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+	"guardrails/internal/watch"
+)
+
+const (
+	// daemonConfigFile holds the daemon's automations, in the .guardrails directory
+	daemonConfigFile = "daemon.json"
+	// daemonActor is recorded as the actor of commands the daemon runs
+	daemonActor = "daemon"
+	// EnvWebhookSecret is the GitHub webhook secret used to verify deliveries
+	EnvWebhookSecret = "GUR_WEBHOOK_SECRET"
+
+	defaultDaemonPollInterval = 2 * time.Second
+)
+
+var daemonConfigPath string
+
+var daemonCmd = &cobra.Command{
+	Use:         "daemon",
+	Short:       "Watch the repository and run configured automations",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Run a local automation engine for the backlog until interrupted.
+
+Automations are configured in .guardrails/daemon.json (or --config):
+
+  {
+    "poll_interval": "2s",
+    "watch": [
+      {"path": "internal/", "gates": ["test", "gate-abc12345"]}
+    ],
+    "sync_on_close": true,
+    "jobs": [
+      {"every": "24h", "args": ["stale", "--threshold", "14d", "--action", "label"]}
+    ],
+    "webhook": {"addr": "127.0.0.1:8766"}
+  }
+
+watch          When files under path change, run the listed automated gates
+               (by ID or type) for every open task they are linked to, as
+               'gur gate run <gate> <task> --by daemon'
+sync_on_close  Push each task closed since the last check with 'gur sync push <id>'
+jobs           Run a gur command periodically (durations like 30m, 24h, 7d)
+webhook        Listen for GitHub webhook deliveries (issues, issue_comment)
+               and run 'gur sync pull --force'; deliveries are verified with
+               the secret in $GUR_WEBHOOK_SECRET
+
+Files are polled every poll_interval; hidden directories such as .git are
+ignored. Commands run from the project root with GUR_ACTOR=daemon, so they
+appear in 'gur events' as the daemon's.
+
+Examples:
+  gur daemon
+  gur daemon --config ci/daemon.json
+  GUR_WEBHOOK_SECRET=s3cret gur daemon`,
+	Args: cobra.NoArgs,
+	RunE: runDaemon,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.Flags().StringVar(&daemonConfigPath, "config", "", "Automation config file (default: .guardrails/daemon.json)")
+}
+
+// daemonWatchRule runs gates when files under Path change
+type daemonWatchRule struct {
+	Path  string   `json:"path"`
+	Gates []string `json:"gates"` // Gate IDs or types
+}
+
+// daemonJob runs a gur command periodically
+type daemonJob struct {
+	Every string   `json:"every"`
+	Args  []string `json:"args"`
+
+	every time.Duration
+}
+
+// daemonWebhook configures the GitHub webhook listener
+type daemonWebhook struct {
+	Addr string `json:"addr"`
+}
+
+// daemonConfig is the contents of daemon.json
+type daemonConfig struct {
+	PollInterval string            `json:"poll_interval,omitempty"`
+	Watch        []daemonWatchRule `json:"watch,omitempty"`
+	SyncOnClose  bool              `json:"sync_on_close,omitempty"`
+	Jobs         []daemonJob       `json:"jobs,omitempty"`
+	Webhook      *daemonWebhook    `json:"webhook,omitempty"`
+
+	pollInterval time.Duration
+}
+
+// parseDaemonDuration accepts positive durations in any form parseAnyDuration
+// does
+func parseDaemonDuration(s string) (time.Duration, error) {
+	d, err := parseAnyDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid duration '%s': must be positive", s)
+	}
+	return d, nil
+}
+
+// parseDaemonConfig decodes and validates a daemon config
+func parseDaemonConfig(data []byte) (*daemonConfig, error) {
+	var cfg daemonConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid daemon config: %w", err)
+	}
+
+	cfg.pollInterval = defaultDaemonPollInterval
+	if cfg.PollInterval != "" {
+		d, err := parseDaemonDuration(cfg.PollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid daemon config: poll_interval: %w", err)
+		}
+		cfg.pollInterval = d
+	}
+	for i, rule := range cfg.Watch {
+		if rule.Path == "" || len(rule.Gates) == 0 {
+			return nil, fmt.Errorf("invalid daemon config: watch[%d] needs a path and at least one gate", i)
+		}
+	}
+	for i := range cfg.Jobs {
+		job := &cfg.Jobs[i]
+		if len(job.Args) == 0 {
+			return nil, fmt.Errorf("invalid daemon config: jobs[%d] needs args", i)
+		}
+		if job.Args[0] == "daemon" {
+			return nil, fmt.Errorf("invalid daemon config: jobs[%d] cannot start another daemon", i)
+		}
+		d, err := parseDaemonDuration(job.Every)
+		if err != nil {
+			return nil, fmt.Errorf("invalid daemon config: jobs[%d].every: %w", i, err)
+		}
+		job.every = d
+	}
+	if cfg.Webhook != nil && cfg.Webhook.Addr == "" {
+		return nil, fmt.Errorf("invalid daemon config: webhook needs an addr")
+	}
+	return &cfg, nil
+}
+
+// daemonRunFunc runs a gur command with the given arguments
+type daemonRunFunc func(ctx context.Context, args []string) error
+
+// daemon runs automations against the project rooted at root
+type daemon struct {
+	database *gorm.DB
+	root     string
+	cfg      *daemonConfig
+	run      daemonRunFunc
+	out      io.Writer
+
+	snapshot watch.Snapshot
+	since    time.Time   // Closes up to this time have been synced
+	nextJob  []time.Time // When each job runs next
+}
+
+// newDaemon snapshots the tree and schedules jobs from now
+func newDaemon(database *gorm.DB, root string, cfg *daemonConfig, run daemonRunFunc, out io.Writer, now time.Time) (*daemon, error) {
+	snapshot, err := watch.Scan(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	d := &daemon{database: database, root: root, cfg: cfg, run: run, out: out, snapshot: snapshot, since: now}
+	for _, job := range cfg.Jobs {
+		d.nextJob = append(d.nextJob, now.Add(job.every))
+	}
+	return d, nil
+}
+
+func (d *daemon) logf(format string, args ...interface{}) {
+	fmt.Fprintf(d.out, "%s %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+}
+
+// exec runs a command, logging rather than returning failures so one
+// broken automation doesn't stop the others
+func (d *daemon) exec(ctx context.Context, args ...string) {
+	d.logf("running: gur %s", strings.Join(args, " "))
+	if err := d.run(ctx, args); err != nil {
+		d.logf("failed: gur %s: %v", strings.Join(args, " "), err)
+	}
+}
+
+// tick runs every automation that is due at now
+func (d *daemon) tick(ctx context.Context, now time.Time) {
+	if len(d.cfg.Watch) > 0 {
+		if err := d.checkFiles(ctx); err != nil {
+			d.logf("watch: %v", err)
+		}
+	}
+	if d.cfg.SyncOnClose {
+		if err := d.checkCloses(ctx, now); err != nil {
+			d.logf("sync on close: %v", err)
+		}
+	}
+	d.runJobs(ctx, now)
+}
+
+// gateTask is a gate to run for a task
+type gateTask struct {
+	GateID string
+	TaskID string
+}
+
+// checkFiles runs the watched gates whose paths have changed files
+func (d *daemon) checkFiles(ctx context.Context) error {
+	cur, err := watch.Scan(d.root)
+	if err != nil {
+		return err
+	}
+	changed := watch.Changed(d.snapshot, cur)
+	d.snapshot = cur
+	if len(changed) == 0 {
+		return nil
+	}
+
+	seen := make(map[gateTask]bool)
+	var runs []gateTask
+	for _, rule := range d.cfg.Watch {
+		var matched []string
+		for _, path := range changed {
+			if watch.Under(path, rule.Path) {
+				matched = append(matched, path)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		d.logf("%d file(s) changed under %s", len(matched), rule.Path)
+		pairs, err := watchedGateRuns(d.database, rule.Gates)
+		if err != nil {
+			return fmt.Errorf("failed to find gates for %s: database error: %w", rule.Path, err)
+		}
+		for _, p := range pairs {
+			if !seen[p] {
+				seen[p] = true
+				runs = append(runs, p)
+			}
+		}
+	}
+	for _, p := range runs {
+		d.exec(ctx, "gate", "run", p.GateID, p.TaskID, "--by", daemonActor)
+	}
+	return nil
+}
+
+// watchedGateRuns returns the automated gates matching gates (IDs or
+// types) paired with each task they are linked to that isn't closed
+func watchedGateRuns(database *gorm.DB, gates []string) ([]gateTask, error) {
+	var ids, types []string
+	for _, g := range gates {
+		if models.ValidateGateID(g) {
+			ids = append(ids, g)
+		} else {
+			types = append(types, strings.ToLower(g))
+		}
+	}
+
+	var pairs []gateTask
+	err := database.Model(&models.GateTaskLink{}).
+		Select("gate_task_links.gate_id, gate_task_links.task_id").
+		Joins("JOIN gates ON gates.id = gate_task_links.gate_id AND gates.deleted_at IS NULL").
+		Joins("JOIN tasks ON tasks.id = gate_task_links.task_id AND tasks.deleted_at IS NULL").
+		Where("tasks.status != ? AND gates.command != ''", models.StatusClosed).
+		Where(database.Where("gates.id IN ?", append(ids, "")).Or("LOWER(gates.type) IN ?", append(types, ""))).
+		Order("gate_task_links.task_id ASC, gate_task_links.gate_id ASC").
+		Scan(&pairs).Error
+	return pairs, err
+}
+
+// checkCloses pushes the tasks closed since the last check
+func (d *daemon) checkCloses(ctx context.Context, now time.Time) error {
+	var taskIDs []string
+	err := d.database.Model(&models.TaskHistory{}).
+		Distinct("task_id").
+		Where("field = ? AND new_value = ? AND changed_at > ? AND changed_at <= ?", "status", models.StatusClosed, d.since, now).
+		Order("task_id ASC").
+		Pluck("task_id", &taskIDs).Error
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	d.since = now
+	for _, id := range taskIDs {
+		d.exec(ctx, "sync", "push", id)
+	}
+	return nil
+}
+
+// runJobs runs the periodic jobs that are due
+func (d *daemon) runJobs(ctx context.Context, now time.Time) {
+	for i, job := range d.cfg.Jobs {
+		if now.Before(d.nextJob[i]) {
+			continue
+		}
+		d.nextJob[i] = now.Add(job.every)
+		d.exec(ctx, job.Args...)
+	}
+}
+
+// webhookHandler accepts GitHub webhook deliveries signed with secret and
+// signals pull for issue activity
+func webhookHandler(secret string, pull chan<- struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if !validWebhookSignature(secret, body, r.Header.Get("X-Hub-Signature-256")) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		switch r.Header.Get("X-GitHub-Event") {
+		case "issues", "issue_comment":
+			select {
+			case pull <- struct{}{}:
+			default: // A pull is already pending
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// validWebhookSignature checks a GitHub "sha256=<hex>" HMAC signature
+func validWebhookSignature(secret string, body []byte, signature string) bool {
+	sig, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// daemonRunner runs gur commands as subprocesses of this binary from root,
+// so each automation records its own events and history
+func daemonRunner(root string, out io.Writer) (daemonRunFunc, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("cannot locate the gur executable: %w", err)
+	}
+	return func(ctx context.Context, args []string) error {
+		if path := db.DBPathOverride(); path != "" {
+			args = append([]string{"--db", path}, args...)
+		}
+		c := exec.CommandContext(ctx, exe, args...)
+		c.Dir = root
+		c.Env = append(os.Environ(), EnvActor+"="+daemonActor)
+		c.Stdout = out
+		c.Stderr = out
+		return c.Run()
+	}, nil
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+	path := daemonConfigPath
+	if path == "" {
+		path = filepath.Join(root, db.GuardrailsDir, daemonConfigFile)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no daemon config at %s (see 'gur daemon --help' for the format)", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read daemon config: %w", err)
+	}
+	cfg, err := parseDaemonConfig(data)
+	if err != nil {
+		return err
+	}
+
+	var secret string
+	if cfg.Webhook != nil {
+		if secret = os.Getenv(EnvWebhookSecret); secret == "" {
+			return fmt.Errorf("webhook requires a secret: set %s to the secret configured on GitHub", EnvWebhookSecret)
+		}
+	}
+
+	run, err := daemonRunner(root, os.Stdout)
+	if err != nil {
+		return err
+	}
+	d, err := newDaemon(db.GetDB(), root, cfg, run, os.Stdout, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pull := make(chan struct{}, 1)
+	errc := make(chan error, 1)
+	if cfg.Webhook != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/webhook", webhookHandler(secret, pull))
+		server := &http.Server{Addr: cfg.Webhook.Addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() { errc <- server.ListenAndServe() }()
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(shutdownCtx)
+		}()
+		d.logf("listening for GitHub webhooks at http://%s/webhook", cfg.Webhook.Addr)
+	}
+
+	d.logf("watching %s (%d watch rule(s), %d job(s), sync on close: %t); Ctrl+C to stop",
+		root, len(cfg.Watch), len(cfg.Jobs), cfg.SyncOnClose)
+	ticker := time.NewTicker(cfg.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			d.logf("stopping")
+			return nil
+		case err := <-errc:
+			return fmt.Errorf("webhook server failed: %w", err)
+		case <-pull:
+			d.exec(ctx, "sync", "pull", "--force")
+		case now := <-ticker.C:
+			d.tick(ctx, now)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestParseDaemonConfig(t *testing.T) {
+	cfg, err := parseDaemonConfig([]byte(`{
+		"poll_interval": "500ms",
+		"watch": [{"path": "src/", "gates": ["test"]}],
+		"sync_on_close": true,
+		"jobs": [{"every": "1d", "args": ["stale", "--action", "label"]}]
+	}`))
+	if err != nil {
+		t.Fatalf("parseDaemonConfig() error: %v", err)
+	}
+	if cfg.pollInterval != 500*time.Millisecond || cfg.Jobs[0].every != 24*time.Hour {
+		t.Errorf("durations = %s, %s; want 500ms, 24h", cfg.pollInterval, cfg.Jobs[0].every)
+	}
+
+	invalid := []string{
+		`{"watch": [{"path": "src/"}]}`,
+		`{"jobs": [{"every": "1h"}]}`,
+		`{"jobs": [{"every": "soon", "args": ["stale"]}]}`,
+		`{"jobs": [{"every": "1h", "args": ["daemon"]}]}`,
+		`{"webhook": {}}`,
+		`{"unknown": true}`,
+	}
+	for _, in := range invalid {
+		if _, err := parseDaemonConfig([]byte(in)); err == nil {
+			t.Errorf("parseDaemonConfig(%s) should fail", in)
+		}
+	}
+}
+
+// recordingRunner records the commands a daemon runs
+func recordingRunner(calls *[]string) daemonRunFunc {
+	return func(ctx context.Context, args []string) error {
+		*calls = append(*calls, strings.Join(args, " "))
+		return nil
+	}
+}
+
+func TestDaemonRunsWatchedGates(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-daemon01", Title: "Open", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-daemon02", Title: "Closed", Status: models.StatusClosed})
+	database.Create(&models.Gate{ID: "gate-0000d001", Title: "Unit", Type: "test", Command: "go test ./..."})
+	database.Create(&models.Gate{ID: "gate-0000d002", Title: "Manual", Type: "test"})
+	database.Create(&models.Gate{ID: "gate-0000d003", Title: "Lint", Type: "lint", Command: "go vet ./..."})
+	for _, l := range []models.GateTaskLink{
+		{GateID: "gate-0000d001", TaskID: "gur-daemon01"},
+		{GateID: "gate-0000d002", TaskID: "gur-daemon01"},
+		{GateID: "gate-0000d003", TaskID: "gur-daemon01"},
+		{GateID: "gate-0000d001", TaskID: "gur-daemon02"},
+	} {
+		database.Create(&l)
+	}
+
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "src"), 0755)
+	os.MkdirAll(filepath.Join(root, "docs"), 0755)
+	cfg, err := parseDaemonConfig([]byte(`{"watch": [
+		{"path": "src", "gates": ["TEST"]},
+		{"path": "src", "gates": ["gate-0000d001", "gate-0000d003"]}
+	]}`))
+	if err != nil {
+		t.Fatalf("parseDaemonConfig() error: %v", err)
+	}
+	var calls []string
+	d, err := newDaemon(database, root, cfg, recordingRunner(&calls), io.Discard, time.Now())
+	if err != nil {
+		t.Fatalf("newDaemon() error: %v", err)
+	}
+
+	// Changes outside the watched path run nothing
+	os.WriteFile(filepath.Join(root, "docs", "readme.md"), []byte("docs"), 0644)
+	d.tick(context.Background(), time.Now())
+	if len(calls) != 0 {
+		t.Fatalf("unwatched change ran %v", calls)
+	}
+
+	os.WriteFile(filepath.Join(root, "src", "main.go"), []byte("package main"), 0644)
+	d.tick(context.Background(), time.Now())
+	want := []string{
+		"gate run gate-0000d001 gur-daemon01 --by daemon",
+		"gate run gate-0000d003 gur-daemon01 --by daemon",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	// No further changes, no further runs
+	d.tick(context.Background(), time.Now())
+	if len(calls) != len(want) {
+		t.Errorf("unchanged tree ran %v", calls[len(want):])
+	}
+}
+
+func TestDaemonSyncsClosesAndRunsJobs(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	cfg, err := parseDaemonConfig([]byte(`{"sync_on_close": true, "jobs": [{"every": "1h", "args": ["stale", "--action", "label"]}]}`))
+	if err != nil {
+		t.Fatalf("parseDaemonConfig() error: %v", err)
+	}
+	start := time.Now()
+	var calls []string
+	d, err := newDaemon(database, t.TempDir(), cfg, recordingRunner(&calls), io.Discard, start.Add(-time.Second))
+	if err != nil {
+		t.Fatalf("newDaemon() error: %v", err)
+	}
+
+	models.RecordChange(database, "gur-closed01", "status", models.StatusOpen, models.StatusClosed, "user")
+	models.RecordChange(database, "gur-closed01", "close_reason", "", "Done", "user")
+	models.RecordChange(database, "gur-inprog01", "status", models.StatusOpen, models.StatusInProgress, "user")
+
+	d.tick(context.Background(), time.Now())
+	if want := []string{"sync push gur-closed01"}; !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+
+	// Closes already synced are not pushed again; the job runs once due
+	calls = nil
+	d.tick(context.Background(), start.Add(time.Hour))
+	if want := []string{"stale --action label"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	calls = nil
+	d.tick(context.Background(), start.Add(90*time.Minute))
+	if len(calls) != 0 {
+		t.Errorf("job ran again before it was due: %v", calls)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	pull := make(chan struct{}, 1)
+	server := httptest.NewServer(webhookHandler("s3cret", pull))
+	defer server.Close()
+
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	post := func(event, body, signature string) int {
+		req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-Hub-Signature-256", signature)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST error: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := post("issues", `{"action":"opened"}`, sign(`{"action":"other"}`)); code != http.StatusUnauthorized {
+		t.Errorf("bad signature: status %d, want 401", code)
+	}
+	if code := post("ping", `{}`, sign(`{}`)); code != http.StatusNoContent || len(pull) != 0 {
+		t.Errorf("ping: status %d, pending pulls %d; want 204, 0", code, len(pull))
+	}
+	if code := post("issues", `{"action":"opened"}`, sign(`{"action":"opened"}`)); code != http.StatusNoContent || len(pull) != 1 {
+		t.Errorf("issues: status %d, pending pulls %d; want 204, 1", code, len(pull))
+	}
+	// A second delivery while a pull is pending doesn't block
+	if code := post("issue_comment", `{}`, sign(`{}`)); code != http.StatusNoContent || len(pull) != 1 {
+		t.Errorf("issue_comment: status %d, pending pulls %d; want 204, 1", code, len(pull))
+	}
+}
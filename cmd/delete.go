@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var deleteDetachRemote bool
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <id>...",
+	Short: "Delete tasks",
+	Long: `Delete one or more tasks. Their dependencies, gate links and other records
+are left for 'gur cleanup' to remove. A task with subtasks can only be
+deleted together with them.
+
+A task synced to GitHub is refused, since its issue would stay open with
+nothing behind it. With --detach-remote the issue gets a comment saying the
+task was deleted, is closed as not planned, and its link is removed.
+
+Examples:
+  gur delete gur-abc123
+  gur delete gur-abc123 gur-abc123.1
+  gur delete gur-abc123 --detach-remote`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(deleteCmd)
+	deleteCmd.Flags().BoolVar(&deleteDetachRemote, "detach-remote", false, "Comment on and close linked GitHub issues, then delete")
+}
+
+// detachRemoteIssue tells a linked issue that its task was deleted by
+// command and closes it if it is still open
+func detachRemoteIssue(ctx context.Context, client *github.Client, link models.GitHubIssueLink, command string) error {
+	owner, repo, ok := strings.Cut(link.Repository, "/")
+	if !ok {
+		return fmt.Errorf("invalid repository '%s': expected 'owner/repo'", link.Repository)
+	}
+	issue, _, err := client.Issues.Get(ctx, owner, repo, link.IssueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	body := fmt.Sprintf("Task `%s` was deleted with `%s`; this issue is no longer synced with it.", link.TaskID, command)
+	if _, _, err := client.Issues.CreateComment(ctx, owner, repo, link.IssueNumber, &github.IssueComment{Body: &body}); err != nil {
+		return fmt.Errorf("failed to comment: %w", err)
+	}
+	if issue.GetState() == "closed" {
+		return nil
+	}
+	state, reason := "closed", "not_planned"
+	if _, _, err := client.Issues.Edit(ctx, owner, repo, link.IssueNumber, &github.IssueRequest{State: &state, StateReason: &reason}); err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+	return nil
+}
+
+// deleteTask soft-deletes a task with its issue link and queued pushes
+func deleteTask(database *gorm.DB, task models.Task) error {
+	return database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", task.ID).Delete(&models.GitHubIssueLink{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id = ?", task.ID).Delete(&models.GitHubOutboxEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&task).Error
+	})
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	release, err := lockWorkspace("gur delete")
+	if err != nil {
+		return err
+	}
+	defer release()
+	database := db.GetDB()
+
+	var tasks []models.Task
+	deleting := make(map[string]bool)
+	for _, id := range args {
+		task, err := db.GetTaskByID(id)
+		if err != nil {
+			return codedErrorf(ErrCodeNotFound, "cannot delete task: task '%s' not found (use 'gur list' to see available tasks)", id)
+		}
+		if !deleting[task.ID] {
+			deleting[task.ID] = true
+			tasks = append(tasks, *task)
+		}
+	}
+
+	links := make(map[string]models.GitHubIssueLink)
+	for _, task := range tasks {
+		var subtasks []models.Task
+		if err := database.Where("parent_id = ?", task.ID).Find(&subtasks).Error; err != nil {
+			return fmt.Errorf("failed to check subtasks of '%s': database error: %w", task.ID, err)
+		}
+		for _, s := range subtasks {
+			if !deleting[s.ID] {
+				return fmt.Errorf("cannot delete task '%s': subtask '%s' would be left without its parent (delete the subtask too)", task.ID, s.ID)
+			}
+		}
+		var link models.GitHubIssueLink
+		if database.Where("task_id = ?", task.ID).First(&link).Error != nil {
+			continue
+		}
+		if !deleteDetachRemote {
+			return codedErrorf(ErrCodeRemoteLinked, "cannot delete task '%s': it is synced to GitHub issue %s#%d (close the task instead, or pass --detach-remote to comment on and close the issue)", task.ID, link.Repository, link.IssueNumber)
+		}
+		links[task.ID] = link
+	}
+
+	var client *github.Client
+	if len(links) > 0 {
+		token, err := GetGitHubToken()
+		if err != nil {
+			return err
+		}
+		if client, err = newGitHubClient(token); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	type deleted struct {
+		TaskID   string `json:"task_id"`
+		IssueURL string `json:"detached_issue,omitempty"`
+	}
+	var results []deleted
+	for _, task := range tasks {
+		result := deleted{TaskID: task.ID}
+		if link, ok := links[task.ID]; ok {
+			if err := detachRemoteIssue(ctx, client, link, "gur delete --detach-remote"); err != nil {
+				return fmt.Errorf("failed to detach task '%s' from issue %s#%d: %w", task.ID, link.Repository, link.IssueNumber, err)
+			}
+			result.IssueURL = link.IssueURL
+		}
+		if err := deleteTask(database, task); err != nil {
+			return fmt.Errorf("failed to delete task '%s': database error: %w", task.ID, err)
+		}
+		noteAffected(task.ID)
+		results = append(results, result)
+		if !IsJSONOutput() {
+			if result.IssueURL != "" {
+				fmt.Printf("Deleted: %s (closed %s)\n", task.ID, result.IssueURL)
+			} else {
+				fmt.Printf("Deleted: %s\n", task.ID)
+			}
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "deleted": results})
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestRunDeleteRefusesLinkedTask(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-de100001", Title: "Synced", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-de100002", Title: "Parent", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-de100002.1", ParentID: "gur-de100002", Title: "Child", Status: models.StatusOpen})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-de100001", IssueNumber: 4, Repository: "acme/app"})
+
+	deleteDetachRemote = false
+	err := runDelete(deleteCmd, []string{"gur-de100001"})
+	if errorCodeOf(err) != ErrCodeRemoteLinked || !strings.Contains(err.Error(), "acme/app#4") {
+		t.Fatalf("delete of a synced task = %v, want %s naming the issue", err, ErrCodeRemoteLinked)
+	}
+	if err := runDelete(deleteCmd, []string{"gur-de100002"}); err == nil || !strings.Contains(err.Error(), "gur-de100002.1") {
+		t.Fatalf("delete of a parent alone = %v, want it refused for the subtask", err)
+	}
+	if _, err := db.GetTaskByID("gur-de100001"); err != nil {
+		t.Error("refused delete removed the synced task")
+	}
+
+	if err := runDelete(deleteCmd, []string{"gur-de100002", "gur-de100002.1"}); err != nil {
+		t.Fatalf("delete with subtask = %v", err)
+	}
+	if _, err := db.GetTaskByID("gur-de100002.1"); err == nil {
+		t.Error("subtask still exists after delete")
+	}
+}
+
+func TestDetachRemoteIssue(t *testing.T) {
+	var comment string
+	closed := false
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, r.ContentLength)
+		r.Body.Read(body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/app/issues/4":
+			w.Write([]byte(`{"number": 4, "state": "open"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/issues/4/comments":
+			comment = string(body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 1}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/repos/acme/app/issues/4":
+			closed = strings.Contains(string(body), `"not_planned"`)
+			w.Write([]byte(`{"number": 4, "state": "closed"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	link := models.GitHubIssueLink{TaskID: "gur-de100001", IssueNumber: 4, Repository: "acme/app"}
+	if err := detachRemoteIssue(context.Background(), client, link, "gur delete --detach-remote"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(comment, "gur-de100001") || !closed {
+		t.Errorf("comment = %q, closed = %v; want the task named and the issue closed as not planned", comment, closed)
+	}
+}
+
+func TestCleanupKeepsGitHubLinks(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-de100009", IssueNumber: 9, Repository: "acme/app"})
+
+	cleanupDryRun, cleanupDetachRemote = false, false
+	if err := runCleanup(cleanupCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	database.Model(&models.GitHubIssueLink{}).Count(&count)
+	if count != 1 {
+		t.Errorf("cleanup removed the link of a deleted task without --detach-remote")
+	}
+}
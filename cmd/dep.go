@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gorm.io/gorm"
@@ -18,7 +19,7 @@ var depCmd = &cobra.Command{
 }
 
 var depAddCmd = &cobra.Command{
-	Use:   "add <blocker-id> <blocked-id>",
+	Use:   "add <blocker-id> <blocked-id> [lag]",
 	Short: "Add dependency: first task blocks the second",
 	Long: `Add a dependency where the first task BLOCKS the second task.
 
@@ -28,8 +29,20 @@ Example: If Task B cannot start until Task A is done:
 This means:
   - Task A is the BLOCKER (must be done first)
   - Task B is BLOCKED (waiting on Task A)
-  - Task B will NOT appear in 'gur ready' until Task A is closed`,
-	Args: cobra.ExactArgs(2),
+  - Task B will NOT appear in 'gur ready' until Task A is closed
+
+Types (--type) change how the dependency affects 'gur ready':
+  blocks                  B is not ready until A closes (default)
+  soft-blocks             B stays ready, but is flagged while A is open
+  finish-to-start-after   B is not ready until the lag (e.g., 24h, 2d) has
+                          passed since A closed
+  related, parent-child   no effect on ready
+
+Examples:
+  gur dep add gur-aaa gur-bbb
+  gur dep add gur-aaa gur-bbb --type soft-blocks
+  gur dep add gur-migrate gur-cleanup --type finish-to-start-after 48h`,
+	Args: cobra.RangeArgs(2, 3),
 	RunE: runDepAdd,
 }
 
@@ -41,10 +54,11 @@ var depRemoveCmd = &cobra.Command{
 }
 
 var depListCmd = &cobra.Command{
-	Use:   "list <id>",
-	Short: "List dependencies for a task",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDepList,
+	Use:         "list <id>",
+	Short:       "List dependencies for a task",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runDepList,
 }
 
 func init() {
@@ -53,51 +67,92 @@ func init() {
 	depCmd.AddCommand(depRemoveCmd)
 	depCmd.AddCommand(depListCmd)
 
-	depAddCmd.Flags().StringVarP(&depType, "type", "t", "blocks", "Type (blocks/related/parent-child)")
+	depAddCmd.Flags().StringVarP(&depType, "type", "t", "blocks", "Type (blocks/soft-blocks/finish-to-start-after/related/parent-child)")
 }
 
-// wouldCreateCycle checks if adding blockerID -> blockedID would create a cycle
-// by checking if blockedID can reach blockerID through existing dependencies
+// wouldCreateCycle checks if adding blockerID -> blockedID would create a cycle,
+// i.e. whether blockerID already depends on blockedID
 func wouldCreateCycle(database *gorm.DB, blockerID, blockedID string) bool {
-	// BFS to check if blockedID can reach blockerID
-	visited := make(map[string]bool)
-	queue := []string{blockedID}
+	g, err := loadDepGraph(database)
+	if err != nil {
+		return false
+	}
+	return g.path(blockerID, blockedID) != nil
+}
 
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+// formatLag renders a lag in minutes in the units parseDuration accepts
+func formatLag(minutes int) string {
+	switch {
+	case minutes > 0 && minutes%(7*24*60) == 0:
+		return fmt.Sprintf("%dw", minutes/(7*24*60))
+	case minutes > 0 && minutes%(24*60) == 0:
+		return fmt.Sprintf("%dd", minutes/(24*60))
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
 
-		if visited[current] {
-			continue
+// depEffect explains how a dependency affects whether its child is ready,
+// given the parent task as it is now
+func depEffect(d models.Dependency, parent *models.Task, now time.Time) (effect string, satisfied bool) {
+	var closedAt *time.Time
+	if parent != nil && parent.Status == models.StatusClosed {
+		closedAt = parent.ClosedAt
+	}
+	switch d.Type {
+	case models.DepTypeBlocks:
+		if closedAt != nil {
+			return fmt.Sprintf("satisfied: %s closed", d.ParentID), true
 		}
-		visited[current] = true
-
-		// Find all tasks that 'current' blocks (where current is the parent/blocker)
-		var deps []models.Dependency
-		database.Where("parent_id = ?", current).Find(&deps)
-
-		for _, dep := range deps {
-			if dep.ChildID == blockerID {
-				// blockedID can reach blockerID - cycle detected
-				return true
-			}
-			if !visited[dep.ChildID] {
-				queue = append(queue, dep.ChildID)
-			}
+		return fmt.Sprintf("%s is not ready until %s closes", d.ChildID, d.ParentID), false
+	case models.DepTypeSoftBlocks:
+		if closedAt != nil {
+			return fmt.Sprintf("satisfied: %s closed", d.ParentID), true
 		}
+		return fmt.Sprintf("%s is ready, but flagged until %s closes", d.ChildID, d.ParentID), false
+	case models.DepTypeFinishStart:
+		at, ok := d.ReadyAt(closedAt)
+		switch {
+		case !ok:
+			return fmt.Sprintf("%s is not ready until %s after %s closes", d.ChildID, formatLag(d.Lag), d.ParentID), false
+		case at.After(now):
+			return fmt.Sprintf("%s is not ready until %s (%s after %s closed)", d.ChildID, at.Format(models.DateTimeShortFormat), formatLag(d.Lag), d.ParentID), false
+		default:
+			return fmt.Sprintf("satisfied: %s after %s closed", formatLag(d.Lag), d.ParentID), true
+		}
+	default:
+		return "no effect on ready", true
 	}
-	return false
 }
 
 func runDepAdd(cmd *cobra.Command, args []string) error {
 	blockerID, blockedID := args[0], args[1]
 	database := db.GetDB()
 
+	if err := models.ValidateDepType(depType); err != nil {
+		return err
+	}
+	lag := 0
+	if depType == models.DepTypeFinishStart {
+		if len(args) < 3 {
+			return fmt.Errorf("--type %s needs a lag (e.g., gur dep add %s %s --type %s 24h)", depType, blockerID, blockedID, depType)
+		}
+		d, err := parseDuration(args[2])
+		if err != nil {
+			return err
+		}
+		lag = int(d / time.Minute)
+	} else if len(args) == 3 {
+		return fmt.Errorf("a lag is only used with --type %s", models.DepTypeFinishStart)
+	}
+
 	if _, err := db.GetTaskByID(blockerID); err != nil {
-		return fmt.Errorf("cannot add dependency: blocker task '%s' not found (use 'gur list' to see available tasks)", blockerID)
+		return codedErrorf(ErrCodeNotFound, "cannot add dependency: blocker task '%s' not found (use 'gur list' to see available tasks)", blockerID)
 	}
 	if _, err := db.GetTaskByID(blockedID); err != nil {
-		return fmt.Errorf("cannot add dependency: blocked task '%s' not found (use 'gur list' to see available tasks)", blockedID)
+		return codedErrorf(ErrCodeNotFound, "cannot add dependency: blocked task '%s' not found (use 'gur list' to see available tasks)", blockedID)
 	}
 
 	if blockerID == blockedID {
@@ -114,6 +169,7 @@ func runDepAdd(cmd *cobra.Command, args []string) error {
 		ChildID:  blockedID, // blocked task
 		ParentID: blockerID, // blocker task
 		Type:     depType,
+		Lag:      lag,
 	}
 
 	if err := database.Create(dep).Error; err != nil {
@@ -123,7 +179,11 @@ func runDepAdd(cmd *cobra.Command, args []string) error {
 	if IsJSONOutput() {
 		OutputJSON(map[string]interface{}{"success": true, "dependency": dep})
 	} else {
-		fmt.Printf("Added: %s blocks %s\n", blockerID, blockedID)
+		if depType == models.DepTypeFinishStart {
+			fmt.Printf("Added: %s starts %s after %s finishes\n", blockedID, formatLag(lag), blockerID)
+		} else {
+			fmt.Printf("Added: %s %s %s\n", blockerID, depType, blockedID)
+		}
 	}
 	return nil
 }
@@ -134,10 +194,10 @@ func runDepRemove(cmd *cobra.Command, args []string) error {
 
 	// Validate that both tasks exist
 	if _, err := db.GetTaskByID(blockerID); err != nil {
-		return fmt.Errorf("cannot remove dependency: blocker task '%s' not found", blockerID)
+		return codedErrorf(ErrCodeNotFound, "cannot remove dependency: blocker task '%s' not found", blockerID)
 	}
 	if _, err := db.GetTaskByID(blockedID); err != nil {
-		return fmt.Errorf("cannot remove dependency: blocked task '%s' not found", blockedID)
+		return codedErrorf(ErrCodeNotFound, "cannot remove dependency: blocked task '%s' not found", blockedID)
 	}
 
 	result := database.Where("parent_id = ? AND child_id = ?", blockerID, blockedID).Delete(&models.Dependency{})
@@ -162,19 +222,46 @@ func runDepList(cmd *cobra.Command, args []string) error {
 	database.Where("child_id = ?", taskID).Find(&blockedBy)
 	database.Where("parent_id = ?", taskID).Find(&blocks)
 
+	now := time.Now()
+	explain := func(deps []models.Dependency) []depEdge {
+		edges := make([]depEdge, len(deps))
+		for i, d := range deps {
+			parent, _ := db.GetTaskByID(d.ParentID)
+			edges[i].Dependency = d
+			edges[i].Effect, edges[i].Satisfied = depEffect(d, parent, now)
+		}
+		return edges
+	}
+	blockedByEdges, blocksEdges := explain(blockedBy), explain(blocks)
+
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"blocked_by": blockedBy, "blocks": blocks})
+		OutputJSON(map[string]interface{}{"blocked_by": blockedByEdges, "blocks": blocksEdges})
 		return nil
 	}
 
 	fmt.Printf("Dependencies for %s:\n", taskID)
-	fmt.Printf("\nBlocked by (%d):\n", len(blockedBy))
-	for _, d := range blockedBy {
-		fmt.Printf("  - %s (%s)\n", d.ParentID, d.Type)
+	fmt.Printf("\nBlocked by (%d):\n", len(blockedByEdges))
+	for _, e := range blockedByEdges {
+		fmt.Printf("  - %s (%s): %s\n", e.ParentID, depTypeLabel(e.Dependency), e.Effect)
 	}
-	fmt.Printf("\nBlocks (%d):\n", len(blocks))
-	for _, d := range blocks {
-		fmt.Printf("  - %s (%s)\n", d.ChildID, d.Type)
+	fmt.Printf("\nBlocks (%d):\n", len(blocksEdges))
+	for _, e := range blocksEdges {
+		fmt.Printf("  - %s (%s): %s\n", e.ChildID, depTypeLabel(e.Dependency), e.Effect)
 	}
 	return nil
 }
+
+// depEdge is a dependency with its effect on ready, for dep list
+type depEdge struct {
+	models.Dependency
+	Effect    string `json:"effect"`
+	Satisfied bool   `json:"satisfied"`
+}
+
+// depTypeLabel names a dependency's type, with the lag for finish-to-start
+func depTypeLabel(d models.Dependency) string {
+	if d.Type == models.DepTypeFinishStart {
+		return d.Type + " " + formatLag(d.Lag)
+	}
+	return d.Type
+}
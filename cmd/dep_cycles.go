@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var depCyclesBreakAt []string
+
+var depCyclesCmd = &cobra.Command{
+	Use:   "cycles",
+	Short: "Find dependency cycles already in the graph",
+	Long: `Find dependency cycles already in the graph. 'gur dep add' refuses to
+create one, but imported or hand-edited data can contain them, and tasks on
+a blocking cycle never become ready.
+
+Each group of tasks that depend on one another in a circle is reported once,
+with its shortest cycle written blocker first: a → b → a means a blocks b
+and b blocks a. Every dependency type except related is followed.
+
+--break-at <blocker>:<blocked> removes the dependency between two tasks on
+a cycle; several can be given and are removed together or not at all.
+
+Examples:
+  gur dep cycles
+  gur dep cycles --json
+  gur dep cycles --break-at gur-abc123:gur-def456`,
+	Args: cobra.NoArgs,
+	RunE: runDepCycles,
+}
+
+func init() {
+	depCmd.AddCommand(depCyclesCmd)
+	depCyclesCmd.Flags().StringSliceVar(&depCyclesBreakAt, "break-at", nil, "Remove the dependency <blocker>:<blocked> on a cycle (repeatable)")
+}
+
+// depCycle is a strongly connected group of tasks and its shortest cycle
+type depCycle struct {
+	Tasks     []string            `json:"tasks"`    // Every task in the group, sorted
+	Path      []string            `json:"path"`     // Shortest cycle, blocker first, ending where it starts
+	Edges     []models.Dependency `json:"edges"`    // Dependencies along Path
+	BreakHint string              `json:"break_at"` // An edge on Path for --break-at
+}
+
+// components returns the strongly connected components of the graph
+// (Tarjan's algorithm), following edges from blocker to blocked task
+func (g *depGraph) components() [][]string {
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var sccs [][]string
+	next := 0
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id], low[id] = next, next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, d := range g.dependents[id] {
+			w := d.ChildID
+			if _, seen := index[w]; !seen {
+				visit(w)
+				low[id] = min(low[id], low[w])
+			} else if onStack[w] {
+				low[id] = min(low[id], index[w])
+			}
+		}
+		if low[id] == index[id] {
+			var scc []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == id {
+					break
+				}
+			}
+			sccs = append(sccs, scc)
+		}
+	}
+
+	ids := make([]string, 0, len(g.dependents))
+	for id := range g.dependents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	return sccs
+}
+
+// cycles returns every group of tasks on a dependency cycle with its
+// shortest cycle, ordered by the group's first task ID
+func (g *depGraph) cycles() []depCycle {
+	var found []depCycle
+	for _, scc := range g.components() {
+		sort.Strings(scc)
+		var shortest []models.Dependency
+		for _, id := range scc {
+			for _, d := range g.dependsOn[id] {
+				// d makes id wait on its parent; the cycle closes through
+				// the parent depending back on id
+				chain := []models.Dependency{d}
+				if d.ParentID != id {
+					back := g.path(d.ParentID, id)
+					if back == nil {
+						continue
+					}
+					chain = append(chain, back...)
+				}
+				if shortest == nil || len(chain) < len(shortest) {
+					shortest = chain
+				}
+			}
+		}
+		if shortest == nil {
+			continue // A single task with no dependency on itself
+		}
+
+		// Write the cycle blocker first: each dependency's parent blocks
+		// its child, so walk the chain from its end
+		c := depCycle{Tasks: scc}
+		for i := len(shortest) - 1; i >= 0; i-- {
+			c.Edges = append(c.Edges, shortest[i])
+			c.Path = append(c.Path, shortest[i].ParentID)
+		}
+		c.Path = append(c.Path, c.Path[0])
+		last := c.Edges[len(c.Edges)-1]
+		c.BreakHint = last.ParentID + ":" + last.ChildID
+		found = append(found, c)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Tasks[0] < found[j].Tasks[0] })
+	return found
+}
+
+// parseDepEdge parses a <blocker>:<blocked> edge for --break-at
+func parseDepEdge(s string) (blocker, blocked string, err error) {
+	blocker, blocked, ok := strings.Cut(s, ":")
+	if !ok || blocker == "" || blocked == "" {
+		return "", "", fmt.Errorf("invalid edge '%s': expected <blocker>:<blocked>, e.g. gur-abc123:gur-def456", s)
+	}
+	return blocker, blocked, nil
+}
+
+// breakCycles removes the given <blocker>:<blocked> dependencies in one
+// transaction. Each must lie on a cycle: both tasks in the same group, not
+// necessarily on the shortest cycle shown.
+func breakCycles(database *gorm.DB, cycles []depCycle, edges []string) error {
+	return database.Transaction(func(tx *gorm.DB) error {
+		for _, e := range edges {
+			blocker, blocked, err := parseDepEdge(e)
+			if err != nil {
+				return err
+			}
+			if !sameCycle(cycles, blocker, blocked) {
+				return fmt.Errorf("cannot break at '%s': '%s' blocking '%s' is not on a dependency cycle (see 'gur dep cycles')", e, blocker, blocked)
+			}
+			result := tx.Where("parent_id = ? AND child_id = ? AND type IN ?", blocker, blocked, orderingDepTypes).Delete(&models.Dependency{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("cannot break at '%s': no dependency where '%s' blocks '%s' (use 'gur dep list %s' to see existing dependencies)", e, blocker, blocked, blocker)
+			}
+		}
+		return nil
+	})
+}
+
+// sameCycle reports whether a and b are in the same cycle group
+func sameCycle(cycles []depCycle, a, b string) bool {
+	for _, c := range cycles {
+		hasA, hasB := false, false
+		for _, id := range c.Tasks {
+			hasA = hasA || id == a
+			hasB = hasB || id == b
+		}
+		if hasA && hasB {
+			return true
+		}
+	}
+	return false
+}
+
+func runDepCycles(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	g, err := loadDepGraph(database, orderingDepTypes...)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: database error: %w", err)
+	}
+	cycles := g.cycles()
+
+	if len(depCyclesBreakAt) > 0 {
+		if err := breakCycles(database, cycles, depCyclesBreakAt); err != nil {
+			return err
+		}
+		if g, err = loadDepGraph(database, orderingDepTypes...); err != nil {
+			return fmt.Errorf("failed to reload dependencies: database error: %w", err)
+		}
+		cycles = g.cycles()
+	}
+
+	if IsJSONOutput() {
+		if cycles == nil {
+			cycles = []depCycle{}
+		}
+		result := map[string]interface{}{"count": len(cycles), "cycles": cycles}
+		if len(depCyclesBreakAt) > 0 {
+			result["removed"] = depCyclesBreakAt
+		}
+		OutputJSON(result)
+		return nil
+	}
+
+	for _, e := range depCyclesBreakAt {
+		blocker, blocked, _ := parseDepEdge(e)
+		fmt.Printf("Removed: %s no longer blocks %s\n", blocker, blocked)
+	}
+	if len(cycles) == 0 {
+		fmt.Println("No dependency cycles")
+		return nil
+	}
+	fmt.Printf("Dependency cycles (%d):\n", len(cycles))
+	for _, c := range cycles {
+		fmt.Printf("  %s", strings.Join(c.Path, " → "))
+		if len(c.Tasks) > len(c.Path)-1 {
+			fmt.Printf("  (%d tasks involved: %s)", len(c.Tasks), strings.Join(c.Tasks, ", "))
+		}
+		fmt.Println()
+		fmt.Printf("    break with: gur dep cycles --break-at %s\n", c.BreakHint)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestDepCycles(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	for _, id := range []string{"gur-c0c00001", "gur-c0c00002", "gur-c0c00003", "gur-c0c00004", "gur-c0c00005", "gur-c0c00006"} {
+		database.Create(&models.Task{ID: id, Title: id, Status: models.StatusOpen})
+	}
+	// 1 → 2 → 3 → 1 with a shortcut 3 → 2, so the shortest cycle is 2 → 3 → 2;
+	// 4 → 5 is no cycle, and 5 and 6 are only related both ways
+	for _, d := range []models.Dependency{
+		{ParentID: "gur-c0c00001", ChildID: "gur-c0c00002"},
+		{ParentID: "gur-c0c00002", ChildID: "gur-c0c00003"},
+		{ParentID: "gur-c0c00003", ChildID: "gur-c0c00001", Type: models.DepTypeSoftBlocks},
+		{ParentID: "gur-c0c00003", ChildID: "gur-c0c00002"},
+		{ParentID: "gur-c0c00004", ChildID: "gur-c0c00005"},
+		{ParentID: "gur-c0c00005", ChildID: "gur-c0c00006", Type: models.DepTypeRelated},
+		{ParentID: "gur-c0c00006", ChildID: "gur-c0c00005", Type: models.DepTypeRelated},
+	} {
+		database.Create(&d)
+	}
+
+	g, err := loadDepGraph(database, orderingDepTypes...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cycles := g.cycles()
+	if len(cycles) != 1 {
+		t.Fatalf("cycles = %+v, want one group", cycles)
+	}
+	c := cycles[0]
+	if !reflect.DeepEqual(c.Tasks, []string{"gur-c0c00001", "gur-c0c00002", "gur-c0c00003"}) {
+		t.Errorf("group = %v, want tasks 1-3", c.Tasks)
+	}
+	if len(c.Path) != 3 || c.Path[0] != c.Path[2] || !sameCycle(cycles, c.Path[0], c.Path[1]) {
+		t.Errorf("shortest cycle = %v, want 2 → 3 → 2", c.Path)
+	}
+
+	if err := breakCycles(database, cycles, []string{"gur-c0c00004:gur-c0c00005"}); err == nil {
+		t.Error("breaking a dependency that isn't on a cycle succeeded")
+	}
+	if err := breakCycles(database, cycles, []string{"gur-c0c00003:gur-c0c00002", "gur-c0c00001:gur-c0c00003"}); err == nil {
+		t.Error("breaking at a missing dependency succeeded")
+	}
+	var count int64
+	database.Model(&models.Dependency{}).Count(&count)
+	if count != 7 {
+		t.Errorf("a failed break removed dependencies: %d left, want 7", count)
+	}
+
+	depCyclesBreakAt = []string{"gur-c0c00003:gur-c0c00002", "gur-c0c00003:gur-c0c00001"}
+	defer func() { depCyclesBreakAt = nil }()
+	if err := runDepCycles(depCyclesCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if g, _ = loadDepGraph(database, orderingDepTypes...); len(g.cycles()) != 0 {
+		t.Errorf("cycles left after breaking: %+v", g.cycles())
+	}
+}
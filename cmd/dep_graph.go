@@ -0,0 +1,414 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var depPathCmd = &cobra.Command{
+	Use:   "path <a> <b>",
+	Short: "Show whether a depends on b, and through which chain",
+	Long: `Show whether task a depends on task b, directly or through other tasks,
+and the shortest chain of dependencies between them. Every dependency type
+except related is followed, whether or not its blocker is closed.
+
+Examples:
+  gur dep path gur-deploy gur-schema
+  gur dep path gur-deploy gur-schema --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDepPath,
+}
+
+var depRootsCmd = &cobra.Command{
+	Use:   "roots",
+	Short: "List open tasks nothing depends on",
+	Long: `List the open tasks at the top of the dependency graph: they depend on
+other tasks, but no open task depends on them. These are usually the
+deliverables the rest of the work feeds into. Tasks without any
+dependencies are left out.
+
+Examples:
+  gur dep roots
+  gur dep roots --json`,
+	Args: cobra.NoArgs,
+	RunE: runDepRoots,
+}
+
+var depCriticalPathCmd = &cobra.Command{
+	Use:   "critical-path",
+	Short: "Show the longest chain of open blocking tasks",
+	Long: `Show the longest chain of open tasks that block one another (blocks and
+finish-to-start-after dependencies). Nothing at the end of the chain can
+start before everything ahead of it closes, so its length bounds how soon
+the work can finish.
+
+With --milestone (or --release), only chains ending at a task targeted at
+that release are considered; blockers upstream of them count wherever they
+are targeted.
+
+Examples:
+  gur dep critical-path
+  gur dep critical-path --milestone v1.3.0
+  gur dep critical-path --release v1.3.0 --json`,
+	Args: cobra.NoArgs,
+	RunE: runDepCriticalPath,
+}
+
+var depCriticalRelease string
+
+func init() {
+	depCmd.AddCommand(depPathCmd)
+	depCmd.AddCommand(depRootsCmd)
+	depCmd.AddCommand(depCriticalPathCmd)
+
+	depCriticalPathCmd.Flags().StringVar(&depCriticalRelease, "milestone", "", "Only chains ending at tasks targeted at this release")
+	depCriticalPathCmd.Flags().StringVar(&depCriticalRelease, "release", "", "Same as --milestone")
+}
+
+// depGraph holds dependencies in memory, indexed both ways, so graph
+// queries don't walk the database edge by edge
+type depGraph struct {
+	tasks      map[string]models.Task
+	dependsOn  map[string][]models.Dependency // child ID -> its dependencies
+	dependents map[string][]models.Dependency // parent ID -> dependencies on it
+}
+
+// loadDepGraph loads the dependencies of the given types (all if none) and
+// the tasks they connect
+func loadDepGraph(database *gorm.DB, types ...string) (*depGraph, error) {
+	query := database.Model(&models.Dependency{})
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+	var deps []models.Dependency
+	if err := query.Order("id ASC").Find(&deps).Error; err != nil {
+		return nil, err
+	}
+
+	g := &depGraph{
+		tasks:      make(map[string]models.Task),
+		dependsOn:  make(map[string][]models.Dependency),
+		dependents: make(map[string][]models.Dependency),
+	}
+	ids := make(map[string]bool)
+	for _, d := range deps {
+		g.dependsOn[d.ChildID] = append(g.dependsOn[d.ChildID], d)
+		g.dependents[d.ParentID] = append(g.dependents[d.ParentID], d)
+		ids[d.ParentID] = true
+		ids[d.ChildID] = true
+	}
+	if len(ids) == 0 {
+		return g, nil
+	}
+
+	idList := make([]string, 0, len(ids))
+	for id := range ids {
+		idList = append(idList, id)
+	}
+	var tasks []models.Task
+	if err := database.Where("id IN ?", idList).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		g.tasks[t.ID] = t
+	}
+	return g, nil
+}
+
+// path returns the shortest chain of dependencies by which from depends on
+// to, from's own dependency first, or nil if it doesn't
+func (g *depGraph) path(from, to string) []models.Dependency {
+	via := map[string]models.Dependency{}
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, d := range g.dependsOn[current] {
+			if seen[d.ParentID] {
+				continue
+			}
+			seen[d.ParentID] = true
+			via[d.ParentID] = d
+			if d.ParentID == to {
+				var chain []models.Dependency
+				for id := to; id != from; id = via[id].ChildID {
+					chain = append([]models.Dependency{via[id]}, chain...)
+				}
+				return chain
+			}
+			queue = append(queue, d.ParentID)
+		}
+	}
+	return nil
+}
+
+// upstream returns the IDs of the open tasks id depends on, directly or
+// further up
+func (g *depGraph) upstream(id string) []string {
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	var ids []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, d := range g.dependsOn[current] {
+			if seen[d.ParentID] {
+				continue
+			}
+			seen[d.ParentID] = true
+			queue = append(queue, d.ParentID)
+			if t, ok := g.tasks[d.ParentID]; ok && !t.IsClosed() {
+				ids = append(ids, d.ParentID)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// roots returns the open tasks that depend on something but that no open
+// task depends on, sorted by priority
+func (g *depGraph) roots() []models.Task {
+	var roots []models.Task
+	for id := range g.dependsOn {
+		t, ok := g.tasks[id]
+		if !ok || t.IsClosed() {
+			continue
+		}
+		root := true
+		for _, d := range g.dependents[id] {
+			if child, ok := g.tasks[d.ChildID]; ok && !child.IsClosed() {
+				root = false
+				break
+			}
+		}
+		if root {
+			roots = append(roots, t)
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		if roots[i].Priority != roots[j].Priority {
+			return roots[i].Priority < roots[j].Priority
+		}
+		return roots[i].ID < roots[j].ID
+	})
+	return roots
+}
+
+// criticalPath returns the longest chain of open tasks linked by blocking
+// dependencies and ending at a task accepted by end, furthest upstream
+// first. On a cycle the repeated task ends the chain; ties go to the
+// lowest IDs.
+func (g *depGraph) criticalPath(end func(models.Task) bool) []string {
+	memo := make(map[string][]string)
+	onPath := make(map[string]bool)
+	var longest func(id string) []string
+	longest = func(id string) []string {
+		if chain, ok := memo[id]; ok {
+			return chain
+		}
+		onPath[id] = true
+		var best []string
+		for _, d := range g.dependsOn[id] {
+			t, ok := g.tasks[d.ParentID]
+			if !d.IsBlocking() || !ok || t.IsClosed() || onPath[d.ParentID] {
+				continue
+			}
+			if chain := longest(d.ParentID); len(chain) > len(best) || (len(chain) == len(best) && len(best) > 0 && chain[0] < best[0]) {
+				best = chain
+			}
+		}
+		onPath[id] = false
+		chain := append(append([]string{}, best...), id)
+		memo[id] = chain
+		return chain
+	}
+
+	ids := make([]string, 0, len(g.dependsOn))
+	for id := range g.dependsOn {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var best []string
+	for _, id := range ids {
+		t, ok := g.tasks[id]
+		if !ok || t.IsClosed() || !end(t) {
+			continue
+		}
+		if chain := longest(id); len(chain) > len(best) {
+			best = chain
+		}
+	}
+	if len(best) < 2 {
+		return nil
+	}
+	return best
+}
+
+// orderingDepTypes are the dependency types that order work; related
+// dependencies only link tasks
+var orderingDepTypes = []string{models.DepTypeBlocks, models.DepTypeSoftBlocks, models.DepTypeFinishStart, models.DepTypeParentChild}
+
+func runDepPath(cmd *cobra.Command, args []string) error {
+	from, to := args[0], args[1]
+	for _, id := range args {
+		if _, err := db.GetTaskByID(id); err != nil {
+			return codedErrorf(ErrCodeNotFound, "task '%s' not found (use 'gur list' to see available tasks)", id)
+		}
+	}
+
+	g, err := loadDepGraph(db.GetDB(), orderingDepTypes...)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: database error: %w", err)
+	}
+	chain := g.path(from, to)
+	reverse := chain == nil && g.path(to, from) != nil
+
+	if IsJSONOutput() {
+		if chain == nil {
+			chain = []models.Dependency{}
+		}
+		OutputJSON(map[string]interface{}{
+			"from":     from,
+			"to":       to,
+			"depends":  len(chain) > 0,
+			"path":     chain,
+			"reversed": reverse,
+		})
+		return nil
+	}
+
+	if chain == nil {
+		fmt.Printf("%s does not depend on %s\n", from, to)
+		if reverse {
+			fmt.Printf("(but %s depends on %s: see 'gur dep path %s %s')\n", to, from, to, from)
+		}
+		return nil
+	}
+	fmt.Printf("%s depends on %s (%d step(s)):\n", from, to, len(chain))
+	fmt.Printf("  %s %q\n", from, g.tasks[from].Title)
+	for _, d := range chain {
+		fmt.Printf("    <- %s: %s %q\n", depTypeLabel(d), d.ParentID, g.tasks[d.ParentID].Title)
+	}
+	return nil
+}
+
+// depRoot is a root task and how much open work it depends on
+type depRoot struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Upstream int    `json:"upstream"` // Open tasks it depends on, directly or further up
+}
+
+func runDepRoots(cmd *cobra.Command, args []string) error {
+	g, err := loadDepGraph(db.GetDB(), orderingDepTypes...)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: database error: %w", err)
+	}
+	roots := make([]depRoot, 0)
+	for _, t := range g.roots() {
+		roots = append(roots, depRoot{ID: t.ID, Title: t.Title, Status: t.Status, Priority: t.Priority, Upstream: len(g.upstream(t.ID))})
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(roots), "roots": roots})
+		return nil
+	}
+	if len(roots) == 0 {
+		fmt.Println("No open tasks with dependencies.")
+		return nil
+	}
+	fmt.Printf("Root tasks (%d):\n", len(roots))
+	for _, r := range roots {
+		fmt.Printf("  [%s] P%d %s (%s, depends on %d open task(s))\n", r.ID, r.Priority, r.Title, r.Status, r.Upstream)
+	}
+	return nil
+}
+
+// criticalStep is a task on the critical path
+type criticalStep struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Assignee string `json:"assignee,omitempty"`
+	Release  string `json:"release,omitempty"`
+	Estimate int    `json:"estimate_minutes,omitempty"`
+}
+
+func runDepCriticalPath(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	end := func(models.Task) bool { return true }
+	if depCriticalRelease != "" {
+		if _, err := findRelease(database, depCriticalRelease); err != nil {
+			return err
+		}
+		end = func(t models.Task) bool { return t.Release == depCriticalRelease }
+	}
+
+	g, err := loadDepGraph(database, models.DepTypeBlocks, models.DepTypeFinishStart)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: database error: %w", err)
+	}
+	path := g.criticalPath(end)
+	steps := make([]criticalStep, 0, len(path))
+	estimate, unestimated := 0, 0
+	for _, id := range path {
+		t := g.tasks[id]
+		steps = append(steps, criticalStep{ID: t.ID, Title: t.Title, Status: t.Status, Priority: t.Priority, Assignee: t.Assignee, Release: t.Release, Estimate: t.Estimate})
+		estimate += t.Estimate
+		if t.Estimate == 0 {
+			unestimated++
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"release":          depCriticalRelease,
+			"length":           len(steps),
+			"path":             steps,
+			"estimate_minutes": estimate,
+			"unestimated":      unestimated,
+		})
+		return nil
+	}
+
+	scope := ""
+	if depCriticalRelease != "" {
+		scope = " for " + depCriticalRelease
+	}
+	if len(steps) == 0 {
+		fmt.Printf("No open blocking chains%s.\n", scope)
+		return nil
+	}
+	fmt.Printf("Critical path%s: %d task(s)\n", scope, len(steps))
+	for i, s := range steps {
+		line := fmt.Sprintf("  %d. [%s] P%d %s (%s)", i+1, s.ID, s.Priority, s.Title, s.Status)
+		if s.Assignee != "" {
+			line += " @" + s.Assignee
+		}
+		if s.Estimate > 0 {
+			line += " ~" + models.FormatEffort(s.Estimate)
+		}
+		fmt.Println(line)
+	}
+	if estimate > 0 {
+		total := "Estimated effort: " + models.FormatEffort(estimate)
+		if unestimated > 0 {
+			total += fmt.Sprintf(" (%d task(s) unestimated)", unestimated)
+		}
+		fmt.Println(total)
+	}
+	fmt.Printf("\nClose in order: %s\n", strings.Join(path, " -> "))
+	return nil
+}
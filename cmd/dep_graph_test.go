@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestDepGraphQueries(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	// schema -> api -> ui -> launch, docs -> launch, done -> api (closed),
+	// notes related to launch
+	for _, task := range []models.Task{
+		{ID: "gur-graph001", Title: "Schema", Status: models.StatusOpen, Release: "v1"},
+		{ID: "gur-graph002", Title: "API", Status: models.StatusInProgress, Estimate: 120},
+		{ID: "gur-graph003", Title: "UI", Status: models.StatusOpen, Release: "v1", Estimate: 60},
+		{ID: "gur-graph004", Title: "Launch", Status: models.StatusOpen, Release: "v2"},
+		{ID: "gur-graph005", Title: "Docs", Status: models.StatusOpen},
+		{ID: "gur-graph006", Title: "Done", Status: models.StatusClosed},
+		{ID: "gur-graph007", Title: "Notes", Status: models.StatusOpen},
+	} {
+		database.Create(&task)
+	}
+	database.Create(&models.Dependency{ParentID: "gur-graph001", ChildID: "gur-graph002"})
+	database.Create(&models.Dependency{ParentID: "gur-graph002", ChildID: "gur-graph003", Type: models.DepTypeFinishStart, Lag: 60})
+	database.Create(&models.Dependency{ParentID: "gur-graph003", ChildID: "gur-graph004"})
+	database.Create(&models.Dependency{ParentID: "gur-graph005", ChildID: "gur-graph004", Type: models.DepTypeSoftBlocks})
+	database.Create(&models.Dependency{ParentID: "gur-graph006", ChildID: "gur-graph002"})
+	database.Create(&models.Dependency{ParentID: "gur-graph004", ChildID: "gur-graph007", Type: models.DepTypeRelated})
+
+	g, err := loadDepGraph(database, orderingDepTypes...)
+	if err != nil {
+		t.Fatalf("loadDepGraph: %v", err)
+	}
+
+	chain := g.path("gur-graph004", "gur-graph001")
+	var via []string
+	for _, d := range chain {
+		via = append(via, d.ChildID+"<-"+d.ParentID)
+	}
+	want := []string{"gur-graph004<-gur-graph003", "gur-graph003<-gur-graph002", "gur-graph002<-gur-graph001"}
+	if !reflect.DeepEqual(via, want) {
+		t.Errorf("path = %v, want %v", via, want)
+	}
+	if g.path("gur-graph001", "gur-graph004") != nil {
+		t.Error("schema should not depend on launch")
+	}
+	if g.path("gur-graph007", "gur-graph004") != nil {
+		t.Error("related dependencies should not be followed")
+	}
+
+	var roots []string
+	for _, r := range g.roots() {
+		roots = append(roots, r.ID)
+	}
+	if !reflect.DeepEqual(roots, []string{"gur-graph004"}) {
+		t.Errorf("roots = %v, want [gur-graph004]", roots)
+	}
+	if up := g.upstream("gur-graph004"); !reflect.DeepEqual(up, []string{"gur-graph001", "gur-graph002", "gur-graph003", "gur-graph005"}) {
+		t.Errorf("upstream = %v", up)
+	}
+
+	blocking, err := loadDepGraph(database, models.DepTypeBlocks, models.DepTypeFinishStart)
+	if err != nil {
+		t.Fatalf("loadDepGraph: %v", err)
+	}
+	all := func(models.Task) bool { return true }
+	if got := blocking.criticalPath(all); !reflect.DeepEqual(got, []string{"gur-graph001", "gur-graph002", "gur-graph003", "gur-graph004"}) {
+		t.Errorf("critical path = %v", got)
+	}
+	v1 := func(task models.Task) bool { return task.Release == "v1" }
+	if got := blocking.criticalPath(v1); !reflect.DeepEqual(got, []string{"gur-graph001", "gur-graph002", "gur-graph003"}) {
+		t.Errorf("v1 critical path = %v", got)
+	}
+	none := func(task models.Task) bool { return task.Release == "v9" }
+	if got := blocking.criticalPath(none); got != nil {
+		t.Errorf("critical path with no matching tasks = %v, want nil", got)
+	}
+}
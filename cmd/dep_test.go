@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestReadyDependencyTypes(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	now := time.Now()
+	recently, longAgo := now.Add(-2*time.Hour), now.Add(-48*time.Hour)
+	database.Create(&models.Task{ID: "gur-deptyp01", Title: "Open parent", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-deptyp02", Title: "Closed recently", Status: models.StatusClosed, ClosedAt: &recently})
+	database.Create(&models.Task{ID: "gur-deptyp03", Title: "Closed long ago", Status: models.StatusClosed, ClosedAt: &longAgo})
+	database.Create(&models.Task{ID: "gur-deptyp11", Title: "Soft-blocked", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-deptyp12", Title: "Waits on open parent", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-deptyp13", Title: "Still in lag", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-deptyp14", Title: "Lag passed", Status: models.StatusOpen})
+	database.Create(&models.Dependency{ParentID: "gur-deptyp01", ChildID: "gur-deptyp11", Type: models.DepTypeSoftBlocks})
+	database.Create(&models.Dependency{ParentID: "gur-deptyp01", ChildID: "gur-deptyp12", Type: models.DepTypeFinishStart, Lag: 60})
+	database.Create(&models.Dependency{ParentID: "gur-deptyp02", ChildID: "gur-deptyp13", Type: models.DepTypeFinishStart, Lag: 24 * 60})
+	database.Create(&models.Dependency{ParentID: "gur-deptyp03", ChildID: "gur-deptyp14", Type: models.DepTypeFinishStart, Lag: 24 * 60})
+
+	got := readyIDs(t)
+	sort.Strings(got)
+	want := []string{"gur-deptyp01", "gur-deptyp11", "gur-deptyp14"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ready = %v, want %v", got, want)
+	}
+
+	tasks, _ := findReadyTasks(database)
+	soft, err := findSoftBlockers(database, tasks)
+	if err != nil {
+		t.Fatalf("findSoftBlockers() error: %v", err)
+	}
+	if len(soft) != 1 || !reflect.DeepEqual(soft["gur-deptyp11"], []string{"gur-deptyp01"}) {
+		t.Errorf("soft blockers = %v, want gur-deptyp11 soft-blocked by gur-deptyp01", soft)
+	}
+	if a := softBlockAnnotation(soft["gur-deptyp11"]); a != " (soft-blocked by gur-deptyp01)" {
+		t.Errorf("softBlockAnnotation() = %q", a)
+	}
+}
+
+func TestDepEffect(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	closed := now.Add(-2 * time.Hour)
+	open := &models.Task{ID: "gur-a", Status: models.StatusOpen}
+	done := &models.Task{ID: "gur-a", Status: models.StatusClosed, ClosedAt: &closed}
+
+	tests := []struct {
+		name      string
+		dep       models.Dependency
+		parent    *models.Task
+		contains  string
+		satisfied bool
+	}{
+		{"blocks open", models.Dependency{Type: models.DepTypeBlocks}, open, "not ready until gur-a closes", false},
+		{"blocks closed", models.Dependency{Type: models.DepTypeBlocks}, done, "satisfied", true},
+		{"soft open", models.Dependency{Type: models.DepTypeSoftBlocks}, open, "ready, but flagged", false},
+		{"lag open", models.Dependency{Type: models.DepTypeFinishStart, Lag: 24 * 60}, open, "until 1d after gur-a closes", false},
+		{"lag running", models.Dependency{Type: models.DepTypeFinishStart, Lag: 3 * 60}, done, "not ready until 2026-03-10 13:00", false},
+		{"lag passed", models.Dependency{Type: models.DepTypeFinishStart, Lag: 60}, done, "satisfied: 1h after", true},
+		{"related", models.Dependency{Type: models.DepTypeRelated}, open, "no effect", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.dep.ParentID, tt.dep.ChildID = "gur-a", "gur-b"
+			effect, satisfied := depEffect(tt.dep, tt.parent, now)
+			if !strings.Contains(effect, tt.contains) || satisfied != tt.satisfied {
+				t.Errorf("depEffect() = %q, %v; want %q, %v", effect, satisfied, tt.contains, tt.satisfied)
+			}
+		})
+	}
+
+	if err := models.ValidateDepType("blocked-by"); err == nil {
+		t.Error("ValidateDepType accepted an unknown type")
+	}
+}
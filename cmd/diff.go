@@ -0,0 +1,519 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var diffCmd = &cobra.Command{
+	Use:         "diff [task-id]",
+	Short:       "Summarize what changed over a period or since a snapshot",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Summarize what changed: tasks created, closed, deleted and edited (with a
+per-field diff), gates verified, and dependencies added or removed. Useful
+for reviewing what an overnight agent run did.
+
+--since compares against the change history over a period (a duration like
+24h or 7d, or a date). --snapshot compares the current database against a
+copy taken earlier: a path to a .sqlite file, or the name of an environment
+(see 'gur env'). Give a task ID to limit the diff to that task.
+
+Examples:
+  gur diff --since 24h
+  gur diff --since 2024-01-02 gur-abc123
+  cp .guardrails/db.sqlite /tmp/before.sqlite   # before the run
+  gur diff --snapshot /tmp/before.sqlite
+  gur diff --snapshot staging --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDiff,
+}
+
+var (
+	diffSince    string
+	diffSnapshot string
+)
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffSince, "since", "", "Changes over a period: duration (24h, 7d) or date")
+	diffCmd.Flags().StringVar(&diffSnapshot, "snapshot", "", "Changes since a database copy (.sqlite path or environment name)")
+}
+
+// diffValueWidth is how much of a changed value is shown in text output
+const diffValueWidth = 60
+
+// fieldChange is one field's value before and after
+type fieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// taskDiff is a task that appears in the diff, with its field changes
+type taskDiff struct {
+	ID      string        `json:"id"`
+	Title   string        `json:"title"`
+	Status  string        `json:"status,omitempty"`
+	Changes []fieldChange `json:"changes,omitempty"`
+}
+
+// gateVerification is a gate link that was verified (or waived) in the diff
+type gateVerification struct {
+	GateID    string `json:"gate_id"`
+	GateTitle string `json:"gate_title"`
+	TaskID    string `json:"task_id"`
+	Status    string `json:"status"`
+	By        string `json:"verified_by,omitempty"`
+}
+
+// depChange is a dependency added or removed in the diff
+type depChange struct {
+	ParentID string `json:"parent_id"`
+	ChildID  string `json:"child_id"`
+	Type     string `json:"type"`
+}
+
+// diffReport is everything that changed between two points
+type diffReport struct {
+	Since         *time.Time         `json:"since,omitempty"`
+	Snapshot      string             `json:"snapshot,omitempty"`
+	Created       []taskDiff         `json:"created"`
+	Closed        []taskDiff         `json:"closed"`
+	Deleted       []taskDiff         `json:"deleted"`
+	Edited        []taskDiff         `json:"edited"`
+	GatesVerified []gateVerification `json:"gates_verified"`
+	DepsAdded     []depChange        `json:"deps_added"`
+	DepsRemoved   []depChange        `json:"deps_removed"`
+}
+
+// empty reports whether nothing changed
+func (r *diffReport) empty() bool {
+	return len(r.Created)+len(r.Closed)+len(r.Deleted)+len(r.Edited)+
+		len(r.GatesVerified)+len(r.DepsAdded)+len(r.DepsRemoved) == 0
+}
+
+// taskIDs returns the IDs of every task touched by the diff, in first-seen order
+func (r *diffReport) taskIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, list := range [][]taskDiff{r.Created, r.Closed, r.Deleted, r.Edited} {
+		for _, t := range list {
+			add(t.ID)
+		}
+	}
+	for _, g := range r.GatesVerified {
+		add(g.TaskID)
+	}
+	for _, d := range append(append([]depChange{}, r.DepsAdded...), r.DepsRemoved...) {
+		add(d.ChildID)
+	}
+	return ids
+}
+
+// taskFieldValues returns the compared fields of a task as strings, named as
+// in the change history
+func taskFieldValues(t *models.Task) []fieldChange {
+	return []fieldChange{
+		{Field: "title", New: t.Title},
+		{Field: "description", New: t.Description},
+		{Field: "status", New: t.Status},
+		{Field: "priority", New: strconv.Itoa(t.Priority)},
+		{Field: "type", New: t.Type},
+		{Field: "assignee", New: t.Assignee},
+		{Field: "labels", New: strings.Join(t.Labels, ",")},
+		{Field: "due_at", New: t.DueString()},
+		{Field: "estimate", New: strconv.Itoa(t.Estimate)},
+		{Field: "rank", New: strconv.Itoa(t.Rank)},
+		{Field: "release", New: t.Release},
+		{Field: "parent_id", New: t.ParentID},
+		{Field: "notes", New: t.Notes},
+		{Field: "block_reason", New: t.BlockReason},
+		{Field: "close_reason", New: t.CloseReason},
+		{Field: "resolution", New: t.Resolution},
+	}
+}
+
+// compareTasks returns the fields that differ between two versions of a task
+func compareTasks(before, after *models.Task) []fieldChange {
+	old, cur := taskFieldValues(before), taskFieldValues(after)
+	var changes []fieldChange
+	for i := range cur {
+		if old[i].New != cur[i].New {
+			changes = append(changes, fieldChange{Field: cur[i].Field, Old: old[i].New, New: cur[i].New})
+		}
+	}
+	return changes
+}
+
+// diffTask returns the diff entry for a task
+func diffTask(t *models.Task) taskDiff {
+	return taskDiff{ID: t.ID, Title: t.Title, Status: t.Status}
+}
+
+// depKey identifies a dependency independently of its row ID
+func depKey(d models.Dependency) string {
+	return d.ParentID + "|" + d.ChildID + "|" + d.Type
+}
+
+// scopeTask limits a task query to taskID when set
+func scopeTask(query *gorm.DB, column, taskID string) *gorm.DB {
+	if taskID == "" {
+		return query
+	}
+	return query.Where(column+" = ?", taskID)
+}
+
+// scopeDeps limits a dependency query to those touching taskID when set
+func scopeDeps(query *gorm.DB, taskID string) *gorm.DB {
+	if taskID == "" {
+		return query
+	}
+	return query.Where("parent_id = ? OR child_id = ?", taskID, taskID)
+}
+
+// gateTitles maps gate IDs to titles, including deleted gates
+func gateTitles(database *gorm.DB) map[string]string {
+	var gates []models.Gate
+	database.Unscoped().Find(&gates)
+	titles := make(map[string]string, len(gates))
+	for _, g := range gates {
+		titles[g.ID] = g.Title
+	}
+	return titles
+}
+
+// diffSinceTime builds the report from what was recorded after cutoff.
+// Edits come from the task history, collapsed to each field's first old
+// value and last new value; fields that ended where they started are dropped.
+func diffSinceTime(database *gorm.DB, cutoff time.Time, taskID string) (*diffReport, error) {
+	r := &diffReport{Since: &cutoff}
+
+	var created []models.Task
+	if err := scopeTask(database.Where("created_at >= ?", cutoff), "id", taskID).Order("created_at").Find(&created).Error; err != nil {
+		return nil, err
+	}
+	for i := range created {
+		r.Created = append(r.Created, diffTask(&created[i]))
+	}
+
+	var closed []models.Task
+	if err := scopeTask(database.Where("status = ? AND closed_at >= ?", models.StatusClosed, cutoff), "id", taskID).Order("closed_at").Find(&closed).Error; err != nil {
+		return nil, err
+	}
+	for i := range closed {
+		r.Closed = append(r.Closed, diffTask(&closed[i]))
+	}
+
+	var deleted []models.Task
+	if err := scopeTask(database.Unscoped().Where("deleted_at >= ?", cutoff), "id", taskID).Order("deleted_at").Find(&deleted).Error; err != nil {
+		return nil, err
+	}
+	for i := range deleted {
+		r.Deleted = append(r.Deleted, diffTask(&deleted[i]))
+	}
+
+	var history []models.TaskHistory
+	if err := scopeTask(database.Where("changed_at >= ?", cutoff), "task_id", taskID).Order("changed_at, id").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	var order []string
+	byTask := make(map[string][]fieldChange)
+	for _, h := range history {
+		changes, seen := byTask[h.TaskID]
+		if !seen {
+			order = append(order, h.TaskID)
+		}
+		merged := false
+		for i := range changes {
+			if changes[i].Field == h.Field {
+				changes[i].New = h.NewValue
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			changes = append(changes, fieldChange{Field: h.Field, Old: h.OldValue, New: h.NewValue})
+		}
+		byTask[h.TaskID] = changes
+	}
+	for _, id := range order {
+		var changes []fieldChange
+		for _, c := range byTask[id] {
+			if c.Old != c.New {
+				changes = append(changes, c)
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		entry := taskDiff{ID: id, Changes: changes}
+		var task models.Task
+		if database.Unscoped().First(&task, "id = ?", id).Error == nil {
+			entry.Title, entry.Status = task.Title, task.Status
+		}
+		r.Edited = append(r.Edited, entry)
+	}
+
+	var links []models.GateTaskLink
+	if err := scopeTask(database.Where("verified_at >= ? AND status != ?", cutoff, models.GateLinkPending), "task_id", taskID).Order("verified_at").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	titles := gateTitles(database)
+	for _, l := range links {
+		r.GatesVerified = append(r.GatesVerified, gateVerification{GateID: l.GateID, GateTitle: titles[l.GateID], TaskID: l.TaskID, Status: l.Status, By: l.VerifiedBy})
+	}
+
+	var added, removed []models.Dependency
+	if err := scopeDeps(database.Where("created_at >= ?", cutoff), taskID).Order("created_at").Find(&added).Error; err != nil {
+		return nil, err
+	}
+	if err := scopeDeps(database.Unscoped().Where("deleted_at >= ?", cutoff), taskID).Order("deleted_at").Find(&removed).Error; err != nil {
+		return nil, err
+	}
+	for _, d := range added {
+		r.DepsAdded = append(r.DepsAdded, depChange{ParentID: d.ParentID, ChildID: d.ChildID, Type: d.Type})
+	}
+	for _, d := range removed {
+		// Added and removed within the period: no net change
+		if d.CreatedAt.Before(cutoff) {
+			r.DepsRemoved = append(r.DepsRemoved, depChange{ParentID: d.ParentID, ChildID: d.ChildID, Type: d.Type})
+		}
+	}
+	return r, nil
+}
+
+// diffSnapshotDB builds the report by comparing the current database
+// against a snapshot copy of it
+func diffSnapshotDB(database, snapshot *gorm.DB, name, taskID string) (*diffReport, error) {
+	r := &diffReport{Snapshot: name}
+
+	var before, after []models.Task
+	if err := scopeTask(snapshot, "id", taskID).Order("created_at, id").Find(&before).Error; err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := scopeTask(database, "id", taskID).Order("created_at, id").Find(&after).Error; err != nil {
+		return nil, err
+	}
+	old := make(map[string]*models.Task, len(before))
+	for i := range before {
+		old[before[i].ID] = &before[i]
+	}
+	current := make(map[string]bool, len(after))
+	for i := range after {
+		t := &after[i]
+		current[t.ID] = true
+		prev, ok := old[t.ID]
+		if !ok {
+			r.Created = append(r.Created, diffTask(t))
+			continue
+		}
+		if t.IsClosed() && !prev.IsClosed() {
+			r.Closed = append(r.Closed, diffTask(t))
+		}
+		if changes := compareTasks(prev, t); len(changes) > 0 {
+			entry := diffTask(t)
+			entry.Changes = changes
+			r.Edited = append(r.Edited, entry)
+		}
+	}
+	for i := range before {
+		if !current[before[i].ID] {
+			r.Deleted = append(r.Deleted, diffTask(&before[i]))
+		}
+	}
+
+	var oldLinks, links []models.GateTaskLink
+	if err := scopeTask(snapshot, "task_id", taskID).Find(&oldLinks).Error; err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := scopeTask(database, "task_id", taskID).Order("verified_at").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	oldStatus := make(map[string]string, len(oldLinks))
+	for _, l := range oldLinks {
+		oldStatus[l.GateID+"|"+l.TaskID] = l.Status
+	}
+	titles := gateTitles(database)
+	for _, l := range links {
+		if l.Status == models.GateLinkPending || oldStatus[l.GateID+"|"+l.TaskID] == l.Status {
+			continue
+		}
+		r.GatesVerified = append(r.GatesVerified, gateVerification{GateID: l.GateID, GateTitle: titles[l.GateID], TaskID: l.TaskID, Status: l.Status, By: l.VerifiedBy})
+	}
+
+	var oldDeps, deps []models.Dependency
+	if err := scopeDeps(snapshot, taskID).Order("id").Find(&oldDeps).Error; err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := scopeDeps(database, taskID).Order("id").Find(&deps).Error; err != nil {
+		return nil, err
+	}
+	had := make(map[string]bool, len(oldDeps))
+	for _, d := range oldDeps {
+		had[depKey(d)] = true
+	}
+	has := make(map[string]bool, len(deps))
+	for _, d := range deps {
+		has[depKey(d)] = true
+		if !had[depKey(d)] {
+			r.DepsAdded = append(r.DepsAdded, depChange{ParentID: d.ParentID, ChildID: d.ChildID, Type: d.Type})
+		}
+	}
+	for _, d := range oldDeps {
+		if !has[depKey(d)] {
+			r.DepsRemoved = append(r.DepsRemoved, depChange{ParentID: d.ParentID, ChildID: d.ChildID, Type: d.Type})
+		}
+	}
+	return r, nil
+}
+
+// openSnapshot opens a snapshot given as a database path or environment name
+func openSnapshot(ref string) (*gorm.DB, error) {
+	if _, err := os.Stat(ref); err == nil {
+		return db.OpenDB(ref)
+	}
+	root, err := db.FindProjectRoot()
+	if err != nil || db.ValidateEnvName(ref) != nil {
+		return nil, codedErrorf(ErrCodeNotFound, "snapshot '%s' not found: give a path to a .sqlite file or an environment name", ref)
+	}
+	return openEnvDB(root, ref)
+}
+
+// diffValue shortens a value for display on one line
+func diffValue(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > diffValueWidth {
+		s = string(r[:diffValueWidth-3]) + "..."
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	if (diffSince == "") == (diffSnapshot == "") {
+		return fmt.Errorf("specify one of --since or --snapshot (see 'gur diff --help')")
+	}
+	taskID := ""
+	if len(args) == 1 {
+		taskID = args[0]
+		if _, err := db.GetTaskByID(taskID); err != nil && diffSince != "" {
+			var deleted int64
+			db.GetDB().Unscoped().Model(&models.Task{}).Where("id = ?", taskID).Count(&deleted)
+			if deleted == 0 {
+				return codedErrorf(ErrCodeNotFound, "cannot diff task: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+			}
+		}
+	}
+
+	database := db.GetDB()
+	var report *diffReport
+	if diffSince != "" {
+		cutoff, err := parseSince(diffSince, time.Now())
+		if err != nil {
+			return err
+		}
+		report, err = diffSinceTime(database, cutoff, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to compute diff: database error: %w", err)
+		}
+	} else {
+		snapshot, err := openSnapshot(diffSnapshot)
+		if err != nil {
+			return err
+		}
+		defer db.CloseConn(snapshot)
+		report, err = diffSnapshotDB(database, snapshot, diffSnapshot, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to compute diff: %w", err)
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task_id": taskID, "diff": report})
+		return nil
+	}
+	if IsQuietOutput() {
+		printQuietIDs(report.taskIDs()...)
+		return nil
+	}
+	printDiffReport(report)
+	return nil
+}
+
+// printDiffReport prints the report as sections, skipping empty ones
+func printDiffReport(r *diffReport) {
+	from := "snapshot " + r.Snapshot
+	if r.Since != nil {
+		from = r.Since.Format(models.DateTimeShortFormat)
+	}
+	if r.empty() {
+		fmt.Printf("No changes since %s\n", from)
+		return
+	}
+	p := colors()
+	fmt.Printf("Changes since %s:\n", from)
+
+	taskSection := func(heading string, tasks []taskDiff) {
+		if len(tasks) == 0 {
+			return
+		}
+		fmt.Printf("\n%s (%d):\n", heading, len(tasks))
+		for _, t := range tasks {
+			status := ""
+			if t.Status != "" {
+				status = " [" + p.Status(t.Status) + "]"
+			}
+			fmt.Printf("  %s%s %s\n", t.ID, status, t.Title)
+			for _, c := range t.Changes {
+				switch {
+				case c.Old == "":
+					fmt.Printf("      %s: set to %s\n", c.Field, diffValue(c.New))
+				case c.New == "":
+					fmt.Printf("      %s: removed %s\n", c.Field, diffValue(c.Old))
+				default:
+					fmt.Printf("      %s: %s → %s\n", c.Field, diffValue(c.Old), diffValue(c.New))
+				}
+			}
+		}
+	}
+	taskSection("Created", r.Created)
+	taskSection("Closed", r.Closed)
+	taskSection("Deleted", r.Deleted)
+	taskSection("Edited", r.Edited)
+
+	if len(r.GatesVerified) > 0 {
+		fmt.Printf("\nGates verified (%d):\n", len(r.GatesVerified))
+		for _, g := range r.GatesVerified {
+			by := ""
+			if g.By != "" {
+				by = " (by " + g.By + ")"
+			}
+			fmt.Printf("  %s %s on %s: %s%s\n", g.GateID, g.GateTitle, g.TaskID, p.Result(g.Status), by)
+		}
+	}
+	depSection := func(heading string, deps []depChange) {
+		if len(deps) == 0 {
+			return
+		}
+		fmt.Printf("\n%s (%d):\n", heading, len(deps))
+		for _, d := range deps {
+			fmt.Printf("  %s %s %s\n", d.ParentID, d.Type, d.ChildID)
+		}
+	}
+	depSection("Dependencies added", r.DepsAdded)
+	depSection("Dependencies removed", r.DepsRemoved)
+}
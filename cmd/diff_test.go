@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestDiffSinceTime(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	cutoff := time.Now().Add(-time.Hour)
+	old := cutoff.Add(-24 * time.Hour)
+	database.Create(&models.Task{ID: "gur-dif00001", Title: "Old", Status: models.StatusOpen, Priority: 2, CreatedAt: old})
+	database.Create(&models.Task{ID: "gur-dif00002", Title: "New", Status: models.StatusOpen, Priority: 2})
+	database.Create(&models.Dependency{ParentID: "gur-dif00002", ChildID: "gur-dif00001", Type: models.DepTypeBlocks})
+
+	models.RecordChange(database, "gur-dif00001", "priority", "2", "1", "agent")
+	models.RecordChange(database, "gur-dif00001", "priority", "1", "0", "agent")
+	models.RecordChange(database, "gur-dif00001", "assignee", "", "bob", "agent")
+	models.RecordChange(database, "gur-dif00001", "assignee", "bob", "", "agent")
+	// Before the cutoff
+	database.Create(&models.TaskHistory{TaskID: "gur-dif00001", Field: "title", OldValue: "Older", NewValue: "Old", ChangedAt: old})
+
+	r, err := diffSinceTime(database, cutoff, "")
+	if err != nil {
+		t.Fatalf("diffSinceTime() error: %v", err)
+	}
+	if len(r.Created) != 1 || r.Created[0].ID != "gur-dif00002" {
+		t.Errorf("created = %+v, want gur-dif00002", r.Created)
+	}
+	if len(r.Edited) != 1 || len(r.Edited[0].Changes) != 1 {
+		t.Fatalf("edited = %+v, want one task with one net change", r.Edited)
+	}
+	if c := r.Edited[0].Changes[0]; c.Field != "priority" || c.Old != "2" || c.New != "0" {
+		t.Errorf("change = %+v, want priority 2 → 0", c)
+	}
+	if len(r.DepsAdded) != 1 {
+		t.Errorf("deps added = %+v, want 1", r.DepsAdded)
+	}
+
+	scoped, _ := diffSinceTime(database, cutoff, "gur-dif00002")
+	if len(scoped.Edited) != 0 || len(scoped.Created) != 1 || len(scoped.DepsAdded) != 1 {
+		t.Errorf("scoped diff = %+v, want only gur-dif00002 and its dep", scoped)
+	}
+}
+
+func TestDiffSnapshotDB(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	snapshot, err := db.OpenDB(filepath.Join(t.TempDir(), "before.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.CloseConn(snapshot)
+
+	now := time.Now()
+	snapshot.Create(&models.Task{ID: "gur-snp00001", Title: "Kept", Status: models.StatusOpen, Priority: 2})
+	snapshot.Create(&models.Task{ID: "gur-snp00002", Title: "Removed", Status: models.StatusOpen, Priority: 2})
+	snapshot.Create(&models.Gate{ID: "gate-snp1", Title: "Tests pass"})
+	snapshot.Create(&models.GateTaskLink{GateID: "gate-snp1", TaskID: "gur-snp00001", Status: models.GateLinkPending})
+	snapshot.Create(&models.Dependency{ParentID: "gur-snp00002", ChildID: "gur-snp00001", Type: models.DepTypeBlocks})
+
+	database.Create(&models.Task{ID: "gur-snp00001", Title: "Kept", Status: models.StatusClosed, Priority: 1, Resolution: models.ResolutionCompleted, ClosedAt: &now})
+	database.Create(&models.Task{ID: "gur-snp00003", Title: "Added", Status: models.StatusOpen, Priority: 2})
+	database.Create(&models.Gate{ID: "gate-snp1", Title: "Tests pass"})
+	database.Create(&models.GateTaskLink{GateID: "gate-snp1", TaskID: "gur-snp00001", Status: models.GateLinkPassed, VerifiedBy: "agent", VerifiedAt: &now})
+	database.Create(&models.Dependency{ParentID: "gur-snp00003", ChildID: "gur-snp00001", Type: models.DepTypeBlocks})
+
+	r, err := diffSnapshotDB(database, snapshot, "before", "")
+	if err != nil {
+		t.Fatalf("diffSnapshotDB() error: %v", err)
+	}
+	if len(r.Created) != 1 || r.Created[0].ID != "gur-snp00003" {
+		t.Errorf("created = %+v, want gur-snp00003", r.Created)
+	}
+	if len(r.Deleted) != 1 || r.Deleted[0].ID != "gur-snp00002" {
+		t.Errorf("deleted = %+v, want gur-snp00002", r.Deleted)
+	}
+	if len(r.Closed) != 1 || r.Closed[0].ID != "gur-snp00001" {
+		t.Errorf("closed = %+v, want gur-snp00001", r.Closed)
+	}
+	fields := map[string]bool{}
+	for _, e := range r.Edited {
+		for _, c := range e.Changes {
+			fields[c.Field] = true
+		}
+	}
+	if len(fields) != 3 || !fields["status"] || !fields["priority"] || !fields["resolution"] {
+		t.Errorf("edited fields = %v, want status, priority and resolution", fields)
+	}
+	if len(r.GatesVerified) != 1 || r.GatesVerified[0].Status != models.GateLinkPassed || r.GatesVerified[0].GateTitle != "Tests pass" {
+		t.Errorf("gates verified = %+v, want gate-snp1 passed", r.GatesVerified)
+	}
+	if len(r.DepsAdded) != 1 || r.DepsAdded[0].ParentID != "gur-snp00003" || len(r.DepsRemoved) != 1 || r.DepsRemoved[0].ParentID != "gur-snp00002" {
+		t.Errorf("deps added %+v removed %+v, want one each", r.DepsAdded, r.DepsRemoved)
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// noDueDate clears a due date with 'gur update --due none'
+const noDueDate = "none"
+
+// parseDueDate parses an absolute date (2025-07-01), meaning the end of that
+// day, or a duration from now (7d, 2w, 36h)
+func parseDueDate(s string, now time.Time) (time.Time, error) {
+	if d, err := parseDuration(s); err == nil && d >= 0 {
+		return now.Add(d), nil
+	}
+	if t, err := time.ParseInLocation(models.DateFormat, s, time.Local); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	if t, err := time.ParseInLocation(models.DateTimeShortFormat, s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due date '%s': use a date like 2025-07-01 or a duration like 7d or 2w", s)
+}
+
+// dueAnnotation renders the due date suffix used in task listings
+func dueAnnotation(t models.Task, now time.Time) string {
+	switch {
+	case t.DueAt == nil || t.IsClosed() || t.IsArchived():
+		return ""
+	case t.IsOverdue(now):
+		return fmt.Sprintf(" [overdue: %s]", t.DueString())
+	default:
+		return fmt.Sprintf(" [due: %s]", t.DueString())
+	}
+}
+
+// whereOverdue restricts a task query to open tasks past their due date
+func whereOverdue(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("due_at IS NOT NULL AND due_at < ?", now).
+		Where("status NOT IN ?", []string{models.StatusClosed, models.StatusArchived})
+}
+
+// findTasksDueWithin returns open tasks due before now+window, overdue first
+func findTasksDueWithin(database *gorm.DB, now time.Time, window time.Duration) ([]models.Task, error) {
+	var tasks []models.Task
+	err := database.
+		Where("due_at IS NOT NULL AND due_at < ?", now.Add(window)).
+		Where("status NOT IN ?", []string{models.StatusClosed, models.StatusArchived}).
+		Order("due_at ASC, priority ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestParseDueDate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.Local)
+
+	got, err := parseDueDate("7d", now)
+	if err != nil || !got.Equal(now.Add(7*24*time.Hour)) {
+		t.Errorf("parseDueDate(7d) = %v, %v", got, err)
+	}
+
+	got, err = parseDueDate("2025-07-01", now)
+	want := time.Date(2025, 7, 1, 23, 59, 59, 0, time.Local)
+	if err != nil || !got.Equal(want) {
+		t.Errorf("parseDueDate(2025-07-01) = %v, %v, want end of day %v", got, err, want)
+	}
+
+	if _, err := parseDueDate("next tuesday", now); err == nil {
+		t.Error("parseDueDate should reject unparseable input")
+	}
+}
+
+func TestDueAnnotation(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.Local)
+	past := now.Add(-time.Hour)
+	future := now.Add(48 * time.Hour)
+
+	tests := []struct {
+		name string
+		task models.Task
+		want string
+	}{
+		{"no due date", models.Task{Status: models.StatusOpen}, ""},
+		{"due later", models.Task{Status: models.StatusOpen, DueAt: &future}, " [due: 2025-06-03]"},
+		{"overdue", models.Task{Status: models.StatusOpen, DueAt: &past}, " [overdue: 2025-06-01]"},
+		{"closed", models.Task{Status: models.StatusClosed, DueAt: &past}, ""},
+	}
+	for _, tt := range tests {
+		if got := dueAnnotation(tt.task, now); got != tt.want {
+			t.Errorf("%s: dueAnnotation() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDueDateQueries(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	soon := now.Add(3 * 24 * time.Hour)
+	later := now.Add(30 * 24 * time.Hour)
+
+	tasks := []models.Task{
+		{Title: "no due", Status: models.StatusOpen, Priority: models.PriorityHigh},
+		{Title: "due later", Status: models.StatusOpen, Priority: models.PriorityHigh, DueAt: &later},
+		{Title: "due soon", Status: models.StatusOpen, Priority: models.PriorityHigh, DueAt: &soon},
+		{Title: "overdue", Status: models.StatusOpen, Priority: models.PriorityLow, DueAt: &yesterday},
+		{Title: "closed overdue", Status: models.StatusClosed, Priority: models.PriorityHigh, DueAt: &yesterday},
+	}
+	for i := range tasks {
+		if err := database.Create(&tasks[i]).Error; err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+	}
+
+	var overdue []models.Task
+	whereOverdue(database.Model(&models.Task{}), now).Find(&overdue)
+	if len(overdue) != 1 || overdue[0].Title != "overdue" {
+		t.Errorf("whereOverdue() = %v, want only the open overdue task", overdue)
+	}
+
+	dueSoon, err := findTasksDueWithin(database, now, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("findTasksDueWithin() error: %v", err)
+	}
+	if len(dueSoon) != 2 || dueSoon[0].Title != "overdue" || dueSoon[1].Title != "due soon" {
+		t.Errorf("findTasksDueWithin() = %v, want [overdue, due soon]", dueSoon)
+	}
+
+	ready, err := findReadyTasks(database)
+	if err != nil {
+		t.Fatalf("findReadyTasks() error: %v", err)
+	}
+	var titles []string
+	for _, r := range ready {
+		titles = append(titles, r.Title)
+	}
+	want := []string{"due soon", "due later", "no due", "overdue"}
+	if len(titles) != len(want) {
+		t.Fatalf("findReadyTasks() = %v, want %v", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("findReadyTasks() = %v, want %v", titles, want)
+		}
+	}
+}
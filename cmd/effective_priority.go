@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// inheritedPriority is the priority an open blocker takes on from a more
+// urgent task it blocks, directly or further downstream
+type inheritedPriority struct {
+	Priority int    `json:"priority"`
+	Via      string `json:"via"` // The downstream task the priority comes from
+}
+
+// inheritedPriorities returns the effective priority of every open blocker
+// that blocks a more urgent open task than itself. A blocker's effective
+// priority is the most urgent of its own and that of every task downstream
+// of it, so a P3 task blocking a P0 task surfaces as P0.
+func (g *blockerGraph) inheritedPriorities() map[string]inheritedPriority {
+	memo := make(map[string]inheritedPriority)
+	onPath := make(map[string]bool)
+	var urgent func(id string) inheritedPriority
+	urgent = func(id string) inheritedPriority {
+		if p, ok := memo[id]; ok {
+			return p
+		}
+		onPath[id] = true
+		best := inheritedPriority{Priority: g.tasks[id].Priority, Via: id}
+		for _, child := range g.dependent[id] {
+			if t, ok := g.tasks[child]; !ok || t.IsClosed() || onPath[child] {
+				continue
+			}
+			if p := urgent(child); p.Priority < best.Priority || (p.Priority == best.Priority && best.Via != id && p.Via < best.Via) {
+				best = p
+			}
+		}
+		onPath[id] = false
+		memo[id] = best
+		return best
+	}
+
+	inherited := make(map[string]inheritedPriority)
+	for id := range g.dependent {
+		t, ok := g.tasks[id]
+		if !ok || t.IsClosed() {
+			continue
+		}
+		if p := urgent(id); p.Priority < t.Priority {
+			inherited[id] = p
+		}
+	}
+	return inherited
+}
+
+// loadInheritedPriorities returns the effective priorities of open blockers
+// whose downstream tasks are more urgent than they are
+func loadInheritedPriorities(database *gorm.DB) (map[string]inheritedPriority, error) {
+	g, err := loadBlockerGraph(database)
+	if err != nil {
+		return nil, err
+	}
+	return g.inheritedPriorities(), nil
+}
+
+// effectiveReadyOrder is readyOrder with each task's own priority replaced
+// by its effective priority
+func effectiveReadyOrder(inherited map[string]inheritedPriority) string {
+	if len(inherited) == 0 {
+		return readyOrder
+	}
+	ids := make([]string, 0, len(inherited))
+	for id := range inherited {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var b strings.Builder
+	b.WriteString("CASE id")
+	for _, id := range ids {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", strings.ReplaceAll(id, "'", "''"), inherited[id].Priority)
+	}
+	b.WriteString(" ELSE priority END ASC, ")
+	b.WriteString(strings.TrimPrefix(readyOrder, "priority ASC, "))
+	return b.String()
+}
+
+// inheritedFor returns the inherited priorities of the given tasks only
+func inheritedFor(inherited map[string]inheritedPriority, tasks []models.Task) map[string]inheritedPriority {
+	shown := make(map[string]inheritedPriority)
+	for _, t := range tasks {
+		if p, ok := inherited[t.ID]; ok {
+			shown[t.ID] = p
+		}
+	}
+	return shown
+}
+
+// effectivePriorityAnnotation notes a priority inherited from downstream
+func effectivePriorityAnnotation(p inheritedPriority, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" (effective P%d via %s)", p.Priority, p.Via)
+}
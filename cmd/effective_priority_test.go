@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestEffectivePriority(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	// gur-eff00001 (P3) blocks gur-eff00002 (P2), which blocks gur-eff00003 (P0)
+	database.Create(&models.Task{ID: "gur-eff00001", Title: "Chore", Status: models.StatusOpen, Priority: 3})
+	database.Create(&models.Task{ID: "gur-eff00002", Title: "Middle", Status: models.StatusOpen, Priority: 2})
+	database.Create(&models.Task{ID: "gur-eff00003", Title: "Outage", Status: models.StatusOpen, Priority: 0})
+	database.Create(&models.Task{ID: "gur-eff00004", Title: "Feature", Status: models.StatusOpen, Priority: 1})
+	database.Create(&models.Task{ID: "gur-eff00005", Title: "Closed", Status: models.StatusClosed, Priority: 0})
+	database.Create(&models.Dependency{ParentID: "gur-eff00001", ChildID: "gur-eff00002", Type: models.DepTypeBlocks})
+	database.Create(&models.Dependency{ParentID: "gur-eff00002", ChildID: "gur-eff00003", Type: models.DepTypeBlocks})
+	// A closed dependent lends no urgency
+	database.Create(&models.Dependency{ParentID: "gur-eff00004", ChildID: "gur-eff00005", Type: models.DepTypeBlocks})
+
+	inherited, err := loadInheritedPriorities(database)
+	if err != nil {
+		t.Fatalf("loadInheritedPriorities() error: %v", err)
+	}
+	want := map[string]inheritedPriority{
+		"gur-eff00001": {Priority: 0, Via: "gur-eff00003"},
+		"gur-eff00002": {Priority: 0, Via: "gur-eff00003"},
+	}
+	if !reflect.DeepEqual(inherited, want) {
+		t.Errorf("inherited = %v, want %v", inherited, want)
+	}
+
+	// The P3 blocker now ranks ahead of the P1 task in ready
+	if got := readyIDs(t); !reflect.DeepEqual(got, []string{"gur-eff00001", "gur-eff00004"}) {
+		t.Errorf("ready order = %v, want the P3 blocker first", got)
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// effectivenessStats summarises outcomes of the tasks linked to one skill or agent
+type effectivenessStats struct {
+	Name          string  `json:"name"`
+	Tasks         int     `json:"tasks"`
+	Completed     int     `json:"completed"`
+	ForceClosed   int     `json:"force_closed"`
+	AvgCloseHours float64 `json:"avg_close_hours"`
+	GatesPassed   int     `json:"gates_passed"`
+	GatesFailed   int     `json:"gates_failed"`
+	GatePassRate  float64 `json:"gate_pass_rate"`
+}
+
+// collectEffectiveness aggregates task outcomes per linked skill or agent.
+// linkTable/linkColumn name the join table and its foreign key, entityTable the
+// skills or agents table.
+func collectEffectiveness(database *gorm.DB, linkTable, linkColumn, entityTable string) ([]effectivenessStats, error) {
+	type linkedTask struct {
+		Name        string
+		TaskID      string
+		Status      string
+		CreatedAt   time.Time
+		ClosedAt    *time.Time
+		CloseReason string
+	}
+	var rows []linkedTask
+	err := database.Table(linkTable + " AS l").
+		Select("e.name, t.id AS task_id, t.status, t.created_at, t.closed_at, t.close_reason").
+		Joins("JOIN " + entityTable + " e ON e.id = l." + linkColumn + " AND e.deleted_at IS NULL").
+		Joins("JOIN tasks t ON t.id = l.task_id AND t.deleted_at IS NULL").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Gate link outcomes per task, in a single query
+	taskIDs := make([]string, 0, len(rows))
+	for _, r := range rows {
+		taskIDs = append(taskIDs, r.TaskID)
+	}
+	type gateCount struct {
+		TaskID string
+		Status string
+		Count  int
+	}
+	var gateCounts []gateCount
+	if len(taskIDs) > 0 {
+		if err := database.Model(&models.GateTaskLink{}).
+			Select("task_id, status, count(*) as count").
+			Where("task_id IN ?", taskIDs).
+			Group("task_id, status").
+			Scan(&gateCounts).Error; err != nil {
+			return nil, err
+		}
+	}
+	passed := make(map[string]int)
+	failed := make(map[string]int)
+	for _, gc := range gateCounts {
+		switch gc.Status {
+		case models.GatePassed:
+			passed[gc.TaskID] += gc.Count
+		case models.GateFailed:
+			failed[gc.TaskID] += gc.Count
+		}
+	}
+
+	byName := make(map[string]*effectivenessStats)
+	closeHours := make(map[string]float64)
+	closeCount := make(map[string]int)
+	for _, r := range rows {
+		s, ok := byName[r.Name]
+		if !ok {
+			s = &effectivenessStats{Name: r.Name}
+			byName[r.Name] = s
+		}
+		s.Tasks++
+		s.GatesPassed += passed[r.TaskID]
+		s.GatesFailed += failed[r.TaskID]
+
+		task := models.Task{Status: r.Status, CloseReason: r.CloseReason}
+		if !task.IsClosed() && !task.IsArchived() {
+			continue
+		}
+		s.Completed++
+		if task.WasForceClosed() {
+			s.ForceClosed++
+		}
+		if r.ClosedAt != nil {
+			closeHours[r.Name] += r.ClosedAt.Sub(r.CreatedAt).Hours()
+			closeCount[r.Name]++
+		}
+	}
+
+	result := make([]effectivenessStats, 0, len(byName))
+	for name, s := range byName {
+		if n := closeCount[name]; n > 0 {
+			s.AvgCloseHours = closeHours[name] / float64(n)
+		}
+		if total := s.GatesPassed + s.GatesFailed; total > 0 {
+			s.GatePassRate = float64(s.GatesPassed) / float64(total) * models.GatePercentMultiplier
+		}
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Tasks != result[j].Tasks {
+			return result[i].Tasks > result[j].Tasks
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// formatHours renders a duration in hours compactly (e.g., 45m, 3.5h, 2.1d)
+func formatHours(h float64) string {
+	switch {
+	case h < 1:
+		return fmt.Sprintf("%.0fm", h*60)
+	case h < 48:
+		return fmt.Sprintf("%.1fh", h)
+	default:
+		return fmt.Sprintf("%.1fd", h/24)
+	}
+}
+
+// printEffectiveness writes the stats table shared by skill and agent stats
+func printEffectiveness(kind string, stats []effectivenessStats) {
+	if len(stats) == 0 {
+		fmt.Printf("No %ss are linked to tasks yet.\n", kind)
+		return
+	}
+
+	fmt.Printf("%-24s %6s %6s %10s %10s %7s\n", strings.ToUpper(kind), "TASKS", "DONE", "AVG CLOSE", "GATE PASS", "FORCED")
+	for _, s := range stats {
+		avg := "-"
+		if s.Completed > 0 {
+			avg = formatHours(s.AvgCloseHours)
+		}
+		rate := "-"
+		if s.GatesPassed+s.GatesFailed > 0 {
+			rate = fmt.Sprintf("%.0f%%", s.GatePassRate)
+		}
+		fmt.Printf("%-24s %6d %6d %10s %10s %7d\n", s.Name, s.Tasks, s.Completed, avg, rate, s.ForceClosed)
+	}
+}
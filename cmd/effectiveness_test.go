@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestCollectEffectiveness(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	skill := models.Skill{Name: "go-testing"}
+	idle := models.Skill{Name: "unused"}
+	database.Create(&skill)
+	database.Create(&idle)
+
+	created := time.Now().Add(-4 * time.Hour)
+	closed := created.Add(2 * time.Hour)
+	tasks := []models.Task{
+		{ID: "gur-aaaa0001", Title: "Done", Status: models.StatusClosed, CreatedAt: created, ClosedAt: &closed, CloseReason: "done"},
+		{ID: "gur-aaaa0002", Title: "Forced", Status: models.StatusClosed, CreatedAt: created, ClosedAt: &closed, CloseReason: models.ForceClosePrefix + "ship it"},
+		{ID: "gur-aaaa0003", Title: "Open", Status: models.StatusOpen},
+	}
+	for i := range tasks {
+		database.Create(&tasks[i])
+		database.Create(&models.TaskSkillLink{TaskID: tasks[i].ID, SkillID: skill.ID})
+	}
+	database.Create(&models.GateTaskLink{GateID: "gate-aaaa0001", TaskID: "gur-aaaa0001", Status: models.GatePassed})
+	database.Create(&models.GateTaskLink{GateID: "gate-aaaa0001", TaskID: "gur-aaaa0002", Status: models.GateFailed})
+	database.Create(&models.GateTaskLink{GateID: "gate-aaaa0002", TaskID: "gur-aaaa0003", Status: models.GatePassed})
+
+	stats, err := collectEffectiveness(database, models.TaskSkillLink{}.TableName(), "skill_id", models.Skill{}.TableName())
+	if err != nil {
+		t.Fatalf("collectEffectiveness() error: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("got %d entries, want 1 (unlinked skills are omitted)", len(stats))
+	}
+
+	s := stats[0]
+	if s.Name != "go-testing" || s.Tasks != 3 || s.Completed != 2 || s.ForceClosed != 1 {
+		t.Errorf("stats = %+v, want 3 tasks, 2 completed, 1 force-closed", s)
+	}
+	if s.AvgCloseHours < 1.99 || s.AvgCloseHours > 2.01 {
+		t.Errorf("AvgCloseHours = %.2f, want 2", s.AvgCloseHours)
+	}
+	if s.GatesPassed != 2 || s.GatesFailed != 1 {
+		t.Errorf("gates passed/failed = %d/%d, want 2/1", s.GatesPassed, s.GatesFailed)
+	}
+}
@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Manage separate backlogs (environments) in one project",
+	Long: `Keep separate backlogs such as dev, staging and prod in one project.
+
+Each environment is its own database in .guardrails/<name>.sqlite; the
+default environment is .guardrails/db.sqlite. 'gur env use' switches the
+environment every command uses, and --db <path> overrides it for a single
+command. Projects on a shared backend (gur init --backend postgres) have no
+environments.
+
+Examples:
+  gur env use staging          # Create (if needed) and switch to staging
+  gur env list
+  gur env use default          # Back to .guardrails/db.sqlite
+  gur move-env gur-abc123 --to prod
+  gur --db /tmp/scratch.sqlite list`,
+}
+
+var envUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch to an environment, creating it if needed",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runEnvUse,
+}
+
+var envListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List environments",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runEnvList,
+}
+
+var moveEnvCmd = &cobra.Command{
+	Use:   "move-env <task-id>",
+	Short: "Move a task and its subtasks to another environment",
+	Long: `Move a task, its subtasks, and their notes, history, custom fields,
+skill/agent links, gate links and GitHub links to another environment.
+
+Custom fields, skills and agents are matched by name and skipped if the
+target environment doesn't define them. Gates are copied if missing.
+Dependencies on tasks that stay behind are dropped.
+
+Examples:
+  gur move-env gur-abc123 --to prod
+  gur move-env gur-abc123 --to default`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoveEnv,
+}
+
+var moveEnvTo string
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+	envCmd.AddCommand(envUseCmd)
+	envCmd.AddCommand(envListCmd)
+	rootCmd.AddCommand(moveEnvCmd)
+
+	moveEnvCmd.Flags().StringVar(&moveEnvTo, "to", "", "Target environment (required)")
+	moveEnvCmd.MarkFlagRequired("to")
+}
+
+// requireFileBackend refuses environment commands in projects whose data
+// isn't in SQLite files
+func requireFileBackend(root string) error {
+	b, err := db.LoadBackend(root)
+	if err != nil {
+		return err
+	}
+	if b != nil && db.DBPathOverride() == "" {
+		return fmt.Errorf("environments are SQLite databases, but this project uses the %s backend", b.Name)
+	}
+	return nil
+}
+
+// openEnvDB opens an existing environment's database
+func openEnvDB(root, name string) (*gorm.DB, error) {
+	if err := db.ValidateEnvName(name); err != nil {
+		return nil, err
+	}
+	path := db.EnvDBPath(root, name)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("environment '%s' does not exist (create it with 'gur env use %s')", name, name)
+	}
+	return db.OpenDB(path)
+}
+
+func runEnvUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := db.ValidateEnvName(name); err != nil {
+		return err
+	}
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("guardrails not initialized. Run 'gur init' first")
+	}
+	if err := requireFileBackend(root); err != nil {
+		return err
+	}
+
+	path := db.EnvDBPath(root, name)
+	created := false
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		database, err := db.OpenDB(path)
+		if err != nil {
+			return err
+		}
+		mode := models.ModeDefault
+		if current, err := db.OpenDB(db.EnvDBPath(root, db.CurrentEnv(root))); err == nil {
+			var cfg models.Config
+			if current.Where("key = ?", models.ConfigMode).First(&cfg).Error == nil {
+				mode = cfg.Value
+			}
+			db.CloseConn(current)
+		}
+		err = seedDatabase(database, mode)
+		db.CloseConn(database)
+		if err != nil {
+			return err
+		}
+		created = true
+	}
+
+	if err := db.SetCurrentEnv(root, name); err != nil {
+		return fmt.Errorf("failed to switch environment: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"env": name, "path": path, "created": created})
+		return nil
+	}
+	if created {
+		fmt.Printf("Created environment %s (%s)\n", name, path)
+	}
+	fmt.Printf("Using environment: %s\n", name)
+	return nil
+}
+
+func runEnvList(cmd *cobra.Command, args []string) error {
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("guardrails not initialized. Run 'gur init' first")
+	}
+	envs, err := db.ListEnvs(root)
+	if err != nil {
+		return err
+	}
+	current := db.CurrentEnv(root)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"current": current, "environments": envs})
+		return nil
+	}
+	for _, name := range envs {
+		marker := "  "
+		if name == current {
+			marker = "* "
+		}
+		fmt.Printf("%s%s\n", marker, name)
+	}
+	return nil
+}
+
+// envMove summarizes a move-env
+type envMove struct {
+	Tasks       []string `json:"tasks"`
+	DroppedDeps int      `json:"dropped_dependencies"`
+	Skipped     []string `json:"skipped,omitempty"` // Links the target couldn't take
+}
+
+// collectSubtree returns the IDs of a task and all its subtasks
+func collectSubtree(database *gorm.DB, rootID string) ([]string, error) {
+	ids := []string{rootID}
+	for i := 0; i < len(ids); i++ {
+		var children []string
+		if err := database.Model(&models.Task{}).Where("parent_id = ?", ids[i]).Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+		ids = append(ids, children...)
+	}
+	return ids, nil
+}
+
+// moveTasksBetweenDBs copies tasks and their related rows from src to dst,
+// then removes them from src
+func moveTasksBetweenDBs(src, dst *gorm.DB, ids []string) (envMove, error) {
+	move := envMove{Tasks: ids}
+	moving := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		moving[id] = true
+	}
+
+	var tasks []models.Task
+	if err := src.Where("id IN ?", ids).Find(&tasks).Error; err != nil {
+		return move, err
+	}
+	var taken int64
+	if err := dst.Unscoped().Model(&models.Task{}).Where("id IN ?", ids).Count(&taken).Error; err != nil {
+		return move, err
+	}
+	if taken > 0 {
+		return move, fmt.Errorf("target environment already has a task with one of these IDs")
+	}
+
+	var notes []models.NoteEntry
+	var history []models.TaskHistory
+	var fieldValues []models.TaskFieldValue
+	var skillLinks []models.TaskSkillLink
+	var agentLinks []models.TaskAgentLink
+	var gateLinks []models.GateTaskLink
+	var issueLinks []models.GitHubIssueLink
+	var deps []models.Dependency
+	for _, q := range []struct {
+		dest  interface{}
+		query *gorm.DB
+	}{
+		{&notes, src.Where("task_id IN ?", ids)},
+		{&history, src.Where("task_id IN ?", ids)},
+		{&fieldValues, src.Preload("Field").Where("task_id IN ?", ids)},
+		{&skillLinks, src.Preload("Skill").Where("task_id IN ?", ids)},
+		{&agentLinks, src.Preload("Agent").Where("task_id IN ?", ids)},
+		{&gateLinks, src.Where("task_id IN ?", ids)},
+		{&issueLinks, src.Where("task_id IN ?", ids)},
+		{&deps, src.Where("parent_id IN ? OR child_id IN ?", ids, ids)},
+	} {
+		if err := q.query.Find(q.dest).Error; err != nil {
+			return move, err
+		}
+	}
+
+	err := dst.Transaction(func(tx *gorm.DB) error {
+		for i := range tasks {
+			if !moving[tasks[i].ParentID] {
+				tasks[i].ParentID = "" // The parent stays behind
+			}
+			if err := tx.Create(&tasks[i]).Error; err != nil {
+				return fmt.Errorf("failed to copy task '%s': %w", tasks[i].ID, err)
+			}
+		}
+		for _, n := range notes {
+			n.ID = 0
+			if err := tx.Create(&n).Error; err != nil {
+				return err
+			}
+		}
+		if len(history) > 0 {
+			if err := tx.Create(&history).Error; err != nil {
+				return err
+			}
+		}
+		for _, fv := range fieldValues {
+			var field models.CustomField
+			if tx.Where("name = ?", fv.Field.Name).First(&field).Error != nil {
+				move.Skipped = append(move.Skipped, fmt.Sprintf("%s: field %s", fv.TaskID, fv.Field.Name))
+				continue
+			}
+			if err := tx.Create(&models.TaskFieldValue{TaskID: fv.TaskID, FieldID: field.ID, Value: fv.Value}).Error; err != nil {
+				return err
+			}
+		}
+		for _, l := range skillLinks {
+			var skill models.Skill
+			if tx.Where("name = ?", l.Skill.Name).First(&skill).Error != nil {
+				move.Skipped = append(move.Skipped, fmt.Sprintf("%s: skill %s", l.TaskID, l.Skill.Name))
+				continue
+			}
+			if err := tx.Create(&models.TaskSkillLink{TaskID: l.TaskID, SkillID: skill.ID}).Error; err != nil {
+				return err
+			}
+		}
+		for _, l := range agentLinks {
+			var agent models.Agent
+			if tx.Where("name = ?", l.Agent.Name).First(&agent).Error != nil {
+				move.Skipped = append(move.Skipped, fmt.Sprintf("%s: agent %s", l.TaskID, l.Agent.Name))
+				continue
+			}
+			if err := tx.Create(&models.TaskAgentLink{TaskID: l.TaskID, AgentID: agent.ID, IsPrimary: l.IsPrimary}).Error; err != nil {
+				return err
+			}
+		}
+		for _, l := range gateLinks {
+			var count int64
+			tx.Model(&models.Gate{}).Where("id = ?", l.GateID).Count(&count)
+			if count == 0 {
+				var gate models.Gate
+				if err := src.Where("id = ?", l.GateID).First(&gate).Error; err != nil {
+					move.Skipped = append(move.Skipped, fmt.Sprintf("%s: gate %s", l.TaskID, l.GateID))
+					continue
+				}
+				if err := tx.Create(&gate).Error; err != nil {
+					return err
+				}
+			}
+			l.ID = 0
+			if err := tx.Create(&l).Error; err != nil {
+				return err
+			}
+		}
+		for _, l := range issueLinks {
+			l.ID = 0
+			if err := tx.Create(&l).Error; err != nil {
+				return err
+			}
+		}
+		for _, d := range deps {
+			if !moving[d.ParentID] || !moving[d.ChildID] {
+				move.DroppedDeps++
+				continue
+			}
+			d.ID = 0
+			if err := tx.Create(&d).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return move, err
+	}
+
+	err = src.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&models.NoteEntry{}, &models.TaskHistory{}, &models.TaskFieldValue{}, &models.TaskSkillLink{},
+			&models.TaskAgentLink{}, &models.GateTaskLink{}, &models.GitHubIssueLink{},
+		} {
+			if err := tx.Unscoped().Where("task_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Where("parent_id IN ? OR child_id IN ?", ids, ids).Delete(&models.Dependency{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Task{}).Error
+	})
+	if err != nil {
+		return move, fmt.Errorf("copied to the target environment but failed to remove from this one: %w", err)
+	}
+	return move, nil
+}
+
+func runMoveEnv(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot move task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return err
+	}
+	if err := requireFileBackend(root); err != nil {
+		return err
+	}
+	srcPath, _ := db.GetDefaultDBPath()
+	if srcPath == db.EnvDBPath(root, moveEnvTo) {
+		return fmt.Errorf("task '%s' is already in environment '%s'", task.ID, moveEnvTo)
+	}
+
+	target, err := openEnvDB(root, moveEnvTo)
+	if err != nil {
+		return err
+	}
+	defer db.CloseConn(target)
+
+	database := db.GetDB()
+	ids, err := collectSubtree(database, task.ID)
+	if err != nil {
+		return err
+	}
+	move, err := moveTasksBetweenDBs(database, target, ids)
+	if err != nil {
+		return fmt.Errorf("failed to move task '%s' to '%s': %w", task.ID, moveEnvTo, err)
+	}
+	noteAffected(ids...)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "to": moveEnvTo, "move": move})
+		return nil
+	}
+	fmt.Printf("Moved %d task(s) to %s: %s\n", len(ids), moveEnvTo, task.ID)
+	if move.DroppedDeps > 0 {
+		fmt.Printf("Dropped %d dependency(ies) on tasks left behind\n", move.DroppedDeps)
+	}
+	for _, s := range move.Skipped {
+		fmt.Printf("Skipped (not defined in %s): %s\n", moveEnvTo, s)
+	}
+	return nil
+}
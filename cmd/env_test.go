@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestMoveTasksBetweenDBs(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	src := db.GetDB()
+	dst, err := db.OpenDB(filepath.Join(t.TempDir(), "prod.sqlite"))
+	if err != nil {
+		t.Fatalf("open target: %v", err)
+	}
+	defer db.CloseConn(dst)
+
+	parent := models.Task{ID: "gur-e1000000", Title: "Ship", Status: models.StatusOpen}
+	child := models.Task{ID: "gur-e1000000.1", ParentID: parent.ID, Title: "Build", Status: models.StatusOpen}
+	other := models.Task{ID: "gur-e2000000", Title: "Stays", Status: models.StatusOpen}
+	for _, task := range []*models.Task{&parent, &child, &other} {
+		src.Create(task)
+	}
+	src.Create(&models.Dependency{ParentID: child.ID, ChildID: parent.ID})
+	src.Create(&models.Dependency{ParentID: other.ID, ChildID: parent.ID})
+	src.Create(&models.NoteEntry{TaskID: parent.ID, Kind: models.NoteKindDecision, Body: "Ship Friday"})
+	src.Create(&models.Skill{Name: "go"})
+	var skill models.Skill
+	src.Where("name = ?", "go").First(&skill)
+	src.Create(&models.TaskSkillLink{TaskID: parent.ID, SkillID: skill.ID})
+	src.Create(&models.Gate{ID: "gate-e1000000", Title: "Smoke"})
+	src.Create(&models.GateTaskLink{GateID: "gate-e1000000", TaskID: child.ID, Status: models.GateLinkPassed})
+
+	ids, err := collectSubtree(src, parent.ID)
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("collectSubtree = %v, %v", ids, err)
+	}
+	move, err := moveTasksBetweenDBs(src, dst, ids)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if move.DroppedDeps != 1 || len(move.Skipped) != 1 {
+		t.Errorf("move = %+v, want 1 dropped dependency and the skill skipped", move)
+	}
+
+	var count int64
+	src.Unscoped().Model(&models.Task{}).Where("id IN ?", ids).Count(&count)
+	if count != 0 {
+		t.Errorf("%d moved task(s) left in source", count)
+	}
+	dst.Model(&models.Task{}).Where("id IN ?", ids).Count(&count)
+	if count != 2 {
+		t.Errorf("target has %d task(s), want 2", count)
+	}
+	dst.Model(&models.Dependency{}).Count(&count)
+	if count != 1 {
+		t.Errorf("target has %d dependencies, want 1", count)
+	}
+	var note models.NoteEntry
+	if err := dst.Where("task_id = ?", parent.ID).First(&note).Error; err != nil || note.Body != "Ship Friday" {
+		t.Errorf("note not moved: %v %+v", err, note)
+	}
+	var link models.GateTaskLink
+	if err := dst.Where("task_id = ?", child.ID).First(&link).Error; err != nil || link.Status != models.GateLinkPassed {
+		t.Errorf("gate link not moved: %v %+v", err, link)
+	}
+	if err := dst.Where("id = ?", "gate-e1000000").First(&models.Gate{}).Error; err != nil {
+		t.Errorf("gate not copied: %v", err)
+	}
+
+	// Moving the same IDs again must not clobber the target
+	src.Create(&models.Task{ID: parent.ID, Title: "Again", Status: models.StatusOpen})
+	if _, err := moveTasksBetweenDBs(src, dst, []string{parent.ID}); err == nil {
+		t.Error("expected error for ID already in target")
+	}
+}
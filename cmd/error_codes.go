@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Error codes reported in JSON error output. They are stable: agents
+// branch on them instead of matching message text (see 'gur explain').
+const (
+	ErrCodeNotFound      = "ERR_NOT_FOUND"
+	ErrCodeNotConfigured = "ERR_NOT_CONFIGURED"
+	ErrCodeBlocked       = "ERR_BLOCKED"
+	ErrCodeGatePending   = "ERR_GATE_PENDING"
+	ErrCodeGateMissing   = "ERR_GATE_MISSING"
+	ErrCodeWorkMissing   = "ERR_WORK_MISSING"
+	ErrCodeCriteriaUnmet = "ERR_CRITERIA_UNMET"
+	ErrCodeUntrusted     = "ERR_UNTRUSTED_VERIFIER"
+	ErrCodeRemoteLinked  = "ERR_REMOTE_LINKED"
+	ErrCodeTooLarge      = "ERR_TOO_LARGE"
+	ErrCodeUsage         = "ERR_USAGE"
+	ErrCodeGeneral       = "ERR_GENERAL"
+)
+
+// errorCodeInfo documents an error code for 'gur explain'
+type errorCodeInfo struct {
+	Code        string `json:"code"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	Hint        string `json:"hint"`
+}
+
+// errorCodes lists every error code, in the order 'gur explain' shows them
+var errorCodes = []errorCodeInfo{
+	{
+		Code:        ErrCodeNotFound,
+		Summary:     "A task, gate, template or other named item does not exist",
+		Description: "The ID or name given to the command matched nothing. IDs are case-sensitive and subtask IDs include the parent (gur-abc123.1). Archived tasks are hidden from 'gur list' unless --archived is given.",
+		Hint:        "Look the item up with its list command ('gur list', 'gur gate list', 'gur template list'...) or 'gur search <keyword>', then retry with the exact ID",
+	},
+	{
+		Code:        ErrCodeNotConfigured,
+		Summary:     "A setting the command needs is missing",
+		Description: "The project has no database yet, or the command talks to GitHub (or another service) that hasn't been set up: no repository or no token.",
+		Hint:        "Run 'gur init' in the project root, or 'gur config github' (or set GUR_GITHUB_TOKEN) for GitHub commands",
+	},
+	{
+		Code:        ErrCodeBlocked,
+		Summary:     "The task can't close while other tasks are open",
+		Description: "The task is blocked by an open task ('gur dep add') or still has open subtasks. Closing is refused until they are done.",
+		Hint:        "See what's in the way with 'gur show <id>', close the blockers or subtasks first, or ask a human to close with --force",
+	},
+	{
+		Code:        ErrCodeGatePending,
+		Summary:     "A gate linked to the task hasn't been verified",
+		Description: "Every gate linked to a task must pass (or be waived) for that task before it can close. The message lists each unverified gate with the command that verifies it; gates awaiting approval need an approver.",
+		Hint:        "Run the gate ('gur gate run <gate-id> <task-id>') or record its result with 'gur gate pass <gate-id> <task-id>', then close again",
+	},
+	{
+		Code:        ErrCodeGateMissing,
+		Summary:     "The task has no gate, or lacks one its priority requires",
+		Description: "A task needs at least one linked gate to close, and the priority policy ('gur config policy') can require gates of given types for each priority.",
+		Hint:        "Find a gate with 'gur gate list' and link it with 'gur gate link <gate-id> <task-id>'",
+	},
+	{
+		Code:        ErrCodeWorkMissing,
+		Summary:     "The task's type requires linked work it doesn't have",
+		Description: "The policy ('gur config policy --require-artifacts') requires tasks of some types, e.g. bugs and features, to link the change that did the work before they close: a stored artifact, or the URL of a pull request or commit in the description or notes.",
+		Hint:        "Capture the change with 'gur artifact add <task-id> --from-git <range>', or add the PR or commit URL with 'gur note add <task-id> \"<url>\"'",
+	},
+	{
+		Code:        ErrCodeCriteriaUnmet,
+		Summary:     "The task has unchecked acceptance criteria",
+		Description: "The policy ('gur config policy --require-acceptance') requires every acceptance criterion of a task to be checked before it closes.",
+		Hint:        "List them with 'gur ac list <task-id>' and check them off with 'gur ac check <task-id> <number>'",
+	},
+	{
+		Code:        ErrCodeUntrusted,
+		Summary:     "The verifier may not pass gates of this type",
+		Description: "The trust rules ('gur config trust') limit who may pass each gate type, e.g. only humans may pass review gates while agents may pass test gates. The actor running the command (GUR_ACTOR, or the configured machine name) is not among the allowed verifiers.",
+		Hint:        "Ask an allowed verifier to pass the gate ('gur config trust --show' lists them); a human can record an audited exception with --override-trust \"<reason>\", confirmed in a terminal",
+	},
+	{
+		Code:        ErrCodeRemoteLinked,
+		Summary:     "The task is synced to a GitHub issue and can't be deleted",
+		Description: "Deleting a task linked to a GitHub issue would leave the issue open with no task behind it, so 'gur delete' refuses unless told what to do with the issue.",
+		Hint:        "Close the task instead, or delete it with --detach-remote to comment on and close the issue",
+	},
+	{
+		Code:        ErrCodeTooLarge,
+		Summary:     "A note or description is over the configured size limit",
+		Description: "The size limits ('gur config limits') cap how many bytes a single note or a description may hold, and the reject mode refuses text over them instead of warning or truncating.",
+		Hint:        "Shorten the text, e.g. keep the last lines of a log or store the whole output with 'gur artifact add', then retry",
+	},
+	{
+		Code:        ErrCodeUsage,
+		Summary:     "The command line is invalid",
+		Description: "An unknown command or flag, a flag value of the wrong type, or the wrong number of arguments.",
+		Hint:        "Check the usage with 'gur <command> --help'",
+	},
+	{
+		Code:        ErrCodeGeneral,
+		Summary:     "Any other error",
+		Description: "The error has no specific code yet, e.g. a database or network failure or an invalid value. The message explains it.",
+		Hint:        "Read the message; retrying won't help unless the cause was transient",
+	},
+}
+
+// lookupErrorCode returns the documentation of a code. The ERR_ prefix and
+// case are optional.
+func lookupErrorCode(code string) (errorCodeInfo, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !strings.HasPrefix(code, "ERR_") {
+		code = "ERR_" + code
+	}
+	for _, info := range errorCodes {
+		if info.Code == code {
+			return info, true
+		}
+	}
+	return errorCodeInfo{}, false
+}
+
+// codedError attaches an error code to an error
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+func (e *codedError) Unwrap() error { return e.err }
+
+// codedErrorf formats an error carrying code
+func codedErrorf(code, format string, args ...interface{}) error {
+	return &codedError{code: code, err: fmt.Errorf(format, args...)}
+}
+
+// withErrorCode attaches code to err, keeping a code err already carries
+func withErrorCode(code string, err error) error {
+	var coded *codedError
+	if err == nil || errors.As(err, &coded) {
+		return err
+	}
+	return &codedError{code: code, err: err}
+}
+
+// reportedError is a failure a command already described in its JSON
+// output, so only the exit status reports it
+type reportedError struct {
+	err error
+}
+
+func (e *reportedError) Error() string { return e.err.Error() }
+func (e *reportedError) Unwrap() error { return e.err }
+
+// reportedErrorf formats an error carrying code that JSON output doesn't
+// repeat
+func reportedErrorf(code, format string, args ...interface{}) error {
+	return &reportedError{err: codedErrorf(code, format, args...)}
+}
+
+// errorCodeOf returns the code carried by err, or ERR_GENERAL
+func errorCodeOf(err error) string {
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return ErrCodeGeneral
+}
+
+// errorJSON is the JSON output for a failed command
+func errorJSON(err error) map[string]interface{} {
+	code := errorCodeOf(err)
+	result := map[string]interface{}{"error": true, "code": code, "message": err.Error()}
+	if info, ok := lookupErrorCode(code); ok {
+		result["hint"] = info.Hint
+	}
+	return result
+}
+
+// markUsageErrors codes flag and argument errors of cmd and its subcommands
+// as ERR_USAGE
+func markUsageErrors(cmd *cobra.Command) {
+	if !cmd.HasParent() {
+		cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+			return withErrorCode(ErrCodeUsage, err)
+		})
+	}
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error {
+			return withErrorCode(ErrCodeUsage, args(c, a))
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		markUsageErrors(sub)
+	}
+}
@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// EnvActor overrides the actor recorded in the event log
+const EnvActor = "GUR_ACTOR"
+
+var (
+	eventsSince   string
+	eventsCommand string
+	eventsLimit   int
+	eventsFormat  string
+	eventsOutput  string
+)
+
+// readOnlyAnnotation marks commands that never mutate state and so are not
+// logged. It is set per command, not inherited by subcommands.
+const readOnlyAnnotation = "gur-read-only"
+
+// secretFlagAnnotation marks flags whose values are secrets; events record
+// them as [redacted]
+const secretFlagAnnotation = "gur-secret"
+
+// markSecretFlags annotates the named flags of cmd as secrets
+func markSecretFlags(cmd *cobra.Command, names ...string) {
+	for _, name := range names {
+		if err := cmd.Flags().SetAnnotation(name, secretFlagAnnotation, []string{"true"}); err != nil {
+			panic(fmt.Sprintf("cannot mark flag --%s of '%s' secret: %v", name, cmd.Name(), err))
+		}
+	}
+}
+
+var entityIDRegex = regexp.MustCompile(`^(gur|gate|tmpl)-[0-9a-f]{8}(\.\d+)*$`)
+
+// Per-invocation event state, filled in as the command runs
+var (
+	commandStartedAt time.Time
+	commandAffected  []string
+	commandReverts   uint
+)
+
+var eventsCmd = &cobra.Command{
+	Use:         "events",
+	Short:       "Audit log of mutating commands",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Every command that changes the database is appended to an event log
+recording the command, its arguments, the actor, and the affected IDs.
+
+Events are hash-chained: each stores the hash of the one before it, so
+edits or deletions are detected by 'gur events verify'.
+
+The actor defaults to the configured machine name; set GUR_ACTOR to
+override it (e.g., with an agent name).`,
+}
+
+var eventsListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List recorded events",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `List recorded events, most recent first.
+
+Examples:
+  gur events list --since 24h
+  gur events list --since 7d --command close
+  gur events list --jsonl --limit 0 | jq -r .command`,
+	RunE: runEventsList,
+}
+
+var eventsExportCmd = &cobra.Command{
+	Use:         "export",
+	Short:       "Export the event log",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Export the event log in chronological order.
+
+Examples:
+  gur events export --format jsonl > audit.jsonl
+  gur events export --since 2024-01-01 -o audit.jsonl`,
+	RunE: runEventsExport,
+}
+
+var eventsVerifyCmd = &cobra.Command{
+	Use:         "verify",
+	Short:       "Verify the event log hash chain",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runEventsVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+	eventsCmd.AddCommand(eventsListCmd)
+	eventsCmd.AddCommand(eventsExportCmd)
+	eventsCmd.AddCommand(eventsVerifyCmd)
+
+	for _, c := range []*cobra.Command{eventsListCmd, eventsExportCmd} {
+		c.Flags().StringVar(&eventsSince, "since", "", "Only events after this time (e.g., 24h, 7d, 2024-01-02)")
+		c.Flags().StringVar(&eventsCommand, "command", "", "Only events for this command (e.g., close, gate pass)")
+	}
+	eventsListCmd.Flags().IntVarP(&eventsLimit, "limit", "n", 50, "Maximum events to show (0 = all)")
+	addJSONLFlag(eventsListCmd)
+	eventsExportCmd.Flags().StringVarP(&eventsFormat, "format", "f", "jsonl", "Output format (jsonl/json)")
+	eventsExportCmd.Flags().StringVarP(&eventsOutput, "output", "o", "", "Write to file instead of stdout")
+}
+
+// parseSince parses a relative duration (30m, 24h, 7d, 2w) or a date into a cutoff time
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := parseAnyDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, models.DateTimeShortFormat, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value '%s': use a duration like 24h, 7d, or 2w, or a date like 2024-01-02", s)
+}
+
+// eventsQuery selects events matching the --since and --command filters
+func eventsQuery(order string, limit int) (*gorm.DB, error) {
+	query := db.GetDB().Model(&models.Event{})
+	if eventsSince != "" {
+		cutoff, err := parseSince(eventsSince, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("started_at >= ?", cutoff)
+	}
+	if eventsCommand != "" {
+		query = query.Where("command = ?", eventsCommand)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	return query.Order(order), nil
+}
+
+// streamEvents writes events matching the filters as JSON Lines to w
+func streamEvents(order string, limit int, w *jsonlWriter) error {
+	query, err := eventsQuery(order, limit)
+	if err != nil {
+		return err
+	}
+	if err := streamRows(db.GetDB(), query, func(e models.Event) error { return w.write(e) }); err != nil {
+		return fmt.Errorf("failed to read events: database error: %w", err)
+	}
+	return nil
+}
+
+// queryEvents returns events matching the --since and --command filters
+func queryEvents(order string, limit int) ([]models.Event, error) {
+	query, err := eventsQuery(order, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.Event
+	if err := query.Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to read events: database error: %w", err)
+	}
+	return events, nil
+}
+
+func runEventsList(cmd *cobra.Command, args []string) error {
+	if jsonlOutput {
+		return streamEvents("id DESC", eventsLimit, newJSONLWriter(nil, nil))
+	}
+
+	events, err := queryEvents("id DESC", eventsLimit)
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(events), "events": events})
+		return nil
+	}
+
+	if len(events) == 0 {
+		fmt.Println("No events recorded")
+		return nil
+	}
+
+	for _, e := range events {
+		status := "ok"
+		if !e.Success {
+			status = "failed"
+		}
+		fmt.Printf("[%s] #%d %s %s", e.StartedAt.Format(models.DateTimeFormat), e.ID, e.Command, status)
+		if e.Actor != "" {
+			fmt.Printf(" (by %s)", e.Actor)
+		}
+		fmt.Println()
+		if len(e.AffectedIDs) > 0 {
+			fmt.Printf("    affected: %s\n", strings.Join(e.AffectedIDs, ", "))
+		}
+		if e.Error != "" {
+			fmt.Printf("    error: %s\n", e.Error)
+		}
+	}
+	return nil
+}
+
+func runEventsExport(cmd *cobra.Command, args []string) error {
+	if eventsFormat != "jsonl" && eventsFormat != "json" {
+		return fmt.Errorf("invalid format '%s': must be jsonl or json", eventsFormat)
+	}
+
+	var w io.Writer = os.Stdout
+	if eventsOutput != "" {
+		f, err := os.Create(eventsOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", eventsOutput, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+	var count int
+	if eventsFormat == "json" {
+		events, err := queryEvents("id ASC", 0)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(events); err != nil {
+			return fmt.Errorf("failed to write events: %w", err)
+		}
+		count = len(events)
+	} else {
+		jw := newJSONLWriter(bw, nil)
+		if err := streamEvents("id ASC", 0, jw); err != nil {
+			return err
+		}
+		count = jw.count
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+
+	if eventsOutput != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d events to %s\n", count, eventsOutput)
+	}
+	return nil
+}
+
+func runEventsVerify(cmd *cobra.Command, args []string) error {
+	var events []models.Event
+	if err := db.GetDB().Order("id ASC").Find(&events).Error; err != nil {
+		return fmt.Errorf("failed to read events: database error: %w", err)
+	}
+
+	brokenAt := models.VerifyEventChain(events)
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(events), "valid": brokenAt == 0, "broken_at": brokenAt})
+		return nil
+	}
+	if brokenAt != 0 {
+		return fmt.Errorf("event log verification failed at event #%d: the log has been modified", brokenAt)
+	}
+	fmt.Printf("Event log intact (%d events)\n", len(events))
+	return nil
+}
+
+// noteAffected records IDs touched by the running command that don't appear
+// in its arguments (e.g., the ID of a newly created task)
+func noteAffected(ids ...string) {
+	commandAffected = append(commandAffected, ids...)
+}
+
+// eventCommandName returns the command path without the root, e.g. "gate pass"
+func eventCommandName(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+}
+
+// isLoggedCommand reports whether the command mutates state and should be logged
+func isLoggedCommand(cmd *cobra.Command) bool {
+	if cmd == rootCmd || cmd.Annotations[aliasAnnotation] != "" {
+		return false
+	}
+	if cmd.Annotations[readOnlyAnnotation] != "" {
+		return false
+	}
+	return !commandsExemptFromDB[cmd.Name()] && !(cmd.HasParent() && commandsExemptFromDB[cmd.Parent().Name()])
+}
+
+// eventActor returns who is running the command
+func eventActor() string {
+	if actor := os.Getenv(EnvActor); actor != "" {
+		return actor
+	}
+	if name, err := db.GetConfig(models.ConfigMachineName); err == nil && name != "" {
+		return name
+	}
+	return "user"
+}
+
+// buildEventArgs returns positional args plus changed flags, with secrets redacted
+func buildEventArgs(cmd *cobra.Command, args []string) []string {
+	out := append([]string{}, args...)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name == "json" {
+			return
+		}
+		value := f.Value.String()
+		if len(f.Annotations[secretFlagAnnotation]) > 0 {
+			value = "[redacted]"
+		}
+		out = append(out, fmt.Sprintf("--%s=%s", f.Name, value))
+	})
+	return out
+}
+
+// recordCommandEvent appends the executed command to the event log
+func recordCommandEvent(cmd *cobra.Command, args []string, runErr error) {
+	database := db.GetDB()
+	if cmd == nil || database == nil || commandStartedAt.IsZero() || !isLoggedCommand(cmd) {
+		return
+	}
+
+	affected := []string{}
+	seen := map[string]bool{}
+	for _, id := range append(append([]string{}, args...), commandAffected...) {
+		if entityIDRegex.MatchString(id) && !seen[id] {
+			seen[id] = true
+			affected = append(affected, id)
+		}
+	}
+
+	event := &models.Event{
+		Command:     eventCommandName(cmd),
+		Args:        buildEventArgs(cmd, args),
+		Actor:       eventActor(),
+		AffectedIDs: affected,
+		Success:     runErr == nil,
+		Reverts:     commandReverts,
+		StartedAt:   commandStartedAt,
+		FinishedAt:  time.Now(),
+	}
+	if runErr != nil {
+		event.Error = runErr.Error()
+	}
+	if err := models.AppendEvent(database, event); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record event: %v\n", err)
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/zalando/go-keyring"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"24h", now.Add(-24 * time.Hour), false},
+		{"30m", now.Add(-30 * time.Minute), false},
+		{"7d", now.Add(-7 * 24 * time.Hour), false},
+		{"2w", now.Add(-14 * 24 * time.Hour), false},
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local), false},
+		{"yesterday", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseSince(tt.in, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSince(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendEventChain(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	for _, c := range []string{"create", "close"} {
+		e := &models.Event{Command: c, Success: true, StartedAt: time.Now(), FinishedAt: time.Now()}
+		if err := models.AppendEvent(database, e); err != nil {
+			t.Fatalf("AppendEvent(%s) error: %v", c, err)
+		}
+	}
+
+	var events []models.Event
+	database.Order("id ASC").Find(&events)
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[1].PrevHash != events[0].Hash {
+		t.Error("second event should link to the first")
+	}
+	if got := models.VerifyEventChain(events); got != 0 {
+		t.Errorf("VerifyEventChain() after reload = %d, want 0", got)
+	}
+
+	// Events cannot be changed or removed through the ORM
+	if err := database.Model(&events[0]).Update("actor", "mallory").Error; err == nil {
+		t.Error("updating an event should fail")
+	}
+	if err := database.Delete(&events[0]).Error; err == nil {
+		t.Error("deleting an event should fail")
+	}
+}
+
+func TestIsLoggedCommandReadOnlyAnnotation(t *testing.T) {
+	for _, c := range []*cobra.Command{listCmd, gateListCmd, gatePendingCmd, eventsListCmd} {
+		if isLoggedCommand(c) {
+			t.Errorf("%s is read-only but would be logged", eventCommandName(c))
+		}
+	}
+	// Sharing a name or parent with a read-only command doesn't exempt one
+	// that mutates
+	for _, c := range []*cobra.Command{gateCreateCmd, gateOwnerSetCmd, tokenCreateCmd} {
+		if !isLoggedCommand(c) {
+			t.Errorf("%s mutates state but would not be logged", eventCommandName(c))
+		}
+	}
+}
+
+func TestSecretFlagsRedactedInEvents(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	keyring.MockInit()
+	defer func() {
+		configSummarizerCmd.Flags().VisitAll(func(f *pflag.Flag) {
+			f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+		commandStartedAt = time.Time{}
+	}()
+
+	args := []string{"--backend", "anthropic", "--api-key", "sk-ant-secret"}
+	if err := configSummarizerCmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	commandStartedAt = time.Now()
+	err := runConfigSummarizer(configSummarizerCmd, nil)
+	if err != nil {
+		t.Fatalf("runConfigSummarizer() error: %v", err)
+	}
+	recordCommandEvent(configSummarizerCmd, nil, err)
+
+	var event models.Event
+	if err := db.GetDB().Order("id DESC").First(&event).Error; err != nil {
+		t.Fatalf("no event recorded: %v", err)
+	}
+	got := strings.Join(event.Args, " ")
+	if strings.Contains(got, "sk-ant-secret") || !strings.Contains(got, "--api-key=[redacted]") || !strings.Contains(got, "--backend=anthropic") {
+		t.Errorf("event args = %q, want the API key redacted and other flags kept", got)
+	}
+
+	for _, f := range []*pflag.Flag{closeCmd.Flags().Lookup("approval"), configGitHubCmd.Flags().Lookup("token"), initCmd.Flags().Lookup("dsn")} {
+		if len(f.Annotations[secretFlagAnnotation]) == 0 {
+			t.Errorf("--%s is not marked secret", f.Name)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var expandCmd = &cobra.Command{
+	Use:   "expand <id>",
+	Short: "Create subtasks from the task description's checklist",
+	Long: `Create a subtask for each unchecked "- [ ]" item in a task's description.
+
+Each subtask remembers its checklist item: closing the subtask checks the
+box in the parent's description, and reopening it unchecks the box. A
+synced parent's GitHub issue picks up the change on the next 'gur sync
+push'. Items that are already checked or already have a subtask are
+skipped, so expand can be run again after adding items.
+
+Examples:
+  gur expand gur-abc123
+  gur expand gur-abc123 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExpand,
+}
+
+var expandDryRun bool
+
+func init() {
+	rootCmd.AddCommand(expandCmd)
+	expandCmd.Flags().BoolVar(&expandDryRun, "dry-run", false, "Show the subtasks that would be created")
+}
+
+// expandChecklist creates subtasks for the parent's unchecked checklist
+// items that don't have one yet
+func expandChecklist(database *gorm.DB, parent *models.Task, dryRun bool) ([]models.Task, error) {
+	var existing []models.Task
+	if err := database.Where("parent_id = ?", parent.ID).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	expanded := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		if t.Checklist != "" {
+			expanded[t.Checklist] = true
+		}
+	}
+
+	var created []models.Task
+	next := len(existing) + 1
+	err := database.Transaction(func(tx *gorm.DB) error {
+		for _, item := range models.ParseChecklist(parent.Description) {
+			if item.Checked || expanded[item.Text] {
+				continue
+			}
+			expanded[item.Text] = true
+			title := item.Text
+			if r := []rune(title); len(r) > 255 {
+				title = string(r[:255])
+			}
+			subtask := models.Task{
+				ID:        models.GenerateSubtaskID(parent.ID, next),
+				ParentID:  parent.ID,
+				Title:     title,
+				Status:    models.StatusOpen,
+				Priority:  parent.Priority,
+				Type:      models.TypeTask,
+				Assignee:  parent.Assignee,
+				Release:   parent.Release,
+				Checklist: item.Text,
+			}
+			next++
+			if !dryRun {
+				if err := tx.Create(&subtask).Error; err != nil {
+					return err
+				}
+				noteAffected(subtask.ID)
+			}
+			created = append(created, subtask)
+		}
+		return nil
+	})
+	return created, err
+}
+
+// syncParentChecklist checks (or unchecks) the parent's checklist box for a
+// subtask created by 'gur expand', recording the description change
+func syncParentChecklist(database *gorm.DB, task *models.Task, checked bool, by string) {
+	if task.Checklist == "" || task.ParentID == "" {
+		return
+	}
+	var parent models.Task
+	if err := database.First(&parent, "id = ?", task.ParentID).Error; err != nil {
+		return
+	}
+	description, changed := models.SetChecklistItem(parent.Description, task.Checklist, checked)
+	if !changed {
+		return
+	}
+	models.RecordChange(database, parent.ID, "description", parent.Description, description, by)
+	if err := database.Model(&parent).Update("description", description).Error; err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update checklist of %s: %v\n", parent.ID, err)
+		return
+	}
+	noteAffected(parent.ID)
+}
+
+func runExpand(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	parent, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot expand task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	if parent.IsClosed() {
+		return fmt.Errorf("cannot expand task '%s': task is closed (reopen it first with 'gur reopen %s')", parent.ID, parent.ID)
+	}
+
+	created, err := expandChecklist(database, parent, expandDryRun)
+	if err != nil {
+		return fmt.Errorf("failed to create subtasks for '%s': database error: %w", parent.ID, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "parent": parent.ID, "dry_run": expandDryRun, "count": len(created), "subtasks": created})
+		return nil
+	}
+	if IsQuietOutput() {
+		for _, t := range created {
+			printQuietIDs(t.ID)
+		}
+		return nil
+	}
+	if len(created) == 0 {
+		fmt.Printf("No unchecked checklist items without a subtask in %s\n", parent.ID)
+		return nil
+	}
+	verb := "Created"
+	if expandDryRun {
+		verb = "Would create"
+	}
+	for _, t := range created {
+		fmt.Printf("%s: %s - %s\n", verb, t.ID, t.Title)
+	}
+	return nil
+}
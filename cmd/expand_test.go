@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestExpandChecklist(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	parent := &models.Task{ID: "gur-exp00001", Title: "Launch", Status: models.StatusOpen, Priority: 1,
+		Description: "Steps:\n- [ ] Write docs\n- [x] Pick a date\n- [ ] Announce"}
+	database.Create(parent)
+
+	created, err := expandChecklist(database, parent, false)
+	if err != nil {
+		t.Fatalf("expandChecklist() error: %v", err)
+	}
+	if len(created) != 2 || created[0].ID != "gur-exp00001.1" || created[1].Title != "Announce" || created[1].Priority != 1 {
+		t.Fatalf("created = %+v, want subtasks .1 and .2 for the unchecked items", created)
+	}
+
+	// Running again only picks up new items
+	parent.Description += "\n- [ ] Blog post"
+	database.Save(parent)
+	created, _ = expandChecklist(database, parent, false)
+	if len(created) != 1 || created[0].ID != "gur-exp00001.3" || created[0].Checklist != "Blog post" {
+		t.Fatalf("second expand = %+v, want only the blog post", created)
+	}
+
+	var subtask models.Task
+	database.First(&subtask, "id = ?", "gur-exp00001.2")
+	syncParentChecklist(database, &subtask, true, "tester")
+	var got models.Task
+	database.First(&got, "id = ?", parent.ID)
+	if want := "Steps:\n- [ ] Write docs\n- [x] Pick a date\n- [x] Announce\n- [ ] Blog post"; got.Description != want {
+		t.Errorf("after closing subtask description = %q, want %q", got.Description, want)
+	}
+
+	syncParentChecklist(database, &subtask, false, "tester")
+	var reopened models.Task
+	database.First(&reopened, "id = ?", parent.ID)
+	if items := models.ParseChecklist(reopened.Description); items[2].Checked {
+		t.Errorf("after reopening subtask items = %+v, want Announce unchecked", items)
+	}
+}
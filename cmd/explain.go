@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:         "explain [code|-]",
+	Short:       "Explain an error code",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Explain the error code of a failed command: what it means and what to do.
+
+With --json, failed commands print {"error": true, "code": "...", "message":
+"...", "hint": "..."}. Branch on the code, which is stable; the message may
+be reworded. Give '-' to read such a JSON result from stdin. Without an
+argument, every code is listed.
+
+Examples:
+  gur explain ERR_GATE_PENDING
+  gur explain not_found
+  gur close gur-abc123 --json | gur explain -
+  gur explain --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
+
+// explainResultCode reads the error code from a command's JSON result
+func explainResultCode(r io.Reader) (string, error) {
+	var result struct {
+		Error bool   `json:"error"`
+		Code  string `json:"code"`
+	}
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return "", fmt.Errorf("invalid command result: expected the JSON output of a gur command: %w", err)
+	}
+	if !result.Error {
+		return "", fmt.Errorf("the command succeeded: its result has no error code")
+	}
+	if result.Code == "" {
+		return ErrCodeGeneral, nil
+	}
+	return result.Code, nil
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"count": len(errorCodes), "codes": errorCodes})
+			return nil
+		}
+		for _, info := range errorCodes {
+			fmt.Printf("%-20s %s\n", info.Code, info.Summary)
+		}
+		return nil
+	}
+
+	code := args[0]
+	if code == "-" {
+		var err error
+		if code, err = explainResultCode(os.Stdin); err != nil {
+			return err
+		}
+	}
+	info, ok := lookupErrorCode(code)
+	if !ok {
+		return codedErrorf(ErrCodeNotFound, "unknown error code '%s' (use 'gur explain' to list codes)", code)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(info)
+		return nil
+	}
+	fmt.Printf("%s: %s\n\n%s\n\nWhat to do: %s\n", info.Code, info.Summary, info.Description, info.Hint)
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestErrorCodes(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+	defer func() { closeReason = "" }()
+
+	database.Create(&models.Task{ID: "gur-code0001", Title: "Blocker", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-code0002", Title: "Blocked", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-code0003", Title: "Gated", Status: models.StatusOpen})
+	database.Create(&models.Dependency{ParentID: "gur-code0001", ChildID: "gur-code0002", Type: models.DepTypeBlocks})
+	database.Create(&models.Gate{ID: "gate-code1", Title: "Build"})
+	database.Create(&models.GateTaskLink{GateID: "gate-code1", TaskID: "gur-code0003", Status: models.GateLinkPending})
+
+	closeReason = "Done"
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"gur-missing", ErrCodeNotFound},
+		{"gur-code0002", ErrCodeBlocked},
+		{"gur-code0003", ErrCodeGatePending},
+		{"gur-code0001", ErrCodeGateMissing},
+	}
+	for _, tt := range tests {
+		err := runClose(closeCmd, []string{tt.id})
+		if got := errorCodeOf(err); err == nil || got != tt.want {
+			t.Errorf("close %s = %v (%s), want %s", tt.id, err, got, tt.want)
+		}
+	}
+
+	// Codes survive wrapping, and the first code attached wins
+	err := fmt.Errorf("sync failed: %w", codedErrorf(ErrCodeNotConfigured, "no repository"))
+	if got := errorCodeOf(withErrorCode(ErrCodeUsage, err)); got != ErrCodeNotConfigured {
+		t.Errorf("wrapped code = %s, want %s", got, ErrCodeNotConfigured)
+	}
+	result := errorJSON(fmt.Errorf("disk full"))
+	if result["code"] != ErrCodeGeneral || result["hint"] == "" {
+		t.Errorf("errorJSON() = %v, want ERR_GENERAL with a hint", result)
+	}
+
+	// Argument errors are usage errors
+	cmd := &cobra.Command{Use: "x", Args: cobra.ExactArgs(1), RunE: func(*cobra.Command, []string) error { return nil }}
+	markUsageErrors(cmd)
+	if got := errorCodeOf(cmd.Args(cmd, nil)); got != ErrCodeUsage {
+		t.Errorf("argument error code = %s, want %s", got, ErrCodeUsage)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	for _, code := range []string{"ERR_GATE_PENDING", "gate_pending", " err_gate_pending"} {
+		if info, ok := lookupErrorCode(code); !ok || info.Code != ErrCodeGatePending {
+			t.Errorf("lookupErrorCode(%q) = %v, %v", code, info, ok)
+		}
+	}
+	if _, ok := lookupErrorCode("ERR_NOPE"); ok {
+		t.Error("lookupErrorCode accepted an unknown code")
+	}
+
+	code, err := explainResultCode(strings.NewReader(`{"error": true, "code": "ERR_BLOCKED", "message": "..."}`))
+	if err != nil || code != ErrCodeBlocked {
+		t.Errorf("explainResultCode() = %s, %v", code, err)
+	}
+	if _, err := explainResultCode(strings.NewReader(`{"success": true}`)); err == nil {
+		t.Error("explainResultCode accepted a successful result")
+	}
+
+	// Every code has documentation
+	for _, info := range errorCodes {
+		if info.Summary == "" || info.Description == "" || info.Hint == "" {
+			t.Errorf("%s is missing documentation", info.Code)
+		}
+	}
+}
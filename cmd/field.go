@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// fieldHistoryPrefix prefixes the history field name for custom field changes
+const fieldHistoryPrefix = "field:"
+
+var fieldCmd = &cobra.Command{
+	Use:   "field",
+	Short: "Manage custom task fields",
+	Long: `Manage project-specific task fields.
+
+Once defined, set values with 'gur update <id> --field name=value', filter
+with 'gur list --field name=value', and clear with 'gur update <id> --field name='.
+Field values are included in JSON output and in synced GitHub issue bodies.`,
+}
+
+var fieldDefineCmd = &cobra.Command{
+	Use:   "define <name>",
+	Short: "Define a custom field",
+	Long: `Define a custom field.
+
+Types: string (default), number, bool, date (YYYY-MM-DD), enum.
+--enum sets the allowed values and implies --type enum.
+
+Examples:
+  gur field define environment --enum prod,staging
+  gur field define story-points --type number
+  gur field define customer -d "Customer who reported the issue"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFieldDefine,
+}
+
+var fieldListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List custom fields",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runFieldList,
+}
+
+var fieldRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Short:   "Remove a custom field and all its values",
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	RunE:    runFieldRemove,
+}
+
+var (
+	fieldType        string
+	fieldEnum        []string
+	fieldDescription string
+)
+
+func init() {
+	rootCmd.AddCommand(fieldCmd)
+	fieldCmd.AddCommand(fieldDefineCmd)
+	fieldCmd.AddCommand(fieldListCmd)
+	fieldCmd.AddCommand(fieldRemoveCmd)
+
+	fieldDefineCmd.Flags().StringVar(&fieldType, "type", "", "Field type (string/number/bool/date/enum)")
+	fieldDefineCmd.Flags().StringSliceVar(&fieldEnum, "enum", nil, "Allowed values (comma-separated)")
+	fieldDefineCmd.Flags().StringVarP(&fieldDescription, "description", "d", "", "Field description")
+}
+
+func runFieldDefine(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !models.ValidateFieldName(name) {
+		return fmt.Errorf("invalid field name '%s': use lowercase letters, digits, '-' or '_', starting with a letter", name)
+	}
+
+	typ := fieldType
+	if typ == "" {
+		typ = models.FieldTypeString
+		if len(fieldEnum) > 0 {
+			typ = models.FieldTypeEnum
+		}
+	}
+	valid := false
+	for _, t := range models.FieldTypes {
+		if t == typ {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid field type '%s': must be one of: %s", typ, strings.Join(models.FieldTypes, ", "))
+	}
+	if typ == models.FieldTypeEnum && len(fieldEnum) == 0 {
+		return fmt.Errorf("enum field '%s' needs allowed values (use --enum a,b,c)", name)
+	}
+	if typ != models.FieldTypeEnum && len(fieldEnum) > 0 {
+		return fmt.Errorf("--enum can only be used with enum fields, not '%s'", typ)
+	}
+
+	var existing models.CustomField
+	if err := db.GetDB().Where("name = ?", name).First(&existing).Error; err == nil {
+		return fmt.Errorf("cannot define field: field '%s' already exists (use 'gur field remove %s' first)", name, name)
+	}
+
+	field := models.CustomField{
+		Name:          name,
+		Type:          typ,
+		AllowedValues: fieldEnum,
+		Description:   fieldDescription,
+	}
+	if err := db.GetDB().Create(&field).Error; err != nil {
+		return fmt.Errorf("failed to define field '%s': database error: %w", name, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "field": field})
+	} else {
+		fmt.Printf("Defined field: %s (%s)\n", field.Name, field.Type)
+	}
+	return nil
+}
+
+func runFieldList(cmd *cobra.Command, args []string) error {
+	var fields []models.CustomField
+	if err := db.GetDB().Order("name ASC").Find(&fields).Error; err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(fields), "fields": fields})
+		return nil
+	}
+
+	if len(fields) == 0 {
+		fmt.Println("No custom fields defined. Use 'gur field define <name>' to add one.")
+		return nil
+	}
+
+	fmt.Printf("Custom Fields (%d):\n", len(fields))
+	for _, f := range fields {
+		fmt.Printf("  %s (%s)", f.Name, f.Type)
+		if len(f.AllowedValues) > 0 {
+			fmt.Printf(" [%s]", strings.Join(f.AllowedValues, ", "))
+		}
+		if f.Description != "" {
+			fmt.Printf(" - %s", f.Description)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func runFieldRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	database := db.GetDB()
+
+	var field models.CustomField
+	if err := database.Where("name = ?", name).First(&field).Error; err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot remove field: field '%s' not found (use 'gur field list' to see defined fields)", name)
+	}
+
+	if err := database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", field.ID).Delete(&models.TaskFieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&field).Error
+	}); err != nil {
+		return fmt.Errorf("failed to remove field '%s': database error: %w", name, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "message": fmt.Sprintf("Removed field: %s", name)})
+	} else {
+		fmt.Printf("Removed field: %s\n", name)
+	}
+	return nil
+}
+
+// fieldAssignment is a validated custom field value; an empty Value clears the field
+type fieldAssignment struct {
+	Field models.CustomField
+	Value string
+}
+
+// resolveFieldAssignments parses and validates name=value pairs against the
+// defined fields
+func resolveFieldAssignments(database *gorm.DB, pairs []string) ([]fieldAssignment, error) {
+	var assignments []fieldAssignment
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid field '%s': expected name=value", pair)
+		}
+
+		var field models.CustomField
+		if err := database.Where("name = ?", name).First(&field).Error; err != nil {
+			return nil, fmt.Errorf("unknown field '%s' (use 'gur field list' to see defined fields)", name)
+		}
+		if value != "" {
+			normalized, err := field.Normalize(value)
+			if err != nil {
+				return nil, err
+			}
+			value = normalized
+		}
+		assignments = append(assignments, fieldAssignment{Field: field, Value: value})
+	}
+	return assignments, nil
+}
+
+// setTaskFieldValue stores a field value for a task, deleting it when value
+// is empty, and returns the previous value
+func setTaskFieldValue(database *gorm.DB, taskID string, field models.CustomField, value string) (string, error) {
+	var existing models.TaskFieldValue
+	found := database.Where("task_id = ? AND field_id = ?", taskID, field.ID).First(&existing).Error == nil
+
+	switch {
+	case value == "" && found:
+		return existing.Value, database.Delete(&existing).Error
+	case value == "":
+		return "", nil
+	case found:
+		old := existing.Value
+		existing.Value = value
+		return old, database.Save(&existing).Error
+	default:
+		return "", database.Create(&models.TaskFieldValue{TaskID: taskID, FieldID: field.ID, Value: value}).Error
+	}
+}
+
+// applyFieldAssignments stores field values for a task, recording history
+// when changedBy is set
+func applyFieldAssignments(database *gorm.DB, taskID string, assignments []fieldAssignment, changedBy string) error {
+	for _, a := range assignments {
+		old, err := setTaskFieldValue(database, taskID, a.Field, a.Value)
+		if err != nil {
+			return fmt.Errorf("failed to set field '%s' on task '%s': database error: %w", a.Field.Name, taskID, err)
+		}
+		if changedBy != "" && old != a.Value {
+			models.RecordChange(database, taskID, fieldHistoryPrefix+a.Field.Name, old, a.Value, changedBy)
+		}
+	}
+	return nil
+}
+
+// attachFieldValues loads custom field values into Task.Fields
+func attachFieldValues(database *gorm.DB, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]string, len(tasks))
+	index := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+		index[t.ID] = i
+	}
+
+	var values []models.TaskFieldValue
+	if err := database.Preload("Field").Where("task_id IN ?", ids).Find(&values).Error; err != nil {
+		return fmt.Errorf("failed to load custom fields: database error: %w", err)
+	}
+	for _, v := range values {
+		t := &tasks[index[v.TaskID]]
+		if t.Fields == nil {
+			t.Fields = make(map[string]string)
+		}
+		t.Fields[v.Field.Name] = v.Value
+	}
+	return nil
+}
+
+// whereFieldEquals restricts a task query to tasks whose fields match every
+// name=value filter
+func whereFieldEquals(database, query *gorm.DB, filters []string) (*gorm.DB, error) {
+	assignments, err := resolveFieldAssignments(database, filters)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assignments {
+		if a.Value == "" {
+			query = query.Where("id NOT IN (?)",
+				database.Model(&models.TaskFieldValue{}).Select("task_id").Where("field_id = ?", a.Field.ID))
+			continue
+		}
+		query = query.Where("id IN (?)",
+			database.Model(&models.TaskFieldValue{}).Select("task_id").Where("field_id = ? AND value = ?", a.Field.ID, a.Value))
+	}
+	return query, nil
+}
+
+// sortedFieldNames returns the names of a task's custom fields in order
+func sortedFieldNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestCustomFieldValues(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	env := models.CustomField{Name: "environment", Type: models.FieldTypeEnum, AllowedValues: models.StringSlice{"prod", "staging"}}
+	if err := database.Create(&env).Error; err != nil {
+		t.Fatalf("create field: %v", err)
+	}
+	prod := models.Task{Title: "prod task", Status: models.StatusOpen}
+	staging := models.Task{Title: "staging task", Status: models.StatusOpen}
+	unset := models.Task{Title: "no env", Status: models.StatusOpen}
+	for _, task := range []*models.Task{&prod, &staging, &unset} {
+		database.Create(task)
+	}
+
+	if _, err := resolveFieldAssignments(database, []string{"environment=dev"}); err == nil {
+		t.Error("resolveFieldAssignments should reject values outside the enum")
+	}
+	if _, err := resolveFieldAssignments(database, []string{"owner=bob"}); err == nil {
+		t.Error("resolveFieldAssignments should reject undefined fields")
+	}
+
+	for id, value := range map[string]string{prod.ID: "Prod", staging.ID: "staging"} {
+		assignments, err := resolveFieldAssignments(database, []string{"environment=" + value})
+		if err != nil {
+			t.Fatalf("resolveFieldAssignments() error: %v", err)
+		}
+		if err := applyFieldAssignments(database, id, assignments, "user"); err != nil {
+			t.Fatalf("applyFieldAssignments() error: %v", err)
+		}
+	}
+
+	var history models.TaskHistory
+	if err := database.Where("task_id = ? AND field = ?", prod.ID, "field:environment").First(&history).Error; err != nil || history.NewValue != "prod" {
+		t.Errorf("expected history entry with normalized value 'prod', got %+v (%v)", history, err)
+	}
+
+	query, err := whereFieldEquals(database, database.Model(&models.Task{}), []string{"environment=prod"})
+	if err != nil {
+		t.Fatalf("whereFieldEquals() error: %v", err)
+	}
+	var matched []models.Task
+	query.Find(&matched)
+	if len(matched) != 1 || matched[0].ID != prod.ID {
+		t.Errorf("filter environment=prod matched %v, want only %s", matched, prod.ID)
+	}
+
+	query, _ = whereFieldEquals(database, database.Model(&models.Task{}), []string{"environment="})
+	matched = nil
+	query.Find(&matched)
+	if len(matched) != 1 || matched[0].ID != unset.ID {
+		t.Errorf("filter environment= matched %v, want only %s", matched, unset.ID)
+	}
+
+	tasks := []models.Task{prod, unset}
+	if err := attachFieldValues(database, tasks); err != nil {
+		t.Fatalf("attachFieldValues() error: %v", err)
+	}
+	if tasks[0].Fields["environment"] != "prod" || tasks[1].Fields != nil {
+		t.Errorf("attachFieldValues() = %v, %v", tasks[0].Fields, tasks[1].Fields)
+	}
+
+	clearing, _ := resolveFieldAssignments(database, []string{"environment="})
+	applyFieldAssignments(database, prod.ID, clearing, "user")
+	var count int64
+	database.Model(&models.TaskFieldValue{}).Where("task_id = ?", prod.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("clearing a field should delete its value, %d remain", count)
+	}
+}
@@ -24,6 +24,7 @@ var (
 	gateExpected    string
 	gateCommand     string
 	gateDescription string
+	gateApprovers   []string
 )
 
 var gateCmd = &cobra.Command{
@@ -35,7 +36,10 @@ Gates are requirements that must pass before a task can be closed.
 They can be tests, reviews, approvals, or any custom verification.
 
 COMMON TYPES: test, review, approval, manual, deploy, qa, doc (or any custom type)
-RESULTS: pending, passed, failed, skipped`,
+RESULTS: pending, passed, failed, skipped
+
+Use 'gur gate waive' to formally waive a gate for a task; unlike skip, a
+waiver satisfies the gate when closing.`,
 }
 
 var gateCreateCmd = &cobra.Command{
@@ -57,23 +61,26 @@ Examples:
   gur gate create "Unit tests pass" -t test -c backend
   gur gate create "Code review approved" -t review
   gur gate create "PM sign-off" -t approval
-  gur gate create "Security scan" -t security --cmd "npm audit"`,
+  gur gate create "Security scan" -t security --cmd "npm audit"
+  gur gate create "Design review" -t review --approver alice --approver bob`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGateCreate,
 }
 
 var gateListCmd = &cobra.Command{
-	Use:     "list",
-	Short:   "List gates",
-	Aliases: []string{"ls"},
-	RunE:    runGateList,
+	Use:         "list",
+	Short:       "List gates",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Aliases:     []string{"ls"},
+	RunE:        runGateList,
 }
 
 var gateShowCmd = &cobra.Command{
-	Use:   "show <gate-id>",
-	Short: "Show gate details",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runGateShow,
+	Use:         "show <gate-id>",
+	Short:       "Show gate details",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runGateShow,
 }
 
 var gatePassCmd = &cobra.Command{
@@ -83,13 +90,23 @@ var gatePassCmd = &cobra.Command{
 
 Each task requires its own gate verification - you cannot reuse a previous pass.
 
+If the gate has designated approvers, the pass is recorded as "requested"
+and an approver must confirm it with 'gur gate approve' in a terminal.
+
+If trust rules limit who may pass the gate's type ('gur config trust') and
+the actor running the command (GUR_ACTOR, or the machine name) is not
+allowed, the pass is refused unless --override-trust gives a reason and is
+confirmed in an interactive terminal. The reason is recorded in the gate
+notes and the task history.
+
 Examples:
   gur gate pass gate-abc123 gur-def456
   gur gate pass gate-abc123 gur-def456 --notes "All tests green"
-  gur gate pass gate-abc123 gur-def456 --by agent`,
+  gur gate pass gate-abc123 gur-def456 --by agent
+  gur gate pass gate-abc123 gur-def456 --override-trust "Reviewer out, approved on call"`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runGateResult(args[0], args[1], models.GateLinkPassed)
+		return runGateResult(args[0], args[1], models.GateLinkPassed, "")
 	},
 }
 
@@ -98,19 +115,51 @@ var gateFailCmd = &cobra.Command{
 	Short: "Mark a gate as failed for a specific task",
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runGateResult(args[0], args[1], models.GateLinkFailed)
+		return runGateResult(args[0], args[1], models.GateLinkFailed, "")
 	},
 }
 
 var gateSkipCmd = &cobra.Command{
 	Use:   "skip <gate-id> <task-id>",
-	Short: "Mark a gate as skipped for a specific task (still blocks close)",
+	Short: "Mark a gate as skipped for a specific task (still blocks close; see 'gate waive')",
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runGateResult(args[0], args[1], models.GateSkipped)
+		return runGateResult(args[0], args[1], models.GateSkipped, "")
 	},
 }
 
+var gateApproveCmd = &cobra.Command{
+	Use:   "approve <gate-id> <task-id>",
+	Short: "Approve a gate for a task (designated approvers only)",
+	Long: `Approve a gate for a specific task.
+
+Only names listed in the gate's approvers may approve, and the approval
+must be confirmed in an interactive terminal, so scripts and agents can
+only request a pass. This completes a pass requested with 'gur gate pass'.
+
+Example:
+  gur gate approve gate-abc123 gur-def456 --by alice`,
+	Args: cobra.ExactArgs(2),
+	RunE: runGateApprove,
+}
+
+var gateApproversCmd = &cobra.Command{
+	Use:   "approvers <gate-id> [name...]",
+	Short: "Show or set the designated approvers for a gate",
+	Long: `Show or set who may pass a gate.
+
+With no names, shows the current approvers. With names, replaces the list.
+Use --clear to remove all approvers so anyone may pass the gate. Changing
+the list must be confirmed in an interactive terminal.
+
+Examples:
+  gur gate approvers gate-abc123
+  gur gate approvers gate-abc123 alice bob
+  gur gate approvers gate-abc123 --clear`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runGateApprovers,
+}
+
 var gateLinkCmd = &cobra.Command{
 	Use:   "link <gate-id> <task-id>",
 	Short: "Link a gate to a task",
@@ -144,8 +193,12 @@ Unlink from open tasks first, or close those tasks.`,
 }
 
 var (
-	gateNotes string
-	gateRunBy string
+	gateNotes          string
+	gateRunBy          string
+	gateApproversClear bool
+	gateTrustOverride  string
+	gateApproved       bool // Set once an approver confirmed with 'gate approve'
+	gateListPage       pageOptions
 )
 
 func init() {
@@ -156,6 +209,8 @@ func init() {
 	gateCmd.AddCommand(gatePassCmd)
 	gateCmd.AddCommand(gateFailCmd)
 	gateCmd.AddCommand(gateSkipCmd)
+	gateCmd.AddCommand(gateApproveCmd)
+	gateCmd.AddCommand(gateApproversCmd)
 	gateCmd.AddCommand(gateLinkCmd)
 	gateCmd.AddCommand(gateUnlinkCmd)
 	gateCmd.AddCommand(gateDeleteCmd)
@@ -170,19 +225,25 @@ func init() {
 	gateCreateCmd.Flags().StringVar(&gateExpected, "expected", "", "Expected result")
 	gateCreateCmd.Flags().StringVar(&gateCommand, "cmd", "", "Command to run (for automated gates)")
 	gateCreateCmd.Flags().StringVarP(&gateDescription, "description", "d", "", "Description")
+	gateCreateCmd.Flags().StringArrayVar(&gateApprovers, "approver", nil, "Designated approver (repeatable)")
 
 	// List flags
 	gateListCmd.Flags().StringVarP(&gateCategory, "category", "c", "", "Filter by category")
 	gateListCmd.Flags().StringVarP(&gateType, "type", "t", "", "Filter by type")
 	gateListCmd.Flags().StringVar(&listStatus, "result", "", "Filter by last result")
+	addPageFlags(gateListCmd, &gateListPage, gateSorts)
 
 	// Pass/fail/skip flags
 	gatePassCmd.Flags().StringVar(&gateNotes, "notes", "", "Notes about the result")
 	gatePassCmd.Flags().StringVar(&gateRunBy, "by", "human", "Who verified (human/agent/name)")
+	gatePassCmd.Flags().StringVar(&gateTrustOverride, "override-trust", "", "Pass even if you aren't trusted for this gate type, recording this reason (needs a terminal)")
 	gateFailCmd.Flags().StringVar(&gateNotes, "notes", "", "Notes about the result")
 	gateFailCmd.Flags().StringVar(&gateRunBy, "by", "human", "Who verified (human/agent/name)")
 	gateSkipCmd.Flags().StringVar(&gateNotes, "notes", "", "Notes about the result")
 	gateSkipCmd.Flags().StringVar(&gateRunBy, "by", "human", "Who verified (human/agent/name)")
+	gateApproveCmd.Flags().StringVar(&gateNotes, "notes", "", "Notes about the approval")
+	gateApproveCmd.Flags().StringVar(&gateRunBy, "by", "human", "Approver name")
+	gateApproversCmd.Flags().BoolVar(&gateApproversClear, "clear", false, "Remove all approvers")
 }
 
 func runGateCreate(cmd *cobra.Command, args []string) error {
@@ -197,12 +258,17 @@ func runGateCreate(cmd *cobra.Command, args []string) error {
 		ExpectedResult: gateExpected,
 		Command:        gateCommand,
 		Labels:         gateLabels,
+		Approvers:      gateApprovers,
 		LastResult:     models.GatePending,
 	}
+	if _, err := gateCreateExec.apply(cmd, gate); err != nil {
+		return err
+	}
 
 	if err := db.GetDB().Create(gate).Error; err != nil {
 		return err
 	}
+	noteAffected(gate.ID)
 
 	if IsJSONOutput() {
 		OutputJSON(map[string]interface{}{"success": true, "gate": gate})
@@ -212,13 +278,21 @@ func runGateCreate(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  Category: %s\n", gate.Category)
 		}
 		fmt.Printf("  Type: %s\n", gate.TypeString())
+		printGateExecSettings(gate)
 	}
 	return nil
 }
 
 func runGateList(cmd *cobra.Command, args []string) error {
+	if err := gateListPage.resolve(); err != nil {
+		return err
+	}
+	if err := gateListPage.validateFields(models.Gate{}); err != nil {
+		return err
+	}
+
 	var gates []models.Gate
-	query := db.GetDB().Order("priority ASC, category ASC, created_at DESC")
+	query := db.GetDB().Model(&models.Gate{})
 
 	if gateCategory != "" {
 		query = query.Where("category = ?", gateCategory)
@@ -230,12 +304,37 @@ func runGateList(cmd *cobra.Command, args []string) error {
 		query = query.Where("last_result = ?", listStatus)
 	}
 
-	if err := query.Find(&gates).Error; err != nil {
+	query, total, err := gateListPage.paginate(query, &models.Gate{})
+	if err != nil {
+		return err
+	}
+	if err := query.Order(gateListPage.order("priority ASC, category ASC, created_at DESC")).Find(&gates).Error; err != nil {
 		return err
 	}
 
+	if len(gateListPage.fields) > 0 {
+		rows, err := gateListPage.project(gates)
+		if err != nil {
+			return err
+		}
+		if IsJSONOutput() {
+			OutputJSON(gateListPage.meta(map[string]interface{}{"count": len(rows), "gates": rows}, total))
+		} else {
+			gateListPage.printProjected(rows)
+			gateListPage.printMoreHint(len(rows), total)
+		}
+		return nil
+	}
+
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"count": len(gates), "gates": gates})
+		OutputJSON(gateListPage.meta(map[string]interface{}{"count": len(gates), "gates": gates}, total))
+		return nil
+	}
+
+	if IsQuietOutput() {
+		for _, g := range gates {
+			printQuietIDs(g.ID)
+		}
 		return nil
 	}
 
@@ -244,20 +343,22 @@ func runGateList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	c := colors()
 	for _, g := range gates {
 		cat := ""
 		if g.Category != "" {
 			cat = "[" + g.Category + "] "
 		}
-		fmt.Printf("[%s] %s%s - %s (%s)\n", g.ID, cat, g.ResultString(), g.Title, g.TypeString())
+		fmt.Printf("[%s] %s%s - %s (%s)\n", g.ID, cat, c.Result(g.ResultString()), g.Title, g.TypeString())
 	}
+	gateListPage.printMoreHint(len(gates), total)
 	return nil
 }
 
 func runGateShow(cmd *cobra.Command, args []string) error {
 	gate, err := db.GetGateByID(args[0])
 	if err != nil {
-		return fmt.Errorf("gate '%s' not found (use 'gur gate list' to see available gates)", args[0])
+		return codedErrorf(ErrCodeNotFound, "gate '%s' not found (use 'gur gate list' to see available gates)", args[0])
 	}
 
 	// Get linked tasks
@@ -268,20 +369,30 @@ func runGateShow(cmd *cobra.Command, args []string) error {
 	var runs []models.GateRun
 	db.GetDB().Where("gate_id = ?", gate.ID).Order("created_at DESC").Limit(5).Find(&runs)
 
+	trusted := trustedVerifiers(db.GetDB(), gate)
+
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{
+		result := map[string]interface{}{
 			"gate":         gate,
 			"linked_tasks": links,
 			"recent_runs":  runs,
-		})
+		}
+		if trusted != nil && !gate.RequiresApproval() {
+			result["trusted_verifiers"] = trusted
+		}
+		OutputJSON(result)
 		return nil
 	}
 
 	fmt.Printf("ID:       %s\n", gate.ID)
 	fmt.Printf("Title:    %s\n", gate.Title)
-	fmt.Printf("Type:     %s\n", gate.TypeString())
+	if gate.TestType != "" {
+		fmt.Printf("Type:     %s (%s)\n", gate.TypeString(), gate.TestType)
+	} else {
+		fmt.Printf("Type:     %s\n", gate.TypeString())
+	}
 	fmt.Printf("Priority: P%d\n", gate.Priority)
-	fmt.Printf("Result:   %s\n", gate.ResultString())
+	fmt.Printf("Result:   %s\n", colors().Result(gate.ResultString()))
 	if gate.Category != "" {
 		fmt.Printf("Category: %s\n", gate.Category)
 	}
@@ -298,11 +409,17 @@ func runGateShow(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\nExpected:\n%s\n", gate.ExpectedResult)
 	}
 	if gate.Command != "" {
-		fmt.Printf("\nCommand: %s\n", gate.Command)
+		fmt.Println("\nAutomation:")
+		printGateExecSettings(gate)
 	}
 	if len(gate.Labels) > 0 {
 		fmt.Printf("Labels:   %v\n", gate.Labels)
 	}
+	if gate.RequiresApproval() {
+		fmt.Printf("Approvers: %s\n", strings.Join(gate.Approvers, ", "))
+	} else if trusted != nil {
+		fmt.Printf("Trusted:  %s (see 'gur config trust')\n", strings.Join(trusted, ", "))
+	}
 
 	fmt.Printf("\nStats: %d runs, %d passed, %d failed (%.0f%% pass rate)\n",
 		gate.RunCount, gate.PassCount, gate.FailCount, gate.PassRate())
@@ -314,36 +431,62 @@ func runGateShow(cmd *cobra.Command, args []string) error {
 			if status == "" {
 				status = "pending"
 			}
+			if l.Status == models.GateLinkWaived {
+				status = waiverSummary(l)
+			}
 			fmt.Printf("  %s (%s)\n", l.TaskID, status)
 		}
 	}
 
 	if len(runs) > 0 {
 		fmt.Println("\nRecent runs:")
-		for _, r := range runs {
-			fmt.Printf("  %s - %s by %s\n", r.CreatedAt.Format(models.DateTimeShortFormat), r.Result, r.RunBy)
+		for i, r := range runs {
+			fmt.Printf("  %s - %s by %s", r.CreatedAt.Format(models.DateTimeShortFormat), r.Result, r.RunBy)
+			if r.Runner != "" {
+				fmt.Printf(" (%s, %s)", r.Runner, (time.Duration(r.Duration) * time.Millisecond).Round(time.Millisecond))
+			} else if r.Duration > 0 {
+				fmt.Printf(" (%s)", (time.Duration(r.Duration) * time.Millisecond).Round(time.Millisecond))
+			}
+			fmt.Println()
 			if r.Notes != "" {
 				fmt.Printf("    Notes: %s\n", r.Notes)
 			}
+			// Show captured output (e.g., failing tests) for the latest run only
+			if i == 0 && r.Output != "" {
+				for _, line := range strings.Split(strings.TrimRight(r.Output, "\n"), "\n") {
+					fmt.Printf("    | %s\n", line)
+				}
+			}
+		}
+		if len(runs) == 5 {
+			fmt.Printf("  (use 'gur gate runs %s' for the full history)\n", gate.ID)
 		}
 	}
 
 	return nil
 }
 
-func runGateResult(gateID string, taskID string, result string) error {
+// runGateResult records a gate result for a task. output holds the captured
+// output of an automated check (e.g., an ingested test report), if any.
+func runGateResult(gateID string, taskID string, result string, output string) error {
+	return recordGateResult(gateID, taskID, result, models.GateRun{Output: output})
+}
+
+// recordGateResult records a gate result for a task; run carries the
+// details of an automated run (output, duration, runner) for the history
+func recordGateResult(gateID string, taskID string, result string, run models.GateRun) error {
 	database := db.GetDB()
 
 	// Validate gate exists
 	gate, err := db.GetGateByID(gateID)
 	if err != nil {
-		return fmt.Errorf("cannot update gate: gate '%s' not found (use 'gur gate list' to see available gates)", gateID)
+		return codedErrorf(ErrCodeNotFound, "cannot update gate: gate '%s' not found (use 'gur gate list' to see available gates)", gateID)
 	}
 
 	// Validate task exists
 	task, err := db.GetTaskByID(taskID)
 	if err != nil {
-		return fmt.Errorf("cannot update gate: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+		return codedErrorf(ErrCodeNotFound, "cannot update gate: task '%s' not found (use 'gur list' to see available tasks)", taskID)
 	}
 
 	// Find the link between gate and task
@@ -353,37 +496,153 @@ func runGateResult(gateID string, taskID string, result string) error {
 		return fmt.Errorf("cannot update gate: gate '%s' is not linked to task '%s'\nLink it first: gur gate link %s %s", gateID, taskID, gateID, taskID)
 	}
 
+	saved, err := saveGateResult(database, gate, &link, result, gateRunBy, gateNotes, run)
+	if err != nil {
+		return err
+	}
+	if saved.Result == models.GateLinkRequested {
+		printGateApprovalRequest(gate, task, &link)
+		return nil
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "gate": gate, "task": task, "link": link})
+	} else {
+		fmt.Printf("Verified: %s for task %s (%s by %s)\n", gate.Title, taskID, result, gateRunBy)
+	}
+	if result == models.GateLinkFailed {
+		return fireHook(hookOnGateFail, hookPayload{Task: task, Gate: gate, Link: &link, Run: saved})
+	}
+	return nil
+}
+
+// saveGateResult stores a gate result on the link, in the gate's stats and
+// in the run history. A pass of a gate with designated approvers is stored
+// as requested unless an approver confirmed it ('gate approve'), and a pass by
+// a verifier the trust rules don't allow is refused unless --override-trust
+// was given. The saved run is returned; its Result is the status stored.
+func saveGateResult(database *gorm.DB, gate *models.Gate, link *models.GateTaskLink, result, by, notes string, run models.GateRun) (*models.GateRun, error) {
+	run.GateID = gate.ID
+	run.TaskID = link.TaskID
+	run.RunBy = by
+	run.Notes = notes
+
+	if result == models.GateLinkPassed && gate.RequiresApproval() && !gateApproved {
+		link.Status = models.GateLinkRequested
+		link.Notes = notes
+		if err := database.Save(link).Error; err != nil {
+			return nil, fmt.Errorf("failed to update gate link: %w", err)
+		}
+		run.Result = models.GateLinkRequested
+		if err := database.Create(&run).Error; err != nil {
+			return nil, fmt.Errorf("failed to save gate run history: %w", err)
+		}
+		return &run, nil
+	}
+
+	if result == models.GateLinkPassed {
+		actor := eventActor()
+		if err := checkGateTrust(database, gate, actor); err != nil {
+			if gateTrustOverride == "" {
+				return nil, err
+			}
+			if err := confirmAsHuman(fmt.Sprintf("pass %s for %s as %s despite the trust rules", gate.ID, link.TaskID, actor)); err != nil {
+				return nil, err
+			}
+			notes = strings.TrimSpace(models.TrustOverridePrefix + gateTrustOverride + " " + notes)
+			run.Notes = notes
+			models.RecordChange(database, link.TaskID, trustOverrideField, link.Status, gate.ID+": "+gateTrustOverride, actor)
+		}
+	}
+
 	// Update the per-task link status
 	now := time.Now()
 	link.Status = result
 	link.VerifiedAt = &now
-	link.VerifiedBy = gateRunBy
-	link.Notes = gateNotes
-	if err := database.Save(&link).Error; err != nil {
-		return fmt.Errorf("failed to update gate link: %w", err)
+	link.VerifiedBy = by
+	link.Notes = notes
+	if err := database.Save(link).Error; err != nil {
+		return nil, fmt.Errorf("failed to update gate link: %w", err)
 	}
 
 	// Also update global gate stats and save to GateRun history for audit
-	gate.RecordRun(result, gateRunBy, gateNotes)
-	if err := database.Save(&gate).Error; err != nil {
-		return fmt.Errorf("failed to update gate stats: %w", err)
+	gate.RecordRun(result, by, notes)
+	if err := database.Save(gate).Error; err != nil {
+		return nil, fmt.Errorf("failed to update gate stats: %w", err)
 	}
 
-	run := &models.GateRun{
-		GateID: gateID,
-		Result: result,
-		RunBy:  gateRunBy,
-		Notes:  gateNotes,
+	run.Result = result
+	if err := database.Create(&run).Error; err != nil {
+		return nil, fmt.Errorf("failed to save gate run history: %w", err)
+	}
+	return &run, nil
+}
+
+// printGateApprovalRequest reports a pass stored as a pending approval
+// request
+func printGateApprovalRequest(gate *models.Gate, task *models.Task, link *models.GateTaskLink) {
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "requested": true, "gate": gate, "task": task, "link": link})
+		return
+	}
+	fmt.Printf("Requested: %s for task %s (by %s)\n", gate.Title, task.ID, gateRunBy)
+	fmt.Printf("This gate needs an approver's confirmation. Approvers: %s\n", strings.Join(gate.Approvers, ", "))
+	fmt.Printf("An approver must run in a terminal: gur gate approve %s %s --by <approver>\n", gate.ID, task.ID)
+}
+
+func runGateApprove(cmd *cobra.Command, args []string) error {
+	gateID, taskID := args[0], args[1]
+
+	gate, err := db.GetGateByID(gateID)
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot approve gate: gate '%s' not found (use 'gur gate list' to see available gates)", gateID)
+	}
+	if !gate.RequiresApproval() {
+		return fmt.Errorf("cannot approve gate '%s': gate has no designated approvers (use 'gur gate pass %s %s' instead)", gateID, gateID, taskID)
 	}
-	if err := database.Create(run).Error; err != nil {
-		return fmt.Errorf("failed to save gate run history: %w", err)
+	if !gate.IsApprover(gateRunBy) {
+		return fmt.Errorf("cannot approve gate '%s': '%s' is not an approver (approvers: %s)", gateID, gateRunBy, strings.Join(gate.Approvers, ", "))
+	}
+	if err := confirmAsHuman(fmt.Sprintf("approve %s for %s as %s", gateID, taskID, gateRunBy)); err != nil {
+		return err
+	}
+	gateApproved = true
+	defer func() { gateApproved = false }()
+
+	return runGateResult(gateID, taskID, models.GateLinkPassed, "")
+}
+
+func runGateApprovers(cmd *cobra.Command, args []string) error {
+	gate, err := db.GetGateByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "gate '%s' not found (use 'gur gate list' to see available gates)", args[0])
+	}
+
+	names := args[1:]
+	if gateApproversClear || len(names) > 0 {
+		what := fmt.Sprintf("set the approvers of %s to %s", gate.ID, strings.Join(names, ", "))
+		if len(names) == 0 {
+			what = fmt.Sprintf("remove all approvers of %s", gate.ID)
+		}
+		if err := confirmAsHuman(what); err != nil {
+			return err
+		}
+		gate.Approvers = names
+		if err := db.GetDB().Save(gate).Error; err != nil {
+			return fmt.Errorf("failed to update approvers for gate '%s': %w", gate.ID, err)
+		}
 	}
 
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"success": true, "gate": gate, "task": task, "link": link})
-	} else {
-		fmt.Printf("Verified: %s for task %s (%s by %s)\n", gate.Title, taskID, result, gateRunBy)
+		OutputJSON(map[string]interface{}{"gate_id": gate.ID, "approvers": gate.Approvers})
+		return nil
+	}
+
+	if !gate.RequiresApproval() {
+		fmt.Printf("%s: no designated approvers (anyone may pass)\n", gate.ID)
+		return nil
 	}
+	fmt.Printf("%s approvers: %s\n", gate.ID, strings.Join(gate.Approvers, ", "))
 	return nil
 }
 
@@ -393,12 +652,12 @@ func runGateLink(cmd *cobra.Command, args []string) error {
 
 	// Validate gate exists
 	if _, err := db.GetGateByID(gateID); err != nil {
-		return fmt.Errorf("cannot link gate: gate '%s' not found (use 'gur gate list' to see available gates)", gateID)
+		return codedErrorf(ErrCodeNotFound, "cannot link gate: gate '%s' not found (use 'gur gate list' to see available gates)", gateID)
 	}
 
 	// Validate task exists
 	if _, err := db.GetTaskByID(taskID); err != nil {
-		return fmt.Errorf("cannot link gate: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+		return codedErrorf(ErrCodeNotFound, "cannot link gate: task '%s' not found (use 'gur list' to see available tasks)", taskID)
 	}
 
 	// Check if already linked
@@ -473,7 +732,7 @@ func runGateDelete(cmd *cobra.Command, args []string) error {
 	// Check gate exists
 	gate, err := db.GetGateByID(gateID)
 	if err != nil {
-		return fmt.Errorf("cannot delete gate: gate '%s' not found", gateID)
+		return codedErrorf(ErrCodeNotFound, "cannot delete gate: gate '%s' not found", gateID)
 	}
 
 	// Check for linked open tasks
@@ -526,46 +785,73 @@ type GateLinkInfo struct {
 	Status string
 }
 
-// GetGateLinksForTask returns all gate links for a task with their per-task status
+// gateLinkRow is a gate link joined with its gate, whose columns are
+// selected with the gateColumnPrefix
+type gateLinkRow struct {
+	models.GateTaskLink
+	Gate models.Gate `gorm:"embedded;embeddedPrefix:gate__"`
+}
+
+const gateColumnPrefix = "gate__"
+
+// gateColumns selects every gates column under the gateColumnPrefix
+func gateColumns(database *gorm.DB) (string, error) {
+	stmt := &gorm.Statement{DB: database}
+	if err := stmt.Parse(&models.Gate{}); err != nil {
+		return "", err
+	}
+	cols := make([]string, len(stmt.Schema.DBNames))
+	for i, name := range stmt.Schema.DBNames {
+		cols[i] = fmt.Sprintf("gates.%s AS %s%s", name, gateColumnPrefix, name)
+	}
+	return strings.Join(cols, ", "), nil
+}
+
+// GetGateLinksForTask returns all gate links for a task with their per-task
+// status, loading links and gates in a single query
 func GetGateLinksForTask(taskID string) ([]GateLinkInfo, error) {
 	database := db.GetDB()
 
-	var links []models.GateTaskLink
-	if err := database.Where("task_id = ? AND deleted_at IS NULL", taskID).Find(&links).Error; err != nil {
+	cols, err := gateColumns(database)
+	if err != nil {
 		return nil, err
 	}
-
-	var result []GateLinkInfo
-	for _, link := range links {
-		gate, err := db.GetGateByID(link.GateID)
-		if err != nil {
-			continue
-		}
-		result = append(result, GateLinkInfo{
-			Gate:   *gate,
-			Link:   link,
-			Status: link.Status,
-		})
+	var rows []gateLinkRow
+	if err := database.Model(&models.GateTaskLink{}).
+		Select("gate_task_links.*, "+cols).
+		Joins("JOIN gates ON gates.id = gate_task_links.gate_id AND gates.deleted_at IS NULL").
+		Where("gate_task_links.task_id = ?", taskID).
+		Order("gate_task_links.id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
 	}
 
+	result := make([]GateLinkInfo, len(rows))
+	for i, row := range rows {
+		result[i] = GateLinkInfo{Gate: row.Gate, Link: row.GateTaskLink, Status: row.Status}
+	}
 	return result, nil
 }
 
-// GetFailingGateLinksForTask returns gates linked to a task where the per-task status is not "passed"
+// GetFailingGateLinksForTask returns gates linked to a task that are not
+// satisfied: neither passed nor covered by an unexpired waiver
 func GetFailingGateLinksForTask(taskID string) ([]GateLinkInfo, error) {
 	links, err := GetGateLinksForTask(taskID)
 	if err != nil {
 		return nil, err
 	}
+	return failingGateLinks(links, time.Now()), nil
+}
 
+// failingGateLinks filters links to those not satisfied at now
+func failingGateLinks(links []GateLinkInfo, now time.Time) []GateLinkInfo {
 	var failing []GateLinkInfo
 	for _, info := range links {
-		if info.Status != models.GateLinkPassed {
+		if !info.Link.Satisfied(now) {
 			failing = append(failing, info)
 		}
 	}
-
-	return failing, nil
+	return failing
 }
 
 // GetLinkedGatesForTask returns all gates linked to a task
@@ -586,7 +872,8 @@ func GetLinkedGatesForTask(taskID string) ([]models.Gate, error) {
 }
 
 // CheckGatesBeforeClose checks if all linked gates have been verified as passed for this specific task.
-// Tasks MUST have at least one gate linked to be closed.
+// Tasks MUST have at least one gate linked to be closed, plus any gates the
+// priority policy requires (see 'gur config policy').
 // Each gate must be verified per-task - global gate status is not sufficient.
 func CheckGatesBeforeClose(taskID string) error {
 	gateLinks, err := GetGateLinksForTask(taskID)
@@ -594,17 +881,65 @@ func CheckGatesBeforeClose(taskID string) error {
 		return err
 	}
 
-	// Require at least one gate to be linked
-	if len(gateLinks) == 0 {
-		return fmt.Errorf("Cannot close task: no gates linked.\n\nEvery task must have at least one gate before closing.\nLink a gate: gur gate link <gate-id> %s\nOr use --force to close anyway (requires interactive confirmation).", taskID)
-	}
-
-	failingLinks, err := GetFailingGateLinksForTask(taskID)
+	// Gates required by policy for this task's priority must be linked
+	task, err := db.GetTaskByID(taskID)
 	if err != nil {
 		return err
 	}
+	if missing := missingPolicyGates(task.Priority, gateLinks); len(missing) > 0 {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("Cannot close task: policy requires these gates for P%d tasks, but none are linked:\n", task.Priority))
+		for _, m := range missing {
+			sb.WriteString(fmt.Sprintf("  - %s\n", m))
+		}
+		sb.WriteString(fmt.Sprintf("\nFind a gate by type: gur gate list --type <type>\nLink it: gur gate link <gate-id> %s\n", taskID))
+		sb.WriteString("\nOr use --force to close anyway (requires interactive confirmation).")
+		return codedErrorf(ErrCodeGateMissing, "%s", sb.String())
+	}
 
-	if len(failingLinks) > 0 {
+	// The policy can require the change itself to be linked for this type
+	if artifactsRequired(task.Type) {
+		linked, err := hasLinkedWork(db.GetDB(), taskID)
+		if err != nil {
+			return err
+		}
+		if !linked {
+			var sb strings.Builder
+			sb.WriteString(fmt.Sprintf("Cannot close task: policy requires a linked commit, PR or artifact for %s tasks, but none is linked.\n", task.Type))
+			sb.WriteString(fmt.Sprintf("\nCapture the change: gur artifact add %s --from-git <range>\n", taskID))
+			sb.WriteString(fmt.Sprintf("Or add its PR or commit URL: gur note add %s \"<url>\"\n", taskID))
+			sb.WriteString(fmt.Sprintf("Or open a PR for it: gur pr describe %s --create --head <branch>\n", taskID))
+			sb.WriteString("\nOr use --force to close anyway (requires interactive confirmation).")
+			return codedErrorf(ErrCodeWorkMissing, "%s", sb.String())
+		}
+	}
+
+	// The policy can require every acceptance criterion to be checked
+	if acceptanceRequired() {
+		criteria, err := loadAcceptanceCriteria(db.GetDB(), taskID)
+		if err != nil {
+			return err
+		}
+		if done := criteriaDone(criteria); done < len(criteria) {
+			var sb strings.Builder
+			sb.WriteString(fmt.Sprintf("Cannot close task: policy requires all acceptance criteria checked, but %d of %d are not:\n", len(criteria)-done, len(criteria)))
+			for i, c := range criteria {
+				if !c.Done {
+					sb.WriteString(fmt.Sprintf("  %d. %s\n", i+1, c.Text))
+				}
+			}
+			sb.WriteString(fmt.Sprintf("\nCheck them off: gur ac check %s <number>...\n", taskID))
+			sb.WriteString("\nOr use --force to close anyway (requires interactive confirmation).")
+			return codedErrorf(ErrCodeCriteriaUnmet, "%s", sb.String())
+		}
+	}
+
+	// Require at least one gate to be linked
+	if len(gateLinks) == 0 {
+		return codedErrorf(ErrCodeGateMissing, "Cannot close task: no gates linked.\n\nEvery task must have at least one gate before closing.\nLink a gate: gur gate link <gate-id> %s\nOr use --force to close anyway (requires interactive confirmation).", taskID)
+	}
+
+	if failingLinks := failingGateLinks(gateLinks, time.Now()); len(failingLinks) > 0 {
 		var sb strings.Builder
 		sb.WriteString(fmt.Sprintf("Cannot close task: %d gate(s) not verified for this task:\n", len(failingLinks)))
 		for _, info := range failingLinks {
@@ -612,14 +947,22 @@ func CheckGatesBeforeClose(taskID string) error {
 			if status == "" {
 				status = "pending"
 			}
+			if info.Status == models.GateLinkWaived {
+				status = "waiver expired " + info.Link.ExpiresAt.Format(models.DateTimeShortFormat)
+			}
 			sb.WriteString(fmt.Sprintf("  - %s: %s (status: %s)\n", info.Gate.ID, info.Gate.Title, status))
 		}
 		sb.WriteString(fmt.Sprintf("\nVerify gates for this task:\n"))
 		for _, info := range failingLinks {
+			if info.Status == models.GateLinkRequested {
+				sb.WriteString(fmt.Sprintf("  gur gate approve %s %s --by <approver>\n", info.Gate.ID, taskID))
+				continue
+			}
 			sb.WriteString(fmt.Sprintf("  gur gate pass %s %s\n", info.Gate.ID, taskID))
 		}
+		sb.WriteString(fmt.Sprintf("\nOr waive a gate with a reason: gur gate waive <gate-id> %s --reason \"...\"\n", taskID))
 		sb.WriteString("\nOr use --force to close anyway (requires interactive confirmation).")
-		return fmt.Errorf("%s", sb.String())
+		return codedErrorf(ErrCodeGatePending, "%s", sb.String())
 	}
 
 	return nil
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"guardrails/internal/models"
+)
+
+// defaultHTTPGateTimeout bounds an HTTP gate's request when the gate has no
+// timeout of its own
+const defaultHTTPGateTimeout = 30 * time.Second
+
+// jsonPathIndex matches an array index written items[0]
+var jsonPathIndex = regexp.MustCompile(`\[(\d+)\]`)
+
+// parseExpectJSON splits an --expect-json assertion "<path>=<value>" into
+// the path's keys and the expected value. Paths are dotted, optionally
+// starting with "$.", with array indices as items.0 or items[0].
+func parseExpectJSON(s string) ([]string, string, error) {
+	path, want, ok := strings.Cut(s, "=")
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$.")
+	path = jsonPathIndex.ReplaceAllString(path, ".$1")
+	if !ok || path == "" {
+		return nil, "", fmt.Errorf("invalid --expect-json '%s': expected <path>=<value>, e.g. version=1.4.2 or checks.db.status=ok", s)
+	}
+	keys := strings.Split(path, ".")
+	for _, k := range keys {
+		if k == "" {
+			return nil, "", fmt.Errorf("invalid --expect-json '%s': empty key in path '%s'", s, path)
+		}
+	}
+	return keys, strings.TrimSpace(want), nil
+}
+
+// lookupJSONPath follows keys through a decoded JSON value
+func lookupJSONPath(v interface{}, keys []string) (interface{}, bool) {
+	for _, k := range keys {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[k]
+			if !ok {
+				return nil, false
+			}
+			v = next
+		case []interface{}:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// formatJSONValue renders a decoded JSON value for comparison: strings
+// without quotes, anything else as JSON
+func formatJSONValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// checkHTTPResponse returns why a response fails the gate's expectations,
+// or "" if it meets them
+func checkHTTPResponse(gate *models.Gate, status int, body []byte) string {
+	switch {
+	case gate.ExpectStatus != 0 && status != gate.ExpectStatus:
+		return fmt.Sprintf("HTTP %d, want %d", status, gate.ExpectStatus)
+	case gate.ExpectStatus == 0 && (status < 200 || status > 299):
+		return fmt.Sprintf("HTTP %d, want 2xx", status)
+	}
+	if gate.ExpectJSON == "" {
+		return ""
+	}
+	keys, want, err := parseExpectJSON(gate.ExpectJSON)
+	if err != nil {
+		return err.Error()
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Sprintf("HTTP %d, but the response is not JSON", status)
+	}
+	v, ok := lookupJSONPath(doc, keys)
+	if !ok {
+		return fmt.Sprintf("HTTP %d, but %s is not in the response", status, strings.Join(keys, "."))
+	}
+	if got := formatJSONValue(v); got != want {
+		return fmt.Sprintf("HTTP %d, but %s is %q, want %q", status, strings.Join(keys, "."), got, want)
+	}
+	return ""
+}
+
+// executeHTTPCheck requests the gate's URL and checks the response. An
+// unreachable service fails the gate; errors mean the URL is unusable.
+func executeHTTPCheck(gate *models.Gate) (*gateExecution, error) {
+	u, err := url.Parse(gate.Command)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("cannot run gate check: '%s' is not an http(s) URL (set one with 'gur gate configure %s --cmd <url>')", gate.Command, gate.ID)
+	}
+	client := &http.Client{Timeout: gateTimeout(gate)}
+
+	started := time.Now()
+	resp, err := client.Get(u.String())
+	res := &gateExecution{}
+	if err != nil {
+		res.Duration = time.Since(started)
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			res.TimedOut = true
+			res.ExitCode = -1
+			return res, nil
+		}
+		res.ExitCode = 1
+		res.Failure = err.Error()
+		res.Output = err.Error()
+		return res, nil
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, gateOutputLimit))
+	res.Duration = time.Since(started)
+
+	res.HTTPStatus = resp.StatusCode
+	res.Output = fmt.Sprintf("GET %s\n%s %s\n\n%s", u, resp.Proto, resp.Status, body)
+	if failure := checkHTTPResponse(gate, resp.StatusCode, body); failure != "" {
+		res.ExitCode = 1
+		res.Failure = failure
+	}
+	return res, nil
+}
+
+// describeHTTPExpectations summarizes what an HTTP gate checks
+func describeHTTPExpectations(gate *models.Gate) string {
+	s := "HTTP 2xx"
+	if gate.ExpectStatus != 0 {
+		s = fmt.Sprintf("HTTP %d", gate.ExpectStatus)
+	}
+	if gate.ExpectJSON != "" {
+		s += ", " + gate.ExpectJSON
+	}
+	return s
+}
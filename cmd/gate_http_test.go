@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"guardrails/internal/models"
+)
+
+func TestParseExpectJSON(t *testing.T) {
+	keys, want, err := parseExpectJSON("$.checks[0].status = ok")
+	if err != nil || !reflect.DeepEqual(keys, []string{"checks", "0", "status"}) || want != "ok" {
+		t.Errorf("parseExpectJSON = %v, %q, %v", keys, want, err)
+	}
+	for _, bad := range []string{"version", "=1.0", "a..b=1"} {
+		if _, _, err := parseExpectJSON(bad); err == nil {
+			t.Errorf("parseExpectJSON(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestExecuteHTTPCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"version": "1.4.2", "replicas": 3, "checks": [{"name": "db", "ok": true}]}`))
+	}))
+	defer server.Close()
+
+	for _, tt := range []struct {
+		name    string
+		gate    models.Gate
+		failure string
+	}{
+		{"any 2xx", models.Gate{Command: server.URL + "/health"}, ""},
+		{"version", models.Gate{Command: server.URL, ExpectStatus: 200, ExpectJSON: "version=1.4.2"}, ""},
+		{"number", models.Gate{Command: server.URL, ExpectJSON: "replicas=3"}, ""},
+		{"nested bool", models.Gate{Command: server.URL, ExpectJSON: "checks[0].ok=true"}, ""},
+		{"wrong version", models.Gate{Command: server.URL, ExpectJSON: "version=1.5.0"}, `version is "1.4.2", want "1.5.0"`},
+		{"missing key", models.Gate{Command: server.URL, ExpectJSON: "build.sha=abc"}, "build.sha is not in the response"},
+		{"status", models.Gate{Command: server.URL + "/down"}, "HTTP 503, want 2xx"},
+		{"expected status", models.Gate{Command: server.URL + "/down", ExpectStatus: 503}, ""},
+	} {
+		tt.gate.Runner = models.RunnerHTTP
+		res, err := executeGateCommand(&tt.gate, t.TempDir())
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		status, summary := res.status(&tt.gate)
+		if tt.failure == "" && status != models.GateLinkPassed {
+			t.Errorf("%s: %s (%s), want passed", tt.name, status, summary)
+		}
+		if tt.failure != "" && (status != models.GateLinkFailed || !strings.Contains(summary, tt.failure)) {
+			t.Errorf("%s: %s (%s), want failed with %q", tt.name, status, summary, tt.failure)
+		}
+	}
+
+	if _, err := executeGateCommand(&models.Gate{Runner: models.RunnerHTTP, Command: "go test ./..."}, t.TempDir()); err == nil {
+		t.Error("an http gate without a URL ran")
+	}
+	server.Close()
+	res, err := executeGateCommand(&models.Gate{Runner: models.RunnerHTTP, Command: server.URL}, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := res.status(&models.Gate{}); status != models.GateLinkFailed {
+		t.Error("an unreachable service passed the gate")
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/models"
+	"guardrails/internal/testreport"
+)
+
+var (
+	gateIngestJUnit string
+	gateIngestTAP   string
+	gateIngestBy    string
+)
+
+var gateIngestCmd = &cobra.Command{
+	Use:   "ingest <gate-id> <task-id>",
+	Short: "Record a gate result from a JUnit or TAP test report",
+	Long: `Parse a JUnit XML or TAP test report and record the result for a task.
+
+The gate passes if the report has at least one test and none failed.
+Counts go in the run notes; failing test names and messages go in the run
+output (see 'gur gate show'). Use "-" to read the report from stdin.
+
+Gates with designated approvers still require approval when --by is not
+an approver.
+
+Examples:
+  gur gate ingest gate-abc123 gur-def456 --junit report.xml
+  go test -v ./... | go-junit-report | gur gate ingest gate-abc123 gur-def456 --junit -
+  prove -v t/ | gur gate ingest gate-abc123 gur-def456 --tap - --by ci`,
+	Args: cobra.ExactArgs(2),
+	RunE: runGateIngest,
+}
+
+func init() {
+	gateCmd.AddCommand(gateIngestCmd)
+	gateIngestCmd.Flags().StringVar(&gateIngestJUnit, "junit", "", "JUnit XML report file (- for stdin)")
+	gateIngestCmd.Flags().StringVar(&gateIngestTAP, "tap", "", "TAP report file (- for stdin)")
+	gateIngestCmd.Flags().StringVar(&gateIngestBy, "by", "ci", "Who ran the tests (ci/agent/name)")
+	gateIngestCmd.MarkFlagsOneRequired("junit", "tap")
+	gateIngestCmd.MarkFlagsMutuallyExclusive("junit", "tap")
+}
+
+// openReport opens a report file, or stdin for "-"
+func openReport(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read report: %w", err)
+	}
+	return f, nil
+}
+
+// parseGateReport parses the report given by --junit or --tap
+func parseGateReport() (*testreport.Report, error) {
+	path, parse := gateIngestJUnit, testreport.ParseJUnit
+	if gateIngestTAP != "" {
+		path, parse = gateIngestTAP, testreport.ParseTAP
+	}
+
+	r, err := openReport(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return parse(r)
+}
+
+func runGateIngest(cmd *cobra.Command, args []string) error {
+	report, err := parseGateReport()
+	if err != nil {
+		return err
+	}
+	if report.Total == 0 {
+		return fmt.Errorf("cannot ingest report: it contains no tests")
+	}
+
+	result := models.GateLinkPassed
+	if !report.OK() {
+		result = models.GateLinkFailed
+	}
+	gateRunBy = gateIngestBy
+	gateNotes = report.Summary()
+	return runGateResult(args[0], args[1], result, report.Output())
+}
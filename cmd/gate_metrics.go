@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var gateMetricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Export gate quality metrics for dashboards",
+	Long: `Export per-gate and per-category quality metrics: runs by result, pass
+rate, mean time-to-verify (from linking a gate to a task until it passed),
+the current failure streak, and gate links still pending on open tasks.
+
+The prometheus format is the text exposition format, so the output can be
+served by node_exporter's textfile collector (or pushed to a Pushgateway) and
+graphed in Grafana.
+
+Examples:
+  gur gate metrics
+  gur gate metrics --format json
+  gur gate metrics -o /var/lib/node_exporter/textfile/gur.prom`,
+	Args: cobra.NoArgs,
+	RunE: runGateMetrics,
+}
+
+var (
+	gateMetricsFormat string
+	gateMetricsOutput string
+)
+
+func init() {
+	gateCmd.AddCommand(gateMetricsCmd)
+	gateMetricsCmd.Flags().StringVarP(&gateMetricsFormat, "format", "f", "prometheus", "Output format (prometheus/json)")
+	gateMetricsCmd.Flags().StringVarP(&gateMetricsOutput, "output", "o", "", "Write to file instead of stdout")
+}
+
+// gateMetric holds the quality metrics of one gate, or of a category of
+// gates when GateID is empty
+type gateMetric struct {
+	GateID           string  `json:"gate_id,omitempty"`
+	Title            string  `json:"title,omitempty"`
+	Category         string  `json:"category"`
+	Runs             int     `json:"runs"`
+	Passed           int     `json:"passed"`
+	Failed           int     `json:"failed"`
+	PassRate         float64 `json:"pass_rate"` // 0..1
+	MeanTimeToVerify float64 `json:"mean_time_to_verify_seconds"`
+	Verified         int     `json:"verified_links"`
+	FailureStreak    int     `json:"failure_streak"` // Consecutive failed runs, most recent first
+	Pending          int     `json:"pending"`
+	verifySecondsSum float64
+}
+
+// finish derives the rates and means from the counts
+func (m *gateMetric) finish() {
+	if m.Runs > 0 {
+		m.PassRate = float64(m.Passed) / float64(m.Runs)
+	}
+	if m.Verified > 0 {
+		m.MeanTimeToVerify = m.verifySecondsSum / float64(m.Verified)
+	}
+}
+
+// collectGateMetrics computes metrics for every gate and rolls them up by
+// category (gates without one are grouped under "uncategorized")
+func collectGateMetrics(database *gorm.DB) ([]gateMetric, []gateMetric, error) {
+	var gates []models.Gate
+	if err := database.Order("category ASC, id ASC").Find(&gates).Error; err != nil {
+		return nil, nil, err
+	}
+	byGate := make(map[string]*gateMetric, len(gates))
+	perGate := make([]gateMetric, len(gates))
+	for i, g := range gates {
+		category := g.Category
+		if category == "" {
+			category = "uncategorized"
+		}
+		perGate[i] = gateMetric{GateID: g.ID, Title: g.Title, Category: category}
+		byGate[g.ID] = &perGate[i]
+	}
+
+	// Runs, newest first per gate, for the counts and failure streaks
+	var runs []models.GateRun
+	if err := database.Order("gate_id ASC, created_at DESC, id DESC").Find(&runs).Error; err != nil {
+		return nil, nil, err
+	}
+	streakBroken := make(map[string]bool)
+	for _, r := range runs {
+		m := byGate[r.GateID]
+		if m == nil {
+			continue
+		}
+		m.Runs++
+		switch r.Result {
+		case models.GatePassed:
+			m.Passed++
+		case models.GateFailed:
+			m.Failed++
+		}
+		if streakBroken[r.GateID] {
+			continue
+		}
+		if r.Result == models.GateFailed {
+			m.FailureStreak++
+		} else {
+			streakBroken[r.GateID] = true
+		}
+	}
+
+	var links []models.GateTaskLink
+	if err := database.Where("status = ? AND verified_at IS NOT NULL", models.GateLinkPassed).Find(&links).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, l := range links {
+		m := byGate[l.GateID]
+		if m == nil || l.VerifiedAt.Before(l.CreatedAt) {
+			continue
+		}
+		m.Verified++
+		m.verifySecondsSum += l.VerifiedAt.Sub(l.CreatedAt).Seconds()
+	}
+
+	pending, err := findPendingGates(database, nil, "", "")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range pending {
+		if m := byGate[p.GateID]; m != nil {
+			m.Pending++
+		}
+	}
+
+	byCategory := make(map[string]*gateMetric)
+	for i := range perGate {
+		m := &perGate[i]
+		m.finish()
+		c := byCategory[m.Category]
+		if c == nil {
+			c = &gateMetric{Category: m.Category}
+			byCategory[m.Category] = c
+		}
+		c.Runs += m.Runs
+		c.Passed += m.Passed
+		c.Failed += m.Failed
+		c.Verified += m.Verified
+		c.verifySecondsSum += m.verifySecondsSum
+		c.Pending += m.Pending
+		c.FailureStreak = max(c.FailureStreak, m.FailureStreak)
+	}
+	perCategory := make([]gateMetric, 0, len(byCategory))
+	for _, c := range byCategory {
+		c.finish()
+		perCategory = append(perCategory, *c)
+	}
+	sort.Slice(perCategory, func(i, j int) bool { return perCategory[i].Category < perCategory[j].Category })
+	return perGate, perCategory, nil
+}
+
+// promLabelValue escapes a Prometheus label value
+func promLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// writePrometheusMetrics writes the metrics in the Prometheus text
+// exposition format
+func writePrometheusMetrics(w io.Writer, perGate, perCategory []gateMetric) {
+	type family struct {
+		name, help, kind string
+		value            func(m gateMetric) float64
+	}
+	families := []family{
+		{"passed_runs_total", "Gate runs with a passed result.", "counter", func(m gateMetric) float64 { return float64(m.Passed) }},
+		{"failed_runs_total", "Gate runs with a failed result.", "counter", func(m gateMetric) float64 { return float64(m.Failed) }},
+		{"pass_rate", "Share of gate runs that passed (0-1).", "gauge", func(m gateMetric) float64 { return m.PassRate }},
+		{"time_to_verify_seconds_mean", "Mean time from linking a gate to a task until it passed.", "gauge", func(m gateMetric) float64 { return m.MeanTimeToVerify }},
+		{"failure_streak", "Consecutive failed runs, counting back from the latest run.", "gauge", func(m gateMetric) float64 { return float64(m.FailureStreak) }},
+		{"pending", "Gate links pending verification on open tasks.", "gauge", func(m gateMetric) float64 { return float64(m.Pending) }},
+	}
+	for _, scope := range []struct {
+		prefix  string
+		metrics []gateMetric
+		labels  func(m gateMetric) string
+	}{
+		{"gur_gate_", perGate, func(m gateMetric) string {
+			return fmt.Sprintf(`gate="%s",title="%s",category="%s"`, promLabelValue(m.GateID), promLabelValue(m.Title), promLabelValue(m.Category))
+		}},
+		{"gur_gate_category_", perCategory, func(m gateMetric) string {
+			return fmt.Sprintf(`category="%s"`, promLabelValue(m.Category))
+		}},
+	} {
+		for _, f := range families {
+			name := scope.prefix + f.name
+			fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, f.help, name, f.kind)
+			for _, m := range scope.metrics {
+				fmt.Fprintf(w, "%s{%s} %g\n", name, scope.labels(m), f.value(m))
+			}
+		}
+	}
+}
+
+func runGateMetrics(cmd *cobra.Command, args []string) error {
+	format := gateMetricsFormat
+	if IsJSONOutput() {
+		format = "json"
+	}
+	if format != "prometheus" && format != "json" {
+		return fmt.Errorf("invalid format '%s': must be prometheus or json", gateMetricsFormat)
+	}
+
+	perGate, perCategory, err := collectGateMetrics(db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to collect gate metrics: database error: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if gateMetricsOutput != "" {
+		f, err := os.Create(gateMetricsOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", gateMetricsOutput, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+	if format == "json" {
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		enc.Encode(map[string]interface{}{"gates": perGate, "categories": perCategory})
+	} else {
+		writePrometheusMetrics(bw, perGate, perCategory)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write gate metrics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestCollectGateMetrics(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	database.Create(&models.Gate{ID: "gate-met00001", Title: `Unit "fast" tests`, Category: "ci"})
+	database.Create(&models.Gate{ID: "gate-met00002", Title: "Lint", Category: "ci"})
+	database.Create(&models.Gate{ID: "gate-met00003", Title: "Review"})
+	for i, result := range []string{models.GatePassed, models.GatePassed, models.GateFailed, models.GateFailed} {
+		database.Create(&models.GateRun{GateID: "gate-met00001", Result: result, CreatedAt: base.Add(time.Duration(i) * time.Hour)})
+	}
+	database.Create(&models.GateRun{GateID: "gate-met00002", Result: models.GateFailed, CreatedAt: base})
+	database.Create(&models.GateRun{GateID: "gate-met00002", Result: models.GatePassed, CreatedAt: base.Add(time.Hour)})
+
+	database.Create(&models.Task{ID: "gur-met00001", Title: "Open", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-met00002", Title: "Closed", Status: models.StatusClosed})
+	verified := base.Add(2 * time.Hour)
+	database.Create(&models.GateTaskLink{GateID: "gate-met00002", TaskID: "gur-met00002", Status: models.GateLinkPassed, CreatedAt: base, VerifiedAt: &verified})
+	database.Create(&models.GateTaskLink{GateID: "gate-met00001", TaskID: "gur-met00001", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-met00003", TaskID: "gur-met00001", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-met00003", TaskID: "gur-met00002", Status: models.GateLinkPending})
+
+	perGate, perCategory, err := collectGateMetrics(database)
+	if err != nil {
+		t.Fatalf("collectGateMetrics() error: %v", err)
+	}
+	got := make(map[string]gateMetric)
+	for _, m := range perGate {
+		got[m.GateID] = m
+	}
+	if m := got["gate-met00001"]; m.Runs != 4 || m.PassRate != 0.5 || m.FailureStreak != 2 || m.Pending != 1 {
+		t.Errorf("unit tests metrics = %+v, want 4 runs, 0.5 pass rate, streak 2, 1 pending", m)
+	}
+	if m := got["gate-met00002"]; m.FailureStreak != 0 || m.MeanTimeToVerify != 7200 {
+		t.Errorf("lint metrics = %+v, want no streak and 2h to verify", m)
+	}
+	if m := got["gate-met00003"]; m.Category != "uncategorized" || m.Pending != 1 {
+		t.Errorf("review metrics = %+v, want uncategorized with 1 pending (closed task excluded)", m)
+	}
+
+	if len(perCategory) != 2 || perCategory[0].Category != "ci" {
+		t.Fatalf("categories = %+v, want ci and uncategorized", perCategory)
+	}
+	if ci := perCategory[0]; ci.Runs != 6 || ci.Passed != 3 || ci.FailureStreak != 2 || ci.Pending != 1 || ci.MeanTimeToVerify != 7200 {
+		t.Errorf("ci category = %+v", ci)
+	}
+
+	var buf bytes.Buffer
+	writePrometheusMetrics(&buf, perGate, perCategory)
+	out := buf.String()
+	for _, want := range []string{
+		"# TYPE gur_gate_pass_rate gauge\n",
+		`gur_gate_pass_rate{gate="gate-met00001",title="Unit \"fast\" tests",category="ci"} 0.5`,
+		`gur_gate_failure_streak{gate="gate-met00001",title="Unit \"fast\" tests",category="ci"} 2`,
+		`gur_gate_category_time_to_verify_seconds_mean{category="ci"} 7200`,
+		`gur_gate_category_pending{category="uncategorized"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("prometheus output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var gateOwnerCmd = &cobra.Command{
+	Use:   "owner",
+	Short: "Assign owners to gate categories",
+	Long: `Assign owners to gate categories, so gates awaiting verification can be
+routed to the people or teams responsible for them.
+
+Owners are GitHub users or teams (@org/team). 'gur gate pending --owner'
+lists the gates an owner needs to verify, and 'gur sync push
+--mention-owners' @mentions owners on issues with gates awaiting them.
+
+Examples:
+  gur gate owner set security @acme/security-team
+  gur gate owner set api alice bob
+  gur gate owner list
+  gur gate owner remove api`,
+}
+
+var gateOwnerSetCmd = &cobra.Command{
+	Use:   "set <category> <owner...>",
+	Short: "Set the owners of a gate category",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runGateOwnerSet,
+}
+
+var gateOwnerListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List gate category owners",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runGateOwnerList,
+}
+
+var gateOwnerRemoveCmd = &cobra.Command{
+	Use:     "remove <category>",
+	Aliases: []string{"rm"},
+	Short:   "Remove the owners of a gate category",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runGateOwnerRemove,
+}
+
+var gatePendingCmd = &cobra.Command{
+	Use:         "pending",
+	Short:       "List gates awaiting verification across open tasks",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `List gate links that are pending or awaiting approval on tasks that
+are not closed, with the owners of each gate's category.
+
+Examples:
+  gur gate pending
+  gur gate pending --owner @acme/security-team
+  gur gate pending --category api --json`,
+	Args: cobra.NoArgs,
+	RunE: runGatePending,
+}
+
+var (
+	gatePendingOwner    string
+	gatePendingCategory string
+)
+
+func init() {
+	gateCmd.AddCommand(gateOwnerCmd)
+	gateCmd.AddCommand(gatePendingCmd)
+	gateOwnerCmd.AddCommand(gateOwnerSetCmd)
+	gateOwnerCmd.AddCommand(gateOwnerListCmd)
+	gateOwnerCmd.AddCommand(gateOwnerRemoveCmd)
+	gatePendingCmd.Flags().StringVar(&gatePendingOwner, "owner", "", "Only gates in categories owned by this user or team")
+	gatePendingCmd.Flags().StringVarP(&gatePendingCategory, "category", "c", "", "Only gates in this category")
+}
+
+// normalizeOwner strips a leading @ and lowercases, so "@Alice" matches "alice"
+func normalizeOwner(owner string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(owner), "@"))
+}
+
+// mentionOwner formats an owner as a GitHub @mention
+func mentionOwner(owner string) string {
+	return "@" + strings.TrimPrefix(strings.TrimSpace(owner), "@")
+}
+
+// loadGateOwners returns the owners of each gate category
+func loadGateOwners(database *gorm.DB) (map[string][]string, error) {
+	var configs []models.Config
+	if err := database.Where("key LIKE ?", models.ConfigGateOwnerPrefix+"%").Find(&configs).Error; err != nil {
+		return nil, err
+	}
+	owners := make(map[string][]string, len(configs))
+	for _, c := range configs {
+		owners[strings.TrimPrefix(c.Key, models.ConfigGateOwnerPrefix)] = strings.Split(c.Value, ",")
+	}
+	return owners, nil
+}
+
+// ownsCategory reports whether owner is among a category's owners
+func ownsCategory(owners map[string][]string, category, owner string) bool {
+	for _, o := range owners[category] {
+		if normalizeOwner(o) == normalizeOwner(owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingGate is a gate awaiting verification on a task
+type pendingGate struct {
+	TaskID    string   `json:"task_id"`
+	TaskTitle string   `json:"task_title"`
+	GateID    string   `json:"gate_id"`
+	GateTitle string   `json:"gate_title"`
+	Category  string   `json:"category,omitempty"`
+	Status    string   `json:"status"`
+	Owners    []string `json:"owners,omitempty"`
+}
+
+// findPendingGates returns pending and requested gate links on tasks that
+// are not closed, optionally limited to a category and to an owner's
+// categories
+func findPendingGates(database *gorm.DB, owners map[string][]string, owner, category string) ([]pendingGate, error) {
+	query := database.Model(&models.GateTaskLink{}).
+		Select("gate_task_links.task_id, tasks.title AS task_title, gate_task_links.gate_id, gates.title AS gate_title, gates.category, gate_task_links.status").
+		Joins("JOIN gates ON gates.id = gate_task_links.gate_id AND gates.deleted_at IS NULL").
+		Joins("JOIN tasks ON tasks.id = gate_task_links.task_id AND tasks.deleted_at IS NULL").
+		Where("gate_task_links.status IN ?", []string{models.GateLinkPending, models.GateLinkRequested}).
+		Where("tasks.status NOT IN ?", []string{models.StatusClosed, models.StatusArchived})
+	if category != "" {
+		query = query.Where("gates.category = ?", category)
+	}
+	var rows []pendingGate
+	if err := query.Order("tasks.priority ASC, gate_task_links.task_id ASC, gates.priority ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	pending := rows[:0]
+	for _, r := range rows {
+		if owner != "" && !ownsCategory(owners, r.Category, owner) {
+			continue
+		}
+		r.Owners = owners[r.Category]
+		pending = append(pending, r)
+	}
+	return pending, nil
+}
+
+// gateOwnerMention builds the comment asking owners to verify their pending
+// gates on a task, and a signature of who was asked about what so unchanged
+// mentions aren't repeated. Both are empty if no owned gates are pending.
+func gateOwnerMention(pending []pendingGate) (body, signature string) {
+	byOwner := make(map[string][]pendingGate)
+	for _, p := range pending {
+		for _, o := range p.Owners {
+			byOwner[mentionOwner(o)] = append(byOwner[mentionOwner(o)], p)
+		}
+	}
+	if len(byOwner) == 0 {
+		return "", ""
+	}
+	mentions := make([]string, 0, len(byOwner))
+	for o := range byOwner {
+		mentions = append(mentions, o)
+	}
+	sort.Strings(mentions)
+
+	var sb strings.Builder
+	var sig []string
+	sb.WriteString("**Gates awaiting verification**\n")
+	for _, o := range mentions {
+		sb.WriteString(fmt.Sprintf("\n%s:\n", o))
+		for _, p := range byOwner[o] {
+			sb.WriteString(fmt.Sprintf("- %s (`%s`, %s)\n", p.GateTitle, p.GateID, p.Status))
+			sig = append(sig, fmt.Sprintf("%s:%s:%s", normalizeOwner(o), p.GateID, p.Status))
+		}
+	}
+	sort.Strings(sig)
+	return sb.String(), strings.Join(sig, ",")
+}
+
+// mentionGateOwners comments on a task's issue to @mention the owners of its
+// pending gates, unless the same owners were already asked about the same
+// gates. It returns the mentioned owners.
+func mentionGateOwners(ctx context.Context, database *gorm.DB, client *github.Client, owner, repo string, issueNum int, taskID string, owners map[string][]string) ([]string, error) {
+	pending, err := findPendingGates(database.Where("gate_task_links.task_id = ?", taskID), owners, "", "")
+	if err != nil {
+		return nil, err
+	}
+	body, signature := gateOwnerMention(pending)
+	last, _ := db.GetConfig(models.GateMentionKey(taskID))
+	if body == "" || signature == last {
+		return nil, nil
+	}
+	if _, _, err := client.Issues.CreateComment(ctx, owner, repo, issueNum, &github.IssueComment{Body: github.String(body)}); err != nil {
+		return nil, fmt.Errorf("failed to mention gate owners: %w", err)
+	}
+	if err := db.SetConfig(models.GateMentionKey(taskID), signature); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var mentioned []string
+	for _, p := range pending {
+		for _, o := range p.Owners {
+			if m := mentionOwner(o); !seen[m] {
+				seen[m] = true
+				mentioned = append(mentioned, m)
+			}
+		}
+	}
+	sort.Strings(mentioned)
+	return mentioned, nil
+}
+
+func runGateOwnerSet(cmd *cobra.Command, args []string) error {
+	category := strings.TrimSpace(args[0])
+	var owners []string
+	for _, o := range args[1:] {
+		if normalizeOwner(o) == "" || strings.Contains(o, ",") {
+			return fmt.Errorf("invalid owner '%s': use a GitHub user or team like alice or @org/team", o)
+		}
+		owners = append(owners, mentionOwner(o))
+	}
+	if err := db.SetConfig(models.GateOwnerKey(category), strings.Join(owners, ",")); err != nil {
+		return fmt.Errorf("failed to set owners for category '%s': %w", category, err)
+	}
+
+	var gates int64
+	db.GetDB().Model(&models.Gate{}).Where("category = ?", category).Count(&gates)
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "category": category, "owners": owners, "gates": gates})
+		return nil
+	}
+	fmt.Printf("Category '%s' owned by %s\n", category, strings.Join(owners, ", "))
+	if gates == 0 {
+		fmt.Printf("Note: no gates in category '%s' yet (create with 'gur gate create -c %s')\n", category, category)
+	}
+	return nil
+}
+
+func runGateOwnerList(cmd *cobra.Command, args []string) error {
+	owners, err := loadGateOwners(db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to load gate owners: database error: %w", err)
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"owners": owners})
+		return nil
+	}
+	if len(owners) == 0 {
+		fmt.Println("No gate category owners (set one with 'gur gate owner set <category> <owner>')")
+		return nil
+	}
+	categories := make([]string, 0, len(owners))
+	for c := range owners {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+	for _, c := range categories {
+		fmt.Printf("%-20s %s\n", c, strings.Join(owners[c], ", "))
+	}
+	return nil
+}
+
+func runGateOwnerRemove(cmd *cobra.Command, args []string) error {
+	result := db.GetDB().Where("key = ?", models.GateOwnerKey(args[0])).Delete(&models.Config{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove owners for category '%s': %w", args[0], result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("category '%s' has no owners (use 'gur gate owner list' to see owned categories)", args[0])
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "category": args[0]})
+		return nil
+	}
+	fmt.Printf("Removed owners of category '%s'\n", args[0])
+	return nil
+}
+
+func runGatePending(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	owners, err := loadGateOwners(database)
+	if err != nil {
+		return fmt.Errorf("failed to load gate owners: database error: %w", err)
+	}
+	pending, err := findPendingGates(database, owners, gatePendingOwner, gatePendingCategory)
+	if err != nil {
+		return fmt.Errorf("failed to list pending gates: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(pending), "pending": pending})
+		return nil
+	}
+	if len(pending) == 0 {
+		if gatePendingOwner != "" {
+			fmt.Printf("No gates awaiting verification by %s\n", mentionOwner(gatePendingOwner))
+		} else {
+			fmt.Println("No gates awaiting verification")
+		}
+		return nil
+	}
+
+	fmt.Printf("Gates awaiting verification (%d):\n\n", len(pending))
+	for _, p := range pending {
+		owned := ""
+		if len(p.Owners) > 0 {
+			owned = " → " + strings.Join(p.Owners, ", ")
+		}
+		fmt.Printf("  [%s] %s: %s (%s, %s)%s\n", p.TaskID, p.TaskTitle, p.GateTitle, p.GateID, p.Status, owned)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func setupOwnedGates(t *testing.T) {
+	t.Helper()
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-own00001", Title: "Login", Status: models.StatusOpen, Priority: 1})
+	database.Create(&models.Task{ID: "gur-own00002", Title: "Export", Status: models.StatusInProgress, Priority: 2})
+	database.Create(&models.Task{ID: "gur-own00003", Title: "Done", Status: models.StatusClosed})
+	database.Create(&models.Gate{ID: "gate-own00001", Title: "Security review", Category: "security"})
+	database.Create(&models.Gate{ID: "gate-own00002", Title: "API contract", Category: "api"})
+	database.Create(&models.Gate{ID: "gate-own00003", Title: "Smoke test"})
+	database.Create(&models.GateTaskLink{GateID: "gate-own00001", TaskID: "gur-own00001", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-own00002", TaskID: "gur-own00001", Status: models.GateLinkPassed})
+	database.Create(&models.GateTaskLink{GateID: "gate-own00001", TaskID: "gur-own00002", Status: models.GateLinkRequested})
+	database.Create(&models.GateTaskLink{GateID: "gate-own00002", TaskID: "gur-own00002", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-own00003", TaskID: "gur-own00002", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-own00001", TaskID: "gur-own00003", Status: models.GateLinkPending})
+	db.SetConfig(models.GateOwnerKey("security"), "@acme/security")
+	db.SetConfig(models.GateOwnerKey("api"), "@alice,@bob")
+}
+
+func TestFindPendingGates(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	setupOwnedGates(t)
+
+	database := db.GetDB()
+	owners, err := loadGateOwners(database)
+	if err != nil || len(owners) != 2 || len(owners["api"]) != 2 {
+		t.Fatalf("loadGateOwners() = %v, %v", owners, err)
+	}
+
+	all, err := findPendingGates(database, owners, "", "")
+	if err != nil {
+		t.Fatalf("findPendingGates() error: %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("found %d pending gates, want 4 (passed and closed tasks excluded): %+v", len(all), all)
+	}
+	if all[0].TaskID != "gur-own00001" || all[0].Owners[0] != "@acme/security" {
+		t.Errorf("first pending = %+v, want the P1 task's security gate", all[0])
+	}
+
+	security, _ := findPendingGates(database, owners, "acme/security", "")
+	if len(security) != 2 || security[1].Status != models.GateLinkRequested {
+		t.Errorf("pending for acme/security = %+v, want 2 including the requested approval", security)
+	}
+	if bob, _ := findPendingGates(database, owners, "@Bob", ""); len(bob) != 1 || bob[0].GateID != "gate-own00002" {
+		t.Errorf("pending for @Bob = %+v, want the api gate", bob)
+	}
+	if api, _ := findPendingGates(database, owners, "", "api"); len(api) != 1 {
+		t.Errorf("pending in api = %+v, want 1", api)
+	}
+}
+
+func TestMentionGateOwners(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	setupOwnedGates(t)
+
+	var comments []string
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/app/issues/5/comments" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		var c struct {
+			Body string `json:"body"`
+		}
+		json.NewDecoder(r.Body).Decode(&c)
+		comments = append(comments, c.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+
+	database := db.GetDB()
+	owners, _ := loadGateOwners(database)
+	mentioned, err := mentionGateOwners(context.Background(), database, client, "acme", "app", 5, "gur-own00002", owners)
+	if err != nil {
+		t.Fatalf("mentionGateOwners() error: %v", err)
+	}
+	if strings.Join(mentioned, " ") != "@acme/security @alice @bob" {
+		t.Errorf("mentioned = %v, want @acme/security @alice @bob", mentioned)
+	}
+	if len(comments) != 1 || !strings.Contains(comments[0], "@acme/security:\n- Security review (`gate-own00001`, requested)") {
+		t.Fatalf("comments = %q", comments)
+	}
+	if strings.Contains(comments[0], "Smoke test") {
+		t.Error("gates without owners should not be mentioned")
+	}
+
+	// Unchanged gates: no repeat mention
+	if mentioned, _ := mentionGateOwners(context.Background(), database, client, "acme", "app", 5, "gur-own00002", owners); len(mentioned) != 0 || len(comments) != 1 {
+		t.Errorf("repeat push mentioned %v (%d comments), want no new comment", mentioned, len(comments))
+	}
+
+	// Once the api gate passes, only security is still awaited
+	database.Model(&models.GateTaskLink{}).Where("gate_id = ? AND task_id = ?", "gate-own00002", "gur-own00002").Update("status", models.GateLinkPassed)
+	if mentioned, _ := mentionGateOwners(context.Background(), database, client, "acme", "app", 5, "gur-own00002", owners); strings.Join(mentioned, " ") != "@acme/security" || len(comments) != 2 {
+		t.Errorf("after api passed mentioned %v (%d comments), want @acme/security in a new comment", mentioned, len(comments))
+	}
+}
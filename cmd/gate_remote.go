@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// sharedGateDir is the directory of a shared gate source holding its packs
+const sharedGateDir = "gates"
+
+// Shared gate statuses, as found by comparing the source with local copies
+const (
+	sharedGateNew      = "new"      // Upstream, not copied yet
+	sharedGateOutdated = "outdated" // Changed upstream since it was copied
+	sharedGateModified = "modified" // Edited locally since it was copied
+	sharedGateRemoved  = "removed"  // Copied, but gone upstream
+	sharedGateCurrent  = "current"
+)
+
+var (
+	gateRemoteRef   string
+	gateRemoteCheck bool
+	gateRemoteForce bool
+)
+
+var gateSyncRemoteCmd = &cobra.Command{
+	Use:   "sync-remote [source]",
+	Short: "Copy the organization's shared gates and flag drift",
+	Long: `Copy gates from a shared source, so every repository in an organization
+enforces the same review and security gates.
+
+The source is a git repository (org/repo or a git URL) or a local directory
+with a gates/ directory of packs: one YAML file per pack, named after the
+pack, holding its gates like a gate pack of 'gur template publish':
+
+  # gates/security.yml
+  gates:
+    - title: Secret scan
+      type: security
+      command: gitleaks detect
+
+Gates are copied into the pack's category. Each copy records its version
+and the commit it came from; a changed upstream gate is copied again with
+the next version. A copy edited locally is flagged as modified and kept,
+unless --force. Gates removed upstream are flagged but not deleted.
+
+The source given is remembered; later runs can omit it. --check reports
+drift without copying and fails if there is any, for CI.
+
+Examples:
+  gur gate sync-remote acme/guardrails-gates
+  gur gate sync-remote acme/guardrails-gates --ref v2
+  gur gate sync-remote --check
+  gur gate sync-remote --force`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runGateSyncRemote,
+}
+
+func init() {
+	gateCmd.AddCommand(gateSyncRemoteCmd)
+	gateSyncRemoteCmd.Flags().StringVar(&gateRemoteRef, "ref", "", "Branch or tag of the source repository (remembered)")
+	gateSyncRemoteCmd.Flags().BoolVar(&gateRemoteCheck, "check", false, "Report drift without copying; fail if there is any")
+	gateSyncRemoteCmd.Flags().BoolVar(&gateRemoteForce, "force", false, "Overwrite copies edited locally")
+}
+
+// sharedGateChange is the status of one shared gate, and whether sync
+// copied it
+type sharedGateChange struct {
+	GateID  string `json:"gate_id,omitempty"`
+	Pack    string `json:"pack"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	Version int    `json:"version,omitempty"`
+	Copied  bool   `json:"copied,omitempty"`
+}
+
+// sharedGateHash hashes a gate definition, so copies can be compared with
+// the source and with what was copied
+func sharedGateHash(mg manifestGate) string {
+	if mg.Type == "" {
+		mg.Type = "manual"
+	}
+	data, _ := json.Marshal(mg)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// fetchSharedGates reads the packs of a shared gate source, along with the
+// commit they were read at ("" if the source isn't a git checkout)
+func fetchSharedGates(source, ref string) ([]manifestGatePack, string, error) {
+	dir := source
+	if info, err := os.Stat(source); err != nil || !info.IsDir() {
+		repoURL, ok := gitRepoURL(source)
+		if !ok {
+			return nil, "", fmt.Errorf("unknown gate source '%s': use org/repo, a git URL, or a local directory", source)
+		}
+		tmp, err := os.MkdirTemp("", "gur-gates-*")
+		if err != nil {
+			return nil, "", err
+		}
+		defer os.RemoveAll(tmp)
+		if err := cloneTemplateRepo(repoURL, ref, tmp); err != nil {
+			return nil, "", err
+		}
+		dir = tmp
+	}
+
+	packs, err := readSharedGatePacks(filepath.Join(dir, sharedGateDir))
+	if err != nil {
+		return nil, "", err
+	}
+	return packs, gitRevision(dir), nil
+}
+
+// readSharedGatePacks reads and validates the pack files in dir
+func readSharedGatePacks(dir string) ([]manifestGatePack, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shared gates: %w", err)
+	}
+	var packs []manifestGatePack
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || (ext != ".yml" && ext != ".yaml" && ext != ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shared gates: %w", err)
+		}
+		var pack manifestGatePack
+		if err := decodeYAML(data, &pack); err != nil {
+			return nil, fmt.Errorf("invalid gate pack %s: %w", e.Name(), err)
+		}
+		if pack.Name == "" {
+			pack.Name = strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+		}
+		titles := make(map[string]bool)
+		for _, g := range pack.Gates {
+			if strings.TrimSpace(g.Title) == "" {
+				return nil, fmt.Errorf("invalid gate pack %s: gate without a title", e.Name())
+			}
+			if titles[g.Title] {
+				return nil, fmt.Errorf("invalid gate pack %s: two gates titled '%s'", e.Name(), g.Title)
+			}
+			titles[g.Title] = true
+			if err := models.ValidateRunner(g.Runner); err != nil {
+				return nil, fmt.Errorf("invalid gate pack %s: gate '%s': %w", e.Name(), g.Title, err)
+			}
+		}
+		packs = append(packs, pack)
+	}
+	if len(packs) == 0 {
+		return nil, fmt.Errorf("no gate packs found in %s", dir)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, nil
+}
+
+// gitRevision returns the commit checked out in dir, or "" if it isn't a
+// git checkout
+func gitRevision(dir string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// syncSharedGates compares the packs of source with their local copies and,
+// with apply, copies new and outdated gates (and modified ones with force)
+func syncSharedGates(database *gorm.DB, source, revision string, packs []manifestGatePack, apply, force bool, now time.Time) ([]sharedGateChange, error) {
+	var tracked []models.SharedGate
+	if err := database.Where("source = ?", source).Find(&tracked).Error; err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]models.SharedGate, len(tracked))
+	for _, sg := range tracked {
+		byKey[sg.Pack+"\x00"+sg.Title] = sg
+	}
+
+	var changes []sharedGateChange
+	err := database.Transaction(func(tx *gorm.DB) error {
+		seen := make(map[string]bool)
+		for _, pack := range packs {
+			for _, mg := range pack.Gates {
+				key := pack.Name + "\x00" + mg.Title
+				seen[key] = true
+				hash := sharedGateHash(mg)
+				change := sharedGateChange{Pack: pack.Name, Title: mg.Title}
+
+				sg, ok := byKey[key]
+				var gate models.Gate
+				exists := ok && tx.Where("id = ?", sg.GateID).First(&gate).Error == nil
+				modified := exists && sharedGateHash(manifestGateFrom(gate)) != sg.Hash
+				switch {
+				case !exists:
+					change.Status = sharedGateNew
+				case modified && !force:
+					change.Status = sharedGateModified
+				case hash != sg.Hash || modified:
+					change.Status = sharedGateOutdated
+				default:
+					change.Status = sharedGateCurrent
+				}
+				change.GateID, change.Version = sg.GateID, sg.Version
+				if !apply || change.Status == sharedGateCurrent || change.Status == sharedGateModified {
+					changes = append(changes, change)
+					continue
+				}
+
+				if !exists {
+					// Adopt a gate already created locally under the same title
+					exists = tx.Where("category = ? AND title = ?", pack.Name, mg.Title).First(&gate).Error == nil
+				}
+				mg.applyTo(&gate, pack.Name)
+				if err := saveOrCreate(tx, &gate, exists); err != nil {
+					return fmt.Errorf("failed to save gate '%s': %w", mg.Title, err)
+				}
+				if hash != sg.Hash {
+					sg.Version++
+				}
+				if ok && sg.GateID != gate.ID {
+					if err := tx.Delete(&models.SharedGate{}, "gate_id = ?", sg.GateID).Error; err != nil {
+						return err
+					}
+				}
+				sg = models.SharedGate{
+					GateID: gate.ID, Source: source, Pack: pack.Name, Title: mg.Title,
+					Version: sg.Version, Revision: revision, Hash: hash, SyncedAt: now,
+				}
+				if err := tx.Save(&sg).Error; err != nil {
+					return fmt.Errorf("failed to record shared gate '%s': %w", mg.Title, err)
+				}
+				noteAffected(gate.ID)
+				change.GateID, change.Version, change.Copied = gate.ID, sg.Version, true
+				changes = append(changes, change)
+			}
+		}
+		for _, sg := range tracked {
+			if !seen[sg.Pack+"\x00"+sg.Title] {
+				changes = append(changes, sharedGateChange{GateID: sg.GateID, Pack: sg.Pack, Title: sg.Title, Status: sharedGateRemoved, Version: sg.Version})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func runGateSyncRemote(cmd *cobra.Command, args []string) error {
+	source, _ := db.GetConfig(models.ConfigGateRemoteSource)
+	ref, _ := db.GetConfig(models.ConfigGateRemoteRef)
+	if len(args) > 0 {
+		if args[0] != source {
+			ref = ""
+		}
+		source = args[0]
+		// A relative directory is remembered as an absolute one, so it
+		// resolves the same from any working directory
+		if info, err := os.Stat(source); err == nil && info.IsDir() {
+			if abs, err := filepath.Abs(source); err == nil {
+				source = abs
+			}
+		}
+	}
+	if cmd.Flags().Changed("ref") {
+		ref = gateRemoteRef
+	}
+	if source == "" {
+		return codedErrorf(ErrCodeNotConfigured, "no shared gate source configured (pass one, e.g. 'gur gate sync-remote acme/guardrails-gates')")
+	}
+
+	packs, revision, err := fetchSharedGates(source, ref)
+	if err != nil {
+		return fmt.Errorf("cannot sync shared gates: %w", err)
+	}
+	changes, err := syncSharedGates(db.GetDB(), source, revision, packs, !gateRemoteCheck, gateRemoteForce, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to sync shared gates: database error: %w", err)
+	}
+	if !gateRemoteCheck {
+		if err := db.SetConfig(models.ConfigGateRemoteSource, source); err != nil {
+			return fmt.Errorf("failed to save shared gate source: %w", err)
+		}
+		if err := db.SetConfig(models.ConfigGateRemoteRef, ref); err != nil {
+			return fmt.Errorf("failed to save shared gate ref: %w", err)
+		}
+	}
+
+	drift := 0
+	for _, c := range changes {
+		if c.Status != sharedGateCurrent && !c.Copied {
+			drift++
+		}
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": drift == 0 || !gateRemoteCheck, "source": source, "ref": ref, "revision": revision, "gates": changes})
+	} else {
+		printSharedGateChanges(source, revision, changes)
+	}
+	if gateRemoteCheck && drift > 0 {
+		return reportedErrorf(ErrCodeGeneral, "%d shared gate(s) drifted from %s (run 'gur gate sync-remote' to update)", drift, source)
+	}
+	return nil
+}
+
+// printSharedGateChanges reports what sync-remote found and did
+func printSharedGateChanges(source, revision string, changes []sharedGateChange) {
+	at := ""
+	if revision != "" {
+		at = " at " + revision[:min(len(revision), 12)]
+	}
+	fmt.Printf("Shared gates from %s%s:\n", source, at)
+	current := 0
+	for _, c := range changes {
+		name := c.Pack + "/" + c.Title
+		switch {
+		case c.Copied && c.Status == sharedGateNew:
+			fmt.Printf("  Added:    %s (%s, v%d)\n", name, c.GateID, c.Version)
+		case c.Copied:
+			fmt.Printf("  Updated:  %s (%s, v%d)\n", name, c.GateID, c.Version)
+		case c.Status == sharedGateNew:
+			fmt.Printf("  Missing:  %s\n", name)
+		case c.Status == sharedGateOutdated:
+			fmt.Printf("  Outdated: %s (%s, v%d)\n", name, c.GateID, c.Version)
+		case c.Status == sharedGateModified:
+			fmt.Printf("  Modified: %s (%s, v%d edited locally; kept, use --force to overwrite)\n", name, c.GateID, c.Version)
+		case c.Status == sharedGateRemoved:
+			fmt.Printf("  Removed:  %s (%s, gone upstream; delete it with 'gur gate delete %s')\n", name, c.GateID, c.GateID)
+		default:
+			current++
+		}
+	}
+	fmt.Printf("  %d gate(s) current\n", current)
+}
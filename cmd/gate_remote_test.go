@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestSyncSharedGates(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	source := t.TempDir()
+	os.MkdirAll(filepath.Join(source, sharedGateDir), 0755)
+	writePack := func(content string) []manifestGatePack {
+		t.Helper()
+		os.WriteFile(filepath.Join(source, sharedGateDir, "security.yml"), []byte(content), 0644)
+		packs, _, err := fetchSharedGates(source, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return packs
+	}
+	statuses := func(changes []sharedGateChange) map[string]string {
+		m := make(map[string]string)
+		for _, c := range changes {
+			m[c.Title] = c.Status
+		}
+		return m
+	}
+
+	database := db.GetDB()
+	// A gate created by hand under a shared title is adopted, not duplicated
+	database.Create(&models.Gate{ID: "gate-5a4e0001", Title: "Review", Category: "security", Type: "review"})
+	packs := writePack(`{"gates": [{"title": "Secret scan", "type": "security", "command": "gitleaks detect"}, {"title": "Review", "type": "review"}]}`)
+	changes, err := syncSharedGates(database, source, "", packs, true, false, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gates []models.Gate
+	database.Where("category = ?", "security").Find(&gates)
+	if len(gates) != 2 || len(changes) != 2 || !changes[0].Copied || changes[0].Version != 1 {
+		t.Fatalf("first sync = %+v, %d gates; want both copied at v1", changes, len(gates))
+	}
+	if changes[1].GateID != "gate-5a4e0001" {
+		t.Errorf("review gate = %s, want the existing gate adopted", changes[1].GateID)
+	}
+
+	changes, _ = syncSharedGates(database, source, "", packs, false, false, time.Now())
+	if s := statuses(changes); s["Secret scan"] != sharedGateCurrent || s["Review"] != sharedGateCurrent {
+		t.Errorf("check after sync = %v, want all current", s)
+	}
+
+	// Upstream changes one gate and drops the other, now writing block YAML;
+	// a local edit is kept
+	database.Model(&models.Gate{}).Where("id = ?", "gate-5a4e0001").Update("priority", 0)
+	packs = writePack(`gates:
+  - title: Secret scan
+    type: security
+    command: gitleaks detect --redact
+`)
+	changes, _ = syncSharedGates(database, source, "", packs, true, false, time.Now())
+	if s := statuses(changes); s["Secret scan"] != sharedGateOutdated || s["Review"] != sharedGateRemoved {
+		t.Errorf("sync after upstream change = %v, want secret scan updated and review removed", s)
+	}
+	secret, _ := db.GetGateByID(changes[0].GateID)
+	if secret.Command != "gitleaks detect --redact" || changes[0].Version != 2 {
+		t.Errorf("updated gate = %q at v%d, want the new command at v2", secret.Command, changes[0].Version)
+	}
+
+	database.Model(&models.Gate{}).Where("id = ?", secret.ID).Update("command", "true")
+	changes, _ = syncSharedGates(database, source, "", packs, true, false, time.Now())
+	if s := statuses(changes); s["Secret scan"] != sharedGateModified || changes[0].Copied {
+		t.Fatalf("sync over a local edit = %+v, want it flagged and kept", changes)
+	}
+	changes, _ = syncSharedGates(database, source, "", packs, true, true, time.Now())
+	secret, _ = db.GetGateByID(secret.ID)
+	if !changes[0].Copied || secret.Command != "gitleaks detect --redact" || changes[0].Version != 2 {
+		t.Errorf("forced sync = %+v, command %q; want the edit overwritten at the same version", changes[0], secret.Command)
+	}
+}
@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+const (
+	defaultGateTimeout = 10 * time.Minute
+	gateOutputLimit    = 64 << 10 // Keep the last 64KB of command output
+	gateContainerRoot  = "/work"  // Where docker runners mount the project
+)
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var gateRunCmd = &cobra.Command{
+	Use:   "run <gate-id> <task-id>",
+	Short: "Run an automated gate's command and record the result for a task",
+	Long: `Run the gate's command and record passed (exit 0) or failed for the task.
+
+The command runs with the gate's execution settings:
+  --workdir  directory relative to the project root (default: the root)
+  --env      environment variables passed through (default: all, local only)
+  --timeout  maximum run time in whole seconds (default 10m); a timeout
+             fails the gate and kills a docker runner's container
+  --runner   local (sh -c); docker:<image>, which mounts the project at
+             /work and runs the command in the image; or http (see below)
+  --after    gates 'gur verify' runs before this one (e.g., build before test)
+
+With --runner http the command is a URL: the gate passes when a GET of it
+answers with --expect-status (default any 2xx) and, with --expect-json
+<path>=<value>, its JSON response holds that value (e.g., version=1.4.2 or
+checks.db.status=ok). The default timeout is then 30s.
+
+Output, duration and runner are saved with the run (see 'gur gate show').
+Set them with 'gur gate create' or 'gur gate configure'.
+
+Examples:
+  gur gate run gate-abc123 gur-def456
+  gur gate configure gate-abc123 --runner docker:golang:1.22 --timeout 15m --env GOFLAGS
+  gur gate run gate-abc123 gur-def456 --by ci
+  gur gate configure gate-abc123 --runner http --cmd https://staging.example.com/health --expect-json version=1.4.2`,
+	Args: cobra.ExactArgs(2),
+	RunE: runGateRun,
+}
+
+var gateConfigureCmd = &cobra.Command{
+	Use:   "configure <gate-id>",
+	Short: "Change an automated gate's command and execution settings",
+	Long: `Change an automated gate's command and execution settings.
+
+Only the flags given are changed; pass an empty value to reset one
+(e.g., --runner "" or --env "").
+
+--after orders automated gates for 'gur verify': the gate runs once the
+gates it follows have passed, and is skipped if one of them fails.
+
+Examples:
+  gur gate configure gate-abc123 --cmd "go test ./..." --workdir backend
+  gur gate configure gate-abc123 --runner docker:golang:1.22 --env GOFLAGS --env CGO_ENABLED
+  gur gate configure gate-abc123 --timeout 30m
+  gur gate configure gate-abc123 --after gate-def456`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGateConfigure,
+}
+
+// gateExecOptions holds the execution setting flags of one command
+type gateExecOptions struct {
+	workDir      string
+	env          []string
+	timeout      time.Duration
+	runner       string
+	after        []string
+	expectStatus int
+	expectJSON   string
+}
+
+var (
+	gateCreateExec       gateExecOptions
+	gateConfigureExec    gateExecOptions
+	gateConfigureCommand string
+)
+
+func init() {
+	gateCmd.AddCommand(gateRunCmd)
+	gateCmd.AddCommand(gateConfigureCmd)
+
+	addGateExecFlags(gateCreateCmd, &gateCreateExec)
+	addGateExecFlags(gateConfigureCmd, &gateConfigureExec)
+	gateConfigureCmd.Flags().StringVar(&gateConfigureCommand, "cmd", "", "Command to run")
+
+	gateRunCmd.Flags().StringVar(&gateNotes, "notes", "", "Notes about the run (default: exit status and duration)")
+	gateRunCmd.Flags().StringVar(&gateRunBy, "by", "human", "Who ran the gate (human/agent/ci/name)")
+	gateRunCmd.Flags().StringVar(&gateTrustOverride, "override-trust", "", "Record a pass even if you aren't trusted for this gate type, with this reason (needs a terminal)")
+}
+
+// addGateExecFlags registers --workdir, --env, --timeout, --runner, --after
+// and the http runner's --expect-status and --expect-json
+func addGateExecFlags(cmd *cobra.Command, opts *gateExecOptions) {
+	cmd.Flags().StringVar(&opts.workDir, "workdir", "", "Working directory for the command, relative to the project root")
+	cmd.Flags().StringArrayVar(&opts.env, "env", nil, "Environment variable to pass to the command (repeatable; default all)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 0, fmt.Sprintf("Command timeout in whole seconds (e.g., 90s, 15m; default %s)", defaultGateTimeout))
+	cmd.Flags().StringVar(&opts.runner, "runner", "", "Where to run the command: local, docker:<image>, or http to check the URL given as the command")
+	cmd.Flags().StringSliceVar(&opts.after, "after", nil, "Gates 'gur verify' runs before this one (repeatable)")
+	cmd.Flags().IntVar(&opts.expectStatus, "expect-status", 0, "HTTP runner: response status the gate requires (default any 2xx)")
+	cmd.Flags().StringVar(&opts.expectJSON, "expect-json", "", "HTTP runner: <path>=<value> the JSON response must hold (e.g., version=1.4.2)")
+}
+
+// apply validates the flags that were set on cmd and copies them to gate.
+// It returns whether anything changed.
+func (o *gateExecOptions) apply(cmd *cobra.Command, gate *models.Gate) (bool, error) {
+	changed := false
+	if cmd.Flags().Changed("workdir") {
+		dir := filepath.ToSlash(filepath.Clean(o.workDir))
+		if o.workDir == "" || dir == "." {
+			dir = ""
+		} else if !filepath.IsLocal(o.workDir) {
+			return false, fmt.Errorf("invalid --workdir '%s': must be a path inside the project", o.workDir)
+		}
+		gate.WorkDir = dir
+		changed = true
+	}
+	if cmd.Flags().Changed("env") {
+		var names models.StringSlice
+		for _, name := range o.env {
+			if name == "" {
+				continue
+			}
+			if !envNameRegex.MatchString(name) {
+				return false, fmt.Errorf("invalid --env '%s': must be a variable name (values come from the caller's environment)", name)
+			}
+			names = append(names, name)
+		}
+		gate.EnvAllow = names
+		changed = true
+	}
+	if cmd.Flags().Changed("timeout") {
+		if o.timeout < 0 {
+			return false, fmt.Errorf("--timeout cannot be negative")
+		}
+		if o.timeout%time.Second != 0 {
+			return false, fmt.Errorf("invalid --timeout %s: must be whole seconds (e.g., 1s, 90s, 15m)", o.timeout)
+		}
+		gate.Timeout = int(o.timeout.Seconds())
+		changed = true
+	}
+	if cmd.Flags().Changed("runner") {
+		if err := models.ValidateRunner(o.runner); err != nil {
+			return false, err
+		}
+		gate.Runner = o.runner
+		if gate.Runner == models.RunnerLocal {
+			gate.Runner = ""
+		}
+		changed = true
+	}
+	if cmd.Flags().Changed("after") {
+		var after models.StringSlice
+		for _, id := range o.after {
+			if id = strings.TrimSpace(id); id == "" {
+				continue
+			}
+			if id == gate.ID {
+				return false, fmt.Errorf("invalid --after '%s': a gate can't run after itself", id)
+			}
+			if _, err := db.GetGateByID(id); err != nil {
+				return false, codedErrorf(ErrCodeNotFound, "invalid --after: gate '%s' not found (use 'gur gate list' to see available gates)", id)
+			}
+			after = append(after, id)
+		}
+		if gate.ID != "" {
+			if cycle, err := gateOrderCycle(gate.ID, after); err != nil {
+				return false, err
+			} else if cycle != "" {
+				return false, fmt.Errorf("invalid --after: '%s' already runs after %s, which would make a cycle", cycle, gate.ID)
+			}
+		}
+		gate.After = after
+		changed = true
+	}
+	if cmd.Flags().Changed("expect-status") {
+		if o.expectStatus != 0 && (o.expectStatus < 100 || o.expectStatus > 599) {
+			return false, fmt.Errorf("invalid --expect-status %d: must be an HTTP status from 100 to 599, or 0 for any 2xx", o.expectStatus)
+		}
+		gate.ExpectStatus = o.expectStatus
+		changed = true
+	}
+	if cmd.Flags().Changed("expect-json") {
+		if o.expectJSON != "" {
+			if _, _, err := parseExpectJSON(o.expectJSON); err != nil {
+				return false, err
+			}
+		}
+		gate.ExpectJSON = o.expectJSON
+		changed = true
+	}
+	return changed, nil
+}
+
+// gateOrderCycle returns the gate among after that already runs after id,
+// directly or through other gates, or "" if id can run after them all
+func gateOrderCycle(id string, after []string) (string, error) {
+	var gates []models.Gate
+	if err := db.GetDB().Select("id", "after").Find(&gates).Error; err != nil {
+		return "", fmt.Errorf("failed to load gates: database error: %w", err)
+	}
+	follows := make(map[string][]string, len(gates))
+	for _, g := range gates {
+		follows[g.ID] = g.After
+	}
+	for _, start := range after {
+		seen := map[string]bool{}
+		stack := []string{start}
+		for len(stack) > 0 {
+			cur := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if cur == id {
+				return start, nil
+			}
+			if seen[cur] {
+				continue
+			}
+			seen[cur] = true
+			stack = append(stack, follows[cur]...)
+		}
+	}
+	return "", nil
+}
+
+// gateTimeout returns the gate's command timeout
+func gateTimeout(gate *models.Gate) time.Duration {
+	if gate.Timeout > 0 {
+		return time.Duration(gate.Timeout) * time.Second
+	}
+	if gate.Runner == models.RunnerHTTP {
+		return defaultHTTPGateTimeout
+	}
+	return defaultGateTimeout
+}
+
+// filterEnv keeps the allowed variables from environ
+func filterEnv(environ []string, allow []string) []string {
+	allowed := make(map[string]bool, len(allow))
+	for _, name := range allow {
+		allowed[name] = true
+	}
+	filtered := []string{}
+	for _, kv := range environ {
+		if name, _, ok := strings.Cut(kv, "="); ok && allowed[name] {
+			filtered = append(filtered, kv)
+		}
+	}
+	return filtered
+}
+
+// gateContainerName names a docker runner's container so it can be killed
+// on timeout, e.g. gur-gate-abc123-9f3e01c2
+func gateContainerName(gate *models.Gate) string {
+	nonce := make([]byte, 4)
+	rand.Read(nonce)
+	return "gur-" + gate.ID + "-" + hex.EncodeToString(nonce)
+}
+
+// buildGateCommand returns the command for a gate run from the project at
+// root. Canceling ctx kills a docker runner's container as well as the
+// docker client, which would otherwise leave it running.
+func buildGateCommand(ctx context.Context, gate *models.Gate, root string, environ []string) *exec.Cmd {
+	if image, ok := strings.CutPrefix(gate.Runner, models.RunnerDockerPrefix); ok {
+		name := gateContainerName(gate)
+		args := []string{"run", "--rm", "--init", "--name", name, "-v", root + ":" + gateContainerRoot, "-w", path.Join(gateContainerRoot, gate.WorkDir)}
+		for _, name := range gate.EnvAllow {
+			args = append(args, "-e", name)
+		}
+		args = append(args, image, "sh", "-c", gate.Command)
+		c := exec.CommandContext(ctx, "docker", args...)
+		c.Env = environ
+		c.Cancel = func() error {
+			kill, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			exec.CommandContext(kill, "docker", "kill", name).Run()
+			return c.Process.Kill()
+		}
+		return c
+	}
+
+	c := exec.CommandContext(ctx, "sh", "-c", gate.Command)
+	c.Dir = filepath.Join(root, filepath.FromSlash(gate.WorkDir))
+	c.Env = environ
+	if len(gate.EnvAllow) > 0 {
+		c.Env = filterEnv(environ, gate.EnvAllow)
+	}
+	return c
+}
+
+// gateExecution is the outcome of running a gate's command
+type gateExecution struct {
+	ExitCode   int
+	TimedOut   bool
+	Duration   time.Duration
+	Output     string
+	HTTPStatus int    // Response status of an HTTP check
+	Failure    string // Why an HTTP check failed
+}
+
+// executeGateCommand runs the gate's command, or for the http runner checks
+// its URL. Errors mean the command could not be started (e.g., docker isn't
+// installed), not that it failed.
+func executeGateCommand(gate *models.Gate, root string) (*gateExecution, error) {
+	if gate.Runner == models.RunnerHTTP {
+		return executeHTTPCheck(gate)
+	}
+	timeout := gateTimeout(gate)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	c := buildGateCommand(ctx, gate, root, os.Environ())
+	var out bytes.Buffer
+	c.Stdout = &out
+	c.Stderr = &out
+	c.WaitDelay = 5 * time.Second
+
+	started := time.Now()
+	err := c.Run()
+	res := &gateExecution{Duration: time.Since(started), Output: tailOutput(out.String(), gateOutputLimit)}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		res.TimedOut = true
+		res.ExitCode = -1
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return nil, fmt.Errorf("cannot run gate command with runner %s: %w", gate.RunnerString(), err)
+	}
+	return res, nil
+}
+
+// status returns the gate link status for the run (passed on exit 0) and
+// a one-line summary for its notes
+func (e *gateExecution) status(gate *models.Gate) (string, string) {
+	switch {
+	case e.TimedOut:
+		return models.GateLinkFailed, fmt.Sprintf("timed out after %s", gateTimeout(gate))
+	case e.Failure != "":
+		return models.GateLinkFailed, fmt.Sprintf("%s in %s", e.Failure, e.Duration.Round(time.Millisecond))
+	case e.HTTPStatus != 0:
+		return models.GateLinkPassed, fmt.Sprintf("HTTP %d in %s", e.HTTPStatus, e.Duration.Round(time.Millisecond))
+	case e.ExitCode != 0:
+		return models.GateLinkFailed, fmt.Sprintf("exit %d in %s", e.ExitCode, e.Duration.Round(time.Millisecond))
+	}
+	return models.GateLinkPassed, fmt.Sprintf("exit %d in %s", e.ExitCode, e.Duration.Round(time.Millisecond))
+}
+
+// tailOutput keeps the last limit bytes of output
+func tailOutput(output string, limit int) string {
+	if len(output) <= limit {
+		return output
+	}
+	return "[... output truncated ...]\n" + output[len(output)-limit:]
+}
+
+func runGateRun(cmd *cobra.Command, args []string) error {
+	gate, err := db.GetGateByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot run gate: gate '%s' not found (use 'gur gate list' to see available gates)", args[0])
+	}
+	if gate.Command == "" {
+		return fmt.Errorf("cannot run gate '%s': it has no command (set one with 'gur gate configure %s --cmd \"...\"')", gate.ID, gate.ID)
+	}
+	if _, err := db.GetTaskByID(args[1]); err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot run gate: task '%s' not found (use 'gur list' to see available tasks)", args[1])
+	}
+	var link models.GateTaskLink
+	if err := db.GetDB().Where("gate_id = ? AND task_id = ?", gate.ID, args[1]).First(&link).Error; err != nil {
+		return fmt.Errorf("cannot run gate: gate '%s' is not linked to task '%s'\nLink it first: gur gate link %s %s", gate.ID, args[1], gate.ID, args[1])
+	}
+
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		if root, err = os.Getwd(); err != nil {
+			return err
+		}
+	}
+
+	if !IsJSONOutput() {
+		fmt.Printf("Running %s (%s): %s\n", gate.ID, gate.RunnerString(), gate.Command)
+	}
+	result, err := executeGateCommand(gate, root)
+	if err != nil {
+		return err
+	}
+
+	status, summary := result.status(gate)
+	if gateNotes == "" {
+		gateNotes = summary
+	}
+
+	if !IsJSONOutput() && status == models.GateLinkFailed && result.Output != "" {
+		fmt.Print(tailOutput(result.Output, 4<<10))
+		if !strings.HasSuffix(result.Output, "\n") {
+			fmt.Println()
+		}
+	}
+	return recordGateResult(gate.ID, args[1], status, models.GateRun{
+		Output:   result.Output,
+		Duration: int(result.Duration.Milliseconds()),
+		Runner:   gate.RunnerString(),
+	})
+}
+
+func runGateConfigure(cmd *cobra.Command, args []string) error {
+	gate, err := db.GetGateByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot configure gate: gate '%s' not found (use 'gur gate list' to see available gates)", args[0])
+	}
+
+	changed, err := gateConfigureExec.apply(cmd, gate)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("cmd") {
+		gate.Command = gateConfigureCommand
+		changed = true
+	}
+	if !changed {
+		return fmt.Errorf("nothing to change: use --cmd, --workdir, --env, --timeout, --runner, --after, --expect-status or --expect-json")
+	}
+	if err := db.GetDB().Save(gate).Error; err != nil {
+		return fmt.Errorf("failed to update gate '%s': %w", gate.ID, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "gate": gate})
+		return nil
+	}
+	fmt.Printf("Updated: %s - %s\n", gate.ID, gate.Title)
+	printGateExecSettings(gate)
+	return nil
+}
+
+// printGateExecSettings prints an automated gate's command and execution settings
+func printGateExecSettings(gate *models.Gate) {
+	if gate.Command == "" {
+		return
+	}
+	if gate.Runner == models.RunnerHTTP {
+		fmt.Printf("  URL:     %s\n", gate.Command)
+		fmt.Printf("  Runner:  %s\n", gate.RunnerString())
+		fmt.Printf("  Expect:  %s\n", describeHTTPExpectations(gate))
+	} else {
+		fmt.Printf("  Command: %s\n", gate.Command)
+		fmt.Printf("  Runner:  %s\n", gate.RunnerString())
+		workDir := gate.WorkDir
+		if workDir == "" {
+			workDir = "(project root)"
+		}
+		fmt.Printf("  Workdir: %s\n", workDir)
+		env := "(all)"
+		if gate.Runner != "" {
+			env = "(none)"
+		}
+		if len(gate.EnvAllow) > 0 {
+			env = strings.Join(gate.EnvAllow, ", ")
+		}
+		fmt.Printf("  Env:     %s\n", env)
+	}
+	fmt.Printf("  Timeout: %s\n", gateTimeout(gate))
+	if len(gate.After) > 0 {
+		fmt.Printf("  After:   %s\n", strings.Join(gate.After, ", "))
+	}
+}
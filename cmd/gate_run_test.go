@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestBuildGateCommand(t *testing.T) {
+	environ := []string{"PATH=/usr/bin", "HOME=/home/me", "GOFLAGS=-count=1"}
+
+	local := &models.Gate{Command: "go test ./...", WorkDir: "backend", EnvAllow: models.StringSlice{"PATH", "GOFLAGS"}}
+	c := buildGateCommand(context.Background(), local, "/repo", environ)
+	if want := []string{"sh", "-c", "go test ./..."}; !reflect.DeepEqual(c.Args, want) {
+		t.Errorf("local args = %v, want %v", c.Args, want)
+	}
+	if c.Dir != "/repo/backend" {
+		t.Errorf("local dir = %q", c.Dir)
+	}
+	if want := []string{"PATH=/usr/bin", "GOFLAGS=-count=1"}; !reflect.DeepEqual(c.Env, want) {
+		t.Errorf("local env = %v, want %v", c.Env, want)
+	}
+
+	docker := &models.Gate{ID: "gate-d0000001", Command: "go test ./...", WorkDir: "backend", EnvAllow: models.StringSlice{"GOFLAGS"}, Runner: "docker:golang:1.22"}
+	c = buildGateCommand(context.Background(), docker, "/repo", environ)
+	if len(c.Args) < 6 || !strings.HasPrefix(c.Args[5], "gur-gate-d0000001-") {
+		t.Fatalf("docker args = %v, want a named container", c.Args)
+	}
+	want := []string{"docker", "run", "--rm", "--init", "--name", c.Args[5], "-v", "/repo:/work", "-w", "/work/backend", "-e", "GOFLAGS", "golang:1.22", "sh", "-c", "go test ./..."}
+	if !reflect.DeepEqual(c.Args, want) {
+		t.Errorf("docker args = %v, want %v", c.Args, want)
+	}
+}
+
+func TestDockerGateTimeoutKillsContainer(t *testing.T) {
+	// A stand-in docker that logs its arguments and hangs on run
+	bin := t.TempDir()
+	log := filepath.Join(bin, "docker.log")
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\n[ \"$1\" = run ] && exec sleep 30\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(bin, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	gate := &models.Gate{ID: "gate-d0000002", Command: "sleep 30", Runner: "docker:alpine", Timeout: 1}
+	res, err := executeGateCommand(gate, t.TempDir())
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !res.TimedOut {
+		t.Fatalf("result = %+v, want a timeout", res)
+	}
+	data, _ := os.ReadFile(log)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "kill gur-gate-d0000002-") || !strings.Contains(lines[0], "--name "+strings.TrimPrefix(lines[1], "kill ")) {
+		t.Errorf("docker calls = %q, want the run's container killed by name", lines)
+	}
+}
+
+func TestExecuteGateCommand(t *testing.T) {
+	root := t.TempDir()
+
+	res, err := executeGateCommand(&models.Gate{Command: "echo out; echo err >&2; exit 3"}, root)
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if res.ExitCode != 3 || res.TimedOut || !strings.Contains(res.Output, "out") || !strings.Contains(res.Output, "err") {
+		t.Errorf("result = %+v", res)
+	}
+
+	res, err = executeGateCommand(&models.Gate{Command: "exec sleep 5", Timeout: 1}, root)
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !res.TimedOut || res.Duration > 4*time.Second {
+		t.Errorf("expected timeout, got %+v", res)
+	}
+}
+
+func TestGateExecOptionsApply(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		ok   bool
+	}{
+		{[]string{"--workdir", "backend/api", "--env", "GOFLAGS", "--timeout", "90s", "--runner", "docker:golang:1.22"}, true},
+		{[]string{"--workdir", "../outside"}, false},
+		{[]string{"--env", "NOT-A-NAME"}, false},
+		{[]string{"--runner", "podman:alpine"}, false},
+		{[]string{"--timeout", "500ms"}, false},
+		{[]string{"--timeout", "1.5s"}, false},
+	} {
+		opts := gateExecOptions{}
+		cmd := &cobra.Command{Use: "configure"}
+		addGateExecFlags(cmd, &opts)
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatalf("parse %v: %v", tt.args, err)
+		}
+		gate := &models.Gate{}
+		_, err := opts.apply(cmd, gate)
+		if (err == nil) != tt.ok {
+			t.Errorf("apply(%v) error = %v, want ok=%v", tt.args, err, tt.ok)
+		}
+		if tt.ok && (gate.WorkDir != "backend/api" || gate.Timeout != 90 || gate.Runner != "docker:golang:1.22" || len(gate.EnvAllow) != 1) {
+			t.Errorf("gate = %+v", gate)
+		}
+	}
+}
+
+func TestRecordGateResultKeepsRunDetails(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-r1000000", Title: "Task", Status: models.StatusOpen})
+	database.Create(&models.Gate{ID: "gate-r1000000", Title: "Build", Command: "true"})
+	database.Create(&models.GateTaskLink{GateID: "gate-r1000000", TaskID: "gur-r1000000", Status: models.GateLinkPending})
+
+	gateRunBy, gateNotes = "ci", ""
+	if err := recordGateResult("gate-r1000000", "gur-r1000000", models.GateLinkPassed, models.GateRun{Output: "ok", Duration: 1500, Runner: "docker:alpine"}); err != nil {
+		t.Fatalf("recordGateResult: %v", err)
+	}
+	var run models.GateRun
+	database.Where("gate_id = ?", "gate-r1000000").First(&run)
+	if run.Result != models.GateLinkPassed || run.Runner != "docker:alpine" || run.Duration != 1500 || run.RunBy != "ci" {
+		t.Errorf("run = %+v", run)
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var gateRunsCmd = &cobra.Command{
+	Use:         "runs <gate-id>",
+	Short:       "List a gate's run history",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `List a gate's full run history, newest first, with duration statistics
+and a flakiness score. 'gur gate show' only shows the last 5 runs.
+
+The flakiness score is how often the result flips between passed and failed
+from one run to the next (0 = never, 1 = every run), over the runs matching
+the filters. Skipped, requested and waived runs don't count toward it.
+Statistics cover every matching run, not just the page shown.
+
+Runs recorded before runs were tied to tasks have no task, so --task leaves
+them out.
+
+Examples:
+  gur gate runs gate-abc123
+  gur gate runs gate-abc123 --result failed --since 7d
+  gur gate runs gate-abc123 --task gur-abc123 --limit 20 --page 2
+  gur gate runs gate-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGateRuns,
+}
+
+var (
+	gateRunsTask   string
+	gateRunsResult string
+	gateRunsSince  string
+	gateRunsPage   pageOptions
+)
+
+// gateRunSorts are the --sort keys for gate run history
+var gateRunSorts = map[string]string{
+	"created":  "created_at DESC, id DESC",
+	"oldest":   "created_at ASC, id ASC",
+	"duration": "duration DESC, created_at DESC, id DESC",
+}
+
+func init() {
+	gateCmd.AddCommand(gateRunsCmd)
+	gateRunsCmd.Flags().StringVar(&gateRunsTask, "task", "", "Only runs recorded for this task")
+	gateRunsCmd.Flags().StringVar(&gateRunsResult, "result", "", "Only runs with this result (passed/failed/skipped/requested/waived)")
+	gateRunsCmd.Flags().StringVar(&gateRunsSince, "since", "", "Only runs in this period (e.g., 24h, 7d, 2w)")
+	addPageFlags(gateRunsCmd, &gateRunsPage, gateRunSorts)
+}
+
+// gateRunStats summarizes a gate's runs
+type gateRunStats struct {
+	Runs        int     `json:"runs"`
+	Passed      int     `json:"passed"`
+	Failed      int     `json:"failed"`
+	Timed       int     `json:"timed"` // Runs with a recorded duration
+	MinDuration int     `json:"min_duration_ms,omitempty"`
+	AvgDuration int     `json:"avg_duration_ms,omitempty"`
+	MaxDuration int     `json:"max_duration_ms,omitempty"`
+	Flips       int     `json:"flips"`     // Passed/failed changes between consecutive runs
+	Flakiness   float64 `json:"flakiness"` // Flips per consecutive pair of passed/failed runs (0..1)
+}
+
+// computeGateRunStats summarizes runs given oldest first
+func computeGateRunStats(runs []models.GateRun) gateRunStats {
+	var s gateRunStats
+	var sum int
+	var last string
+	var outcomes int
+	for _, r := range runs {
+		s.Runs++
+		if r.Duration > 0 {
+			if s.Timed == 0 || r.Duration < s.MinDuration {
+				s.MinDuration = r.Duration
+			}
+			s.MaxDuration = max(s.MaxDuration, r.Duration)
+			sum += r.Duration
+			s.Timed++
+		}
+		if r.Result != models.GatePassed && r.Result != models.GateFailed {
+			continue
+		}
+		if r.Result == models.GatePassed {
+			s.Passed++
+		} else {
+			s.Failed++
+		}
+		if last != "" && r.Result != last {
+			s.Flips++
+		}
+		last = r.Result
+		outcomes++
+	}
+	if s.Timed > 0 {
+		s.AvgDuration = sum / s.Timed
+	}
+	if outcomes > 1 {
+		s.Flakiness = float64(s.Flips) / float64(outcomes-1)
+	}
+	return s
+}
+
+// formatRunDuration renders a run duration in milliseconds
+func formatRunDuration(ms int) string {
+	return (time.Duration(ms) * time.Millisecond).Round(time.Millisecond).String()
+}
+
+// gateRunsQuery returns the gate's runs matching the filters
+func gateRunsQuery(database *gorm.DB, gateID string, since time.Duration) *gorm.DB {
+	query := database.Model(&models.GateRun{}).Where("gate_id = ?", gateID)
+	if gateRunsTask != "" {
+		query = query.Where("task_id = ?", gateRunsTask)
+	}
+	if gateRunsResult != "" {
+		query = query.Where("result = ?", gateRunsResult)
+	}
+	if since > 0 {
+		query = query.Where("created_at >= ?", time.Now().Add(-since))
+	}
+	return query
+}
+
+func runGateRuns(cmd *cobra.Command, args []string) error {
+	gate, err := db.GetGateByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "gate '%s' not found (use 'gur gate list' to see available gates)", args[0])
+	}
+	if err := gateRunsPage.resolve(); err != nil {
+		return err
+	}
+	if err := gateRunsPage.validateFields(models.GateRun{}); err != nil {
+		return err
+	}
+	switch gateRunsResult {
+	case "", models.GatePassed, models.GateFailed, models.GateSkipped, models.GateLinkRequested, models.GateLinkWaived:
+	default:
+		return codedErrorf(ErrCodeUsage, "invalid result '%s': must be one of passed, failed, skipped, requested, waived", gateRunsResult)
+	}
+	var since time.Duration
+	if gateRunsSince != "" {
+		if since, err = parseDuration(gateRunsSince); err != nil {
+			return err
+		}
+	}
+	database := db.GetDB()
+
+	var all []models.GateRun
+	if err := gateRunsQuery(database, gate.ID, since).Select("id", "result", "duration", "created_at").
+		Order("created_at ASC, id ASC").Find(&all).Error; err != nil {
+		return fmt.Errorf("failed to load gate runs: database error: %w", err)
+	}
+	stats := computeGateRunStats(all)
+
+	query, total, err := gateRunsPage.paginate(gateRunsQuery(database, gate.ID, since), &models.GateRun{})
+	if err != nil {
+		return fmt.Errorf("failed to count gate runs: database error: %w", err)
+	}
+	var runs []models.GateRun
+	if err := query.Order(gateRunsPage.order(gateRunSorts["created"])).Find(&runs).Error; err != nil {
+		return fmt.Errorf("failed to load gate runs: database error: %w", err)
+	}
+
+	if len(gateRunsPage.fields) > 0 {
+		rows, err := gateRunsPage.project(runs)
+		if err != nil {
+			return err
+		}
+		if IsJSONOutput() {
+			OutputJSON(gateRunsPage.meta(map[string]interface{}{"gate_id": gate.ID, "count": len(rows), "runs": rows, "stats": stats}, total))
+		} else {
+			gateRunsPage.printProjected(rows)
+			gateRunsPage.printMoreHint(len(rows), total)
+		}
+		return nil
+	}
+	if IsJSONOutput() {
+		if runs == nil {
+			runs = []models.GateRun{}
+		}
+		OutputJSON(gateRunsPage.meta(map[string]interface{}{"gate_id": gate.ID, "count": len(runs), "runs": runs, "stats": stats}, total))
+		return nil
+	}
+	if IsQuietOutput() {
+		for _, r := range runs {
+			fmt.Println(r.ID)
+		}
+		return nil
+	}
+
+	fmt.Printf("%s: %s\n", gate.ID, gate.Title)
+	if len(runs) == 0 {
+		fmt.Println("No runs found.")
+		return nil
+	}
+	fmt.Printf("%-16s %-10s %-14s %-12s %-10s %s\n", "WHEN", "RESULT", "TASK", "BY", "DURATION", "NOTES")
+	for _, r := range runs {
+		task, duration := "-", "-"
+		if r.TaskID != "" {
+			task = r.TaskID
+		}
+		if r.Duration > 0 {
+			duration = formatRunDuration(r.Duration)
+		}
+		notes, _, _ := strings.Cut(r.Notes, "\n")
+		result := colors().Result(r.Result) + strings.Repeat(" ", max(0, 10-len(r.Result)))
+		fmt.Printf("%-16s %s %-14s %-12s %-10s %s\n", r.CreatedAt.Format(models.DateTimeShortFormat),
+			result, task, r.RunBy, duration, notes)
+	}
+	gateRunsPage.printMoreHint(len(runs), total)
+
+	fmt.Printf("\nStats: %d runs, %d passed, %d failed", stats.Runs, stats.Passed, stats.Failed)
+	if stats.Timed > 0 {
+		fmt.Printf("; duration min %s, avg %s, max %s", formatRunDuration(stats.MinDuration),
+			formatRunDuration(stats.AvgDuration), formatRunDuration(stats.MaxDuration))
+	}
+	fmt.Println()
+	fmt.Printf("Flakiness: %.2f (%d pass/fail flips)\n", stats.Flakiness, stats.Flips)
+	return nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestComputeGateRunStats(t *testing.T) {
+	runs := []models.GateRun{
+		{Result: models.GatePassed, Duration: 100},
+		{Result: models.GateFailed, Duration: 300},
+		{Result: models.GateSkipped},
+		{Result: models.GatePassed, Duration: 200},
+		{Result: models.GatePassed},
+	}
+	s := computeGateRunStats(runs)
+	if s.Runs != 5 || s.Passed != 3 || s.Failed != 1 {
+		t.Errorf("counts = %d runs, %d passed, %d failed, want 5, 3, 1", s.Runs, s.Passed, s.Failed)
+	}
+	if s.Timed != 3 || s.MinDuration != 100 || s.AvgDuration != 200 || s.MaxDuration != 300 {
+		t.Errorf("durations = %+v, want min 100, avg 200, max 300 over 3 timed runs", s)
+	}
+	// passed, failed, passed, passed: 2 flips over 3 consecutive pairs
+	if s.Flips != 2 || s.Flakiness < 0.66 || s.Flakiness > 0.67 {
+		t.Errorf("flakiness = %d flips, %.2f, want 2 flips, 0.67", s.Flips, s.Flakiness)
+	}
+	if s := computeGateRunStats([]models.GateRun{{Result: models.GateFailed}}); s.Flakiness != 0 {
+		t.Errorf("flakiness of a single run = %.2f, want 0", s.Flakiness)
+	}
+}
+
+func TestGateRunsFilters(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-9a000001", Title: "Login", Status: models.StatusOpen})
+	gate := &models.Gate{ID: "gate-9a000001", Title: "Unit tests", Type: "test", Approvers: []string{"ci"}}
+	database.Create(gate)
+	link := &models.GateTaskLink{GateID: gate.ID, TaskID: "gur-9a000001"}
+	database.Create(link)
+
+	saved, err := saveGateResult(database, gate, link, models.GateFailed, "ci", "", models.GateRun{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.TaskID != "gur-9a000001" {
+		t.Errorf("saved run task = %q, want the link's task", saved.TaskID)
+	}
+	database.Create(&models.GateRun{GateID: gate.ID, Result: models.GatePassed, RunBy: "ci", CreatedAt: time.Now().Add(-10 * 24 * time.Hour)})
+
+	defer func() { gateRunsTask, gateRunsResult = "", "" }()
+	count := func(since time.Duration) int64 {
+		var n int64
+		gateRunsQuery(database, gate.ID, since).Count(&n)
+		return n
+	}
+	if n := count(0); n != 2 {
+		t.Errorf("unfiltered runs = %d, want 2", n)
+	}
+	if n := count(7 * 24 * time.Hour); n != 1 {
+		t.Errorf("runs in the last 7 days = %d, want 1", n)
+	}
+	gateRunsTask = "gur-9a000001"
+	if n := count(0); n != 1 {
+		t.Errorf("runs for the task = %d, want 1 (the older run has no task)", n)
+	}
+	gateRunsTask, gateRunsResult = "", models.GatePassed
+	if n := count(0); n != 1 {
+		t.Errorf("passed runs = %d, want 1", n)
+	}
+}
@@ -1,15 +1,17 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"guardrails/internal/db"
 	"guardrails/internal/models"
 )
 
-func setupTestDB(t *testing.T) func() {
+func setupTestDB(t testing.TB) func() {
 	t.Helper()
 
 	tmpDir, err := os.MkdirTemp("", "gur-cmd-test-*")
@@ -158,3 +160,255 @@ func TestGetFailingGateLinksForTask(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckGatesBeforeClosePriorityPolicy(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+
+	task := &models.Task{ID: "gur-policy01", Title: "Critical Task", Status: models.StatusOpen, Priority: models.PriorityCritical}
+	database.Create(task)
+	testGate := &models.Gate{ID: "gate-policy01", Title: "Unit tests", Type: "test"}
+	reviewGate := &models.Gate{ID: "gate-policy02", Title: "Code review", Type: "review"}
+	database.Create(testGate)
+	database.Create(reviewGate)
+	database.Create(&models.GateTaskLink{GateID: testGate.ID, TaskID: task.ID, Status: models.GateLinkPassed})
+
+	// No policy: one passed gate is enough
+	if err := CheckGatesBeforeClose(task.ID); err != nil {
+		t.Fatalf("CheckGatesBeforeClose() without policy should pass, got: %v", err)
+	}
+
+	if err := db.SetConfig(models.PolicyRequiredGatesKey(models.PriorityCritical), "review,test"); err != nil {
+		t.Fatalf("SetConfig() error: %v", err)
+	}
+
+	// Policy requires a review gate that was never linked
+	err := CheckGatesBeforeClose(task.ID)
+	if err == nil || !strings.Contains(err.Error(), "review") || strings.Contains(err.Error(), "- test") {
+		t.Errorf("CheckGatesBeforeClose() should report only the missing review gate, got: %v", err)
+	}
+
+	// Linked but not passed: falls through to the normal verification check
+	link := &models.GateTaskLink{GateID: reviewGate.ID, TaskID: task.ID, Status: models.GateLinkPending}
+	database.Create(link)
+	if err := CheckGatesBeforeClose(task.ID); err == nil || !strings.Contains(err.Error(), "not verified") {
+		t.Errorf("CheckGatesBeforeClose() with pending policy gate should fail verification, got: %v", err)
+	}
+
+	link.Status = models.GateLinkPassed
+	database.Save(link)
+	if err := CheckGatesBeforeClose(task.ID); err != nil {
+		t.Errorf("CheckGatesBeforeClose() with all policy gates passed should pass, got: %v", err)
+	}
+
+	// Policy for another priority doesn't apply
+	lowTask := &models.Task{ID: "gur-policy02", Title: "Low Task", Status: models.StatusOpen, Priority: models.PriorityLow}
+	database.Create(lowTask)
+	database.Create(&models.GateTaskLink{GateID: testGate.ID, TaskID: lowTask.ID, Status: models.GateLinkPassed})
+	if err := CheckGatesBeforeClose(lowTask.ID); err != nil {
+		t.Errorf("CheckGatesBeforeClose() for P3 task should ignore P0 policy, got: %v", err)
+	}
+}
+
+func TestCheckGatesBeforeCloseArtifactPolicy(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	bug := &models.Task{ID: "gur-policy11", Title: "Crash on save", Status: models.StatusOpen, Type: models.TypeBug}
+	chore := &models.Task{ID: "gur-policy12", Title: "Tidy docs", Status: models.StatusOpen, Type: models.TypeTask}
+	database.Create(bug)
+	database.Create(chore)
+	database.Create(&models.Gate{ID: "gate-policy11", Title: "Unit tests", Type: "test"})
+	for _, id := range []string{bug.ID, chore.ID} {
+		database.Create(&models.GateTaskLink{GateID: "gate-policy11", TaskID: id, Status: models.GateLinkPassed})
+	}
+
+	if err := db.SetConfig(models.ConfigPolicyRequireArtifacts, "bug,feature"); err != nil {
+		t.Fatalf("SetConfig() error: %v", err)
+	}
+	if err := CheckGatesBeforeClose(bug.ID); errorCodeOf(err) != ErrCodeWorkMissing {
+		t.Errorf("CheckGatesBeforeClose() for a bug without linked work = %v, want %s", err, ErrCodeWorkMissing)
+	}
+	if err := CheckGatesBeforeClose(chore.ID); err != nil {
+		t.Errorf("CheckGatesBeforeClose() for a task type without the rule should pass, got: %v", err)
+	}
+
+	// An issue link isn't the work; a pull request URL is
+	bug.Notes = "Reported in https://github.com/acme/app/issues/7"
+	database.Save(bug)
+	if err := CheckGatesBeforeClose(bug.ID); errorCodeOf(err) != ErrCodeWorkMissing {
+		t.Errorf("CheckGatesBeforeClose() with only an issue URL = %v, want %s", err, ErrCodeWorkMissing)
+	}
+	bug.Notes += "\nFixed in https://github.com/acme/app/pull/12"
+	database.Save(bug)
+	if err := CheckGatesBeforeClose(bug.ID); err != nil {
+		t.Errorf("CheckGatesBeforeClose() with a PR URL should pass, got: %v", err)
+	}
+
+	feature := &models.Task{ID: "gur-policy13", Title: "Export", Status: models.StatusOpen, Type: models.TypeFeature}
+	database.Create(feature)
+	database.Create(&models.GateTaskLink{GateID: "gate-policy11", TaskID: feature.ID, Status: models.GateLinkPassed})
+	database.Create(&models.Artifact{TaskID: feature.ID, Kind: models.ArtifactKindPatch, Content: "diff"})
+	if err := CheckGatesBeforeClose(feature.ID); err != nil {
+		t.Errorf("CheckGatesBeforeClose() with an artifact should pass, got: %v", err)
+	}
+}
+
+func TestGetGateLinksForTaskJoinsGates(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-joingate", Title: "Join", Status: models.StatusOpen}
+	database.Create(task)
+	kept := &models.Gate{ID: "gate-join0001", Title: "Kept", Type: "review", Approvers: models.StringSlice{"alice", "bob"}}
+	deleted := &models.Gate{ID: "gate-join0002", Title: "Deleted", Type: "test"}
+	database.Create(kept)
+	database.Create(deleted)
+	database.Create(&models.GateTaskLink{GateID: kept.ID, TaskID: task.ID, Status: models.GateLinkPassed, VerifiedBy: "alice"})
+	database.Create(&models.GateTaskLink{GateID: deleted.ID, TaskID: task.ID, Status: models.GateLinkPending})
+	database.Delete(deleted)
+
+	links, err := GetGateLinksForTask(task.ID)
+	if err != nil {
+		t.Fatalf("GetGateLinksForTask() error: %v", err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("GetGateLinksForTask() returned %d links, want 1 (deleted gates excluded)", len(links))
+	}
+	got := links[0]
+	if got.Gate.ID != kept.ID || got.Gate.Title != "Kept" || got.Gate.Type != "review" {
+		t.Errorf("Gate = %s %q %s, want the joined gate", got.Gate.ID, got.Gate.Title, got.Gate.Type)
+	}
+	if len(got.Gate.Approvers) != 2 || got.Gate.Approvers[1] != "bob" {
+		t.Errorf("Gate.Approvers = %v, want [alice bob]", got.Gate.Approvers)
+	}
+	if got.Link.GateID != kept.ID || got.Status != models.GateLinkPassed || got.Link.VerifiedBy != "alice" {
+		t.Errorf("Link = %+v, want the passed link", got.Link)
+	}
+}
+
+// BenchmarkCheckGatesBeforeClose measures the close check on a database
+// with thousands of gate links
+func BenchmarkCheckGatesBeforeClose(b *testing.B) {
+	cleanup := setupTestDB(b)
+	defer cleanup()
+
+	database := db.GetDB()
+	const tasks, gatesPerTask = 500, 10
+	gates := make([]models.Gate, gatesPerTask)
+	for i := range gates {
+		gates[i] = models.Gate{ID: fmt.Sprintf("gate-%08x", i), Title: fmt.Sprintf("Gate %d", i), Type: "test"}
+	}
+	if err := database.Create(&gates).Error; err != nil {
+		b.Fatalf("Failed to create gates: %v", err)
+	}
+	var links []models.GateTaskLink
+	for i := 0; i < tasks; i++ {
+		id := fmt.Sprintf("gur-%08x", i)
+		if err := database.Create(&models.Task{ID: id, Title: id, Status: models.StatusOpen}).Error; err != nil {
+			b.Fatalf("Failed to create task: %v", err)
+		}
+		for _, g := range gates {
+			links = append(links, models.GateTaskLink{GateID: g.ID, TaskID: id, Status: models.GateLinkPassed})
+		}
+	}
+	if err := database.CreateInBatches(&links, 500).Error; err != nil {
+		b.Fatalf("Failed to create links: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := CheckGatesBeforeClose(fmt.Sprintf("gur-%08x", i%tasks)); err != nil {
+			b.Fatalf("CheckGatesBeforeClose() error: %v", err)
+		}
+	}
+}
+
+func TestGateApprovalFlow(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	isTerminal := stdinIsTerminal
+	defer func() {
+		stdinIsTerminal = isTerminal
+		confirmInput = os.Stdin
+		gateRunBy, gateNotes, gateApproversClear = "human", "", false
+	}()
+	interactive := func(answer string) {
+		stdinIsTerminal = func() bool { return true }
+		confirmInput = strings.NewReader(answer + "\n")
+	}
+	stdinIsTerminal = func() bool { return false }
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-aprv0001", Title: "Release", Status: models.StatusOpen})
+	database.Create(&models.Gate{ID: "gate-aprv0001", Title: "PM sign-off", Type: "approval", Approvers: models.StringSlice{"alice"}})
+	database.Create(&models.GateTaskLink{GateID: "gate-aprv0001", TaskID: "gur-aprv0001", Status: models.GateLinkPending})
+	linkStatus := func() string {
+		var link models.GateTaskLink
+		database.Where("gate_id = ? AND task_id = ?", "gate-aprv0001", "gur-aprv0001").First(&link)
+		return link.Status
+	}
+
+	// Any pass, even one claiming to be by an approver, is only a request
+	for _, by := range []string{"agent", "alice"} {
+		gateRunBy = by
+		if err := runGateResult("gate-aprv0001", "gur-aprv0001", models.GateLinkPassed, ""); err != nil {
+			t.Fatalf("gate pass --by %s: %v", by, err)
+		}
+		if s := linkStatus(); s != models.GateLinkRequested {
+			t.Fatalf("gate pass --by %s stored %s, want requested", by, s)
+		}
+	}
+
+	// Approving needs a terminal, an approver and a typed yes
+	args := []string{"gate-aprv0001", "gur-aprv0001"}
+	gateRunBy = "alice"
+	if err := runGateApprove(gateApproveCmd, args); err == nil || !strings.Contains(err.Error(), "interactive") {
+		t.Errorf("approve without a terminal = %v, want refused", err)
+	}
+	interactive("yes")
+	gateRunBy = "agent"
+	if err := runGateApprove(gateApproveCmd, args); err == nil || !strings.Contains(err.Error(), "not an approver") {
+		t.Errorf("approve by a non-approver = %v, want refused", err)
+	}
+	interactive("no")
+	gateRunBy = "alice"
+	if err := runGateApprove(gateApproveCmd, args); err == nil {
+		t.Error("approve without confirming was accepted")
+	}
+	if s := linkStatus(); s != models.GateLinkRequested {
+		t.Fatalf("refused approvals stored %s, want requested", s)
+	}
+	interactive("yes")
+	if err := runGateApprove(gateApproveCmd, args); err != nil {
+		t.Fatalf("approve by alice: %v", err)
+	}
+	if s := linkStatus(); s != models.GateLinkPassed {
+		t.Errorf("approved link = %s, want passed", s)
+	}
+	if gateApproved {
+		t.Error("approval confirmation outlived the command")
+	}
+
+	// Changing the approvers needs a terminal too
+	stdinIsTerminal = func() bool { return false }
+	if err := runGateApprovers(gateApproversCmd, []string{"gate-aprv0001", "agent"}); err == nil {
+		t.Error("approvers changed without a terminal")
+	}
+	gateApproversClear = true
+	if err := runGateApprovers(gateApproversCmd, []string{"gate-aprv0001"}); err == nil {
+		t.Error("approvers cleared without a terminal")
+	}
+	gate, _ := db.GetGateByID("gate-aprv0001")
+	if len(gate.Approvers) != 1 || gate.Approvers[0] != "alice" {
+		t.Errorf("approvers = %v, want [alice]", gate.Approvers)
+	}
+	interactive("yes")
+	if err := runGateApprovers(gateApproversCmd, []string{"gate-aprv0001"}); err != nil {
+		t.Fatalf("clearing approvers at a terminal: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var (
+	gateWaiveReason  string
+	gateWaiveBy      string
+	gateWaiveExpires string
+	gateWaiversAll   bool
+)
+
+var gateWaiveCmd = &cobra.Command{
+	Use:   "waive <gate-id> <task-id>",
+	Short: "Waive a gate for a task so it no longer blocks close",
+	Long: `Formally waive a gate for a specific task.
+
+Unlike 'gur gate skip', a waiver satisfies the gate when closing the task.
+A reason is required, and waivers can expire: once expired, the gate
+blocks close again until it is passed or waived anew. Waivers are recorded
+in the gate's run history and the task's history (see 'gur history').
+
+Gates with designated approvers can only be waived by an approver, who
+must confirm in an interactive terminal.
+
+Examples:
+  gur gate waive gate-abc123 gur-def456 --reason "covered by e2e"
+  gur gate waive gate-abc123 gur-def456 --reason "covered by e2e" --by alice --expires 30d`,
+	Args: cobra.ExactArgs(2),
+	RunE: runGateWaive,
+}
+
+var gateWaiversCmd = &cobra.Command{
+	Use:   "waivers",
+	Short: "List active gate waivers",
+	Long: `List gate waivers with their reason, who granted them, and when they expire.
+
+Examples:
+  gur gate waivers
+  gur gate waivers --all    # Include expired waivers`,
+	Args: cobra.NoArgs,
+	RunE: runGateWaivers,
+}
+
+func init() {
+	gateCmd.AddCommand(gateWaiveCmd)
+	gateCmd.AddCommand(gateWaiversCmd)
+	gateWaiveCmd.Flags().StringVar(&gateWaiveReason, "reason", "", "Why the gate is waived (required)")
+	gateWaiveCmd.Flags().StringVar(&gateWaiveBy, "by", "human", "Who granted the waiver (human/agent/name)")
+	gateWaiveCmd.Flags().StringVar(&gateWaiveExpires, "expires", "", "Expire the waiver after a duration (e.g., 30d, 2w, 24h)")
+	gateWaiveCmd.MarkFlagRequired("reason")
+	gateWaiversCmd.Flags().BoolVar(&gateWaiversAll, "all", false, "Include expired waivers")
+}
+
+// waiveGate waives the gate for the task, recording the waiver in the gate's
+// run history and the task's history. expires is zero for no expiry.
+func waiveGate(database *gorm.DB, gate *models.Gate, task *models.Task, reason, by string, expires time.Duration) (*models.GateTaskLink, error) {
+	var link models.GateTaskLink
+	if err := database.Where("gate_id = ? AND task_id = ?", gate.ID, task.ID).First(&link).Error; err != nil {
+		return nil, fmt.Errorf("cannot waive gate: gate '%s' is not linked to task '%s'\nLink it first: gur gate link %s %s", gate.ID, task.ID, gate.ID, task.ID)
+	}
+	if !gate.IsApprover(by) {
+		return nil, fmt.Errorf("cannot waive gate: '%s' is not an approver for gate '%s' (approvers: %s)", by, gate.ID, strings.Join(gate.Approvers, ", "))
+	}
+
+	now := time.Now()
+	oldStatus := link.Status
+	link.Status = models.GateLinkWaived
+	link.VerifiedAt = &now
+	link.VerifiedBy = by
+	link.Notes = reason
+	link.ExpiresAt = nil
+	if expires > 0 {
+		expiresAt := now.Add(expires)
+		link.ExpiresAt = &expiresAt
+	}
+
+	err := database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&link).Error; err != nil {
+			return fmt.Errorf("failed to update gate link: %w", err)
+		}
+		run := models.GateRun{GateID: gate.ID, TaskID: task.ID, Result: models.GateLinkWaived, RunBy: by, Notes: reason}
+		if err := tx.Create(&run).Error; err != nil {
+			return fmt.Errorf("failed to save gate run history: %w", err)
+		}
+		// The old value keeps the prior link status so undo can restore it
+		return models.RecordChange(tx, task.ID, "gate_waived", oldStatus, gate.ID, by)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func runGateWaive(cmd *cobra.Command, args []string) error {
+	gateID, taskID := args[0], args[1]
+	if strings.TrimSpace(gateWaiveReason) == "" {
+		return fmt.Errorf("cannot waive gate: --reason must not be empty")
+	}
+	var expires time.Duration
+	if gateWaiveExpires != "" {
+		d, err := parseDuration(gateWaiveExpires)
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return fmt.Errorf("invalid --expires '%s': must be positive", gateWaiveExpires)
+		}
+		expires = d
+	}
+
+	gate, err := db.GetGateByID(gateID)
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot waive gate: gate '%s' not found (use 'gur gate list' to see available gates)", gateID)
+	}
+	task, err := db.GetTaskByID(taskID)
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot waive gate: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+	}
+
+	if gate.RequiresApproval() && gate.IsApprover(gateWaiveBy) {
+		if err := confirmAsHuman(fmt.Sprintf("waive %s for %s as %s", gate.ID, task.ID, gateWaiveBy)); err != nil {
+			return err
+		}
+	}
+	link, err := waiveGate(db.GetDB(), gate, task, gateWaiveReason, gateWaiveBy, expires)
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "gate": gate, "task": task, "link": link})
+	} else {
+		fmt.Printf("Waived: %s for task %s (by %s)\n", gate.Title, task.ID, gateWaiveBy)
+		fmt.Printf("  Reason: %s\n", gateWaiveReason)
+		if link.ExpiresAt != nil {
+			fmt.Printf("  Expires: %s\n", link.ExpiresAt.Format(models.DateTimeShortFormat))
+		}
+	}
+	return nil
+}
+
+// waiverInfo describes a waived gate link for reports
+type waiverInfo struct {
+	GateID    string     `json:"gate_id"`
+	GateTitle string     `json:"gate_title"`
+	TaskID    string     `json:"task_id"`
+	TaskTitle string     `json:"task_title"`
+	Reason    string     `json:"reason"`
+	WaivedBy  string     `json:"waived_by"`
+	WaivedAt  *time.Time `json:"waived_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Expired   bool       `json:"expired"`
+}
+
+// listWaivers returns waived gate links, soonest to expire first; expired
+// waivers are included only if all is set
+func listWaivers(database *gorm.DB, all bool, now time.Time) ([]waiverInfo, error) {
+	var rows []struct {
+		models.GateTaskLink
+		GateTitle string
+		TaskTitle string
+	}
+	if err := database.Model(&models.GateTaskLink{}).
+		Select("gate_task_links.*, gates.title AS gate_title, tasks.title AS task_title").
+		Joins("JOIN gates ON gates.id = gate_task_links.gate_id AND gates.deleted_at IS NULL").
+		Joins("JOIN tasks ON tasks.id = gate_task_links.task_id AND tasks.deleted_at IS NULL").
+		Where("gate_task_links.status = ?", models.GateLinkWaived).
+		Order("gate_task_links.expires_at IS NULL, gate_task_links.expires_at ASC, gate_task_links.id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var waivers []waiverInfo
+	for _, r := range rows {
+		expired := r.WaiverExpired(now)
+		if expired && !all {
+			continue
+		}
+		waivers = append(waivers, waiverInfo{
+			GateID:    r.GateID,
+			GateTitle: r.GateTitle,
+			TaskID:    r.TaskID,
+			TaskTitle: r.TaskTitle,
+			Reason:    r.Notes,
+			WaivedBy:  r.VerifiedBy,
+			WaivedAt:  r.VerifiedAt,
+			ExpiresAt: r.ExpiresAt,
+			Expired:   expired,
+		})
+	}
+	return waivers, nil
+}
+
+func runGateWaivers(cmd *cobra.Command, args []string) error {
+	waivers, err := listWaivers(db.GetDB(), gateWaiversAll, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to list waivers: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"waivers": waivers, "count": len(waivers)})
+		return nil
+	}
+
+	if len(waivers) == 0 {
+		fmt.Println("No gate waivers")
+		return nil
+	}
+	for _, w := range waivers {
+		fmt.Printf("%s  %s - %s\n", w.TaskID, w.GateID, w.GateTitle)
+		fmt.Printf("  Reason: %s (by %s)\n", w.Reason, w.WaivedBy)
+		switch {
+		case w.Expired:
+			fmt.Printf("  Expired: %s\n", w.ExpiresAt.Format(models.DateTimeShortFormat))
+		case w.ExpiresAt != nil:
+			fmt.Printf("  Expires: %s\n", w.ExpiresAt.Format(models.DateTimeShortFormat))
+		default:
+			fmt.Println("  Expires: never")
+		}
+	}
+	return nil
+}
+
+// waiverSummary describes a waived link, e.g. "waived by alice: covered by
+// e2e, expires 2026-01-02 15:04"
+func waiverSummary(link models.GateTaskLink) string {
+	s := "waived"
+	if link.VerifiedBy != "" {
+		s += " by " + link.VerifiedBy
+	}
+	if link.Notes != "" {
+		s += ": " + link.Notes
+	}
+	if link.ExpiresAt != nil {
+		verb := "expires"
+		if link.WaiverExpired(time.Now()) {
+			verb = "expired"
+		}
+		s += fmt.Sprintf(", %s %s", verb, link.ExpiresAt.Format(models.DateTimeShortFormat))
+	}
+	return s
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestWaiveGateSatisfiesClose(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-waive001", Title: "Waived task", Status: models.StatusOpen}
+	gate := &models.Gate{ID: "gate-waive001", Title: "E2E", Type: "test"}
+	database.Create(task)
+	database.Create(gate)
+	database.Create(&models.GateTaskLink{GateID: gate.ID, TaskID: task.ID, Status: models.GateLinkFailed})
+
+	if err := CheckGatesBeforeClose(task.ID); err == nil {
+		t.Fatal("CheckGatesBeforeClose() with failed link should fail")
+	}
+
+	link, err := waiveGate(database, gate, task, "covered by e2e", "alice", 0)
+	if err != nil {
+		t.Fatalf("waiveGate() error: %v", err)
+	}
+	if link.Status != models.GateLinkWaived || link.ExpiresAt != nil {
+		t.Errorf("link = %s (expires %v), want waived without expiry", link.Status, link.ExpiresAt)
+	}
+	if err := CheckGatesBeforeClose(task.ID); err != nil {
+		t.Errorf("CheckGatesBeforeClose() with waived link should pass, got: %v", err)
+	}
+
+	var history models.TaskHistory
+	if err := database.Where("task_id = ? AND field = ?", task.ID, "gate_waived").First(&history).Error; err != nil {
+		t.Fatalf("waiver not recorded in task history: %v", err)
+	}
+	if history.OldValue != models.GateLinkFailed || history.NewValue != gate.ID || history.ChangedBy != "alice" {
+		t.Errorf("history = %+v, want failed -> %s by alice", history, gate.ID)
+	}
+	var run models.GateRun
+	if err := database.Where("gate_id = ? AND result = ?", gate.ID, models.GateLinkWaived).First(&run).Error; err != nil {
+		t.Fatalf("waiver not recorded in gate runs: %v", err)
+	}
+	if run.Notes != "covered by e2e" {
+		t.Errorf("run notes = %q, want the reason", run.Notes)
+	}
+
+	if err := revertGateWaiver(database, task.ID, history); err != nil {
+		t.Fatalf("revertGateWaiver() error: %v", err)
+	}
+	var reverted models.GateTaskLink
+	database.Where("task_id = ? AND gate_id = ?", task.ID, gate.ID).First(&reverted)
+	if reverted.Status != models.GateLinkFailed {
+		t.Errorf("status after undo = %s, want failed", reverted.Status)
+	}
+}
+
+func TestWaiveGateExpiry(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-waive002", Title: "Expiring", Status: models.StatusOpen}
+	gate := &models.Gate{ID: "gate-waive002", Title: "Review", Type: "review"}
+	database.Create(task)
+	database.Create(gate)
+	database.Create(&models.GateTaskLink{GateID: gate.ID, TaskID: task.ID, Status: models.GateLinkPending})
+
+	if _, err := waiveGate(database, gate, task, "reviewed offline", "human", 24*time.Hour); err != nil {
+		t.Fatalf("waiveGate() error: %v", err)
+	}
+	if err := CheckGatesBeforeClose(task.ID); err != nil {
+		t.Errorf("CheckGatesBeforeClose() with unexpired waiver should pass, got: %v", err)
+	}
+	waivers, err := listWaivers(database, false, time.Now())
+	if err != nil || len(waivers) != 1 {
+		t.Fatalf("listWaivers() = %v, %v; want 1 active waiver", waivers, err)
+	}
+
+	// Expire the waiver
+	past := time.Now().Add(-time.Hour)
+	database.Model(&models.GateTaskLink{}).Where("task_id = ?", task.ID).Update("expires_at", past)
+
+	err = CheckGatesBeforeClose(task.ID)
+	if err == nil {
+		t.Fatal("CheckGatesBeforeClose() with expired waiver should fail")
+	}
+	if !strings.Contains(err.Error(), "waiver expired") {
+		t.Errorf("error should mention the expired waiver, got: %v", err)
+	}
+	if waivers, _ := listWaivers(database, false, time.Now()); len(waivers) != 0 {
+		t.Errorf("listWaivers() returned %d active waivers, want 0", len(waivers))
+	}
+	if waivers, _ := listWaivers(database, true, time.Now()); len(waivers) != 1 || !waivers[0].Expired {
+		t.Errorf("listWaivers(all) = %+v, want 1 expired waiver", waivers)
+	}
+}
+
+func TestWaiveGateRequiresApprover(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-waive003", Title: "Guarded", Status: models.StatusOpen}
+	gate := &models.Gate{ID: "gate-waive003", Title: "PM sign-off", Type: "approval", Approvers: []string{"alice"}}
+	database.Create(task)
+	database.Create(gate)
+	database.Create(&models.GateTaskLink{GateID: gate.ID, TaskID: task.ID, Status: models.GateLinkPending})
+
+	if _, err := waiveGate(database, gate, task, "not needed", "agent", 0); err == nil {
+		t.Error("waiveGate() by a non-approver should fail")
+	}
+	if _, err := waiveGate(database, gate, task, "not needed", "Alice", 0); err != nil {
+		t.Errorf("waiveGate() by an approver should succeed, got: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// newGitHubClient builds an authenticated client for github.com, or for the
+// GitHub Enterprise Server set with 'gur config github --base-url'
+func newGitHubClient(token string) (*github.Client, error) {
+	// Connection pooling for the many requests a sync makes
+	httpClient := &http.Client{
+		Timeout: githubAPITimeout,
+		Transport: perfTransport{&http.Transport{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 10,
+			IdleConnTimeout:     90 * time.Second,
+		}},
+	}
+	client := github.NewClient(httpClient).WithAuthToken(token)
+
+	baseURL, _ := db.GetConfig(models.ConfigGitHubBaseURL)
+	if baseURL == "" {
+		return client, nil
+	}
+	uploadURL, _ := db.GetConfig(models.ConfigGitHubUploadURL)
+	if uploadURL == "" {
+		uploadURL = enterpriseUploadURL(baseURL)
+	}
+	client, err := client.WithEnterpriseURLs(baseURL, uploadURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub Enterprise URL '%s': %w (run 'gur config github --base-url' to fix)", baseURL, err)
+	}
+	return client, nil
+}
+
+// normalizeEnterpriseURL validates a GitHub Enterprise API URL and strips
+// any trailing slash
+func normalizeEnterpriseURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("invalid URL '%s': expected e.g. https://github.mycorp.com/api/v3", raw)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// enterpriseUploadURL derives the upload endpoint from an API base URL, since
+// GitHub Enterprise Server serves uploads from /api/uploads on the same host
+func enterpriseUploadURL(baseURL string) string {
+	return strings.TrimSuffix(strings.TrimRight(baseURL, "/"), "/api/v3") + "/api/uploads/"
+}
+
+// checkGitHubToken confirms the token authenticates against the configured
+// server and returns the login it belongs to. Tokens are issued per server,
+// so a github.com token fails against an Enterprise host and vice versa.
+func checkGitHubToken(ctx context.Context, client *github.Client) (string, error) {
+	user, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return "", fmt.Errorf("token rejected by %s: create a token on that server and run 'gur config github --token'", client.BaseURL.Host)
+		}
+		return "", fmt.Errorf("cannot reach %s: %w", client.BaseURL, err)
+	}
+	return user.GetLogin(), nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestEnterpriseURLs(t *testing.T) {
+	tests := []struct {
+		raw, base, upload string
+	}{
+		{"https://github.mycorp.com/api/v3", "https://github.mycorp.com/api/v3", "https://github.mycorp.com/api/uploads/"},
+		{"https://github.mycorp.com/api/v3/", "https://github.mycorp.com/api/v3", "https://github.mycorp.com/api/uploads/"},
+		{"https://github.mycorp.com", "https://github.mycorp.com", "https://github.mycorp.com/api/uploads/"},
+	}
+	for _, tt := range tests {
+		base, err := normalizeEnterpriseURL(tt.raw)
+		if err != nil {
+			t.Errorf("normalizeEnterpriseURL(%q): %v", tt.raw, err)
+			continue
+		}
+		if base != tt.base {
+			t.Errorf("normalizeEnterpriseURL(%q) = %q, want %q", tt.raw, base, tt.base)
+		}
+		if upload := enterpriseUploadURL(base); upload != tt.upload {
+			t.Errorf("enterpriseUploadURL(%q) = %q, want %q", base, upload, tt.upload)
+		}
+	}
+
+	for _, bad := range []string{"github.mycorp.com", "ftp://github.mycorp.com", "https://"} {
+		if _, err := normalizeEnterpriseURL(bad); err == nil {
+			t.Errorf("normalizeEnterpriseURL(%q) should fail", bad)
+		}
+	}
+}
+
+func TestNewGitHubClientEnterprise(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	client, err := newGitHubClient("token")
+	if err != nil {
+		t.Fatalf("newGitHubClient: %v", err)
+	}
+	if client.BaseURL.Host != "api.github.com" {
+		t.Errorf("default base URL = %s, want api.github.com", client.BaseURL)
+	}
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/user" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		if gotAuth != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Bad credentials"}`)
+			return
+		}
+		fmt.Fprint(w, `{"login":"octocat"}`)
+	}))
+	defer server.Close()
+
+	if err := setGitHubServer(server.URL+"/api/v3/", ""); err != nil {
+		t.Fatalf("setGitHubServer: %v", err)
+	}
+	if base, _ := db.GetConfig(models.ConfigGitHubBaseURL); base != server.URL+"/api/v3" {
+		t.Errorf("saved base URL = %q", base)
+	}
+
+	client, err = newGitHubClient("good")
+	if err != nil {
+		t.Fatalf("newGitHubClient: %v", err)
+	}
+	if client.UploadURL.String() != server.URL+"/api/uploads/" {
+		t.Errorf("upload URL = %s", client.UploadURL)
+	}
+	login, err := checkGitHubToken(context.Background(), client)
+	if err != nil || login != "octocat" {
+		t.Errorf("checkGitHubToken = %q, %v; want octocat", login, err)
+	}
+
+	client, _ = newGitHubClient("bad")
+	if _, err := checkGitHubToken(context.Background(), client); err == nil {
+		t.Error("expected rejected token to fail")
+	}
+
+	// Switching back to github.com removes the enterprise URLs
+	if err := setGitHubServer("github.com", ""); err != nil {
+		t.Fatalf("setGitHubServer: %v", err)
+	}
+	if base, _ := db.GetConfig(models.ConfigGitHubBaseURL); base != "" {
+		t.Errorf("base URL should be cleared, got %q", base)
+	}
+}
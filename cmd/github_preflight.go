@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/models"
+)
+
+// Approximate API calls per item, used to check the rate-limit budget
+// before a sync starts rather than running out halfway through
+const (
+	pushCallsPerTask  = 5 // Read, create/edit issue, labels, state
+	pullCallsPerIssue = 2 // Comment scan, sync marker
+
+	hierarchyCallsPerTask = 2 // Issue type, sub-issue
+)
+
+// githubPreflight is the result of checking the token and repository
+type githubPreflight struct {
+	Server         string    `json:"server"`
+	Login          string    `json:"login"`
+	TokenType      string    `json:"token_type"`       // classic or fine-grained
+	Scopes         []string  `json:"scopes,omitempty"` // Classic tokens only
+	Repository     string    `json:"repository"`
+	CanWriteIssues bool      `json:"can_write_issues"`
+	RateLimit      int       `json:"rate_limit"`
+	RateRemaining  int       `json:"rate_remaining"`
+	RateReset      time.Time `json:"rate_reset"`
+}
+
+// runGitHubPreflight verifies the token authenticates, can write issues on
+// owner/repo, and reports the remaining rate-limit budget
+func runGitHubPreflight(ctx context.Context, client *github.Client, owner, repo string) (*githubPreflight, error) {
+	p := &githubPreflight{
+		Server:     client.BaseURL.Host,
+		Repository: owner + "/" + repo,
+		TokenType:  "fine-grained",
+	}
+
+	user, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return nil, fmt.Errorf("token rejected by %s (expired or revoked?): create a new token and run 'gur config github --token'", p.Server)
+		}
+		return nil, fmt.Errorf("cannot reach %s: %w", client.BaseURL, err)
+	}
+	p.Login = user.GetLogin()
+	p.RateLimit = resp.Rate.Limit
+	p.RateRemaining = resp.Rate.Remaining
+	p.RateReset = resp.Rate.Reset.Time
+
+	// Classic tokens report their scopes; fine-grained tokens send no header
+	if header, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		p.TokenType = "classic"
+		for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				p.Scopes = append(p.Scopes, scope)
+			}
+		}
+	}
+
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, codedErrorf(ErrCodeNotFound, "repository %s not found or not visible to @%s: check 'gur config github --repo' and the token's repository access", p.Repository, p.Login)
+		}
+		return nil, fmt.Errorf("failed to read repository %s: %w", p.Repository, err)
+	}
+	if !repository.GetHasIssues() {
+		return nil, fmt.Errorf("issues are disabled on %s: enable them in the repository settings", p.Repository)
+	}
+
+	if p.TokenType == "classic" && !hasScope(p.Scopes, "repo") && !(hasScope(p.Scopes, "public_repo") && !repository.GetPrivate()) {
+		return nil, fmt.Errorf("token is missing the 'repo' scope (has: %s): regenerate it with 'repo' and run 'gur config github --token'", scopeList(p.Scopes))
+	}
+
+	// Labels, state changes and edits need triage access or better
+	perms := repository.GetPermissions()
+	p.CanWriteIssues = perms["admin"] || perms["maintain"] || perms["push"] || perms["triage"]
+	if !p.CanWriteIssues {
+		return nil, fmt.Errorf("@%s cannot write issues on %s: ask for triage or write access (fine-grained tokens also need Issues: Read and write)", p.Login, p.Repository)
+	}
+
+	return p, nil
+}
+
+// requireRateBudget fails if fewer than needed API calls remain this hour.
+// An unknown limit (some Enterprise servers disable rate limiting) passes.
+func (p *githubPreflight) requireRateBudget(needed int) error {
+	if p.RateLimit == 0 || p.RateRemaining >= needed {
+		return nil
+	}
+	return fmt.Errorf("GitHub rate limit too low: about %d API calls needed but %d of %d remain until %s; retry then or sync fewer items",
+		needed, p.RateRemaining, p.RateLimit, p.RateReset.Local().Format(models.DateTimeShortFormat))
+}
+
+func hasScope(scopes []string, want string) bool {
+	for _, s := range scopes {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+func scopeList(scopes []string) string {
+	if len(scopes) == 0 {
+		return "none"
+	}
+	return strings.Join(scopes, ", ")
+}
+
+// printGitHubPreflight writes the preflight report for 'gur config github --test'
+func printGitHubPreflight(p *githubPreflight) {
+	fmt.Println("GitHub token check:")
+	fmt.Printf("  Server:       %s\n", p.Server)
+	fmt.Printf("  User:         @%s\n", p.Login)
+	if p.TokenType == "classic" {
+		fmt.Printf("  Token:        classic (scopes: %s)\n", scopeList(p.Scopes))
+	} else {
+		fmt.Println("  Token:        fine-grained")
+	}
+	fmt.Printf("  Repository:   %s (issues: write)\n", p.Repository)
+	if p.RateLimit > 0 {
+		fmt.Printf("  Rate limit:   %d/%d remaining, resets %s\n", p.RateRemaining, p.RateLimit, p.RateReset.Local().Format(models.DateTimeShortFormat))
+	} else {
+		fmt.Println("  Rate limit:   not reported")
+	}
+	fmt.Println("OK")
+}
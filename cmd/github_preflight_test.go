@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// preflightServer fakes the user and repository endpoints
+func preflightServer(scopes *string, repoJSON string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			if scopes != nil {
+				w.Header().Set("X-OAuth-Scopes", *scopes)
+			}
+			w.Header().Set("X-RateLimit-Limit", "5000")
+			w.Header().Set("X-RateLimit-Remaining", "42")
+			w.Header().Set("X-RateLimit-Reset", "1700000000")
+			fmt.Fprint(w, `{"login":"octocat"}`)
+		case "/repos/acme/widgets":
+			fmt.Fprint(w, repoJSON)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestRunGitHubPreflight(t *testing.T) {
+	writable := `{"has_issues":true,"private":true,"permissions":{"push":true}}`
+	classicRepo := "repo, workflow"
+	classicPublic := "public_repo"
+
+	tests := []struct {
+		name    string
+		scopes  *string
+		repo    string
+		wantErr string
+	}{
+		{"fine-grained with write", nil, writable, ""},
+		{"classic with repo scope", &classicRepo, writable, ""},
+		{"classic public_repo on private repo", &classicPublic, writable, "missing the 'repo' scope"},
+		{"read-only access", nil, `{"has_issues":true,"permissions":{"pull":true}}`, "cannot write issues"},
+		{"issues disabled", nil, `{"has_issues":false,"permissions":{"push":true}}`, "issues are disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestGitHubClient(t, preflightServer(tt.scopes, tt.repo))
+			p, err := runGitHubPreflight(context.Background(), client, "acme", "widgets")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runGitHubPreflight: %v", err)
+			}
+			if p.Login != "octocat" || !p.CanWriteIssues || p.RateRemaining != 42 {
+				t.Errorf("unexpected preflight: %+v", p)
+			}
+			if (tt.scopes != nil) != (p.TokenType == "classic") {
+				t.Errorf("token type = %s", p.TokenType)
+			}
+		})
+	}
+}
+
+func TestRunGitHubPreflightMissingRepo(t *testing.T) {
+	client := newTestGitHubClient(t, preflightServer(nil, ""))
+	if _, err := runGitHubPreflight(context.Background(), client, "acme", "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want not found", err)
+	}
+}
+
+func TestRequireRateBudget(t *testing.T) {
+	p := &githubPreflight{RateLimit: 5000, RateRemaining: 10}
+	if err := p.requireRateBudget(10); err != nil {
+		t.Errorf("budget of exactly 10 should pass: %v", err)
+	}
+	if err := p.requireRateBudget(11); err == nil {
+		t.Error("expected budget error")
+	}
+	unlimited := &githubPreflight{}
+	if err := unlimited.requireRateBudget(1000); err != nil {
+		t.Errorf("unknown limit should pass: %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var (
+	grepField      string
+	grepContext    int
+	grepIgnoreCase bool
+)
+
+var grepCmd = &cobra.Command{
+	Use:         "grep <regex>",
+	Short:       "Search task notes and descriptions line by line",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Search task notes and descriptions with a regular expression, printing
+matching lines with surrounding context and the owning task ID and status.
+
+Output follows grep conventions: matching lines use ':' after the line
+number, context lines use '-', and separate hunks are divided by '--'.
+
+Examples:
+  gur grep "s3://"                         # Search notes and descriptions
+  gur grep -i "bucket name" --field notes  # Case-insensitive, notes only
+  gur grep "TODO|FIXME" -C 0               # Matching lines only`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGrep,
+}
+
+func init() {
+	rootCmd.AddCommand(grepCmd)
+	grepCmd.Flags().StringVar(&grepField, "field", "all", "Field to search (notes/description/all)")
+	grepCmd.Flags().IntVarP(&grepContext, "context", "C", 2, "Lines of context around each match")
+	grepCmd.Flags().BoolVarP(&grepIgnoreCase, "ignore-case", "i", false, "Case-insensitive match")
+}
+
+// grepLine is one line of a hunk
+type grepLine struct {
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+	Match  bool   `json:"match"`
+}
+
+// grepHunk is a run of matching lines with context from one task field
+type grepHunk struct {
+	TaskID string     `json:"task_id"`
+	Status string     `json:"status"`
+	Title  string     `json:"title"`
+	Field  string     `json:"field"`
+	Lines  []grepLine `json:"lines"`
+}
+
+// grepText returns hunks of lines matching re, with context lines on either
+// side. Overlapping or adjacent hunks are merged.
+func grepText(re *regexp.Regexp, text string, context int) [][]grepLine {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	match := make([]bool, len(lines))
+	include := make([]bool, len(lines))
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		match[i] = true
+		for j := max(i-context, 0); j <= min(i+context, len(lines)-1); j++ {
+			include[j] = true
+		}
+	}
+
+	var hunks [][]grepLine
+	var current []grepLine
+	for i, line := range lines {
+		if !include[i] {
+			if current != nil {
+				hunks = append(hunks, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, grepLine{Number: i + 1, Text: line, Match: match[i]})
+	}
+	if current != nil {
+		hunks = append(hunks, current)
+	}
+	return hunks
+}
+
+func runGrep(cmd *cobra.Command, args []string) error {
+	var fields []string
+	switch grepField {
+	case "all":
+		fields = []string{"description", "notes"}
+	case "notes", "description":
+		fields = []string{grepField}
+	default:
+		return fmt.Errorf("invalid --field '%s': must be one of: notes, description, all", grepField)
+	}
+	if grepContext < 0 {
+		return fmt.Errorf("invalid --context %d: must be 0 or more", grepContext)
+	}
+
+	pattern := args[0]
+	if grepIgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid regex '%s': %w", args[0], err)
+	}
+
+	var tasks []models.Task
+	if err := db.GetDB().
+		Select("id, title, status, description, notes").
+		Where("COALESCE(description, '') != '' OR COALESCE(notes, '') != ''").
+		Order("created_at ASC").
+		Find(&tasks).Error; err != nil {
+		return err
+	}
+
+	var hunks []grepHunk
+	for _, t := range tasks {
+		for _, field := range fields {
+			text := t.Description
+			if field == "notes" {
+				text = t.Notes
+			}
+			if text == "" {
+				continue
+			}
+			for _, lines := range grepText(re, text, grepContext) {
+				hunks = append(hunks, grepHunk{TaskID: t.ID, Status: t.Status, Title: t.Title, Field: field, Lines: lines})
+			}
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(hunks), "matches": hunks})
+		return nil
+	}
+
+	if len(hunks) == 0 {
+		fmt.Println("No matches found")
+		return nil
+	}
+
+	for i, h := range hunks {
+		if i > 0 && grepContext > 0 {
+			fmt.Println("--")
+		}
+		for _, l := range h.Lines {
+			sep := "-"
+			if l.Match {
+				sep = ":"
+			}
+			fmt.Printf("%s (%s) %s%s%d%s %s\n", h.TaskID, h.Status, h.Field, sep, l.Number, sep, l.Text)
+		}
+	}
+	return nil
+}
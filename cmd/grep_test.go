@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestGrepText(t *testing.T) {
+	text := "one\nbucket: s3://alpha\nthree\nfour\nfive\nsix\nbucket: s3://beta\neight\n"
+	re := regexp.MustCompile(`s3://`)
+
+	hunks := grepText(re, text, 1)
+	want := [][]grepLine{
+		{{1, "one", false}, {2, "bucket: s3://alpha", true}, {3, "three", false}},
+		{{6, "six", false}, {7, "bucket: s3://beta", true}, {8, "eight", false}},
+	}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Errorf("grepText(context=1) = %v, want %v", hunks, want)
+	}
+
+	// Wider context merges the two hunks
+	if hunks := grepText(re, text, 2); len(hunks) != 1 || len(hunks[0]) != 8 {
+		t.Errorf("grepText(context=2) = %v, want one merged hunk of 8 lines", hunks)
+	}
+
+	if hunks := grepText(re, text, 0); len(hunks) != 2 || len(hunks[0]) != 1 || hunks[0][0].Number != 2 {
+		t.Errorf("grepText(context=0) = %v, want matching lines only", hunks)
+	}
+
+	if hunks := grepText(regexp.MustCompile(`gcs://`), text, 2); hunks != nil {
+		t.Errorf("grepText() with no match = %v, want nil", hunks)
+	}
+}
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// Grooming checks
+const (
+	groomNoDescription = "no-description"
+	groomStalePriority = "stale-priority"
+	groomNoLabels      = "no-labels"
+	groomNoGates       = "no-gates"
+)
+
+// priorityConfirmedField is the history field recording that a task's
+// priority was reviewed and kept
+const priorityConfirmedField = "priority_confirmed"
+
+// groomChecks lists the grooming checks in the order they're reported
+var groomChecks = []string{groomNoDescription, groomStalePriority, groomNoLabels, groomNoGates}
+
+var (
+	groomReport     bool
+	groomStaleAfter string
+	groomCheck      []string
+)
+
+var groomCmd = &cobra.Command{
+	Use:   "groom",
+	Short: "Walk open tasks and fix gaps in their metadata",
+	Long: `Walk open tasks (open, in_progress and blocked) and flag the ones that need
+attention:
+
+  no-description  The task has no description
+  stale-priority  The priority hasn't been set or confirmed within --stale-after
+  no-labels       The task has no labels
+  no-gates        No gate is linked, so the task can't close
+
+By default each flagged task is shown in turn with a prompt per finding, so
+it can be fixed in place: type a description, a priority (0-4; entering the
+current one confirms it), comma-separated labels or a gate ID to link. Press
+Enter to skip a finding, or 'q' to end the session. This needs an
+interactive terminal.
+
+With --report (implied by --json) the findings are only listed.
+
+Examples:
+  gur groom
+  gur groom --report
+  gur groom --check no-gates,no-labels
+  gur groom --stale-after 60d --report --json`,
+	Args: cobra.NoArgs,
+	RunE: runGroom,
+}
+
+func init() {
+	rootCmd.AddCommand(groomCmd)
+	groomCmd.Flags().BoolVar(&groomReport, "report", false, "List findings without prompting for fixes")
+	groomCmd.Flags().StringVar(&groomStaleAfter, "stale-after", "30d", "Priorities not set or confirmed for this long are stale")
+	groomCmd.Flags().StringSliceVar(&groomCheck, "check", nil, "Checks to run: no-description, stale-priority, no-labels, no-gates (default all)")
+}
+
+// groomFinding is an open task and the checks it fails
+type groomFinding struct {
+	Task        models.Task `json:"-"`
+	TaskID      string      `json:"task_id"`
+	Title       string      `json:"title"`
+	Priority    int         `json:"priority"`
+	Issues      []string    `json:"issues"`
+	PrioritySet time.Time   `json:"priority_set"`
+}
+
+// has reports whether the finding includes the check
+func (f *groomFinding) has(check string) bool {
+	for _, i := range f.Issues {
+		if i == check {
+			return true
+		}
+	}
+	return false
+}
+
+// prioritySetAt returns when the task's priority was last set or confirmed,
+// per its history
+func prioritySetAt(database *gorm.DB, task models.Task) time.Time {
+	var h models.TaskHistory
+	if database.Where("task_id = ? AND field IN ?", task.ID, []string{"priority", priorityConfirmedField}).Order("changed_at DESC").First(&h).Error == nil {
+		return h.ChangedAt
+	}
+	return task.CreatedAt
+}
+
+// findGroomIssues returns the open tasks failing any of checks, most
+// important first
+func findGroomIssues(database *gorm.DB, checks map[string]bool, staleCutoff time.Time) ([]groomFinding, error) {
+	var tasks []models.Task
+	err := database.
+		Where("status IN ?", []string{models.StatusOpen, models.StatusInProgress, models.StatusBlocked}).
+		Order("priority ASC, created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []groomFinding
+	for _, task := range tasks {
+		f := groomFinding{Task: task, TaskID: task.ID, Title: task.Title, Priority: task.Priority, PrioritySet: prioritySetAt(database, task)}
+		if checks[groomNoDescription] && strings.TrimSpace(task.Description) == "" {
+			f.Issues = append(f.Issues, groomNoDescription)
+		}
+		if checks[groomStalePriority] && f.PrioritySet.Before(staleCutoff) {
+			f.Issues = append(f.Issues, groomStalePriority)
+		}
+		if checks[groomNoLabels] && len(task.Labels) == 0 {
+			f.Issues = append(f.Issues, groomNoLabels)
+		}
+		if checks[groomNoGates] {
+			var links int64
+			if err := database.Model(&models.GateTaskLink{}).Where("task_id = ?", task.ID).Count(&links).Error; err != nil {
+				return nil, err
+			}
+			if links == 0 {
+				f.Issues = append(f.Issues, groomNoGates)
+			}
+		}
+		if len(f.Issues) > 0 {
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+// errGroomQuit ends an interactive grooming session
+var errGroomQuit = errors.New("grooming session ended")
+
+// groomPrompt asks a question and returns the trimmed answer. 'q' (or the
+// end of input) returns errGroomQuit.
+func groomPrompt(reader *bufio.Reader, out io.Writer, question string) (string, error) {
+	fmt.Fprint(out, question)
+	answer, err := reader.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "q" || (err != nil && answer == "") {
+		return "", errGroomQuit
+	}
+	return answer, nil
+}
+
+// groomTask prompts for a fix to each of the finding's issues and applies
+// the answers, returning the fixes made
+func groomTask(database *gorm.DB, reader *bufio.Reader, out io.Writer, f *groomFinding) ([]string, error) {
+	task := &f.Task
+	var fixed []string
+	changed := false
+
+	for _, issue := range f.Issues {
+		switch issue {
+		case groomNoDescription:
+			answer, err := groomPrompt(reader, out, "  Description: ")
+			if err != nil {
+				return fixed, err
+			}
+			if answer == "" {
+				continue
+			}
+			models.RecordChange(database, task.ID, "description", task.Description, answer, "user")
+			task.Description = answer
+			changed = true
+
+		case groomStalePriority:
+			answer, err := groomPrompt(reader, out, fmt.Sprintf("  Priority (0-4, currently P%d since %s): ", task.Priority, f.PrioritySet.Format(models.DateFormat)))
+			if err != nil {
+				return fixed, err
+			}
+			if answer == "" {
+				continue
+			}
+			priority, convErr := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(answer), "P"))
+			if convErr != nil || priority < 0 || priority > models.PriorityLowest {
+				fmt.Fprintf(out, "  Skipped: invalid priority '%s' (must be 0 to 4)\n", answer)
+				continue
+			}
+			if priority == task.Priority {
+				models.RecordChange(database, task.ID, priorityConfirmedField, "", fmt.Sprintf("%d", priority), "user")
+				break
+			}
+			models.RecordChange(database, task.ID, "priority", fmt.Sprintf("%d", task.Priority), fmt.Sprintf("%d", priority), "user")
+			task.Priority = priority
+			changed = true
+
+		case groomNoLabels:
+			answer, err := groomPrompt(reader, out, "  Labels (comma-separated): ")
+			if err != nil {
+				return fixed, err
+			}
+			added := false
+			for _, l := range strings.Split(answer, ",") {
+				if l = strings.TrimSpace(l); l != "" && !task.HasLabel(l) {
+					models.RecordChange(database, task.ID, "label_added", "", l, "user")
+					task.AddLabel(l)
+					added = true
+				}
+			}
+			if !added {
+				continue
+			}
+			changed = true
+
+		case groomNoGates:
+			answer, err := groomPrompt(reader, out, "  Gate to link (ID): ")
+			if err != nil {
+				return fixed, err
+			}
+			if answer == "" {
+				continue
+			}
+			if _, err := db.GetGateByID(answer); err != nil {
+				fmt.Fprintf(out, "  Skipped: gate '%s' not found (use 'gur gate list' to see available gates)\n", answer)
+				continue
+			}
+			link := &models.GateTaskLink{GateID: answer, TaskID: task.ID, Status: models.GateLinkPending}
+			if err := database.Create(link).Error; err != nil {
+				return fixed, fmt.Errorf("failed to link gate '%s' to task '%s': database error: %w", answer, task.ID, err)
+			}
+		}
+		fixed = append(fixed, issue)
+	}
+
+	if changed {
+		if err := database.Save(task).Error; err != nil {
+			return fixed, fmt.Errorf("failed to update task '%s': database error: %w", task.ID, err)
+		}
+	}
+	if len(fixed) > 0 {
+		noteAffected(task.ID)
+	}
+	return fixed, nil
+}
+
+func runGroom(cmd *cobra.Command, args []string) error {
+	checks := make(map[string]bool)
+	if len(groomCheck) == 0 {
+		for _, c := range groomChecks {
+			checks[c] = true
+		}
+	}
+	for _, c := range groomCheck {
+		c = strings.TrimSpace(c)
+		known := false
+		for _, k := range groomChecks {
+			known = known || k == c
+		}
+		if !known {
+			return fmt.Errorf("invalid check '%s': must be one of %s", c, strings.Join(groomChecks, ", "))
+		}
+		checks[c] = true
+	}
+
+	staleAfter, err := parseDuration(groomStaleAfter)
+	if err != nil {
+		return err
+	}
+
+	report := groomReport || IsJSONOutput()
+	if !report && !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("gur groom prompts for fixes and requires an interactive terminal (use --report in scripts)")
+	}
+
+	database := db.GetDB()
+	findings, err := findGroomIssues(database, checks, time.Now().Add(-staleAfter))
+	if err != nil {
+		return fmt.Errorf("failed to find tasks to groom: database error: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, f := range findings {
+		for _, i := range f.Issues {
+			counts[i]++
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"count":    len(findings),
+			"findings": findings,
+			"summary":  counts,
+		})
+		return nil
+	}
+
+	if len(findings) == 0 {
+		fmt.Println("Nothing to groom: every open task passes the checks.")
+		return nil
+	}
+
+	if report {
+		fmt.Println("Tasks needing grooming:")
+		for _, f := range findings {
+			fmt.Printf("  [%s] P%d %s: %s\n", f.TaskID, f.Priority, f.Title, strings.Join(f.Issues, ", "))
+		}
+		fmt.Println()
+		for _, c := range groomChecks {
+			if counts[c] > 0 {
+				fmt.Printf("%-15s %d\n", c, counts[c])
+			}
+		}
+		fmt.Printf("\n%d task(s) need grooming. Run 'gur groom' in a terminal to fix them.\n", len(findings))
+		return nil
+	}
+
+	fmt.Printf("%d task(s) need grooming. Press Enter to skip a finding, 'q' to stop.\n", len(findings))
+	reader := bufio.NewReader(os.Stdin)
+	groomed, fixes := 0, 0
+	for i := range findings {
+		f := &findings[i]
+		fmt.Printf("\n[%d/%d] [%s] P%d %s\n  Needs: %s\n", i+1, len(findings), f.TaskID, f.Priority, f.Title, strings.Join(f.Issues, ", "))
+		fixed, err := groomTask(database, reader, os.Stdout, f)
+		fixes += len(fixed)
+		if len(fixed) > 0 {
+			groomed++
+		}
+		if errors.Is(err, errGroomQuit) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("\nMade %d fix(es) to %d of %d task(s).\n", fixes, groomed, len(findings))
+	return nil
+}
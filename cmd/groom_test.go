@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestFindGroomIssues(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	now := time.Now()
+	old := now.Add(-60 * 24 * time.Hour)
+
+	database.Create(&models.Task{ID: "gur-groo0001", Title: "Bare", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-groo0002", Title: "Groomed", Description: "Done right", Labels: []string{"bug"}, Status: models.StatusInProgress})
+	database.Create(&models.Task{ID: "gur-groo0003", Title: "Closed", Status: models.StatusClosed})
+	database.Create(&models.Task{ID: "gur-groo0004", Title: "Reprioritized", Description: "x", Labels: []string{"ui"}, Status: models.StatusBlocked})
+	database.Exec("UPDATE tasks SET created_at = ? WHERE id IN ?", old, []string{"gur-groo0001", "gur-groo0004"})
+	database.Create(&models.TaskHistory{TaskID: "gur-groo0004", Field: "priority", OldValue: "3", NewValue: "2"})
+	database.Create(&models.Gate{ID: "gate-groom001", Title: "Tests", Type: "test"})
+	database.Create(&models.GateTaskLink{GateID: "gate-groom001", TaskID: "gur-groo0002", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-groom001", TaskID: "gur-groo0004", Status: models.GateLinkPending})
+
+	all := map[string]bool{groomNoDescription: true, groomStalePriority: true, groomNoLabels: true, groomNoGates: true}
+	findings, err := findGroomIssues(database, all, now.Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("findGroomIssues: %v", err)
+	}
+	if len(findings) != 1 || findings[0].TaskID != "gur-groo0001" {
+		t.Fatalf("findings = %+v, want only gur-groo0001", findings)
+	}
+	want := []string{groomNoDescription, groomStalePriority, groomNoLabels, groomNoGates}
+	if strings.Join(findings[0].Issues, ",") != strings.Join(want, ",") {
+		t.Errorf("issues = %v, want %v", findings[0].Issues, want)
+	}
+
+	findings, _ = findGroomIssues(database, map[string]bool{groomNoGates: true}, now.Add(-30*24*time.Hour))
+	if len(findings) != 1 || !findings[0].has(groomNoGates) || findings[0].has(groomNoLabels) {
+		t.Errorf("findings with only no-gates = %+v", findings)
+	}
+}
+
+func TestGroomTask(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-groo0010", Title: "Bare", Status: models.StatusOpen, Priority: 2})
+	database.Create(&models.Gate{ID: "gate-groom010", Title: "Review", Type: "review"})
+
+	findings, err := findGroomIssues(database, map[string]bool{groomNoDescription: true, groomStalePriority: true, groomNoLabels: true, groomNoGates: true}, time.Now().Add(time.Hour))
+	if err != nil || len(findings) != 1 {
+		t.Fatalf("findGroomIssues = %v, %v", findings, err)
+	}
+
+	input := "Explain the bug\n2\nbug, ui\ngate-groom010\n"
+	fixed, err := groomTask(database, bufio.NewReader(strings.NewReader(input)), io.Discard, &findings[0])
+	if err != nil {
+		t.Fatalf("groomTask: %v", err)
+	}
+	if len(fixed) != 4 {
+		t.Errorf("fixed = %v, want all four issues", fixed)
+	}
+
+	var task models.Task
+	database.First(&task, "id = ?", "gur-groo0010")
+	if task.Description != "Explain the bug" || !task.HasLabel("bug") || !task.HasLabel("ui") {
+		t.Errorf("task = %+v, want description and labels set", task)
+	}
+	var links int64
+	database.Model(&models.GateTaskLink{}).Where("task_id = ?", task.ID).Count(&links)
+	if links != 1 {
+		t.Errorf("gate links = %d, want 1", links)
+	}
+	var confirmed int64
+	database.Model(&models.TaskHistory{}).Where("task_id = ? AND field = ?", task.ID, priorityConfirmedField).Count(&confirmed)
+	if confirmed != 1 || task.Priority != 2 {
+		t.Errorf("priority confirmations = %d (P%d), want 1 confirming P2", confirmed, task.Priority)
+	}
+
+	// Skipping with Enter and quitting with q
+	task2 := models.Task{ID: "gur-groo0011", Title: "Skipped", Status: models.StatusOpen}
+	database.Create(&task2)
+	f := groomFinding{Task: task2, TaskID: task2.ID, Issues: []string{groomNoDescription, groomNoLabels}}
+	fixed, err = groomTask(database, bufio.NewReader(strings.NewReader("\nq\n")), io.Discard, &f)
+	if err != errGroomQuit || len(fixed) != 0 {
+		t.Errorf("groomTask = %v, %v; want no fixes and errGroomQuit", fixed, err)
+	}
+}
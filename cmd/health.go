@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Show a project health score with breakdown and suggestions",
+	Long: `Compute a 0-100 project health score from:
+
+  overdue      Overdue P0/P1 tasks (each costs 25 points)
+  stale        In-progress tasks not updated within --stale
+  gates        Gate run failure rate within --window
+  force-close  Share of tasks force-closed within --window
+  unsynced     Open tasks not pushed to GitHub (only when GitHub is configured)
+
+The overall score is a weighted average of the components, graded A-F.
+Use --json to feed dashboards.
+
+Examples:
+  gur health
+  gur health --stale 3d --window 14d
+  gur health --json`,
+	Args: cobra.NoArgs,
+	RunE: runHealth,
+}
+
+var (
+	healthStale  string
+	healthWindow string
+)
+
+func init() {
+	rootCmd.AddCommand(healthCmd)
+	healthCmd.Flags().StringVar(&healthStale, "stale", "7d", "In-progress tasks not updated for this long are stale")
+	healthCmd.Flags().StringVar(&healthWindow, "window", "30d", "Look-back window for gate runs and closures")
+}
+
+// Component weights; components that don't apply are left out and the
+// remaining weights renormalized
+const (
+	healthWeightOverdue    = 30
+	healthWeightStale      = 20
+	healthWeightGates      = 20
+	healthWeightForceClose = 15
+	healthWeightUnsynced   = 15
+
+	healthOverduePenalty = 25 // Points lost per overdue P0/P1 task
+)
+
+// healthInputs are the raw counts behind the score
+type healthInputs struct {
+	OverdueCritical int64
+	InProgress      int64
+	StaleInProgress int64
+	GateRuns        int64
+	GateFailures    int64
+	Closed          int64
+	ForceClosed     int64
+	GitHubEnabled   bool
+	Open            int64
+	Unsynced        int64
+}
+
+// healthComponent is one scored part of the health report
+type healthComponent struct {
+	Name       string  `json:"name"`
+	Score      int     `json:"score"`
+	Weight     int     `json:"weight"`
+	Value      float64 `json:"value"`
+	Detail     string  `json:"detail"`
+	Suggestion string  `json:"suggestion,omitempty"`
+}
+
+// healthReport is the output of 'gur health'
+type healthReport struct {
+	Score       int               `json:"score"`
+	Grade       string            `json:"grade"`
+	Components  []healthComponent `json:"components"`
+	Suggestions []string          `json:"suggestions"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
+
+// collectHealthInputs gathers the counts for the health score
+func collectHealthInputs(database *gorm.DB, now time.Time, staleAfter, window time.Duration) (healthInputs, error) {
+	var in healthInputs
+	since := now.Add(-window)
+
+	counts := []struct {
+		dest  *int64
+		query *gorm.DB
+	}{
+		{&in.OverdueCritical, whereOverdue(database.Model(&models.Task{}), now).Where("priority <= ?", models.PriorityHigh)},
+		{&in.InProgress, database.Model(&models.Task{}).Where("status = ?", models.StatusInProgress)},
+		{&in.StaleInProgress, database.Model(&models.Task{}).Where("status = ? AND updated_at < ?", models.StatusInProgress, now.Add(-staleAfter))},
+		{&in.GateRuns, database.Model(&models.GateRun{}).Where("created_at >= ? AND result IN ?", since, []string{models.GateLinkPassed, models.GateLinkFailed})},
+		{&in.GateFailures, database.Model(&models.GateRun{}).Where("created_at >= ? AND result = ?", since, models.GateLinkFailed)},
+		{&in.Closed, database.Model(&models.Task{}).Where("closed_at >= ?", since)},
+		{&in.ForceClosed, database.Model(&models.Task{}).Where("closed_at >= ? AND close_reason LIKE ?", since, models.ForceClosePrefix+"%")},
+	}
+	for _, c := range counts {
+		if err := c.query.Count(c.dest).Error; err != nil {
+			return in, err
+		}
+	}
+
+	if repo, _ := db.GetConfig(models.ConfigGitHubRepo); repo != "" {
+		in.GitHubEnabled = true
+		open := database.Model(&models.Task{}).Where("status IN ?", []string{models.StatusOpen, models.StatusInProgress, models.StatusBlocked})
+		if err := open.Session(&gorm.Session{}).Count(&in.Open).Error; err != nil {
+			return in, err
+		}
+		if err := open.Where("synced = ?", false).Count(&in.Unsynced).Error; err != nil {
+			return in, err
+		}
+	}
+	return in, nil
+}
+
+// ratioScore maps a bad-item ratio to a 0-100 score; scale > 1 makes the
+// score drop faster (e.g., 2 means 50% bad scores 0)
+func ratioScore(bad, total int64, scale float64) (int, float64) {
+	if total == 0 {
+		return 100, 0
+	}
+	ratio := float64(bad) / float64(total)
+	return clampScore(100 * (1 - ratio*scale)), ratio
+}
+
+func clampScore(v float64) int {
+	return int(math.Round(math.Max(0, math.Min(100, v))))
+}
+
+// healthGrade converts a score to a letter grade
+func healthGrade(score int) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 75:
+		return "B"
+	case score >= 60:
+		return "C"
+	case score >= 40:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+// scoreHealth turns the raw counts into a scored report
+func scoreHealth(in healthInputs, stale, window string) healthReport {
+	var components []healthComponent
+
+	overdue := healthComponent{
+		Name:   "overdue",
+		Score:  clampScore(100 - float64(in.OverdueCritical*healthOverduePenalty)),
+		Weight: healthWeightOverdue,
+		Value:  float64(in.OverdueCritical),
+		Detail: fmt.Sprintf("%d overdue P0/P1 task(s)", in.OverdueCritical),
+	}
+	if in.OverdueCritical > 0 {
+		overdue.Suggestion = "Reschedule or finish overdue critical work: gur list --overdue --priority 0 (and --priority 1)"
+	}
+	components = append(components, overdue)
+
+	staleScore, staleRatio := ratioScore(in.StaleInProgress, in.InProgress, 1)
+	staleComp := healthComponent{
+		Name:   "stale",
+		Score:  staleScore,
+		Weight: healthWeightStale,
+		Value:  staleRatio,
+		Detail: fmt.Sprintf("%d of %d in-progress task(s) not updated in %s", in.StaleInProgress, in.InProgress, stale),
+	}
+	if in.StaleInProgress > 0 {
+		staleComp.Suggestion = "Update, block, or return stale in-progress tasks to open: gur list --status in_progress --sort updated"
+	}
+	components = append(components, staleComp)
+
+	gateScore, failRate := ratioScore(in.GateFailures, in.GateRuns, 1)
+	gates := healthComponent{
+		Name:   "gates",
+		Score:  gateScore,
+		Weight: healthWeightGates,
+		Value:  failRate,
+		Detail: fmt.Sprintf("%d of %d gate run(s) failed in %s", in.GateFailures, in.GateRuns, window),
+	}
+	if failRate > 0.2 {
+		gates.Suggestion = "Investigate frequently failing gates: gur gate list --result failed"
+	}
+	components = append(components, gates)
+
+	forceScore, forceRate := ratioScore(in.ForceClosed, in.Closed, 2)
+	force := healthComponent{
+		Name:   "force-close",
+		Score:  forceScore,
+		Weight: healthWeightForceClose,
+		Value:  forceRate,
+		Detail: fmt.Sprintf("%d of %d task(s) closed in %s were force-closed", in.ForceClosed, in.Closed, window),
+	}
+	if in.ForceClosed > 0 {
+		force.Suggestion = "Reduce force-closes by fixing or unlinking gates that block legitimate closes"
+	}
+	components = append(components, force)
+
+	if in.GitHubEnabled {
+		syncScore, unsyncedRatio := ratioScore(in.Unsynced, in.Open, 1)
+		unsynced := healthComponent{
+			Name:   "unsynced",
+			Score:  syncScore,
+			Weight: healthWeightUnsynced,
+			Value:  unsyncedRatio,
+			Detail: fmt.Sprintf("%d of %d open task(s) not pushed to GitHub", in.Unsynced, in.Open),
+		}
+		if in.Unsynced > 0 {
+			unsynced.Suggestion = "Push the unsynced backlog: gur sync push"
+		}
+		components = append(components, unsynced)
+	}
+
+	var weighted, weights float64
+	suggestions := []string{}
+	for _, c := range components {
+		weighted += float64(c.Score * c.Weight)
+		weights += float64(c.Weight)
+		if c.Suggestion != "" {
+			suggestions = append(suggestions, c.Suggestion)
+		}
+	}
+	score := clampScore(weighted / weights)
+
+	return healthReport{
+		Score:       score,
+		Grade:       healthGrade(score),
+		Components:  components,
+		Suggestions: suggestions,
+	}
+}
+
+func runHealth(cmd *cobra.Command, args []string) error {
+	staleAfter, err := parseDuration(healthStale)
+	if err != nil {
+		return err
+	}
+	window, err := parseDuration(healthWindow)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	in, err := collectHealthInputs(db.GetDB(), now, staleAfter, window)
+	if err != nil {
+		return fmt.Errorf("failed to compute health: database error: %w", err)
+	}
+	report := scoreHealth(in, healthStale, healthWindow)
+	report.GeneratedAt = now
+
+	if IsJSONOutput() {
+		OutputJSON(report)
+		return nil
+	}
+
+	fmt.Printf("Project health: %d/100 (%s)\n\n", report.Score, report.Grade)
+	for _, c := range report.Components {
+		fmt.Printf("  %-12s %3d  %s\n", c.Name, c.Score, c.Detail)
+	}
+	if len(report.Suggestions) > 0 {
+		fmt.Println("\nSuggestions:")
+		for _, s := range report.Suggestions {
+			fmt.Printf("  - %s\n", s)
+		}
+	}
+	if !in.GitHubEnabled {
+		fmt.Println("\n(unsynced backlog not scored: GitHub not configured)")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestScoreHealth(t *testing.T) {
+	perfect := scoreHealth(healthInputs{}, "7d", "30d")
+	if perfect.Score != 100 || perfect.Grade != "A" || len(perfect.Suggestions) != 0 {
+		t.Errorf("empty project = %+v, want 100/A with no suggestions", perfect)
+	}
+	if len(perfect.Components) != 4 {
+		t.Errorf("expected unsynced component to be skipped without GitHub, got %d components", len(perfect.Components))
+	}
+
+	report := scoreHealth(healthInputs{
+		OverdueCritical: 2, // 50
+		InProgress:      4, // 1 stale -> 75
+		StaleInProgress: 1,
+		GateRuns:        10, // 5 failed -> 50
+		GateFailures:    5,
+		Closed:          4, // 1 forced, scaled x2 -> 50
+		ForceClosed:     1,
+		GitHubEnabled:   true,
+		Open:            10, // 10 unsynced -> 0
+		Unsynced:        10,
+	}, "7d", "30d")
+
+	scores := make(map[string]int)
+	for _, c := range report.Components {
+		scores[c.Name] = c.Score
+	}
+	want := map[string]int{"overdue": 50, "stale": 75, "gates": 50, "force-close": 50, "unsynced": 0}
+	for name, score := range want {
+		if scores[name] != score {
+			t.Errorf("%s score = %d, want %d", name, scores[name], score)
+		}
+	}
+	// (50*30 + 75*20 + 50*20 + 50*15 + 0*15) / 100 = 47.5 -> 48
+	if report.Score != 48 || report.Grade != "D" {
+		t.Errorf("overall = %d (%s), want 48 (D)", report.Score, report.Grade)
+	}
+	if len(report.Suggestions) != 5 {
+		t.Errorf("expected a suggestion per component, got %v", report.Suggestions)
+	}
+}
+
+func TestCollectHealthInputs(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	now := time.Now()
+	past := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	database.Create(&models.Task{ID: "gur-hlth0001", Title: "Overdue P0", Status: models.StatusOpen, Priority: 0, DueAt: &past})
+	database.Create(&models.Task{ID: "gur-hlth0002", Title: "Overdue P3", Status: models.StatusOpen, Priority: 3, DueAt: &past})
+	database.Create(&models.Task{ID: "gur-hlth0003", Title: "Working", Status: models.StatusInProgress})
+	database.Exec("UPDATE tasks SET updated_at = ? WHERE id = ?", now.Add(-10*24*time.Hour), "gur-hlth0003")
+	database.Create(&models.Task{ID: "gur-hlth0004", Title: "Forced", Status: models.StatusClosed, ClosedAt: &recent, CloseReason: models.ForceClosePrefix + "skip"})
+	database.Create(&models.Task{ID: "gur-hlth0005", Title: "Done", Status: models.StatusClosed, ClosedAt: &recent, CloseReason: "done"})
+	database.Create(&models.GateRun{GateID: "gate-a", Result: models.GateLinkPassed})
+	database.Create(&models.GateRun{GateID: "gate-a", Result: models.GateLinkFailed})
+	database.Create(&models.GateRun{GateID: "gate-a", Result: "skipped"})
+
+	in, err := collectHealthInputs(database, now, 7*24*time.Hour, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("collectHealthInputs: %v", err)
+	}
+	want := healthInputs{
+		OverdueCritical: 1,
+		InProgress:      1,
+		StaleInProgress: 1,
+		GateRuns:        2,
+		GateFailures:    1,
+		Closed:          2,
+		ForceClosed:     1,
+	}
+	if in != want {
+		t.Errorf("collectHealthInputs = %+v, want %+v", in, want)
+	}
+}
@@ -12,15 +12,17 @@ import (
 var historyLimit int
 
 var historyCmd = &cobra.Command{
-	Use:   "history <task-id>",
-	Short: "Show change history for a task",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runHistory,
+	Use:         "history <task-id>",
+	Short:       "Show change history for a task",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runHistory,
 }
 
 func init() {
 	rootCmd.AddCommand(historyCmd)
 	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 50, "Maximum entries to show")
+	addJSONLFlag(historyCmd)
 }
 
 func runHistory(cmd *cobra.Command, args []string) error {
@@ -29,14 +31,22 @@ func runHistory(cmd *cobra.Command, args []string) error {
 	// Verify task exists
 	task, err := db.GetTaskByID(taskID)
 	if err != nil {
-		return fmt.Errorf("cannot show history: task '%s' not found (use 'gur list' to see available tasks)", taskID)
+		return codedErrorf(ErrCodeNotFound, "cannot show history: task '%s' not found (use 'gur list' to see available tasks)", taskID)
 	}
 
-	var history []models.TaskHistory
-	if err := db.GetDB().Where("task_id = ?", taskID).
+	query := db.GetDB().Model(&models.TaskHistory{}).Where("task_id = ?", taskID).
 		Order("changed_at DESC").
-		Limit(historyLimit).
-		Find(&history).Error; err != nil {
+		Limit(historyLimit)
+	if jsonlOutput {
+		w := newJSONLWriter(nil, nil)
+		if err := streamRows(db.GetDB(), query, func(h models.TaskHistory) error { return w.write(h) }); err != nil {
+			return fmt.Errorf("failed to retrieve history for task '%s': database error: %w", taskID, err)
+		}
+		return nil
+	}
+
+	var history []models.TaskHistory
+	if err := query.Find(&history).Error; err != nil {
 		return fmt.Errorf("failed to retrieve history for task '%s': database error: %w", taskID, err)
 	}
 
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// Hook events: each runs .guardrails/hooks/<event> if it exists
+const (
+	hookOnCreate   = "on-create"
+	hookOnClose    = "on-close"
+	hookOnGateFail = "on-gate-fail"
+)
+
+// hookEvents lists the hook events in display order
+var hookEvents = []string{hookOnCreate, hookOnClose, hookOnGateFail}
+
+const (
+	hooksDir           = "hooks"
+	defaultHookTimeout = 30 * time.Second
+)
+
+var configHooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Configure lifecycle hook scripts",
+	Long: `Configure the scripts run on lifecycle events.
+
+Put an executable named after the event in .guardrails/hooks/:
+  on-create      after 'gur create'
+  on-close       after 'gur close'
+  on-gate-fail   after a gate fails ('gate fail', 'gate run', 'gate ingest')
+
+Hooks run from the project root after the change is saved, with a JSON
+payload on stdin: {"event", "timestamp", "actor", "task", and for
+on-gate-fail "gate", "link" and "run"}. GUR_HOOK_EVENT and GUR_TASK_ID are
+set in the environment. Hook output goes to stderr.
+
+A hook that exits non-zero or runs past --timeout has failed; --on-failure
+decides what that does to the command:
+  warn     print a warning (default)
+  fail     exit with an error (the change is still saved)
+  ignore   do nothing
+
+Examples:
+  gur config hooks
+  gur config hooks --timeout 10s --on-failure fail`,
+	Args: cobra.NoArgs,
+	RunE: runConfigHooks,
+}
+
+var (
+	configHooksTimeout   string
+	configHooksOnFailure string
+)
+
+func init() {
+	configCmd.AddCommand(configHooksCmd)
+	configHooksCmd.Flags().StringVar(&configHooksTimeout, "timeout", "", "How long a hook may run (e.g., 10s, 2m; default 30s)")
+	configHooksCmd.Flags().StringVar(&configHooksOnFailure, "on-failure", "", "What a failing hook does: "+strings.Join(models.HookFailurePolicies, "/"))
+}
+
+// hookPayload is the JSON passed to hooks on stdin
+type hookPayload struct {
+	Event     string               `json:"event"`
+	Timestamp time.Time            `json:"timestamp"`
+	Actor     string               `json:"actor"`
+	Task      *models.Task         `json:"task"`
+	Gate      *models.Gate         `json:"gate,omitempty"`
+	Link      *models.GateTaskLink `json:"link,omitempty"`
+	Run       *models.GateRun      `json:"run,omitempty"`
+}
+
+// hookSettings are the configured hook timeout and failure policy
+type hookSettings struct {
+	Timeout time.Duration
+	Policy  string
+}
+
+// loadHookSettings returns the configured settings, falling back to the
+// defaults for unset or invalid values
+func loadHookSettings() hookSettings {
+	s := hookSettings{Timeout: defaultHookTimeout, Policy: models.HookFailureWarn}
+	if v, _ := db.GetConfig(models.ConfigHookTimeout); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			s.Timeout = d
+		}
+	}
+	if v, _ := db.GetConfig(models.ConfigHookFailurePolicy); validHookPolicy(v) {
+		s.Policy = v
+	}
+	return s
+}
+
+func validHookPolicy(policy string) bool {
+	for _, p := range models.HookFailurePolicies {
+		if policy == p {
+			return true
+		}
+	}
+	return false
+}
+
+// hookPath returns the hook script for event in the project at root
+func hookPath(root, event string) string {
+	return filepath.Join(root, db.GuardrailsDir, hooksDir, event)
+}
+
+// executeHook runs the hook at path from root with payload on stdin,
+// sending its output to out
+func executeHook(path, root string, payload hookPayload, timeout time.Duration, out io.Writer) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	c := exec.CommandContext(ctx, path)
+	c.Dir = root
+	c.Env = append(os.Environ(), "GUR_HOOK_EVENT="+payload.Event, "GUR_TASK_ID="+payload.Task.ID)
+	c.Stdin = bytes.NewReader(data)
+	c.Stdout = out
+	c.Stderr = out
+	c.WaitDelay = 5 * time.Second
+
+	err = c.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("exited with status %d", exitErr.ExitCode())
+	}
+	return err
+}
+
+// fireHook runs the project's hook for event, if one is installed.
+// It only returns an error for a failed hook under the "fail" policy.
+func fireHook(event string, payload hookPayload) error {
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return nil
+	}
+	path := hookPath(root, event)
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+
+	settings := loadHookSettings()
+	payload.Event = event
+	payload.Timestamp = time.Now().UTC()
+	payload.Actor = eventActor()
+	err = executeHook(path, root, payload, settings.Timeout, os.Stderr)
+	if err == nil {
+		return nil
+	}
+	switch settings.Policy {
+	case models.HookFailureFail:
+		return fmt.Errorf("%s hook failed for task '%s': %w", event, payload.Task.ID, err)
+	case models.HookFailureIgnore:
+		return nil
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: %s hook failed for task '%s': %v\n", event, payload.Task.ID, err)
+		return nil
+	}
+}
+
+func runConfigHooks(cmd *cobra.Command, args []string) error {
+	if configHooksTimeout != "" {
+		d, err := time.ParseDuration(configHooksTimeout)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid timeout '%s': use a positive duration like 10s or 2m", configHooksTimeout)
+		}
+		if err := db.SetConfig(models.ConfigHookTimeout, d.String()); err != nil {
+			return fmt.Errorf("failed to save hook timeout: %w", err)
+		}
+	}
+	if configHooksOnFailure != "" {
+		if !validHookPolicy(configHooksOnFailure) {
+			return fmt.Errorf("invalid failure policy '%s': must be one of %s", configHooksOnFailure, strings.Join(models.HookFailurePolicies, ", "))
+		}
+		if err := db.SetConfig(models.ConfigHookFailurePolicy, configHooksOnFailure); err != nil {
+			return fmt.Errorf("failed to save hook failure policy: %w", err)
+		}
+	}
+
+	settings := loadHookSettings()
+	installed := make(map[string]bool)
+	if root, err := db.FindProjectRoot(); err == nil {
+		for _, event := range hookEvents {
+			_, statErr := os.Stat(hookPath(root, event))
+			installed[event] = statErr == nil
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"timeout": settings.Timeout.String(), "on_failure": settings.Policy, "installed": installed})
+		return nil
+	}
+	if configHooksTimeout != "" || configHooksOnFailure != "" {
+		fmt.Println("Hook settings updated")
+	}
+	fmt.Printf("Timeout:    %s\n", settings.Timeout)
+	fmt.Printf("On failure: %s\n", settings.Policy)
+	fmt.Println("Hooks:")
+	for _, event := range hookEvents {
+		state := "not installed"
+		if installed[event] {
+			state = "installed"
+		}
+		fmt.Printf("  %-14s %s\n", event, state)
+	}
+	return nil
+}
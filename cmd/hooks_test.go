@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// installHook writes an executable hook script into a temp project and
+// changes into it
+func installHook(t *testing.T, event, script string) string {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, db.GuardrailsDir, hooksDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, event), []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+	return root
+}
+
+func TestFireHookPayload(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	root := installHook(t, hookOnGateFail, `cat > payload.json; echo "$GUR_HOOK_EVENT $GUR_TASK_ID" > env.txt`)
+	t.Setenv("GUR_ACTOR", "ci-bot")
+
+	task := &models.Task{ID: "gur-hook0001", Title: "Login", Status: models.StatusOpen}
+	gate := &models.Gate{ID: "gate-hook0001", Title: "Tests"}
+	link := &models.GateTaskLink{GateID: gate.ID, TaskID: task.ID, Status: models.GateLinkFailed}
+	run := &models.GateRun{GateID: gate.ID, Result: models.GateLinkFailed, Output: "FAIL TestLogin"}
+	if err := fireHook(hookOnGateFail, hookPayload{Task: task, Gate: gate, Link: link, Run: run}); err != nil {
+		t.Fatalf("fireHook() error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "payload.json"))
+	if err != nil {
+		t.Fatalf("hook did not run from the project root: %v", err)
+	}
+	var got struct {
+		Event string              `json:"event"`
+		Actor string              `json:"actor"`
+		Task  models.Task         `json:"task"`
+		Gate  models.Gate         `json:"gate"`
+		Link  models.GateTaskLink `json:"link"`
+		Run   models.GateRun      `json:"run"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("payload is not JSON: %v\n%s", err, data)
+	}
+	if got.Event != hookOnGateFail || got.Actor != "ci-bot" || got.Task.ID != task.ID || got.Gate.ID != gate.ID || got.Run.Output != "FAIL TestLogin" {
+		t.Errorf("payload = %+v", got)
+	}
+	if env, _ := os.ReadFile(filepath.Join(root, "env.txt")); strings.TrimSpace(string(env)) != "on-gate-fail gur-hook0001" {
+		t.Errorf("hook env = %q", env)
+	}
+
+	// Events without an installed hook are a no-op
+	if err := fireHook(hookOnClose, hookPayload{Task: task}); err != nil {
+		t.Errorf("fireHook(uninstalled) error: %v", err)
+	}
+}
+
+func TestFireHookFailurePolicy(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	installHook(t, hookOnClose, "exit 3\n")
+	task := &models.Task{ID: "gur-hook0002", Title: "Export"}
+
+	if err := fireHook(hookOnClose, hookPayload{Task: task}); err != nil {
+		t.Errorf("warn policy error = %v, want nil", err)
+	}
+
+	db.SetConfig(models.ConfigHookFailurePolicy, models.HookFailureFail)
+	err := fireHook(hookOnClose, hookPayload{Task: task})
+	if err == nil || !strings.Contains(err.Error(), "exited with status 3") {
+		t.Errorf("fail policy error = %v, want exit status error", err)
+	}
+
+	db.SetConfig(models.ConfigHookFailurePolicy, models.HookFailureIgnore)
+	if err := fireHook(hookOnClose, hookPayload{Task: task}); err != nil {
+		t.Errorf("ignore policy error = %v, want nil", err)
+	}
+}
+
+func TestFireHookTimeout(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	installHook(t, hookOnCreate, "exec sleep 10\n")
+	db.SetConfig(models.ConfigHookTimeout, "100ms")
+	db.SetConfig(models.ConfigHookFailurePolicy, models.HookFailureFail)
+
+	err := fireHook(hookOnCreate, hookPayload{Task: &models.Task{ID: "gur-hook0003"}})
+	if err == nil || !strings.Contains(err.Error(), "timed out after 100ms") {
+		t.Errorf("fireHook() error = %v, want timeout", err)
+	}
+
+	db.SetConfig(models.ConfigHookTimeout, "bogus")
+	if s := loadHookSettings(); s.Timeout != defaultHookTimeout {
+		t.Errorf("invalid timeout loaded as %s, want default %s", s.Timeout, defaultHookTimeout)
+	}
+}
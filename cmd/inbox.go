@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// Triage outcomes of an inbox item
+const (
+	inboxPromoted  = "promoted"
+	inboxDiscarded = "discarded"
+	inboxSkipped   = "skipped"
+)
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "Capture quick thoughts and triage them into tasks later",
+	Long: `The inbox holds items captured without stopping to decide their type,
+priority or labels. Inbox items are hidden from 'gur ready' and 'gur list'
+(see them with 'gur inbox list' or 'gur list --status inbox') and are never
+pushed to GitHub until promoted.
+
+Examples:
+  gur inbox add "cache the label lookups"
+  gur inbox list
+  gur inbox triage`,
+}
+
+var inboxAddCmd = &cobra.Command{
+	Use:   "add <text>",
+	Short: "Capture an item in the inbox",
+	Long: `Capture an item in the inbox. The words are joined into the title; text
+longer than a title is kept whole in the description.
+
+Examples:
+  gur inbox add "thought about caching"
+  gur inbox add flaky login test on CI`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runInboxAdd,
+}
+
+var inboxListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List inbox items, oldest first",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runInboxList,
+}
+
+var inboxTriageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Promote inbox items into tasks or discard them",
+	Long: `Walk the inbox, oldest first, and decide on each item:
+
+  p  Promote it into an open task, prompting for its type, priority and
+     labels (Enter keeps the defaults: task, P2, none)
+  d  Discard it; the item is deleted
+  s  Skip it for now
+  q  End the session
+
+This needs an interactive terminal; in scripts promote an item with
+'gur update <id> --status open'.
+
+Examples:
+  gur inbox triage`,
+	Args: cobra.NoArgs,
+	RunE: runInboxTriage,
+}
+
+func init() {
+	rootCmd.AddCommand(inboxCmd)
+	inboxCmd.AddCommand(inboxAddCmd)
+	inboxCmd.AddCommand(inboxListCmd)
+	inboxCmd.AddCommand(inboxTriageCmd)
+}
+
+func runInboxAdd(cmd *cobra.Command, args []string) error {
+	text := strings.TrimSpace(strings.Join(args, " "))
+	if text == "" {
+		return fmt.Errorf("nothing to capture: give the item's text")
+	}
+	task := &models.Task{
+		Title:    text,
+		Status:   models.StatusInbox,
+		Priority: models.PriorityMedium,
+		Type:     models.TypeTask,
+	}
+	if r := []rune(text); len(r) > 255 {
+		task.Title = string(r[:255])
+		task.Description = text
+	}
+	if err := db.GetDB().Create(task).Error; err != nil {
+		return fmt.Errorf("failed to capture '%s': database error: %w", task.Title, err)
+	}
+	noteAffected(task.ID)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task": task})
+	} else if IsQuietOutput() {
+		printQuietIDs(task.ID)
+	} else {
+		fmt.Printf("Captured: %s - %s\n", task.ID, task.Title)
+	}
+	return nil
+}
+
+// inboxItems returns the items in the inbox, oldest first
+func inboxItems(database *gorm.DB) ([]models.Task, error) {
+	var items []models.Task
+	err := database.Where("status = ?", models.StatusInbox).Order("created_at ASC, id ASC").Find(&items).Error
+	return items, err
+}
+
+func runInboxList(cmd *cobra.Command, args []string) error {
+	items, err := inboxItems(db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to list inbox: database error: %w", err)
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(items), "items": items})
+		return nil
+	}
+	if IsQuietOutput() {
+		for _, item := range items {
+			printQuietIDs(item.ID)
+		}
+		return nil
+	}
+	if len(items) == 0 {
+		fmt.Println("Inbox is empty")
+		return nil
+	}
+	for _, item := range items {
+		fmt.Printf("  [%s] %s  %s\n", item.ID, item.CreatedAt.Format(models.DateFormat), item.Title)
+	}
+	fmt.Printf("\n%d item(s) in the inbox. Run 'gur inbox triage' to sort them.\n", len(items))
+	return nil
+}
+
+// triageInboxItem asks what to do with an inbox item and does it,
+// returning the outcome
+func triageInboxItem(database *gorm.DB, reader *bufio.Reader, out io.Writer, item *models.Task) (string, error) {
+	for {
+		answer, err := groomPrompt(reader, out, "  (p)romote, (d)iscard, (s)kip or (q)uit? ")
+		if err != nil {
+			return "", err
+		}
+		switch strings.ToLower(answer) {
+		case "p", "promote":
+			return inboxPromoted, promoteInboxItem(database, reader, out, item)
+		case "d", "discard":
+			if err := discardInboxItem(database, item.ID); err != nil {
+				return "", fmt.Errorf("failed to discard '%s': database error: %w", item.ID, err)
+			}
+			return inboxDiscarded, nil
+		case "", "s", "skip":
+			return inboxSkipped, nil
+		}
+		fmt.Fprintf(out, "  Unknown answer '%s'\n", answer)
+	}
+}
+
+// promoteInboxItem prompts for the item's type, priority and labels and
+// turns it into an open task
+func promoteInboxItem(database *gorm.DB, reader *bufio.Reader, out io.Writer, item *models.Task) error {
+	for {
+		answer, err := groomPrompt(reader, out, "  Type (task, bug, feature, epic) [task]: ")
+		if err != nil {
+			return err
+		}
+		answer = strings.ToLower(answer)
+		if answer == "" {
+			answer = models.TypeTask
+		}
+		if answer == models.TypeTask || answer == models.TypeBug || answer == models.TypeFeature || answer == models.TypeEpic {
+			item.Type = answer
+			break
+		}
+		fmt.Fprintf(out, "  Invalid type '%s'\n", answer)
+	}
+	for {
+		answer, err := groomPrompt(reader, out, "  Priority (0-4) [2]: ")
+		if err != nil {
+			return err
+		}
+		if answer == "" {
+			break
+		}
+		priority, convErr := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(answer), "P"))
+		if convErr == nil && priority >= 0 && priority <= models.PriorityLowest {
+			item.Priority = priority
+			break
+		}
+		fmt.Fprintf(out, "  Invalid priority '%s' (must be 0 to 4)\n", answer)
+	}
+	answer, err := groomPrompt(reader, out, "  Labels (comma-separated): ")
+	if err != nil {
+		return err
+	}
+	for _, l := range strings.Split(answer, ",") {
+		if l = strings.TrimSpace(l); l != "" && !item.HasLabel(l) {
+			item.AddLabel(l)
+		}
+	}
+
+	err = database.Transaction(func(tx *gorm.DB) error {
+		models.RecordChange(tx, item.ID, "status", item.Status, models.StatusOpen, "user")
+		item.Status = models.StatusOpen
+		return tx.Save(item).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to promote '%s': database error: %w", item.ID, err)
+	}
+	noteAffected(item.ID)
+	return fireHook(hookOnCreate, hookPayload{Task: item})
+}
+
+// discardInboxItem deletes an inbox item and what was recorded about it
+func discardInboxItem(database *gorm.DB, id string) error {
+	return database.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.NoteEntry{}, &models.TaskHistory{}, &models.Reference{}} {
+			if err := tx.Unscoped().Where("task_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("id = ? AND status = ?", id, models.StatusInbox).Delete(&models.Task{}).Error
+	})
+}
+
+func runInboxTriage(cmd *cobra.Command, args []string) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("gur inbox triage prompts for each item and requires an interactive terminal (promote items with 'gur update <id> --status open')")
+	}
+	database := db.GetDB()
+	items, err := inboxItems(database)
+	if err != nil {
+		return fmt.Errorf("failed to list inbox: database error: %w", err)
+	}
+	if len(items) == 0 {
+		fmt.Println("Inbox is empty")
+		return nil
+	}
+
+	fmt.Printf("%d item(s) in the inbox. Press Enter to skip an item, 'q' to stop.\n", len(items))
+	reader := bufio.NewReader(os.Stdin)
+	counts := make(map[string]int)
+	for i := range items {
+		item := &items[i]
+		fmt.Printf("\n[%d/%d] [%s] %s (captured %s)\n", i+1, len(items), item.ID, item.Title, item.CreatedAt.Format(models.DateFormat))
+		outcome, err := triageInboxItem(database, reader, os.Stdout, item)
+		if errors.Is(err, errGroomQuit) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		counts[outcome]++
+		if outcome == inboxPromoted {
+			fmt.Printf("  Promoted: %s - %s (%s, P%d)\n", item.ID, item.Title, item.Type, item.Priority)
+		}
+	}
+	fmt.Printf("\nPromoted %d, discarded %d, skipped %d item(s).\n", counts[inboxPromoted], counts[inboxDiscarded], counts[inboxSkipped])
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestTriageInboxItem(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-inbx0001", Title: "Cache label lookups", Status: models.StatusInbox, Priority: 2, Type: models.TypeTask})
+	database.Create(&models.Task{ID: "gur-inbx0002", Title: "Half a thought", Status: models.StatusInbox, Priority: 2, Type: models.TypeTask})
+	database.Create(&models.Task{ID: "gur-inbx0003", Title: "Later", Status: models.StatusInbox, Priority: 2, Type: models.TypeTask})
+
+	items, err := inboxItems(database)
+	if err != nil || len(items) != 3 {
+		t.Fatalf("inboxItems() = %d items, %v", len(items), err)
+	}
+
+	triage := func(item *models.Task, input string) (string, error) {
+		t.Helper()
+		return triageInboxItem(database, bufio.NewReader(strings.NewReader(input)), io.Discard, item)
+	}
+
+	// An invalid priority is asked again
+	if outcome, err := triage(&items[0], "x\np\nbug\n9\n1\nperf, area/cache\n"); err != nil || outcome != inboxPromoted {
+		t.Fatalf("promote = %s, %v", outcome, err)
+	}
+	task, _ := db.GetTaskByID("gur-inbx0001")
+	if task.Status != models.StatusOpen || task.Type != models.TypeBug || task.Priority != 1 || !task.HasLabel("area/cache") {
+		t.Errorf("promoted task = %+v", task)
+	}
+
+	if outcome, err := triage(&items[1], "d\n"); err != nil || outcome != inboxDiscarded {
+		t.Fatalf("discard = %s, %v", outcome, err)
+	}
+	if _, err := db.GetTaskByID("gur-inbx0002"); err == nil {
+		t.Error("discarded item still exists")
+	}
+
+	if outcome, err := triage(&items[2], "\n"); err != nil || outcome != inboxSkipped {
+		t.Errorf("skip = %s, %v", outcome, err)
+	}
+	if _, err := triage(&items[2], "q\n"); !errors.Is(err, errGroomQuit) {
+		t.Errorf("quit error = %v, want errGroomQuit", err)
+	}
+
+	items, _ = inboxItems(database)
+	if len(items) != 1 || items[0].ID != "gur-inbx0003" {
+		t.Errorf("inbox after triage = %+v, want only the skipped item", items)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 
 	"guardrails/internal/db"
 	"guardrails/internal/models"
@@ -16,12 +18,38 @@ var (
 	forceInit       bool
 	stealthMode     bool
 	contributorMode bool
+	initBackend     string
+	initDSN         string
+	initFromGitHub  string
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize GuardRails in the current directory",
-	RunE:  runInit,
+	Long: `Initialize GuardRails in the current directory.
+
+Tasks are stored in .guardrails/db.sqlite by default. To share one central
+backlog across a team, store them in Postgres instead with --backend
+postgres and a connection string (--dsn, or the GUR_DSN environment
+variable). Each checkout runs the same init: the first creates the schema,
+later ones join the existing backlog. The choice is kept in
+.guardrails/backend.json; a --dsn password is stored there too, so prefer
+GUR_DSN when .guardrails is committed. Postgres support needs a gur built
+with -tags postgres.
+
+For a repository that already tracks its work in GitHub Issues, --from-github
+configures sync with that repository and pulls its open issues in the same
+step: labels map to types, priorities and task labels, milestone due dates
+become due dates, and each issue is linked to its task. The GitHub token
+comes from the keyring or GUR_GITHUB_TOKEN.
+
+Examples:
+  gur init
+  gur init --stealth
+  gur init --from-github acme/webapp
+  gur init --backend postgres --dsn "postgres://gur@db.internal/backlog"
+  GUR_DSN="host=db.internal user=gur password=... dbname=backlog" gur init --backend postgres`,
+	RunE: runInit,
 }
 
 func init() {
@@ -29,6 +57,49 @@ func init() {
 	initCmd.Flags().BoolVarP(&forceInit, "force", "f", false, "Force reinitialize")
 	initCmd.Flags().BoolVar(&stealthMode, "stealth", false, "Initialize in stealth mode (local-only, add to .gitignore)")
 	initCmd.Flags().BoolVar(&contributorMode, "contributor", false, "Initialize in contributor mode (separate tracking)")
+	initCmd.Flags().StringVar(&initBackend, "backend", db.BackendSQLite, "Storage backend: sqlite or postgres")
+	initCmd.Flags().StringVar(&initDSN, "dsn", "", "Connection string for --backend postgres (default: $GUR_DSN)")
+	markSecretFlags(initCmd, "dsn")
+	initCmd.Flags().StringVar(&initFromGitHub, "from-github", "", "Configure sync with this GitHub repository (owner/repo or URL) and pull its issues")
+}
+
+// parseGitHubRepoArg returns owner/repo from "owner/repo" or a GitHub URL
+// (https://github.com/owner/repo, git@github.com:owner/repo.git)
+func parseGitHubRepoArg(s string) (string, error) {
+	repo := strings.TrimSpace(s)
+	if i := strings.Index(repo, "://"); i >= 0 {
+		repo = repo[i+3:]
+		if _, path, ok := strings.Cut(repo, "/"); ok {
+			repo = path
+		}
+	} else if host, path, ok := strings.Cut(repo, ":"); ok && strings.Contains(host, "@") {
+		repo = path
+	}
+	repo = strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid GitHub repository '%s': expected owner/repo or a repository URL", s)
+	}
+	return repo, nil
+}
+
+// importGitHubBacklog configures sync with repo and pulls its open issues
+// into the new backlog; a no-op if repo is empty
+func importGitHubBacklog(cmd *cobra.Command, repo string) error {
+	if repo == "" {
+		return nil
+	}
+	if err := db.SetConfig(models.ConfigGitHubRepo, repo); err != nil {
+		return fmt.Errorf("failed to save repository: %w", err)
+	}
+	if !IsJSONOutput() {
+		fmt.Printf("\nImporting open issues from %s...\n", repo)
+	}
+	syncPullForce, syncPullMilestoneDue = true, true
+	if err := runSyncPull(cmd, nil); err != nil {
+		return fmt.Errorf("initialized, but importing issues from %s failed (fix the problem, then run 'gur sync pull --milestone-due'): %w", repo, err)
+	}
+	return nil
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -36,17 +107,103 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
+
+	// Determine mode
+	mode := models.ModeDefault
+	if stealthMode {
+		mode = models.ModeStealth
+	} else if contributorMode {
+		mode = models.ModeContributor
+	}
+
+	if err := db.ValidateBackend(initBackend); err != nil {
+		return err
+	}
+	backend := db.Backend{Name: initBackend, DSN: initDSN}
+	if backend.Name != db.BackendSQLite {
+		if db.DBPathOverride() != "" {
+			return fmt.Errorf("--db names a SQLite file and can't be combined with --backend %s", backend.Name)
+		}
+		if backend.DSN == "" {
+			backend.DSN = os.Getenv(db.EnvDSN)
+		}
+		if backend.DSN == "" {
+			return fmt.Errorf("--backend %s needs a connection string: give --dsn or set %s", backend.Name, db.EnvDSN)
+		}
+	} else if initDSN != "" {
+		return fmt.Errorf("--dsn is only used with --backend postgres")
+	}
+
+	// Check the import can run before creating anything
+	var fromRepo string
+	if initFromGitHub != "" {
+		if fromRepo, err = parseGitHubRepoArg(initFromGitHub); err != nil {
+			return err
+		}
+		if _, err := GetGitHubToken(); err != nil {
+			return codedErrorf(ErrCodeNotConfigured, "cannot import from GitHub: no token found: set GUR_GITHUB_TOKEN, or run 'gur init', then 'gur config github --repo %s' and 'gur sync pull'", fromRepo)
+		}
+	}
+
+	// --db initializes a standalone database file
+	if dbPath := db.DBPathOverride(); dbPath != "" {
+		if _, err := os.Stat(dbPath); err == nil {
+			if !forceInit {
+				return fmt.Errorf("already initialized. Use --force to reinitialize")
+			}
+			if err := os.Remove(dbPath); err != nil {
+				return fmt.Errorf("failed to remove existing database: %w", err)
+			}
+		}
+		database, err := db.InitDB(dbPath)
+		if err != nil {
+			return err
+		}
+		if err := seedDatabase(database, mode); err != nil {
+			return err
+		}
+		if !IsJSONOutput() {
+			fmt.Printf("GuardRails initialized in %s\n", dbPath)
+		} else if fromRepo == "" {
+			OutputJSON(map[string]interface{}{"success": true, "path": dbPath, "mode": mode})
+		}
+		return importGitHubBacklog(cmd, fromRepo)
+	}
+
 	guardrailsDir := filepath.Join(cwd, db.GuardrailsDir)
 	dbPath := filepath.Join(guardrailsDir, db.DBFileName)
 
+	// Connect to a shared backend before touching the local directory, so a
+	// bad connection string leaves an existing setup alone
+	var database *gorm.DB
+	if backend.Name != db.BackendSQLite {
+		if database, err = db.InitBackend(backend); err != nil {
+			return err
+		}
+	}
+
 	// Check if already initialized
 	if info, err := os.Stat(guardrailsDir); err == nil && info.IsDir() {
 		if !forceInit {
 			return fmt.Errorf("already initialized. Use --force to reinitialize")
 		}
-		// Remove existing guardrails directory
-		if err := os.RemoveAll(guardrailsDir); err != nil {
-			return fmt.Errorf("failed to remove existing guardrails directory: %w", err)
+		release, err := db.AcquireLock(cwd, "gur init --force")
+		if err != nil {
+			return fmt.Errorf("cannot reinitialize: %w", err)
+		}
+		defer release()
+		// Remove the existing guardrails directory, except the lock we hold
+		entries, err := os.ReadDir(guardrailsDir)
+		if err != nil {
+			return fmt.Errorf("failed to read existing guardrails directory: %w", err)
+		}
+		for _, e := range entries {
+			if e.Name() == db.LockFileName {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Join(guardrailsDir, e.Name())); err != nil {
+				return fmt.Errorf("failed to remove existing guardrails directory: %w", err)
+			}
 		}
 	}
 
@@ -55,27 +212,31 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create guardrails directory: %w", err)
 	}
 
-	database, err := db.InitDB(dbPath)
-	if err != nil {
-		return err
-	}
-
-	if err := database.Create(&models.Config{Key: models.ConfigSchemaVersion, Value: db.SchemaVersion}).Error; err != nil {
-		return fmt.Errorf("failed to save schema version: %w", err)
-	}
-	if err := database.Create(&models.Config{Key: models.ConfigInitializedAt, Value: time.Now().Format(time.RFC3339)}).Error; err != nil {
-		return fmt.Errorf("failed to save initialization time: %w", err)
-	}
-
-	// Determine and save mode
-	mode := models.ModeDefault
-	if stealthMode {
-		mode = models.ModeStealth
-	} else if contributorMode {
-		mode = models.ModeContributor
+	joined := false
+	if database == nil {
+		if database, err = db.InitDB(dbPath); err != nil {
+			return err
+		}
+	} else {
+		// Only --dsn is stored; a connection string from GUR_DSN stays there
+		stored := db.Backend{Name: backend.Name, DSN: initDSN}
+		if err := db.SaveBackend(cwd, stored); err != nil {
+			return fmt.Errorf("failed to save backend: %w", err)
+		}
+		if strings.Contains(db.RedactDSN(initDSN), "xxxxx") {
+			fmt.Fprintf(os.Stderr, "Warning: the connection string's password is stored in %s/%s; prefer setting %s\n", db.GuardrailsDir, db.BackendFileName, db.EnvDSN)
+		}
+		// Later checkouts join the backlog the first one set up
+		var seeded int64
+		if err := database.Model(&models.Config{}).Where("key = ?", models.ConfigSchemaVersion).Count(&seeded).Error; err != nil {
+			return fmt.Errorf("failed to check existing backlog: database error: %w", err)
+		}
+		joined = seeded > 0
 	}
-	if err := database.Create(&models.Config{Key: models.ConfigMode, Value: mode}).Error; err != nil {
-		return fmt.Errorf("failed to save mode: %w", err)
+	if !joined {
+		if err := seedDatabase(database, mode); err != nil {
+			return err
+		}
 	}
 
 	// In stealth mode, add .guardrails to .gitignore
@@ -87,7 +248,15 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"success": true, "path": guardrailsDir, "mode": mode})
+		// The pull's result is the output of an import
+		if fromRepo != "" {
+			return importGitHubBacklog(cmd, fromRepo)
+		}
+		result := map[string]interface{}{"success": true, "path": guardrailsDir, "mode": mode, "backend": backend.Name}
+		if backend.Name != db.BackendSQLite {
+			result["joined"] = joined
+		}
+		OutputJSON(result)
 		return nil
 	}
 
@@ -95,7 +264,18 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if mode != models.ModeDefault {
 		modeStr = fmt.Sprintf(" (mode: %s)", mode)
 	}
-	fmt.Printf("GuardRails initialized in %s/%s\n", db.GuardrailsDir, modeStr)
+	switch {
+	case joined:
+		fmt.Printf("GuardRails initialized in %s/, joining the existing %s backlog at %s\n", db.GuardrailsDir, backend.Name, db.RedactDSN(backend.DSN))
+	case backend.Name != db.BackendSQLite:
+		fmt.Printf("GuardRails initialized in %s/ with the %s backend at %s%s\n", db.GuardrailsDir, backend.Name, db.RedactDSN(backend.DSN), modeStr)
+	default:
+		fmt.Printf("GuardRails initialized in %s/%s\n", db.GuardrailsDir, modeStr)
+	}
+
+	if fromRepo != "" {
+		return importGitHubBacklog(cmd, fromRepo)
+	}
 
 	// Detect git repo and offer helpful next steps
 	isGitRepo := false
@@ -113,6 +293,20 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// seedDatabase stores the configuration every new database starts with
+func seedDatabase(database *gorm.DB, mode string) error {
+	if err := database.Create(&models.Config{Key: models.ConfigSchemaVersion, Value: db.SchemaVersion}).Error; err != nil {
+		return fmt.Errorf("failed to save schema version: %w", err)
+	}
+	if err := database.Create(&models.Config{Key: models.ConfigInitializedAt, Value: time.Now().Format(time.RFC3339)}).Error; err != nil {
+		return fmt.Errorf("failed to save initialization time: %w", err)
+	}
+	if err := database.Create(&models.Config{Key: models.ConfigMode, Value: mode}).Error; err != nil {
+		return fmt.Errorf("failed to save mode: %w", err)
+	}
+	return nil
+}
+
 func addToGitignore(dir, entry string) error {
 	gitignorePath := filepath.Join(dir, ".gitignore")
 
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/models"
+)
+
+func TestParseGitHubRepoArg(t *testing.T) {
+	for in, want := range map[string]string{
+		"acme/webapp":                          "acme/webapp",
+		"https://github.com/acme/webapp":       "acme/webapp",
+		"https://github.com/acme/webapp.git":   "acme/webapp",
+		"git@github.com:acme/webapp.git":       "acme/webapp",
+		"https://ghe.example.com/acme/webapp/": "acme/webapp",
+		"ssh://git@github.com/acme/webapp.git": "acme/webapp",
+	} {
+		if got, err := parseGitHubRepoArg(in); err != nil || got != want {
+			t.Errorf("parseGitHubRepoArg(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"webapp", "acme/webapp/issues", "https://github.com/acme"} {
+		if _, err := parseGitHubRepoArg(bad); err == nil {
+			t.Errorf("parseGitHubRepoArg(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestApplyMilestoneDue(t *testing.T) {
+	dueOn := time.Date(2025, 9, 30, 7, 0, 0, 0, time.UTC)
+	issue := &github.Issue{Milestone: &github.Milestone{Title: github.String("Q3"), DueOn: &github.Timestamp{Time: dueOn}}}
+
+	task := &models.Task{}
+	applyMilestoneDue(task, issue)
+	if task.DueString() != "2025-09-30" {
+		t.Errorf("due = %q, want the milestone's due date 2025-09-30, which push maps back to it", task.DueString())
+	}
+
+	own := time.Date(2025, 8, 1, 0, 0, 0, 0, time.Local)
+	task = &models.Task{DueAt: &own}
+	applyMilestoneDue(task, issue)
+	if task.DueString() != "2025-08-01" {
+		t.Errorf("milestone overrode the task's own due date: %q", task.DueString())
+	}
+
+	task = &models.Task{}
+	applyMilestoneDue(task, &github.Issue{})
+	if task.DueAt != nil {
+		t.Error("an issue without a milestone set a due date")
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// charsPerToken approximates how many characters make a token, close enough
+// to keep a prompt within a model's context
+const charsPerToken = 4
+
+// minInstructionTokens is the least room worth cutting a file down to;
+// with less, the file is only listed by path
+const minInstructionTokens = 200
+
+var (
+	instructionsBudget int
+	instructionsOutput string
+)
+
+var instructionsCmd = &cobra.Command{
+	Use:         "instructions <task-id>",
+	Short:       "Print a prompt that bootstraps an agent for a task",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Print one prompt block with everything a worker agent needs for a task: the
+files of its linked agents (primary first) and skills, followed by the task
+brief.
+
+The prompt is kept within --budget tokens (estimated at 4 characters each).
+The brief is filled first, then agent files, then skill files; a file that
+doesn't fit whole is cut at a line, and files left out entirely are listed
+by path so the agent can read them itself. YAML frontmatter is dropped.
+
+Examples:
+  gur instructions gur-abc123 | claude -p
+  gur instructions gur-abc123 --budget 4000 -o prompt.md
+  gur instructions gur-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInstructions,
+}
+
+func init() {
+	rootCmd.AddCommand(instructionsCmd)
+	instructionsCmd.Flags().IntVar(&instructionsBudget, "budget", 8000, "Maximum size of the prompt in tokens")
+	instructionsCmd.Flags().StringVarP(&instructionsOutput, "output", "o", "", "Write to file instead of stdout")
+}
+
+// instructionSource is a part of the prompt: a linked agent or skill file,
+// or the task brief
+type instructionSource struct {
+	Kind      string `json:"kind"` // agent, skill or task
+	Name      string `json:"name"`
+	Path      string `json:"path,omitempty"`
+	Tokens    int    `json:"tokens"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Omitted   bool   `json:"omitted,omitempty"` // Left out for the budget
+	Error     string `json:"error,omitempty"`   // The file couldn't be read
+
+	content string
+}
+
+// estimateTokens returns the approximate number of tokens in s
+func estimateTokens(s string) int {
+	return (len(s) + charsPerToken - 1) / charsPerToken
+}
+
+// fitTokens cuts s at a line so it takes at most budget tokens, reporting
+// whether it was cut
+func fitTokens(s string, budget int) (string, bool) {
+	if estimateTokens(s) <= budget {
+		return s, false
+	}
+	limit := budget * charsPerToken
+	if limit <= 0 {
+		return "", true
+	}
+	cut := s[:limit]
+	if i := strings.LastIndex(cut, "\n"); i > 0 {
+		cut = cut[:i+1]
+	}
+	return strings.TrimRight(cut, " \t\n") + "\n", true
+}
+
+// stripFrontmatter drops a leading YAML frontmatter block
+func stripFrontmatter(s string) string {
+	if !strings.HasPrefix(s, "---\n") {
+		return s
+	}
+	end := strings.Index(s[4:], "\n---")
+	if end < 0 {
+		return s
+	}
+	rest := s[4+end+4:]
+	return strings.TrimLeft(strings.TrimPrefix(rest, "\n"), "\n")
+}
+
+// resolveInstructionPath expands a leading ~ in a registered path
+func resolveInstructionPath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+// collectInstructionSources returns the task's linked agents, primary first,
+// then its skills, with the contents of their files
+func collectInstructionSources(database *gorm.DB, taskID string) ([]instructionSource, error) {
+	var agentLinks []models.TaskAgentLink
+	if err := database.Preload("Agent").Where("task_id = ?", taskID).Order("is_primary DESC, id ASC").Find(&agentLinks).Error; err != nil {
+		return nil, err
+	}
+	var skillLinks []models.TaskSkillLink
+	if err := database.Preload("Skill").Where("task_id = ?", taskID).Order("id ASC").Find(&skillLinks).Error; err != nil {
+		return nil, err
+	}
+
+	var sources []instructionSource
+	add := func(kind, name, path string) {
+		src := instructionSource{Kind: kind, Name: name, Path: path}
+		if path == "" {
+			src.Error = "no file registered"
+		} else if data, err := os.ReadFile(resolveInstructionPath(path)); err != nil {
+			src.Error = err.Error()
+		} else {
+			src.content = strings.TrimSpace(stripFrontmatter(string(data)))
+		}
+		sources = append(sources, src)
+	}
+	for _, l := range agentLinks {
+		add("agent", l.Agent.Name, l.Agent.Path)
+	}
+	for _, l := range skillLinks {
+		add("skill", l.Skill.Name, l.Skill.Path)
+	}
+	return sources, nil
+}
+
+// buildInstructions fits the brief and then the files into budget tokens
+// and joins them into one prompt, files first
+func buildInstructions(brief string, files []instructionSource, budget int) (string, []instructionSource) {
+	const alsoRead = "# Also read\n\nThese files were left out to save space:\n\n"
+
+	task := instructionSource{Kind: "task", Name: "brief"}
+	task.content, task.Truncated = fitTokens(brief, budget)
+	task.Tokens = estimateTokens(task.content)
+	remaining := budget - task.Tokens
+
+	var sections, omitted []string
+	for i := range files {
+		f := &files[i]
+		if f.Error != "" {
+			continue
+		}
+		heading := fmt.Sprintf("# %s: %s\n\nFrom `%s`\n\n", strings.ToUpper(f.Kind[:1])+f.Kind[1:], f.Name, f.Path)
+		marker := fmt.Sprintf("\n[... cut to fit; read the rest in %s]\n", f.Path)
+		// One more token for the line joining the sections
+		available := remaining - estimateTokens(heading) - 1
+		if available < estimateTokens(f.content) && available-estimateTokens(marker) < minInstructionTokens {
+			f.Omitted = true
+			line := fmt.Sprintf("- %s %s: `%s`\n", f.Kind, f.Name, f.Path)
+			if len(omitted) == 0 {
+				remaining -= estimateTokens(alsoRead) + 1
+			}
+			omitted = append(omitted, line)
+			remaining -= estimateTokens(line)
+			continue
+		}
+		content, cut := fitTokens(f.content, available)
+		if cut {
+			content, _ = fitTokens(f.content, available-estimateTokens(marker))
+			content += marker
+			f.Truncated = true
+		}
+		section := heading + strings.TrimRight(content, "\n") + "\n"
+		f.Tokens = estimateTokens(section)
+		remaining -= f.Tokens + 1
+		sections = append(sections, section)
+	}
+	if len(omitted) > 0 {
+		sections = append(sections, alsoRead+strings.Join(omitted, ""))
+	}
+	sections = append(sections, task.content)
+	return strings.Join(sections, "\n"), append(files, task)
+}
+
+func runInstructions(cmd *cobra.Command, args []string) error {
+	if instructionsBudget <= 0 {
+		return fmt.Errorf("invalid budget %d: must be a positive number of tokens", instructionsBudget)
+	}
+	b, err := collectTaskBrief(args[0])
+	if err != nil {
+		return err
+	}
+	// The history is for people; the agent needs the task as it is now
+	b.History = nil
+
+	files, err := collectInstructionSources(db.GetDB(), b.Task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load agents and skills for task '%s': database error: %w", b.Task.ID, err)
+	}
+	for _, f := range files {
+		if f.Error != "" {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s %s: %s\n", f.Kind, f.Name, f.Error)
+		}
+	}
+	prompt, sources := buildInstructions(renderBriefMarkdown(b), files, instructionsBudget)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"task_id": b.Task.ID,
+			"budget":  instructionsBudget,
+			"tokens":  estimateTokens(prompt),
+			"sources": sources,
+			"prompt":  prompt,
+		})
+		return nil
+	}
+	if instructionsOutput != "" {
+		if err := os.WriteFile(instructionsOutput, []byte(prompt), 0644); err != nil {
+			return fmt.Errorf("failed to write instructions to %s: %w", instructionsOutput, err)
+		}
+		fmt.Printf("Wrote instructions for %s to %s (~%d tokens)\n", b.Task.ID, instructionsOutput, estimateTokens(prompt))
+		return nil
+	}
+	fmt.Print(prompt)
+	return nil
+}
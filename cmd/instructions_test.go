@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestFitTokens(t *testing.T) {
+	s := strings.Repeat("line of text\n", 10) // 130 characters
+	if got, cut := fitTokens(s, 100); cut || got != s {
+		t.Errorf("fitTokens() cut text within the budget")
+	}
+	got, cut := fitTokens(s, 10)
+	if !cut || estimateTokens(got) > 10 || !strings.HasSuffix(got, "text\n") {
+		t.Errorf("fitTokens(10) = %q, %v; want whole lines within 10 tokens", got, cut)
+	}
+}
+
+func TestStripFrontmatter(t *testing.T) {
+	if got := stripFrontmatter("---\nname: x\ndescription: y\n---\n\n# Body\n"); got != "# Body\n" {
+		t.Errorf("stripFrontmatter() = %q", got)
+	}
+	if got := stripFrontmatter("# No frontmatter\n---\n"); got != "# No frontmatter\n---\n" {
+		t.Errorf("stripFrontmatter() changed text without frontmatter: %q", got)
+	}
+}
+
+func TestInstructions(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0644)
+		return path
+	}
+	agentPath := write("reviewer.md", "---\nname: reviewer\n---\nYou review Go code.\n")
+	skillPath := write("SKILL.md", strings.Repeat("Write table-driven tests.\n", 400))
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-inst0001", Title: "Fix login", Status: models.StatusOpen})
+	database.Create(&models.Agent{ID: 1, Name: "helper", Path: filepath.Join(dir, "missing.md")})
+	database.Create(&models.Agent{ID: 2, Name: "reviewer", Path: agentPath})
+	database.Create(&models.Skill{ID: 1, Name: "go-testing", Path: skillPath})
+	database.Create(&models.TaskAgentLink{TaskID: "gur-inst0001", AgentID: 1})
+	database.Create(&models.TaskAgentLink{TaskID: "gur-inst0001", AgentID: 2, IsPrimary: true})
+	database.Create(&models.TaskSkillLink{TaskID: "gur-inst0001", SkillID: 1})
+
+	files, err := collectInstructionSources(database, "gur-inst0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 || files[0].Name != "reviewer" || files[1].Error == "" || files[2].Kind != "skill" {
+		t.Fatalf("sources = %+v, want the primary agent first and the missing file flagged", files)
+	}
+
+	brief := "# Fix login\n\nThe login form rejects valid passwords.\n"
+	prompt, sources := buildInstructions(brief, files, 1000)
+	if estimateTokens(prompt) > 1000 {
+		t.Errorf("prompt is %d tokens, over the budget of 1000", estimateTokens(prompt))
+	}
+	if !strings.HasPrefix(prompt, "# Agent: reviewer") || strings.Contains(prompt, "name: reviewer") {
+		t.Errorf("prompt should open with the agent file, without its frontmatter:\n%s", prompt)
+	}
+	if !strings.HasSuffix(prompt, brief) {
+		t.Error("prompt should end with the brief")
+	}
+	if skill := sources[2]; !skill.Truncated || !strings.Contains(prompt, "read the rest in "+skillPath) {
+		t.Errorf("skill source = %+v, want it cut with a pointer to the file", skill)
+	}
+
+	// Without room, files are listed instead
+	prompt, sources = buildInstructions(brief, files, 40)
+	if !sources[2].Omitted || !strings.Contains(prompt, "- skill go-testing: `"+skillPath+"`") {
+		t.Errorf("prompt with a small budget should list the files:\n%s", prompt)
+	}
+}
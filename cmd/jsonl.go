@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// jsonlBatchSize is how many tasks are read before their custom fields are
+// loaded and the batch is written out
+const jsonlBatchSize = 100
+
+// jsonlOutput is set by --jsonl on commands that support streaming output
+var jsonlOutput bool
+
+// addJSONLFlag registers --jsonl on a list-style command
+func addJSONLFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&jsonlOutput, "jsonl", false, "Stream one JSON record per line (JSON Lines)")
+}
+
+// jsonlWriter writes compact JSON records one per line. With fields set,
+// each record is reduced to those keys as with --fields.
+type jsonlWriter struct {
+	enc    *json.Encoder
+	fields []string
+	count  int
+}
+
+// newJSONLWriter returns a writer to w (os.Stdout if nil)
+func newJSONLWriter(w io.Writer, fields []string) *jsonlWriter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &jsonlWriter{enc: json.NewEncoder(w), fields: fields}
+}
+
+// write encodes one record on its own line
+func (w *jsonlWriter) write(record interface{}) error {
+	w.count++
+	if len(w.fields) == 0 {
+		return w.enc.Encode(record)
+	}
+	rows, err := (&pageOptions{fields: w.fields}).project([]interface{}{record})
+	if err != nil {
+		return err
+	}
+	return w.enc.Encode(rows[0])
+}
+
+// streamRows scans query row by row into a T and passes each to fn, so
+// records are produced in query order without loading the full result
+func streamRows[T any](database *gorm.DB, query *gorm.DB, fn func(T) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item T
+		if err := database.ScanRows(rows, &item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// streamTasksJSONL writes the tasks of query as JSON Lines, attaching custom
+// field values a batch at a time
+func streamTasksJSONL(database *gorm.DB, query *gorm.DB, w *jsonlWriter) error {
+	batch := make([]models.Task, 0, jsonlBatchSize)
+	flush := func() error {
+		if err := attachFieldValues(database, batch); err != nil {
+			return err
+		}
+		for _, t := range batch {
+			if err := w.write(t); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+	err := streamRows(database, query, func(t models.Task) error {
+		batch = append(batch, t)
+		if len(batch) < jsonlBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestStreamTasksJSONL(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	// More than one batch, so field values must be attached per batch
+	n := jsonlBatchSize + 5
+	field := models.CustomField{Name: "team", Type: models.FieldTypeString}
+	database.Create(&field)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("gur-jsonl%03d", i)
+		database.Create(&models.Task{ID: id, Title: fmt.Sprintf("Task %d", i), Status: models.StatusOpen, Priority: 2})
+		database.Create(&models.TaskFieldValue{TaskID: id, FieldID: field.ID, Value: fmt.Sprintf("t%d", i)})
+	}
+
+	var buf bytes.Buffer
+	query := database.Model(&models.Task{}).Order("id ASC")
+	w := newJSONLWriter(&buf, []string{"id", "fields"})
+	if err := streamTasksJSONL(database, query, w); err != nil {
+		t.Fatalf("streamTasksJSONL: %v", err)
+	}
+	if w.count != n {
+		t.Errorf("count = %d, want %d", w.count, n)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	i := 0
+	for scanner.Scan() {
+		var row map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatalf("line %d is not JSON: %v", i+1, err)
+		}
+		if len(row) != 2 {
+			t.Errorf("line %d has keys %v, want id and fields", i+1, row)
+		}
+		if want := fmt.Sprintf("gur-jsonl%03d", i); row["id"] != want {
+			t.Errorf("line %d id = %v, want %s", i+1, row["id"], want)
+		}
+		fields, _ := row["fields"].(map[string]interface{})
+		if want := fmt.Sprintf("t%d", i); fields["team"] != want {
+			t.Errorf("line %d fields = %v, want team=%s", i+1, row["fields"], want)
+		}
+		i++
+	}
+	if i != n {
+		t.Errorf("got %d lines, want %d", i, n)
+	}
+}
+
+func TestStreamRowsKeepsOrder(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	for i, field := range []string{"title", "status", "priority"} {
+		models.RecordChange(database, "gur-a1b2c3d4", field, "", fmt.Sprint(i), "")
+	}
+
+	var got []string
+	err := streamRows(database, database.Model(&models.TaskHistory{}).Order("new_value DESC"), func(h models.TaskHistory) error {
+		got = append(got, h.Field)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("streamRows: %v", err)
+	}
+	if fmt.Sprint(got) != "[priority status title]" {
+		t.Errorf("streamRows order = %v", got)
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var labelRenameApply bool
+
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Manage labels across tasks",
+}
+
+var labelRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a label on every task that carries it",
+	Long: `Rename a label on every task that carries it, in one transaction.
+
+Without --apply the affected tasks are listed but nothing changes. Tasks
+that already carry the new label just lose the old one. Each change is
+recorded in the task's history, so 'gur undo' reverts the rename.
+
+Examples:
+  gur label rename frontend ui
+  gur label rename frontend ui --apply`,
+	Args: cobra.ExactArgs(2),
+	RunE: runLabelRename,
+}
+
+func init() {
+	rootCmd.AddCommand(labelCmd)
+	labelCmd.AddCommand(labelRenameCmd)
+	labelRenameCmd.Flags().BoolVar(&labelRenameApply, "apply", false, "Apply the rename (default: preview only)")
+}
+
+// tasksWithLabel returns the tasks carrying label
+func tasksWithLabel(database *gorm.DB, label string) ([]models.Task, error) {
+	var candidates []models.Task
+	// Labels are stored as a JSON array; narrow with LIKE, then match exactly
+	if err := database.Where("labels LIKE ?", "%"+fmt.Sprintf("%q", label)+"%").Order("id ASC").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	var tasks []models.Task
+	for _, t := range candidates {
+		if t.HasLabel(label) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+// labelsInUse returns the distinct labels carried by tasks, sorted
+func labelsInUse(database *gorm.DB) ([]string, error) {
+	var sets []models.StringSlice
+	if err := database.Model(&models.Task{}).Where("labels IS NOT NULL AND labels != ''").Distinct().Pluck("labels", &sets).Error; err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var labels []string
+	for _, set := range sets {
+		for _, l := range set {
+			if !seen[l] {
+				seen[l] = true
+				labels = append(labels, l)
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+// labelCondition returns a SQL condition on tasks carrying a label matching
+// pattern: a label, or a glob such as area/* resolved against the labels in
+// use. Labels are stored as a JSON array, so each is matched with LIKE.
+func labelCondition(database *gorm.DB, pattern string) (string, []interface{}, error) {
+	if !models.IsLabelPattern(pattern) {
+		return "labels LIKE ?", []interface{}{"%" + fmt.Sprintf("%q", pattern) + "%"}, nil
+	}
+	if err := models.ValidateLabelPattern(pattern); err != nil {
+		return "", nil, fmt.Errorf("invalid label pattern '%s': %w", pattern, err)
+	}
+	inUse, err := labelsInUse(database)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read labels: database error: %w", err)
+	}
+	var conds []string
+	var args []interface{}
+	for _, l := range inUse {
+		if models.MatchLabel(pattern, l) {
+			conds = append(conds, "labels LIKE ?")
+			args = append(args, "%"+fmt.Sprintf("%q", l)+"%")
+		}
+	}
+	if len(conds) == 0 {
+		return "1 = 0", nil, nil
+	}
+	return "(" + strings.Join(conds, " OR ") + ")", args, nil
+}
+
+// whereLabels keeps tasks carrying a label matching each of patterns
+func whereLabels(database, query *gorm.DB, patterns []string) (*gorm.DB, error) {
+	for _, p := range patterns {
+		cond, args, err := labelCondition(database, strings.TrimSpace(p))
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(cond, args...)
+	}
+	return query, nil
+}
+
+// renameLabel replaces oldLabel with newLabel on tasks, keeping its
+// position, and records the change in each task's history
+func renameLabel(tx *gorm.DB, tasks []models.Task, oldLabel, newLabel string) error {
+	for i := range tasks {
+		task := &tasks[i]
+		if err := models.RecordChange(tx, task.ID, "label_removed", oldLabel, "", "user"); err != nil {
+			return err
+		}
+		if task.HasLabel(newLabel) {
+			task.RemoveLabel(oldLabel)
+		} else {
+			if err := models.RecordChange(tx, task.ID, "label_added", "", newLabel, "user"); err != nil {
+				return err
+			}
+			for j, l := range task.Labels {
+				if l == oldLabel {
+					task.Labels[j] = newLabel
+				}
+			}
+		}
+		if err := tx.Save(task).Error; err != nil {
+			return fmt.Errorf("failed to update task '%s': database error: %w", task.ID, err)
+		}
+	}
+	return nil
+}
+
+func runLabelRename(cmd *cobra.Command, args []string) error {
+	oldLabel, newLabel := args[0], args[1]
+	if newLabel == "" || newLabel == oldLabel {
+		return fmt.Errorf("invalid new label '%s': must differ from '%s'", newLabel, oldLabel)
+	}
+
+	database := db.GetDB()
+	tasks, err := tasksWithLabel(database, oldLabel)
+	if err != nil {
+		return fmt.Errorf("failed to find tasks: database error: %w", err)
+	}
+
+	if labelRenameApply && len(tasks) > 0 {
+		if err := database.Transaction(func(tx *gorm.DB) error {
+			return renameLabel(tx, tasks, oldLabel, newLabel)
+		}); err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			noteAffected(t.ID)
+		}
+	}
+
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "applied": labelRenameApply, "from": oldLabel, "to": newLabel, "tasks": ids, "count": len(ids)})
+		return nil
+	}
+
+	if len(tasks) == 0 {
+		fmt.Printf("No tasks have label '%s'\n", oldLabel)
+		return nil
+	}
+	verb := "Would rename"
+	if labelRenameApply {
+		verb = "Renamed"
+	}
+	fmt.Printf("%s label '%s' to '%s' on %d task(s):\n", verb, oldLabel, newLabel, len(tasks))
+	for _, t := range tasks {
+		fmt.Printf("  %s  %s\n", t.ID, t.Title)
+	}
+	if !labelRenameApply {
+		fmt.Println("\nRun again with --apply to rename.")
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestRenameLabel(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-label001", Title: "A", Status: models.StatusOpen, Labels: models.StringSlice{"backend", "frontend", "urgent"}})
+	database.Create(&models.Task{ID: "gur-label002", Title: "B", Status: models.StatusClosed, Labels: models.StringSlice{"frontend", "ui"}})
+	database.Create(&models.Task{ID: "gur-label003", Title: "C", Status: models.StatusOpen, Labels: models.StringSlice{"frontend-legacy"}})
+
+	tasks, err := tasksWithLabel(database, "frontend")
+	if err != nil {
+		t.Fatalf("tasksWithLabel() error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("tasksWithLabel() returned %d tasks, want 2 (exact matches only)", len(tasks))
+	}
+
+	if err := renameLabel(database, tasks, "frontend", "ui"); err != nil {
+		t.Fatalf("renameLabel() error: %v", err)
+	}
+
+	want := map[string][]string{
+		"gur-label001": {"backend", "ui", "urgent"},
+		"gur-label002": {"ui"},
+		"gur-label003": {"frontend-legacy"},
+	}
+	for id, labels := range want {
+		task, _ := db.GetTaskByID(id)
+		if !reflect.DeepEqual([]string(task.Labels), labels) {
+			t.Errorf("%s labels = %v, want %v", id, task.Labels, labels)
+		}
+	}
+
+	var history []models.TaskHistory
+	database.Where("task_id = ?", "gur-label001").Order("changed_at ASC").Find(&history)
+	if len(history) != 2 || history[0].Field != "label_removed" || history[1].Field != "label_added" || history[1].NewValue != "ui" {
+		t.Errorf("history = %+v, want label_removed frontend then label_added ui", history)
+	}
+	var added int64
+	database.Model(&models.TaskHistory{}).Where("task_id = ? AND field = ?", "gur-label002", "label_added").Count(&added)
+	if added != 0 {
+		t.Error("a task that already had the new label should only record the removal")
+	}
+}
+
+func TestWhereLabels(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-w1000001", Title: "Login", Status: models.StatusOpen, Labels: models.StringSlice{"area/auth", "team/platform"}})
+	database.Create(&models.Task{ID: "gur-w1000002", Title: "Endpoint", Status: models.StatusOpen, Labels: models.StringSlice{"area/api"}})
+	database.Create(&models.Task{ID: "gur-w1000003", Title: "Guide", Status: models.StatusClosed, Labels: models.StringSlice{"docs"}})
+
+	ids := func(patterns ...string) []string {
+		query, err := whereLabels(database, database.Model(&models.Task{}), patterns)
+		if err != nil {
+			t.Fatalf("whereLabels(%v) error: %v", patterns, err)
+		}
+		var got []string
+		query.Order("id ASC").Pluck("id", &got)
+		return got
+	}
+	if got := ids("area/*"); !reflect.DeepEqual(got, []string{"gur-w1000001", "gur-w1000002"}) {
+		t.Errorf("area/* = %v", got)
+	}
+	if got := ids("area/*", "team/*"); !reflect.DeepEqual(got, []string{"gur-w1000001"}) {
+		t.Errorf("area/* and team/* = %v", got)
+	}
+	if got := ids("docs"); !reflect.DeepEqual(got, []string{"gur-w1000003"}) {
+		t.Errorf("docs = %v", got)
+	}
+	if got := ids("ops/*"); len(got) != 0 {
+		t.Errorf("ops/* = %v, want none", got)
+	}
+	if _, err := whereLabels(database, database, []string{"area/["}); err == nil {
+		t.Error("whereLabels(area/[) should fail")
+	}
+
+	stats, err := collectLabelStats(database)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Labels["area/auth"] != 1 || stats.Labels["docs"] != 0 || stats.Namespaces["area"] != 2 || stats.Namespaces["team"] != 1 {
+		t.Errorf("collectLabelStats() = %+v", stats)
+	}
+}
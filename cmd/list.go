@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -10,20 +12,43 @@ import (
 )
 
 var (
-	listStatus   string
-	listPriority int
-	listType     string
-	listAssignee string
-	listArchived bool
-	listLimit    int
-	listOffset   int
+	listStatus       string
+	listPriority     int
+	listType         string
+	listAssignee     string
+	listAssigneeKind string
+	listArchived     bool
+	listOverdue      bool
+	listResolved     string
+	listRelease      string
+	listFields       []string
+	listLabels       []string
+	listPage         pageOptions
 )
 
 var listCmd = &cobra.Command{
-	Use:     "list",
-	Short:   "List tasks",
-	Aliases: []string{"ls"},
-	RunE:    runList,
+	Use:         "list",
+	Short:       "List tasks",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Aliases:     []string{"ls"},
+	Long: `List tasks, highest priority first.
+
+--label takes a label or a glob over namespaced labels (area/*, */auth);
+repeat it to require several.
+
+Use --limit/--page (or --offset) to page through large databases, --sort to
+order by priority, created, updated, due or votes, and --fields to output
+only some fields, e.g. --fields id,title,status.
+
+Examples:
+  gur list --status open --limit 20
+  gur list --resolution wontfix --archived
+  gur list --status inbox
+  gur list --label area/* --label team/platform
+  gur list --page 2 --limit 50 --sort updated
+  gur list --fields id,title,status --json
+  gur list --jsonl | jq -r .id`,
+	RunE: runList,
 }
 
 func init() {
@@ -32,19 +57,41 @@ func init() {
 	listCmd.Flags().IntVarP(&listPriority, "priority", "p", -1, "Filter by priority")
 	listCmd.Flags().StringVarP(&listType, "type", "t", "", "Filter by type")
 	listCmd.Flags().StringVarP(&listAssignee, "assignee", "a", "", "Filter by assignee")
+	listCmd.Flags().StringVar(&listAssigneeKind, "assignee-kind", "", "Filter by assignee kind (human/agent/unregistered/unassigned, see 'gur people')")
 	listCmd.Flags().BoolVar(&listArchived, "archived", false, "Include archived tasks")
-	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Limit number of results (0 = no limit)")
-	listCmd.Flags().IntVar(&listOffset, "offset", 0, "Skip first N results")
+	addPageFlags(listCmd, &listPage, taskSorts)
+	listCmd.Flags().StringArrayVar(&listFields, "field", nil, "Filter by custom field (name=value)")
+	listCmd.Flags().StringArrayVarP(&listLabels, "label", "l", nil, "Filter by label or glob, e.g. area/* (repeatable; all must match)")
+	listCmd.Flags().StringVar(&listRelease, "release", "", "Filter by target release")
+	listCmd.Flags().BoolVar(&listOverdue, "overdue", false, "Only open tasks past their due date")
+	listCmd.Flags().StringVar(&listResolved, "resolution", "", "Only closed tasks with this resolution ("+strings.Join(models.Resolutions, "/")+")")
+	addJSONLFlag(listCmd)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	if err := listPage.resolve(); err != nil {
+		return err
+	}
+	if err := listPage.validateFields(models.Task{}); err != nil {
+		return err
+	}
+	if listResolved != "" {
+		if err := models.ValidateResolution(listResolved); err != nil {
+			return err
+		}
+	}
+
 	var tasks []models.Task
-	query := db.GetDB().Order("priority ASC, created_at DESC")
+	query := db.GetDB().Model(&models.Task{})
 
 	// Exclude archived by default unless --archived flag or filtering by archived status
 	if !listArchived && listStatus != models.StatusArchived {
 		query = query.Where("status != ?", models.StatusArchived)
 	}
+	// Inbox items aren't tasks until triaged
+	if listStatus != models.StatusInbox {
+		query = query.Where("status != ?", models.StatusInbox)
+	}
 
 	if listStatus != "" {
 		query = query.Where("status = ?", listStatus)
@@ -58,20 +105,83 @@ func runList(cmd *cobra.Command, args []string) error {
 	if listAssignee != "" {
 		query = query.Where("assignee = ?", listAssignee)
 	}
-
-	if listOffset > 0 {
-		query = query.Offset(listOffset)
+	if listRelease != "" {
+		query = query.Where("release = ?", listRelease)
+	}
+	if listAssigneeKind != "" {
+		var err error
+		if query, err = whereAssigneeKind(db.GetDB(), query, listAssigneeKind); err != nil {
+			return err
+		}
+	}
+	if listResolved != "" {
+		query = query.Where("resolution = ?", listResolved)
+	}
+	now := time.Now()
+	if listOverdue {
+		query = whereOverdue(query, now)
+	}
+	if len(listFields) > 0 {
+		var err error
+		if query, err = whereFieldEquals(db.GetDB(), query, listFields); err != nil {
+			return err
+		}
+	}
+	if len(listLabels) > 0 {
+		var err error
+		if query, err = whereLabels(db.GetDB(), query, listLabels); err != nil {
+			return err
+		}
 	}
-	if listLimit > 0 {
-		query = query.Limit(listLimit)
+
+	if jsonlOutput {
+		query = listPage.bound(query).Order(listPage.order("priority ASC, rank = 0, rank ASC, created_at DESC"))
+		return streamTasksJSONL(db.GetDB(), query, newJSONLWriter(nil, listPage.fields))
 	}
 
-	if err := query.Find(&tasks).Error; err != nil {
+	query, total, err := listPage.paginate(query, &models.Task{})
+	if err != nil {
 		return err
 	}
+	if err := query.Order(listPage.order("priority ASC, rank = 0, rank ASC, created_at DESC")).Find(&tasks).Error; err != nil {
+		return err
+	}
+
+	return printTaskList(tasks, &listPage, total, now)
+}
+
+// printTaskList outputs a page of tasks as list does: projected to --fields,
+// as JSON, as quiet IDs or as indented text lines
+func printTaskList(tasks []models.Task, page *pageOptions, total int64, now time.Time) error {
+	if IsJSONOutput() || len(page.fields) > 0 {
+		if err := attachFieldValues(db.GetDB(), tasks); err != nil {
+			return err
+		}
+	}
+
+	if len(page.fields) > 0 {
+		rows, err := page.project(tasks)
+		if err != nil {
+			return err
+		}
+		if IsJSONOutput() {
+			OutputJSON(page.meta(map[string]interface{}{"count": len(rows), "tasks": rows}, total))
+		} else {
+			page.printProjected(rows)
+			page.printMoreHint(len(rows), total)
+		}
+		return nil
+	}
 
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"count": len(tasks), "tasks": tasks})
+		OutputJSON(page.meta(map[string]interface{}{"count": len(tasks), "tasks": tasks}, total))
+		return nil
+	}
+
+	if IsQuietOutput() {
+		for _, t := range tasks {
+			printQuietIDs(t.ID)
+		}
 		return nil
 	}
 
@@ -80,13 +190,20 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	c := colors()
 	for _, t := range tasks {
 		indent := ""
 		depth := models.GetDepth(t.ID)
 		for i := 0; i < depth; i++ {
 			indent += "  "
 		}
-		fmt.Printf("%s[%s] P%d %s - %s (%s)\n", indent, t.ID, t.Priority, t.Status, t.Title, t.Type)
+		due := dueAnnotation(t, now)
+		if t.IsBlocked() && t.BlockReason != "" {
+			fmt.Printf("%s[%s] %s %s - %s (%s)%s [blocked: %s]\n", indent, t.ID, c.Priority(t.Priority), c.Status(t.Status), t.Title, t.Type, due, t.BlockReason)
+			continue
+		}
+		fmt.Printf("%s[%s] %s %s - %s (%s)%s\n", indent, t.ID, c.Priority(t.Priority), c.Status(t.Status), t.Title, t.Type, due)
 	}
+	page.printMoreHint(len(tasks), total)
 	return nil
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"guardrails/internal/db"
+)
+
+// lockWorkspace takes the workspace lock (.guardrails/lock) for a
+// destructive command, so other commands don't interleave with it.
+// Standalone --db databases outside a project aren't locked.
+func lockWorkspace(command string) (func(), error) {
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return func() {}, nil
+	}
+	release, err := db.AcquireLock(root, command)
+	if err != nil {
+		return nil, fmt.Errorf("cannot run '%s': %w", command, err)
+	}
+	return release, nil
+}
+
+// checkWorkspaceLock fails if a destructive command holds the workspace lock
+func checkWorkspaceLock() error {
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return nil
+	}
+	if err := db.CheckLock(root); err != nil {
+		return fmt.Errorf("a destructive command is in progress: %w", err)
+	}
+	return nil
+}
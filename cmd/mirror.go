@@ -0,0 +1,439 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// mirrorDirName is the mirror's directory inside .guardrails
+const mirrorDirName = "mirror"
+
+var mirrorCmd = &cobra.Command{
+	Use:   "mirror",
+	Short: "Mirror open tasks as markdown files for code review",
+	Long: `Keep a plaintext mirror of the open backlog in .guardrails/mirror/, one
+markdown file per task named after its ID. Commit the mirror so backlog
+changes show up in git diffs and pull request review; edits made to the
+files (in review, or by hand) are applied back with 'gur mirror import'.
+
+Each file has a header with the task's id, title, status, priority, type,
+assignee and labels, followed by its description:
+
+  ---
+  id: gur-abc12345
+  title: Fix login redirect
+  status: open
+  priority: 1
+  type: bug
+  assignee: alice
+  labels: auth, web
+  ---
+
+  The description, as markdown.
+
+Examples:
+  gur mirror write
+  git diff .guardrails/mirror
+  gur mirror import --dry-run
+  gur mirror import`,
+}
+
+var mirrorWriteCmd = &cobra.Command{
+	Use:   "write",
+	Short: "Write open tasks to the mirror",
+	Long: `Write every open, in-progress and blocked task to .guardrails/mirror/<id>.md.
+Files are only rewritten when their content changes, and files of tasks that
+are no longer open are removed, so the mirror's git diff is the backlog's.
+
+Examples:
+  gur mirror write
+  gur mirror write --dir docs/backlog`,
+	Args: cobra.NoArgs,
+	RunE: runMirrorWrite,
+}
+
+var mirrorImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Apply edits made to mirror files",
+	Long: `Apply edits made to the mirror's files back to their tasks: title, status
+(open or in_progress), priority, type, assignee, labels and description.
+Every change is recorded in the task's history. Nothing is applied if any
+file is invalid. Files of tasks that don't exist are skipped with a warning;
+create tasks with 'gur create'. Use 'gur close' and 'gur block' to close and
+block tasks, as gates and reasons apply.
+
+Examples:
+  gur mirror import --dry-run
+  gur mirror import --by reviewer`,
+	Args: cobra.NoArgs,
+	RunE: runMirrorImport,
+}
+
+var (
+	mirrorDir    string
+	mirrorDryRun bool
+	mirrorBy     string
+)
+
+func init() {
+	rootCmd.AddCommand(mirrorCmd)
+	mirrorCmd.AddCommand(mirrorWriteCmd)
+	mirrorCmd.AddCommand(mirrorImportCmd)
+
+	mirrorCmd.PersistentFlags().StringVar(&mirrorDir, "dir", "", "Mirror directory (default: .guardrails/mirror)")
+	mirrorImportCmd.Flags().BoolVar(&mirrorDryRun, "dry-run", false, "Show the changes without applying them")
+	mirrorImportCmd.Flags().StringVar(&mirrorBy, "by", "user", "Who made the edits, for the history")
+}
+
+// mirrorPath returns the mirror directory: --dir, or .guardrails/mirror in
+// the project root
+func mirrorPath() (string, error) {
+	if mirrorDir != "" {
+		return mirrorDir, nil
+	}
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, db.GuardrailsDir, mirrorDirName), nil
+}
+
+// renderMirrorFile renders a task as a mirror file
+func renderMirrorFile(task models.Task) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "id: %s\n", task.ID)
+	fmt.Fprintf(&b, "title: %s\n", task.Title)
+	fmt.Fprintf(&b, "status: %s\n", task.Status)
+	fmt.Fprintf(&b, "priority: %d\n", task.Priority)
+	fmt.Fprintf(&b, "type: %s\n", task.Type)
+	fmt.Fprintf(&b, "assignee: %s\n", task.Assignee)
+	fmt.Fprintf(&b, "labels: %s\n", strings.Join(task.Labels, ", "))
+	b.WriteString("---\n")
+	if desc := strings.TrimSpace(task.Description); desc != "" {
+		b.WriteString("\n" + desc + "\n")
+	}
+	return b.String()
+}
+
+// mirrorEntry is a task as read from a mirror file
+type mirrorEntry struct {
+	ID          string
+	Title       string
+	Status      string
+	Priority    int
+	Type        string
+	Assignee    string
+	Labels      []string
+	Description string
+}
+
+// parseMirrorFile reads a mirror file back
+func parseMirrorFile(content string) (mirrorEntry, error) {
+	var e mirrorEntry
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		return e, fmt.Errorf("missing '---' header")
+	}
+	header, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		if header, ok = strings.CutSuffix(rest, "\n---"); !ok {
+			return e, fmt.Errorf("unterminated '---' header")
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(header, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return e, fmt.Errorf("invalid header line '%s': expected 'key: value'", line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		seen[key] = true
+		switch key {
+		case "id":
+			e.ID = value
+		case "title":
+			e.Title = value
+		case "status":
+			e.Status = value
+		case "priority":
+			p, err := strconv.Atoi(value)
+			if err != nil || p < 0 || p > 4 {
+				return e, fmt.Errorf("invalid priority '%s': must be 0 (critical) to 4 (lowest)", value)
+			}
+			e.Priority = p
+		case "type":
+			e.Type = value
+		case "assignee":
+			e.Assignee = value
+		case "labels":
+			for _, l := range strings.Split(value, ",") {
+				if l = strings.TrimSpace(l); l != "" {
+					e.Labels = append(e.Labels, l)
+				}
+			}
+		default:
+			return e, fmt.Errorf("unknown header field '%s'", key)
+		}
+	}
+	for _, key := range []string{"id", "title", "status", "priority", "type"} {
+		if !seen[key] {
+			return e, fmt.Errorf("missing header field '%s'", key)
+		}
+	}
+	if e.Title == "" {
+		return e, fmt.Errorf("title cannot be empty")
+	}
+	e.Description = strings.TrimSpace(body)
+	return e, nil
+}
+
+// mirrorChange is one field a mirror file changes on its task
+type mirrorChange struct {
+	TaskID string `json:"task_id"`
+	Field  string `json:"field"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+// diffMirrorEntry returns the changes a mirror file makes to its task,
+// after checking they can be applied
+func diffMirrorEntry(database *gorm.DB, task models.Task, e mirrorEntry) ([]mirrorChange, error) {
+	var changes []mirrorChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, mirrorChange{TaskID: task.ID, Field: field, Old: old, New: new})
+		}
+	}
+	add("title", task.Title, e.Title)
+	if e.Status != task.Status {
+		if task.IsClosed() || task.IsBlocked() || (e.Status != models.StatusOpen && e.Status != models.StatusInProgress) {
+			return nil, fmt.Errorf("cannot change status from %s to %s in the mirror: use 'gur close', 'gur block', 'gur unblock' or 'gur reopen'", task.Status, e.Status)
+		}
+		add("status", task.Status, e.Status)
+	}
+	add("priority", strconv.Itoa(task.Priority), strconv.Itoa(e.Priority))
+	add("type", task.Type, e.Type)
+	if e.Assignee != task.Assignee {
+		if err := checkAssignee(database, e.Assignee); err != nil {
+			return nil, err
+		}
+		add("assignee", task.Assignee, e.Assignee)
+	}
+	want := make(map[string]bool, len(e.Labels))
+	for _, l := range e.Labels {
+		want[l] = true
+		if !task.HasLabel(l) {
+			changes = append(changes, mirrorChange{TaskID: task.ID, Field: "label_added", New: l})
+		}
+	}
+	for _, l := range task.Labels {
+		if !want[l] {
+			changes = append(changes, mirrorChange{TaskID: task.ID, Field: "label_removed", Old: l})
+		}
+	}
+	add("description", strings.TrimSpace(task.Description), e.Description)
+	return changes, nil
+}
+
+// applyMirrorChanges applies a task's changes and records them. Only fields
+// 'gur undo' can revert are accepted, so an import can always be undone.
+func applyMirrorChanges(tx *gorm.DB, task *models.Task, changes []mirrorChange, by string) error {
+	for _, c := range changes {
+		switch c.Field {
+		case "title":
+			task.Title = c.New
+		case "status":
+			task.Status = c.New
+		case "priority":
+			task.Priority, _ = strconv.Atoi(c.New)
+		case "type":
+			task.Type = c.New
+		case "assignee":
+			task.Assignee = c.New
+		case "label_added":
+			task.AddLabel(c.New)
+		case "label_removed":
+			task.RemoveLabel(c.Old)
+		case "description":
+			task.Description = c.New
+		default:
+			return fmt.Errorf("cannot import a change to field '%s' from the mirror", c.Field)
+		}
+		if err := models.RecordChange(tx, task.ID, c.Field, c.Old, c.New, by); err != nil {
+			return err
+		}
+	}
+	return tx.Save(task).Error
+}
+
+func runMirrorWrite(cmd *cobra.Command, args []string) error {
+	dir, err := mirrorPath()
+	if err != nil {
+		return err
+	}
+	var tasks []models.Task
+	if err := db.GetDB().Where("status IN ?", []string{models.StatusOpen, models.StatusInProgress, models.StatusBlocked}).
+		Order("id ASC").Find(&tasks).Error; err != nil {
+		return fmt.Errorf("failed to load tasks: database error: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+
+	var written, removed []string
+	keep := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		name := t.ID + ".md"
+		keep[name] = true
+		path := filepath.Join(dir, name)
+		content := renderMirrorFile(t)
+		if existing, err := os.ReadFile(path); err == nil && string(existing) == content {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, t.ID)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read mirror directory: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || keep[name] || !strings.HasSuffix(name, ".md") || !models.ValidateTaskID(strings.TrimSuffix(name, ".md")) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", name, err)
+		}
+		removed = append(removed, strings.TrimSuffix(name, ".md"))
+	}
+
+	if IsJSONOutput() {
+		if written == nil {
+			written = []string{}
+		}
+		if removed == nil {
+			removed = []string{}
+		}
+		OutputJSON(map[string]interface{}{"dir": dir, "tasks": len(tasks), "written": written, "removed": removed})
+		return nil
+	}
+	fmt.Printf("Mirrored %d task(s) to %s: %d written, %d removed, %d unchanged\n",
+		len(tasks), dir, len(written), len(removed), len(tasks)-len(written))
+	return nil
+}
+
+func runMirrorImport(cmd *cobra.Command, args []string) error {
+	dir, err := mirrorPath()
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read mirror directory (run 'gur mirror write' first): %w", err)
+	}
+	database := db.GetDB()
+
+	type pending struct {
+		task    models.Task
+		changes []mirrorChange
+	}
+	var updates []pending
+	var all []mirrorChange
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		e, err := parseMirrorFile(string(content))
+		if err != nil {
+			return fmt.Errorf("invalid mirror file %s: %w", entry.Name(), err)
+		}
+		task, err := db.GetTaskByID(e.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: task '%s' not found, skipping\n", entry.Name(), e.ID)
+			continue
+		}
+		changes, err := diffMirrorEntry(database, *task, e)
+		if err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		if len(changes) > 0 {
+			updates = append(updates, pending{task: *task, changes: changes})
+			all = append(all, changes...)
+		}
+	}
+	sort.SliceStable(updates, func(i, j int) bool { return updates[i].task.ID < updates[j].task.ID })
+
+	if !mirrorDryRun && len(updates) > 0 {
+		err := database.Transaction(func(tx *gorm.DB) error {
+			for i := range updates {
+				if err := applyMirrorChanges(tx, &updates[i].task, updates[i].changes, mirrorBy); err != nil {
+					return fmt.Errorf("failed to update task '%s': database error: %w", updates[i].task.ID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, u := range updates {
+			noteAffected(u.task.ID)
+		}
+	}
+
+	if IsJSONOutput() {
+		if all == nil {
+			all = []mirrorChange{}
+		}
+		OutputJSON(map[string]interface{}{"dry_run": mirrorDryRun, "updated": len(updates), "changes": all})
+		return nil
+	}
+	if len(updates) == 0 {
+		fmt.Println("No changes in the mirror")
+		return nil
+	}
+	for _, u := range updates {
+		fmt.Printf("%s:\n", u.task.ID)
+		for _, c := range u.changes {
+			switch c.Field {
+			case "description":
+				fmt.Println("  description changed")
+			case "label_added":
+				fmt.Printf("  + label %s\n", c.New)
+			case "label_removed":
+				fmt.Printf("  - label %s\n", c.Old)
+			default:
+				fmt.Printf("  %s: %q -> %q\n", c.Field, c.Old, c.New)
+			}
+		}
+	}
+	if mirrorDryRun {
+		fmt.Printf("\nWould update %d task(s) (dry run)\n", len(updates))
+	} else {
+		fmt.Printf("\nUpdated %d task(s) from the mirror\n", len(updates))
+	}
+	return nil
+}
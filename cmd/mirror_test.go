@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestMirrorFileRoundTrip(t *testing.T) {
+	task := models.Task{ID: "gur-3e000001", Title: "Fix login: redirect", Status: models.StatusOpen, Priority: 1,
+		Type: "bug", Assignee: "alice", Labels: models.StringSlice{"auth", "web"}, Description: "Steps\n\n---\n\nmore"}
+	e, err := parseMirrorFile(renderMirrorFile(task))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ID != task.ID || e.Title != task.Title || e.Priority != 1 || e.Assignee != "alice" ||
+		strings.Join(e.Labels, ",") != "auth,web" || e.Description != task.Description {
+		t.Errorf("round trip = %+v, want the task back", e)
+	}
+
+	for _, bad := range []string{
+		"id: gur-3e000001\n",
+		"---\nid: gur-3e000001\ntitle: x\nstatus: open\npriority: 9\ntype: task\n---\n",
+		"---\nid: gur-3e000001\ntitle: x\nstatus: open\npriority: 1\ntype: task\ncolor: red\n---\n",
+		"---\nid: gur-3e000001\nstatus: open\npriority: 1\ntype: task\n---\n",
+	} {
+		if _, err := parseMirrorFile(bad); err == nil {
+			t.Errorf("parseMirrorFile(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestMirrorWriteAndImport(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-3e000002", Title: "Login", Status: models.StatusOpen, Priority: 2, Type: "task", Labels: models.StringSlice{"auth"}})
+	database.Create(&models.Task{ID: "gur-3e000003", Title: "Done", Status: models.StatusClosed, Type: "task"})
+	commandAffected = nil
+
+	mirrorDir = t.TempDir()
+	defer func() { mirrorDir, mirrorDryRun = "", false }()
+	os.WriteFile(filepath.Join(mirrorDir, "gur-3e000003.md"), []byte("stale"), 0644)
+
+	if err := runMirrorWrite(mirrorWriteCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(mirrorDir, "gur-3e000003.md")); !os.IsNotExist(err) {
+		t.Error("the closed task's file was not removed")
+	}
+	path := filepath.Join(mirrorDir, "gur-3e000002.md")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited := strings.Replace(string(content), "priority: 2", "priority: 0", 1)
+	edited = strings.Replace(edited, "labels: auth", "labels: web", 1) + "\nUse SSO.\n"
+	os.WriteFile(path, []byte(edited), 0644)
+
+	mirrorDryRun = true
+	if err := runMirrorImport(mirrorImportCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	task, _ := db.GetTaskByID("gur-3e000002")
+	if task.Priority != 2 {
+		t.Error("dry run changed the task")
+	}
+
+	mirrorDryRun = false
+	if err := runMirrorImport(mirrorImportCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	task, _ = db.GetTaskByID("gur-3e000002")
+	if task.Priority != 0 || !task.HasLabel("web") || task.HasLabel("auth") || task.Description != "Use SSO." {
+		t.Errorf("imported task = %+v, want priority 0, label web and the new description", task)
+	}
+	var changes int64
+	database.Model(&models.TaskHistory{}).Where("task_id = ?", "gur-3e000002").Count(&changes)
+	if changes != 4 {
+		t.Errorf("recorded %d changes, want 4 (priority, label added, label removed, description)", changes)
+	}
+	if len(commandAffected) != 1 {
+		t.Errorf("affected = %v, want the updated task", commandAffected)
+	}
+
+	// Every imported change can be undone
+	var history []models.TaskHistory
+	database.Where("task_id = ?", "gur-3e000002").Order("changed_at ASC").Find(&history)
+	if err := revertChanges(database, history); err != nil {
+		t.Fatalf("undoing the import: %v", err)
+	}
+	task, _ = db.GetTaskByID("gur-3e000002")
+	if task.Priority != 2 || !task.HasLabel("auth") || task.HasLabel("web") || task.Description != "" {
+		t.Errorf("task after undoing the import = %+v, want it as before", task)
+	}
+	if err := applyMirrorChanges(database, task, []mirrorChange{{TaskID: task.ID, Field: "rank", Old: "0", New: "1"}}, "user"); err == nil {
+		t.Error("imported a change to a field the mirror doesn't carry")
+	}
+
+	os.WriteFile(path, []byte(strings.Replace(edited, "status: open", "status: closed", 1)), 0644)
+	if err := runMirrorImport(mirrorImportCmd, nil); err == nil {
+		t.Error("closing a task through the mirror succeeded")
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var noteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Add and list typed task notes",
+	Long: `Record notes on a task as typed entries so they can be retrieved by kind.
+
+Kinds: note (default), decision, blocker, log.
+Entries also appear in the task's notes text ('gur show', 'gur grep'), and
+'gur update <id> --notes' adds a plain note.`,
+}
+
+var noteAddCmd = &cobra.Command{
+	Use:   "add <task-id> <text>",
+	Short: "Add a note to a task",
+	Long: `Add a typed note to a task.
+
+Examples:
+  gur note add gur-abc123 "Check the retry path too"
+  gur note add gur-abc123 --kind decision "Use SQLite WAL mode for concurrent readers"
+  gur note add gur-abc123 -k blocker "Waiting on staging credentials" --by alice`,
+	Args: cobra.ExactArgs(2),
+	RunE: runNoteAdd,
+}
+
+var noteListCmd = &cobra.Command{
+	Use:         "list <task-id>",
+	Short:       "List a task's notes",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `List a task's notes, oldest first.
+
+Examples:
+  gur note list gur-abc123
+  gur note list gur-abc123 --kind decision --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNoteList,
+}
+
+var (
+	noteKind     string
+	noteBy       string
+	noteListKind string
+)
+
+func init() {
+	rootCmd.AddCommand(noteCmd)
+	noteCmd.AddCommand(noteAddCmd)
+	noteCmd.AddCommand(noteListCmd)
+
+	noteAddCmd.Flags().StringVarP(&noteKind, "kind", "k", models.NoteKindNote, "Note kind ("+strings.Join(models.NoteKinds, "/")+")")
+	noteAddCmd.Flags().StringVar(&noteBy, "by", "agent", "Author of the note")
+	noteListCmd.Flags().StringVarP(&noteListKind, "kind", "k", "", "Only list notes of this kind")
+}
+
+// validateNoteKind returns an error for an unknown note kind
+func validateNoteKind(kind string) error {
+	if !models.IsValidNoteKind(kind) {
+		return fmt.Errorf("invalid note kind '%s': must be one of %s", kind, strings.Join(models.NoteKinds, ", "))
+	}
+	return nil
+}
+
+// addNote records a typed note entry and appends it to task.Notes, recording
+// history so it can be undone. The body is held to the configured note size
+// limit ('gur config limits'). The caller saves the task.
+func addNote(database *gorm.DB, task *models.Task, kind, author, body, changedBy string) (*models.NoteEntry, error) {
+	limits := loadSizeLimits()
+	body, err := limits.enforce(task.ID, "note", body, limits.Note)
+	if err != nil {
+		return nil, err
+	}
+	entry := &models.NoteEntry{TaskID: task.ID, Kind: kind, Author: author, Body: body}
+	if err := database.Create(entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to save note: %w", err)
+	}
+	task.AppendNotes(entry.Line())
+	models.RecordChange(database, task.ID, "notes", "", entry.Line(), changedBy)
+	return entry, nil
+}
+
+// removeNoteEntry deletes the most recent entry whose text matches line,
+// undoing addNote
+func removeNoteEntry(database *gorm.DB, taskID, line string) error {
+	var entries []models.NoteEntry
+	if err := database.Where("task_id = ?", taskID).Order("id DESC").Find(&entries).Error; err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.Line() == line {
+			return database.Delete(&e).Error
+		}
+	}
+	return nil
+}
+
+func runNoteAdd(cmd *cobra.Command, args []string) error {
+	if err := validateNoteKind(noteKind); err != nil {
+		return err
+	}
+	body := strings.TrimSpace(args[1])
+	if body == "" {
+		return fmt.Errorf("note text cannot be empty")
+	}
+
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot add note: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	var entry *models.NoteEntry
+	if err := db.GetDB().Transaction(func(tx *gorm.DB) error {
+		if entry, err = addNote(tx, task, noteKind, noteBy, body, noteBy); err != nil {
+			return err
+		}
+		return tx.Save(task).Error
+	}); err != nil {
+		return fmt.Errorf("failed to add note to task '%s': %w", task.ID, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(entry)
+	} else {
+		fmt.Printf("Added %s to %s\n", entry.Kind, task.ID)
+	}
+	return nil
+}
+
+func runNoteList(cmd *cobra.Command, args []string) error {
+	if noteListKind != "" {
+		if err := validateNoteKind(noteListKind); err != nil {
+			return err
+		}
+	}
+
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	query := db.GetDB().Where("task_id = ?", task.ID)
+	if noteListKind != "" {
+		query = query.Where("kind = ?", noteListKind)
+	}
+	var entries []models.NoteEntry
+	if err := query.Order("created_at ASC, id ASC").Find(&entries).Error; err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"task_id": task.ID, "count": len(entries), "notes": entries})
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No notes for %s\n", task.ID)
+		return nil
+	}
+	for _, e := range entries {
+		author := ""
+		if e.Author != "" {
+			author = " @" + e.Author
+		}
+		fmt.Printf("[%s] %s%s: %s\n", e.CreatedAt.Format(models.DateTimeShortFormat), e.Kind, author, e.Body)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestAddNoteAndUndo(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-note0001", Title: "Notes", Status: models.StatusOpen}
+	database.Create(task)
+
+	if _, err := addNote(database, task, models.NoteKindNote, "alice", "plain remark", "alice"); err != nil {
+		t.Fatalf("addNote: %v", err)
+	}
+	if _, err := addNote(database, task, models.NoteKindDecision, "bob", "use WAL", "bob"); err != nil {
+		t.Fatalf("addNote: %v", err)
+	}
+	database.Save(task)
+
+	if !strings.Contains(task.Notes, "] plain remark\n") || !strings.Contains(task.Notes, "] decision: use WAL\n") {
+		t.Errorf("notes text = %q", task.Notes)
+	}
+
+	var decisions []models.NoteEntry
+	database.Where("task_id = ? AND kind = ?", task.ID, models.NoteKindDecision).Find(&decisions)
+	if len(decisions) != 1 || decisions[0].Author != "bob" || decisions[0].Body != "use WAL" {
+		t.Fatalf("decision entries = %+v", decisions)
+	}
+
+	// Undoing the decision removes both the text and the entry
+	var changes []models.TaskHistory
+	database.Where("task_id = ? AND field = ? AND new_value = ?", task.ID, "notes", "decision: use WAL").Find(&changes)
+	if err := revertChanges(database, changes); err != nil {
+		t.Fatalf("revertChanges: %v", err)
+	}
+
+	reverted, _ := db.GetTaskByID(task.ID)
+	if strings.Contains(reverted.Notes, "use WAL") || !strings.Contains(reverted.Notes, "plain remark") {
+		t.Errorf("notes after undo = %q", reverted.Notes)
+	}
+	var count int64
+	database.Model(&models.NoteEntry{}).Where("task_id = ?", task.ID).Count(&count)
+	if count != 1 {
+		t.Errorf("expected 1 entry after undo, got %d", count)
+	}
+}
+
+func TestValidateNoteKind(t *testing.T) {
+	for _, kind := range models.NoteKinds {
+		if err := validateNoteKind(kind); err != nil {
+			t.Errorf("validateNoteKind(%q): %v", kind, err)
+		}
+	}
+	if err := validateNoteKind("idea"); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
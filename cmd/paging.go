@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// defaultPageSize is the page size used with --page when --limit isn't set
+const defaultPageSize = 50
+
+// pageOptions holds the paging, sorting and projection flags shared by
+// list-style commands
+type pageOptions struct {
+	limit  int
+	offset int
+	page   int
+	sort   string
+	fields []string
+
+	sorts map[string]string // --sort key -> ORDER BY clause
+}
+
+// taskSorts are the --sort keys for task listings
+var taskSorts = map[string]string{
+	"priority": "priority ASC, rank = 0, rank ASC, created_at DESC",
+	"created":  "created_at DESC",
+	"updated":  "updated_at DESC",
+	"due":      "due_at IS NULL, due_at ASC, priority ASC, rank = 0, rank ASC",
+	"votes":    "votes DESC, priority ASC, rank = 0, rank ASC, created_at DESC",
+}
+
+// gateSorts are the --sort keys for gate listings
+var gateSorts = map[string]string{
+	"priority": "priority ASC, category ASC, created_at DESC",
+	"created":  "created_at DESC",
+	"updated":  "updated_at DESC",
+}
+
+// addPageFlags registers --limit, --offset, --page, --sort and --fields
+func addPageFlags(cmd *cobra.Command, opts *pageOptions, sorts map[string]string) {
+	opts.sorts = sorts
+	keys := make([]string, 0, len(sorts))
+	for k := range sorts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	cmd.Flags().IntVar(&opts.limit, "limit", 0, "Limit number of results (0 = no limit)")
+	cmd.Flags().IntVar(&opts.offset, "offset", 0, "Skip first N results")
+	cmd.Flags().IntVar(&opts.page, "page", 0, fmt.Sprintf("Page number, 1-based (page size is --limit, default %d)", defaultPageSize))
+	cmd.Flags().StringVar(&opts.sort, "sort", "", "Sort by "+strings.Join(keys, "/"))
+	cmd.Flags().StringSliceVar(&opts.fields, "fields", nil, "Only output these fields (e.g., id,title,status)")
+	cmd.MarkFlagsMutuallyExclusive("offset", "page")
+}
+
+// resolve validates the flags and turns --page into limit/offset
+func (o *pageOptions) resolve() error {
+	if o.limit < 0 || o.offset < 0 || o.page < 0 {
+		return fmt.Errorf("--limit, --offset and --page cannot be negative")
+	}
+	if o.sort != "" {
+		if _, ok := o.sorts[o.sort]; !ok {
+			keys := make([]string, 0, len(o.sorts))
+			for k := range o.sorts {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return fmt.Errorf("invalid sort '%s': must be one of %s", o.sort, strings.Join(keys, ", "))
+		}
+	}
+	if o.page > 0 {
+		if o.limit == 0 {
+			o.limit = defaultPageSize
+		}
+		o.offset = (o.page - 1) * o.limit
+	}
+	return nil
+}
+
+// order returns the ORDER BY clause for --sort, or def if unset
+func (o *pageOptions) order(def string) string {
+	if clause, ok := o.sorts[o.sort]; ok {
+		return clause
+	}
+	return def
+}
+
+// paginate counts the rows matching query, then applies offset and limit.
+// The query must not be ordered or limited yet.
+func (o *pageOptions) paginate(query *gorm.DB, model interface{}) (*gorm.DB, int64, error) {
+	var total int64
+	if err := query.Session(&gorm.Session{}).Model(model).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return o.bound(query), total, nil
+}
+
+// bound applies offset and limit without counting, for streamed output
+func (o *pageOptions) bound(query *gorm.DB) *gorm.DB {
+	if o.offset > 0 {
+		query = query.Offset(o.offset)
+	}
+	if o.limit > 0 {
+		query = query.Limit(o.limit)
+	}
+	return query
+}
+
+// window returns the bounds of the current page in a slice of n items
+func (o *pageOptions) window(n int) (int, int) {
+	start := min(o.offset, n)
+	end := n
+	if o.limit > 0 {
+		end = min(start+o.limit, n)
+	}
+	return start, end
+}
+
+// meta adds paging details to a JSON result
+func (o *pageOptions) meta(result map[string]interface{}, total int64) map[string]interface{} {
+	result["total"] = total
+	if o.offset > 0 {
+		result["offset"] = o.offset
+	}
+	if o.limit > 0 {
+		result["limit"] = o.limit
+	}
+	if o.page > 0 {
+		result["page"] = o.page
+	}
+	return result
+}
+
+// printMoreHint tells text readers how to get the next page
+func (o *pageOptions) printMoreHint(shown int, total int64) {
+	if int64(o.offset+shown) >= total || shown == 0 || IsQuietOutput() {
+		return
+	}
+	next := fmt.Sprintf("--offset %d", o.offset+shown)
+	if o.page > 0 {
+		next = fmt.Sprintf("--page %d", o.page+1)
+	}
+	fmt.Printf("(showing %d-%d of %d; use %s for more)\n", o.offset+1, o.offset+shown, total, next)
+}
+
+// validateFields checks --fields against the JSON field names of item
+func (o *pageOptions) validateFields(item interface{}) error {
+	known := jsonFieldNames(reflect.TypeOf(item))
+	for _, f := range o.fields {
+		if !known[f] {
+			names := make([]string, 0, len(known))
+			for n := range known {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("unknown field '%s' for --fields: must be one of %s", f, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// jsonFieldNames returns the JSON names of a struct's exported fields
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// project reduces each item to the --fields keys, in JSON form
+func (o *pageOptions) project(items interface{}) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		projected := make(map[string]interface{}, len(o.fields))
+		for _, f := range o.fields {
+			projected[f] = row[f]
+		}
+		rows[i] = projected
+	}
+	return rows, nil
+}
+
+// printProjected writes projected rows as tab-separated columns in --fields order
+func (o *pageOptions) printProjected(rows []map[string]interface{}) {
+	for _, row := range rows {
+		values := make([]string, len(o.fields))
+		for i, f := range o.fields {
+			values[i] = formatProjectedValue(row[f])
+		}
+		fmt.Println(strings.Join(values, "\t"))
+	}
+}
+
+// formatProjectedValue renders a JSON value for a text column
+func formatProjectedValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []interface{}:
+		parts := make([]string, len(val))
+		for i, p := range val {
+			parts[i] = formatProjectedValue(p)
+		}
+		return strings.Join(parts, ",")
+	case map[string]interface{}:
+		data, _ := json.Marshal(val)
+		return string(data)
+	default:
+		return fmt.Sprint(val)
+	}
+}
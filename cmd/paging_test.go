@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestPageOptionsResolve(t *testing.T) {
+	opts := pageOptions{page: 3, sorts: taskSorts}
+	if err := opts.resolve(); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if opts.limit != defaultPageSize || opts.offset != 2*defaultPageSize {
+		t.Errorf("page 3 = limit %d offset %d", opts.limit, opts.offset)
+	}
+
+	opts = pageOptions{page: 2, limit: 10, sorts: taskSorts}
+	opts.resolve()
+	if opts.offset != 10 {
+		t.Errorf("page 2 of 10 = offset %d, want 10", opts.offset)
+	}
+
+	if err := (&pageOptions{sort: "title", sorts: taskSorts}).resolve(); err == nil {
+		t.Error("expected error for unknown sort")
+	}
+	if err := (&pageOptions{limit: -1, sorts: taskSorts}).resolve(); err == nil {
+		t.Error("expected error for negative limit")
+	}
+}
+
+func TestPageOptionsWindow(t *testing.T) {
+	tests := []struct {
+		limit, offset, n, start, end int
+	}{
+		{0, 0, 5, 0, 5},
+		{2, 0, 5, 0, 2},
+		{2, 4, 5, 4, 5},
+		{2, 9, 5, 5, 5},
+	}
+	for _, tt := range tests {
+		opts := pageOptions{limit: tt.limit, offset: tt.offset}
+		if start, end := opts.window(tt.n); start != tt.start || end != tt.end {
+			t.Errorf("window(limit=%d, offset=%d, n=%d) = %d,%d; want %d,%d", tt.limit, tt.offset, tt.n, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestPageOptionsPaginate(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	for i := 0; i < 7; i++ {
+		database.Create(&models.Task{ID: fmt.Sprintf("gur-page%04d", i), Title: fmt.Sprintf("Task %d", i), Status: models.StatusOpen, Priority: i % 3})
+	}
+
+	opts := pageOptions{page: 2, limit: 3, sort: "priority", sorts: taskSorts}
+	if err := opts.resolve(); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	query, total, err := opts.paginate(database.Model(&models.Task{}).Where("status = ?", models.StatusOpen), &models.Task{})
+	if err != nil {
+		t.Fatalf("paginate: %v", err)
+	}
+	var tasks []models.Task
+	if err := query.Order(opts.order("")).Find(&tasks).Error; err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if total != 7 || len(tasks) != 3 {
+		t.Fatalf("total %d, page of %d; want 7 and 3", total, len(tasks))
+	}
+	// Priorities are 0,0,0,1,1,1,2 so page 2 starts with the first P1
+	if tasks[0].Priority != 1 {
+		t.Errorf("first task on page 2 has priority %d, want 1", tasks[0].Priority)
+	}
+}
+
+func TestPageOptionsProject(t *testing.T) {
+	opts := pageOptions{fields: []string{"id", "status", "labels"}}
+	if err := opts.validateFields(models.Task{}); err != nil {
+		t.Fatalf("validateFields: %v", err)
+	}
+	if err := (&pageOptions{fields: []string{"nope"}}).validateFields(models.Task{}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+
+	rows, err := opts.project([]models.Task{{ID: "gur-a1b2c3d4", Title: "Hidden", Status: models.StatusOpen, Labels: models.StringSlice{"a", "b"}}})
+	if err != nil {
+		t.Fatalf("project: %v", err)
+	}
+	want := map[string]interface{}{"id": "gur-a1b2c3d4", "status": "open", "labels": []interface{}{"a", "b"}}
+	if len(rows) != 1 || !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("project = %v, want %v", rows, want)
+	}
+	if got := formatProjectedValue(rows[0]["labels"]); got != "a,b" {
+		t.Errorf("formatProjectedValue(labels) = %q", got)
+	}
+}
@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var peopleCmd = &cobra.Command{
+	Use:     "people",
+	Aliases: []string{"person"},
+	Short:   "Manage the assignee registry",
+	Long: `Register the people and agents tasks are assigned to, so human work can
+be told apart from agent work in stats and filters ('gur list
+--assignee-kind agent').
+
+Assignees are checked against the registry on create, update and reassign:
+an unknown assignee prints a warning, or with strict mode on, is rejected.
+An empty registry accepts any assignee unless strict mode is on.
+
+Examples:
+  gur people add alice --kind human --contact alice@example.com --timezone Europe/Berlin
+  gur people add claude-backend --kind agent
+  gur people list --kind agent
+  gur people strict on`,
+}
+
+var peopleAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Register or update an assignee",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPeopleAdd,
+}
+
+var peopleListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List registered assignees",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runPeopleList,
+}
+
+var peopleRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove an assignee from the registry",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runPeopleRemove,
+}
+
+var peopleStrictCmd = &cobra.Command{
+	Use:       "strict <on|off>",
+	Short:     "Reject (on) or only warn about (off) unregistered assignees",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"on", "off"},
+	RunE:      runPeopleStrict,
+}
+
+var (
+	peopleKind     string
+	peopleContact  string
+	peopleTimezone string
+	peopleListKind string
+)
+
+func init() {
+	rootCmd.AddCommand(peopleCmd)
+	peopleCmd.AddCommand(peopleAddCmd)
+	peopleCmd.AddCommand(peopleListCmd)
+	peopleCmd.AddCommand(peopleRemoveCmd)
+	peopleCmd.AddCommand(peopleStrictCmd)
+
+	peopleAddCmd.Flags().StringVarP(&peopleKind, "kind", "k", models.PersonKindHuman, "Kind (human/agent)")
+	peopleAddCmd.Flags().StringVar(&peopleContact, "contact", "", "Contact (email, handle, ...)")
+	peopleAddCmd.Flags().StringVar(&peopleTimezone, "timezone", "", "IANA timezone (e.g., America/New_York)")
+	peopleListCmd.Flags().StringVarP(&peopleListKind, "kind", "k", "", "Only this kind (human/agent)")
+}
+
+// assigneeKindUnregistered and assigneeKindUnassigned group tasks whose
+// assignee isn't in the registry, or who have none
+const (
+	assigneeKindUnregistered = "unregistered"
+	assigneeKindUnassigned   = "unassigned"
+)
+
+// checkAssignee validates an assignee against the registry: unknown names
+// are an error in strict mode and a warning otherwise (unless the registry
+// is empty)
+func checkAssignee(database *gorm.DB, name string) error {
+	if name == "" {
+		return nil
+	}
+	var count int64
+	if err := database.Model(&models.Person{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check assignee: database error: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if strict, _ := db.GetConfig(models.ConfigAssigneeStrict); strict == "true" {
+		return fmt.Errorf("unknown assignee '%s': register it with 'gur people add %s --kind human|agent' (strict mode is on)", name, name)
+	}
+	var registered int64
+	database.Model(&models.Person{}).Count(&registered)
+	if registered > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: assignee '%s' is not registered (add with 'gur people add %s --kind human|agent')\n", name, name)
+	}
+	return nil
+}
+
+// whereAssigneeKind limits a task query to assignees of a kind: human,
+// agent, unregistered or unassigned
+func whereAssigneeKind(database, query *gorm.DB, kind string) (*gorm.DB, error) {
+	registered := database.Model(&models.Person{}).Select("name")
+	switch kind {
+	case models.PersonKindHuman, models.PersonKindAgent:
+		return query.Where("assignee IN (?)", registered.Where("kind = ?", kind)), nil
+	case assigneeKindUnregistered:
+		return query.Where("assignee != '' AND assignee NOT IN (?)", registered), nil
+	case assigneeKindUnassigned:
+		return query.Where("(assignee = '' OR assignee IS NULL)"), nil
+	default:
+		return nil, fmt.Errorf("invalid assignee kind '%s': must be one of human, agent, %s, %s", kind, assigneeKindUnregistered, assigneeKindUnassigned)
+	}
+}
+
+// kindCounts counts open and closed tasks for one assignee kind
+type kindCounts struct {
+	Open   int64 `json:"open"`
+	Closed int64 `json:"closed"`
+}
+
+// collectAssigneeKindStats counts tasks by the kind of their assignee
+func collectAssigneeKindStats(database *gorm.DB) (map[string]kindCounts, error) {
+	var rows []struct {
+		AssigneeKind string
+		Closed       bool
+		Count        int64
+	}
+	err := database.Model(&models.Task{}).
+		Select(`CASE
+			WHEN tasks.assignee IS NULL OR tasks.assignee = '' THEN ?
+			WHEN people.kind IS NULL THEN ?
+			ELSE people.kind END AS assignee_kind,
+			tasks.status IN ? AS closed, count(*) AS count`,
+			assigneeKindUnassigned, assigneeKindUnregistered, []string{models.StatusClosed, models.StatusArchived}).
+		Joins("LEFT JOIN people ON people.name = tasks.assignee").
+		Group("assignee_kind, closed").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[string]kindCounts)
+	for _, r := range rows {
+		c := stats[r.AssigneeKind]
+		if r.Closed {
+			c.Closed += r.Count
+		} else {
+			c.Open += r.Count
+		}
+		stats[r.AssigneeKind] = c
+	}
+	return stats, nil
+}
+
+func runPeopleAdd(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if err := models.ValidatePersonKind(peopleKind); err != nil {
+		return err
+	}
+	if peopleTimezone != "" {
+		if _, err := time.LoadLocation(peopleTimezone); err != nil {
+			return fmt.Errorf("invalid timezone '%s': use an IANA name like Europe/Berlin", peopleTimezone)
+		}
+	}
+
+	database := db.GetDB()
+	var person models.Person
+	updated := database.Where("name = ?", name).First(&person).Error == nil
+	person.Name = name
+	if cmd.Flags().Changed("kind") || !updated {
+		person.Kind = peopleKind
+	}
+	if cmd.Flags().Changed("contact") || !updated {
+		person.Contact = peopleContact
+	}
+	if cmd.Flags().Changed("timezone") || !updated {
+		person.Timezone = peopleTimezone
+	}
+	if err := database.Save(&person).Error; err != nil {
+		return fmt.Errorf("failed to save '%s': database error: %w", name, err)
+	}
+
+	var assigned int64
+	database.Model(&models.Task{}).Where("assignee = ?", name).Count(&assigned)
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "updated": updated, "person": person, "assigned_tasks": assigned})
+		return nil
+	}
+	verb := "Registered"
+	if updated {
+		verb = "Updated"
+	}
+	fmt.Printf("%s %s (%s)\n", verb, person.Name, person.Kind)
+	if assigned > 0 {
+		fmt.Printf("%d task(s) assigned to %s\n", assigned, person.Name)
+	}
+	return nil
+}
+
+func runPeopleList(cmd *cobra.Command, args []string) error {
+	query := db.GetDB().Order("kind ASC, name ASC")
+	if peopleListKind != "" {
+		if err := models.ValidatePersonKind(peopleListKind); err != nil {
+			return err
+		}
+		query = query.Where("kind = ?", peopleListKind)
+	}
+	var people []models.Person
+	if err := query.Find(&people).Error; err != nil {
+		return fmt.Errorf("failed to list people: database error: %w", err)
+	}
+	strict, _ := db.GetConfig(models.ConfigAssigneeStrict)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(people), "people": people, "strict": strict == "true"})
+		return nil
+	}
+	if len(people) == 0 {
+		fmt.Println("No people registered (add one with 'gur people add <name> --kind human|agent')")
+		return nil
+	}
+	for _, p := range people {
+		line := fmt.Sprintf("%-24s %-6s", p.Name, p.Kind)
+		if p.Contact != "" {
+			line += "  " + p.Contact
+		}
+		if p.Timezone != "" {
+			line += "  (" + p.Timezone + ")"
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	if strict == "true" {
+		fmt.Println("\nStrict mode: unregistered assignees are rejected")
+	}
+	return nil
+}
+
+func runPeopleRemove(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	result := database.Where("name = ?", args[0]).Delete(&models.Person{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove '%s': database error: %w", args[0], result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("'%s' is not registered (use 'gur people list' to see registered people)", args[0])
+	}
+
+	var assigned int64
+	database.Model(&models.Task{}).Where("assignee = ? AND status NOT IN ?", args[0], []string{models.StatusClosed, models.StatusArchived}).Count(&assigned)
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "name": args[0], "open_tasks": assigned})
+		return nil
+	}
+	fmt.Printf("Removed %s\n", args[0])
+	if assigned > 0 {
+		fmt.Printf("Note: %d open task(s) are still assigned to %s (move them with 'gur reassign --from %s --to <name>')\n", assigned, args[0], args[0])
+	}
+	return nil
+}
+
+func runPeopleStrict(cmd *cobra.Command, args []string) error {
+	var value string
+	switch args[0] {
+	case "on":
+		value = "true"
+	case "off":
+		value = "false"
+	default:
+		return fmt.Errorf("invalid value '%s': must be on or off", args[0])
+	}
+	if err := db.SetConfig(models.ConfigAssigneeStrict, value); err != nil {
+		return fmt.Errorf("failed to set strict mode: %w", err)
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "strict": value == "true"})
+		return nil
+	}
+	if value == "true" {
+		fmt.Println("Strict mode on: unregistered assignees are rejected")
+	} else {
+		fmt.Println("Strict mode off: unregistered assignees only print a warning")
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestCheckAssignee(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	// An empty registry accepts anyone
+	if err := checkAssignee(database, "alice"); err != nil {
+		t.Errorf("empty registry error = %v", err)
+	}
+
+	database.Create(&models.Person{Name: "alice", Kind: models.PersonKindHuman})
+	if err := checkAssignee(database, "alice"); err != nil {
+		t.Errorf("registered assignee error = %v", err)
+	}
+	if err := checkAssignee(database, "mallory"); err != nil {
+		t.Errorf("unregistered assignee outside strict mode error = %v, want a warning only", err)
+	}
+
+	db.SetConfig(models.ConfigAssigneeStrict, "true")
+	if err := checkAssignee(database, "mallory"); err == nil || !strings.Contains(err.Error(), "strict mode") {
+		t.Errorf("strict mode error = %v, want unknown assignee", err)
+	}
+	if err := checkAssignee(database, ""); err != nil {
+		t.Errorf("unassigning in strict mode error = %v", err)
+	}
+}
+
+func TestAssigneeKinds(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	database.Create(&models.Person{Name: "alice", Kind: models.PersonKindHuman})
+	database.Create(&models.Person{Name: "backend-bot", Kind: models.PersonKindAgent})
+	database.Create(&models.Task{ID: "gur-ppl00001", Title: "A", Status: models.StatusOpen, Assignee: "alice"})
+	database.Create(&models.Task{ID: "gur-ppl00002", Title: "B", Status: models.StatusClosed, Assignee: "alice"})
+	database.Create(&models.Task{ID: "gur-ppl00003", Title: "C", Status: models.StatusOpen, Assignee: "backend-bot"})
+	database.Create(&models.Task{ID: "gur-ppl00004", Title: "D", Status: models.StatusOpen, Assignee: "bob"})
+	database.Create(&models.Task{ID: "gur-ppl00005", Title: "E", Status: models.StatusOpen})
+
+	stats, err := collectAssigneeKindStats(database)
+	if err != nil {
+		t.Fatalf("collectAssigneeKindStats() error: %v", err)
+	}
+	want := map[string]kindCounts{
+		models.PersonKindHuman:   {Open: 1, Closed: 1},
+		models.PersonKindAgent:   {Open: 1},
+		assigneeKindUnregistered: {Open: 1},
+		assigneeKindUnassigned:   {Open: 1},
+	}
+	for k, w := range want {
+		if stats[k] != w {
+			t.Errorf("%s = %+v, want %+v", k, stats[k], w)
+		}
+	}
+
+	for kind, wantIDs := range map[string]string{
+		models.PersonKindHuman:   "gur-ppl00001 gur-ppl00002",
+		models.PersonKindAgent:   "gur-ppl00003",
+		assigneeKindUnregistered: "gur-ppl00004",
+		assigneeKindUnassigned:   "gur-ppl00005",
+	} {
+		query, err := whereAssigneeKind(database, database.Model(&models.Task{}), kind)
+		if err != nil {
+			t.Fatalf("whereAssigneeKind(%s) error: %v", kind, err)
+		}
+		var ids []string
+		query.Order("id ASC").Pluck("id", &ids)
+		if got := strings.Join(ids, " "); got != wantIDs {
+			t.Errorf("%s tasks = %s, want %s", kind, got, wantIDs)
+		}
+	}
+	if _, err := whereAssigneeKind(database, database, "robot"); err == nil {
+		t.Error("whereAssigneeKind accepted an unknown kind")
+	}
+}
@@ -0,0 +1,443 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// EnvPerfLog turns the performance log on ("1") or off ("0") for a single
+// command, whatever 'gur perf enable' set
+const EnvPerfLog = "GUR_PERF_LOG"
+
+const (
+	perfLogFileName = "perf.jsonl"
+	perfLogMaxBytes = 10 << 20 // Past this the log is moved to perf.jsonl.old
+)
+
+var (
+	perfSince   string
+	perfCommand string
+	perfLimit   int
+)
+
+var perfCmd = &cobra.Command{
+	Use:   "perf",
+	Short: "Local log of command timings",
+	Long: `Record how long each command takes, how many database queries it runs and
+how many GitHub API calls it makes, to find what is slow on a large
+backlog.
+
+The log is off until 'gur perf enable'. Entries are appended to
+.guardrails/perf.jsonl on this machine only: they are never synced or
+sent anywhere, and hold the command name but not its arguments. Set
+GUR_PERF_LOG=1 (or 0) to log one command regardless of the setting.
+
+Examples:
+  gur perf enable
+  gur perf report
+  gur perf report --since 7d --command "sync push"
+  gur perf clear`,
+}
+
+var perfEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Start logging command timings",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPerfLog(true)
+	},
+}
+
+var perfDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Stop logging command timings (the log is kept)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPerfLog(false)
+	},
+}
+
+var perfReportCmd = &cobra.Command{
+	Use:         "report",
+	Short:       "Summarize the slowest commands in the performance log",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Summarize the performance log per command, slowest first by 95th
+percentile duration, followed by the slowest single runs. QUERIES and API
+are averages per run; DB is the average time spent in the database.
+
+Examples:
+  gur perf report
+  gur perf report --since 24h -n 5
+  gur perf report --command list --json`,
+	Args: cobra.NoArgs,
+	RunE: runPerfReport,
+}
+
+var perfClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the performance log",
+	Args:  cobra.NoArgs,
+	RunE:  runPerfClear,
+}
+
+func init() {
+	rootCmd.AddCommand(perfCmd)
+	perfCmd.AddCommand(perfEnableCmd)
+	perfCmd.AddCommand(perfDisableCmd)
+	perfCmd.AddCommand(perfReportCmd)
+	perfCmd.AddCommand(perfClearCmd)
+	perfReportCmd.Flags().StringVar(&perfSince, "since", "", "Only commands run after this time (e.g., 24h, 7d, 2024-01-02)")
+	perfReportCmd.Flags().StringVar(&perfCommand, "command", "", "Only this command (e.g., list, \"sync push\")")
+	perfReportCmd.Flags().IntVarP(&perfLimit, "limit", "n", 10, "Commands and runs to show (0 = all)")
+}
+
+// perfEntry is one command in the performance log
+type perfEntry struct {
+	Time      time.Time `json:"time"`
+	Command   string    `json:"command"`
+	Duration  int64     `json:"duration_ms"`
+	DBQueries int64     `json:"db_queries"`
+	DBTime    int64     `json:"db_ms"`
+	APICalls  int64     `json:"api_calls"`
+	Success   bool      `json:"success"`
+}
+
+// Per-invocation counters, kept while the performance log is on
+var (
+	perfEnabled   bool
+	perfDBQueries atomic.Int64
+	perfDBTime    atomic.Int64 // Nanoseconds
+	perfAPICalls  atomic.Int64
+)
+
+// perfLogger counts the statements GORM runs and the time they take, then
+// hands them to the configured logger
+type perfLogger struct {
+	logger.Interface
+}
+
+func (l perfLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return perfLogger{l.Interface.LogMode(level)}
+}
+
+func (l perfLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	perfDBQueries.Add(1)
+	perfDBTime.Add(int64(time.Since(begin)))
+	l.Interface.Trace(ctx, begin, fc, err)
+}
+
+// perfTransport counts GitHub API requests
+type perfTransport struct {
+	base http.RoundTripper
+}
+
+func (t perfTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	perfAPICalls.Add(1)
+	return t.base.RoundTrip(req)
+}
+
+// perfLogPath returns the project's performance log file
+func perfLogPath() (string, error) {
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, db.GuardrailsDir, perfLogFileName), nil
+}
+
+// perfLogEnabled reports whether commands are being timed
+func perfLogEnabled() bool {
+	switch os.Getenv(EnvPerfLog) {
+	case "1", "true":
+		return true
+	case "0", "false":
+		return false
+	}
+	v, _ := db.GetConfig(models.ConfigPerfLog)
+	return v == "true"
+}
+
+// startPerfLog starts counting database statements for this command if the
+// performance log is on
+func startPerfLog(database *gorm.DB) {
+	if perfEnabled || database == nil || !perfLogEnabled() {
+		return
+	}
+	perfEnabled = true
+	database.Logger = perfLogger{database.Logger}
+}
+
+// isTimedCommand reports whether a command goes into the performance log;
+// 'gur perf' itself doesn't
+func isTimedCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == perfCmd {
+			return false
+		}
+	}
+	return cmd != rootCmd
+}
+
+// recordCommandPerf appends the finished command to the performance log
+func recordCommandPerf(cmd *cobra.Command, runErr error) {
+	if !perfEnabled || commandStartedAt.IsZero() || !isTimedCommand(cmd) {
+		return
+	}
+	entry := perfEntry{
+		Time:      commandStartedAt,
+		Command:   eventCommandName(cmd),
+		Duration:  time.Since(commandStartedAt).Milliseconds(),
+		DBQueries: perfDBQueries.Load(),
+		DBTime:    time.Duration(perfDBTime.Load()).Milliseconds(),
+		APICalls:  perfAPICalls.Load(),
+		Success:   runErr == nil,
+	}
+	if err := appendPerfEntry(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write performance log: %v\n", err)
+	}
+}
+
+// appendPerfEntry writes one entry to the log, starting a new file once it
+// grows past perfLogMaxBytes
+func appendPerfEntry(entry perfEntry) error {
+	path, err := perfLogPath()
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > perfLogMaxBytes {
+		if err := os.Rename(path, path+".old"); err != nil {
+			return err
+		}
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readPerfLog returns the logged entries; none if there is no log yet
+func readPerfLog(path string) ([]perfEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []perfEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e perfEntry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil && e.Command != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// perfSummary aggregates the logged runs of one command
+type perfSummary struct {
+	Command     string  `json:"command"`
+	Runs        int     `json:"runs"`
+	Failed      int     `json:"failed"`
+	AvgMs       int64   `json:"avg_ms"`
+	P95Ms       int64   `json:"p95_ms"`
+	MaxMs       int64   `json:"max_ms"`
+	AvgQueries  float64 `json:"avg_db_queries"`
+	AvgDBMs     int64   `json:"avg_db_ms"`
+	AvgAPICalls float64 `json:"avg_api_calls"`
+}
+
+// summarizePerf groups entries by command, slowest 95th percentile first
+func summarizePerf(entries []perfEntry) []perfSummary {
+	byCommand := make(map[string][]perfEntry)
+	for _, e := range entries {
+		byCommand[e.Command] = append(byCommand[e.Command], e)
+	}
+	summaries := make([]perfSummary, 0, len(byCommand))
+	for command, runs := range byCommand {
+		s := perfSummary{Command: command, Runs: len(runs)}
+		durations := make([]int64, len(runs))
+		var total, queries, dbTime, calls int64
+		for i, e := range runs {
+			durations[i] = e.Duration
+			total += e.Duration
+			queries += e.DBQueries
+			dbTime += e.DBTime
+			calls += e.APICalls
+			if !e.Success {
+				s.Failed++
+			}
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		n := int64(len(runs))
+		s.AvgMs = total / n
+		s.P95Ms = durations[int(math.Ceil(0.95*float64(n)))-1]
+		s.MaxMs = durations[n-1]
+		s.AvgQueries = math.Round(float64(queries)/float64(n)*10) / 10
+		s.AvgDBMs = dbTime / n
+		s.AvgAPICalls = math.Round(float64(calls)/float64(n)*10) / 10
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].P95Ms != summaries[j].P95Ms {
+			return summaries[i].P95Ms > summaries[j].P95Ms
+		}
+		return summaries[i].Command < summaries[j].Command
+	})
+	return summaries
+}
+
+// formatMs renders milliseconds as a rounded duration, e.g. 1.25s
+func formatMs(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	if d >= time.Second {
+		return d.Round(10 * time.Millisecond).String()
+	}
+	return d.String()
+}
+
+func setPerfLog(on bool) error {
+	if err := db.SetConfig(models.ConfigPerfLog, fmt.Sprintf("%t", on)); err != nil {
+		return fmt.Errorf("failed to save performance log setting: %w", err)
+	}
+	path, _ := perfLogPath()
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"enabled": on, "path": path})
+		return nil
+	}
+	if on {
+		fmt.Printf("Performance log on: commands are timed into %s\n", path)
+	} else {
+		fmt.Println("Performance log off (the existing log is kept; 'gur perf clear' deletes it)")
+	}
+	return nil
+}
+
+func runPerfReport(cmd *cobra.Command, args []string) error {
+	path, err := perfLogPath()
+	if err != nil {
+		return err
+	}
+	entries, err := readPerfLog(path)
+	if err != nil {
+		return fmt.Errorf("failed to read performance log: %w", err)
+	}
+
+	var cutoff time.Time
+	if perfSince != "" {
+		if cutoff, err = parseSince(perfSince, time.Now()); err != nil {
+			return withErrorCode(ErrCodeUsage, err)
+		}
+	}
+	filtered := entries[:0]
+	for _, e := range entries {
+		if e.Time.Before(cutoff) || (perfCommand != "" && !strings.EqualFold(e.Command, perfCommand)) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	entries = filtered
+
+	summaries := summarizePerf(entries)
+	slowest := append([]perfEntry{}, entries...)
+	sort.SliceStable(slowest, func(i, j int) bool { return slowest[i].Duration > slowest[j].Duration })
+	if perfLimit > 0 {
+		summaries = summaries[:min(perfLimit, len(summaries))]
+		slowest = slowest[:min(perfLimit, len(slowest))]
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"enabled":  perfLogEnabled(),
+			"path":     path,
+			"runs":     len(entries),
+			"commands": summaries,
+			"slowest":  slowest,
+		})
+		return nil
+	}
+
+	if len(entries) == 0 {
+		if perfLogEnabled() {
+			fmt.Println("No commands logged yet")
+		} else {
+			fmt.Println("No commands logged (the performance log is off; turn it on with 'gur perf enable')")
+		}
+		return nil
+	}
+
+	fmt.Printf("%d run(s) logged in %s\n\n", len(entries), path)
+	fmt.Printf("%-20s %5s %9s %9s %9s %8s %9s %6s\n", "COMMAND", "RUNS", "AVG", "P95", "MAX", "QUERIES", "DB", "API")
+	for _, s := range summaries {
+		fmt.Printf("%-20s %5d %9s %9s %9s %8.1f %9s %6.1f\n", s.Command, s.Runs,
+			formatMs(s.AvgMs), formatMs(s.P95Ms), formatMs(s.MaxMs), s.AvgQueries, formatMs(s.AvgDBMs), s.AvgAPICalls)
+	}
+
+	fmt.Println("\nSlowest runs:")
+	for _, e := range slowest {
+		status := ""
+		if !e.Success {
+			status = " (failed)"
+		}
+		fmt.Printf("  %s  %-20s %9s  %d queries, %d API calls%s\n", e.Time.Local().Format(models.DateTimeShortFormat),
+			e.Command, formatMs(e.Duration), e.DBQueries, e.APICalls, status)
+	}
+	if !perfLogEnabled() {
+		fmt.Println("\nThe performance log is off; 'gur perf enable' turns it back on")
+	}
+	return nil
+}
+
+func runPerfClear(cmd *cobra.Command, args []string) error {
+	path, err := perfLogPath()
+	if err != nil {
+		return err
+	}
+	removed := false
+	for _, p := range []string{path, path + ".old"} {
+		err := os.Remove(p)
+		if err == nil {
+			removed = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete performance log: %w", err)
+		}
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"cleared": removed, "path": path})
+	} else if removed {
+		fmt.Println("Performance log deleted")
+	} else {
+		fmt.Println("No performance log to delete")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSummarizePerf(t *testing.T) {
+	now := time.Now()
+	var entries []perfEntry
+	for i := int64(1); i <= 20; i++ {
+		entries = append(entries, perfEntry{Time: now, Command: "list", Duration: i * 10, DBQueries: 3, Success: true})
+	}
+	entries = append(entries,
+		perfEntry{Time: now, Command: "sync push", Duration: 4000, DBQueries: 200, APICalls: 30, Success: true},
+		perfEntry{Time: now, Command: "sync push", Duration: 2000, DBQueries: 100, APICalls: 15},
+	)
+
+	summaries := summarizePerf(entries)
+	if len(summaries) != 2 || summaries[0].Command != "sync push" {
+		t.Fatalf("summarizePerf() = %+v, want sync push first", summaries)
+	}
+	push, list := summaries[0], summaries[1]
+	if push.Runs != 2 || push.Failed != 1 || push.AvgMs != 3000 || push.MaxMs != 4000 || push.AvgQueries != 150 || push.AvgAPICalls != 22.5 {
+		t.Errorf("sync push summary = %+v", push)
+	}
+	if list.Runs != 20 || list.P95Ms != 190 || list.MaxMs != 200 || list.AvgMs != 105 {
+		t.Errorf("list summary = %+v", list)
+	}
+}
+
+func TestReadPerfLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), perfLogFileName)
+	if entries, err := readPerfLog(path); err != nil || entries != nil {
+		t.Fatalf("readPerfLog() without a log = %v, %v", entries, err)
+	}
+	data := `{"time":"2026-01-02T10:00:00Z","command":"list","duration_ms":12,"db_queries":3,"db_ms":1,"api_calls":0,"success":true}
+not json
+{"time":"2026-01-02T10:01:00Z","command":"sync pull","duration_ms":900,"db_queries":40,"db_ms":20,"api_calls":6,"success":false}
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := readPerfLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Command != "sync pull" || entries[1].APICalls != 6 || entries[1].Success {
+		t.Errorf("readPerfLog() = %+v", entries)
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var (
+	planImportDryRun bool
+	planImportNoDeps bool
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Turn Markdown planning documents into tasks",
+}
+
+var planImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Create an epic and task tree from a Markdown plan",
+	Long: `Create tasks from a Markdown plan in one transaction ('-' reads stdin):
+
+  # Q3 auth rework              A lone top heading is the plan's title
+  ## Sessions [P1] #area/auth   Each section heading becomes an epic
+  Text under a heading is the epic's description.
+  - Store sessions in Redis     Bullets become tasks of the epic...
+    - Add a Redis client [P0]   ...and nested bullets their subtasks
+      Indented text is the task's description.
+  - [x] Pick a library          Checked items are done and skipped
+  ## Login #frontend            The next epic waits for this one
+
+[P0]-[P4] sets an item's priority, otherwise it takes its parent's (P2 at
+the top); #name adds a label and may be namespaced (#area/auth). Bullets
+above the first heading become tasks without an epic.
+
+Sections are done in order: each epic, and each task directly under it,
+is blocked by the previous section's epic, so 'gur ready' offers the next
+section once the previous epic is closed. --no-deps leaves the sections
+independent.
+
+Examples:
+  gur plan import plan.md --dry-run
+  gur plan import plan.md
+  gur plan import docs/roadmap.md --no-deps --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanImport,
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	planCmd.AddCommand(planImportCmd)
+	planImportCmd.Flags().BoolVar(&planImportDryRun, "dry-run", false, "Show the tasks that would be created")
+	planImportCmd.Flags().BoolVar(&planImportNoDeps, "no-deps", false, "Don't make each section wait for the previous one")
+}
+
+// plannedTask is a task created from a plan item, with its subtasks
+type plannedTask struct {
+	ID       string         `json:"id,omitempty"` // Empty in a dry run
+	Title    string         `json:"title"`
+	Type     string         `json:"type"`
+	Priority int            `json:"priority"`
+	Labels   []string       `json:"labels,omitempty"`
+	After    string         `json:"after,omitempty"` // The previous section's epic (its quoted title in a dry run)
+	Subtasks []*plannedTask `json:"subtasks,omitempty"`
+}
+
+// importPlan creates the plan's tasks and section dependencies. Nothing is
+// written in a dry run, and tasks are returned without IDs.
+func importPlan(database *gorm.DB, plan models.Plan, dryRun, linkSections bool) ([]*plannedTask, error) {
+	var created []*plannedTask
+	err := database.Transaction(func(tx *gorm.DB) error {
+		var create func(item *models.PlanItem, taskType, parentID string, n, priority int) (*plannedTask, error)
+		create = func(item *models.PlanItem, taskType, parentID string, n, priority int) (*plannedTask, error) {
+			if item.Priority >= 0 {
+				priority = item.Priority
+			}
+			title := item.Title
+			if r := []rune(title); len(r) > 255 {
+				title = string(r[:255])
+			}
+			task := models.Task{
+				Title:       title,
+				Description: item.Description,
+				Status:      models.StatusOpen,
+				Priority:    priority,
+				Type:        taskType,
+				Labels:      item.Labels,
+			}
+			if parentID != "" {
+				task.ID = models.GenerateSubtaskID(parentID, n)
+				task.ParentID = parentID
+			}
+			if !dryRun {
+				if err := tx.Create(&task).Error; err != nil {
+					return nil, err
+				}
+				noteAffected(task.ID)
+			}
+			planned := &plannedTask{ID: task.ID, Title: task.Title, Type: task.Type, Priority: task.Priority, Labels: item.Labels}
+			for i, child := range item.Children {
+				sub, err := create(child, models.TypeTask, task.ID, i+1, priority)
+				if err != nil {
+					return nil, err
+				}
+				planned.Subtasks = append(planned.Subtasks, sub)
+			}
+			return planned, nil
+		}
+
+		for _, item := range plan.Tasks {
+			task, err := create(item, models.TypeTask, "", 0, models.PriorityMedium)
+			if err != nil {
+				return err
+			}
+			created = append(created, task)
+		}
+		var previous *plannedTask
+		for _, section := range plan.Sections {
+			epic, err := create(section, models.TypeEpic, "", 0, models.PriorityMedium)
+			if err != nil {
+				return err
+			}
+			if linkSections && previous != nil {
+				epic.After = previous.ID
+				if dryRun {
+					epic.After = fmt.Sprintf("%q", previous.Title)
+				} else {
+					blocked := []string{epic.ID}
+					for _, task := range epic.Subtasks {
+						blocked = append(blocked, task.ID)
+					}
+					for _, id := range blocked {
+						dep := models.Dependency{ParentID: previous.ID, ChildID: id, Type: models.DepTypeBlocks}
+						if err := tx.Create(&dep).Error; err != nil {
+							return err
+						}
+					}
+				}
+			}
+			created = append(created, epic)
+			previous = epic
+		}
+		return nil
+	})
+	return created, err
+}
+
+// printPlannedTasks prints the created tree, indenting subtasks
+func printPlannedTasks(tasks []*plannedTask, depth int) {
+	c := colors()
+	for _, t := range tasks {
+		id := t.ID
+		if id == "" {
+			id = "-"
+		}
+		fmt.Printf("%s%s %s %s", strings.Repeat("  ", depth), id, c.Priority(t.Priority), t.Title)
+		if t.Type == models.TypeEpic {
+			fmt.Print(" (epic)")
+		}
+		if len(t.Labels) > 0 {
+			fmt.Printf(" [%s]", strings.Join(t.Labels, ", "))
+		}
+		if t.After != "" {
+			fmt.Printf(" - after %s", t.After)
+		}
+		fmt.Println()
+		printPlannedTasks(t.Subtasks, depth+1)
+	}
+}
+
+func runPlanImport(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read plan: %w", err)
+	}
+	plan := models.ParsePlan(string(data))
+	if plan.Count() == 0 {
+		return fmt.Errorf("no headings or bullets found in '%s' (see 'gur plan import --help' for the format)", args[0])
+	}
+
+	created, err := importPlan(db.GetDB(), plan, planImportDryRun, !planImportNoDeps)
+	if err != nil {
+		return fmt.Errorf("failed to import plan: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"success": true,
+			"dry_run": planImportDryRun,
+			"title":   plan.Title,
+			"count":   plan.Count(),
+			"skipped": plan.Done,
+			"tasks":   created,
+		})
+		return nil
+	}
+	if IsQuietOutput() {
+		var printIDs func(tasks []*plannedTask)
+		printIDs = func(tasks []*plannedTask) {
+			for _, t := range tasks {
+				printQuietIDs(t.ID)
+				printIDs(t.Subtasks)
+			}
+		}
+		printIDs(created)
+		return nil
+	}
+
+	if plan.Title != "" {
+		fmt.Printf("%s\n\n", plan.Title)
+	}
+	printPlannedTasks(created, 0)
+	verb := "Created"
+	if planImportDryRun {
+		verb = "Would create"
+	}
+	fmt.Printf("\n%s %d task(s), %d of them epics", verb, plan.Count(), len(plan.Sections))
+	if plan.Done > 0 {
+		fmt.Printf("; skipped %d checked item(s)", plan.Done)
+	}
+	fmt.Println()
+	return nil
+}
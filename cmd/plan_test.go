@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestImportPlan(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	plan := models.ParsePlan("## Backend [P1]\n- API\n  - Handler [P0]\n## Frontend #ui\n- Form\n")
+
+	preview, err := importPlan(database, plan, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	database.Model(&models.Task{}).Count(&count)
+	if count != 0 || len(preview) != 2 || preview[0].ID != "" {
+		t.Fatalf("dry run created %d task(s), preview = %+v", count, preview)
+	}
+
+	created, err := importPlan(database, plan, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend, frontend := created[0], created[1]
+	if backend.Type != models.TypeEpic || backend.Priority != 1 {
+		t.Errorf("backend epic = %+v", backend)
+	}
+	handler := backend.Subtasks[0].Subtasks[0]
+	if handler.ID != backend.ID+".1.1" || handler.Priority != 0 || backend.Subtasks[0].Priority != 1 {
+		t.Errorf("backend tasks = %+v, %+v", backend.Subtasks[0], handler)
+	}
+	var stored models.Task
+	if err := database.First(&stored, "id = ?", handler.ID).Error; err != nil || stored.ParentID != backend.Subtasks[0].ID {
+		t.Errorf("subtask %s parent = %q, %v", handler.ID, stored.ParentID, err)
+	}
+
+	var blocked []string
+	database.Model(&models.Dependency{}).Where("parent_id = ? AND type = ?", backend.ID, models.DepTypeBlocks).Order("child_id ASC").Pluck("child_id", &blocked)
+	if len(blocked) != 2 || blocked[0] != frontend.ID || blocked[1] != frontend.Subtasks[0].ID {
+		t.Errorf("blocked by the backend epic = %v, want the frontend epic and its task", blocked)
+	}
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// prBodyFooter ends every generated pull request body
+const prBodyFooter = "*Generated from [GuardRails](https://github.com/Giancarlos/GuardRails) task management*"
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Work with GitHub pull requests for tasks",
+}
+
+var prDescribeCmd = &cobra.Command{
+	Use:   "describe <task-id>",
+	Short: "Generate a pull request description from a task",
+	Long: `Generate a ready-to-paste pull request body from a task: its summary,
+acceptance criteria from linked gates (steps and expected results, checked
+once the gate is satisfied), dependencies, and a footer referencing the task
+and the GitHub issue it closes.
+
+With --create, the pull request is opened in the configured repository
+('gur config github') and its URL is added to the task's notes.
+
+Examples:
+  gur pr describe gur-abc123
+  gur pr describe gur-abc123 | pbcopy
+  gur pr describe gur-abc123 --create --base main --head fix/login-timeout
+  gur pr describe gur-abc123 --create --head fix/login-timeout --draft`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRDescribe,
+}
+
+var (
+	prCreate bool
+	prBase   string
+	prHead   string
+	prTitle  string
+	prDraft  bool
+)
+
+func init() {
+	rootCmd.AddCommand(prCmd)
+	prCmd.AddCommand(prDescribeCmd)
+	prDescribeCmd.Flags().BoolVar(&prCreate, "create", false, "Open the pull request on GitHub")
+	prDescribeCmd.Flags().StringVar(&prBase, "base", "main", "Branch to merge into (with --create)")
+	prDescribeCmd.Flags().StringVar(&prHead, "head", "", "Branch with the changes (required with --create)")
+	prDescribeCmd.Flags().StringVar(&prTitle, "title", "", "Pull request title (default: task title)")
+	prDescribeCmd.Flags().BoolVar(&prDraft, "draft", false, "Open as a draft pull request (with --create)")
+}
+
+// prDescription gathers everything needed to render a pull request body
+type prDescription struct {
+	Task      models.Task
+	Gates     []GateLinkInfo
+	Relations issueBodyData // Blockers, blocking tasks, subtasks and parent
+	Issue     *models.GitHubIssueLink
+}
+
+func collectPRDescription(database *gorm.DB, task *models.Task) (*prDescription, error) {
+	d := &prDescription{Task: *task}
+	relations, err := loadIssueBodyData(database, *task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies for task '%s': %w", task.ID, err)
+	}
+	d.Relations = relations
+	if d.Gates, err = GetGateLinksForTask(task.ID); err != nil {
+		return nil, fmt.Errorf("failed to load gates for task '%s': %w", task.ID, err)
+	}
+	var link models.GitHubIssueLink
+	if database.Where("task_id = ?", task.ID).First(&link).Error == nil {
+		d.Issue = &link
+	}
+	return d, nil
+}
+
+// prTaskRef describes a related task, by issue number when it is synced
+func prTaskRef(t issueBodyTask) string {
+	if t.Issue > 0 {
+		return fmt.Sprintf("#%d (`%s` %s, %s)", t.Issue, t.ID, t.Title, t.Status)
+	}
+	return fmt.Sprintf("`%s` %s (%s)", t.ID, t.Title, t.Status)
+}
+
+// renderPRDescription renders the pull request body as Markdown
+func renderPRDescription(d *prDescription, now time.Time) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Summary\n\n")
+	switch {
+	case d.Task.Summary != "":
+		sb.WriteString(d.Task.Summary)
+	case d.Task.Description != "":
+		sb.WriteString(d.Task.Description)
+	default:
+		sb.WriteString(d.Task.Title)
+	}
+	sb.WriteString("\n\n")
+
+	if len(d.Gates) > 0 {
+		sb.WriteString("## Acceptance Criteria\n\n")
+		for _, info := range d.Gates {
+			check := " "
+			if info.Link.Satisfied(now) {
+				check = "x"
+			}
+			sb.WriteString(fmt.Sprintf("- [%s] **%s** (%s)", check, info.Gate.Title, info.Gate.TypeString()))
+			if info.Link.Status == models.GateLinkWaived {
+				sb.WriteString(" — waived")
+			}
+			sb.WriteString("\n")
+			if steps := strings.TrimSpace(info.Gate.Steps); steps != "" {
+				sb.WriteString("  - Steps:\n")
+				for _, line := range strings.Split(steps, "\n") {
+					sb.WriteString(fmt.Sprintf("    %s\n", strings.TrimSpace(line)))
+				}
+			}
+			if expected := strings.TrimSpace(info.Gate.ExpectedResult); expected != "" {
+				sb.WriteString(fmt.Sprintf("  - Expected: %s\n", expected))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	r := d.Relations
+	if len(r.Blockers) > 0 || len(r.Blocking) > 0 || len(r.Subtasks) > 0 || r.Parent != nil {
+		sb.WriteString("## Dependencies\n\n")
+		if r.Parent != nil {
+			sb.WriteString(fmt.Sprintf("- Part of %s\n", prTaskRef(*r.Parent)))
+		}
+		for _, t := range r.Blockers {
+			sb.WriteString(fmt.Sprintf("- Depends on %s\n", prTaskRef(t)))
+		}
+		for _, t := range r.Blocking {
+			sb.WriteString(fmt.Sprintf("- Unblocks %s\n", prTaskRef(t)))
+		}
+		for _, t := range r.Subtasks {
+			sb.WriteString(fmt.Sprintf("- Subtask %s\n", prTaskRef(t)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("---\n\n")
+	sb.WriteString(fmt.Sprintf("**Task:** `%s`", d.Task.ID))
+	if d.Issue != nil {
+		sb.WriteString(fmt.Sprintf(" · Closes #%d", d.Issue.IssueNumber))
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(prBodyFooter)
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// createPullRequest opens a pull request in owner/repo
+func createPullRequest(ctx context.Context, client *github.Client, owner, repo string, pr *github.NewPullRequest) (*github.PullRequest, error) {
+	created, _, err := client.PullRequests.Create(ctx, owner, repo, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+	return created, nil
+}
+
+func runPRDescribe(cmd *cobra.Command, args []string) error {
+	if prCreate && prHead == "" {
+		return fmt.Errorf("--create requires --head <branch>")
+	}
+	if !prCreate && (cmd.Flags().Changed("head") || cmd.Flags().Changed("base") || prDraft) {
+		return fmt.Errorf("--base, --head and --draft only apply with --create")
+	}
+
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot describe pull request: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	database := db.GetDB()
+	d, err := collectPRDescription(database, task)
+	if err != nil {
+		return err
+	}
+	title := prTitle
+	if title == "" {
+		title = task.Title
+	}
+	// Only reference the issue when it lives in the repository the PR targets
+	repo, _ := db.GetConfig(models.ConfigGitHubRepo)
+	if d.Issue != nil && d.Issue.Repository != repo {
+		d.Issue = nil
+	}
+	body := renderPRDescription(d, time.Now())
+
+	if !prCreate {
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"task_id": task.ID, "title": title, "body": body})
+		} else {
+			fmt.Print(body)
+		}
+		return nil
+	}
+
+	if repo == "" {
+		return codedErrorf(ErrCodeNotConfigured, "GitHub sync not configured: repository not set (run 'gur config github' to configure)")
+	}
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository format '%s': expected 'owner/repo' (run 'gur config github' to reconfigure)", repo)
+	}
+	token, err := GetGitHubToken()
+	if err != nil {
+		return err
+	}
+	client, err := newGitHubClient(token)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pr, err := createPullRequest(ctx, client, parts[0], parts[1], &github.NewPullRequest{
+		Title: github.String(title),
+		Head:  github.String(prHead),
+		Base:  github.String(prBase),
+		Body:  github.String(body),
+		Draft: github.Bool(prDraft),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := database.Transaction(func(tx *gorm.DB) error {
+		if _, err := addNote(tx, task, models.NoteKindLog, eventActor(), "Opened pull request "+pr.GetHTMLURL(), eventActor()); err != nil {
+			return err
+		}
+		return tx.Save(task).Error
+	}); err != nil {
+		return fmt.Errorf("pull request %s created but not recorded on task '%s': %w", pr.GetHTMLURL(), task.ID, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task_id": task.ID, "number": pr.GetNumber(), "url": pr.GetHTMLURL(), "title": title, "body": body})
+		return nil
+	}
+	fmt.Printf("Created pull request #%d: %s\n", pr.GetNumber(), pr.GetHTMLURL())
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestRenderPRDescription(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-pr000001", Title: "Fix login timeout", Status: models.StatusInProgress, Description: "Sessions expire after 5 minutes."})
+	database.Create(&models.Task{ID: "gur-pr000002", Title: "Upgrade session store", Status: models.StatusClosed})
+	database.Create(&models.Dependency{ParentID: "gur-pr000002", ChildID: "gur-pr000001", Type: models.DepTypeBlocks})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-pr000002", IssueNumber: 7, Repository: "acme/app"})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-pr000001", IssueNumber: 12, Repository: "acme/app"})
+	database.Create(&models.Gate{ID: "gate-pr000001", Title: "Login stays active", Type: "qa", Steps: "Log in\nWait 10 minutes", ExpectedResult: "Still logged in"})
+	database.Create(&models.Gate{ID: "gate-pr000002", Title: "Unit tests", Type: "test"})
+	database.Create(&models.GateTaskLink{GateID: "gate-pr000001", TaskID: "gur-pr000001", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: "gate-pr000002", TaskID: "gur-pr000001", Status: models.GateLinkPassed})
+
+	task, _ := db.GetTaskByID("gur-pr000001")
+	d, err := collectPRDescription(database, task)
+	if err != nil {
+		t.Fatalf("collectPRDescription() error: %v", err)
+	}
+	body := renderPRDescription(d, time.Now())
+
+	for _, want := range []string{
+		"## Summary\n\nSessions expire after 5 minutes.",
+		"- [ ] **Login stays active** (qa)\n  - Steps:\n    Log in\n    Wait 10 minutes\n  - Expected: Still logged in\n",
+		"- [x] **Unit tests** (test)\n",
+		"- Depends on #7 (`gur-pr000002` Upgrade session store, closed)",
+		"**Task:** `gur-pr000001` · Closes #12",
+		prBodyFooter,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	var got github.NewPullRequest
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/app/pulls" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"number": 42, "html_url": "https://github.com/acme/app/pull/42"})
+	}))
+
+	pr, err := createPullRequest(context.Background(), client, "acme", "app", &github.NewPullRequest{
+		Title: github.String("Fix login timeout"),
+		Head:  github.String("fix/login"),
+		Base:  github.String("main"),
+		Body:  github.String("body"),
+	})
+	if err != nil {
+		t.Fatalf("createPullRequest() error: %v", err)
+	}
+	if pr.GetNumber() != 42 || got.GetHead() != "fix/login" || got.GetBase() != "main" || got.GetBody() != "body" {
+		t.Errorf("pr #%d, request %+v", pr.GetNumber(), got)
+	}
+	if !pullRequestPattern.MatchString(pr.GetHTMLURL()) {
+		t.Errorf("PR URL %q should be picked up by briefs", pr.GetHTMLURL())
+	}
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// rankStep spaces out ranks so most moves only rewrite the moved task
+const rankStep = 1024
+
+var (
+	rankBefore string
+	rankAfter  string
+	rankClear  bool
+	rankBy     string
+)
+
+var rankCmd = &cobra.Command{
+	Use:   "rank <task-id>",
+	Short: "Order a task relative to another of the same priority",
+	Long: `Order the backlog by hand within a priority. Ranked tasks come before
+unranked ones of the same priority in list, ready and the web dashboard;
+unranked tasks keep the default order (newest first).
+
+Ranking a task relative to an unranked task ranks that task too, after
+any tasks already ranked. Ranks are kept in the GitHub issue body, so they
+survive 'gur sync push' and 'gur sync pull'.
+
+Examples:
+  gur rank gur-abc123 --before gur-def456
+  gur rank gur-abc123 --after gur-def456
+  gur rank gur-abc123 --clear`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRank,
+}
+
+func init() {
+	rootCmd.AddCommand(rankCmd)
+	rankCmd.Flags().StringVar(&rankBefore, "before", "", "Place the task directly before this task")
+	rankCmd.Flags().StringVar(&rankAfter, "after", "", "Place the task directly after this task")
+	rankCmd.Flags().BoolVar(&rankClear, "clear", false, "Remove the task's rank")
+	rankCmd.Flags().StringVar(&rankBy, "by", "human", "Who is ranking (human/agent/name)")
+	rankCmd.MarkFlagsMutuallyExclusive("before", "after", "clear")
+	rankCmd.MarkFlagsOneRequired("before", "after", "clear")
+}
+
+// setRank changes a task's rank, recording history for undo
+func setRank(tx *gorm.DB, task *models.Task, rank int, by string) error {
+	if task.Rank == rank {
+		return nil
+	}
+	old := task.Rank
+	task.Rank = rank
+	if err := tx.Model(task).Update("rank", rank).Error; err != nil {
+		return err
+	}
+	noteAffected(task.ID)
+	return models.RecordChange(tx, task.ID, "rank", strconv.Itoa(old), strconv.Itoa(rank), by)
+}
+
+// rankTask moves task directly before (or after) anchor, which must have the
+// same priority. An unranked anchor is ranked after the ranked tasks first.
+// If there is no gap left between the neighbours, the priority's ranked
+// tasks are renumbered.
+func rankTask(tx *gorm.DB, task, anchor *models.Task, before bool, by string) error {
+	if task.ID == anchor.ID {
+		return fmt.Errorf("cannot rank task '%s' relative to itself", task.ID)
+	}
+	if task.Priority != anchor.Priority {
+		return fmt.Errorf("cannot rank task '%s' (P%d) relative to '%s' (P%d): ranks only order tasks of the same priority (use 'gur update %s --priority %d' first)",
+			task.ID, task.Priority, anchor.ID, anchor.Priority, task.ID, anchor.Priority)
+	}
+
+	var ranked []models.Task
+	if err := tx.Where("priority = ? AND rank > 0 AND id != ?", task.Priority, task.ID).
+		Order("rank ASC, id ASC").Find(&ranked).Error; err != nil {
+		return err
+	}
+	if anchor.Rank == 0 {
+		last := 0
+		if len(ranked) > 0 {
+			last = ranked[len(ranked)-1].Rank
+		}
+		if err := setRank(tx, anchor, last+rankStep, by); err != nil {
+			return err
+		}
+		ranked = append(ranked, *anchor)
+	}
+
+	// Insertion point: the index task will occupy in ranked
+	at := 0
+	for i, t := range ranked {
+		if t.ID == anchor.ID {
+			at = i
+			if !before {
+				at = i + 1
+			}
+			break
+		}
+	}
+	prev := 0
+	if at > 0 {
+		prev = ranked[at-1].Rank
+	}
+	next := prev + 2*rankStep
+	if at < len(ranked) {
+		next = ranked[at].Rank
+	}
+	if next-prev >= 2 {
+		return setRank(tx, task, prev+(next-prev)/2, by)
+	}
+
+	order := append(append(append([]models.Task{}, ranked[:at]...), *task), ranked[at:]...)
+	for i := range order {
+		t := &order[i]
+		if t.ID == task.ID {
+			t = task
+		}
+		if err := setRank(tx, t, (i+1)*rankStep, by); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runRank(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot rank task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	database := db.GetDB()
+	var anchor *models.Task
+	if !rankClear {
+		anchorID := rankBefore
+		if anchorID == "" {
+			anchorID = rankAfter
+		}
+		if anchor, err = db.GetTaskByID(anchorID); err != nil {
+			return codedErrorf(ErrCodeNotFound, "cannot rank task: task '%s' not found (use 'gur list' to see available tasks)", anchorID)
+		}
+	}
+
+	err = database.Transaction(func(tx *gorm.DB) error {
+		if rankClear {
+			return setRank(tx, task, 0, rankBy)
+		}
+		return rankTask(tx, task, anchor, rankBefore != "", rankBy)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rank task '%s': %w", task.ID, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "task_id": task.ID, "rank": task.Rank, "priority": task.Priority})
+		return nil
+	}
+	switch {
+	case rankClear:
+		fmt.Printf("Cleared rank of %s\n", task.ID)
+	case rankBefore != "":
+		fmt.Printf("Ranked %s before %s (P%d)\n", task.ID, anchor.ID, task.Priority)
+	default:
+		fmt.Printf("Ranked %s after %s (P%d)\n", task.ID, anchor.ID, task.Priority)
+	}
+	return nil
+}
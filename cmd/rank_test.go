@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func readyIDs(t *testing.T) []string {
+	t.Helper()
+	tasks, err := findReadyTasks(db.GetDB())
+	if err != nil {
+		t.Fatalf("findReadyTasks() error: %v", err)
+	}
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func rankByID(t *testing.T, moved, anchor string, before bool) {
+	t.Helper()
+	task, _ := db.GetTaskByID(moved)
+	other, _ := db.GetTaskByID(anchor)
+	if err := rankTask(db.GetDB(), task, other, before, "alice"); err != nil {
+		t.Fatalf("rankTask(%s, %s) error: %v", moved, anchor, err)
+	}
+}
+
+func TestRankTask(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	for _, id := range []string{"gur-rank0001", "gur-rank0002", "gur-rank0003", "gur-rank0004"} {
+		database.Create(&models.Task{ID: id, Title: id, Status: models.StatusOpen, Priority: models.PriorityMedium})
+	}
+	database.Create(&models.Task{ID: "gur-rank0005", Title: "urgent", Status: models.StatusOpen, Priority: models.PriorityHigh})
+	database.Exec("UPDATE tasks SET created_at = datetime('now', '-' || substr(id, -1) || ' minutes')")
+
+	// Unranked: newest first within the priority
+	want := []string{"gur-rank0005", "gur-rank0001", "gur-rank0002", "gur-rank0003", "gur-rank0004"}
+	if got := readyIDs(t); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unranked order = %v, want %v", got, want)
+	}
+
+	// Ranking before an unranked task ranks both ahead of the unranked rest
+	rankByID(t, "gur-rank0004", "gur-rank0003", true)
+	want = []string{"gur-rank0005", "gur-rank0004", "gur-rank0003", "gur-rank0001", "gur-rank0002"}
+	if got := readyIDs(t); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after rank 4 before 3 = %v, want %v", got, want)
+	}
+
+	rankByID(t, "gur-rank0002", "gur-rank0004", false)
+	rankByID(t, "gur-rank0001", "gur-rank0004", true)
+	want = []string{"gur-rank0005", "gur-rank0001", "gur-rank0004", "gur-rank0002", "gur-rank0003"}
+	if got := readyIDs(t); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after more moves = %v, want %v", got, want)
+	}
+
+	// Exhaust the gap so the priority is renumbered
+	database.Model(&models.Task{}).Where("id = ?", "gur-rank0004").Update("rank", 1)
+	database.Model(&models.Task{}).Where("id = ?", "gur-rank0002").Update("rank", 2)
+	database.Model(&models.Task{}).Where("id = ?", "gur-rank0003").Update("rank", 3)
+	database.Model(&models.Task{}).Where("id = ?", "gur-rank0001").Update("rank", 0)
+	rankByID(t, "gur-rank0001", "gur-rank0002", true)
+	want = []string{"gur-rank0005", "gur-rank0004", "gur-rank0001", "gur-rank0002", "gur-rank0003"}
+	if got := readyIDs(t); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after renumbering = %v, want %v", got, want)
+	}
+	if task, _ := db.GetTaskByID("gur-rank0003"); task.Rank != 4*rankStep {
+		t.Errorf("renumbered rank = %d, want %d", task.Rank, 4*rankStep)
+	}
+
+	urgent, _ := db.GetTaskByID("gur-rank0005")
+	other, _ := db.GetTaskByID("gur-rank0001")
+	if err := rankTask(database, urgent, other, true, "alice"); err == nil || !strings.Contains(err.Error(), "same priority") {
+		t.Errorf("ranking across priorities error = %v, want same priority error", err)
+	}
+}
+
+func TestRankUndo(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-rankund1", Title: "A", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-rankund2", Title: "B", Status: models.StatusOpen})
+	rankByID(t, "gur-rankund1", "gur-rankund2", false)
+
+	var history []models.TaskHistory
+	database.Where("field = ?", "rank").Order("id ASC").Find(&history)
+	if len(history) != 2 {
+		t.Fatalf("recorded %d rank changes, want 2", len(history))
+	}
+	if err := revertChanges(database, history); err != nil {
+		t.Fatalf("revertChanges() error: %v", err)
+	}
+	for _, id := range []string{"gur-rankund1", "gur-rankund2"} {
+		if task, _ := db.GetTaskByID(id); task.Rank != 0 {
+			t.Errorf("%s rank after undo = %d, want 0", id, task.Rank)
+		}
+	}
+}
+
+func TestRankMarkerRoundTrip(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := models.Task{ID: "gur-rankmrk1", Title: "Ranked", Description: "Details", Status: models.StatusOpen, Rank: 3 * rankStep}
+	database.Create(&task)
+
+	body, err := renderIssueBody(database, nil, task)
+	if err != nil {
+		t.Fatalf("renderIssueBody() error: %v", err)
+	}
+	pulled, err := createTaskFromIssue(&github.Issue{Title: github.String("Ranked"), Body: github.String(body), State: github.String("open")}, models.DefaultLabelMap())
+	if err != nil {
+		t.Fatalf("createTaskFromIssue() error: %v", err)
+	}
+	if pulled.Rank != task.Rank {
+		t.Errorf("pulled rank = %d, want %d", pulled.Rank, task.Rank)
+	}
+	if pulled.Description != buildIssueBody(task) {
+		t.Errorf("pulled description kept the rank marker:\n%s", pulled.Description)
+	}
+
+	if rank, rest := parseRankMarker("No marker here"); rank != 0 || rest != "No marker here" {
+		t.Errorf("parseRankMarker(no marker) = %d, %q", rank, rest)
+	}
+}
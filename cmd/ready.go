@@ -2,58 +2,419 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 
 	"guardrails/internal/db"
 	"guardrails/internal/models"
 )
 
 var readyCmd = &cobra.Command{
-	Use:   "ready",
-	Short: "List tasks with no open blockers",
-	RunE:  runReady,
+	Use:         "ready",
+	Short:       "List tasks with no open blockers",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `List tasks with no open blockers.
+
+A "blocks" dependency hides a task until its blocker closes; a
+"finish-to-start-after" dependency also waits out its lag after the close.
+"soft-blocks" dependencies never hide a task, but ready flags it while the
+soft blocker is open (see 'gur dep add --type').
+
+Tasks are ordered by effective priority: a task blocking a more urgent task
+(directly or further down a chain of "blocks" dependencies) ranks at that
+task's priority, and is flagged "effective P0 via <id>".
+
+With --agent, tasks are matched against the agent's registered capabilities
+(see 'gur agent add --capabilities'). A task's requirements are its linked
+skills and its labels. Tasks linked to the agent come first, then tasks
+whose requirements match its capabilities; tasks whose primary agent is
+someone else are flagged and listed last. --strict hides tasks with
+requirements the agent doesn't match and tasks for other primary agents.
+
+Paging (--limit, --page, --offset), --sort and --fields work as in 'gur list'.
+
+--group epic, label or assignee lists the tasks under headings, keeping
+their order within each. A task's epic is the nearest epic above it, through
+subtask parents and parent-child dependencies. --format tree shows the epics
+as a tree instead, with the parent tasks between each epic and its ready
+tasks, to help pick a coherent stream of work.
+
+Examples:
+  gur ready
+  gur ready --limit 10 --fields id,title
+  gur ready --group assignee
+  gur ready --format tree
+  gur ready --agent frontend-dev
+  gur ready --agent frontend-dev --strict
+  gur ready --jsonl | jq -c '{id, title}'`,
+	RunE: runReady,
 }
 
+var (
+	readyAgent   string
+	readyStrict  bool
+	readyGroupBy string
+	readyFormat  string
+	readyPage    pageOptions
+)
+
 func init() {
 	rootCmd.AddCommand(readyCmd)
+	readyCmd.Flags().StringVar(&readyAgent, "agent", "", "Match tasks against this agent's capabilities")
+	readyCmd.Flags().BoolVar(&readyStrict, "strict", false, "With --agent, only show tasks the agent matches")
+	readyCmd.Flags().StringVar(&readyGroupBy, "group", "", "Group tasks by "+strings.Join(readyGroupings, ", "))
+	readyCmd.Flags().StringVar(&readyFormat, "format", readyFormatList, "Output format: list, or tree of epics")
+	addPageFlags(readyCmd, &readyPage, taskSorts)
+	addJSONLFlag(readyCmd)
 }
 
 func runReady(cmd *cobra.Command, args []string) error {
+	if readyStrict && readyAgent == "" {
+		return fmt.Errorf("--strict requires --agent")
+	}
+	if err := readyPage.resolve(); err != nil {
+		return err
+	}
+	if err := readyPage.validateFields(models.Task{}); err != nil {
+		return err
+	}
+	if err := validateReadyGrouping(readyGroupBy, readyFormat); err != nil {
+		return err
+	}
+	grouped := readyGroupBy != "" || readyFormat == readyFormatTree
+	if grouped && (readyAgent != "" || jsonlOutput || len(readyPage.fields) > 0) {
+		return fmt.Errorf("--group and --format tree can't be combined with --agent, --jsonl or --fields")
+	}
+
 	database := db.GetDB()
+	inherited, err := loadInheritedPriorities(database)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	order := readyPage.order(effectiveReadyOrder(inherited))
+	if readyAgent != "" {
+		// Agent ranking reorders the whole set, so page after ranking
+		var readyTasks []models.Task
+		if err := readyTasksQuery(database).Order(order).Find(&readyTasks).Error; err != nil {
+			return err
+		}
+		return runReadyForAgent(database, readyTasks, inherited)
+	}
+
+	if jsonlOutput {
+		query := readyPage.bound(readyTasksQuery(database)).Order(order)
+		return streamTasksJSONL(database, query, newJSONLWriter(nil, readyPage.fields))
+	}
+
+	query, total, err := readyPage.paginate(readyTasksQuery(database), &models.Task{})
+	if err != nil {
+		return err
+	}
+	var readyTasks []models.Task
+	if err := query.Order(order).Find(&readyTasks).Error; err != nil {
+		return err
+	}
+
+	if len(readyPage.fields) > 0 {
+		return printProjectedTasks(&readyPage, readyTasks, total)
+	}
+
+	soft, err := findSoftBlockers(database, readyTasks)
+	if err != nil {
+		return err
+	}
+
+	var groups []readyGroup
+	var tree []*readyTreeNode
+	if readyFormat == readyFormatTree {
+		if tree, err = buildReadyTree(database, readyTasks); err != nil {
+			return fmt.Errorf("failed to load epics: database error: %w", err)
+		}
+	} else if readyGroupBy != "" {
+		if groups, err = groupReadyTasks(database, readyTasks, readyGroupBy); err != nil {
+			return fmt.Errorf("failed to group ready tasks: database error: %w", err)
+		}
+	}
+
+	if IsJSONOutput() {
+		result := map[string]interface{}{"count": len(readyTasks), "tasks": readyTasks}
+		if len(soft) > 0 {
+			result["soft_blocked"] = soft
+		}
+		if shown := inheritedFor(inherited, readyTasks); len(shown) > 0 {
+			result["effective_priority"] = shown
+		}
+		if groups != nil {
+			result["groups"] = groups
+		}
+		if tree != nil {
+			result["tree"] = tree
+		}
+		OutputJSON(readyPage.meta(result, total))
+		return nil
+	}
+
+	if IsQuietOutput() {
+		for _, t := range readyTasks {
+			printQuietIDs(t.ID)
+		}
+		return nil
+	}
+
+	if len(readyTasks) == 0 {
+		fmt.Println("No ready tasks")
+		return nil
+	}
+
+	now := time.Now()
+	c := colors()
+	line := func(t models.Task) string {
+		p, ok := inherited[t.ID]
+		return fmt.Sprintf("[%s] %s %s - %s%s%s%s", t.ID, c.Priority(t.Priority), c.Status(t.Status), t.Title, effectivePriorityAnnotation(p, ok), dueAnnotation(t, now), softBlockAnnotation(soft[t.ID]))
+	}
+	switch {
+	case tree != nil:
+		fmt.Printf("Ready tasks (%d) by epic:\n", total)
+		printReadyTree(tree, 0, line)
+	case groups != nil:
+		fmt.Printf("Ready tasks (%d) by %s:\n", total, readyGroupBy)
+		for _, g := range groups {
+			heading := g.Key
+			switch {
+			case g.Key == "":
+				heading = "No " + readyGroupBy
+			case g.Title != "":
+				heading = g.Key + " " + g.Title
+			}
+			fmt.Printf("%s (%d):\n", heading, len(g.tasks))
+			for _, t := range g.tasks {
+				fmt.Printf("  %s\n", line(t))
+			}
+		}
+	default:
+		fmt.Printf("Ready tasks (%d):\n", total)
+		for _, t := range readyTasks {
+			fmt.Println(line(t))
+		}
+	}
+	readyPage.printMoreHint(len(readyTasks), total)
+	return nil
+}
+
+// printProjectedTasks outputs only the --fields of each task
+func printProjectedTasks(opts *pageOptions, tasks []models.Task, total int64) error {
+	if err := attachFieldValues(db.GetDB(), tasks); err != nil {
+		return err
+	}
+	rows, err := opts.project(tasks)
+	if err != nil {
+		return err
+	}
+	if IsJSONOutput() {
+		OutputJSON(opts.meta(map[string]interface{}{"count": len(rows), "tasks": rows}, total))
+		return nil
+	}
+	opts.printProjected(rows)
+	opts.printMoreHint(len(rows), total)
+	return nil
+}
 
+// runReadyForAgent lists ready tasks ranked for an agent
+func runReadyForAgent(database *gorm.DB, readyTasks []models.Task, inherited map[string]inheritedPriority) error {
+	var agent models.Agent
+	if err := database.Where("name = ?", readyAgent).First(&agent).Error; err != nil {
+		return codedErrorf(ErrCodeNotFound, "agent '%s' not found (use 'gur agent list' to see registered agents)", readyAgent)
+	}
+
+	matches, err := matchTasksToAgent(database, agent, readyTasks)
+	if err != nil {
+		return err
+	}
+	if readyStrict {
+		kept := matches[:0]
+		for _, m := range matches {
+			if m.OtherPrimary == "" && !m.Unmatched {
+				kept = append(kept, m)
+			}
+		}
+		matches = kept
+	}
+
+	total := int64(len(matches))
+	start, end := readyPage.window(len(matches))
+	matches = matches[start:end]
+
+	if len(readyPage.fields) > 0 {
+		tasks := make([]models.Task, len(matches))
+		for i, m := range matches {
+			tasks[i] = m.Task
+		}
+		if jsonlOutput {
+			if err := attachFieldValues(database, tasks); err != nil {
+				return err
+			}
+			w := newJSONLWriter(nil, readyPage.fields)
+			for _, t := range tasks {
+				if err := w.write(t); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return printProjectedTasks(&readyPage, tasks, total)
+	}
+
+	if jsonlOutput {
+		w := newJSONLWriter(nil, nil)
+		for _, m := range matches {
+			if err := w.write(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tasks := make([]models.Task, len(matches))
+	for i, m := range matches {
+		tasks[i] = m.Task
+	}
+	soft, err := findSoftBlockers(database, tasks)
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		result := map[string]interface{}{"agent": agent.Name, "count": len(matches), "tasks": matches}
+		if len(soft) > 0 {
+			result["soft_blocked"] = soft
+		}
+		if shown := inheritedFor(inherited, tasks); len(shown) > 0 {
+			result["effective_priority"] = shown
+		}
+		OutputJSON(readyPage.meta(result, total))
+		return nil
+	}
+
+	if IsQuietOutput() {
+		for _, m := range matches {
+			printQuietIDs(m.Task.ID)
+		}
+		return nil
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No ready tasks for %s\n", agent.Name)
+		return nil
+	}
+
+	now := time.Now()
+	c := colors()
+	fmt.Printf("Ready tasks for %s (%d):\n", agent.Name, total)
+	for _, m := range matches {
+		t := m.Task
+		p, ok := inherited[t.ID]
+		fmt.Printf("[%s] %s %s - %s%s%s%s%s\n", t.ID, c.Priority(t.Priority), c.Status(t.Status), t.Title, effectivePriorityAnnotation(p, ok), dueAnnotation(t, now), agentMatchAnnotation(m), softBlockAnnotation(soft[t.ID]))
+	}
+	readyPage.printMoreHint(len(matches), total)
+	return nil
+}
+
+// readyOrder ranks ready tasks by priority, then manual rank ('gur rank'),
+// then earliest due date, then most votes ('gur sync pull --votes'), then
+// newest. Ready listings rank by effective
+// priority instead (see effectiveReadyOrder).
+const readyOrder = "priority ASC, rank = 0, rank ASC, due_at IS NULL, due_at ASC, votes DESC, created_at DESC"
+
+// findReadyTasks returns open/in-progress tasks with no open blockers, most
+// urgent by effective priority first
+func findReadyTasks(database *gorm.DB) ([]models.Task, error) {
+	inherited, err := loadInheritedPriorities(database)
+	if err != nil {
+		return nil, err
+	}
+	var readyTasks []models.Task
+	if err := readyTasksQuery(database).Order(effectiveReadyOrder(inherited)).Find(&readyTasks).Error; err != nil {
+		return nil, err
+	}
+	return readyTasks, nil
+}
+
+// readyTasksQuery selects open/in-progress tasks with no open blockers,
+// unordered so callers can count and page it
+func readyTasksQuery(database *gorm.DB) *gorm.DB {
 	// Get IDs of tasks that have open blockers (single query)
 	var blockedTaskIDs []string
 	database.Model(&models.Dependency{}).
 		Select("DISTINCT dependencies.child_id").
 		Joins("JOIN tasks ON tasks.id = dependencies.parent_id").
-		Where("dependencies.type = ? AND tasks.status != ?",
-			models.DepTypeBlocks, models.StatusClosed).
+		Where("dependencies.type IN ? AND tasks.status != ?",
+			[]string{models.DepTypeBlocks, models.DepTypeFinishStart}, models.StatusClosed).
 		Pluck("child_id", &blockedTaskIDs)
+	blockedTaskIDs = append(blockedTaskIDs, laggingTaskIDs(database, time.Now())...)
 
 	// Get all open/in-progress tasks that are NOT in the blocked list (single query)
-	var readyTasks []models.Task
-	query := database.Where("status IN ?", []string{models.StatusOpen, models.StatusInProgress})
+	query := database.Model(&models.Task{}).Where("status IN ?", []string{models.StatusOpen, models.StatusInProgress})
 	if len(blockedTaskIDs) > 0 {
 		query = query.Where("id NOT IN ?", blockedTaskIDs)
 	}
-	if err := query.Order("priority ASC, created_at DESC").Find(&readyTasks).Error; err != nil {
-		return err
+	return query
+}
+
+// laggingTaskIDs returns children of finish-to-start-after dependencies whose
+// closed parent hasn't been closed for the lag yet
+func laggingTaskIDs(database *gorm.DB, now time.Time) []string {
+	var rows []struct {
+		ChildID  string
+		Lag      int
+		ClosedAt *time.Time
 	}
+	database.Model(&models.Dependency{}).
+		Select("dependencies.child_id, dependencies.lag, tasks.closed_at").
+		Joins("JOIN tasks ON tasks.id = dependencies.parent_id").
+		Where("dependencies.type = ? AND tasks.status = ?", models.DepTypeFinishStart, models.StatusClosed).
+		Scan(&rows)
 
-	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{"count": len(readyTasks), "tasks": readyTasks})
-		return nil
+	var ids []string
+	for _, r := range rows {
+		dep := models.Dependency{Type: models.DepTypeFinishStart, Lag: r.Lag}
+		if at, ok := dep.ReadyAt(r.ClosedAt); ok && at.After(now) {
+			ids = append(ids, r.ChildID)
+		}
 	}
+	return ids
+}
 
-	if len(readyTasks) == 0 {
-		fmt.Println("No ready tasks")
-		return nil
+// findSoftBlockers returns the open soft blockers of each of the given tasks
+func findSoftBlockers(database *gorm.DB, tasks []models.Task) (map[string][]string, error) {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	var deps []models.Dependency
+	if err := database.Model(&models.Dependency{}).
+		Select("dependencies.parent_id, dependencies.child_id").
+		Joins("JOIN tasks ON tasks.id = dependencies.parent_id").
+		Where("dependencies.type = ? AND tasks.status != ? AND dependencies.child_id IN ?",
+			models.DepTypeSoftBlocks, models.StatusClosed, ids).
+		Order("dependencies.parent_id ASC").
+		Find(&deps).Error; err != nil {
+		return nil, err
 	}
+	soft := make(map[string][]string)
+	for _, d := range deps {
+		soft[d.ChildID] = append(soft[d.ChildID], d.ParentID)
+	}
+	return soft, nil
+}
 
-	fmt.Printf("Ready tasks (%d):\n", len(readyTasks))
-	for _, t := range readyTasks {
-		fmt.Printf("[%s] P%d %s - %s\n", t.ID, t.Priority, t.Status, t.Title)
+// softBlockAnnotation flags a ready task that open soft blockers would
+// rather see finished first
+func softBlockAnnotation(blockers []string) string {
+	if len(blockers) == 0 {
+		return ""
 	}
-	return nil
+	return " (soft-blocked by " + strings.Join(blockers, ", ") + ")"
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// agentMatch is a ready task scored against an agent's capabilities
+type agentMatch struct {
+	Task         models.Task `json:"task"`
+	Linked       bool        `json:"linked,omitempty"`        // Explicitly linked to this agent
+	Matched      []string    `json:"matched,omitempty"`       // Capability keywords the task needs and the agent has
+	Unmatched    bool        `json:"unmatched,omitempty"`     // Task has requirements but none match
+	OtherPrimary string      `json:"other_primary,omitempty"` // Primary agent, when it is someone else
+}
+
+// rank orders matches: linked first, then by matched keywords, and tasks
+// belonging to another primary agent last
+func (m agentMatch) rank() int {
+	switch {
+	case m.OtherPrimary != "":
+		return -1
+	case m.Linked:
+		return 1000 + len(m.Matched)
+	default:
+		return len(m.Matched)
+	}
+}
+
+// matchTasksToAgent scores tasks against an agent. A task's required
+// capabilities are the names and descriptions of its linked skills plus its
+// labels; these are matched by keyword against the agent's Capabilities.
+// The result keeps the input order among equally ranked tasks.
+func matchTasksToAgent(database *gorm.DB, agent models.Agent, tasks []models.Task) ([]agentMatch, error) {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+
+	requirements := make(map[string][]string)
+	var skillLinks []models.TaskSkillLink
+	if err := database.Preload("Skill").Where("task_id IN ?", ids).Find(&skillLinks).Error; err != nil {
+		return nil, err
+	}
+	for _, sl := range skillLinks {
+		requirements[sl.TaskID] = append(requirements[sl.TaskID], sl.Skill.Name, sl.Skill.Description)
+	}
+
+	var agentLinks []models.TaskAgentLink
+	if err := database.Preload("Agent").Where("task_id IN ?", ids).Find(&agentLinks).Error; err != nil {
+		return nil, err
+	}
+	linked := make(map[string]bool)
+	primary := make(map[string]string)
+	for _, al := range agentLinks {
+		if al.AgentID == agent.ID {
+			linked[al.TaskID] = true
+		} else if al.IsPrimary {
+			primary[al.TaskID] = al.Agent.Name
+		}
+	}
+
+	capabilities := suggestTokens(agent.Capabilities)
+	matches := make([]agentMatch, len(tasks))
+	for i, t := range tasks {
+		m := agentMatch{Task: t, Linked: linked[t.ID]}
+		if !m.Linked {
+			m.OtherPrimary = primary[t.ID]
+		}
+
+		needed := suggestTokens(strings.Join(append(requirements[t.ID], t.Labels...), " "))
+		for tok := range needed {
+			if capabilities[tok] {
+				m.Matched = append(m.Matched, tok)
+			}
+		}
+		sort.Strings(m.Matched)
+		m.Unmatched = len(needed) > 0 && len(m.Matched) == 0
+		matches[i] = m
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank() > matches[j].rank()
+	})
+	return matches, nil
+}
+
+// agentMatchAnnotation describes a match for text output
+func agentMatchAnnotation(m agentMatch) string {
+	switch {
+	case m.OtherPrimary != "":
+		return fmt.Sprintf(" [primary: %s]", m.OtherPrimary)
+	case m.Linked:
+		return " [linked]"
+	case len(m.Matched) > 0:
+		return fmt.Sprintf(" [match: %s]", strings.Join(m.Matched, ", "))
+	case m.Unmatched:
+		return " [no capability match]"
+	}
+	return ""
+}
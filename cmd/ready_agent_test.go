@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestMatchTasksToAgent(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	frontend := models.Agent{Name: "frontend-dev", Capabilities: "React, browser testing"}
+	backend := models.Agent{Name: "backend-dev", Capabilities: "Go, databases"}
+	database.Create(&frontend)
+	database.Create(&backend)
+	skill := models.Skill{Name: "e2e", Description: "Browser end-to-end tests"}
+	database.Create(&skill)
+
+	tasks := []models.Task{
+		{ID: "gur-plain001", Title: "No requirements", Status: models.StatusOpen},
+		{ID: "gur-other001", Title: "Backend work", Status: models.StatusOpen},
+		{ID: "gur-label001", Title: "Labelled", Status: models.StatusOpen, Labels: models.StringSlice{"databases"}},
+		{ID: "gur-skill001", Title: "Needs e2e", Status: models.StatusOpen},
+		{ID: "gur-link0001", Title: "Linked", Status: models.StatusOpen},
+	}
+	for i := range tasks {
+		database.Create(&tasks[i])
+	}
+	database.Create(&models.TaskAgentLink{TaskID: "gur-other001", AgentID: backend.ID, IsPrimary: true})
+	database.Create(&models.TaskAgentLink{TaskID: "gur-link0001", AgentID: frontend.ID})
+	database.Create(&models.TaskSkillLink{TaskID: "gur-skill001", SkillID: skill.ID})
+
+	matches, err := matchTasksToAgent(database, frontend, tasks)
+	if err != nil {
+		t.Fatalf("matchTasksToAgent: %v", err)
+	}
+
+	var order []string
+	byID := make(map[string]agentMatch)
+	for _, m := range matches {
+		order = append(order, m.Task.ID)
+		byID[m.Task.ID] = m
+	}
+	want := []string{"gur-link0001", "gur-skill001", "gur-plain001", "gur-label001", "gur-other001"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+
+	if m := byID["gur-skill001"]; !reflect.DeepEqual(m.Matched, []string{"browser", "test"}) {
+		t.Errorf("skill task matched = %v", m.Matched)
+	}
+	if m := byID["gur-label001"]; !m.Unmatched {
+		t.Error("expected labelled task to be unmatched for frontend agent")
+	}
+	if m := byID["gur-plain001"]; m.Unmatched || len(m.Matched) != 0 {
+		t.Errorf("task without requirements should be neutral, got %+v", m)
+	}
+	if m := byID["gur-other001"]; m.OtherPrimary != "backend-dev" {
+		t.Errorf("other primary = %q, want backend-dev", m.OtherPrimary)
+	}
+
+	// The primary agent itself sees its task as linked
+	matches, err = matchTasksToAgent(database, backend, tasks)
+	if err != nil {
+		t.Fatalf("matchTasksToAgent: %v", err)
+	}
+	if matches[0].Task.ID != "gur-other001" || !matches[0].Linked {
+		t.Errorf("expected backend's linked task first, got %+v", matches[0])
+	}
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// Ready groupings (--group)
+const (
+	readyGroupEpic     = "epic"
+	readyGroupLabel    = "label"
+	readyGroupAssignee = "assignee"
+)
+
+// readyGroupings lists the valid --group values
+var readyGroupings = []string{readyGroupEpic, readyGroupLabel, readyGroupAssignee}
+
+// Ready output formats (--format)
+const (
+	readyFormatList = "list"
+	readyFormatTree = "tree"
+)
+
+// readyGroup is the ready tasks sharing an epic, label or assignee. Key is
+// empty for the tasks with none.
+type readyGroup struct {
+	Key     string        `json:"key"`
+	Title   string        `json:"title,omitempty"` // The epic's title
+	TaskIDs []string      `json:"task_ids"`
+	tasks   []models.Task // In ready order
+}
+
+// readyTreeNode is a task in the epic tree: a ready task, or an epic or
+// parent task above one
+type readyTreeNode struct {
+	ID       string           `json:"id"`
+	Title    string           `json:"title"`
+	Type     string           `json:"type,omitempty"`
+	Ready    bool             `json:"ready"`
+	Children []*readyTreeNode `json:"children,omitempty"`
+	task     models.Task
+}
+
+// taskAncestry loads the ancestors of tasks and returns every task seen by
+// ID and each one's parent: its ParentID, or else the parent of a
+// parent-child dependency
+func taskAncestry(database *gorm.DB, tasks []models.Task) (map[string]models.Task, map[string]string, error) {
+	known := make(map[string]models.Task, len(tasks))
+	parents := make(map[string]string)
+	pending := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		known[t.ID] = t
+		pending = append(pending, t.ID)
+	}
+
+	for len(pending) > 0 {
+		var deps []models.Dependency
+		if err := database.Where("type = ? AND child_id IN ?", models.DepTypeParentChild, pending).
+			Order("id ASC").Find(&deps).Error; err != nil {
+			return nil, nil, err
+		}
+		depParent := make(map[string]string)
+		for _, d := range deps {
+			if depParent[d.ChildID] == "" {
+				depParent[d.ChildID] = d.ParentID
+			}
+		}
+
+		var missing []string
+		for _, id := range pending {
+			parent := known[id].ParentID
+			if parent == "" {
+				parent = depParent[id]
+			}
+			if parent == "" {
+				continue
+			}
+			parents[id] = parent
+			if _, ok := known[parent]; !ok {
+				missing = append(missing, parent)
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+		var found []models.Task
+		if err := database.Where("id IN ?", missing).Find(&found).Error; err != nil {
+			return nil, nil, err
+		}
+		pending = pending[:0]
+		for _, t := range found {
+			known[t.ID] = t
+			pending = append(pending, t.ID)
+		}
+	}
+	return known, parents, nil
+}
+
+// ancestorChain returns id followed by its ancestors up to and including
+// the nearest epic, or up to the topmost ancestor if there is no epic, and
+// whether an epic ended it
+func ancestorChain(id string, known map[string]models.Task, parents map[string]string) ([]string, bool) {
+	chain := []string{id}
+	seen := map[string]bool{id: true}
+	for cur := id; ; {
+		if known[cur].Type == models.TypeEpic {
+			return chain, true
+		}
+		next, ok := parents[cur]
+		if !ok || seen[next] {
+			return chain, false
+		}
+		if _, loaded := known[next]; !loaded {
+			return chain, false
+		}
+		seen[next] = true
+		chain = append(chain, next)
+		cur = next
+	}
+}
+
+// groupReadyTasks groups ready tasks by epic, label or assignee. Groups
+// come in the order of their first task, with the tasks that have none
+// last; a task with several labels is listed under each.
+func groupReadyTasks(database *gorm.DB, tasks []models.Task, by string) ([]readyGroup, error) {
+	var known map[string]models.Task
+	var parents map[string]string
+	if by == readyGroupEpic {
+		var err error
+		if known, parents, err = taskAncestry(database, tasks); err != nil {
+			return nil, err
+		}
+	}
+
+	var groups []readyGroup
+	index := make(map[string]int)
+	var none *readyGroup
+	add := func(key, title string, t models.Task) {
+		if key == "" {
+			if none == nil {
+				none = &readyGroup{}
+			}
+			none.tasks = append(none.tasks, t)
+			none.TaskIDs = append(none.TaskIDs, t.ID)
+			return
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, readyGroup{Key: key, Title: title})
+		}
+		groups[i].tasks = append(groups[i].tasks, t)
+		groups[i].TaskIDs = append(groups[i].TaskIDs, t.ID)
+	}
+
+	for _, t := range tasks {
+		switch by {
+		case readyGroupEpic:
+			chain, isEpic := ancestorChain(t.ID, known, parents)
+			if !isEpic {
+				add("", "", t)
+				continue
+			}
+			epic := known[chain[len(chain)-1]]
+			add(epic.ID, epic.Title, t)
+		case readyGroupLabel:
+			if len(t.Labels) == 0 {
+				add("", "", t)
+			}
+			for _, l := range t.Labels {
+				add(l, "", t)
+			}
+		case readyGroupAssignee:
+			add(t.Assignee, "", t)
+		}
+	}
+	if none != nil {
+		groups = append(groups, *none)
+	}
+	return groups, nil
+}
+
+// buildReadyTree arranges ready tasks under their epics, with the parent
+// tasks between them. Tasks without an epic go under a root with no ID.
+// Roots and children come in the order of their first ready task.
+func buildReadyTree(database *gorm.DB, tasks []models.Task) ([]*readyTreeNode, error) {
+	known, parents, err := taskAncestry(database, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*readyTreeNode)
+	node := func(id string) (*readyTreeNode, bool) {
+		if n, ok := nodes[id]; ok {
+			return n, false
+		}
+		t := known[id]
+		n := &readyTreeNode{ID: t.ID, Title: t.Title, Type: t.Type, task: t}
+		nodes[id] = n
+		return n, true
+	}
+	var roots []*readyTreeNode
+	var noEpic *readyTreeNode
+
+	for _, t := range tasks {
+		chain, isEpic := ancestorChain(t.ID, known, parents)
+		leaf, _ := node(t.ID)
+		leaf.Ready = true
+		// Link each task under its parent, stopping at a node already linked
+		child := leaf
+		linked := false
+		for _, id := range chain[1:] {
+			parent, created := node(id)
+			if !created && containsNode(parent.Children, child) {
+				linked = true
+				break
+			}
+			parent.Children = append(parent.Children, child)
+			if !created {
+				linked = true
+				break
+			}
+			child = parent
+		}
+		if linked {
+			continue
+		}
+		top := nodes[chain[len(chain)-1]]
+		if isEpic {
+			if !containsNode(roots, top) {
+				roots = append(roots, top)
+			}
+			continue
+		}
+		if noEpic == nil {
+			noEpic = &readyTreeNode{Title: "No epic"}
+		}
+		if !containsNode(noEpic.Children, top) {
+			noEpic.Children = append(noEpic.Children, top)
+		}
+	}
+	if noEpic != nil {
+		roots = append(roots, noEpic)
+	}
+	return roots, nil
+}
+
+func containsNode(nodes []*readyTreeNode, n *readyTreeNode) bool {
+	for _, m := range nodes {
+		if m == n {
+			return true
+		}
+	}
+	return false
+}
+
+// validateReadyGrouping checks --group and --format
+func validateReadyGrouping(group, format string) error {
+	if group != "" {
+		valid := false
+		for _, g := range readyGroupings {
+			valid = valid || group == g
+		}
+		if !valid {
+			return fmt.Errorf("invalid --group '%s': must be one of %s", group, strings.Join(readyGroupings, ", "))
+		}
+	}
+	switch format {
+	case "", readyFormatList:
+	case readyFormatTree:
+		if group != "" && group != readyGroupEpic {
+			return fmt.Errorf("--format tree is rooted at epics and can't be grouped by %s", group)
+		}
+	default:
+		return fmt.Errorf("invalid --format '%s': must be %s or %s", format, readyFormatList, readyFormatTree)
+	}
+	return nil
+}
+
+// printReadyTree prints the epic tree, ready tasks with their usual line
+// and the epics and parents above them by ID and title
+func printReadyTree(nodes []*readyTreeNode, depth int, line func(models.Task) string) {
+	indent := strings.Repeat("  ", depth)
+	for _, n := range nodes {
+		switch {
+		case n.ID == "":
+			fmt.Printf("%s%s:\n", indent, n.Title)
+		case n.Ready:
+			fmt.Printf("%s%s\n", indent, line(n.task))
+		default:
+			kind := ""
+			if n.Type == models.TypeEpic {
+				kind = " (epic)"
+			}
+			fmt.Printf("%s%s %s%s\n", indent, n.ID, n.Title, kind)
+		}
+		printReadyTree(n.Children, depth+1, line)
+	}
+}
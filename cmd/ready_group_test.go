@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestReadyGroupsAndTree(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	// Epic gur-e0000001 > parent gur-e0000001.1 > gur-e0000001.1.1 (ready);
+	// gur-a0000002 is in the epic through a parent-child dependency;
+	// gur-a0000003 has no epic
+	for _, task := range []models.Task{
+		{ID: "gur-e0000001", Title: "Checkout", Type: models.TypeEpic, Status: models.StatusOpen},
+		{ID: "gur-e0000001.1", Title: "Payments", Type: models.TypeFeature, Status: models.StatusBlocked, ParentID: "gur-e0000001"},
+	} {
+		database.Create(&task)
+	}
+	ready := []models.Task{
+		{ID: "gur-a0000003", Title: "Docs", Type: models.TypeTask, Status: models.StatusOpen, Labels: models.StringSlice{"docs"}},
+		{ID: "gur-e0000001.1.1", Title: "Card form", Type: models.TypeTask, Status: models.StatusOpen, ParentID: "gur-e0000001.1", Assignee: "alice", Labels: models.StringSlice{"ui", "docs"}},
+		{ID: "gur-a0000002", Title: "Cart", Type: models.TypeTask, Status: models.StatusOpen, Assignee: "alice"},
+	}
+	for _, task := range ready {
+		database.Create(&task)
+	}
+	database.Create(&models.Dependency{ParentID: "gur-e0000001", ChildID: "gur-a0000002", Type: models.DepTypeParentChild})
+
+	keys := func(groups []readyGroup) map[string][]string {
+		m := make(map[string][]string)
+		for _, g := range groups {
+			m[g.Key] = g.TaskIDs
+		}
+		return m
+	}
+	byEpic, err := groupReadyTasks(database, ready, readyGroupEpic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{"gur-e0000001": {"gur-e0000001.1.1", "gur-a0000002"}, "": {"gur-a0000003"}}
+	if got := keys(byEpic); !reflect.DeepEqual(got, want) || byEpic[0].Title != "Checkout" || byEpic[len(byEpic)-1].Key != "" {
+		t.Errorf("by epic = %+v, want %v with the no-epic group last", byEpic, want)
+	}
+	byLabel, _ := groupReadyTasks(database, ready, readyGroupLabel)
+	want = map[string][]string{"docs": {"gur-a0000003", "gur-e0000001.1.1"}, "ui": {"gur-e0000001.1.1"}, "": {"gur-a0000002"}}
+	if got := keys(byLabel); !reflect.DeepEqual(got, want) {
+		t.Errorf("by label = %v, want %v", got, want)
+	}
+	byAssignee, _ := groupReadyTasks(database, ready, readyGroupAssignee)
+	if got := keys(byAssignee); len(got["alice"]) != 2 || len(got[""]) != 1 {
+		t.Errorf("by assignee = %v", got)
+	}
+
+	tree, err := buildReadyTree(database, ready)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree) != 2 || tree[0].ID != "gur-e0000001" || tree[0].Ready || tree[1].ID != "" {
+		t.Fatalf("tree roots = %+v, want the epic, then the tasks without one", tree)
+	}
+	epic := tree[0]
+	if len(epic.Children) != 2 || epic.Children[0].ID != "gur-e0000001.1" || epic.Children[1].ID != "gur-a0000002" {
+		t.Fatalf("epic children = %+v, want the parent task, then the cart", epic.Children)
+	}
+	if leaf := epic.Children[0].Children; len(leaf) != 1 || leaf[0].ID != "gur-e0000001.1.1" || !leaf[0].Ready {
+		t.Errorf("parent's children = %+v, want the ready card form", leaf)
+	}
+	if noEpic := tree[1].Children; len(noEpic) != 1 || noEpic[0].ID != "gur-a0000003" {
+		t.Errorf("no-epic children = %+v, want the docs task", noEpic)
+	}
+
+	if err := validateReadyGrouping(readyGroupLabel, readyFormatTree); err == nil {
+		t.Error("--format tree --group label was accepted")
+	}
+	if err := validateReadyGrouping("team", ""); err == nil {
+		t.Error("an unknown --group was accepted")
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var (
+	reassignFrom   string
+	reassignTo     string
+	reassignStatus string
+	reassignDryRun bool
+)
+
+var reassignCmd = &cobra.Command{
+	Use:   "reassign",
+	Short: "Move every matching task from one assignee to another",
+	Long: `Change the assignee of every task assigned to --from, in one transaction.
+
+By default closed and archived tasks keep their assignee; use --status to
+pick the tasks to move instead (e.g., --status closed). An empty --to
+unassigns the tasks. Each change is recorded in the task's history, so
+'gur undo' reverts the reassignment.
+
+Examples:
+  gur reassign --from alice --to bob
+  gur reassign --from alice --to bob --status open
+  gur reassign --from agent-1 --to "" --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runReassign,
+}
+
+func init() {
+	rootCmd.AddCommand(reassignCmd)
+	reassignCmd.Flags().StringVar(&reassignFrom, "from", "", "Current assignee (required)")
+	reassignCmd.Flags().StringVar(&reassignTo, "to", "", "New assignee; empty to unassign (required)")
+	reassignCmd.Flags().StringVarP(&reassignStatus, "status", "s", "", "Only tasks with this status (default: all but closed and archived)")
+	reassignCmd.Flags().BoolVar(&reassignDryRun, "dry-run", false, "Show the tasks that would move without changing them")
+	reassignCmd.MarkFlagRequired("from")
+	reassignCmd.MarkFlagRequired("to")
+}
+
+// tasksAssignedTo returns the tasks assigned to assignee with status, or
+// all open work if status is empty
+func tasksAssignedTo(database *gorm.DB, assignee, status string) ([]models.Task, error) {
+	query := database.Where("assignee = ?", assignee)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	} else {
+		query = query.Where("status NOT IN ?", []string{models.StatusClosed, models.StatusArchived})
+	}
+	var tasks []models.Task
+	err := query.Order("priority ASC, id ASC").Find(&tasks).Error
+	return tasks, err
+}
+
+// reassignTasks sets the assignee of tasks, recording each change
+func reassignTasks(tx *gorm.DB, tasks []models.Task, to string) error {
+	for i := range tasks {
+		task := &tasks[i]
+		if err := models.RecordChange(tx, task.ID, "assignee", task.Assignee, to, "user"); err != nil {
+			return err
+		}
+		task.Assignee = to
+		if err := tx.Save(task).Error; err != nil {
+			return fmt.Errorf("failed to update task '%s': database error: %w", task.ID, err)
+		}
+	}
+	return nil
+}
+
+func runReassign(cmd *cobra.Command, args []string) error {
+	if reassignFrom == reassignTo {
+		return fmt.Errorf("--from and --to are both '%s': nothing to reassign", reassignFrom)
+	}
+	switch reassignStatus {
+	case "", models.StatusOpen, models.StatusInProgress, models.StatusBlocked, models.StatusClosed, models.StatusArchived:
+	default:
+		return fmt.Errorf("invalid status '%s': must be one of: open, in_progress, blocked, closed, archived", reassignStatus)
+	}
+
+	database := db.GetDB()
+	if err := checkAssignee(database, reassignTo); err != nil {
+		return err
+	}
+	tasks, err := tasksAssignedTo(database, reassignFrom, reassignStatus)
+	if err != nil {
+		return fmt.Errorf("failed to find tasks: database error: %w", err)
+	}
+
+	if !reassignDryRun && len(tasks) > 0 {
+		if err := database.Transaction(func(tx *gorm.DB) error {
+			return reassignTasks(tx, tasks, reassignTo)
+		}); err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			noteAffected(t.ID)
+		}
+	}
+
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "dry_run": reassignDryRun, "from": reassignFrom, "to": reassignTo, "tasks": ids, "count": len(ids)})
+		return nil
+	}
+
+	if len(tasks) == 0 {
+		fmt.Printf("No matching tasks assigned to '%s'\n", reassignFrom)
+		return nil
+	}
+	to := reassignTo
+	if to == "" {
+		to = "(unassigned)"
+	}
+	verb := "Reassigned"
+	if reassignDryRun {
+		verb = "Would reassign"
+	}
+	fmt.Printf("%s %d task(s) from %s to %s:\n", verb, len(tasks), reassignFrom, to)
+	for _, t := range tasks {
+		fmt.Printf("  %s  [%s] %s\n", t.ID, t.Status, t.Title)
+	}
+	return nil
+}
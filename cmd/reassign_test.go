@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestReassignTasks(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-reasgn01", Title: "Open", Status: models.StatusOpen, Assignee: "alice"})
+	database.Create(&models.Task{ID: "gur-reasgn02", Title: "Working", Status: models.StatusInProgress, Assignee: "alice"})
+	database.Create(&models.Task{ID: "gur-reasgn03", Title: "Done", Status: models.StatusClosed, Assignee: "alice"})
+	database.Create(&models.Task{ID: "gur-reasgn04", Title: "Other", Status: models.StatusOpen, Assignee: "carol"})
+
+	tasks, err := tasksAssignedTo(database, "alice", "")
+	if err != nil {
+		t.Fatalf("tasksAssignedTo() error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("tasksAssignedTo() returned %d tasks, want 2 (closed excluded)", len(tasks))
+	}
+	if only, _ := tasksAssignedTo(database, "alice", models.StatusInProgress); len(only) != 1 || only[0].ID != "gur-reasgn02" {
+		t.Errorf("tasksAssignedTo(in_progress) = %v, want gur-reasgn02", only)
+	}
+
+	if err := reassignTasks(database, tasks, "bob"); err != nil {
+		t.Fatalf("reassignTasks() error: %v", err)
+	}
+	want := map[string]string{"gur-reasgn01": "bob", "gur-reasgn02": "bob", "gur-reasgn03": "alice", "gur-reasgn04": "carol"}
+	for id, assignee := range want {
+		if task, _ := db.GetTaskByID(id); task.Assignee != assignee {
+			t.Errorf("%s assignee = %q, want %q", id, task.Assignee, assignee)
+		}
+	}
+
+	var h models.TaskHistory
+	if err := database.Where("task_id = ? AND field = ?", "gur-reasgn01", "assignee").First(&h).Error; err != nil {
+		t.Fatalf("reassignment not recorded in history: %v", err)
+	}
+	if h.OldValue != "alice" || h.NewValue != "bob" {
+		t.Errorf("history = %q -> %q, want alice -> bob", h.OldValue, h.NewValue)
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// taskReference is a reference from a task's text and where it points
+type taskReference struct {
+	models.Reference
+	Link string `json:"link,omitempty"` // "" for #123 when no GitHub repo is configured
+}
+
+// githubWebURL returns the web address of the configured GitHub server:
+// https://github.com, or the Enterprise host behind its API URL
+func githubWebURL() string {
+	base, _ := db.GetConfig(models.ConfigGitHubBaseURL)
+	if base == "" {
+		return "https://github.com"
+	}
+	return strings.TrimSuffix(strings.TrimRight(base, "/"), "/api/v3")
+}
+
+// loadTaskReferences returns the references indexed for a task, resolved
+// against the configured GitHub repository
+func loadTaskReferences(database *gorm.DB, taskID string) ([]taskReference, error) {
+	var refs []models.Reference
+	if err := database.Where("task_id = ?", taskID).Order("id ASC").Find(&refs).Error; err != nil {
+		return nil, err
+	}
+	webURL := githubWebURL()
+	repo, _ := db.GetConfig(models.ConfigGitHubRepo)
+	resolved := make([]taskReference, 0, len(refs))
+	for _, r := range refs {
+		resolved = append(resolved, taskReference{Reference: r, Link: r.Link(webURL, repo)})
+	}
+	return resolved, nil
+}
+
+// mentionedTaskIDRegex matches task IDs written in free text
+var mentionedTaskIDRegex = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9]*-[a-f0-9]{8}(?:\.\d+)*\b`)
+
+// linkedTaskIDRegex matches a task ID followed by the issue reference
+// linkTaskMentions added to it
+var linkedTaskIDRegex = regexp.MustCompile(`\b([A-Za-z][A-Za-z0-9]*-[a-f0-9]{8}(?:\.\d+)*) \((?:[\w.-]+/[\w.-]+)?#\d+\)`)
+
+// linkTaskMentions follows each task ID in text that is synced to GitHub
+// with its issue, e.g. "gur-abc12345 (#12)", so GitHub links it. Issues in
+// another repository than repo are written owner/repo#12.
+func linkTaskMentions(database *gorm.DB, text, repo string) string {
+	ids := mentionedTaskIDRegex.FindAllString(text, -1)
+	if len(ids) == 0 {
+		return text
+	}
+	var links []models.GitHubIssueLink
+	if database.Where("task_id IN ?", ids).Find(&links).Error != nil || len(links) == 0 {
+		return text
+	}
+	issues := make(map[string]string, len(links))
+	for _, l := range links {
+		ref := fmt.Sprintf("#%d", l.IssueNumber)
+		if !strings.EqualFold(l.Repository, repo) {
+			ref = l.Repository + ref
+		}
+		issues[l.TaskID] = " (" + ref + ")"
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range mentionedTaskIDRegex.FindAllStringIndex(text, -1) {
+		ref, ok := issues[text[loc[0]:loc[1]]]
+		if !ok || strings.HasPrefix(text[loc[1]:], ref) {
+			continue
+		}
+		sb.WriteString(text[last:loc[1]])
+		sb.WriteString(ref)
+		last = loc[1]
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// unlinkTaskMentions removes the issue references linkTaskMentions added,
+// so text read back from GitHub matches the task's own
+func unlinkTaskMentions(text string) string {
+	return linkedTaskIDRegex.ReplaceAllString(text, "$1")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestTaskReferencesIndexed(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := models.Task{ID: "gur-f1000000", Title: "Task", Status: models.StatusOpen, Description: "Follows up #12"}
+	database.Create(&task)
+
+	refs, err := loadTaskReferences(database, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 1 || refs[0].String() != "#12" || refs[0].Link != "" {
+		t.Fatalf("references after create = %+v", refs)
+	}
+
+	db.SetConfig(models.ConfigGitHubRepo, "acme/web")
+	task.AppendNotes("Upstream: acme/lib#3, https://example.com/bug")
+	task.Description = "Done"
+	database.Save(&task)
+	refs, _ = loadTaskReferences(database, task.ID)
+	var got []string
+	for _, r := range refs {
+		got = append(got, r.String()+" "+r.Source+" "+r.Link)
+	}
+	want := []string{
+		"acme/lib#3 notes https://github.com/acme/lib/issues/3",
+		"https://example.com/bug notes https://example.com/bug",
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("references after update = %q, want %q", got, want)
+	}
+
+	// Compaction clears the text but keeps what it referenced
+	task.Compact()
+	database.Save(&task)
+	if refs, _ = loadTaskReferences(database, task.ID); len(refs) != 2 {
+		t.Errorf("references after compact = %+v, want 2 kept", refs)
+	}
+}
+
+func TestLinkTaskMentions(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-a1000000", IssueNumber: 7, Repository: "acme/web"})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-b1000000", IssueNumber: 9, Repository: "acme/api"})
+
+	text := "Needs gur-a1000000 and gur-b1000000; unrelated to gur-c1000000. Already gur-a1000000 (#7)."
+	linked := linkTaskMentions(database, text, "acme/web")
+	want := "Needs gur-a1000000 (#7) and gur-b1000000 (acme/api#9); unrelated to gur-c1000000. Already gur-a1000000 (#7)."
+	if linked != want {
+		t.Errorf("linkTaskMentions() = %q, want %q", linked, want)
+	}
+	if back := unlinkTaskMentions(linkTaskMentions(database, "Needs gur-a1000000.", "acme/web")); back != "Needs gur-a1000000." {
+		t.Errorf("unlinkTaskMentions() = %q", back)
+	}
+}
@@ -0,0 +1,513 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Track releases and the tasks targeted at them",
+	Long: `Track releases: target tasks at a release, follow the remaining work and
+unverified gates, then cut it to tag the shipped tasks and generate the
+changelog.
+
+Target tasks with 'gur create --release <name>' or 'gur update <id>
+--release <name>', and list them with 'gur list --release <name>'.
+
+Examples:
+  gur release create v1.3.0 --target 2025-08-01
+  gur update gur-abc123 --release v1.3.0
+  gur release status v1.3.0
+  gur release cut v1.3.0 -o CHANGELOG.md`,
+}
+
+var releaseCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a release",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runReleaseCreate,
+}
+
+var releaseListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List releases with their progress",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runReleaseList,
+}
+
+var releaseStatusCmd = &cobra.Command{
+	Use:         "status <name>",
+	Short:       "Show a release's remaining work and unverified gates",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runReleaseStatus,
+}
+
+var releaseCutCmd = &cobra.Command{
+	Use:   "cut <name>",
+	Short: "Close a release, tag its tasks and generate the changelog",
+	Long: `Close a release: its closed tasks are labeled release:<name>, the release
+is marked released, and a Markdown changelog of the completed tasks is
+printed (or prepended to --output).
+
+A release with unfinished tasks can't be cut; use --force to cut it anyway,
+which untargets the unfinished tasks so they can be moved to another
+release.
+
+Examples:
+  gur release cut v1.3.0
+  gur release cut v1.3.0 -o CHANGELOG.md
+  gur release cut v1.3.0 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReleaseCut,
+}
+
+var (
+	releaseTarget      string
+	releaseDescription string
+	releaseCutForce    bool
+	releaseCutOutput   string
+)
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+	releaseCmd.AddCommand(releaseCreateCmd)
+	releaseCmd.AddCommand(releaseListCmd)
+	releaseCmd.AddCommand(releaseStatusCmd)
+	releaseCmd.AddCommand(releaseCutCmd)
+
+	releaseCreateCmd.Flags().StringVar(&releaseTarget, "target", "", "Target date (e.g., 2025-08-01) or duration from now (e.g., 2w)")
+	releaseCreateCmd.Flags().StringVarP(&releaseDescription, "description", "d", "", "Release description")
+	releaseCutCmd.Flags().BoolVar(&releaseCutForce, "force", false, "Cut even with unfinished tasks (they are untargeted)")
+	releaseCutCmd.Flags().StringVarP(&releaseCutOutput, "output", "o", "", "Prepend the changelog to this file (e.g., CHANGELOG.md)")
+}
+
+// noRelease untargets a task with 'gur update --release none'
+const noRelease = "none"
+
+// findRelease loads a release by name
+func findRelease(database *gorm.DB, name string) (*models.Release, error) {
+	var release models.Release
+	if err := database.Where("name = ?", name).First(&release).Error; err != nil {
+		return nil, codedErrorf(ErrCodeNotFound, "release '%s' not found (use 'gur release list' to see releases)", name)
+	}
+	return &release, nil
+}
+
+// targetRelease checks that tasks can be targeted at the named release;
+// an empty name untargets
+func targetRelease(database *gorm.DB, name string) error {
+	if name == "" {
+		return nil
+	}
+	release, err := findRelease(database, name)
+	if err != nil {
+		return err
+	}
+	if release.IsReleased() {
+		return fmt.Errorf("release '%s' was already cut on %s (create a new release to target)", name, release.ReleasedAt.Format(models.DateFormat))
+	}
+	return nil
+}
+
+// setTaskRelease targets a task at a release, recording history for undo
+func setTaskRelease(tx *gorm.DB, task *models.Task, release, by string) error {
+	if task.Release == release {
+		return nil
+	}
+	if err := models.RecordChange(tx, task.ID, "release", task.Release, release, by); err != nil {
+		return err
+	}
+	task.Release = release
+	noteAffected(task.ID)
+	return tx.Model(task).Update("release", release).Error
+}
+
+// releaseProgress summarizes the tasks targeted at a release
+type releaseProgress struct {
+	Release    models.Release `json:"release"`
+	Total      int            `json:"total"`
+	Done       int            `json:"done"`
+	Remaining  []models.Task  `json:"remaining"`
+	Unverified []pendingGate  `json:"unverified_gates"`
+}
+
+// collectReleaseProgress loads a release's tasks, the ones not yet finished,
+// and the gates on its tasks that are neither passed nor waived
+func collectReleaseProgress(database *gorm.DB, release *models.Release, now time.Time) (*releaseProgress, error) {
+	var tasks []models.Task
+	if err := database.Where("release = ?", release.Name).Order("priority ASC, rank = 0, rank ASC, id ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	p := &releaseProgress{Release: *release, Total: len(tasks), Remaining: []models.Task{}, Unverified: []pendingGate{}}
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+		if t.Status == models.StatusClosed || t.Status == models.StatusArchived {
+			p.Done++
+		} else {
+			p.Remaining = append(p.Remaining, t)
+		}
+	}
+	if len(ids) == 0 {
+		return p, nil
+	}
+
+	var links []struct {
+		models.GateTaskLink
+		TaskTitle string
+		GateTitle string
+		Category  string
+	}
+	if err := database.Model(&models.GateTaskLink{}).
+		Select("gate_task_links.*, tasks.title AS task_title, gates.title AS gate_title, gates.category").
+		Joins("JOIN gates ON gates.id = gate_task_links.gate_id AND gates.deleted_at IS NULL").
+		Joins("JOIN tasks ON tasks.id = gate_task_links.task_id").
+		Where("gate_task_links.task_id IN ?", ids).
+		Order("tasks.priority ASC, gate_task_links.task_id ASC, gates.priority ASC").
+		Scan(&links).Error; err != nil {
+		return nil, err
+	}
+	for _, l := range links {
+		if l.GateTaskLink.Satisfied(now) {
+			continue
+		}
+		status := l.Status
+		if l.WaiverExpired(now) {
+			status = "waiver expired"
+		}
+		p.Unverified = append(p.Unverified, pendingGate{
+			TaskID: l.TaskID, TaskTitle: l.TaskTitle, GateID: l.GateID, GateTitle: l.GateTitle,
+			Category: l.Category, Status: status,
+		})
+	}
+	return p, nil
+}
+
+// changelogSections groups changelog entries by task type, in output order
+var changelogSections = []struct {
+	Heading string
+	Types   []string
+}{
+	{"Features", []string{models.TypeFeature, models.TypeEpic}},
+	{"Bug fixes", []string{models.TypeBug}},
+	{"Other changes", nil}, // Everything else
+}
+
+// renderChangelog writes the Markdown changelog section for a release from
+// its completed tasks; tasks closed as wontfix, duplicate, etc. are left out
+func renderChangelog(release *models.Release, tasks []models.Task, issues map[string]int, date time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## %s - %s\n", release.Name, date.Format(models.DateFormat)))
+	if release.Description != "" {
+		sb.WriteString("\n" + release.Description + "\n")
+	}
+
+	placed := make(map[string]bool)
+	empty := true
+	for _, section := range changelogSections {
+		var entries []string
+		for _, t := range tasks {
+			if placed[t.ID] || (t.Resolution != "" && t.Resolution != models.ResolutionCompleted) {
+				continue
+			}
+			if section.Types != nil && !containsString(section.Types, t.Type) {
+				continue
+			}
+			placed[t.ID] = true
+			ref := t.ID
+			if n, ok := issues[t.ID]; ok {
+				ref = fmt.Sprintf("#%d", n)
+			}
+			entries = append(entries, fmt.Sprintf("- %s (%s)\n", t.Title, ref))
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		empty = false
+		sb.WriteString("\n### " + section.Heading + "\n\n")
+		sb.WriteString(strings.Join(entries, ""))
+	}
+	if empty {
+		sb.WriteString("\nNo changes.\n")
+	}
+	return sb.String()
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// prependChangelog inserts section at the top of the changelog file, below
+// its "# " title if it has one
+func prependChangelog(path, section string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	content := string(existing)
+	var out string
+	switch {
+	case content == "":
+		out = "# Changelog\n\n" + section
+	case strings.HasPrefix(content, "# "):
+		title, rest, _ := strings.Cut(content, "\n")
+		out = title + "\n\n" + section + "\n" + strings.TrimLeft(rest, "\n")
+	default:
+		out = section + "\n" + content
+	}
+	return os.WriteFile(path, []byte(out), 0644)
+}
+
+// cutRelease marks the release released, untargets unfinished tasks and
+// labels the finished ones. It returns the finished tasks.
+func cutRelease(tx *gorm.DB, release *models.Release, now time.Time, by string) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := tx.Where("release = ?", release.Name).Order("type ASC, priority ASC, id ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	label := models.ReleaseLabelPrefix + release.Name
+	var shipped []models.Task
+	for i := range tasks {
+		task := &tasks[i]
+		if task.Status != models.StatusClosed && task.Status != models.StatusArchived {
+			if err := setTaskRelease(tx, task, "", by); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !task.HasLabel(label) {
+			task.AddLabel(label)
+			if err := tx.Model(task).Update("labels", task.Labels).Error; err != nil {
+				return nil, err
+			}
+			if err := models.RecordChange(tx, task.ID, "label_added", "", label, by); err != nil {
+				return nil, err
+			}
+			noteAffected(task.ID)
+		}
+		shipped = append(shipped, *task)
+	}
+
+	release.Status = models.ReleaseStatusReleased
+	release.ReleasedAt = &now
+	if err := tx.Save(release).Error; err != nil {
+		return nil, err
+	}
+	return shipped, nil
+}
+
+func runReleaseCreate(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid release name '%s': use a version like v1.3.0", args[0])
+	}
+	database := db.GetDB()
+	if _, err := findRelease(database, name); err == nil {
+		return fmt.Errorf("cannot create release: release '%s' already exists (use 'gur release status %s' to view it)", name, name)
+	}
+
+	release := &models.Release{Name: name, Description: releaseDescription, Status: models.ReleaseStatusOpen}
+	if releaseTarget != "" {
+		target, err := parseDueDate(releaseTarget, time.Now())
+		if err != nil {
+			return err
+		}
+		release.TargetAt = &target
+	}
+	if err := database.Create(release).Error; err != nil {
+		return fmt.Errorf("failed to create release '%s': database error: %w", name, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "release": release})
+		return nil
+	}
+	fmt.Printf("Created release %s", name)
+	if release.TargetAt != nil {
+		fmt.Printf(" (target %s)", release.TargetAt.Format(models.DateFormat))
+	}
+	fmt.Printf("\nTarget tasks with: gur update <id> --release %s\n", name)
+	return nil
+}
+
+func runReleaseList(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	var releases []models.Release
+	if err := database.Order("status ASC, target_at IS NULL, target_at ASC, name ASC").Find(&releases).Error; err != nil {
+		return fmt.Errorf("failed to list releases: database error: %w", err)
+	}
+
+	now := time.Now()
+	progress := make([]*releaseProgress, len(releases))
+	for i := range releases {
+		p, err := collectReleaseProgress(database, &releases[i], now)
+		if err != nil {
+			return fmt.Errorf("failed to load release '%s': database error: %w", releases[i].Name, err)
+		}
+		progress[i] = p
+	}
+
+	if IsJSONOutput() {
+		rows := make([]map[string]interface{}, len(progress))
+		for i, p := range progress {
+			rows[i] = map[string]interface{}{"release": p.Release, "total": p.Total, "done": p.Done, "remaining": len(p.Remaining)}
+		}
+		OutputJSON(map[string]interface{}{"count": len(rows), "releases": rows})
+		return nil
+	}
+	if len(releases) == 0 {
+		fmt.Println("No releases (create one with 'gur release create <name> --target <date>')")
+		return nil
+	}
+	for _, p := range progress {
+		r := p.Release
+		when := ""
+		switch {
+		case r.IsReleased():
+			when = "released " + r.ReleasedAt.Format(models.DateFormat)
+		case r.TargetAt != nil:
+			when = "target " + r.TargetAt.Format(models.DateFormat)
+		}
+		fmt.Printf("%-16s %-9s %d/%d done  %s\n", r.Name, r.Status, p.Done, p.Total, when)
+	}
+	return nil
+}
+
+func runReleaseStatus(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	release, err := findRelease(database, args[0])
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	p, err := collectReleaseProgress(database, release, now)
+	if err != nil {
+		return fmt.Errorf("failed to load release '%s': database error: %w", release.Name, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(p)
+		return nil
+	}
+
+	fmt.Printf("Release %s (%s)\n", release.Name, release.Status)
+	if release.Description != "" {
+		fmt.Println(release.Description)
+	}
+	switch {
+	case release.IsReleased():
+		fmt.Printf("Released: %s\n", release.ReleasedAt.Format(models.DateFormat))
+	case release.TargetAt != nil:
+		days := int(release.TargetAt.Sub(now).Hours() / 24)
+		if release.TargetAt.Before(now) {
+			fmt.Printf("Target:   %s (%d day(s) overdue)\n", release.TargetAt.Format(models.DateFormat), -days)
+		} else {
+			fmt.Printf("Target:   %s (%d day(s) left)\n", release.TargetAt.Format(models.DateFormat), days)
+		}
+	}
+	if p.Total == 0 {
+		fmt.Printf("\nNo tasks targeted (use 'gur update <id> --release %s')\n", release.Name)
+		return nil
+	}
+	fmt.Printf("Progress: %d/%d tasks done (%.0f%%)\n", p.Done, p.Total, float64(p.Done)*100/float64(p.Total))
+
+	if len(p.Remaining) > 0 {
+		fmt.Printf("\nRemaining (%d):\n", len(p.Remaining))
+		for _, t := range p.Remaining {
+			fmt.Printf("  [%s] P%d %s - %s%s\n", t.ID, t.Priority, t.Status, t.Title, dueAnnotation(t, now))
+		}
+	}
+	if len(p.Unverified) > 0 {
+		fmt.Printf("\nUnverified gates (%d):\n", len(p.Unverified))
+		for _, g := range p.Unverified {
+			fmt.Printf("  [%s] %s: %s (%s, %s)\n", g.TaskID, g.TaskTitle, g.GateTitle, g.GateID, g.Status)
+		}
+	}
+	if len(p.Remaining) == 0 && len(p.Unverified) == 0 && !release.IsReleased() {
+		fmt.Printf("\nReady to cut: gur release cut %s\n", release.Name)
+	}
+	return nil
+}
+
+func runReleaseCut(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	release, err := findRelease(database, args[0])
+	if err != nil {
+		return err
+	}
+	if release.IsReleased() {
+		return fmt.Errorf("cannot cut release '%s': already released on %s", release.Name, release.ReleasedAt.Format(models.DateFormat))
+	}
+	now := time.Now()
+	p, err := collectReleaseProgress(database, release, now)
+	if err != nil {
+		return fmt.Errorf("failed to load release '%s': database error: %w", release.Name, err)
+	}
+	if len(p.Remaining) > 0 && !releaseCutForce {
+		return fmt.Errorf("cannot cut release '%s': %d task(s) unfinished (use 'gur release status %s' to see them, or --force to cut without them)",
+			release.Name, len(p.Remaining), release.Name)
+	}
+
+	var shipped []models.Task
+	err = database.Transaction(func(tx *gorm.DB) error {
+		var err error
+		shipped, err = cutRelease(tx, release, now, eventActor())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cut release '%s': database error: %w", release.Name, err)
+	}
+
+	ids := make([]string, len(shipped))
+	for i, t := range shipped {
+		ids[i] = t.ID
+	}
+	var links []models.GitHubIssueLink
+	database.Where("task_id IN ?", ids).Find(&links)
+	issues := make(map[string]int, len(links))
+	for _, l := range links {
+		issues[l.TaskID] = l.IssueNumber
+	}
+	changelog := renderChangelog(release, shipped, issues, now)
+	if releaseCutOutput != "" {
+		if err := prependChangelog(releaseCutOutput, changelog); err != nil {
+			return fmt.Errorf("release '%s' was cut, but writing the changelog failed: %w", release.Name, err)
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "release": release, "tasks": ids, "untargeted": len(p.Remaining), "changelog": changelog})
+		return nil
+	}
+	fmt.Printf("Cut release %s: %d task(s) tagged %s%s\n", release.Name, len(shipped), models.ReleaseLabelPrefix, release.Name)
+	if len(p.Remaining) > 0 {
+		fmt.Printf("Untargeted %d unfinished task(s)\n", len(p.Remaining))
+	}
+	if len(p.Unverified) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d gate(s) on the release's tasks were not verified\n", len(p.Unverified))
+	}
+	if releaseCutOutput != "" {
+		fmt.Printf("Changelog written to %s\n", releaseCutOutput)
+	} else {
+		fmt.Printf("\n%s", changelog)
+	}
+	return nil
+}
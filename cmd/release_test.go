@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestReleaseStatusAndCut(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+	now := time.Now()
+
+	release := &models.Release{Name: "v1.3.0", Status: models.ReleaseStatusOpen}
+	database.Create(release)
+	database.Create(&models.Task{ID: "gur-rel00001", Title: "Export to CSV", Type: models.TypeFeature, Status: models.StatusClosed, Resolution: models.ResolutionCompleted, Release: "v1.3.0"})
+	database.Create(&models.Task{ID: "gur-rel00002", Title: "Crash on empty input", Type: models.TypeBug, Status: models.StatusClosed, Release: "v1.3.0"})
+	database.Create(&models.Task{ID: "gur-rel00003", Title: "Dropped idea", Type: models.TypeFeature, Status: models.StatusClosed, Resolution: models.ResolutionWontfix, Release: "v1.3.0"})
+	database.Create(&models.Task{ID: "gur-rel00004", Title: "Still going", Type: models.TypeTask, Status: models.StatusInProgress, Release: "v1.3.0"})
+	database.Create(&models.Task{ID: "gur-rel00005", Title: "Elsewhere", Status: models.StatusClosed})
+	database.Create(&models.Gate{ID: "gate-rel00001", Title: "Security review"})
+	database.Create(&models.GateTaskLink{GateID: "gate-rel00001", TaskID: "gur-rel00001", Status: models.GateLinkPassed})
+	database.Create(&models.GateTaskLink{GateID: "gate-rel00001", TaskID: "gur-rel00002", Status: models.GateLinkPending})
+
+	p, err := collectReleaseProgress(database, release, now)
+	if err != nil {
+		t.Fatalf("collectReleaseProgress() error: %v", err)
+	}
+	if p.Total != 4 || p.Done != 3 || len(p.Remaining) != 1 || p.Remaining[0].ID != "gur-rel00004" {
+		t.Errorf("progress = %d/%d done, remaining %v; want 3/4 and gur-rel00004", p.Done, p.Total, p.Remaining)
+	}
+	if len(p.Unverified) != 1 || p.Unverified[0].TaskID != "gur-rel00002" {
+		t.Errorf("unverified gates = %+v, want the pending gate on gur-rel00002", p.Unverified)
+	}
+
+	shipped, err := cutRelease(database, release, now, "tester")
+	if err != nil {
+		t.Fatalf("cutRelease() error: %v", err)
+	}
+	if len(shipped) != 3 {
+		t.Errorf("shipped %d tasks, want 3", len(shipped))
+	}
+	var task models.Task
+	database.First(&task, "id = ?", "gur-rel00004")
+	if task.Release != "" {
+		t.Errorf("unfinished task release = %q, want untargeted", task.Release)
+	}
+	var shippedTask models.Task
+	database.First(&shippedTask, "id = ?", "gur-rel00001")
+	if !shippedTask.HasLabel("release:v1.3.0") {
+		t.Errorf("shipped task labels = %v, want release:v1.3.0", shippedTask.Labels)
+	}
+	database.First(release, release.ID)
+	if !release.IsReleased() {
+		t.Errorf("release status = %s, want released", release.Status)
+	}
+	if err := targetRelease(database, "v1.3.0"); err == nil {
+		t.Error("targetRelease accepted a released release")
+	}
+
+	changelog := renderChangelog(release, shipped, map[string]int{"gur-rel00002": 42}, now)
+	for _, want := range []string{"## v1.3.0 - ", "### Features\n\n- Export to CSV (gur-rel00001)", "### Bug fixes\n\n- Crash on empty input (#42)"} {
+		if !strings.Contains(changelog, want) {
+			t.Errorf("changelog missing %q:\n%s", want, changelog)
+		}
+	}
+	if strings.Contains(changelog, "Dropped idea") {
+		t.Errorf("changelog includes a wontfix task:\n%s", changelog)
+	}
+}
+
+func TestPrependChangelog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	if err := prependChangelog(path, "## v1.0.0 - 2025-01-01\n"); err != nil {
+		t.Fatalf("prependChangelog() error: %v", err)
+	}
+	if err := prependChangelog(path, "## v1.1.0 - 2025-02-01\n"); err != nil {
+		t.Fatalf("prependChangelog() error: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "# Changelog\n\n## v1.1.0 - 2025-02-01\n\n## v1.0.0 - 2025-01-01\n"
+	if string(data) != want {
+		t.Errorf("changelog = %q, want %q", data, want)
+	}
+}
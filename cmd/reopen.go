@@ -23,7 +23,7 @@ func init() {
 func runReopen(cmd *cobra.Command, args []string) error {
 	task, err := db.GetTaskByID(args[0])
 	if err != nil {
-		return fmt.Errorf("cannot reopen task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+		return codedErrorf(ErrCodeNotFound, "cannot reopen task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
 	}
 
 	if !task.IsClosed() {
@@ -32,10 +32,13 @@ func runReopen(cmd *cobra.Command, args []string) error {
 
 	database := db.GetDB()
 	models.RecordChange(database, task.ID, "status", task.Status, models.StatusOpen, "user")
+	models.RecordChange(database, task.ID, "close_reason", task.CloseReason, "", "user")
+	models.RecordChange(database, task.ID, "resolution", task.Resolution, "", "user")
 	task.Reopen()
 	if err := database.Save(&task).Error; err != nil {
 		return fmt.Errorf("failed to reopen task '%s': database error: %w", task.ID, err)
 	}
+	syncParentChecklist(database, task, false, "user")
 
 	if IsJSONOutput() {
 		OutputJSON(map[string]interface{}{"success": true, "task": task})
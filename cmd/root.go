@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -11,8 +14,11 @@ import (
 )
 
 var (
-	Version    = "0.1.0"
-	jsonOutput bool
+	Version     = "0.1.0"
+	jsonOutput  bool
+	quietOutput bool
+	noColor     bool
+	dbPathFlag  string
 )
 
 // commandsExemptFromDB lists commands that don't require database initialization
@@ -21,6 +27,9 @@ var commandsExemptFromDB = map[string]bool{
 	"version":    true,
 	"help":       true,
 	"completion": true,
+	"ws":         true, // opens each workspace project itself
+	"env":        true, // picks the environment database itself
+	"explain":    true,
 }
 
 var rootCmd = &cobra.Command{
@@ -38,41 +47,75 @@ QUICK START:
 
 PRIORITIES: 0=Critical, 1=High, 2=Medium (default), 3=Low, 4=Lowest
 TYPES: task (default), bug, feature, epic
-STATUSES: open, in_progress, closed
+STATUSES: open, in_progress, blocked, closed
 
 TASK IDS: Auto-generated like "gur-a1b2c3d4"
 
 DEPENDENCIES:
   gur dep add <blocker> <blocked>   # First task blocks the second
   gur ready                         # Shows only unblocked tasks
+  gur why-not-ready <id>            # Explain why a task isn't ready
 
 TEST CASES:
   gur test create "Login works" -c auth -t e2e
   gur test list                     # List all tests
   gur test run <id> passed/failed   # Record test result
   gur test link <test-id> <task-id> # Link test to task (required to close)
+  gur test history <id>             # Show past runs with durations
 
 TEST TYPES: unit, integration, e2e, manual, smoke, regression
 
 WORKFLOW: Tasks with linked tests cannot be closed until tests pass.
 
-JSON OUTPUT: Add --json flag to any command for machine-readable output.`,
+JSON OUTPUT: Add --json flag to any command for machine-readable output.
+  Errors carry a stable code and a hint; 'gur explain <code>' describes it.
+QUIET OUTPUT: Add -q/--quiet to print only IDs, e.g. gur ready -q | head -1
+COLORS: Set with 'gur config theme'; disabled by --no-color, NO_COLOR, or piping.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		if commandsExemptFromDB[cmd.Name()] {
+		commandStartedAt = time.Now()
+		if dbPathFlag != "" {
+			db.SetDBPath(dbPathFlag)
+		}
+		if commandsExemptFromDB[cmd.Name()] || (cmd.HasParent() && commandsExemptFromDB[cmd.Parent().Name()]) {
 			return nil
 		}
-		return db.EnsureInitialized()
+		if err := checkWorkspaceLock(); err != nil {
+			return err
+		}
+		if err := db.EnsureInitialized(); err != nil {
+			if errors.Is(err, db.ErrNotInitialized) {
+				return withErrorCode(ErrCodeNotConfigured, err)
+			}
+			return err
+		}
+		startPerfLog(db.GetDB())
+		return nil
 	},
 }
 
 func Execute() {
 	defer db.CloseDB()
 
-	if err := rootCmd.Execute(); err != nil {
-		if jsonOutput {
-			OutputJSON(map[string]interface{}{"error": true, "message": err.Error()})
+	applyDBFlag(os.Args[1:])
+	registerAliases(os.Args[1:])
+	markUsageErrors(rootCmd)
+	cmd, err := rootCmd.ExecuteC()
+	if cmd != nil {
+		recordCommandEvent(cmd, cmd.Flags().Args(), err)
+		recordCommandPerf(cmd, err)
+		runAutoPush(cmd, cmd.Flags().Args(), err)
+	}
+	if err != nil {
+		if cmd == rootCmd && strings.HasPrefix(err.Error(), "unknown command") {
+			err = withErrorCode(ErrCodeUsage, err)
+		}
+		var reported *reportedError
+		if jsonOutput || hasJSONFlag(os.Args[1:]) {
+			if !errors.As(err, &reported) {
+				OutputJSON(errorJSON(err))
+			}
 		} else {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		}
@@ -82,9 +125,44 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "Print only IDs (one per line) so output can be piped")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().StringVar(&dbPathFlag, "db", "", "Use this database file instead of the project's active environment")
 	rootCmd.Version = Version
 }
 
+// applyDBFlag honors --db before cobra parses flags, since aliases are
+// loaded from the database ahead of command dispatch
+func applyDBFlag(args []string) {
+	for i, a := range args {
+		if a == "--" {
+			return
+		}
+		if v, ok := strings.CutPrefix(a, "--db="); ok {
+			db.SetDBPath(v)
+			return
+		}
+		if a == "--db" && i+1 < len(args) {
+			db.SetDBPath(args[i+1])
+			return
+		}
+	}
+}
+
+// hasJSONFlag reports whether --json was given, for errors raised before
+// cobra parsed the flags (unknown commands and flags)
+func hasJSONFlag(args []string) bool {
+	for _, a := range args {
+		if a == "--" {
+			return false
+		}
+		if a == "--json" || a == "--json=true" {
+			return true
+		}
+	}
+	return false
+}
+
 func OutputJSON(data interface{}) {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -94,3 +172,8 @@ func OutputJSON(data interface{}) {
 func IsJSONOutput() bool {
 	return jsonOutput
 }
+
+// IsQuietOutput reports whether only IDs should be printed; --json wins
+func IsQuietOutput() bool {
+	return quietOutput && !jsonOutput
+}
@@ -11,10 +11,11 @@ import (
 )
 
 var searchCmd = &cobra.Command{
-	Use:   "search <query>",
-	Short: "Search tasks",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSearch,
+	Use:         "search <query>",
+	Short:       "Search tasks",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runSearch,
 }
 
 func init() {
@@ -49,13 +50,21 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if IsQuietOutput() {
+		for _, t := range matches {
+			printQuietIDs(t.ID)
+		}
+		return nil
+	}
+
 	if len(matches) == 0 {
 		fmt.Println("No matches found")
 		return nil
 	}
 
+	c := colors()
 	for _, t := range matches {
-		fmt.Printf("[%s] P%d %s - %s\n", t.ID, t.Priority, t.Status, t.Title)
+		fmt.Printf("[%s] %s %s - %s\n", t.ID, c.Priority(t.Priority), c.Status(t.Status), t.Title)
 	}
 	return nil
 }
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/graphql"
+	"guardrails/internal/models"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the local database over HTTP",
+	Long: `Serve the local database over HTTP, read-only.
+
+  gur serve graphql   GraphQL API for custom dashboards
+  gur serve web       Embedded HTML dashboard
+
+Once an API token exists ('gur token create'), both require one.`,
+}
+
+var serveGraphQLCmd = &cobra.Command{
+	Use:         "graphql",
+	Short:       "Serve a read-only GraphQL API over the local database",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Serve a read-only GraphQL API over tasks, gates, dependencies, history
+and GitHub links, for building custom dashboards.
+
+Queries are accepted at /graphql as POST {"query", "variables",
+"operationName"} or GET ?query=... Field names match --json output
+(snake_case). Print the schema with --schema.
+
+Examples:
+  gur serve graphql
+  gur serve graphql --addr 127.0.0.1:9000
+  gur serve graphql --schema
+
+  curl -s localhost:8765/graphql -d '{"query":"{ tasks(status: \"open\", limit: 5) { id title gates { status gate { title } } } }"}'`,
+	Args: cobra.NoArgs,
+	RunE: runServeGraphQL,
+}
+
+var (
+	serveAddr   string
+	serveSchema bool
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.AddCommand(serveGraphQLCmd)
+	serveGraphQLCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8765", "Address to listen on")
+	serveGraphQLCmd.Flags().BoolVar(&serveSchema, "schema", false, "Print the schema and exit")
+}
+
+// graphqlHandler serves GraphQL requests against schema
+func graphqlHandler(schema *graphql.Schema) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphql.Request
+		switch r.Method {
+		case http.MethodGet:
+			req.Query = r.URL.Query().Get("query")
+			req.OperationName = r.URL.Query().Get("operationName")
+			if vars := r.URL.Query().Get("variables"); vars != "" {
+				if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+					http.Error(w, "invalid variables: "+err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+		case http.MethodPost:
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+				http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if req.Query == "" {
+			http.Error(w, "missing query", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(graphql.Execute(r.Context(), schema, req))
+	})
+}
+
+func runServeGraphQL(cmd *cobra.Command, args []string) error {
+	if serveSchema {
+		fmt.Println(graphqlSchemaDoc)
+		return nil
+	}
+
+	database := db.GetDB()
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", requireToken(database, models.TokenScopeRead, graphqlHandler(newGraphQLSchema(database))))
+	return serveHTTP(serveAddr, mux, fmt.Sprintf("Serving GraphQL at http://%s/graphql", serveAddr))
+}
+
+// tokenCookie keeps a dashboard token given as ?token= for later pages
+const tokenCookie = "gur_token"
+
+// requestToken returns the API token a request carries: a bearer token, the
+// token cookie, or a ?token= parameter. fromQuery reports the last.
+func requestToken(r *http.Request) (secret string, fromQuery bool) {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if secret, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return strings.TrimSpace(secret), false
+		}
+		return "", false
+	}
+	if c, err := r.Cookie(tokenCookie); err == nil && c.Value != "" {
+		return c.Value, false
+	}
+	if secret := r.URL.Query().Get("token"); secret != "" {
+		return secret, true
+	}
+	return "", false
+}
+
+// tokenUseInterval is how often a token's last use is written back, so busy
+// dashboards don't turn every request into a database write
+const tokenUseInterval = time.Minute
+
+// requireToken guards next with API tokens: once any active token exists,
+// requests need one whose scope allows need. Tokens are looked up on every
+// request, so revoking one takes effect immediately. A token given as
+// ?token= is moved into a cookie and the request redirected without it, so
+// the secret doesn't stay in the address bar or history.
+func requireToken(database *gorm.DB, need string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret, fromQuery := requestToken(r)
+		var token models.APIToken
+		found := false
+		if secret != "" {
+			err := database.Where("hash = ? AND revoked_at IS NULL", models.HashTokenSecret(secret)).First(&token).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				http.Error(w, "failed to check API tokens: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			found = err == nil
+		}
+
+		if !found {
+			// Only count tokens when the request has no valid one
+			var active int64
+			if err := database.Model(&models.APIToken{}).Where("revoked_at IS NULL").Count(&active).Error; err != nil {
+				http.Error(w, "failed to check API tokens: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if active == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if secret == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="gur"`)
+				http.Error(w, "API token required (send Authorization: Bearer <token>)", http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Bearer realm="gur", error="invalid_token"`)
+			http.Error(w, "invalid or revoked API token", http.StatusUnauthorized)
+			return
+		}
+		if !models.TokenScopeAllows(token.Scope, need) {
+			http.Error(w, fmt.Sprintf("token '%s' has scope %s; this endpoint needs %s", token.Name, token.Scope, need), http.StatusForbidden)
+			return
+		}
+
+		if now := time.Now(); token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenUseInterval {
+			database.Model(&token).Update("last_used_at", now)
+		}
+		if fromQuery {
+			http.SetCookie(w, &http.Cookie{Name: tokenCookie, Value: secret, Path: "/", HttpOnly: true, SameSite: http.SameSiteStrictMode})
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				u := *r.URL
+				query := u.Query()
+				query.Del("token")
+				u.RawQuery = query.Encode()
+				http.Redirect(w, r, u.RequestURI(), http.StatusSeeOther)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// serveHTTP serves handler on addr until interrupted, then shuts down
+// gracefully. banner is printed to stderr once listening starts.
+func serveHTTP(addr string, handler http.Handler, banner string) error {
+	server := &http.Server{Addr: addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errc := make(chan error, 1)
+	go func() { errc <- server.ListenAndServe() }()
+	fmt.Fprintf(os.Stderr, "%s (Ctrl+C to stop)\n", banner)
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/graphql"
+	"guardrails/internal/models"
+)
+
+// graphqlMaxLimit caps list sizes so a dashboard query can't load the world
+const graphqlMaxLimit = 1000
+
+// graphqlSchemaDoc documents the schema for 'gur serve graphql --schema'.
+// Scalar fields use the same snake_case names as --json output.
+const graphqlSchemaDoc = `type Query {
+  task(id: String!): Task
+  tasks(status: String, priority: Int, type: String, assignee: String, label: String,
+        resolution: String, parent: String, search: String, archived: Boolean,
+        limit: Int = 100, offset: Int = 0): [Task]
+  gate(id: String!): Gate
+  gates(status: String, type: String, category: String, limit: Int = 100, offset: Int = 0): [Gate]
+  dependencies(type: String, task: String, limit: Int = 100, offset: Int = 0): [Dependency]
+  history(task: String, field: String, since: String, limit: Int = 100, offset: Int = 0): [History]
+  github_links(repository: String, limit: Int = 100, offset: Int = 0): [GitHubLink]
+}
+
+type Task {
+  id title description status priority type labels assignee notes close_reason
+  resolution block_reason summary compacted synced source created_at updated_at
+  closed_at due_at estimate_minutes rank parent_id fields
+  parent: Task
+  subtasks: [Task]
+  blockers: [Task]          # tasks this one depends on
+  blocking: [Task]          # tasks waiting on this one
+  dependencies: [Dependency]
+  gates: [GateLink]
+  history(limit: Int = 100): [History]
+  note_entries(kind: String): [Note]
+  artifacts: [Artifact]
+  github: GitHubLink
+}
+
+type Gate {
+  id title description category type priority command workdir runner labels
+  approvers last_result last_run_at last_run_by run_count pass_count fail_count
+  created_at updated_at
+  tasks: [GateLink]
+  runs(limit: Int = 20): [GateRun]
+}
+
+type GateLink   { id gate_id task_id status verified_at verified_by notes expires_at created_at gate: Gate task: Task }
+type GateRun    { id gate_id result run_by notes duration_ms runner output created_at }
+type Dependency { id parent_id child_id type created_at parent: Task child: Task }
+type History    { id task_id field old_value new_value changed_by changed_at task: Task }
+type Note       { id task_id kind author body created_at }
+type Artifact   { id task_id kind source content size created_by created_at }
+type GitHubLink { id task_id issue_number issue_url repository last_synced_at sync_direction task: Task }`
+
+// newGraphQLSchema builds the read-only schema over the local database
+func newGraphQLSchema(database *gorm.DB) *graphql.Schema {
+	task := &graphql.Object{Name: "Task", Fields: scalarFields(models.Task{})}
+	gate := &graphql.Object{Name: "Gate", Fields: scalarFields(models.Gate{})}
+	gateLink := &graphql.Object{Name: "GateLink", Fields: scalarFields(models.GateTaskLink{})}
+	gateRun := &graphql.Object{Name: "GateRun", Fields: scalarFields(models.GateRun{})}
+	dependency := &graphql.Object{Name: "Dependency", Fields: scalarFields(models.Dependency{})}
+	history := &graphql.Object{Name: "History", Fields: scalarFields(models.TaskHistory{})}
+	note := &graphql.Object{Name: "Note", Fields: scalarFields(models.NoteEntry{})}
+	artifact := &graphql.Object{Name: "Artifact", Fields: scalarFields(models.Artifact{})}
+	githubLink := &graphql.Object{Name: "GitHubLink", Fields: scalarFields(models.GitHubIssueLink{})}
+
+	taskByID := func(id string) (interface{}, error) {
+		if id == "" {
+			return nil, nil
+		}
+		var t models.Task
+		if err := database.Where("id = ?", id).Limit(1).Find(&t).Error; err != nil || t.ID == "" {
+			return nil, err
+		}
+		tasks := []models.Task{t}
+		return &tasks[0], attachFieldValues(database, tasks)
+	}
+	gateByID := func(id string) (interface{}, error) {
+		var g models.Gate
+		if err := database.Where("id = ?", id).Limit(1).Find(&g).Error; err != nil || g.ID == "" {
+			return nil, err
+		}
+		return &g, nil
+	}
+	findTasks := func(query *gorm.DB) (interface{}, error) {
+		var tasks []models.Task
+		if err := query.Find(&tasks).Error; err != nil {
+			return nil, err
+		}
+		return tasks, attachFieldValues(database, tasks)
+	}
+
+	task.Fields["fields"] = &graphql.Field{Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		t := sourceTask(p.Source)
+		if t.Fields != nil {
+			return t.Fields, nil
+		}
+		tasks := []models.Task{t}
+		if err := attachFieldValues(database, tasks); err != nil {
+			return nil, err
+		}
+		return tasks[0].Fields, nil
+	}}
+	task.Fields["parent"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return taskByID(sourceTask(p.Source).ParentID)
+	}}
+	task.Fields["subtasks"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return findTasks(database.Where("parent_id = ?", sourceTask(p.Source).ID).Order("id ASC"))
+	}}
+	task.Fields["blockers"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		ids := database.Model(&models.Dependency{}).Select("parent_id").
+			Where("child_id = ? AND type = ?", sourceTask(p.Source).ID, models.DepTypeBlocks)
+		return findTasks(database.Where("id IN (?)", ids).Order("priority ASC, id ASC"))
+	}}
+	task.Fields["blocking"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		ids := database.Model(&models.Dependency{}).Select("child_id").
+			Where("parent_id = ? AND type = ?", sourceTask(p.Source).ID, models.DepTypeBlocks)
+		return findTasks(database.Where("id IN (?)", ids).Order("priority ASC, id ASC"))
+	}}
+	task.Fields["dependencies"] = &graphql.Field{Type: dependency, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		id := sourceTask(p.Source).ID
+		var deps []models.Dependency
+		err := database.Where("parent_id = ? OR child_id = ?", id, id).Order("id ASC").Find(&deps).Error
+		return deps, err
+	}}
+	task.Fields["gates"] = &graphql.Field{Type: gateLink, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		var links []models.GateTaskLink
+		err := database.Where("task_id = ?", sourceTask(p.Source).ID).Order("id ASC").Find(&links).Error
+		return links, err
+	}}
+	task.Fields["history"] = &graphql.Field{Type: history, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		var entries []models.TaskHistory
+		err := database.Where("task_id = ?", sourceTask(p.Source).ID).
+			Order("changed_at ASC").Limit(graphqlLimit(p.Args, 100)).Find(&entries).Error
+		return entries, err
+	}}
+	task.Fields["note_entries"] = &graphql.Field{Type: note, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		query := database.Where("task_id = ?", sourceTask(p.Source).ID)
+		if kind := p.Args.String("kind"); kind != "" {
+			query = query.Where("kind = ?", kind)
+		}
+		var entries []models.NoteEntry
+		err := query.Order("created_at ASC, id ASC").Find(&entries).Error
+		return entries, err
+	}}
+	task.Fields["artifacts"] = &graphql.Field{Type: artifact, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		var artifacts []models.Artifact
+		err := database.Where("task_id = ?", sourceTask(p.Source).ID).Order("id ASC").Find(&artifacts).Error
+		return artifacts, err
+	}}
+	task.Fields["github"] = &graphql.Field{Type: githubLink, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		var link models.GitHubIssueLink
+		if err := database.Where("task_id = ?", sourceTask(p.Source).ID).Limit(1).Find(&link).Error; err != nil || link.ID == 0 {
+			return nil, err
+		}
+		return &link, nil
+	}}
+
+	gate.Fields["tasks"] = &graphql.Field{Type: gateLink, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		var links []models.GateTaskLink
+		err := database.Where("gate_id = ?", sourceGate(p.Source).ID).Order("id ASC").Find(&links).Error
+		return links, err
+	}}
+	gate.Fields["runs"] = &graphql.Field{Type: gateRun, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		var runs []models.GateRun
+		err := database.Where("gate_id = ?", sourceGate(p.Source).ID).
+			Order("created_at DESC, id DESC").Limit(graphqlLimit(p.Args, 20)).Find(&runs).Error
+		return runs, err
+	}}
+
+	gateLink.Fields["gate"] = &graphql.Field{Type: gate, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return gateByID(p.Source.(models.GateTaskLink).GateID)
+	}}
+	gateLink.Fields["task"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return taskByID(p.Source.(models.GateTaskLink).TaskID)
+	}}
+	dependency.Fields["parent"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return taskByID(p.Source.(models.Dependency).ParentID)
+	}}
+	dependency.Fields["child"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return taskByID(p.Source.(models.Dependency).ChildID)
+	}}
+	history.Fields["task"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return taskByID(p.Source.(models.TaskHistory).TaskID)
+	}}
+	githubLink.Fields["task"] = &graphql.Field{Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return taskByID(sourceGitHubLink(p.Source).TaskID)
+	}}
+
+	query := &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"task": {Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return taskByID(p.Args.String("id"))
+		}},
+		"tasks": {Type: task, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return findTasks(graphqlPage(graphqlTaskFilter(database.Model(&models.Task{}), p.Args), p.Args).
+				Order("priority ASC, rank = 0, rank ASC, created_at DESC"))
+		}},
+		"gate": {Type: gate, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return gateByID(p.Args.String("id"))
+		}},
+		"gates": {Type: gate, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			query := database.Model(&models.Gate{})
+			for arg, column := range map[string]string{"status": "last_result", "type": "type", "category": "category"} {
+				if v := p.Args.String(arg); v != "" {
+					query = query.Where(column+" = ?", v)
+				}
+			}
+			var gates []models.Gate
+			err := graphqlPage(query, p.Args).Order("priority ASC, id ASC").Find(&gates).Error
+			return gates, err
+		}},
+		"dependencies": {Type: dependency, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			query := database.Model(&models.Dependency{})
+			if v := p.Args.String("type"); v != "" {
+				query = query.Where("type = ?", v)
+			}
+			if v := p.Args.String("task"); v != "" {
+				query = query.Where("parent_id = ? OR child_id = ?", v, v)
+			}
+			var deps []models.Dependency
+			err := graphqlPage(query, p.Args).Order("id ASC").Find(&deps).Error
+			return deps, err
+		}},
+		"history": {Type: history, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			query := database.Model(&models.TaskHistory{})
+			if v := p.Args.String("task"); v != "" {
+				query = query.Where("task_id = ?", v)
+			}
+			if v := p.Args.String("field"); v != "" {
+				query = query.Where("field = ?", v)
+			}
+			if v := p.Args.String("since"); v != "" {
+				d, err := parseDuration(v)
+				if err != nil {
+					return nil, err
+				}
+				query = query.Where("changed_at >= ?", time.Now().Add(-d))
+			}
+			var entries []models.TaskHistory
+			err := graphqlPage(query, p.Args).Order("changed_at DESC").Find(&entries).Error
+			return entries, err
+		}},
+		"github_links": {Type: githubLink, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			query := database.Model(&models.GitHubIssueLink{})
+			if v := p.Args.String("repository"); v != "" {
+				query = query.Where("repository = ?", v)
+			}
+			var links []models.GitHubIssueLink
+			err := graphqlPage(query, p.Args).Order("issue_number ASC").Find(&links).Error
+			return links, err
+		}},
+	}}
+
+	return &graphql.Schema{Query: query}
+}
+
+// graphqlTaskFilter applies the tasks(...) filter arguments
+func graphqlTaskFilter(query *gorm.DB, args graphql.Args) *gorm.DB {
+	if archived, _ := args["archived"].(bool); !archived && args.String("status") != models.StatusArchived {
+		query = query.Where("status != ?", models.StatusArchived)
+	}
+	for arg, column := range map[string]string{"status": "status", "type": "type", "assignee": "assignee", "resolution": "resolution", "parent": "parent_id"} {
+		if v := args.String(arg); v != "" {
+			query = query.Where(column+" = ?", v)
+		}
+	}
+	if args.Has("priority") {
+		query = query.Where("priority = ?", args.Int("priority", 0))
+	}
+	if v := args.String("label"); v != "" {
+		query = query.Where("labels LIKE ?", "%"+fmt.Sprintf("%q", v)+"%")
+	}
+	if v := args.String("search"); v != "" {
+		query = query.Where("title LIKE ? OR description LIKE ?", "%"+v+"%", "%"+v+"%")
+	}
+	return query
+}
+
+// graphqlLimit reads the limit argument, capped at graphqlMaxLimit
+func graphqlLimit(args graphql.Args, def int) int {
+	limit := args.Int("limit", def)
+	if limit <= 0 || limit > graphqlMaxLimit {
+		return graphqlMaxLimit
+	}
+	return limit
+}
+
+// graphqlPage applies the limit and offset arguments
+func graphqlPage(query *gorm.DB, args graphql.Args) *gorm.DB {
+	return query.Limit(graphqlLimit(args, 100)).Offset(args.Int("offset", 0))
+}
+
+// scalarFields exposes every json-tagged field of a model as a scalar field
+func scalarFields(model interface{}) map[string]*graphql.Field {
+	fields := make(map[string]*graphql.Field)
+	for name := range jsonFieldNames(reflect.TypeOf(model)) {
+		fields[name] = &graphql.Field{}
+	}
+	return fields
+}
+
+func sourceTask(src interface{}) models.Task {
+	if t, ok := src.(*models.Task); ok {
+		return *t
+	}
+	return src.(models.Task)
+}
+
+func sourceGate(src interface{}) models.Gate {
+	if g, ok := src.(*models.Gate); ok {
+		return *g
+	}
+	return src.(models.Gate)
+}
+
+func sourceGitHubLink(src interface{}) models.GitHubIssueLink {
+	if l, ok := src.(*models.GitHubIssueLink); ok {
+		return *l
+	}
+	return src.(models.GitHubIssueLink)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestGraphQLSchema(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-gql00001", Title: "Blocker", Status: models.StatusOpen, Priority: 1, Labels: models.StringSlice{"api"}})
+	database.Create(&models.Task{ID: "gur-gql00002", Title: "Blocked", Status: models.StatusOpen, Priority: 2})
+	database.Create(&models.Task{ID: "gur-gql00003", Title: "Done", Status: models.StatusClosed})
+	database.Create(&models.Dependency{ParentID: "gur-gql00001", ChildID: "gur-gql00002", Type: models.DepTypeBlocks})
+	database.Create(&models.Gate{ID: "gate-gql00001", Title: "Unit tests", Type: "test"})
+	database.Create(&models.GateTaskLink{GateID: "gate-gql00001", TaskID: "gur-gql00002", Status: models.GateLinkPassed})
+
+	server := httptest.NewServer(graphqlHandler(newGraphQLSchema(database)))
+	defer server.Close()
+
+	query := `{
+		tasks(status: "open") { id blockers { id } gates { status gate { title } } }
+		labeled: tasks(label: "api") { id }
+		gate(id: "gate-gql00001") { tasks { task { title } } }
+	}`
+	body, _ := json.Marshal(map[string]string{"query": query})
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var got struct {
+		Data struct {
+			Tasks []struct {
+				ID       string `json:"id"`
+				Blockers []struct {
+					ID string `json:"id"`
+				} `json:"blockers"`
+				Gates []struct {
+					Status string `json:"status"`
+					Gate   struct {
+						Title string `json:"title"`
+					} `json:"gate"`
+				} `json:"gates"`
+			} `json:"tasks"`
+			Labeled []struct {
+				ID string `json:"id"`
+			} `json:"labeled"`
+			Gate struct {
+				Tasks []struct {
+					Task struct {
+						Title string `json:"title"`
+					} `json:"task"`
+				} `json:"tasks"`
+			} `json:"gate"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", got.Errors)
+	}
+
+	tasks := got.Data.Tasks
+	if len(tasks) != 2 || tasks[0].ID != "gur-gql00001" || tasks[1].ID != "gur-gql00002" {
+		t.Fatalf("open tasks = %+v, want gql00001 then gql00002", tasks)
+	}
+	if len(tasks[1].Blockers) != 1 || tasks[1].Blockers[0].ID != "gur-gql00001" {
+		t.Errorf("blockers = %+v", tasks[1].Blockers)
+	}
+	if len(tasks[1].Gates) != 1 || tasks[1].Gates[0].Gate.Title != "Unit tests" || tasks[1].Gates[0].Status != models.GateLinkPassed {
+		t.Errorf("gates = %+v", tasks[1].Gates)
+	}
+	if len(got.Data.Labeled) != 1 || got.Data.Labeled[0].ID != "gur-gql00001" {
+		t.Errorf("label filter = %+v", got.Data.Labeled)
+	}
+	if len(got.Data.Gate.Tasks) != 1 || got.Data.Gate.Tasks[0].Task.Title != "Blocked" {
+		t.Errorf("gate tasks = %+v", got.Data.Gate.Tasks)
+	}
+}
+
+func TestGraphQLHandlerRejectsBadRequests(t *testing.T) {
+	handler := graphqlHandler(newGraphQLSchema(nil))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("missing query: status %d, want 400", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/graphql", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE: status %d, want 405", rec.Code)
+	}
+}
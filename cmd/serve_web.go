@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+//go:embed web/*.html
+var webTemplates embed.FS
+
+var serveWebCmd = &cobra.Command{
+	Use:         "web",
+	Short:       "Serve a read-only dashboard over the local database",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Serve a read-only HTML dashboard over the local database, so stakeholders
+can browse tasks without installing the CLI. Pages are embedded in the
+binary and rendered on each request; nothing can be changed from the browser.
+Once an API token exists, open it with ?token=<token> (see 'gur token').
+
+Pages:
+  /              Board: active tasks by status, plus recently closed
+  /tasks/<id>    Task detail with dependencies, gates and history
+  /gates         Gates with per-task verification counts
+  /sync          GitHub sync status
+  /burndown      Open tasks per day (?days=30, up to 365)
+
+Examples:
+  gur serve web
+  gur serve web --addr 0.0.0.0:9000`,
+	Args: cobra.NoArgs,
+	RunE: runServeWeb,
+}
+
+var webAddr string
+
+// Dashboard limits
+const (
+	webRecentlyClosedDays = 14
+	webRecentlyClosedMax  = 20
+	webBurndownDays       = 30
+	webBurndownMaxDays    = 365
+)
+
+func init() {
+	serveCmd.AddCommand(serveWebCmd)
+	serveWebCmd.Flags().StringVar(&webAddr, "addr", "127.0.0.1:8090", "Address to listen on")
+}
+
+var webFuncs = template.FuncMap{
+	"fmtTime":  func(t time.Time) string { return t.Format(models.DateTimeShortFormat) },
+	"fmtDate":  func(t time.Time) string { return t.Format(models.DateFormat) },
+	"history":  describeHistory,
+	"evidence": gateEvidence,
+	"effort":   effortSummary,
+}
+
+// parseWebPage parses a page template together with the shared layout
+func parseWebPage(name string) *template.Template {
+	return template.Must(template.New(name).Funcs(webFuncs).ParseFS(webTemplates, "web/layout.html", "web/"+name+".html"))
+}
+
+// webDashboard renders dashboard pages from the database
+type webDashboard struct {
+	database *gorm.DB
+	pages    map[string]*template.Template
+}
+
+// newWebDashboard returns the dashboard's read-only handler
+func newWebDashboard(database *gorm.DB) http.Handler {
+	d := &webDashboard{database: database, pages: make(map[string]*template.Template)}
+	for _, name := range []string{"board", "task", "gates", "sync", "burndown"} {
+		d.pages[name] = parseWebPage(name)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", d.board)
+	mux.HandleFunc("GET /tasks/{id}", d.task)
+	mux.HandleFunc("GET /gates", d.gates)
+	mux.HandleFunc("GET /sync", d.sync)
+	mux.HandleFunc("GET /burndown", d.burndown)
+	return mux
+}
+
+// render executes a page into a buffer first, so a template error doesn't
+// leave a half-written page behind
+func (d *webDashboard) render(w http.ResponseWriter, page, title string, data interface{}) {
+	var sb strings.Builder
+	err := d.pages[page].ExecuteTemplate(&sb, "layout", map[string]interface{}{
+		"Title": title, "Page": page, "Data": data, "GeneratedAt": time.Now(),
+	})
+	if err != nil {
+		http.Error(w, "failed to render page: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, sb.String())
+}
+
+// boardColumn is one status column on the board
+type boardColumn struct {
+	Status string
+	Title  string
+	Tasks  []models.Task
+}
+
+// collectBoard loads active tasks by status, plus tasks closed in the last
+// webRecentlyClosedDays
+func collectBoard(database *gorm.DB, now time.Time) ([]boardColumn, error) {
+	columns := []boardColumn{
+		{Status: models.StatusOpen, Title: "Open"},
+		{Status: models.StatusInProgress, Title: "In Progress"},
+		{Status: models.StatusBlocked, Title: "Blocked"},
+		{Status: models.StatusClosed, Title: "Recently Closed"},
+	}
+	for i := range columns {
+		query := database.Where("status = ?", columns[i].Status)
+		if columns[i].Status == models.StatusClosed {
+			query = query.Where("closed_at >= ?", now.AddDate(0, 0, -webRecentlyClosedDays)).
+				Order("closed_at DESC").Limit(webRecentlyClosedMax)
+		} else {
+			query = query.Order("priority ASC, rank = 0, rank ASC, created_at ASC")
+		}
+		if err := query.Find(&columns[i].Tasks).Error; err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+func (d *webDashboard) board(w http.ResponseWriter, r *http.Request) {
+	columns, err := collectBoard(d.database, time.Now())
+	if err != nil {
+		http.Error(w, "failed to load tasks: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	d.render(w, "board", "Board", columns)
+}
+
+func (d *webDashboard) task(w http.ResponseWriter, r *http.Request) {
+	b, err := collectTaskBrief(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	logged, err := loggedMinutes(d.database, []string{b.Task.ID})
+	if err != nil {
+		http.Error(w, "failed to load logged time: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	d.render(w, "task", b.Task.ID, map[string]interface{}{"Brief": b, "Logged": logged[b.Task.ID]})
+}
+
+// gateSummary is a gate with its per-task link counts by status
+type gateSummary struct {
+	Gate   models.Gate
+	Counts map[string]int
+}
+
+// collectGateSummaries loads all gates with their link counts by status
+func collectGateSummaries(database *gorm.DB) ([]gateSummary, error) {
+	var gates []models.Gate
+	if err := database.Order("priority ASC, category ASC, created_at DESC").Find(&gates).Error; err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		GateID string
+		Status string
+		Count  int
+	}
+	err := database.Model(&models.GateTaskLink{}).
+		Select("gate_id, status, COUNT(*) AS count").
+		Group("gate_id, status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]map[string]int)
+	for _, row := range rows {
+		if counts[row.GateID] == nil {
+			counts[row.GateID] = make(map[string]int)
+		}
+		counts[row.GateID][row.Status] = row.Count
+	}
+
+	summaries := make([]gateSummary, len(gates))
+	for i, g := range gates {
+		summaries[i] = gateSummary{Gate: g, Counts: counts[g.ID]}
+	}
+	return summaries, nil
+}
+
+func (d *webDashboard) gates(w http.ResponseWriter, r *http.Request) {
+	summaries, err := collectGateSummaries(d.database)
+	if err != nil {
+		http.Error(w, "failed to load gates: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	d.render(w, "gates", "Gates", summaries)
+}
+
+func (d *webDashboard) sync(w http.ResponseWriter, r *http.Request) {
+	st := collectSyncStatus(d.database)
+	st.Repository, _ = db.GetConfig(models.ConfigGitHubRepo)
+	d.render(w, "sync", "Sync", st)
+}
+
+// burndownPoint is the number of open tasks at the end of a day
+type burndownPoint struct {
+	Day    time.Time
+	Open   int
+	Closed int // Closed during the day
+}
+
+// collectBurndown counts open tasks at the end of each of the last days
+// days, ending today. Archived tasks count as closed; reopened tasks count as
+// open since creation, since only the latest close is recorded.
+func collectBurndown(database *gorm.DB, days int, now time.Time) ([]burndownPoint, error) {
+	var tasks []models.Task
+	if err := database.Select("id, status, created_at, updated_at, closed_at").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	points := make([]burndownPoint, days)
+	for i := range points {
+		points[i].Day = today.AddDate(0, 0, i-days+1)
+	}
+	for _, t := range tasks {
+		closedAt := t.ClosedAt
+		if closedAt == nil && t.IsArchived() {
+			closedAt = &t.UpdatedAt
+		}
+		for i := range points {
+			end := points[i].Day.AddDate(0, 0, 1)
+			if !t.CreatedAt.Before(end) {
+				continue
+			}
+			if closedAt == nil || !closedAt.Before(end) {
+				points[i].Open++
+			} else if !closedAt.Before(points[i].Day) {
+				points[i].Closed++
+			}
+		}
+	}
+	return points, nil
+}
+
+// burndownChart is the SVG geometry for a burndown series
+type burndownChart struct {
+	Width, Height int
+	ViewBox       string // Plot area plus a margin for axis labels
+	Max           int
+	Line          string // Polyline points
+}
+
+func newBurndownChart(points []burndownPoint) burndownChart {
+	chart := burndownChart{Width: 720, Height: 240, Max: 1}
+	chart.ViewBox = fmt.Sprintf("-40 -10 %d %d", chart.Width+50, chart.Height+20)
+	for _, p := range points {
+		if p.Open > chart.Max {
+			chart.Max = p.Open
+		}
+	}
+	step := float64(chart.Width)
+	if len(points) > 1 {
+		step = float64(chart.Width) / float64(len(points)-1)
+	}
+	coords := make([]string, len(points))
+	for i, p := range points {
+		y := float64(chart.Height) * (1 - float64(p.Open)/float64(chart.Max))
+		coords[i] = fmt.Sprintf("%.1f,%.1f", float64(i)*step, y)
+	}
+	chart.Line = strings.Join(coords, " ")
+	return chart
+}
+
+func (d *webDashboard) burndown(w http.ResponseWriter, r *http.Request) {
+	days := webBurndownDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > webBurndownMaxDays {
+			http.Error(w, fmt.Sprintf("invalid days '%s': must be 1-%d", v, webBurndownMaxDays), http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+	points, err := collectBurndown(d.database, days, time.Now())
+	if err != nil {
+		http.Error(w, "failed to load tasks: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	d.render(w, "burndown", "Burndown", map[string]interface{}{
+		"Days": days, "Points": points, "Chart": newBurndownChart(points),
+	})
+}
+
+func runServeWeb(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	return serveHTTP(webAddr, requireToken(database, models.TokenScopeRead, newWebDashboard(database)), fmt.Sprintf("Serving dashboard at http://%s/", webAddr))
+}
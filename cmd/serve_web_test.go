@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestWebDashboardPages(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	closed := time.Now().Add(-time.Hour)
+	database.Create(&models.Task{ID: "gur-web00001", Title: "Build <board>", Status: models.StatusOpen, Priority: 1})
+	database.Create(&models.Task{ID: "gur-web00002", Title: "Wire API", Status: models.StatusInProgress, Assignee: "alice", Estimate: 120})
+	database.Create(&models.Task{ID: "gur-web00003", Title: "Shipped", Status: models.StatusClosed, ClosedAt: &closed})
+	database.Create(&models.Gate{ID: "gate-web00001", Title: "Unit tests", Type: "test"})
+	database.Create(&models.GateTaskLink{GateID: "gate-web00001", TaskID: "gur-web00002", Status: models.GateLinkPassed})
+	database.Create(&models.GateTaskLink{GateID: "gate-web00001", TaskID: "gur-web00001", Status: models.GateLinkPending})
+
+	server := httptest.NewServer(newWebDashboard(database))
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/", []string{"Build &lt;board&gt;", `href="/tasks/gur-web00002"`, "Recently Closed (1)"}},
+		{"/tasks/gur-web00002", []string{"Wire API", "alice", "2h estimated, none logged", "Unit tests"}},
+		{"/gates", []string{"Unit tests", `<td class="passed">1</td>`, `<td class="pending">1</td>`}},
+		{"/sync", []string{"GitHub not configured", "<td>Unsynced</td><td>3</td>"}},
+		{"/burndown?days=7", []string{"last 7 days", "<polyline"}},
+	}
+	for _, tt := range tests {
+		status, body := get(tt.path)
+		if status != http.StatusOK {
+			t.Errorf("GET %s = %d, want 200: %s", tt.path, status, body)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(body, want) {
+				t.Errorf("GET %s missing %q", tt.path, want)
+			}
+		}
+	}
+
+	if status, _ := get("/tasks/gur-missing1"); status != http.StatusNotFound {
+		t.Errorf("unknown task = %d, want 404", status)
+	}
+	if status, _ := get("/burndown?days=0"); status != http.StatusBadRequest {
+		t.Errorf("days=0 = %d, want 400", status)
+	}
+	resp, err := http.Post(server.URL+"/", "text/plain", nil)
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST / = %d, want 405 (dashboard is read-only)", resp.StatusCode)
+	}
+}
+
+func TestCollectBurndown(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	closed8 := day(8)
+	database.Create(&models.Task{ID: "gur-burn0001", Title: "Old", Status: models.StatusClosed, CreatedAt: day(1), ClosedAt: &closed8})
+	database.Create(&models.Task{ID: "gur-burn0002", Title: "Open", Status: models.StatusOpen, CreatedAt: day(7)})
+	database.Create(&models.Task{ID: "gur-burn0003", Title: "New", Status: models.StatusOpen, CreatedAt: day(9)})
+
+	points, err := collectBurndown(database, 4, now)
+	if err != nil {
+		t.Fatalf("collectBurndown() error: %v", err)
+	}
+	want := []struct{ open, closed int }{{2, 0}, {1, 1}, {2, 0}, {2, 0}}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+	for i, w := range want {
+		if points[i].Day.Day() != 7+i || points[i].Open != w.open || points[i].Closed != w.closed {
+			t.Errorf("point %d = %s open %d closed %d, want day %d open %d closed %d",
+				i, points[i].Day.Format(models.DateFormat), points[i].Open, points[i].Closed, 7+i, w.open, w.closed)
+		}
+	}
+}
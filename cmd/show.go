@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -11,22 +12,42 @@ import (
 )
 
 var showCmd = &cobra.Command{
-	Use:   "show <id>",
-	Short: "Show task details",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runShow,
+	Use:         "show <id>",
+	Short:       "Show task details",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Show task details.
+
+With --deep, walks the dependency graph transitively to explain why the task
+can't start: every open upstream blocker with its status and assignee, the
+critical path (longest chain of blockers that must close in order), and the
+single closure that would unblock the most tasks.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
 }
 
+var showDeep bool
+
 func init() {
 	rootCmd.AddCommand(showCmd)
+	showCmd.Flags().BoolVar(&showDeep, "deep", false, "Show transitive blocker analysis")
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
 	database := db.GetDB()
 	task, err := db.GetTaskByID(args[0])
 	if err != nil {
-		return fmt.Errorf("task '%s' not found (use 'gur list' to see available tasks, or 'gur search' to find by keyword)", args[0])
+		return codedErrorf(ErrCodeNotFound, "task '%s' not found (use 'gur list' to see available tasks, or 'gur search' to find by keyword)", args[0])
+	}
+
+	// Load custom field values
+	loaded := []models.Task{*task}
+	if err := attachFieldValues(database, loaded); err != nil {
+		return err
+	}
+	if err := attachAcceptanceCriteria(database, loaded); err != nil {
+		return err
 	}
+	task = &loaded[0]
 
 	// Use eager loading to fetch dependencies in fewer queries
 	var blockedBy, blocks []models.Dependency
@@ -45,15 +66,63 @@ func runShow(cmd *cobra.Command, args []string) error {
 	var agentLinks []models.TaskAgentLink
 	database.Preload("Agent").Where("task_id = ?", task.ID).Find(&agentLinks)
 
+	// Issues and URLs mentioned in the description and notes
+	refs, err := loadTaskReferences(database, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load references: %w", err)
+	}
+
+	// Logged time, for the estimate variance
+	logged, err := loggedMinutes(database, []string{task.ID})
+	if err != nil {
+		return fmt.Errorf("failed to total logged time: %w", err)
+	}
+
+	var graph *blockerGraph
+	var analysis blockerAnalysis
+	if showDeep {
+		if graph, err = loadBlockerGraph(database); err != nil {
+			return fmt.Errorf("failed to load dependencies: %w", err)
+		}
+		analysis = graph.analyze(task.ID)
+	}
+
+	// Priority inherited from more urgent tasks this one blocks
+	var effective inheritedPriority
+	hasEffective := false
+	if !task.IsClosed() {
+		if graph == nil {
+			if graph, err = loadBlockerGraph(database); err != nil {
+				return fmt.Errorf("failed to load dependencies: %w", err)
+			}
+		}
+		effective, hasEffective = graph.inheritedPriorities()[task.ID]
+	}
+
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{
+		result := map[string]interface{}{
 			"task":       task,
 			"blocked_by": blockedBy,
 			"blocks":     blocks,
 			"subtasks":   subtasks,
 			"skills":     skillLinks,
 			"agents":     agentLinks,
-		})
+			"references": refs,
+		}
+		if showDeep {
+			result["blocker_analysis"] = analysis
+		}
+		if hasEffective {
+			result["effective_priority"] = effective
+		}
+		if task.Estimate > 0 || logged[task.ID] > 0 {
+			effort := map[string]interface{}{"estimate_minutes": task.Estimate, "logged_minutes": logged[task.ID]}
+			if task.Estimate > 0 && logged[task.ID] > 0 {
+				effort["variance_pct"] = effortVariance(task.Estimate, logged[task.ID])
+			}
+			result["effort"] = effort
+		}
+		OutputJSON(result)
 		return nil
 	}
 
@@ -62,22 +131,52 @@ func runShow(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Parent:   %s\n", task.ParentID)
 	}
 	fmt.Printf("Title:    %s\n", task.Title)
-	fmt.Printf("Status:   %s\n", task.Status)
-	fmt.Printf("Priority: %s\n", task.PriorityString())
+	c := colors()
+	fmt.Printf("Status:   %s\n", c.Status(task.Status))
+	if task.IsBlocked() && task.BlockReason != "" {
+		fmt.Printf("Blocked:  %s\n", task.BlockReason)
+	}
+	fmt.Printf("Priority: %s%s\n", c.PriorityText(task.Priority, task.PriorityString()), effectivePriorityAnnotation(effective, hasEffective))
+	if task.Rank > 0 {
+		fmt.Printf("Rank:     %d\n", task.Rank)
+	}
+	if task.Votes > 0 {
+		fmt.Printf("Votes:    %d\n", task.Votes)
+	}
 	fmt.Printf("Type:     %s\n", task.Type)
 	if task.Description != "" {
 		fmt.Printf("Desc:     %s\n", task.Description)
 	}
+	if task.DueAt != nil {
+		overdue := ""
+		if task.IsOverdue(time.Now()) {
+			overdue = " (overdue)"
+		}
+		fmt.Printf("Due:      %s%s\n", task.DueString(), overdue)
+	}
 	if task.Assignee != "" {
 		fmt.Printf("Assignee: %s\n", task.Assignee)
 	}
+	if task.Release != "" {
+		fmt.Printf("Release:  %s\n", task.Release)
+	}
+	if effort := effortSummary(task.Estimate, logged[task.ID]); effort != "" {
+		fmt.Printf("Effort:   %s\n", effort)
+	}
 	if len(task.Labels) > 0 {
 		fmt.Printf("Labels:   %v\n", task.Labels)
 	}
+	for _, name := range sortedFieldNames(task.Fields) {
+		fmt.Printf("%-9s %s\n", name+":", task.Fields[name])
+	}
 	if task.Summary != "" {
 		fmt.Printf("Summary:  %s\n", task.Summary)
 	}
 	fmt.Printf("Created:  %s\n", task.CreatedAt.Format(models.DateTimeShortFormat))
+	if len(task.Criteria) > 0 {
+		fmt.Printf("\nAcceptance criteria (%d/%d done):\n", criteriaDone(task.Criteria), len(task.Criteria))
+		printCriteria(task.Criteria, "  ")
+	}
 	if len(subtasks) > 0 {
 		fmt.Println("\nSubtasks:")
 		for _, s := range subtasks {
@@ -96,6 +195,15 @@ func runShow(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  - %s\n", d.ChildID)
 		}
 	}
+	if showDeep {
+		printBlockerAnalysis(graph, task.ID, analysis)
+	}
+	if len(refs) > 0 {
+		fmt.Println("\nReferences:")
+		for _, r := range refs {
+			fmt.Printf("  - %s (%s)\n", c.Link(r.Link, r.String()), r.Source)
+		}
+	}
 	if task.Notes != "" {
 		fmt.Printf("\nNotes:\n%s", task.Notes)
 	}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// blockerGraph holds the open "blocks" edges: a task's blockers are only
+// counted while they are not closed, matching findReadyTasks
+type blockerGraph struct {
+	tasks     map[string]models.Task
+	blockers  map[string][]string // child ID -> open blocker IDs
+	dependent map[string][]string // blocker ID -> blocked child IDs
+}
+
+// upstreamBlocker is an open task somewhere upstream of the analyzed task
+type upstreamBlocker struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Priority  int      `json:"priority"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Depth     int      `json:"depth"` // 1 = direct blocker
+	BlockedBy []string `json:"blocked_by,omitempty"`
+}
+
+// blockerAnalysis explains why a task can't start
+type blockerAnalysis struct {
+	Blockers     []upstreamBlocker `json:"blockers"`
+	CriticalPath []string          `json:"critical_path"` // Blocker IDs to close in order, furthest upstream first
+	BestClosure  string            `json:"best_closure,omitempty"`
+	Unblocks     int               `json:"unblocks,omitempty"` // Tasks BestClosure would unblock
+}
+
+// loadBlockerGraph loads every blocks dependency whose blocker is still open
+func loadBlockerGraph(database *gorm.DB) (*blockerGraph, error) {
+	var deps []models.Dependency
+	if err := database.Model(&models.Dependency{}).
+		Joins("JOIN tasks ON tasks.id = dependencies.parent_id").
+		Where("dependencies.type = ? AND tasks.status != ?", models.DepTypeBlocks, models.StatusClosed).
+		Find(&deps).Error; err != nil {
+		return nil, err
+	}
+
+	g := &blockerGraph{
+		tasks:     make(map[string]models.Task),
+		blockers:  make(map[string][]string),
+		dependent: make(map[string][]string),
+	}
+	ids := make(map[string]bool)
+	for _, d := range deps {
+		g.blockers[d.ChildID] = append(g.blockers[d.ChildID], d.ParentID)
+		g.dependent[d.ParentID] = append(g.dependent[d.ParentID], d.ChildID)
+		ids[d.ParentID] = true
+		ids[d.ChildID] = true
+	}
+	if len(ids) == 0 {
+		return g, nil
+	}
+
+	idList := make([]string, 0, len(ids))
+	for id := range ids {
+		idList = append(idList, id)
+	}
+	var tasks []models.Task
+	if err := database.Where("id IN ?", idList).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		g.tasks[t.ID] = t
+	}
+	return g, nil
+}
+
+// analyze walks upstream from taskID and finds the critical path and the
+// blocker whose closure would unblock the most tasks
+func (g *blockerGraph) analyze(taskID string) blockerAnalysis {
+	analysis := blockerAnalysis{Blockers: []upstreamBlocker{}, CriticalPath: []string{}}
+
+	// BFS upstream, recording the shallowest depth of each blocker
+	depth := map[string]int{taskID: 0}
+	queue := []string{taskID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, b := range g.blockers[current] {
+			if _, seen := depth[b]; seen {
+				continue
+			}
+			depth[b] = depth[current] + 1
+			queue = append(queue, b)
+			t := g.tasks[b]
+			analysis.Blockers = append(analysis.Blockers, upstreamBlocker{
+				ID:        b,
+				Title:     t.Title,
+				Status:    t.Status,
+				Priority:  t.Priority,
+				Assignee:  t.Assignee,
+				Depth:     depth[b],
+				BlockedBy: g.blockers[b],
+			})
+		}
+	}
+	if len(analysis.Blockers) == 0 {
+		return analysis
+	}
+
+	// Longest chain of blockers; on a cycle the repeated task ends the chain
+	memo := make(map[string][]string)
+	onPath := make(map[string]bool)
+	var longest func(id string) []string
+	longest = func(id string) []string {
+		if chain, ok := memo[id]; ok {
+			return chain
+		}
+		onPath[id] = true
+		var best []string
+		for _, b := range g.blockers[id] {
+			if onPath[b] {
+				continue
+			}
+			if chain := longest(b); len(chain) > len(best) {
+				best = chain
+			}
+		}
+		onPath[id] = false
+		chain := append(append([]string{}, best...), id)
+		memo[id] = chain
+		return chain
+	}
+	path := longest(taskID)
+	analysis.CriticalPath = path[:len(path)-1]
+
+	// Closing a blocker unblocks every open task for which it is the only
+	// open blocker
+	for _, b := range analysis.Blockers {
+		count := 0
+		for _, child := range g.dependent[b.ID] {
+			if t, ok := g.tasks[child]; ok && !t.IsClosed() && len(g.blockers[child]) == 1 {
+				count++
+			}
+		}
+		if count > analysis.Unblocks || (count == analysis.Unblocks && count > 0 && g.higherPriority(b.ID, analysis.BestClosure)) {
+			analysis.BestClosure = b.ID
+			analysis.Unblocks = count
+		}
+	}
+	return analysis
+}
+
+// higherPriority reports whether task a should be closed before task b
+func (g *blockerGraph) higherPriority(a, b string) bool {
+	ta, tb := g.tasks[a], g.tasks[b]
+	if ta.Priority != tb.Priority {
+		return ta.Priority < tb.Priority
+	}
+	return a < b
+}
+
+// printBlockerAnalysis prints the upstream blockers as a tree under taskID
+func printBlockerAnalysis(g *blockerGraph, taskID string, analysis blockerAnalysis) {
+	fmt.Println("\nBlocker analysis:")
+	if len(analysis.Blockers) == 0 {
+		fmt.Println("  No open blockers - ready to start")
+		return
+	}
+
+	fmt.Printf("  Open upstream blockers: %d\n", len(analysis.Blockers))
+	fmt.Printf("  Critical path: %d task(s): %s -> %s\n",
+		len(analysis.CriticalPath), strings.Join(analysis.CriticalPath, " -> "), taskID)
+	if analysis.BestClosure != "" {
+		fmt.Printf("  Best next closure: %s (unblocks %d task(s))\n", analysis.BestClosure, analysis.Unblocks)
+	}
+	fmt.Println()
+
+	printed := make(map[string]bool)
+	var walk func(id string, indent int)
+	walk = func(id string, indent int) {
+		blockers := append([]string{}, g.blockers[id]...)
+		sort.Slice(blockers, func(i, j int) bool { return g.higherPriority(blockers[i], blockers[j]) })
+		for _, b := range blockers {
+			t := g.tasks[b]
+			line := fmt.Sprintf("%s- %s [%s] %s %q", strings.Repeat("  ", indent), b, t.Status, t.PriorityString(), t.Title)
+			if t.Assignee != "" {
+				line += " @" + t.Assignee
+			}
+			if printed[b] {
+				fmt.Println(line + " (see above)")
+				continue
+			}
+			printed[b] = true
+			fmt.Println(line)
+			walk(b, indent+1)
+		}
+	}
+	walk(taskID, 1)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestBlockerAnalysis(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	for _, task := range []models.Task{
+		{ID: "gur-target01", Title: "Target", Status: models.StatusOpen, Priority: 2},
+		{ID: "gur-mid00001", Title: "Middle", Status: models.StatusInProgress, Priority: 2, Assignee: "alice"},
+		{ID: "gur-root0001", Title: "Root", Status: models.StatusOpen, Priority: 1},
+		{ID: "gur-side0001", Title: "Side", Status: models.StatusOpen, Priority: 2},
+		{ID: "gur-done0001", Title: "Done", Status: models.StatusClosed, Priority: 0},
+		{ID: "gur-other001", Title: "Other", Status: models.StatusOpen, Priority: 2},
+	} {
+		if err := database.Create(&task).Error; err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+	}
+	// root -> mid -> target, side -> target, done -> target (closed),
+	// root -> other
+	for _, dep := range [][2]string{
+		{"gur-root0001", "gur-mid00001"},
+		{"gur-mid00001", "gur-target01"},
+		{"gur-side0001", "gur-target01"},
+		{"gur-done0001", "gur-target01"},
+		{"gur-root0001", "gur-other001"},
+	} {
+		database.Create(&models.Dependency{ParentID: dep[0], ChildID: dep[1], Type: models.DepTypeBlocks})
+	}
+
+	graph, err := loadBlockerGraph(database)
+	if err != nil {
+		t.Fatalf("loadBlockerGraph: %v", err)
+	}
+	analysis := graph.analyze("gur-target01")
+
+	if len(analysis.Blockers) != 3 {
+		t.Fatalf("expected 3 open upstream blockers, got %+v", analysis.Blockers)
+	}
+	depths := make(map[string]int)
+	for _, b := range analysis.Blockers {
+		depths[b.ID] = b.Depth
+	}
+	want := map[string]int{"gur-mid00001": 1, "gur-side0001": 1, "gur-root0001": 2}
+	if !reflect.DeepEqual(depths, want) {
+		t.Errorf("depths = %v, want %v", depths, want)
+	}
+	if path := []string{"gur-root0001", "gur-mid00001"}; !reflect.DeepEqual(analysis.CriticalPath, path) {
+		t.Errorf("critical path = %v, want %v", analysis.CriticalPath, path)
+	}
+	// Closing root unblocks both mid and other; mid and side unblock nothing
+	// alone since target has two open blockers
+	if analysis.BestClosure != "gur-root0001" || analysis.Unblocks != 2 {
+		t.Errorf("best closure = %s (%d), want gur-root0001 (2)", analysis.BestClosure, analysis.Unblocks)
+	}
+
+	if ready := graph.analyze("gur-root0001"); len(ready.Blockers) != 0 || ready.BestClosure != "" {
+		t.Errorf("expected no blockers for root, got %+v", ready)
+	}
+}
+
+func TestBlockerAnalysisCycle(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	for _, id := range []string{"gur-cyc00001", "gur-cyc00002"} {
+		database.Create(&models.Task{ID: id, Title: id, Status: models.StatusOpen})
+	}
+	database.Create(&models.Dependency{ParentID: "gur-cyc00001", ChildID: "gur-cyc00002", Type: models.DepTypeBlocks})
+	database.Create(&models.Dependency{ParentID: "gur-cyc00002", ChildID: "gur-cyc00001", Type: models.DepTypeBlocks})
+
+	graph, err := loadBlockerGraph(database)
+	if err != nil {
+		t.Fatalf("loadBlockerGraph: %v", err)
+	}
+	analysis := graph.analyze("gur-cyc00001")
+	if len(analysis.Blockers) != 1 || len(analysis.CriticalPath) != 1 {
+		t.Errorf("unexpected analysis for cycle: %+v", analysis)
+	}
+}
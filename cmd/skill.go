@@ -23,9 +23,10 @@ When a task has linked skills, the agent working on it will be informed which sk
 }
 
 var skillListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List registered skills",
-	RunE:  runSkillList,
+	Use:         "list",
+	Short:       "List registered skills",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runSkillList,
 }
 
 var skillAddCmd = &cobra.Command{
@@ -44,10 +45,22 @@ var skillRemoveCmd = &cobra.Command{
 }
 
 var skillShowCmd = &cobra.Command{
-	Use:   "show <name>",
-	Short: "Show skill details",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSkillShow,
+	Use:         "show <name>",
+	Short:       "Show skill details",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runSkillShow,
+}
+
+var skillStatsCmd = &cobra.Command{
+	Use:         "stats",
+	Short:       "Show task outcomes per skill",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Correlate skills with the outcomes of the tasks they are linked to:
+tasks completed, average time-to-close, gate pass rate, and how often
+tasks were force-closed.`,
+	Args: cobra.NoArgs,
+	RunE: runSkillStats,
 }
 
 var skillScanCmd = &cobra.Command{
@@ -69,6 +82,7 @@ func init() {
 	skillCmd.AddCommand(skillRemoveCmd)
 	skillCmd.AddCommand(skillShowCmd)
 	skillCmd.AddCommand(skillScanCmd)
+	skillCmd.AddCommand(skillStatsCmd)
 
 	skillAddCmd.Flags().StringVar(&skillPath, "path", "", "Full path to skill file")
 	skillAddCmd.Flags().StringVar(&skillSource, "source", models.SourceCustom, "Source (claude/cursor/windsurf/copilot/custom)")
@@ -149,7 +163,7 @@ func runSkillRemove(cmd *cobra.Command, args []string) error {
 
 	var skill models.Skill
 	if err := db.GetDB().Where("name = ?", name).First(&skill).Error; err != nil {
-		return fmt.Errorf("cannot remove skill: skill '%s' not found (use 'gur skill list' to see registered skills)", name)
+		return codedErrorf(ErrCodeNotFound, "cannot remove skill: skill '%s' not found (use 'gur skill list' to see registered skills)", name)
 	}
 
 	// Remove task links first
@@ -174,7 +188,7 @@ func runSkillShow(cmd *cobra.Command, args []string) error {
 
 	var skill models.Skill
 	if err := db.GetDB().Where("name = ? OR id = ?", name, name).First(&skill).Error; err != nil {
-		return fmt.Errorf("skill '%s' not found (use 'gur skill list' to see registered skills, or 'gur skill scan' to auto-discover)", name)
+		return codedErrorf(ErrCodeNotFound, "skill '%s' not found (use 'gur skill list' to see registered skills, or 'gur skill scan' to auto-discover)", name)
 	}
 
 	// Get linked tasks
@@ -378,3 +392,18 @@ func registerSkillIfNew(skill models.Skill) (bool, error) {
 	}
 	return true, nil
 }
+
+func runSkillStats(cmd *cobra.Command, args []string) error {
+	stats, err := collectEffectiveness(db.GetDB(), models.TaskSkillLink{}.TableName(), "skill_id", models.Skill{}.TableName())
+	if err != nil {
+		return fmt.Errorf("failed to compute skill stats: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(stats), "skills": stats})
+		return nil
+	}
+
+	printEffectiveness("skill", stats)
+	return nil
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// Stale task actions
+const (
+	staleActionLabel       = "label"
+	staleActionDowngrade   = "downgrade"
+	staleActionClosePrompt = "close-prompt"
+)
+
+// staleLabel is added to stale tasks by --action label
+const staleLabel = "stale"
+
+var (
+	staleThreshold string
+	staleAction    string
+)
+
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "Find and handle abandoned in-progress tasks",
+	Long: `Find in_progress tasks with no history entries within --threshold and
+optionally act on them:
+
+  label         Add the 'stale' label
+  downgrade     Lower the priority by one level (down to P4)
+  close-prompt  Ask, task by task, whether to close it as wontfix
+                (requires an interactive terminal)
+
+Without --action the stale tasks are only listed. 'gur summary --stale 14d'
+lists them as part of the session summary.
+
+Examples:
+  gur stale
+  gur stale --threshold 7d --action label
+  gur stale --action close-prompt`,
+	Args: cobra.NoArgs,
+	RunE: runStale,
+}
+
+func init() {
+	rootCmd.AddCommand(staleCmd)
+	staleCmd.Flags().StringVar(&staleThreshold, "threshold", "14d", "Tasks without history entries for this long are stale")
+	staleCmd.Flags().StringVar(&staleAction, "action", "", "Action to apply: label, downgrade or close-prompt")
+}
+
+// findStaleTasks returns in_progress tasks with no history entries since cutoff
+func findStaleTasks(database *gorm.DB, cutoff time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := database.
+		Where("status = ? AND created_at < ?", models.StatusInProgress, cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM task_histories h WHERE h.task_id = tasks.id AND h.changed_at >= ?)", cutoff).
+		Order("priority ASC, created_at ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// lastActivity returns when the task was last changed, per its history
+func lastActivity(database *gorm.DB, task models.Task) time.Time {
+	var h models.TaskHistory
+	if database.Where("task_id = ?", task.ID).Order("changed_at DESC").First(&h).Error == nil {
+		return h.ChangedAt
+	}
+	return task.CreatedAt
+}
+
+// staleLabelTask adds the stale label; it reports false if already labeled
+func staleLabelTask(database *gorm.DB, task *models.Task) (bool, error) {
+	if task.HasLabel(staleLabel) {
+		return false, nil
+	}
+	models.RecordChange(database, task.ID, "label_added", "", staleLabel, "user")
+	task.AddLabel(staleLabel)
+	return true, database.Save(task).Error
+}
+
+// staleDowngradeTask lowers the priority one level; it reports false at P4
+func staleDowngradeTask(database *gorm.DB, task *models.Task) (bool, error) {
+	if task.Priority >= models.PriorityLowest {
+		return false, nil
+	}
+	models.RecordChange(database, task.ID, "priority", fmt.Sprintf("%d", task.Priority), fmt.Sprintf("%d", task.Priority+1), "user")
+	task.Priority++
+	return true, database.Save(task).Error
+}
+
+// staleCloseTask closes a stale task as wontfix, respecting its gates
+func staleCloseTask(database *gorm.DB, task *models.Task, threshold string) error {
+	if err := CheckGatesBeforeClose(task.ID); err != nil {
+		return err
+	}
+	reason := fmt.Sprintf("Stale: no activity in %s", threshold)
+	models.RecordChange(database, task.ID, "status", task.Status, models.StatusClosed, "user")
+	models.RecordChange(database, task.ID, "close_reason", "", reason, "user")
+	models.RecordChange(database, task.ID, "resolution", "", models.ResolutionWontfix, "user")
+	task.CloseAs(models.ResolutionWontfix, reason)
+	return database.Save(task).Error
+}
+
+func runStale(cmd *cobra.Command, args []string) error {
+	switch staleAction {
+	case "", staleActionLabel, staleActionDowngrade:
+	case staleActionClosePrompt:
+		if IsJSONOutput() || !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("--action close-prompt requires an interactive terminal (use --action label or downgrade in scripts)")
+		}
+	default:
+		return fmt.Errorf("invalid action '%s': must be one of label, downgrade, close-prompt", staleAction)
+	}
+
+	threshold, err := parseDuration(staleThreshold)
+	if err != nil {
+		return err
+	}
+	database := db.GetDB()
+	now := time.Now()
+	tasks, err := findStaleTasks(database, now.Add(-threshold))
+	if err != nil {
+		return fmt.Errorf("failed to find stale tasks: database error: %w", err)
+	}
+
+	var changed []string
+	reader := bufio.NewReader(os.Stdin)
+	for i := range tasks {
+		task := &tasks[i]
+		var applied bool
+		switch staleAction {
+		case staleActionLabel:
+			applied, err = staleLabelTask(database, task)
+		case staleActionDowngrade:
+			applied, err = staleDowngradeTask(database, task)
+		case staleActionClosePrompt:
+			fmt.Printf("[%s] P%d %s (idle since %s)\n", task.ID, task.Priority, task.Title, lastActivity(database, *task).Format(models.DateFormat))
+			fmt.Print("Close as wontfix? (yes/no): ")
+			answer, _ := reader.ReadString('\n')
+			if strings.TrimSpace(strings.ToLower(answer)) != "yes" {
+				continue
+			}
+			if err := staleCloseTask(database, task, staleThreshold); err != nil {
+				fmt.Fprintf(os.Stderr, "Skipped %s: %v\n", task.ID, err)
+				continue
+			}
+			applied = true
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update stale task '%s': database error: %w", task.ID, err)
+		}
+		if applied {
+			changed = append(changed, task.ID)
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"threshold": staleThreshold,
+			"action":    staleAction,
+			"stale":     tasks,
+			"changed":   changed,
+		})
+		return nil
+	}
+
+	if len(tasks) == 0 {
+		fmt.Printf("No in-progress tasks idle for %s.\n", staleThreshold)
+		return nil
+	}
+	if staleAction != staleActionClosePrompt {
+		fmt.Printf("Stale in-progress tasks (no activity in %s):\n", staleThreshold)
+		for _, t := range tasks {
+			fmt.Printf("  [%s] P%d %s (idle since %s)\n", t.ID, t.Priority, t.Title, lastActivity(database, t).Format(models.DateFormat))
+		}
+	}
+	switch staleAction {
+	case "":
+		fmt.Printf("\n%d stale task(s). Use --action label, downgrade or close-prompt to handle them.\n", len(tasks))
+	case staleActionLabel:
+		fmt.Printf("\nLabeled %d of %d stale task(s) '%s'.\n", len(changed), len(tasks), staleLabel)
+	case staleActionDowngrade:
+		fmt.Printf("\nDowngraded %d of %d stale task(s).\n", len(changed), len(tasks))
+	case staleActionClosePrompt:
+		fmt.Printf("\nClosed %d of %d stale task(s).\n", len(changed), len(tasks))
+	}
+	return nil
+}
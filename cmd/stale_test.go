@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestFindStaleTasks(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	now := time.Now()
+	old := now.Add(-30 * 24 * time.Hour)
+
+	database.Create(&models.Task{ID: "gur-stal0001", Title: "Abandoned", Status: models.StatusInProgress, Priority: 1})
+	database.Create(&models.Task{ID: "gur-stal0002", Title: "Active", Status: models.StatusInProgress})
+	database.Create(&models.Task{ID: "gur-stal0003", Title: "Open", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-stal0004", Title: "Just started", Status: models.StatusInProgress})
+	database.Exec("UPDATE tasks SET created_at = ? WHERE id IN ?", old, []string{"gur-stal0001", "gur-stal0002", "gur-stal0003"})
+	database.Create(&models.TaskHistory{TaskID: "gur-stal0001", Field: "status", NewValue: models.StatusInProgress, ChangedAt: old})
+	database.Create(&models.TaskHistory{TaskID: "gur-stal0002", Field: "title", NewValue: "Active"})
+
+	tasks, err := findStaleTasks(database, now.Add(-14*24*time.Hour))
+	if err != nil {
+		t.Fatalf("findStaleTasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "gur-stal0001" {
+		t.Fatalf("stale tasks = %v, want only gur-stal0001", tasks)
+	}
+	if got := lastActivity(database, tasks[0]); !got.Equal(old) {
+		t.Errorf("lastActivity = %v, want %v", got, old)
+	}
+
+	task := &tasks[0]
+	if applied, err := staleLabelTask(database, task); err != nil || !applied {
+		t.Fatalf("staleLabelTask = %v, %v", applied, err)
+	}
+	if applied, _ := staleLabelTask(database, task); applied {
+		t.Error("labeling an already stale task should be a no-op")
+	}
+	if applied, err := staleDowngradeTask(database, task); err != nil || !applied {
+		t.Fatalf("staleDowngradeTask = %v, %v", applied, err)
+	}
+
+	var saved models.Task
+	database.First(&saved, "id = ?", task.ID)
+	if !saved.HasLabel(staleLabel) || saved.Priority != 2 {
+		t.Errorf("saved task labels=%v priority=%d, want stale label and P2", saved.Labels, saved.Priority)
+	}
+
+	saved.Priority = models.PriorityLowest
+	if applied, _ := staleDowngradeTask(database, &saved); applied {
+		t.Error("downgrading a P4 task should be a no-op")
+	}
+}
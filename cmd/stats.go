@@ -2,25 +2,44 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 
 	"guardrails/internal/db"
 	"guardrails/internal/models"
 )
 
 var statsCmd = &cobra.Command{
-	Use:   "stats",
-	Short: "Show project statistics",
-	RunE:  runStats,
+	Use:         "stats",
+	Short:       "Show project statistics",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runStats,
 }
 
 func init() {
 	rootCmd.AddCommand(statsCmd)
 }
 
-func runStats(cmd *cobra.Command, args []string) error {
-	database := db.GetDB()
+// resolutionUnrecorded counts tasks closed before resolutions were recorded
+const resolutionUnrecorded = "unrecorded"
+
+// taskStats holds task counts by status and priority
+type taskStats struct {
+	Total        int64
+	Open         int64
+	InProgress   int64
+	Blocked      int64
+	Closed       int64
+	ByPriority   [models.PriorityLowest + 1]int64
+	ByResolution map[string]int64 // Closed and archived tasks by resolution
+}
+
+// collectTaskStats counts tasks by status and priority
+func collectTaskStats(database *gorm.DB) taskStats {
+	var stats taskStats
 
 	// Get status counts in a single query
 	type statusCount struct {
@@ -44,56 +63,228 @@ func runStats(cmd *cobra.Command, args []string) error {
 		Group("priority").
 		Scan(&priorityCounts)
 
-	// Build status map
-	var total, open, inProgress, closed int64
 	for _, sc := range statusCounts {
-		total += sc.Count
+		stats.Total += sc.Count
 		switch sc.Status {
 		case models.StatusOpen:
-			open = sc.Count
+			stats.Open = sc.Count
 		case models.StatusInProgress:
-			inProgress = sc.Count
+			stats.InProgress = sc.Count
+		case models.StatusBlocked:
+			stats.Blocked = sc.Count
 		case models.StatusClosed:
-			closed = sc.Count
+			stats.Closed = sc.Count
 		}
 	}
 
-	// Build priority map
-	var p0, p1, p2, p3, p4 int64
 	for _, pc := range priorityCounts {
-		switch pc.Priority {
-		case 0:
-			p0 = pc.Count
-		case 1:
-			p1 = pc.Count
-		case 2:
-			p2 = pc.Count
-		case 3:
-			p3 = pc.Count
-		case 4:
-			p4 = pc.Count
+		if pc.Priority >= 0 && pc.Priority < len(stats.ByPriority) {
+			stats.ByPriority[pc.Priority] = pc.Count
 		}
 	}
 
-	stats := map[string]interface{}{
-		"total":       total,
-		"open":        open,
-		"in_progress": inProgress,
-		"closed":      closed,
-		"by_priority": map[string]int64{"p0": p0, "p1": p1, "p2": p2, "p3": p3, "p4": p4},
+	// Get resolution counts of finished tasks in a single query
+	type resolutionCount struct {
+		Resolution string
+		Count      int64
 	}
+	var resolutionCounts []resolutionCount
+	database.Model(&models.Task{}).
+		Select("COALESCE(resolution, '') as resolution, count(*) as count").
+		Where("status IN ?", []string{models.StatusClosed, models.StatusArchived}).
+		Group("COALESCE(resolution, '')").
+		Scan(&resolutionCounts)
 
-	if IsJSONOutput() {
-		OutputJSON(stats)
-		return nil
+	stats.ByResolution = make(map[string]int64)
+	for _, rc := range resolutionCounts {
+		key := rc.Resolution
+		if key == "" {
+			key = resolutionUnrecorded
+		}
+		stats.ByResolution[key] += rc.Count
+	}
+
+	return stats
+}
+
+// Add accumulates another set of counts into s
+func (s *taskStats) Add(other taskStats) {
+	s.Total += other.Total
+	s.Open += other.Open
+	s.InProgress += other.InProgress
+	s.Blocked += other.Blocked
+	s.Closed += other.Closed
+	for i := range s.ByPriority {
+		s.ByPriority[i] += other.ByPriority[i]
+	}
+	if s.ByResolution == nil {
+		s.ByResolution = make(map[string]int64)
 	}
+	for r, c := range other.ByResolution {
+		s.ByResolution[r] += c
+	}
+}
 
-	fmt.Printf("Total tasks: %d\n\n", total)
+// ToMap returns the JSON representation used by stats commands
+func (s taskStats) ToMap() map[string]interface{} {
+	byPriority := make(map[string]int64, len(s.ByPriority))
+	for i, c := range s.ByPriority {
+		byPriority[fmt.Sprintf("p%d", i)] = c
+	}
+	return map[string]interface{}{
+		"total":         s.Total,
+		"open":          s.Open,
+		"in_progress":   s.InProgress,
+		"blocked":       s.Blocked,
+		"closed":        s.Closed,
+		"by_priority":   byPriority,
+		"by_resolution": s.ByResolution,
+	}
+}
+
+// Print writes the human-readable stats block
+func (s taskStats) Print() {
+	fmt.Printf("Total tasks: %d\n\n", s.Total)
 	fmt.Println("By status:")
-	fmt.Printf("  Open:        %d\n", open)
-	fmt.Printf("  In Progress: %d\n", inProgress)
-	fmt.Printf("  Closed:      %d\n", closed)
+	fmt.Printf("  Open:        %d\n", s.Open)
+	fmt.Printf("  In Progress: %d\n", s.InProgress)
+	fmt.Printf("  Blocked:     %d\n", s.Blocked)
+	fmt.Printf("  Closed:      %d\n", s.Closed)
 	fmt.Println("\nBy priority:")
-	fmt.Printf("  P0: %d  P1: %d  P2: %d  P3: %d  P4: %d\n", p0, p1, p2, p3, p4)
+	p := s.ByPriority
+	fmt.Printf("  P0: %d  P1: %d  P2: %d  P3: %d  P4: %d\n", p[0], p[1], p[2], p[3], p[4])
+
+	var finished int64
+	for _, c := range s.ByResolution {
+		finished += c
+	}
+	if finished == 0 {
+		return
+	}
+	fmt.Println("\nBy resolution (closed and archived):")
+	for _, r := range append(append([]string{}, models.Resolutions...), resolutionUnrecorded) {
+		if c := s.ByResolution[r]; c > 0 {
+			fmt.Printf("  %-12s %d (%.0f%%)\n", r+":", c, float64(c)*100/float64(finished))
+		}
+	}
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+	stats := collectTaskStats(database)
+	byKind, err := collectAssigneeKindStats(database)
+	if err != nil {
+		return fmt.Errorf("failed to count tasks by assignee kind: database error: %w", err)
+	}
+
+	byLabel, err := collectLabelStats(database)
+	if err != nil {
+		return fmt.Errorf("failed to count tasks by label: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		result := stats.ToMap()
+		result["by_assignee_kind"] = byKind
+		result["by_label"] = byLabel.Labels
+		result["by_label_namespace"] = byLabel.Namespaces
+		OutputJSON(result)
+		return nil
+	}
+
+	stats.Print()
+	printAssigneeKindStats(byKind)
+	byLabel.Print()
 	return nil
 }
+
+// labelStats counts unfinished tasks (open, in progress or blocked) by
+// label and by label namespace
+type labelStats struct {
+	Labels     map[string]int64
+	Namespaces map[string]int64 // Tasks with any label in the namespace
+}
+
+// collectLabelStats counts unfinished tasks by label
+func collectLabelStats(database *gorm.DB) (labelStats, error) {
+	stats := labelStats{Labels: make(map[string]int64), Namespaces: make(map[string]int64)}
+	var sets []models.StringSlice
+	err := database.Model(&models.Task{}).
+		Where("status IN ? AND labels IS NOT NULL AND labels != ''", []string{models.StatusOpen, models.StatusInProgress, models.StatusBlocked}).
+		Pluck("labels", &sets).Error
+	if err != nil {
+		return stats, err
+	}
+	for _, set := range sets {
+		namespaces := make(map[string]bool)
+		for _, l := range set {
+			stats.Labels[l]++
+			if ns, _ := models.SplitLabel(l); ns != "" {
+				namespaces[ns] = true
+			}
+		}
+		for ns := range namespaces {
+			stats.Namespaces[ns]++
+		}
+	}
+	return stats, nil
+}
+
+// Print writes label counts grouped by namespace, most used first, with
+// labels outside any namespace last
+func (s labelStats) Print() {
+	if len(s.Labels) == 0 {
+		return
+	}
+	groups := make(map[string][]string)
+	for l := range s.Labels {
+		ns, _ := models.SplitLabel(l)
+		groups[ns] = append(groups[ns], l)
+	}
+	namespaces := make([]string, 0, len(groups))
+	for ns := range groups {
+		if ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+	if _, ok := groups[""]; ok {
+		namespaces = append(namespaces, "")
+	}
+
+	fmt.Println("\nBy label (unfinished tasks):")
+	for _, ns := range namespaces {
+		labels := groups[ns]
+		sort.Slice(labels, func(i, j int) bool {
+			if s.Labels[labels[i]] != s.Labels[labels[j]] {
+				return s.Labels[labels[i]] > s.Labels[labels[j]]
+			}
+			return labels[i] < labels[j]
+		})
+		parts := make([]string, len(labels))
+		for i, l := range labels {
+			_, name := models.SplitLabel(l)
+			parts[i] = fmt.Sprintf("%s %d", name, s.Labels[l])
+		}
+		heading := "(none)"
+		if ns != "" {
+			heading = fmt.Sprintf("%s/ (%d)", ns, s.Namespaces[ns])
+		}
+		fmt.Printf("  %-16s %s\n", heading, strings.Join(parts, ", "))
+	}
+}
+
+// printAssigneeKindStats writes open/closed counts for human and agent work,
+// once someone is registered with 'gur people'
+func printAssigneeKindStats(byKind map[string]kindCounts) {
+	if _, ok := byKind[models.PersonKindHuman]; !ok {
+		if _, ok := byKind[models.PersonKindAgent]; !ok {
+			return
+		}
+	}
+	fmt.Println("\nBy assignee kind:")
+	for _, k := range []string{models.PersonKindHuman, models.PersonKindAgent, assigneeKindUnregistered, assigneeKindUnassigned} {
+		if c, ok := byKind[k]; ok {
+			fmt.Printf("  %-13s %d open, %d closed\n", k+":", c.Open, c.Closed)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// calibrationDimensions are the groupings 'stats calibration --by' accepts
+var calibrationDimensions = []string{"type", "label", "assignee"}
+
+var (
+	calibrationBy    string
+	calibrationSince string
+)
+
+var statsCalibrationCmd = &cobra.Command{
+	Use:         "calibration",
+	Short:       "Compare estimated and logged effort on closed tasks",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Compare estimates with logged time on closed tasks, grouped by type,
+label and assignee.
+
+Only tasks with both an estimate ('gur update --estimate') and logged time
+('gur time log') count. Ratio is logged / estimated effort: above 1 means
+work takes longer than estimated, so multiply future estimates by it.
+Error is the mean absolute variance per task.
+
+Examples:
+  gur stats calibration
+  gur stats calibration --by assignee --since 90d`,
+	Args: cobra.NoArgs,
+	RunE: runStatsCalibration,
+}
+
+func init() {
+	statsCmd.AddCommand(statsCalibrationCmd)
+	statsCalibrationCmd.Flags().StringVar(&calibrationBy, "by", "", "Group only by type, label or assignee (default: all)")
+	statsCalibrationCmd.Flags().StringVar(&calibrationSince, "since", "", "Only tasks closed within this window (e.g., 30d, 12w)")
+}
+
+// calibrationGroup aggregates estimate accuracy for one group of tasks
+type calibrationGroup struct {
+	Key       string  `json:"key"`
+	Tasks     int     `json:"tasks"`
+	Estimated int     `json:"estimated_minutes"`
+	Logged    int     `json:"logged_minutes"`
+	Ratio     float64 `json:"ratio"`          // Logged / estimated
+	MeanError float64 `json:"mean_error_pct"` // Mean absolute variance per task
+
+	errorSum float64
+}
+
+func (g *calibrationGroup) add(estimate, logged int) {
+	g.Tasks++
+	g.Estimated += estimate
+	g.Logged += logged
+	g.errorSum += math.Abs(effortVariance(estimate, logged))
+	g.Ratio = float64(g.Logged) / float64(g.Estimated)
+	g.MeanError = g.errorSum / float64(g.Tasks)
+}
+
+// calibrationReport holds the overall and per-dimension groups
+type calibrationReport struct {
+	Overall calibrationGroup              `json:"overall"`
+	Groups  map[string][]calibrationGroup `json:"groups"`
+}
+
+// buildCalibration aggregates closed tasks with estimates and logged time,
+// closed at or after since (zero for all)
+func buildCalibration(database *gorm.DB, dimensions []string, since time.Time) (*calibrationReport, error) {
+	query := database.Where("status IN ? AND estimate > 0", []string{models.StatusClosed, models.StatusArchived})
+	if !since.IsZero() {
+		query = query.Where("closed_at >= ?", since)
+	}
+	var tasks []models.Task
+	if err := query.Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	logged, err := loggedMinutes(database, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &calibrationReport{Overall: calibrationGroup{Key: "all"}, Groups: make(map[string][]calibrationGroup)}
+	groups := make(map[string]map[string]*calibrationGroup)
+	addTo := func(dim, key string, estimate, actual int) {
+		if groups[dim] == nil {
+			groups[dim] = make(map[string]*calibrationGroup)
+		}
+		g := groups[dim][key]
+		if g == nil {
+			g = &calibrationGroup{Key: key}
+			groups[dim][key] = g
+		}
+		g.add(estimate, actual)
+	}
+	for _, t := range tasks {
+		actual := logged[t.ID]
+		if actual == 0 {
+			continue
+		}
+		report.Overall.add(t.Estimate, actual)
+		for _, dim := range dimensions {
+			switch dim {
+			case "type":
+				addTo(dim, t.Type, t.Estimate, actual)
+			case "label":
+				for _, l := range t.Labels {
+					addTo(dim, l, t.Estimate, actual)
+				}
+			case "assignee":
+				key := t.Assignee
+				if key == "" {
+					key = "(unassigned)"
+				}
+				addTo(dim, key, t.Estimate, actual)
+			}
+		}
+	}
+
+	for dim, byKey := range groups {
+		list := make([]calibrationGroup, 0, len(byKey))
+		for _, g := range byKey {
+			list = append(list, *g)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Tasks != list[j].Tasks {
+				return list[i].Tasks > list[j].Tasks
+			}
+			return list[i].Key < list[j].Key
+		})
+		report.Groups[dim] = list
+	}
+	return report, nil
+}
+
+func runStatsCalibration(cmd *cobra.Command, args []string) error {
+	dimensions := calibrationDimensions
+	if calibrationBy != "" {
+		valid := false
+		for _, d := range calibrationDimensions {
+			valid = valid || d == calibrationBy
+		}
+		if !valid {
+			return fmt.Errorf("invalid --by '%s': must be one of type, label, assignee", calibrationBy)
+		}
+		dimensions = []string{calibrationBy}
+	}
+	var since time.Time
+	if calibrationSince != "" {
+		window, err := parseDuration(calibrationSince)
+		if err != nil {
+			return err
+		}
+		since = time.Now().Add(-window)
+	}
+
+	report, err := buildCalibration(db.GetDB(), dimensions, since)
+	if err != nil {
+		return fmt.Errorf("failed to build calibration report: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(report)
+		return nil
+	}
+	if report.Overall.Tasks == 0 {
+		fmt.Println("No closed tasks with both an estimate and logged time.")
+		fmt.Println("Set estimates with 'gur update <id> --estimate 3h' and log work with 'gur time log <id> 2h'.")
+		return nil
+	}
+
+	printRow := func(g calibrationGroup) {
+		fmt.Printf("  %-20s %5d %10s %10s %6.2fx %6.0f%%\n", g.Key, g.Tasks,
+			models.FormatEffort(g.Estimated), models.FormatEffort(g.Logged), g.Ratio, g.MeanError)
+	}
+	fmt.Printf("Estimate calibration (%d closed task(s))\n\n", report.Overall.Tasks)
+	fmt.Printf("  %-20s %5s %10s %10s %7s %7s\n", "", "Tasks", "Estimated", "Logged", "Ratio", "Error")
+	printRow(report.Overall)
+	for _, dim := range dimensions {
+		if len(report.Groups[dim]) == 0 {
+			continue
+		}
+		fmt.Printf("\nBy %s:\n", dim)
+		for _, g := range report.Groups[dim] {
+			printRow(g)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// Suggestion tuning
+const (
+	suggestMinScore  = 0.1 // Candidates scoring below this are not suggested
+	suggestNameBonus = 0.3 // Added when the candidate's name appears in the task
+)
+
+var (
+	suggestLimit int
+	suggestLink  bool
+)
+
+// suggestStopWords are ignored when matching task text against descriptions
+var suggestStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"that": true, "this": true, "are": true, "was": true, "not": true, "all": true,
+	"use": true, "using": true, "add": true, "fix": true, "make": true, "when": true,
+	"task": true, "agent": true, "skill": true, "tool": true, "tools": true,
+}
+
+// suggestion is a recommended skill or agent for a task
+type suggestion struct {
+	Kind       string   `json:"kind"` // "skill" or "agent"
+	Name       string   `json:"name"`
+	Confidence float64  `json:"confidence"`
+	Matched    []string `json:"matched,omitempty"`
+}
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest <task-id>",
+	Short: "Suggest skills and agents to link to a task",
+	Long: `Recommend registered skills and agents for a task by matching keywords
+in the task's title, description, and labels against skill descriptions
+and agent descriptions/capabilities.
+
+Examples:
+  gur suggest gur-abc123
+  gur suggest gur-abc123 --link     # Link the top suggestions
+  gur create "Add e2e tests" --suggest`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSuggest,
+}
+
+func init() {
+	rootCmd.AddCommand(suggestCmd)
+	suggestCmd.Flags().IntVarP(&suggestLimit, "limit", "n", 3, "Maximum suggestions per kind")
+	suggestCmd.Flags().BoolVar(&suggestLink, "link", false, "Link the suggested skills and agents to the task")
+}
+
+// suggestTokens splits text into lowercase keywords, dropping short words and
+// stop words and stripping a trailing plural "s" or "ing"
+func suggestTokens(text string) map[string]bool {
+	tokens := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if len(w) > 6 && strings.HasSuffix(w, "ing") {
+			w = strings.TrimSuffix(w, "ing")
+		} else if len(w) > 4 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		if len(w) < 3 || suggestStopWords[w] {
+			continue
+		}
+		tokens[w] = true
+	}
+	return tokens
+}
+
+// scoreSuggestion rates how well a candidate matches the task tokens.
+// The score is the Dice coefficient of the keyword sets, plus a bonus when
+// the candidate's name itself appears in the task, capped at 1.
+func scoreSuggestion(taskTokens map[string]bool, name, text string) (float64, []string) {
+	candTokens := suggestTokens(name + " " + text)
+	if len(taskTokens) == 0 || len(candTokens) == 0 {
+		return 0, nil
+	}
+
+	var matched []string
+	for tok := range candTokens {
+		if taskTokens[tok] {
+			matched = append(matched, tok)
+		}
+	}
+	sort.Strings(matched)
+
+	score := 2 * float64(len(matched)) / float64(len(taskTokens)+len(candTokens))
+	nameTokens := suggestTokens(name)
+	if len(nameTokens) > 0 {
+		allFound := true
+		for tok := range nameTokens {
+			if !taskTokens[tok] {
+				allFound = false
+				break
+			}
+		}
+		if allFound {
+			score += suggestNameBonus
+		}
+	}
+	if score > 1 {
+		score = 1
+	}
+	return score, matched
+}
+
+// rankSuggestions scores skills and agents against a task and returns the
+// best candidates of each kind, highest confidence first
+func rankSuggestions(task models.Task, skills []models.Skill, agents []models.Agent, limit int) []suggestion {
+	taskTokens := suggestTokens(task.Title + " " + task.Description + " " + strings.Join(task.Labels, " "))
+
+	top := func(candidates []suggestion) []suggestion {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].Confidence != candidates[j].Confidence {
+				return candidates[i].Confidence > candidates[j].Confidence
+			}
+			return candidates[i].Name < candidates[j].Name
+		})
+		if limit > 0 && len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+		return candidates
+	}
+
+	var skillSuggestions []suggestion
+	for _, s := range skills {
+		score, matched := scoreSuggestion(taskTokens, s.Name, s.Description)
+		if score >= suggestMinScore {
+			skillSuggestions = append(skillSuggestions, suggestion{Kind: "skill", Name: s.Name, Confidence: score, Matched: matched})
+		}
+	}
+
+	var agentSuggestions []suggestion
+	for _, a := range agents {
+		score, matched := scoreSuggestion(taskTokens, a.Name, a.Description+" "+a.Capabilities)
+		if score >= suggestMinScore {
+			agentSuggestions = append(agentSuggestions, suggestion{Kind: "agent", Name: a.Name, Confidence: score, Matched: matched})
+		}
+	}
+
+	return append(top(skillSuggestions), top(agentSuggestions)...)
+}
+
+// suggestForTask loads registered skills and agents not yet linked to the task and ranks them
+func suggestForTask(task models.Task, limit int) ([]suggestion, error) {
+	database := db.GetDB()
+
+	var skills []models.Skill
+	if err := database.Where("id NOT IN (?)", database.Model(&models.TaskSkillLink{}).Select("skill_id").Where("task_id = ?", task.ID)).
+		Find(&skills).Error; err != nil {
+		return nil, err
+	}
+	var agents []models.Agent
+	if err := database.Where("id NOT IN (?)", database.Model(&models.TaskAgentLink{}).Select("agent_id").Where("task_id = ?", task.ID)).
+		Find(&agents).Error; err != nil {
+		return nil, err
+	}
+
+	return rankSuggestions(task, skills, agents, limit), nil
+}
+
+// linkSuggestions links suggested skills and agents to the task
+func linkSuggestions(task models.Task, suggestions []suggestion) {
+	database := db.GetDB()
+	for _, s := range suggestions {
+		var err error
+		switch s.Kind {
+		case "skill":
+			var skill models.Skill
+			if err = database.Where("name = ?", s.Name).First(&skill).Error; err == nil {
+				err = database.Create(&models.TaskSkillLink{TaskID: task.ID, SkillID: skill.ID}).Error
+			}
+		case "agent":
+			var agent models.Agent
+			if err = database.Where("name = ?", s.Name).First(&agent).Error; err == nil {
+				err = database.Create(&models.TaskAgentLink{TaskID: task.ID, AgentID: agent.ID}).Error
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to link %s %s: %v\n", s.Kind, s.Name, err)
+		}
+	}
+}
+
+// printSuggestions writes suggestions in human-readable form
+func printSuggestions(suggestions []suggestion) {
+	if len(suggestions) == 0 {
+		fmt.Println("No matching skills or agents found.")
+		return
+	}
+	fmt.Println("Suggested links:")
+	for _, s := range suggestions {
+		fmt.Printf("  %-5s %-24s %3.0f%%", s.Kind, s.Name, s.Confidence*100)
+		if len(s.Matched) > 0 {
+			fmt.Printf("  (matched: %s)", strings.Join(s.Matched, ", "))
+		}
+		fmt.Println()
+	}
+}
+
+func runSuggest(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot suggest links: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	suggestions, err := suggestForTask(*task, suggestLimit)
+	if err != nil {
+		return fmt.Errorf("failed to compute suggestions for task '%s': database error: %w", task.ID, err)
+	}
+
+	if suggestLink {
+		linkSuggestions(*task, suggestions)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"task_id": task.ID, "linked": suggestLink, "suggestions": suggestions})
+		return nil
+	}
+
+	printSuggestions(suggestions)
+	if suggestLink && len(suggestions) > 0 {
+		fmt.Printf("Linked %d suggestion(s) to %s\n", len(suggestions), task.ID)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/models"
+)
+
+func TestSuggestTokens(t *testing.T) {
+	got := suggestTokens("Add the Playwright tests for login-flow, testing e2e")
+	for _, want := range []string{"playwright", "test", "login", "flow", "e2e"} {
+		if !got[want] {
+			t.Errorf("suggestTokens() missing %q in %v", want, got)
+		}
+	}
+	for _, unwanted := range []string{"add", "the", "for", "tests", "testing"} {
+		if got[unwanted] {
+			t.Errorf("suggestTokens() should not contain %q", unwanted)
+		}
+	}
+}
+
+func TestRankSuggestions(t *testing.T) {
+	task := models.Task{
+		Title:       "Add playwright e2e tests for login",
+		Description: "Cover the OAuth redirect",
+		Labels:      models.StringSlice{"frontend"},
+	}
+	skills := []models.Skill{
+		{Name: "e2e-testing", Description: "Write end-to-end browser tests with Playwright"},
+		{Name: "db-migrations", Description: "Author SQL schema migrations"},
+	}
+	agents := []models.Agent{
+		{Name: "Bash", Description: "Command execution specialist", Capabilities: "Bash commands, git operations"},
+		{Name: "frontend-dev", Description: "Builds UI", Capabilities: "React, browser testing"},
+	}
+
+	got := rankSuggestions(task, skills, agents, 3)
+	if len(got) != 2 {
+		t.Fatalf("rankSuggestions() returned %d suggestions, want 2: %+v", len(got), got)
+	}
+	if got[0].Kind != "skill" || got[0].Name != "e2e-testing" {
+		t.Errorf("first suggestion = %+v, want skill e2e-testing", got[0])
+	}
+	if got[1].Kind != "agent" || got[1].Name != "frontend-dev" {
+		t.Errorf("second suggestion = %+v, want agent frontend-dev", got[1])
+	}
+	for _, s := range got {
+		if s.Confidence <= 0 || s.Confidence > 1 {
+			t.Errorf("confidence for %s = %f, want (0, 1]", s.Name, s.Confidence)
+		}
+	}
+}
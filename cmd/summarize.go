@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+	"guardrails/internal/summarizer"
+)
+
+// llmTimeout bounds a single summarizer call
+const llmTimeout = 2 * time.Minute
+
+// llmSessionTaskLimit caps how many recent tasks are sent for a session summary
+const llmSessionTaskLimit = 50
+
+// llmTaskSummaries summarizes each task's description and notes with the
+// configured summarizer. Any failure aborts so no text is cleared without a
+// summary.
+func llmTaskSummaries(tasks []models.Task) (map[string]string, error) {
+	s, err := loadSummarizer()
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]string, len(tasks))
+	for i, t := range tasks {
+		if t.Description == "" && t.Notes == "" {
+			continue // Nothing beyond the title to summarize
+		}
+		if !IsJSONOutput() && len(tasks) > 1 {
+			fmt.Fprintf(os.Stderr, "Summarizing %d/%d: %s\n", i+1, len(tasks), t.ID)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), llmTimeout)
+		summary, err := s.Summarize(ctx, summarizer.TaskPrompt(summarizer.TaskInput{
+			Title:       t.Title,
+			Type:        t.Type,
+			Description: t.Description,
+			Notes:       t.Notes,
+			CloseReason: t.CloseReason,
+		}))
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize task '%s' (no tasks were compacted): %w", t.ID, err)
+		}
+		summaries[t.ID] = summary
+	}
+	return summaries, nil
+}
+
+// llmSessionSummary asks the summarizer for a narrative of the last 24 hours
+// of activity and the current high-priority work
+func llmSessionSummary(database *gorm.DB, since time.Time, highPriority []models.Task) (string, error) {
+	s, err := loadSummarizer()
+	if err != nil {
+		return "", err
+	}
+
+	var recent []models.Task
+	database.Where("created_at > ? OR updated_at > ? OR closed_at > ?", since, since, since).
+		Order("updated_at DESC").
+		Limit(llmSessionTaskLimit).
+		Find(&recent)
+
+	var sb strings.Builder
+	sb.WriteString("Write a short status report (3-6 sentences) of this work session for an engineer or AI agent picking it up next. ")
+	sb.WriteString("Say what was finished, what is in progress, and what should happen next. Reply with the report only.\n\n")
+	sb.WriteString("Recent activity:\n")
+	if len(recent) == 0 {
+		sb.WriteString("(none)\n")
+	}
+	for _, t := range recent {
+		fmt.Fprintf(&sb, "- [%s] %s (%s, P%d)", t.ID, t.Title, t.Status, t.Priority)
+		if t.CloseReason != "" {
+			fmt.Fprintf(&sb, " closed: %s", t.CloseReason)
+		}
+		if t.BlockReason != "" {
+			fmt.Fprintf(&sb, " blocked: %s", t.BlockReason)
+		}
+		if t.Summary != "" {
+			fmt.Fprintf(&sb, " summary: %s", t.Summary)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\nHigh-priority open work:\n")
+	if len(highPriority) == 0 {
+		sb.WriteString("(none)\n")
+	}
+	for _, t := range highPriority {
+		fmt.Fprintf(&sb, "- [%s] %s (%s, P%d)\n", t.ID, t.Title, t.Status, t.Priority)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), llmTimeout)
+	defer cancel()
+	return s.Summarize(ctx, sb.String())
+}
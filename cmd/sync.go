@@ -2,13 +2,20 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/google/go-github/v63/github"
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 
 	"guardrails/internal/db"
 	"guardrails/internal/models"
@@ -32,18 +39,42 @@ var syncPushCmd = &cobra.Command{
 If no task ID is provided, pushes all open tasks that haven't been synced yet.
 If a task ID is provided, pushes or updates that specific task.
 
-Tasks that have already been synced will be updated on GitHub.
+Tasks that have already been synced will be updated on GitHub, unless
+their title, body, state and labels are unchanged since the last push; use
+--force to update them anyway (e.g. after editing an issue on GitHub).
 New tasks will create new GitHub issues.
 
-The issue title will be prefixed with the configured prefix (default: "[Coding Agent]").`,
+Each push records in the issue when this machine pushed it. If another
+machine pushed the issue since this one last pushed or pulled it, the push
+is refused rather than silently overwriting those changes: run 'gur sync
+pull' to merge them, or pass --force-push to overwrite them.
+
+The issue title will be prefixed with the configured prefix (default: "[Coding Agent]").
+
+With --mention-owners, owners of the task's pending gates (see 'gur gate
+owner') are @mentioned in an issue comment; owners aren't mentioned again
+until the gates awaiting them change.
+
+Tasks are pushed highest priority first, most recently updated first within
+a priority. With --limit N only the first N are pushed, to stay within a
+tight API quota; the rest are reported as deferred and are pushed by the
+next push.
+
+On Ctrl+C (or SIGTERM) the task being pushed is finished before stopping, and
+the remaining tasks are saved; run 'gur sync push --resume' to continue.`,
 	RunE: runSyncPush,
 }
 
 var (
-	syncPushAll    bool
-	syncPushOpen   bool
-	syncPushClosed bool
-	syncPushDryRun bool
+	syncPushAll       bool
+	syncPushOpen      bool
+	syncPushClosed    bool
+	syncPushDryRun    bool
+	syncPushResume    bool
+	syncPushMention   bool
+	syncPushForce     bool
+	syncPushOverwrite bool
+	syncPushLimit     int
 )
 
 func init() {
@@ -54,42 +85,85 @@ func init() {
 	syncPushCmd.Flags().BoolVar(&syncPushOpen, "open", false, "Push only open tasks")
 	syncPushCmd.Flags().BoolVar(&syncPushClosed, "closed", false, "Push only closed tasks")
 	syncPushCmd.Flags().BoolVar(&syncPushDryRun, "dry-run", false, "Show what would be pushed without actually pushing")
+	syncPushCmd.Flags().BoolVar(&syncPushResume, "resume", false, "Push the tasks left over from an interrupted push")
+	syncPushCmd.Flags().BoolVar(&syncPushForce, "force", false, "Update issues even if unchanged since the last push")
+	syncPushCmd.Flags().BoolVar(&syncPushOverwrite, "force-push", false, "Overwrite issues pushed from another machine since this one last synced them")
+	syncPushCmd.Flags().IntVar(&syncPushLimit, "limit", 0, "Push only the first N tasks in priority order and defer the rest (0 = no limit)")
+	syncPushCmd.Flags().BoolVar(&syncPushMention, "mention-owners", false, "Comment to @mention owners of gates awaiting verification ('gur gate owner')")
 }
 
-func runSyncPush(cmd *cobra.Command, args []string) error {
-	// Get GitHub configuration
+// githubPushTarget returns a client for the configured repository, with
+// the repository's owner and name and the issue title prefix
+func githubPushTarget() (client *github.Client, owner, repoName, prefix string, err error) {
 	repo, err := db.GetConfig(models.ConfigGitHubRepo)
 	if err != nil || repo == "" {
-		return fmt.Errorf("GitHub sync not configured: repository not set (run 'gur config github' to configure)")
+		return nil, "", "", "", codedErrorf(ErrCodeNotConfigured, "GitHub sync not configured: repository not set (run 'gur config github' to configure)")
 	}
 
-	prefix, err := db.GetConfig(models.ConfigGitHubIssuePrefix)
+	prefix, err = db.GetConfig(models.ConfigGitHubIssuePrefix)
 	if err != nil || prefix == "" {
 		prefix = models.DefaultGitHubIssuePrefix
 	}
 
 	token, err := GetGitHubToken()
 	if err != nil {
-		return err
+		return nil, "", "", "", err
 	}
 
 	// Parse owner/repo
 	parts := strings.SplitN(repo, "/", 2)
 	if len(parts) != 2 {
-		return fmt.Errorf("invalid repository format '%s': expected 'owner/repo' (run 'gur config github' to reconfigure)", repo)
+		return nil, "", "", "", fmt.Errorf("invalid repository format '%s': expected 'owner/repo' (run 'gur config github' to reconfigure)", repo)
+	}
+
+	// Create GitHub client (github.com or the configured Enterprise server)
+	client, err = newGitHubClient(token)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+	return client, parts[0], parts[1], prefix, nil
+}
+
+// orderForPush sorts tasks for pushing: highest priority first, then most
+// recently updated
+func orderForPush(tasks []models.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority < tasks[j].Priority
+		}
+		if !tasks[i].UpdatedAt.Equal(tasks[j].UpdatedAt) {
+			return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// deferredIDs returns the IDs of tasks left out by --limit
+func deferredIDs(tasks []models.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// printDeferred reports the tasks left out by --limit
+func printDeferred(deferred []string) {
+	if len(deferred) == 0 {
+		return
 	}
-	owner, repoName := parts[0], parts[1]
+	fmt.Printf("Deferred %d task(s) by --limit %d (run 'gur sync push' again to push them): %s\n",
+		len(deferred), syncPushLimit, strings.Join(deferred, ", "))
+}
 
-	// Create GitHub client with connection pooling
-	httpClient := &http.Client{
-		Timeout: githubAPITimeout,
-		Transport: &http.Transport{
-			MaxIdleConns:        100,
-			MaxIdleConnsPerHost: 10,
-			IdleConnTimeout:     90 * time.Second,
-		},
+func runSyncPush(cmd *cobra.Command, args []string) error {
+	if syncPushLimit < 0 {
+		return fmt.Errorf("--limit cannot be negative")
+	}
+	client, owner, repoName, prefix, err := githubPushTarget()
+	if err != nil {
+		return err
 	}
-	client := github.NewClient(httpClient).WithAuthToken(token)
 
 	// Create context with timeout for the entire sync operation
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
@@ -99,16 +173,27 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 
 	// Determine which tasks to push
 	var tasks []models.Task
-	if len(args) > 0 {
+	if syncPushResume {
+		checkpoint, err := loadSyncCheckpoint("push")
+		if err != nil {
+			return err
+		}
+		if err := database.Where("id IN ?", checkpoint.Remaining).Find(&tasks).Error; err != nil {
+			return err
+		}
+	} else if len(args) > 0 {
 		// Push specific task
 		task, err := db.GetTaskByID(args[0])
 		if err != nil {
-			return fmt.Errorf("cannot sync task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+			return codedErrorf(ErrCodeNotFound, "cannot sync task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+		}
+		if task.Status == models.StatusInbox {
+			return fmt.Errorf("cannot sync task '%s': it is still in the inbox (promote it with 'gur inbox triage' first)", task.ID)
 		}
 		tasks = append(tasks, *task)
 	} else if syncPushAll {
-		// Push all tasks (open and closed, excluding archived)
-		if err := database.Where("status != ?", models.StatusArchived).
+		// Push all tasks (open and closed, excluding archived and inbox items)
+		if err := database.Where("status NOT IN ?", []string{models.StatusArchived, models.StatusInbox}).
 			Where("synced = ?", false).
 			Find(&tasks).Error; err != nil {
 			return err
@@ -122,14 +207,14 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 		}
 	} else if syncPushOpen {
 		// Push only open tasks
-		if err := database.Where("status NOT IN ?", []string{models.StatusArchived, models.StatusClosed}).
+		if err := database.Where("status NOT IN ?", []string{models.StatusArchived, models.StatusClosed, models.StatusInbox}).
 			Where("synced = ?", false).
 			Find(&tasks).Error; err != nil {
 			return err
 		}
 	} else {
 		// Default: push unsynced open tasks (same as --open)
-		if err := database.Where("status NOT IN ?", []string{models.StatusArchived, models.StatusClosed}).
+		if err := database.Where("status NOT IN ?", []string{models.StatusArchived, models.StatusClosed, models.StatusInbox}).
 			Where("synced = ?", false).
 			Find(&tasks).Error; err != nil {
 			return err
@@ -137,6 +222,9 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(tasks) == 0 {
+		if syncPushResume {
+			clearSyncCheckpoint("push")
+		}
 		if IsJSONOutput() {
 			OutputJSON(map[string]interface{}{"success": true, "synced": 0, "message": "No tasks to sync"})
 		} else {
@@ -145,24 +233,98 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	orderForPush(tasks)
+	var deferred []string
+	if syncPushLimit > 0 && len(tasks) > syncPushLimit {
+		deferred = deferredIDs(tasks[syncPushLimit:])
+		tasks = tasks[:syncPushLimit]
+	}
+
+	if err := attachFieldValues(database, tasks); err != nil {
+		return err
+	}
+	if err := attachAcceptanceCriteria(database, tasks); err != nil {
+		return err
+	}
+
 	if syncPushDryRun {
 		if IsJSONOutput() {
-			OutputJSON(map[string]interface{}{"dry_run": true, "tasks": tasks})
+			result := map[string]interface{}{"dry_run": true, "tasks": tasks}
+			if len(deferred) > 0 {
+				result["deferred"] = deferred
+			}
+			OutputJSON(result)
 		} else {
 			fmt.Printf("Would push %d task(s):\n", len(tasks))
 			for _, t := range tasks {
 				fmt.Printf("  [%s] %s\n", t.ID, t.Title)
 			}
+			printDeferred(deferred)
 		}
 		return nil
 	}
 
+	// Check the token, repository access and rate limit up front so problems
+	// surface before any issue is touched
+	preflight, err := runGitHubPreflight(ctx, client, owner, repoName)
+	if err != nil {
+		return fmt.Errorf("GitHub preflight failed: %w", err)
+	}
+	hierarchy, err := loadIssueHierarchy()
+	if err != nil {
+		return err
+	}
+	calls := len(tasks) * pushCallsPerTask
+	if hierarchy != nil {
+		calls += 1 + len(tasks)*hierarchyCallsPerTask // Issue types, then per task
+	}
+	if err := preflight.requireRateBudget(calls); err != nil {
+		return err
+	}
+
+	bodyTmpl, err := loadIssueBodyTemplate()
+	if err != nil {
+		return err
+	}
+	labelMap, err := loadLabelMap()
+	if err != nil {
+		return err
+	}
+	if err := ensureGitHubLabels(ctx, client, owner, repoName, labelMap); err != nil {
+		return err
+	}
+	milestones, err := milestonesByDueDate(ctx, client, owner, repoName, tasks)
+	if err != nil {
+		return err
+	}
+	if err := hierarchy.loadIssueTypes(ctx, client, owner); err != nil {
+		return err
+	}
+	var gateOwners map[string][]string
+	if syncPushMention {
+		if gateOwners, err = loadGateOwners(database); err != nil {
+			return fmt.Errorf("failed to load gate owners: %w", err)
+		}
+	}
+
 	var results []map[string]interface{}
 	synced := 0
 	errors := 0
+	actions := make(map[string]int)
+
+	interrupt := watchSyncInterrupt()
+	defer interrupt.Stop()
+
+	var remaining []string
+	for i, task := range tasks {
+		if interrupt.Interrupted() {
+			for _, t := range tasks[i:] {
+				remaining = append(remaining, t.ID)
+			}
+			break
+		}
 
-	for _, task := range tasks {
-		result, err := syncTaskToGitHub(ctx, client, owner, repoName, prefix, task)
+		result, err := syncTaskToGitHub(ctx, client, owner, repoName, prefix, labelMap, milestones, bodyTmpl, hierarchy, task)
 		if err != nil {
 			errors++
 			result = map[string]interface{}{
@@ -174,60 +336,149 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 			}
 		} else {
 			synced++
+			actions[result["action"].(string)]++
 			if !IsJSONOutput() {
-				fmt.Printf("Synced: %s -> %s\n", task.ID, result["issue_url"])
+				if result["action"] == "skipped" {
+					fmt.Printf("Unchanged: %s -> %s\n", task.ID, result["issue_url"])
+				} else {
+					fmt.Printf("Synced: %s -> %s\n", task.ID, result["issue_url"])
+				}
+			}
+			if len(gateOwners) > 0 {
+				mentioned, err := mentionGateOwners(ctx, database, client, owner, repoName, result["issue_number"].(int), task.ID, gateOwners)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", task.ID, err)
+				} else if len(mentioned) > 0 {
+					result["mentioned"] = mentioned
+					if !IsJSONOutput() {
+						fmt.Printf("  Mentioned %s\n", strings.Join(mentioned, ", "))
+					}
+				}
 			}
 		}
 		results = append(results, result)
 	}
 
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{
-			"success": errors == 0,
+		result := map[string]interface{}{
+			"success": errors == 0 && len(remaining) == 0,
 			"synced":  synced,
+			"created": actions["created"],
+			"updated": actions["updated"],
+			"skipped": actions["skipped"],
 			"errors":  errors,
 			"results": results,
-		})
-	} else if synced > 0 {
-		fmt.Printf("\nSynced %d task(s) to GitHub\n", synced)
-		if errors > 0 {
-			fmt.Printf("%d task(s) failed to sync\n", errors)
 		}
+		if len(remaining) > 0 {
+			result["interrupted"] = true
+			result["remaining"] = remaining
+		}
+		if len(deferred) > 0 {
+			result["deferred"] = deferred
+		}
+		OutputJSON(result)
+	} else {
+		if synced > 0 {
+			fmt.Printf("\nSynced %d task(s) to GitHub: %d created, %d updated, %d unchanged\n",
+				synced, actions["created"], actions["updated"], actions["skipped"])
+			if errors > 0 {
+				fmt.Printf("%d task(s) failed to sync\n", errors)
+			}
+		}
+		printDeferred(deferred)
 	}
 
+	if len(remaining) > 0 {
+		return finishInterruptedSync("push", synced+errors, remaining)
+	}
+	if syncPushResume || len(args) == 0 {
+		clearSyncCheckpoint("push")
+	}
 	return nil
 }
 
-func syncTaskToGitHub(ctx context.Context, client *github.Client, owner, repo, prefix string, task models.Task) (map[string]interface{}, error) {
+func syncTaskToGitHub(ctx context.Context, client *github.Client, owner, repo, prefix string, labelMap models.LabelMap, milestones map[string]int, bodyTmpl *template.Template, hierarchy *issueHierarchy, task models.Task) (map[string]interface{}, error) {
 	database := db.GetDB()
 
+	if hasConflictMarkers(task.Description) {
+		return nil, fmt.Errorf("description has unresolved conflicts from 'gur sync pull': edit the marked sections with 'gur update %s --description ...' first", task.ID)
+	}
+
 	// Check if task already has a GitHub issue
 	var link models.GitHubIssueLink
 	existingLink := database.Where("task_id = ?", task.ID).First(&link).Error == nil
 
 	// Build issue title and body
 	title := fmt.Sprintf("%s - %s", prefix, task.Title)
-	body := buildIssueBody(task)
+	body, err := renderIssueBody(database, bodyTmpl, task)
+	if err != nil {
+		return nil, err
+	}
+
+	userMap, err := loadUserMap()
+	if err != nil {
+		return nil, err
+	}
+	login, _ := userMap.ToGitHub(task.Assignee)
+
+	state := mapStatusToGitHub(task.Status)
+	milestone, hasMilestone := milestones[task.DueString()]
+	hash := issueContentHash(title, body, state, models.ResolutionStateReason(task.Resolution), milestone, labelMap.LabelsForTask(task), login)
 
 	if existingLink {
+		// Nothing to send if the issue already has this content
+		if link.ContentHash == hash && !syncPushForce && hierarchy.inSync(database, link, task) {
+			return map[string]interface{}{
+				"task_id":      task.ID,
+				"issue_number": link.IssueNumber,
+				"issue_url":    link.IssueURL,
+				"action":       "skipped",
+			}, nil
+		}
+
+		// Refuse to overwrite pushes from other machines this one hasn't seen
+		vector, err := checkSplitBrain(ctx, client, owner, repo, link, task, syncPushOverwrite)
+		if err != nil {
+			return nil, err
+		}
+		body += syncVectorMarker(vector)
+
 		// Update existing issue
-		state := mapStatusToGitHub(task.Status)
 		issueRequest := &github.IssueRequest{
 			Title: &title,
 			Body:  &body,
 			State: &state,
 		}
+		if state == "closed" {
+			stateReason := models.ResolutionStateReason(task.Resolution)
+			issueRequest.StateReason = &stateReason
+		}
+		if hasMilestone {
+			issueRequest.Milestone = &milestone
+		}
+		if assignees := issueAssignees(ctx, client, owner, repo, userMap, task); len(assignees) > 0 {
+			issueRequest.Assignees = &assignees
+		}
 
 		issue, _, err := client.Issues.Edit(ctx, owner, repo, link.IssueNumber, issueRequest)
 		if err != nil {
 			return nil, fmt.Errorf("failed to update issue: %w", err)
 		}
 
+		if err := syncMappedLabels(ctx, client, owner, repo, issue, labelMap, task); err != nil {
+			return nil, fmt.Errorf("failed to update labels: %w", err)
+		}
+
 		// Update link
 		link.LastSyncedAt = time.Now()
+		link.ContentHash = hash
+		link.SyncedBody, link.SyncedDesc = body, task.Description
+		link.SyncVector = vector.String()
+		link.IssueID = issue.GetID()
 		if err := database.Save(&link).Error; err != nil {
 			return nil, fmt.Errorf("failed to update link: %w", err)
 		}
+		hierarchy.apply(ctx, client, database, owner, repo, task, &link)
 
 		return map[string]interface{}{
 			"task_id":      task.ID,
@@ -237,27 +488,65 @@ func syncTaskToGitHub(ctx context.Context, client *github.Client, owner, repo, p
 		}, nil
 	}
 
+	// An earlier push may have stopped between creating the issue and
+	// linking it; finish that one rather than creating a duplicate
+	repoFull := fmt.Sprintf("%s/%s", owner, repo)
+	var pending models.GitHubPendingSync
+	if database.Where("task_id = ? AND repository = ?", task.ID, repoFull).Order("id DESC").First(&pending).Error == nil {
+		if pending.IssueNumber == 0 {
+			return nil, fmt.Errorf("an earlier push may have created an issue for this task without linking it (run 'gur sync reconcile' to find it)")
+		}
+		if err := adoptIssue(database, task.ID, repoFull, pending.IssueNumber, pending.IssueURL); err != nil {
+			return nil, err
+		}
+		return syncTaskToGitHub(ctx, client, owner, repo, prefix, labelMap, milestones, bodyTmpl, hierarchy, task)
+	}
+
 	// Create new issue
+	vector := models.SyncVector{syncMachineID(): task.UpdatedAt.UTC().Truncate(time.Second)}
+	body += syncVectorMarker(vector)
 	issueRequest := &github.IssueRequest{
 		Title: &title,
 		Body:  &body,
 	}
+	if hasMilestone {
+		issueRequest.Milestone = &milestone
+	}
+	if assignees := issueAssignees(ctx, client, owner, repo, userMap, task); len(assignees) > 0 {
+		issueRequest.Assignees = &assignees
+	}
 
-	// Add labels based on task type and priority
-	labels := buildLabels(task)
+	// Add labels based on task type, priority, and labels
+	labels := buildLabels(labelMap, task)
 	if len(labels) > 0 {
 		issueRequest.Labels = &labels
 	}
 
+	// Record the creation before making it, so an issue created without a
+	// link can be found and adopted later
+	pending = models.GitHubPendingSync{TaskID: task.ID, Repository: repoFull, Title: title}
+	if err := database.Create(&pending).Error; err != nil {
+		return nil, fmt.Errorf("failed to record pending sync: %w", err)
+	}
 	issue, _, err := client.Issues.Create(ctx, owner, repo, issueRequest)
 	if err != nil {
+		// GitHub answered with an error, so no issue was created; on a
+		// timeout or lost connection it may have been, so keep the record
+		var apiErr *github.ErrorResponse
+		if errors.As(err, &apiErr) {
+			database.Delete(&pending)
+		}
 		return nil, fmt.Errorf("failed to create issue: %w", err)
 	}
+	if err := database.Model(&pending).Updates(map[string]interface{}{"issue_number": issue.GetNumber(), "issue_url": issue.GetHTMLURL()}).Error; err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record issue #%d for %s: %v\n", issue.GetNumber(), task.ID, err)
+	}
 
 	// If task is closed, close the issue immediately
 	if task.IsClosed() {
 		state := "closed"
-		closeRequest := &github.IssueRequest{State: &state}
+		stateReason := models.ResolutionStateReason(task.Resolution)
+		closeRequest := &github.IssueRequest{State: &state, StateReason: &stateReason}
 		issue, _, err = client.Issues.Edit(ctx, owner, repo, issue.GetNumber(), closeRequest)
 		if err != nil {
 			return nil, fmt.Errorf("failed to close issue: %w", err)
@@ -269,17 +558,32 @@ func syncTaskToGitHub(ctx context.Context, client *github.Client, owner, repo, p
 		TaskID:       task.ID,
 		IssueNumber:  issue.GetNumber(),
 		IssueURL:     issue.GetHTMLURL(),
-		Repository:   fmt.Sprintf("%s/%s", owner, repo),
+		Repository:   repoFull,
 		LastSyncedAt: time.Now(),
+		ContentHash:  hash,
+		SyncedBody:   body,
+		SyncedDesc:   task.Description,
+		SyncVector:   vector.String(),
+		IssueID:      issue.GetID(),
 	}
-	if err := database.Create(&newLink).Error; err != nil {
-		return nil, fmt.Errorf("failed to save link: %w", err)
-	}
-
-	// Mark task as synced
-	if err := database.Model(&models.Task{}).Where("id = ?", task.ID).Update("synced", true).Error; err != nil {
-		return nil, fmt.Errorf("failed to mark task as synced: %w", err)
+	// Save the link and synced flag and clear the pending record together,
+	// so the task is either fully linked to the new issue or left for the
+	// next push (which adopts the issue)
+	if err := database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newLink).Error; err != nil {
+			return fmt.Errorf("failed to save link: %w", err)
+		}
+		if err := tx.Model(&models.Task{}).Where("id = ?", task.ID).Update("synced", true).Error; err != nil {
+			return fmt.Errorf("failed to mark task as synced: %w", err)
+		}
+		if err := tx.Where("task_id = ? AND repository = ?", task.ID, repoFull).Delete(&models.GitHubPendingSync{}).Error; err != nil {
+			return fmt.Errorf("failed to clear pending sync: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("%w (issue #%d was created on GitHub; the next push will link it)", err, issue.GetNumber())
 	}
+	hierarchy.apply(ctx, client, database, owner, repo, task, &newLink)
 
 	return map[string]interface{}{
 		"task_id":      task.ID,
@@ -289,6 +593,11 @@ func syncTaskToGitHub(ctx context.Context, client *github.Client, owner, repo, p
 	}, nil
 }
 
+// issueBodyFooter ends every built-in issue body
+const issueBodyFooter = "*Synced from [GuardRails](https://github.com/Giancarlos/GuardRails) task management*"
+
+// buildIssueBody renders the built-in issue body; see renderIssueBody for
+// project templates
 func buildIssueBody(task models.Task) string {
 	var sb strings.Builder
 
@@ -307,6 +616,10 @@ func buildIssueBody(task models.Task) string {
 	sb.WriteString(fmt.Sprintf("| Type | %s |\n", task.Type))
 	sb.WriteString(fmt.Sprintf("| Status | %s |\n", task.Status))
 
+	if task.DueAt != nil {
+		sb.WriteString(fmt.Sprintf("| Due | %s |\n", task.DueString()))
+	}
+
 	if task.Assignee != "" {
 		sb.WriteString(fmt.Sprintf("| Assignee | %s |\n", task.Assignee))
 	}
@@ -315,8 +628,19 @@ func buildIssueBody(task models.Task) string {
 		sb.WriteString(fmt.Sprintf("| Labels | %s |\n", strings.Join(task.Labels, ", ")))
 	}
 
+	for _, name := range sortedFieldNames(task.Fields) {
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", name, task.Fields[name]))
+	}
+
 	sb.WriteString(fmt.Sprintf("| Created | %s |\n", task.CreatedAt.Format(models.DateTimeShortFormat)))
 
+	if len(task.Criteria) > 0 {
+		sb.WriteString("\n## Acceptance Criteria\n\n")
+		for _, c := range task.Criteria {
+			sb.WriteString(c.Checkbox() + "\n")
+		}
+	}
+
 	if task.Notes != "" {
 		sb.WriteString("\n## Notes\n\n")
 		sb.WriteString("```\n")
@@ -325,36 +649,109 @@ func buildIssueBody(task models.Task) string {
 	}
 
 	sb.WriteString("\n---\n")
-	sb.WriteString("*Synced from [GuardRails](https://github.com/Giancarlos/GuardRails) task management*")
+	sb.WriteString(issueBodyFooter)
 
 	return sb.String()
 }
 
-func buildLabels(task models.Task) []string {
-	var labels []string
+// milestonesByDueDate maps due dates to open milestones due that day, so
+// tasks can be placed in the milestone matching their deadline. It only
+// queries GitHub when some task has a due date.
+func milestonesByDueDate(ctx context.Context, client *github.Client, owner, repo string, tasks []models.Task) (map[string]int, error) {
+	milestones := make(map[string]int)
+	needed := false
+	for _, t := range tasks {
+		if t.DueAt != nil {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return milestones, nil
+	}
 
-	// Add type as label
-	switch task.Type {
-	case models.TypeBug:
-		labels = append(labels, "bug")
-	case models.TypeFeature:
-		labels = append(labels, "enhancement")
-	case models.TypeEpic:
-		labels = append(labels, "epic")
+	opts := &github.MilestoneListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list milestones: %w", err)
+		}
+		for _, m := range page {
+			if m.DueOn != nil {
+				milestones[m.GetDueOn().UTC().Format(models.DateFormat)] = m.GetNumber()
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
+	return milestones, nil
+}
 
-	// Add priority as label
-	switch task.Priority {
-	case models.PriorityCritical:
-		labels = append(labels, "priority: critical")
-	case models.PriorityHigh:
-		labels = append(labels, "priority: high")
+// agentCreatedLabel marks issues pushed from gur
+const agentCreatedLabel = "agent-created"
+
+// syncMappedLabels brings an existing issue's labels in line with the task,
+// diffing against the labels the issue already has: missing wanted labels
+// are added and stale type/priority/blocked labels removed. Labels added by
+// hand on GitHub are left alone.
+func syncMappedLabels(ctx context.Context, client *github.Client, owner, repo string, issue *github.Issue, labelMap models.LabelMap, task models.Task) error {
+	current := make(map[string]bool, len(issue.Labels))
+	for _, l := range issue.Labels {
+		current[strings.ToLower(l.GetName())] = true
 	}
 
-	// Add agent label
-	labels = append(labels, "agent-created")
+	wanted := labelMap.LabelsForTask(task)
+	keep := make(map[string]bool, len(wanted))
+	var add []string
+	for _, l := range wanted {
+		keep[strings.ToLower(l)] = true
+		if !current[strings.ToLower(l)] {
+			add = append(add, l)
+		}
+	}
+	if len(add) > 0 {
+		if _, _, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, issue.GetNumber(), add); err != nil {
+			return err
+		}
+	}
+
+	for _, l := range labelMap.Managed() {
+		if keep[strings.ToLower(l)] || !current[strings.ToLower(l)] {
+			continue
+		}
+		resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, issue.GetNumber(), l)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// issueContentHash fingerprints what a push sends to an issue, so unchanged
+// issues can be skipped
+func issueContentHash(title, body, state, stateReason string, milestone int, labels []string, assignee string) string {
+	sorted := append([]string(nil), labels...)
+	sort.Strings(sorted)
+	if state != "closed" {
+		stateReason = ""
+	}
+	h := sha256.New()
+	parts := []string{title, body, state, stateReason, fmt.Sprint(milestone), strings.Join(sorted, "\x00")}
+	if assignee != "" {
+		// Only mapped assignees are pushed; others keep their earlier hashes
+		parts = append(parts, assignee)
+	}
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
 
-	return labels
+func buildLabels(labelMap models.LabelMap, task models.Task) []string {
+	return append(labelMap.LabelsForTask(task), agentCreatedLabel)
 }
 
 func mapStatusToGitHub(status string) string {
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// issueBodyTemplateFile is the project-level issue body template, used when
+// none is stored in config
+const issueBodyTemplateFile = "issue_body.tmpl"
+
+// issueBodyGate is a gate linked to the task, as seen by body templates
+type issueBodyGate struct {
+	ID     string
+	Title  string
+	Type   string
+	Status string // Link status: pending, passed, failed, requested
+}
+
+// issueBodyTask is a related task, as seen by body templates
+type issueBodyTask struct {
+	ID     string
+	Title  string
+	Status string
+	Issue  int // GitHub issue number, 0 if not synced
+}
+
+// issueBodyData is the data passed to issue body templates
+type issueBodyData struct {
+	Task      models.Task
+	Gates     []issueBodyGate
+	Blockers  []issueBodyTask // Tasks blocking this one
+	Blocking  []issueBodyTask // Tasks this one blocks
+	Subtasks  []issueBodyTask
+	Parent    *issueBodyTask
+	Default   string // The built-in body, to wrap rather than replace
+	Generated string // Footer crediting GuardRails
+}
+
+// issueBodyFuncs are the helpers available to body templates
+var issueBodyFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"date": func(t interface{}) string {
+		switch v := t.(type) {
+		case time.Time:
+			return v.Format(models.DateFormat)
+		case *time.Time:
+			if v != nil {
+				return v.Format(models.DateFormat)
+			}
+		}
+		return ""
+	},
+}
+
+// parseIssueBodyTemplate compiles an issue body template
+func parseIssueBodyTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("issue_body").Funcs(issueBodyFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue body template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// loadIssueBodyTemplate returns the configured issue body template: the one
+// stored with 'gur config github --body-template', else
+// .guardrails/issue_body.tmpl, else nil for the built-in body
+func loadIssueBodyTemplate() (*template.Template, error) {
+	if text, _ := db.GetConfig(models.ConfigGitHubIssueBodyTemplate); text != "" {
+		return parseIssueBodyTemplate(text)
+	}
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		return nil, nil
+	}
+	text, err := os.ReadFile(filepath.Join(root, db.GuardrailsDir, issueBodyTemplateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", issueBodyTemplateFile, err)
+	}
+	return parseIssueBodyTemplate(string(text))
+}
+
+// loadIssueBodyData gathers the task's gates and related tasks
+func loadIssueBodyData(database *gorm.DB, task models.Task) (issueBodyData, error) {
+	data := issueBodyData{
+		Task:      task,
+		Default:   buildIssueBody(task),
+		Generated: issueBodyFooter,
+	}
+
+	var gates []struct {
+		models.Gate
+		LinkStatus string
+	}
+	if err := database.Model(&models.GateTaskLink{}).
+		Select("gates.*, gate_task_links.status AS link_status").
+		Joins("JOIN gates ON gates.id = gate_task_links.gate_id AND gates.deleted_at IS NULL").
+		Where("gate_task_links.task_id = ?", task.ID).
+		Order("gate_task_links.id ASC").
+		Scan(&gates).Error; err != nil {
+		return data, err
+	}
+	for _, g := range gates {
+		data.Gates = append(data.Gates, issueBodyGate{ID: g.ID, Title: g.Title, Type: g.Type, Status: g.LinkStatus})
+	}
+
+	related := func(query *gorm.DB) ([]issueBodyTask, error) {
+		var rows []issueBodyTask
+		err := query.Model(&models.Task{}).
+			Select("tasks.id, tasks.title, tasks.status, COALESCE(github_issue_links.issue_number, 0) AS issue").
+			Joins("LEFT JOIN github_issue_links ON github_issue_links.task_id = tasks.id").
+			Order("tasks.id ASC").
+			Scan(&rows).Error
+		return rows, err
+	}
+	var err error
+	blockerIDs := database.Model(&models.Dependency{}).Select("parent_id").Where("child_id = ? AND type = ?", task.ID, models.DepTypeBlocks)
+	if data.Blockers, err = related(database.Where("tasks.id IN (?)", blockerIDs)); err != nil {
+		return data, err
+	}
+	blockingIDs := database.Model(&models.Dependency{}).Select("child_id").Where("parent_id = ? AND type = ?", task.ID, models.DepTypeBlocks)
+	if data.Blocking, err = related(database.Where("tasks.id IN (?)", blockingIDs)); err != nil {
+		return data, err
+	}
+	if data.Subtasks, err = related(database.Where("tasks.parent_id = ?", task.ID)); err != nil {
+		return data, err
+	}
+	if task.ParentID != "" {
+		parents, err := related(database.Where("tasks.id = ?", task.ParentID))
+		if err != nil {
+			return data, err
+		}
+		if len(parents) > 0 {
+			data.Parent = &parents[0]
+		}
+	}
+	return data, nil
+}
+
+// renderIssueBody builds the issue body with tmpl, or the built-in body if
+// tmpl is nil, followed by the task's rank marker. Synced tasks mentioned in
+// the description are linked to their issues.
+func renderIssueBody(database *gorm.DB, tmpl *template.Template, task models.Task) (string, error) {
+	repo, _ := db.GetConfig(models.ConfigGitHubRepo)
+	task.Description = linkTaskMentions(database, task.Description, repo)
+	if tmpl == nil {
+		return buildIssueBody(task) + rankMarker(task.Rank), nil
+	}
+	data, err := loadIssueBodyData(database, task)
+	if err != nil {
+		return "", fmt.Errorf("failed to load issue body data: %w", err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render issue body for task '%s': %w", task.ID, err)
+	}
+	return sb.String() + rankMarker(task.Rank), nil
+}
+
+// rankMarkerRegex matches the hidden rank marker in an issue body
+var rankMarkerRegex = regexp.MustCompile(`\n?<!-- gur-rank:(\d+) -->`)
+
+// rankMarker carries a task's rank through GitHub as a hidden comment, so
+// 'sync pull' can restore it; empty for unranked tasks
+func rankMarker(rank int) string {
+	if rank <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n<!-- gur-rank:%d -->", rank)
+}
+
+// parseRankMarker returns the rank in an issue body (0 if none) and the body
+// without the marker
+func parseRankMarker(body string) (int, string) {
+	m := rankMarkerRegex.FindStringSubmatch(body)
+	if m == nil {
+		return 0, body
+	}
+	rank, _ := strconv.Atoi(m[1])
+	return rank, rankMarkerRegex.ReplaceAllString(body, "")
+}
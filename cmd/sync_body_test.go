@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestRenderIssueBody(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-body0001", Title: "Epic", Status: models.StatusOpen, Type: models.TypeEpic})
+	database.Create(&models.Task{ID: "gur-body0002", ParentID: "gur-body0001", Title: "Child", Status: models.StatusOpen, Labels: models.StringSlice{"api", "ui"}})
+	database.Create(&models.Task{ID: "gur-body0003", Title: "Blocker", Status: models.StatusInProgress})
+	database.Create(&models.Dependency{ParentID: "gur-body0003", ChildID: "gur-body0002", Type: models.DepTypeBlocks})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-body0003", IssueNumber: 12, Repository: "acme/widgets"})
+	database.Create(&models.Gate{ID: "gate-body0001", Title: "Unit tests", Type: "test"})
+	database.Create(&models.GateTaskLink{GateID: "gate-body0001", TaskID: "gur-body0002", Status: models.GateLinkPassed})
+
+	task, _ := db.GetTaskByID("gur-body0002")
+
+	// No template: the built-in body
+	body, err := renderIssueBody(database, nil, *task)
+	if err != nil || body != buildIssueBody(*task) {
+		t.Fatalf("nil template should render the built-in body, got %q, %v", body, err)
+	}
+
+	tmpl, err := parseIssueBodyTemplate(`{{.Task.Title}} [{{join .Task.Labels ", "}}]
+{{range .Gates}}- {{.Title}}: {{.Status}}
+{{end}}{{range .Blockers}}blocked by {{.ID}} (#{{.Issue}})
+{{end}}parent: {{.Parent.Title}}
+{{.Generated}}`)
+	if err != nil {
+		t.Fatalf("parseIssueBodyTemplate: %v", err)
+	}
+	body, err = renderIssueBody(database, tmpl, *task)
+	if err != nil {
+		t.Fatalf("renderIssueBody: %v", err)
+	}
+	want := "Child [api, ui]\n- Unit tests: passed\nblocked by gur-body0003 (#12)\nparent: Epic\n" + issueBodyFooter
+	if body != want {
+		t.Errorf("body =\n%s\nwant\n%s", body, want)
+	}
+
+	bad, _ := parseIssueBodyTemplate(`{{.Task.Nope}}`)
+	if _, err := renderIssueBody(database, bad, *task); err == nil || !strings.Contains(err.Error(), "gur-body0002") {
+		t.Errorf("unknown field should fail with the task ID, got %v", err)
+	}
+	if _, err := parseIssueBodyTemplate(`{{.Task.Title`); err == nil {
+		t.Error("unterminated action should not parse")
+	}
+}
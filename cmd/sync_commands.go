@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// issueCommandPrefix starts a command line in an issue comment
+const issueCommandPrefix = "/gur "
+
+// issueCommandAssociations are the comment author associations allowed to
+// run commands; anyone else who can comment on the issue is ignored
+var issueCommandAssociations = map[string]bool{"OWNER": true, "MEMBER": true, "COLLABORATOR": true}
+
+// issueCommand is a "/gur <verb> [args] [key=value]" line from an issue comment
+type issueCommand struct {
+	Verb   string
+	Args   []string
+	Params map[string]string
+	Line   string
+}
+
+// appliedCommand reports the outcome of an issue comment command
+type appliedCommand struct {
+	IssueNumber int    `json:"issue_number"`
+	TaskID      string `json:"task_id"`
+	Command     string `json:"command"`
+	Author      string `json:"author"`
+	Result      string `json:"result,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// tokenizeCommand splits a command line on spaces, keeping "quoted values"
+// (including key="quoted value") together
+func tokenizeCommand(line string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuote, started := false, false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuote, started = !inQuote, true
+		case (r == ' ' || r == '\t') && !inQuote:
+			if started {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				started = false
+			}
+		default:
+			cur.WriteRune(r)
+			started = true
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote in '%s'", line)
+	}
+	if started {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+// parseIssueCommands returns the /gur command lines of a comment body.
+// Lines inside ``` code blocks are ignored, so commands can be quoted.
+func parseIssueCommands(body string) []issueCommand {
+	var commands []issueCommand
+	inCode := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || !strings.HasPrefix(line, issueCommandPrefix) {
+			continue
+		}
+		cmd := issueCommand{Params: make(map[string]string), Line: line}
+		tokens, err := tokenizeCommand(strings.TrimPrefix(line, issueCommandPrefix))
+		if err != nil || len(tokens) == 0 {
+			cmd.Verb = "invalid"
+			commands = append(commands, cmd)
+			continue
+		}
+		cmd.Verb = strings.ToLower(tokens[0])
+		for _, tok := range tokens[1:] {
+			if k, v, ok := strings.Cut(tok, "="); ok && k != "" {
+				cmd.Params[strings.ToLower(k)] = v
+			} else {
+				cmd.Args = append(cmd.Args, tok)
+			}
+		}
+		commands = append(commands, cmd)
+	}
+	return commands
+}
+
+// arg returns the named parameter, or the first positional argument
+func (c issueCommand) arg(name string) string {
+	if v, ok := c.Params[name]; ok {
+		return v
+	}
+	if len(c.Args) > 0 {
+		return strings.Join(c.Args, " ")
+	}
+	return ""
+}
+
+// applyIssueCommand runs a command against the linked task, recording
+// history attributed to the GitHub user
+func applyIssueCommand(database *gorm.DB, task *models.Task, cmd issueCommand, author string) (string, error) {
+	by := "github:@" + author
+	via := fmt.Sprintf(" (via GitHub comment by @%s)", author)
+	switch cmd.Verb {
+	case "close":
+		if task.IsClosed() {
+			return "", fmt.Errorf("task is already closed")
+		}
+		resolution := cmd.Params["as"]
+		if resolution == "" {
+			resolution = models.ResolutionCompleted
+		}
+		if err := models.ValidateResolution(resolution); err != nil {
+			return "", err
+		}
+		var openSubtasks int64
+		database.Model(&models.Task{}).Where("parent_id = ? AND status != ?", task.ID, models.StatusClosed).Count(&openSubtasks)
+		if openSubtasks > 0 {
+			return "", fmt.Errorf("task has %d open subtask(s)", openSubtasks)
+		}
+		if err := CheckGatesBeforeClose(task.ID); err != nil {
+			return "", err
+		}
+		reason := cmd.Params["reason"]
+		if reason == "" {
+			reason = strings.Join(cmd.Args, " ")
+		}
+		if reason == "" {
+			reason = "Closed on GitHub"
+		}
+		reason += via
+		models.RecordChange(database, task.ID, "status", task.Status, models.StatusClosed, by)
+		models.RecordChange(database, task.ID, "close_reason", "", reason, by)
+		models.RecordChange(database, task.ID, "resolution", "", resolution, by)
+		task.CloseAs(resolution, reason)
+		if err := database.Save(task).Error; err != nil {
+			return "", err
+		}
+		syncParentChecklist(database, task, true, by)
+		return fmt.Sprintf("closed (%s)", resolution), nil
+
+	case "reopen":
+		if !task.IsClosed() {
+			return "", fmt.Errorf("task is not closed")
+		}
+		models.RecordChange(database, task.ID, "status", task.Status, models.StatusOpen, by)
+		models.RecordChange(database, task.ID, "close_reason", task.CloseReason, "", by)
+		models.RecordChange(database, task.ID, "resolution", task.Resolution, "", by)
+		task.Reopen()
+		if err := database.Save(task).Error; err != nil {
+			return "", err
+		}
+		syncParentChecklist(database, task, false, by)
+		return "reopened", nil
+
+	case "priority":
+		p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(cmd.arg("p")), "P"))
+		if err != nil || p < 0 || p > 4 {
+			return "", fmt.Errorf("priority must be 0-4")
+		}
+		models.RecordChange(database, task.ID, "priority", strconv.Itoa(task.Priority), strconv.Itoa(p), by)
+		if err := database.Model(task).Update("priority", p).Error; err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("priority set to P%d", p), nil
+
+	case "assign":
+		assignee := strings.TrimPrefix(cmd.arg("to"), "@")
+		if assignee == "" {
+			return "", fmt.Errorf("assign needs a name")
+		}
+		if err := checkAssignee(database, assignee); err != nil {
+			return "", err
+		}
+		models.RecordChange(database, task.ID, "assignee", task.Assignee, assignee, by)
+		if err := database.Model(task).Update("assignee", assignee).Error; err != nil {
+			return "", err
+		}
+		return "assigned to " + assignee, nil
+
+	case "label":
+		label := cmd.arg("name")
+		if label == "" {
+			return "", fmt.Errorf("label needs a name")
+		}
+		if task.HasLabel(label) {
+			return "already labeled " + label, nil
+		}
+		task.AddLabel(label)
+		models.RecordChange(database, task.ID, "label_added", "", label, by)
+		if err := database.Model(task).Update("labels", task.Labels).Error; err != nil {
+			return "", err
+		}
+		return "labeled " + label, nil
+
+	case "block":
+		if task.IsClosed() {
+			return "", fmt.Errorf("task is closed")
+		}
+		reason := cmd.arg("reason")
+		if reason == "" {
+			return "", fmt.Errorf("block needs a reason")
+		}
+		models.RecordChange(database, task.ID, "status", task.Status, models.StatusBlocked, by)
+		models.RecordChange(database, task.ID, "block_reason", task.BlockReason, reason, by)
+		if err := database.Model(task).Updates(map[string]interface{}{"status": models.StatusBlocked, "block_reason": reason}).Error; err != nil {
+			return "", err
+		}
+		return "blocked: " + reason, nil
+
+	case "unblock":
+		if !task.IsBlocked() {
+			return "", fmt.Errorf("task is not blocked")
+		}
+		models.RecordChange(database, task.ID, "status", task.Status, models.StatusOpen, by)
+		models.RecordChange(database, task.ID, "block_reason", task.BlockReason, "", by)
+		if err := database.Model(task).Updates(map[string]interface{}{"status": models.StatusOpen, "block_reason": ""}).Error; err != nil {
+			return "", err
+		}
+		return "unblocked", nil
+	}
+	return "", fmt.Errorf("unknown command (use close, reopen, priority, assign, label, block or unblock)")
+}
+
+// runIssueCommands applies the /gur commands in comments added to a linked
+// issue since it was last scanned. Commands from users who aren't owners,
+// members or collaborators are ignored; failed commands get a reply on the
+// issue explaining why. With dryRun nothing is applied or recorded.
+func runIssueCommands(ctx context.Context, client *github.Client, database *gorm.DB, owner, repo string, link *models.GitHubIssueLink, dryRun bool) ([]appliedCommand, error) {
+	since := link.LastSyncedAt
+	if link.CommandsAt != nil {
+		since = *link.CommandsAt
+	}
+	checkedAt := time.Now()
+
+	opts := &github.IssueListCommentsOptions{Since: &since, ListOptions: github.ListOptions{PerPage: 100}}
+	var comments []*github.IssueComment
+	for {
+		page, resp, err := client.Issues.ListComments(ctx, owner, repo, link.IssueNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+		comments = append(comments, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var results []appliedCommand
+	lastID := link.CommandID
+	for _, c := range comments {
+		// Skip comments already scanned, and edits of older comments
+		if c.GetID() <= link.CommandID || c.GetCreatedAt().Time.Before(since) {
+			continue
+		}
+		lastID = max(lastID, c.GetID())
+		commands := parseIssueCommands(c.GetBody())
+		if len(commands) == 0 || !issueCommandAssociations[c.GetAuthorAssociation()] {
+			continue
+		}
+		author := c.GetUser().GetLogin()
+		for _, cmd := range commands {
+			r := appliedCommand{IssueNumber: link.IssueNumber, TaskID: link.TaskID, Command: cmd.Line, Author: author}
+			if dryRun {
+				r.Result = "would apply"
+				results = append(results, r)
+				continue
+			}
+			task, err := db.GetTaskByID(link.TaskID)
+			if err != nil {
+				return results, err
+			}
+			r.Result, err = applyIssueCommand(database, task, cmd, author)
+			if err != nil {
+				r.Error = err.Error()
+				reply := fmt.Sprintf("@%s could not apply `%s`: %s", author, cmd.Line, err)
+				if _, _, rerr := client.Issues.CreateComment(ctx, owner, repo, link.IssueNumber, &github.IssueComment{Body: &reply}); rerr != nil {
+					r.Error += fmt.Sprintf(" (reply failed: %v)", rerr)
+				}
+			} else {
+				noteAffected(task.ID)
+			}
+			results = append(results, r)
+		}
+	}
+
+	if !dryRun {
+		link.CommandID, link.CommandsAt = lastID, &checkedAt
+		if err := database.Model(link).Updates(map[string]interface{}{"command_id": lastID, "commands_at": checkedAt}).Error; err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestParseIssueCommands(t *testing.T) {
+	body := "Looks good.\n/gur close reason=\"fixed in #42\" as=completed\n```\n/gur reopen\n```\n  /gur priority P1\n/gurx nope\n/gur label \"needs design\""
+	got := parseIssueCommands(body)
+	if len(got) != 3 {
+		t.Fatalf("parseIssueCommands() = %+v, want 3 commands (code block ignored)", got)
+	}
+	if got[0].Verb != "close" || !reflect.DeepEqual(got[0].Params, map[string]string{"reason": "fixed in #42", "as": "completed"}) {
+		t.Errorf("close command = %+v", got[0])
+	}
+	if got[1].Verb != "priority" || got[1].arg("p") != "P1" {
+		t.Errorf("priority command = %+v", got[1])
+	}
+	if got[2].arg("name") != "needs design" {
+		t.Errorf("label command = %+v", got[2])
+	}
+	if _, err := tokenizeCommand(`close reason="open`); err == nil {
+		t.Error("tokenizeCommand accepted an unterminated quote")
+	}
+}
+
+func TestRunIssueCommands(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	synced := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	database.Create(&models.Task{ID: "gur-cmd00001", Title: "Steered", Status: models.StatusOpen, Priority: 2})
+	link := &models.GitHubIssueLink{TaskID: "gur-cmd00001", IssueNumber: 7, Repository: "o/r", LastSyncedAt: synced}
+	database.Create(link)
+
+	var replies []string
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			replies = append(replies, string(body))
+			fmt.Fprint(w, `{"id": 99}`)
+			return
+		}
+		fmt.Fprint(w, `[
+			{"id": 1, "body": "/gur close", "author_association": "MEMBER", "user": {"login": "old"}, "created_at": "2026-02-01T00:00:00Z"},
+			{"id": 2, "body": "/gur priority 0", "author_association": "NONE", "user": {"login": "drive-by"}, "created_at": "2026-03-02T00:00:00Z"},
+			{"id": 3, "body": "Bumping.\n/gur priority 1\n/gur label ux\n/gur fly", "author_association": "OWNER", "user": {"login": "alice"}, "created_at": "2026-03-02T00:00:00Z"}
+		]`)
+	}))
+
+	results, err := runIssueCommands(context.Background(), client, database, "o", "r", link, false)
+	if err != nil {
+		t.Fatalf("runIssueCommands() error: %v", err)
+	}
+	if len(results) != 3 || results[0].Result != "priority set to P1" || results[2].Error == "" {
+		t.Fatalf("results = %+v, want priority and label applied and fly rejected", results)
+	}
+	if len(replies) != 1 || !strings.Contains(replies[0], "could not apply") {
+		t.Errorf("replies = %v, want one rejection reply", replies)
+	}
+
+	task, _ := db.GetTaskByID("gur-cmd00001")
+	if task.Priority != 1 || !task.HasLabel("ux") || task.IsClosed() {
+		t.Errorf("task = P%d labels %v closed %v; want P1, ux, still open", task.Priority, task.Labels, task.IsClosed())
+	}
+	var history models.TaskHistory
+	database.Where("task_id = ? AND field = ?", task.ID, "priority").First(&history)
+	if history.ChangedBy != "github:@alice" {
+		t.Errorf("history changed_by = %q, want github:@alice", history.ChangedBy)
+	}
+
+	// A second scan doesn't replay the same comments
+	database.First(link, link.ID)
+	if link.CommandID != 3 || link.CommandsAt == nil {
+		t.Errorf("link = %+v, want comment 3 recorded as scanned", link)
+	}
+	if again, _ := runIssueCommands(context.Background(), client, database, "o", "r", link, false); len(again) != 0 {
+		t.Errorf("second scan = %+v, want nothing replayed", again)
+	}
+}
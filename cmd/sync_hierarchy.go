@@ -0,0 +1,411 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v63/github"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// hierarchyBy is the author recorded for parent changes pulled from GitHub
+const hierarchyBy = "github:sub-issue"
+
+// issueRelationBatch is how many issues one GraphQL query asks about
+const issueRelationBatch = 50
+
+// issueHierarchy is what push and pull map besides labels: task types to
+// GitHub issue types and subtasks to sub-issues. A nil *issueHierarchy maps
+// neither.
+type issueHierarchy struct {
+	types     models.IssueTypeMap // nil leaves issue types alone
+	available map[string]string   // Lowercased name -> the owner's issue type, once fetched
+	subIssues bool
+}
+
+// loadIssueHierarchy returns the configured mapping, or nil if neither
+// issue types nor sub-issues are turned on
+func loadIssueHierarchy() (*issueHierarchy, error) {
+	h := &issueHierarchy{}
+	if spec, _ := db.GetConfig(models.ConfigGitHubIssueTypes); spec != "" {
+		types, err := models.ParseIssueTypeMap(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue type mapping in config (run 'gur config github issues --types default'): %w", err)
+		}
+		h.types = types
+	}
+	if v, _ := db.GetConfig(models.ConfigGitHubSubIssues); v == "true" {
+		h.subIssues = true
+	}
+	if h.types == nil && !h.subIssues {
+		return nil, nil
+	}
+	return h, nil
+}
+
+// loadIssueTypes fetches the issue types the repository owner defines.
+// Issue types are an organization feature, so for other owners they are
+// turned off with a warning; mapped types the owner lacks are warned about
+// and left off the issues.
+func (h *issueHierarchy) loadIssueTypes(ctx context.Context, client *github.Client, owner string) error {
+	if h == nil || h.types == nil {
+		return nil
+	}
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%s/issue-types", owner), nil)
+	if err != nil {
+		return err
+	}
+	var types []struct {
+		Name string `json:"name"`
+	}
+	resp, err := client.Do(ctx, req, &types)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			fmt.Fprintf(os.Stderr, "Warning: %s has no issue types (they are an organization feature); pushing without them\n", owner)
+			h.types = nil
+			return nil
+		}
+		return fmt.Errorf("failed to list issue types: %w", err)
+	}
+	h.available = make(map[string]string, len(types))
+	for _, t := range types {
+		h.available[strings.ToLower(t.Name)] = t.Name
+	}
+	for _, taskType := range []string{models.TypeTask, models.TypeBug, models.TypeFeature, models.TypeEpic} {
+		if name, ok := h.types[taskType]; ok && h.available[strings.ToLower(name)] == "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s has no issue type '%s'; %s issues are pushed without a type\n", owner, name, taskType)
+		}
+	}
+	return nil
+}
+
+// issueType returns the issue type a task's issue should have: "" for
+// none, or if the task's type isn't mapped
+func (h *issueHierarchy) issueType(task models.Task) string {
+	if h == nil || h.types == nil {
+		return ""
+	}
+	name, ok := h.types[task.Type]
+	if !ok || h.available == nil {
+		return name
+	}
+	return h.available[strings.ToLower(name)]
+}
+
+// typeInSync reports whether the issue's type, as last synced, is what the
+// task's type maps to. Types gur didn't map, e.g. set by hand on GitHub,
+// are left alone.
+func (h *issueHierarchy) typeInSync(link models.GitHubIssueLink, task models.Task) bool {
+	if h == nil || h.types == nil {
+		return true
+	}
+	want := h.issueType(task)
+	if want == "" {
+		_, mapped := h.types.FromGitHub(link.IssueType)
+		return link.IssueType == "" || !mapped
+	}
+	return strings.EqualFold(want, link.IssueType)
+}
+
+// parentIssue returns the number of the issue a task's issue should be a
+// sub-issue of: 0 for top-level tasks. known is false while the parent
+// isn't linked to an issue in repo, when the relation is left as it is.
+func (h *issueHierarchy) parentIssue(database *gorm.DB, task models.Task, repo string) (number int, known bool) {
+	if task.ParentID == "" {
+		return 0, true
+	}
+	var parent models.GitHubIssueLink
+	if database.Where("task_id = ? AND repository = ?", task.ParentID, repo).First(&parent).Error != nil {
+		return 0, false
+	}
+	return parent.IssueNumber, true
+}
+
+// inSync reports whether the issue's type and parent, as last synced,
+// match the task
+func (h *issueHierarchy) inSync(database *gorm.DB, link models.GitHubIssueLink, task models.Task) bool {
+	if h == nil {
+		return true
+	}
+	if !h.typeInSync(link, task) {
+		return false
+	}
+	if h.subIssues {
+		if parent, known := h.parentIssue(database, task, link.Repository); known && parent != link.ParentIssue {
+			return false
+		}
+	}
+	return true
+}
+
+// apply sets the type and parent of a pushed task's issue, then makes the
+// task's linked subtasks its sub-issues. Failures are warnings: the link
+// keeps its old values, so the next push tries again.
+func (h *issueHierarchy) apply(ctx context.Context, client *github.Client, database *gorm.DB, owner, repo string, task models.Task, link *models.GitHubIssueLink) {
+	if h == nil {
+		return
+	}
+	changed := false
+	if !h.typeInSync(*link, task) {
+		want := h.issueType(task)
+		var value interface{}
+		if want != "" {
+			value = want
+		}
+		if err := githubREST(ctx, client, "PATCH", fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, link.IssueNumber), map[string]interface{}{"type": value}); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to set the issue type of #%d: %v\n", link.IssueNumber, err)
+		} else {
+			link.IssueType = want
+			changed = true
+		}
+	}
+
+	if h.subIssues {
+		if parent, known := h.parentIssue(database, task, link.Repository); known && parent != link.ParentIssue {
+			if err := setParentIssue(ctx, client, owner, repo, link, parent); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to make #%d a sub-issue of #%d: %v\n", link.IssueNumber, parent, err)
+			} else {
+				link.ParentIssue = parent
+				changed = true
+			}
+		}
+		h.attachSubIssues(ctx, client, database, owner, repo, task, *link)
+	}
+
+	if changed {
+		if err := database.Model(link).Updates(map[string]interface{}{"issue_type": link.IssueType, "parent_issue": link.ParentIssue}).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update link for %s: %v\n", task.ID, err)
+		}
+	}
+}
+
+// attachSubIssues makes the issues of a task's subtasks, pushed before the
+// task had an issue of its own, its sub-issues
+func (h *issueHierarchy) attachSubIssues(ctx context.Context, client *github.Client, database *gorm.DB, owner, repo string, task models.Task, link models.GitHubIssueLink) {
+	var children []models.GitHubIssueLink
+	if err := database.Where("repository = ? AND parent_issue <> ?", link.Repository, link.IssueNumber).
+		Where("task_id IN (?)", database.Model(&models.Task{}).Select("id").Where("parent_id = ?", task.ID)).
+		Find(&children).Error; err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to look up subtasks of %s: %v\n", task.ID, err)
+		return
+	}
+	for i := range children {
+		child := &children[i]
+		if err := setParentIssue(ctx, client, owner, repo, child, link.IssueNumber); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to make #%d a sub-issue of #%d: %v\n", child.IssueNumber, link.IssueNumber, err)
+			continue
+		}
+		child.ParentIssue = link.IssueNumber
+		if err := database.Model(child).Updates(map[string]interface{}{"issue_id": child.IssueID, "parent_issue": child.ParentIssue}).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update link for %s: %v\n", child.TaskID, err)
+		}
+	}
+}
+
+// setParentIssue makes link's issue a sub-issue of parent, replacing its
+// current parent, or removes it from its parent when parent is 0
+func setParentIssue(ctx context.Context, client *github.Client, owner, repo string, link *models.GitHubIssueLink, parent int) error {
+	if link.IssueID == 0 {
+		// Links made before issue IDs were recorded
+		issue, _, err := client.Issues.Get(ctx, owner, repo, link.IssueNumber)
+		if err != nil {
+			return err
+		}
+		link.IssueID = issue.GetID()
+	}
+	if parent == 0 {
+		return githubREST(ctx, client, "DELETE", fmt.Sprintf("repos/%s/%s/issues/%d/sub_issue", owner, repo, link.ParentIssue),
+			map[string]interface{}{"sub_issue_id": link.IssueID})
+	}
+	return githubREST(ctx, client, "POST", fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues", owner, repo, parent),
+		map[string]interface{}{"sub_issue_id": link.IssueID, "replace_parent": true})
+}
+
+// githubREST sends a REST request go-github has no method for yet
+func githubREST(ctx context.Context, client *github.Client, method, path string, body interface{}) error {
+	req, err := client.NewRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+// issueRelation is an issue's type and parent as pull reads them
+type issueRelation struct {
+	Type   string // "" without an issue type
+	Parent int    // 0 without a parent in the same repository
+}
+
+// fetchIssueRelations reads the type and parent of issues through GraphQL,
+// which returns both for many issues in one query
+func fetchIssueRelations(ctx context.Context, client *github.Client, owner, repo string, numbers []int) (map[int]issueRelation, error) {
+	relations := make(map[int]issueRelation, len(numbers))
+	for start := 0; start < len(numbers); start += issueRelationBatch {
+		end := start + issueRelationBatch
+		if end > len(numbers) {
+			end = len(numbers)
+		}
+		var sb strings.Builder
+		sb.WriteString("query($owner: String!, $name: String!) {\n  repository(owner: $owner, name: $name) {\n")
+		for _, n := range numbers[start:end] {
+			fmt.Fprintf(&sb, "    i%d: issue(number: %d) { issueType { name } parent { number repository { nameWithOwner } } }\n", n, n)
+		}
+		sb.WriteString("  }\n}")
+
+		var data struct {
+			Repository map[string]*struct {
+				IssueType *struct {
+					Name string `json:"name"`
+				} `json:"issueType"`
+				Parent *struct {
+					Number     int `json:"number"`
+					Repository struct {
+						NameWithOwner string `json:"nameWithOwner"`
+					} `json:"repository"`
+				} `json:"parent"`
+			} `json:"repository"`
+		}
+		if err := githubGraphQL(ctx, client, sb.String(), map[string]interface{}{"owner": owner, "name": repo}, &data); err != nil {
+			return nil, err
+		}
+		for _, n := range numbers[start:end] {
+			issue := data.Repository[fmt.Sprintf("i%d", n)]
+			if issue == nil {
+				continue
+			}
+			var rel issueRelation
+			if issue.IssueType != nil {
+				rel.Type = issue.IssueType.Name
+			}
+			if issue.Parent != nil && strings.EqualFold(issue.Parent.Repository.NameWithOwner, owner+"/"+repo) {
+				rel.Parent = issue.Parent.Number
+			}
+			relations[n] = rel
+		}
+	}
+	return relations, nil
+}
+
+// parentsFirst orders issues so that each comes after its parent, when
+// both are pulled, keeping the order otherwise
+func parentsFirst(issues []*github.Issue, relations map[int]issueRelation) []*github.Issue {
+	pulled := make(map[int]bool, len(issues))
+	for _, issue := range issues {
+		pulled[issue.GetNumber()] = true
+	}
+	depth := func(n int) int {
+		d := 0
+		for seen := 0; seen < len(issues); seen++ {
+			parent := relations[n].Parent
+			if parent == 0 || !pulled[parent] {
+				break
+			}
+			d, n = d+1, parent
+		}
+		return d
+	}
+	ordered := make([]*github.Issue, len(issues))
+	copy(ordered, issues)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return depth(ordered[i].GetNumber()) < depth(ordered[j].GetNumber())
+	})
+	return ordered
+}
+
+// applyPulledRelation gives a task pulled from an issue the type its issue
+// type maps to and, when the parent issue is linked, makes it a subtask of
+// the parent's task
+func (h *issueHierarchy) applyPulledRelation(database *gorm.DB, task *models.Task, link *models.GitHubIssueLink, rel issueRelation) error {
+	if h == nil {
+		return nil
+	}
+	if h.types != nil && rel.Type != "" {
+		if taskType, ok := h.types.FromGitHub(rel.Type); ok {
+			task.Type = taskType
+		}
+		link.IssueType = rel.Type
+	}
+	if h.subIssues && rel.Parent != 0 {
+		var parent models.GitHubIssueLink
+		if database.Where("issue_number = ? AND repository = ?", rel.Parent, link.Repository).First(&parent).Error != nil {
+			return nil // Parent not pulled; the task stays top-level
+		}
+		id, err := nextSubtaskID(database, parent.TaskID)
+		if err != nil {
+			return err
+		}
+		task.ID, task.ParentID = id, parent.TaskID
+		link.ParentIssue = rel.Parent
+	}
+	return nil
+}
+
+// parentSync is a parent change pulled from a sub-issue relation
+type parentSync struct {
+	TaskID      string `json:"task_id"`
+	IssueNumber int    `json:"issue_number"`
+	OldParent   string `json:"old_parent,omitempty"`
+	NewParent   string `json:"new_parent,omitempty"`
+}
+
+// pullParent moves a linked task under the task of its issue's new parent,
+// or to the top level when the issue lost the parent it was synced with.
+// Parents that aren't linked are ignored. Nothing is written in a dry run.
+func (h *issueHierarchy) pullParent(database *gorm.DB, link *models.GitHubIssueLink, rel issueRelation, dryRun bool) (*parentSync, error) {
+	if h == nil || !h.subIssues || rel.Parent == link.ParentIssue {
+		return nil, nil
+	}
+	parentTask := func(number int) string {
+		var parent models.GitHubIssueLink
+		if database.Where("issue_number = ? AND repository = ?", number, link.Repository).First(&parent).Error != nil {
+			return ""
+		}
+		return parent.TaskID
+	}
+	var task models.Task
+	if err := database.Where("id = ?", link.TaskID).First(&task).Error; err != nil {
+		return nil, err
+	}
+	newParent := ""
+	if rel.Parent != 0 {
+		if newParent = parentTask(rel.Parent); newParent == "" || newParent == task.ID {
+			return nil, nil
+		}
+	} else if task.ParentID != parentTask(link.ParentIssue) {
+		// Moved locally since the last sync; push brings GitHub up to date
+		return nil, nil
+	}
+	if task.ParentID == newParent {
+		link.ParentIssue = rel.Parent
+		if !dryRun {
+			return nil, database.Model(link).Update("parent_issue", link.ParentIssue).Error
+		}
+		return nil, nil
+	}
+
+	change := &parentSync{TaskID: task.ID, IssueNumber: link.IssueNumber, OldParent: task.ParentID, NewParent: newParent}
+	if dryRun {
+		return change, nil
+	}
+	err := database.Transaction(func(tx *gorm.DB) error {
+		models.RecordChange(tx, task.ID, "parent_id", task.ParentID, newParent, hierarchyBy)
+		if err := tx.Model(&task).Update("parent_id", newParent).Error; err != nil {
+			return err
+		}
+		link.ParentIssue = rel.Parent
+		return tx.Model(link).Update("parent_issue", link.ParentIssue).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	noteAffected(task.ID)
+	return change, nil
+}
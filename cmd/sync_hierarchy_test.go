@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestSyncTaskToGitHubHierarchy(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	next := 10
+	types := make(map[int]interface{})
+	subIssues := make(map[int][]int64) // Parent number -> sub-issue IDs
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		var n int
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/issues":
+			next++
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id": %d, "number": %d, "html_url": "https://github.com/acme/app/issues/%d"}`, 1000+next, next, next)
+		case r.Method == http.MethodGet && sscanPath(r.URL.Path, "/repos/acme/app/issues/%d", &n):
+			fmt.Fprintf(w, `{"id": %d, "number": %d}`, 1000+n, n)
+		case r.Method == http.MethodPatch && sscanPath(r.URL.Path, "/repos/acme/app/issues/%d", &n):
+			if v, ok := body["type"]; ok {
+				types[n] = v
+			}
+			fmt.Fprintf(w, `{"id": %d, "number": %d}`, 1000+n, n)
+		case r.Method == http.MethodPost && sscanPath(r.URL.Path, "/repos/acme/app/issues/%d/sub_issues", &n):
+			if body["replace_parent"] != true {
+				t.Errorf("sub-issue request without replace_parent: %v", body)
+			}
+			subIssues[n] = append(subIssues[n], int64(body["sub_issue_id"].(float64)))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/labels"):
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-hier0001", Title: "Epic", Status: models.StatusOpen, Type: models.TypeEpic})
+	database.Create(&models.Task{ID: "gur-hier0001.1", Title: "Crash", Status: models.StatusOpen, Type: models.TypeBug, ParentID: "gur-hier0001"})
+	hierarchy := &issueHierarchy{
+		types:     models.IssueTypeMap{models.TypeBug: "Bug"},
+		available: map[string]string{"bug": "Bug"},
+		subIssues: true,
+	}
+	push := func(id string) string {
+		t.Helper()
+		task, _ := db.GetTaskByID(id)
+		result, err := syncTaskToGitHub(context.Background(), client, "acme", "app", "[Agent]", models.DefaultLabelMap(), nil, nil, hierarchy, *task)
+		if err != nil {
+			t.Fatalf("syncTaskToGitHub(%s) error: %v", id, err)
+		}
+		return result["action"].(string)
+	}
+
+	// The subtask goes first, before its parent has an issue
+	push("gur-hier0001.1")
+	if types[11] != "Bug" {
+		t.Errorf("issue type of #11 = %v, want Bug", types[11])
+	}
+	if len(subIssues) != 0 {
+		t.Fatalf("sub-issues = %v before the parent was pushed", subIssues)
+	}
+	push("gur-hier0001")
+	if _, ok := types[12]; ok {
+		t.Errorf("epic issue got type %v, want none with epics unmapped", types[12])
+	}
+	if got := subIssues[12]; len(got) != 1 || got[0] != 1011 {
+		t.Errorf("sub-issues of #12 = %v, want [1011]", got)
+	}
+
+	var link models.GitHubIssueLink
+	database.Where("task_id = ?", "gur-hier0001.1").First(&link)
+	if link.IssueType != "Bug" || link.ParentIssue != 12 || link.IssueID != 1011 {
+		t.Errorf("subtask link = type %q, parent #%d, ID %d", link.IssueType, link.ParentIssue, link.IssueID)
+	}
+	if action := push("gur-hier0001.1"); action != "skipped" {
+		t.Errorf("push in sync action = %s, want skipped", action)
+	}
+
+	// Retyping the task retypes the issue
+	database.Model(&models.Task{}).Where("id = ?", "gur-hier0001.1").Update("type", models.TypeFeature)
+	hierarchy.types[models.TypeFeature] = "Feature"
+	hierarchy.available["feature"] = "Feature"
+	push("gur-hier0001.1")
+	if types[11] != "Feature" {
+		t.Errorf("issue type of #11 after retyping = %v, want Feature", types[11])
+	}
+}
+
+// sscanPath reports whether path matches format, a path with one %d
+func sscanPath(path, format string, n *int) bool {
+	var rest string
+	c, _ := fmt.Sscanf(path+" end", format+" %s", n, &rest)
+	return c == 2 && rest == "end"
+}
+
+func TestPullParent(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-pp000001", Title: "Parent", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-pp000002", Title: "Child", Status: models.StatusOpen})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-pp000001", IssueNumber: 1, Repository: "acme/app"})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-pp000002", IssueNumber: 2, Repository: "acme/app"})
+	hierarchy := &issueHierarchy{subIssues: true}
+
+	var link models.GitHubIssueLink
+	database.Where("task_id = ?", "gur-pp000002").First(&link)
+	if p, err := hierarchy.pullParent(database, &link, issueRelation{Parent: 1}, true); err != nil || p == nil || p.NewParent != "gur-pp000001" {
+		t.Fatalf("dry-run pullParent() = %+v, %v", p, err)
+	}
+	if task, _ := db.GetTaskByID("gur-pp000002"); task.ParentID != "" {
+		t.Fatal("dry run moved the task")
+	}
+
+	if _, err := hierarchy.pullParent(database, &link, issueRelation{Parent: 1}, false); err != nil {
+		t.Fatal(err)
+	}
+	if task, _ := db.GetTaskByID("gur-pp000002"); task.ParentID != "gur-pp000001" || link.ParentIssue != 1 {
+		t.Errorf("parent = %q, link parent = #%d, want gur-pp000001, #1", task.ParentID, link.ParentIssue)
+	}
+
+	// Parents without a linked task are ignored
+	if p, _ := hierarchy.pullParent(database, &link, issueRelation{Parent: 99}, false); p != nil {
+		t.Errorf("pullParent() to an unlinked parent = %+v, want nothing", p)
+	}
+
+	if p, _ := hierarchy.pullParent(database, &link, issueRelation{}, false); p == nil || p.OldParent != "gur-pp000001" {
+		t.Errorf("pullParent() without a parent = %+v", p)
+	}
+	if task, _ := db.GetTaskByID("gur-pp000002"); task.ParentID != "" {
+		t.Errorf("parent after the issue lost its parent = %q, want none", task.ParentID)
+	}
+}
+
+func TestParentsFirst(t *testing.T) {
+	issue := func(n int) *github.Issue { return &github.Issue{Number: github.Int(n)} }
+	relations := map[int]issueRelation{3: {Parent: 2}, 2: {Parent: 1}, 4: {Parent: 50}}
+	var got []int
+	for _, i := range parentsFirst([]*github.Issue{issue(3), issue(4), issue(2), issue(1)}, relations) {
+		got = append(got, i.GetNumber())
+	}
+	if fmt.Sprint(got) != "[4 1 2 3]" {
+		t.Errorf("parentsFirst() = %v, want [4 1 2 3]", got)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// syncInterrupt lets a sync finish its current item when SIGINT/SIGTERM
+// arrives, instead of dying between creating an issue and saving its link.
+// A second signal exits immediately.
+type syncInterrupt struct {
+	signals     chan os.Signal
+	done        chan struct{}
+	interrupted atomic.Bool
+}
+
+// watchSyncInterrupt starts watching for SIGINT/SIGTERM; call Stop when done
+func watchSyncInterrupt() *syncInterrupt {
+	s := &syncInterrupt{
+		signals: make(chan os.Signal, 2),
+		done:    make(chan struct{}),
+	}
+	signal.Notify(s.signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for {
+			select {
+			case sig := <-s.signals:
+				if s.interrupted.Swap(true) {
+					fmt.Fprintf(os.Stderr, "\nReceived %s again, exiting now\n", sig)
+					os.Exit(130)
+				}
+				fmt.Fprintf(os.Stderr, "\nReceived %s, finishing current item (repeat to exit immediately)...\n", sig)
+			case <-s.done:
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// Interrupted reports whether a signal has been received
+func (s *syncInterrupt) Interrupted() bool {
+	return s.interrupted.Load()
+}
+
+// Stop restores default signal handling
+func (s *syncInterrupt) Stop() {
+	signal.Stop(s.signals)
+	close(s.done)
+}
+
+// syncCheckpoint records the items an interrupted sync didn't get to
+type syncCheckpoint struct {
+	Operation     string    `json:"operation"` // push or pull
+	Remaining     []string  `json:"remaining"` // Task IDs (push) or issue numbers (pull)
+	InterruptedAt time.Time `json:"interrupted_at"`
+}
+
+func syncCheckpointKey(operation string) string {
+	if operation == "pull" {
+		return models.ConfigSyncCheckpointPull
+	}
+	return models.ConfigSyncCheckpointPush
+}
+
+// saveSyncCheckpoint stores the remaining items for --resume
+func saveSyncCheckpoint(operation string, remaining []string) error {
+	data, err := json.Marshal(syncCheckpoint{Operation: operation, Remaining: remaining, InterruptedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	return db.SetConfig(syncCheckpointKey(operation), string(data))
+}
+
+// loadSyncCheckpoint returns the checkpoint left by an interrupted sync
+func loadSyncCheckpoint(operation string) (*syncCheckpoint, error) {
+	value, err := db.GetConfig(syncCheckpointKey(operation))
+	if err != nil || value == "" {
+		return nil, fmt.Errorf("nothing to resume: no interrupted sync %s found", operation)
+	}
+	var cp syncCheckpoint
+	if err := json.Unmarshal([]byte(value), &cp); err != nil {
+		return nil, fmt.Errorf("invalid sync %s checkpoint: %w", operation, err)
+	}
+	return &cp, nil
+}
+
+// clearSyncCheckpoint removes a checkpoint once its items have been synced
+func clearSyncCheckpoint(operation string) {
+	db.GetDB().Where("key = ?", syncCheckpointKey(operation)).Delete(&models.Config{})
+}
+
+// finishInterruptedSync saves a checkpoint and reports what wasn't synced.
+// In text mode it returns an error so the command exits non-zero.
+func finishInterruptedSync(operation string, done int, remaining []string) error {
+	if err := saveSyncCheckpoint(operation, remaining); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save resume checkpoint: %v\n", err)
+	}
+	if IsJSONOutput() {
+		return nil
+	}
+
+	fmt.Printf("\nInterrupted after %d item(s); %d not synced:\n", done, len(remaining))
+	for _, item := range remaining {
+		fmt.Printf("  %s\n", item)
+	}
+	return fmt.Errorf("sync %s interrupted: run 'gur sync %s --resume' to continue", operation, operation)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSyncCheckpointRoundTrip(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if _, err := loadSyncCheckpoint("push"); err == nil {
+		t.Fatal("expected error loading a missing checkpoint")
+	}
+
+	remaining := []string{"gur-aaa111", "gur-bbb222"}
+	if err := saveSyncCheckpoint("push", remaining); err != nil {
+		t.Fatalf("saveSyncCheckpoint: %v", err)
+	}
+	if err := saveSyncCheckpoint("pull", []string{"12"}); err != nil {
+		t.Fatalf("saveSyncCheckpoint: %v", err)
+	}
+
+	cp, err := loadSyncCheckpoint("push")
+	if err != nil {
+		t.Fatalf("loadSyncCheckpoint: %v", err)
+	}
+	if cp.Operation != "push" || !reflect.DeepEqual(cp.Remaining, remaining) {
+		t.Errorf("checkpoint = %+v, want push %v", cp, remaining)
+	}
+	if cp.InterruptedAt.IsZero() {
+		t.Error("expected InterruptedAt to be set")
+	}
+
+	clearSyncCheckpoint("push")
+	if _, err := loadSyncCheckpoint("push"); err == nil {
+		t.Error("expected push checkpoint to be cleared")
+	}
+	if cp, err := loadSyncCheckpoint("pull"); err != nil || !reflect.DeepEqual(cp.Remaining, []string{"12"}) {
+		t.Errorf("pull checkpoint should be untouched, got %+v, %v", cp, err)
+	}
+}
+
+func TestSyncInterruptStop(t *testing.T) {
+	interrupt := watchSyncInterrupt()
+	if interrupt.Interrupted() {
+		t.Error("expected no interrupt before a signal")
+	}
+	interrupt.interrupted.Store(true)
+	if !interrupt.Interrupted() {
+		t.Error("expected Interrupted after flag set")
+	}
+	interrupt.Stop()
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"guardrails/internal/models"
+)
+
+// defaultMarkerWorkers bounds concurrent comment lookups during sync pull
+const defaultMarkerWorkers = 8
+
+var syncMarkerRegex = regexp.MustCompile(regexp.QuoteMeta(syncMarkerPrefix) + `(.+?)` + regexp.QuoteMeta(syncMarkerSuffix))
+
+// markerResult is the outcome of a sync-marker lookup for one issue
+type markerResult struct {
+	Marker *SyncMarker
+	Err    error
+	Cached bool // Served from the local cache or a 304 response
+}
+
+// markerJob is a pending lookup, carrying any cached entry for the issue
+type markerJob struct {
+	Issue *github.Issue
+	Cache *models.GitHubMarkerCache
+}
+
+// parseSyncMarker extracts a sync marker from a comment body, or nil if none
+func parseSyncMarker(body string) *SyncMarker {
+	matches := syncMarkerRegex.FindStringSubmatch(body)
+	if len(matches) < 2 {
+		return nil
+	}
+	var marker SyncMarker
+	if err := json.Unmarshal([]byte(matches[1]), &marker); err != nil {
+		return nil
+	}
+	return &marker
+}
+
+// decodeCachedMarker returns the marker stored in a cache entry, or nil
+func decodeCachedMarker(cache *models.GitHubMarkerCache) *SyncMarker {
+	if cache == nil || cache.Marker == "" {
+		return nil
+	}
+	var marker SyncMarker
+	if err := json.Unmarshal([]byte(cache.Marker), &marker); err != nil {
+		return nil
+	}
+	return &marker
+}
+
+// fetchSyncMarker reads an issue's comments looking for a sync marker.
+// If etag is set the request is conditional; notModified reports a 304.
+func fetchSyncMarker(ctx context.Context, client *github.Client, owner, repo string, issueNum int, etag string) (marker *SyncMarker, newETag string, notModified bool, err error) {
+	u := fmt.Sprintf("repos/%v/%v/issues/%d/comments?sort=created&direction=desc&per_page=50", owner, repo, issueNum)
+	req, err := client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	var comments []*github.IssueComment
+	resp, err := client.Do(ctx, req, &comments)
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return nil, etag, true, nil
+	}
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	for _, comment := range comments {
+		if m := parseSyncMarker(comment.GetBody()); m != nil {
+			return m, resp.Header.Get("ETag"), false, nil
+		}
+	}
+	return nil, resp.Header.Get("ETag"), false, nil
+}
+
+// scanSyncMarkers looks up sync markers for many issues using a bounded worker
+// pool. Lookups are skipped when the cache still matches the issue's comment
+// count and updated_at, and cached ETags make the remaining requests conditional.
+// Cache entries are refreshed in the database once all workers finish.
+func scanSyncMarkers(ctx context.Context, database *gorm.DB, client *github.Client, owner, repoName, repo string, issues []*github.Issue, workers int) map[int]markerResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make(map[int]markerResult, len(issues))
+	var jobs []markerJob
+
+	// Resolve cache hits up front so workers only touch the network
+	for _, issue := range issues {
+		var cache models.GitHubMarkerCache
+		var cached *models.GitHubMarkerCache
+		if database.Where("repository = ? AND issue_number = ?", repo, issue.GetNumber()).First(&cache).Error == nil {
+			cached = &cache
+		}
+
+		if cached != nil && cached.Matches(issue.GetComments(), issue.GetUpdatedAt().Time) {
+			results[issue.GetNumber()] = markerResult{Marker: decodeCachedMarker(cached), Cached: true}
+			continue
+		}
+		if issue.GetComments() == 0 {
+			results[issue.GetNumber()] = markerResult{}
+			if err := saveMarkerCache(database, repo, issue, "", nil); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to cache marker lookup for issue #%d: %v\n", issue.GetNumber(), err)
+			}
+			continue
+		}
+		jobs = append(jobs, markerJob{Issue: issue, Cache: cached})
+	}
+
+	type fetched struct {
+		job    markerJob
+		result markerResult
+		etag   string
+	}
+
+	jobCh := make(chan markerJob)
+	outCh := make(chan fetched)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				etag := ""
+				if job.Cache != nil {
+					etag = job.Cache.ETag
+				}
+				marker, newETag, notModified, err := fetchSyncMarker(ctx, client, owner, repoName, job.Issue.GetNumber(), etag)
+				res := markerResult{Marker: marker, Err: err}
+				if notModified {
+					res = markerResult{Marker: decodeCachedMarker(job.Cache), Cached: true}
+				}
+				outCh <- fetched{job: job, result: res, etag: newETag}
+			}
+		}()
+	}
+
+	go func() {
+		for _, job := range jobs {
+			jobCh <- job
+		}
+		close(jobCh)
+		wg.Wait()
+		close(outCh)
+	}()
+
+	var toCache []fetched
+	for f := range outCh {
+		results[f.job.Issue.GetNumber()] = f.result
+		if f.result.Err == nil {
+			toCache = append(toCache, f)
+		}
+	}
+
+	for _, f := range toCache {
+		if err := saveMarkerCache(database, repo, f.job.Issue, f.etag, f.result.Marker); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to cache marker lookup for issue #%d: %v\n", f.job.Issue.GetNumber(), err)
+		}
+	}
+
+	return results
+}
+
+// saveMarkerCache upserts the cached marker lookup for an issue
+func saveMarkerCache(database *gorm.DB, repo string, issue *github.Issue, etag string, marker *SyncMarker) error {
+	entry := models.GitHubMarkerCache{
+		Repository:     repo,
+		IssueNumber:    issue.GetNumber(),
+		CommentCount:   issue.GetComments(),
+		IssueUpdatedAt: issue.GetUpdatedAt().Time,
+		ETag:           etag,
+		CheckedAt:      time.Now(),
+	}
+	if marker != nil {
+		if data, err := json.Marshal(marker); err == nil {
+			entry.Marker = string(data)
+		}
+	}
+	return database.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "repository"}, {Name: "issue_number"}},
+		DoUpdates: clause.AssignmentColumns([]string{"comment_count", "issue_updated_at", "etag", "marker", "checked_at"}),
+	}).Create(&entry).Error
+}
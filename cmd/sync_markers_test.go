@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestParseSyncMarker(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		wantID string
+	}{
+		{"no marker", "Just a comment", ""},
+		{"valid marker", `Synced <!-- gur-sync:{"task_id":"gur-a1b2c3d4","user":"bob"} -->`, "gur-a1b2c3d4"},
+		{"invalid json", `<!-- gur-sync:{not json} -->`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := parseSyncMarker(tt.body)
+			if tt.wantID == "" {
+				if m != nil {
+					t.Errorf("parseSyncMarker() = %+v, want nil", m)
+				}
+				return
+			}
+			if m == nil || m.TaskID != tt.wantID {
+				t.Errorf("parseSyncMarker() = %+v, want task %s", m, tt.wantID)
+			}
+		})
+	}
+}
+
+func newTestGitHubClient(t *testing.T, handler http.Handler) *github.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	base, _ := url.Parse(server.URL + "/")
+	client.BaseURL = base
+	return client
+}
+
+func TestScanSyncMarkersUsesCache(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	var requests, conditional int32
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&conditional, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"body":"<!-- gur-sync:{\"task_id\":\"gur-a1b2c3d4\",\"user\":\"bob\"} -->"}]`))
+	}))
+
+	updated := github.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	issues := []*github.Issue{
+		{Number: github.Int(1), Comments: github.Int(2), UpdatedAt: &updated},
+		{Number: github.Int(2), Comments: github.Int(0), UpdatedAt: &updated},
+	}
+
+	ctx := context.Background()
+	database := db.GetDB()
+
+	// First scan hits the API for issue 1 only (issue 2 has no comments)
+	results := scanSyncMarkers(ctx, database, client, "o", "r", "o/r", issues, 4)
+	if results[1].Marker == nil || results[1].Marker.TaskID != "gur-a1b2c3d4" {
+		t.Fatalf("issue 1 marker = %+v, want gur-a1b2c3d4", results[1].Marker)
+	}
+	if results[2].Marker != nil {
+		t.Errorf("issue 2 marker = %+v, want nil", results[2].Marker)
+	}
+	if requests != 1 {
+		t.Errorf("first scan made %d requests, want 1", requests)
+	}
+
+	// Unchanged issue is served from the cache without a request
+	results = scanSyncMarkers(ctx, database, client, "o", "r", "o/r", issues, 4)
+	if !results[1].Cached || results[1].Marker == nil {
+		t.Errorf("second scan issue 1 = %+v, want cached marker", results[1])
+	}
+	if requests != 1 {
+		t.Errorf("second scan made %d requests total, want 1", requests)
+	}
+
+	// A changed issue triggers a conditional request
+	later := github.Timestamp{Time: updated.Add(time.Hour)}
+	issues[0].UpdatedAt = &later
+	results = scanSyncMarkers(ctx, database, client, "o", "r", "o/r", issues, 4)
+	if conditional != 1 {
+		t.Errorf("conditional requests = %d, want 1", conditional)
+	}
+	if results[1].Marker == nil {
+		t.Error("304 response should reuse cached marker")
+	}
+
+	var cache models.GitHubMarkerCache
+	database.Where("repository = ? AND issue_number = ?", "o/r", 1).First(&cache)
+	if !cache.IssueUpdatedAt.Equal(later.Time) {
+		t.Errorf("cache updated_at = %v, want %v", cache.IssueUpdatedAt, later.Time)
+	}
+}
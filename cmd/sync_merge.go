@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v63/github"
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// syncConflictLabel marks tasks whose description has unresolved conflicts
+// from a pull merge
+const syncConflictLabel = "needs-attention"
+
+// syncMergeBy is recorded in task history for descriptions changed by pull
+const syncMergeBy = "github:merge"
+
+// Conflict markers written into a merged description
+const (
+	conflictStart = "<<<<<<< local"
+	conflictSep   = "======="
+	conflictEnd   = ">>>>>>> github"
+)
+
+// Description sync outcomes for linked issues
+const (
+	descUpdated  = "updated"  // Only GitHub changed: took its description
+	descMerged   = "merged"   // Both changed without overlapping
+	descConflict = "conflict" // Both changed the same lines
+)
+
+// descriptionSync is a linked task whose description pull changed
+type descriptionSync struct {
+	TaskID      string `json:"task_id"`
+	IssueNumber int    `json:"issue_number"`
+	Action      string `json:"action"` // updated, merged or conflict
+}
+
+// verb describes the outcome for pull output
+func (d descriptionSync) verb(dryRun bool) string {
+	switch {
+	case d.Action == descConflict && dryRun:
+		return "Would conflict"
+	case d.Action == descConflict:
+		return "Conflict"
+	case dryRun:
+		return "Would " + strings.TrimSuffix(d.Action, "d")
+	default:
+		return strings.ToUpper(d.Action[:1]) + d.Action[1:]
+	}
+}
+
+// countDescriptionConflicts counts the descriptions left with conflicts
+func countDescriptionConflicts(descriptions []descriptionSync) int {
+	n := 0
+	for _, d := range descriptions {
+		if d.Action == descConflict {
+			n++
+		}
+	}
+	return n
+}
+
+// linkedIssue is a fetched issue already linked to a task
+type linkedIssue struct {
+	link  models.GitHubIssueLink
+	issue *github.Issue
+}
+
+// issueBodyDescription returns the task description carried by an issue
+// body: the Description section of the built-in body, or the whole body of
+// an issue gur never wrote. ok is false for a body rendered from a custom
+// template, which the description can't be recovered from.
+func issueBodyDescription(body string, pushed bool) (desc string, ok bool) {
+	_, body = parseRankMarker(strings.ReplaceAll(body, "\r\n", "\n"))
+	_, body = parseSyncVector(body)
+	if strings.HasPrefix(body, "**Task ID:** `") && strings.Contains(body, issueBodyFooter) {
+		_, rest, found := strings.Cut(body, "## Description\n\n")
+		if !found {
+			return "", true
+		}
+		if end := strings.LastIndex(rest, "\n\n## Details\n\n"); end >= 0 {
+			rest = rest[:end]
+		}
+		return rest, true
+	}
+	if pushed {
+		return "", false
+	}
+	return body, true
+}
+
+// commonLines returns, for each line of a, the index of the line of b it is
+// matched with in a longest common subsequence, or -1
+func commonLines(a, b []string) []int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	match := make([]int, len(a))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			match[i] = j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			match[i] = -1
+			i++
+		default:
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		match[i] = -1
+	}
+	return match
+}
+
+// mergeDescriptions merges the local and remote edits of base line by line.
+// Where both changed the same lines, both versions are kept between
+// conflict markers and conflict is true.
+func mergeDescriptions(base, local, remote string) (merged string, conflict bool) {
+	if local == remote || remote == base {
+		return local, false
+	}
+	if local == base {
+		return remote, false
+	}
+	b, l, r := strings.Split(base, "\n"), strings.Split(local, "\n"), strings.Split(remote, "\n")
+	toLocal, toRemote := commonLines(b, l), commonLines(b, r)
+
+	var out []string
+	li, ri := 0, 0
+	bi := 0
+	for bi <= len(b) {
+		// The next base line kept by both sides, or the end
+		next := bi
+		for next < len(b) && (toLocal[next] < 0 || toRemote[next] < 0) {
+			next++
+		}
+		lEnd, rEnd := len(l), len(r)
+		if next < len(b) {
+			lEnd, rEnd = toLocal[next], toRemote[next]
+		}
+		baseChunk, localChunk, remoteChunk := b[bi:next], l[li:lEnd], r[ri:rEnd]
+		switch {
+		case equalLines(localChunk, remoteChunk), equalLines(remoteChunk, baseChunk):
+			out = append(out, localChunk...)
+		case equalLines(localChunk, baseChunk):
+			out = append(out, remoteChunk...)
+		default:
+			conflict = true
+			out = append(out, conflictStart)
+			out = append(out, localChunk...)
+			out = append(out, conflictSep)
+			out = append(out, remoteChunk...)
+			out = append(out, conflictEnd)
+		}
+		if next == len(b) {
+			break
+		}
+		out = append(out, b[next])
+		bi, li, ri = next+1, lEnd+1, rEnd+1
+	}
+	return strings.Join(out, "\n"), conflict
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasConflictMarkers reports whether a description still holds conflict
+// markers from a pull merge
+func hasConflictMarkers(desc string) bool {
+	for _, line := range strings.Split(desc, "\n") {
+		if line == conflictStart || line == conflictEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// pullDescription brings a linked task's description up to date with its
+// issue. If only GitHub changed it since the last sync, GitHub's version is
+// taken; if both did, they are merged against the last-synced description,
+// and overlapping edits are left between conflict markers with the task
+// labeled needs-attention. It returns nil when nothing changed.
+func pullDescription(database *gorm.DB, link *models.GitHubIssueLink, issue *github.Issue, dryRun bool) (*descriptionSync, error) {
+	body := issue.GetBody()
+	if body == link.SyncedBody {
+		return nil, nil
+	}
+	remote, ok := issueBodyDescription(body, link.ContentHash != "")
+	if !ok {
+		return nil, nil
+	}
+	remote = unlinkTaskMentions(remote)
+	var task models.Task
+	if err := database.Where("id = ?", link.TaskID).First(&task).Error; err != nil {
+		return nil, fmt.Errorf("failed to load task '%s': database error: %w", link.TaskID, err)
+	}
+
+	// Links synced before bases were recorded get one once both sides agree
+	if link.SyncedBody == "" && task.Description != remote {
+		return nil, nil
+	}
+
+	merged, conflict := mergeDescriptions(link.SyncedDesc, task.Description, remote)
+	var result *descriptionSync
+	if merged != task.Description {
+		action := descMerged
+		switch {
+		case conflict:
+			action = descConflict
+		case merged == remote:
+			action = descUpdated
+		}
+		result = &descriptionSync{TaskID: task.ID, IssueNumber: link.IssueNumber, Action: action}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	return result, database.Transaction(func(tx *gorm.DB) error {
+		if result != nil {
+			models.RecordChange(tx, task.ID, "description", task.Description, merged, syncMergeBy)
+			task.Description = merged
+			if conflict && !task.HasLabel(syncConflictLabel) {
+				models.RecordChange(tx, task.ID, "label_added", "", syncConflictLabel, syncMergeBy)
+				task.AddLabel(syncConflictLabel)
+			}
+			if err := tx.Save(&task).Error; err != nil {
+				return fmt.Errorf("failed to update task '%s': database error: %w", task.ID, err)
+			}
+			noteAffected(task.ID)
+		}
+		link.SyncedBody, link.SyncedDesc = body, remote
+		return tx.Model(link).Updates(map[string]interface{}{"synced_body": body, "synced_desc": remote}).Error
+	})
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestMergeDescriptions(t *testing.T) {
+	base := "Intro\n\nStep one\nStep two\n\nOutro"
+	tests := []struct {
+		name, local, remote, want string
+		conflict                  bool
+	}{
+		{"only remote changed", base, "Intro\n\nStep one\nStep 2\n\nOutro", "Intro\n\nStep one\nStep 2\n\nOutro", false},
+		{"only local changed", "Intro!\n\nStep one\nStep two\n\nOutro", base, "Intro!\n\nStep one\nStep two\n\nOutro", false},
+		{"separate lines", "Intro!\n\nStep one\nStep two\n\nOutro", "Intro\n\nStep one\nStep two\n\nOutro.\nPS", "Intro!\n\nStep one\nStep two\n\nOutro.\nPS", false},
+		{"same edit", "Intro\n\nStep 1\nStep two\n\nOutro", "Intro\n\nStep 1\nStep two\n\nOutro", "Intro\n\nStep 1\nStep two\n\nOutro", false},
+		{"overlapping", "Intro\n\nStep one (local)\nStep two\n\nOutro", "Intro\n\nStep one (remote)\nStep two\n\nOutro",
+			"Intro\n\n" + conflictStart + "\nStep one (local)\n" + conflictSep + "\nStep one (remote)\n" + conflictEnd + "\nStep two\n\nOutro", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflict := mergeDescriptions(base, tt.local, tt.remote)
+			if got != tt.want || conflict != tt.conflict {
+				t.Errorf("merge = %q (conflict %v), want %q (conflict %v)", got, conflict, tt.want, tt.conflict)
+			}
+			if hasConflictMarkers(got) != tt.conflict {
+				t.Errorf("hasConflictMarkers = %v, want %v", !tt.conflict, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestIssueBodyDescription(t *testing.T) {
+	task := models.Task{ID: "gur-body0001", Description: "Line one\n\nLine two", Priority: 2, Type: models.TypeTask, Status: models.StatusOpen, Rank: 3}
+	body := buildIssueBody(task) + rankMarker(task.Rank)
+	if desc, ok := issueBodyDescription(strings.ReplaceAll(body, "\n", "\r\n"), true); !ok || desc != task.Description {
+		t.Errorf("built-in body description = %q, %v; want %q", desc, ok, task.Description)
+	}
+	if desc, ok := issueBodyDescription("Written on GitHub", false); !ok || desc != "Written on GitHub" {
+		t.Errorf("plain body description = %q, %v", desc, ok)
+	}
+	if _, ok := issueBodyDescription("# Custom template output", true); ok {
+		t.Error("a pushed body rendered from a custom template should not be mergeable")
+	}
+}
+
+func TestPullDescription(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	base := "Goal\n\nDetails"
+	database.Create(&models.Task{ID: "gur-merg0001", Title: "Both edited", Description: "Goal (local)\n\nDetails", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-merg0002", Title: "Remote edited", Description: base, Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-merg0003", Title: "Legacy link", Description: "Local", Status: models.StatusOpen})
+	links := []models.GitHubIssueLink{
+		{TaskID: "gur-merg0001", IssueNumber: 1, Repository: "o/r", SyncedBody: base, SyncedDesc: base},
+		{TaskID: "gur-merg0002", IssueNumber: 2, Repository: "o/r", SyncedBody: base, SyncedDesc: base},
+		{TaskID: "gur-merg0003", IssueNumber: 3, Repository: "o/r"},
+	}
+	for i := range links {
+		database.Create(&links[i])
+	}
+
+	issue := func(body string) *github.Issue { return &github.Issue{Body: github.String(body)} }
+
+	d, err := pullDescription(database, &links[0], issue("Goal (remote)\n\nDetails"), false)
+	if err != nil || d == nil || d.Action != descConflict {
+		t.Fatalf("pullDescription both edited = %+v, %v; want a conflict", d, err)
+	}
+	var task models.Task
+	database.First(&task, "id = ?", "gur-merg0001")
+	if !hasConflictMarkers(task.Description) || !task.HasLabel(syncConflictLabel) {
+		t.Errorf("task = %q %v, want conflict markers and the %s label", task.Description, task.Labels, syncConflictLabel)
+	}
+	var link models.GitHubIssueLink
+	database.First(&link, "task_id = ?", "gur-merg0001")
+	if link.SyncedDesc != "Goal (remote)\n\nDetails" {
+		t.Errorf("base after merge = %q, want the GitHub description", link.SyncedDesc)
+	}
+	if d, _ := pullDescription(database, &link, issue("Goal (remote)\n\nDetails"), false); d != nil {
+		t.Errorf("unchanged issue body should be a no-op, got %+v", d)
+	}
+
+	d, err = pullDescription(database, &links[1], issue("Goal\n\nMore details"), true)
+	if err != nil || d == nil || d.Action != descUpdated {
+		t.Fatalf("dry-run pullDescription remote edited = %+v, %v", d, err)
+	}
+	var remoteEdited models.Task
+	database.First(&remoteEdited, "id = ?", "gur-merg0002")
+	if remoteEdited.Description != base {
+		t.Errorf("dry run changed the description to %q", remoteEdited.Description)
+	}
+	if d, _ = pullDescription(database, &links[1], issue("Goal\n\nMore details"), false); d == nil || d.Action != descUpdated {
+		t.Fatalf("pullDescription remote edited = %+v", d)
+	}
+	remoteEdited = models.Task{}
+	database.First(&remoteEdited, "id = ?", "gur-merg0002")
+	if remoteEdited.Description != "Goal\n\nMore details" {
+		t.Errorf("description = %q, want GitHub's", remoteEdited.Description)
+	}
+
+	if d, _ := pullDescription(database, &links[2], issue("Remote"), false); d != nil {
+		t.Errorf("a link without a base should not be merged, got %+v", d)
+	}
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// Limits on automatic pushes, so a burst of commands doesn't turn into a
+// burst of GitHub requests
+const (
+	autoPushInterval   = time.Minute      // A task is pushed at most once per interval
+	autoPushBatch      = 10               // Most tasks pushed at the end of one command
+	autoPushTimeout    = 30 * time.Second // Longest a command waits on its pushes
+	autoPushMaxBackoff = time.Hour        // Longest wait before retrying a failed push
+)
+
+// autoPushCloseCommands are the commands that queue pushes in on_close mode
+var autoPushCloseCommands = map[string]bool{
+	"close":         true,
+	"close-batch":   true,
+	"approve close": true,
+}
+
+var (
+	syncOutboxFlush bool
+	syncOutboxClear bool
+)
+
+var syncOutboxCmd = &cobra.Command{
+	Use:   "outbox",
+	Short: "Show or flush tasks queued for automatic push",
+	Long: `With automatic pushes on ('gur config sync --auto-push'), tasks changed by a
+command are queued here and pushed when the command ends. Pushes are
+rate-limited: a task is pushed at most once a minute and at most 10 tasks at
+the end of a command; the rest wait for the next command. Failed pushes stay
+queued and are retried with a growing delay.
+
+--flush pushes everything queued now, ignoring the limits; --clear empties
+the queue without pushing.
+
+Examples:
+  gur sync outbox
+  gur sync outbox --flush
+  gur sync outbox --clear`,
+	Args: cobra.NoArgs,
+	RunE: runSyncOutbox,
+}
+
+func init() {
+	syncCmd.AddCommand(syncOutboxCmd)
+	syncOutboxCmd.Flags().BoolVar(&syncOutboxFlush, "flush", false, "Push all queued tasks now")
+	syncOutboxCmd.Flags().BoolVar(&syncOutboxClear, "clear", false, "Remove all queued tasks without pushing")
+}
+
+// autoPushMode returns the configured auto push mode
+func autoPushMode() string {
+	mode, _ := db.GetConfig(models.ConfigSyncAutoPush)
+	if mode == "" {
+		return models.AutoPushOff
+	}
+	return mode
+}
+
+// queueAutoPush queues the tasks a successful command changed for an
+// automatic push, per the configured mode
+func queueAutoPush(database *gorm.DB, cmd *cobra.Command, args []string, runErr error) error {
+	if runErr != nil || !isLoggedCommand(cmd) {
+		return nil
+	}
+	name := eventCommandName(cmd)
+	if strings.HasPrefix(name, "sync ") || name == "sync" {
+		return nil
+	}
+	switch autoPushMode() {
+	case models.AutoPushOnChange:
+	case models.AutoPushOnClose:
+		if !autoPushCloseCommands[name] {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	for _, id := range append(append([]string{}, args...), commandAffected...) {
+		if seen[id] || !models.ValidateTaskID(id) {
+			continue
+		}
+		seen[id] = true
+		entry := models.GitHubOutboxEntry{TaskID: id, QueuedAt: now, NextAttempt: now}
+		var link models.GitHubIssueLink
+		if database.Where("task_id = ?", id).First(&link).Error == nil && now.Sub(link.LastSyncedAt) < autoPushInterval {
+			entry.NextAttempt = link.LastSyncedAt.Add(autoPushInterval)
+		}
+		// A task already queued keeps its place and any retry delay
+		if err := database.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outboxResult is the outcome of pushing a queued task
+type outboxResult struct {
+	TaskID   string `json:"task_id"`
+	Action   string `json:"action"` // created, updated, skipped, dropped or failed
+	IssueURL string `json:"issue_url,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// autoPushBackoff returns how long to wait before retrying a push that
+// has failed attempts times
+func autoPushBackoff(attempts int) time.Duration {
+	wait := autoPushInterval
+	for i := 1; i < attempts && wait < autoPushMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > autoPushMaxBackoff {
+		wait = autoPushMaxBackoff
+	}
+	return wait
+}
+
+// flushOutbox pushes up to limit queued tasks (0 for all) that are due at
+// now, or all of them with force. Pushed tasks leave the queue; failures
+// stay queued with a retry delay. Tasks deleted or moved to the inbox
+// since they were queued are dropped.
+func flushOutbox(ctx context.Context, database *gorm.DB, client *github.Client, owner, repo, prefix string, now time.Time, force bool, limit int) ([]outboxResult, error) {
+	query := database.Order("queued_at ASC, task_id ASC")
+	if !force {
+		query = query.Where("next_attempt <= ?", now)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var entries []models.GitHubOutboxEntry
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	var results []outboxResult
+	tasks := make([]models.Task, 0, len(entries))
+	for _, e := range entries {
+		var task models.Task
+		if database.Where("id = ?", e.TaskID).First(&task).Error != nil || task.Status == models.StatusInbox {
+			database.Delete(&e)
+			results = append(results, outboxResult{TaskID: e.TaskID, Action: "dropped"})
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 {
+		return results, nil
+	}
+	if err := attachFieldValues(database, tasks); err != nil {
+		return results, err
+	}
+	if err := attachAcceptanceCriteria(database, tasks); err != nil {
+		return results, err
+	}
+
+	bodyTmpl, err := loadIssueBodyTemplate()
+	if err != nil {
+		return results, err
+	}
+	labelMap, err := loadLabelMap()
+	if err != nil {
+		return results, err
+	}
+	milestones, err := milestonesByDueDate(ctx, client, owner, repo, tasks)
+	if err != nil {
+		return results, err
+	}
+	hierarchy, err := loadIssueHierarchy()
+	if err != nil {
+		return results, err
+	}
+	if err := hierarchy.loadIssueTypes(ctx, client, owner); err != nil {
+		return results, err
+	}
+
+	for _, task := range tasks {
+		result, err := syncTaskToGitHub(ctx, client, owner, repo, prefix, labelMap, milestones, bodyTmpl, hierarchy, task)
+		if err != nil {
+			var entry models.GitHubOutboxEntry
+			database.Where("task_id = ?", task.ID).First(&entry)
+			entry.Attempts++
+			entry.LastError = err.Error()
+			entry.NextAttempt = now.Add(autoPushBackoff(entry.Attempts))
+			database.Save(&entry)
+			results = append(results, outboxResult{TaskID: task.ID, Action: "failed", Error: err.Error()})
+			continue
+		}
+		database.Where("task_id = ?", task.ID).Delete(&models.GitHubOutboxEntry{})
+		results = append(results, outboxResult{TaskID: task.ID, Action: result["action"].(string), IssueURL: fmt.Sprint(result["issue_url"])})
+	}
+	return results, nil
+}
+
+// runAutoPush queues the tasks a command changed and pushes the queued
+// tasks that are due. It runs after every mutating command, so problems are
+// warnings: the queue keeps what wasn't pushed.
+func runAutoPush(cmd *cobra.Command, args []string, runErr error) {
+	database := db.GetDB()
+	if database == nil || commandStartedAt.IsZero() || !isLoggedCommand(cmd) {
+		return
+	}
+	if err := queueAutoPush(database, cmd, args, runErr); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to queue automatic push: %v\n", err)
+		return
+	}
+	if autoPushMode() == models.AutoPushOff || strings.HasPrefix(eventCommandName(cmd), "sync") {
+		return
+	}
+	var due int64
+	if database.Model(&models.GitHubOutboxEntry{}).Where("next_attempt <= ?", time.Now()).Count(&due); due == 0 {
+		return
+	}
+
+	client, owner, repo, prefix, err := githubPushTarget()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: automatic push skipped: %v\n", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), autoPushTimeout)
+	defer cancel()
+	results, err := flushOutbox(ctx, database, client, owner, repo, prefix, time.Now(), false, autoPushBatch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: automatic push failed: %v\n", err)
+		return
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "Warning: automatic push of %s failed, will retry: %s (see 'gur sync outbox')\n", r.TaskID, r.Error)
+		}
+	}
+}
+
+func runSyncOutbox(cmd *cobra.Command, args []string) error {
+	database := db.GetDB()
+
+	if syncOutboxClear {
+		result := database.Where("1 = 1").Delete(&models.GitHubOutboxEntry{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to clear outbox: database error: %w", result.Error)
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "cleared": result.RowsAffected})
+		} else {
+			fmt.Printf("Cleared %d queued push(es)\n", result.RowsAffected)
+		}
+		return nil
+	}
+
+	if syncOutboxFlush {
+		client, owner, repo, prefix, err := githubPushTarget()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		results, err := flushOutbox(ctx, database, client, owner, repo, prefix, time.Now(), true, 0)
+		if err != nil {
+			return fmt.Errorf("failed to flush outbox: %w", err)
+		}
+		failed := 0
+		for _, r := range results {
+			if r.Error != "" {
+				failed++
+			}
+		}
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": failed == 0, "results": results})
+			return nil
+		}
+		for _, r := range results {
+			switch {
+			case r.Error != "":
+				fmt.Printf("Failed: %s: %s\n", r.TaskID, r.Error)
+			case r.Action == "dropped":
+				fmt.Printf("Dropped: %s (deleted or in the inbox)\n", r.TaskID)
+			default:
+				fmt.Printf("Pushed: %s -> %s (%s)\n", r.TaskID, r.IssueURL, r.Action)
+			}
+		}
+		fmt.Printf("\nFlushed %d queued push(es), %d failed\n", len(results), failed)
+		return nil
+	}
+
+	var entries []models.GitHubOutboxEntry
+	if err := database.Order("queued_at ASC, task_id ASC").Find(&entries).Error; err != nil {
+		return fmt.Errorf("failed to read outbox: database error: %w", err)
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"mode": autoPushMode(), "count": len(entries), "entries": entries})
+		return nil
+	}
+	if IsQuietOutput() {
+		for _, e := range entries {
+			printQuietIDs(e.TaskID)
+		}
+		return nil
+	}
+	fmt.Printf("Auto push: %s\n", autoPushMode())
+	if len(entries) == 0 {
+		fmt.Println("Outbox is empty")
+		return nil
+	}
+	now := time.Now()
+	for _, e := range entries {
+		when := "due"
+		if e.NextAttempt.After(now) {
+			when = "after " + e.NextAttempt.Format(models.DateTimeShortFormat)
+		}
+		fmt.Printf("  %s  queued %s, %s", e.TaskID, e.QueuedAt.Format(models.DateTimeShortFormat), when)
+		if e.LastError != "" {
+			fmt.Printf(" (%d failed attempt(s): %s)", e.Attempts, e.LastError)
+		}
+		fmt.Println()
+	}
+	return nil
+}
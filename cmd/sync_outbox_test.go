@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestQueueAutoPush(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	// Earlier tests leave the IDs their commands touched behind
+	commandAffected = nil
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-0b000001", Title: "Closed", Status: models.StatusClosed})
+	database.Create(&models.Task{ID: "gur-0b000002", Title: "Changed", Status: models.StatusOpen})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-0b000002", IssueNumber: 2, Repository: "acme/app", LastSyncedAt: time.Now()})
+	queued := func() map[string]models.GitHubOutboxEntry {
+		var entries []models.GitHubOutboxEntry
+		database.Find(&entries)
+		m := make(map[string]models.GitHubOutboxEntry)
+		for _, e := range entries {
+			m[e.TaskID] = e
+		}
+		return m
+	}
+
+	queueAutoPush(database, closeCmd, []string{"gur-0b000001"}, nil)
+	if len(queued()) != 0 {
+		t.Fatal("queued a push with auto push off")
+	}
+
+	db.SetConfig(models.ConfigSyncAutoPush, models.AutoPushOnClose)
+	queueAutoPush(database, updateCmd, []string{"gur-0b000002"}, nil)
+	queueAutoPush(database, closeCmd, []string{"gur-0b000001"}, fmt.Errorf("gates pending"))
+	if len(queued()) != 0 {
+		t.Fatal("on_close queued an update or a failed close")
+	}
+	queueAutoPush(database, closeCmd, []string{"gur-0b000001", "not-a-task"}, nil)
+	if q := queued(); len(q) != 1 || q["gur-0b000001"].NextAttempt.After(time.Now()) {
+		t.Fatalf("queue after close = %+v, want gur-0b000001 due now", q)
+	}
+
+	db.SetConfig(models.ConfigSyncAutoPush, models.AutoPushOnChange)
+	queueAutoPush(database, updateCmd, []string{"gur-0b000002"}, nil)
+	if e, ok := queued()["gur-0b000002"]; !ok || !e.NextAttempt.After(time.Now()) {
+		t.Errorf("entry for a task pushed just now = %+v, want it held back", e)
+	}
+}
+
+func TestFlushOutbox(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, r.ContentLength)
+		r.Body.Read(body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/issues":
+			if strings.Contains(string(body), "Broken") {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"message": "Validation Failed"}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"number": 7, "html_url": "https://github.com/acme/app/issues/7"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	database := db.GetDB()
+	now := time.Now()
+	database.Create(&models.Task{ID: "gur-0b000010", Title: "Works", Status: models.StatusOpen, Type: models.TypeTask})
+	database.Create(&models.Task{ID: "gur-0b000011", Title: "Broken", Status: models.StatusOpen, Type: models.TypeTask})
+	database.Create(&models.Task{ID: "gur-0b000012", Title: "Idea", Status: models.StatusInbox, Type: models.TypeTask})
+	database.Create(&models.Task{ID: "gur-0b000013", Title: "Later", Status: models.StatusOpen, Type: models.TypeTask})
+	for i, id := range []string{"gur-0b000010", "gur-0b000011", "gur-0b000012"} {
+		database.Create(&models.GitHubOutboxEntry{TaskID: id, QueuedAt: now.Add(time.Duration(i) * time.Second), NextAttempt: now})
+	}
+	database.Create(&models.GitHubOutboxEntry{TaskID: "gur-0b000013", QueuedAt: now, NextAttempt: now.Add(time.Minute)})
+
+	results, err := flushOutbox(context.Background(), database, client, "acme", "app", "[Agent]", now, false, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actions := make(map[string]string)
+	for _, r := range results {
+		actions[r.TaskID] = r.Action
+	}
+	want := map[string]string{"gur-0b000010": "created", "gur-0b000011": "failed", "gur-0b000012": "dropped"}
+	if fmt.Sprint(actions) != fmt.Sprint(want) {
+		t.Errorf("actions = %v, want %v", actions, want)
+	}
+
+	var left []models.GitHubOutboxEntry
+	database.Order("task_id ASC").Find(&left)
+	if len(left) != 2 || left[0].TaskID != "gur-0b000011" || left[1].TaskID != "gur-0b000013" {
+		t.Fatalf("outbox after flush = %+v, want the failed and the held-back task", left)
+	}
+	if left[0].Attempts != 1 || !left[0].NextAttempt.Equal(now.Add(autoPushInterval)) || left[0].LastError == "" {
+		t.Errorf("failed entry = %+v, want one attempt retried after the interval", left[0])
+	}
+}
+
+func TestAutoPushBackoff(t *testing.T) {
+	if got := autoPushBackoff(1); got != autoPushInterval {
+		t.Errorf("backoff(1) = %v, want %v", got, autoPushInterval)
+	}
+	if got := autoPushBackoff(3); got != 4*autoPushInterval {
+		t.Errorf("backoff(3) = %v, want %v", got, 4*autoPushInterval)
+	}
+	if got := autoPushBackoff(20); got != autoPushMaxBackoff {
+		t.Errorf("backoff(20) = %v, want %v", got, autoPushMaxBackoff)
+	}
+}
@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// projectCallsPerTask approximates API calls per task: issue lookup, add
+// item, status and priority updates
+const projectCallsPerTask = 4
+
+var syncProjectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Place synced issues on a GitHub Projects (v2) board",
+	Long: `Add the GitHub issues of synced tasks to a Projects (v2) board and set
+their fields: the board's Status field from the task status and a custom
+priority field from the task priority.
+
+Status options are matched by name (case-insensitive):
+  open         Todo
+  in_progress  In Progress
+  blocked      Blocked, falling back to Todo
+  closed       Done
+
+The priority field may be a single select (options named P0-P4 or
+Critical/High/Medium/Low/Lowest), a number or a text field. Fields missing
+from the board are skipped with a warning.
+
+Run 'gur sync push' first; only tasks linked to issues in the configured
+repository are placed. The token needs the 'project' scope (classic) or
+Projects: Read and write (fine-grained).
+
+Examples:
+  gur sync project --project 3
+  gur sync project --project 3 --owner my-org --priority-field Urgency
+  gur sync project --project 3 --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runSyncProject,
+}
+
+var (
+	syncProjectNumber        int
+	syncProjectOwner         string
+	syncProjectStatusField   string
+	syncProjectPriorityField string
+	syncProjectDryRun        bool
+)
+
+func init() {
+	syncCmd.AddCommand(syncProjectCmd)
+	syncProjectCmd.Flags().IntVar(&syncProjectNumber, "project", 0, "Project number (from the project URL)")
+	syncProjectCmd.Flags().StringVar(&syncProjectOwner, "owner", "", "User or organization that owns the project (default: repository owner)")
+	syncProjectCmd.Flags().StringVar(&syncProjectStatusField, "status-field", "Status", "Single-select project field for the task status")
+	syncProjectCmd.Flags().StringVar(&syncProjectPriorityField, "priority-field", "Priority", "Project field for the task priority")
+	syncProjectCmd.Flags().BoolVar(&syncProjectDryRun, "dry-run", false, "Show what would be placed without changing the project")
+	syncProjectCmd.MarkFlagRequired("project")
+}
+
+// projectStatusOptions lists the Status option names tried for each task
+// status, in order of preference
+var projectStatusOptions = map[string][]string{
+	models.StatusOpen:       {"Todo", "To do", "Backlog"},
+	models.StatusInProgress: {"In Progress", "In progress", "Doing"},
+	models.StatusBlocked:    {"Blocked", "Todo"},
+	models.StatusClosed:     {"Done", "Closed"},
+	models.StatusArchived:   {"Done", "Closed"},
+}
+
+// priorityOptionNames are the alternative option names for P0-P4
+var priorityOptionNames = []string{"Critical", "High", "Medium", "Low", "Lowest"}
+
+// projectV2 is a Projects (v2) board and its fields
+type projectV2 struct {
+	ID     string
+	Title  string
+	Fields []projectV2Field
+}
+
+// projectV2Field is a board field; Options is set for single-select fields
+type projectV2Field struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	DataType string `json:"dataType"` // TEXT, NUMBER, SINGLE_SELECT, ...
+	Options  []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"options"`
+}
+
+// field returns the board field with the given name (case-insensitive)
+func (p *projectV2) field(name string) *projectV2Field {
+	for i := range p.Fields {
+		if strings.EqualFold(p.Fields[i].Name, name) {
+			return &p.Fields[i]
+		}
+	}
+	return nil
+}
+
+// option returns the ID of the first option matching one of names
+func (f *projectV2Field) option(names ...string) string {
+	for _, name := range names {
+		for _, o := range f.Options {
+			if strings.EqualFold(o.Name, name) {
+				return o.ID
+			}
+		}
+	}
+	return ""
+}
+
+// projectFieldValue builds the ProjectV2FieldValue input setting f to the
+// task's status or priority; ok is false if nothing matches
+func projectFieldValue(f *projectV2Field, optionNames []string, number int, text string) (map[string]interface{}, bool) {
+	switch f.DataType {
+	case "SINGLE_SELECT":
+		if id := f.option(optionNames...); id != "" {
+			return map[string]interface{}{"singleSelectOptionId": id}, true
+		}
+	case "NUMBER":
+		return map[string]interface{}{"number": number}, true
+	case "TEXT":
+		return map[string]interface{}{"text": text}, true
+	}
+	return nil, false
+}
+
+// githubGraphQL posts a GraphQL query to the server the client points at
+func githubGraphQL(ctx context.Context, client *github.Client, query string, vars map[string]interface{}, out interface{}) error {
+	endpoint := "graphql"
+	if base := client.BaseURL.Path; strings.HasSuffix(base, "/api/v3/") {
+		// GitHub Enterprise Server serves GraphQL beside, not under, the REST API
+		endpoint = strings.TrimSuffix(base, "v3/") + "graphql"
+	}
+	req, err := client.NewRequest("POST", endpoint, map[string]interface{}{"query": query, "variables": vars})
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Data   interface{} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	resp.Data = out
+	if _, err := client.Do(ctx, req, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		msgs := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			msgs[i] = e.Message
+		}
+		return fmt.Errorf("%s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+const projectV2Query = `query($owner: String!, $number: Int!) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        id
+        title
+        fields(first: 100) {
+          nodes {
+            ... on ProjectV2FieldCommon { id name dataType }
+            ... on ProjectV2SingleSelectField { options { id name } }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// loadProjectV2 fetches a user or organization project and its fields
+func loadProjectV2(ctx context.Context, client *github.Client, owner string, number int) (*projectV2, error) {
+	var data struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				ID     string `json:"id"`
+				Title  string `json:"title"`
+				Fields struct {
+					Nodes []projectV2Field `json:"nodes"`
+				} `json:"fields"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+	if err := githubGraphQL(ctx, client, projectV2Query, map[string]interface{}{"owner": owner, "number": number}, &data); err != nil {
+		return nil, fmt.Errorf("failed to read project %d of %s: %w", number, owner, err)
+	}
+	if data.RepositoryOwner == nil || data.RepositoryOwner.ProjectV2 == nil {
+		return nil, codedErrorf(ErrCodeNotFound, "project %d not found for %s (check --project and --owner, and that the token can read projects)", number, owner)
+	}
+	p := data.RepositoryOwner.ProjectV2
+	return &projectV2{ID: p.ID, Title: p.Title, Fields: p.Fields.Nodes}, nil
+}
+
+// addProjectItem adds an issue to the project, returning the item ID. GitHub
+// returns the existing item if the issue is already on the board.
+func addProjectItem(ctx context.Context, client *github.Client, projectID, contentID string) (string, error) {
+	var data struct {
+		AddProjectV2ItemByID struct {
+			Item struct {
+				ID string `json:"id"`
+			} `json:"item"`
+		} `json:"addProjectV2ItemById"`
+	}
+	err := githubGraphQL(ctx, client, `mutation($project: ID!, $content: ID!) {
+  addProjectV2ItemById(input: {projectId: $project, contentId: $content}) { item { id } }
+}`, map[string]interface{}{"project": projectID, "content": contentID}, &data)
+	return data.AddProjectV2ItemByID.Item.ID, err
+}
+
+// setProjectField sets a field value on a project item
+func setProjectField(ctx context.Context, client *github.Client, projectID, itemID, fieldID string, value map[string]interface{}) error {
+	return githubGraphQL(ctx, client, `mutation($project: ID!, $item: ID!, $field: ID!, $value: ProjectV2FieldValue!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: $value}) { projectV2Item { id } }
+}`, map[string]interface{}{"project": projectID, "item": itemID, "field": fieldID, "value": value}, nil)
+}
+
+// syncTaskToProject places the task's issue on the board and sets its status
+// and priority fields
+func syncTaskToProject(ctx context.Context, client *github.Client, owner, repo string, project *projectV2, task models.Task, link models.GitHubIssueLink) (map[string]interface{}, error) {
+	issue, _, err := client.Issues.Get(ctx, owner, repo, link.IssueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue #%d: %w", link.IssueNumber, err)
+	}
+	itemID, err := addProjectItem(ctx, client, project.ID, issue.GetNodeID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to add issue #%d to project: %w", link.IssueNumber, err)
+	}
+
+	result := map[string]interface{}{
+		"task_id":      task.ID,
+		"issue_number": link.IssueNumber,
+		"item_id":      itemID,
+	}
+	if f := project.field(syncProjectStatusField); f != nil {
+		if value, ok := projectFieldValue(f, projectStatusOptions[task.Status], task.Priority, task.Status); ok {
+			if err := setProjectField(ctx, client, project.ID, itemID, f.ID, value); err != nil {
+				return nil, fmt.Errorf("failed to set %s: %w", f.Name, err)
+			}
+			result["status"] = task.Status
+		}
+	}
+	if f := project.field(syncProjectPriorityField); f != nil {
+		label := fmt.Sprintf("P%d", task.Priority)
+		names := []string{label}
+		if task.Priority >= 0 && task.Priority < len(priorityOptionNames) {
+			names = append(names, priorityOptionNames[task.Priority])
+		}
+		if value, ok := projectFieldValue(f, names, task.Priority, label); ok {
+			if err := setProjectField(ctx, client, project.ID, itemID, f.ID, value); err != nil {
+				return nil, fmt.Errorf("failed to set %s: %w", f.Name, err)
+			}
+			result["priority"] = label
+		}
+	}
+	return result, nil
+}
+
+func runSyncProject(cmd *cobra.Command, args []string) error {
+	repo, err := db.GetConfig(models.ConfigGitHubRepo)
+	if err != nil || repo == "" {
+		return codedErrorf(ErrCodeNotConfigured, "GitHub sync not configured: repository not set (run 'gur config github' to configure)")
+	}
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository format '%s': expected 'owner/repo' (run 'gur config github' to reconfigure)", repo)
+	}
+	owner, repoName := parts[0], parts[1]
+	projectOwner := syncProjectOwner
+	if projectOwner == "" {
+		projectOwner = owner
+	}
+
+	database := db.GetDB()
+	var links []models.GitHubIssueLink
+	if err := database.Where("repository = ?", repo).Order("issue_number ASC").Find(&links).Error; err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"success": true, "placed": 0, "message": "No synced tasks"})
+		} else {
+			fmt.Println("No synced tasks (run 'gur sync push' first)")
+		}
+		return nil
+	}
+	taskIDs := make([]string, len(links))
+	for i, l := range links {
+		taskIDs[i] = l.TaskID
+	}
+	var tasks []models.Task
+	if err := database.Where("id IN ?", taskIDs).Find(&tasks).Error; err != nil {
+		return err
+	}
+	tasksByID := make(map[string]models.Task, len(tasks))
+	for _, t := range tasks {
+		tasksByID[t.ID] = t
+	}
+
+	if syncProjectDryRun {
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"dry_run": true, "project": syncProjectNumber, "owner": projectOwner, "links": links})
+			return nil
+		}
+		fmt.Printf("Would place %d issue(s) on project %d of %s:\n", len(links), syncProjectNumber, projectOwner)
+		for _, l := range links {
+			t := tasksByID[l.TaskID]
+			fmt.Printf("  #%d [%s] %s (%s, P%d)\n", l.IssueNumber, t.ID, t.Title, t.Status, t.Priority)
+		}
+		return nil
+	}
+
+	token, err := GetGitHubToken()
+	if err != nil {
+		return err
+	}
+	client, err := newGitHubClient(token)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	preflight, err := runGitHubPreflight(ctx, client, owner, repoName)
+	if err != nil {
+		return fmt.Errorf("GitHub preflight failed: %w", err)
+	}
+	if preflight.TokenType == "classic" && !hasScope(preflight.Scopes, "project") {
+		return fmt.Errorf("token is missing the 'project' scope (has: %s): regenerate it with 'project' and run 'gur config github --token'", scopeList(preflight.Scopes))
+	}
+	if err := preflight.requireRateBudget(len(links) * projectCallsPerTask); err != nil {
+		return err
+	}
+
+	project, err := loadProjectV2(ctx, client, projectOwner, syncProjectNumber)
+	if err != nil {
+		return err
+	}
+	var warnings []string
+	for _, name := range []string{syncProjectStatusField, syncProjectPriorityField} {
+		if project.field(name) == nil {
+			warnings = append(warnings, fmt.Sprintf("project has no '%s' field; skipping it", name))
+		}
+	}
+	if !IsJSONOutput() {
+		for _, w := range warnings {
+			fmt.Printf("Warning: %s\n", w)
+		}
+	}
+
+	var results []map[string]interface{}
+	placed, errors := 0, 0
+	for _, link := range links {
+		task, ok := tasksByID[link.TaskID]
+		if !ok {
+			continue
+		}
+		result, err := syncTaskToProject(ctx, client, owner, repoName, project, task, link)
+		if err != nil {
+			errors++
+			result = map[string]interface{}{"task_id": task.ID, "error": err.Error()}
+			if !IsJSONOutput() {
+				fmt.Printf("Error placing %s: %v\n", task.ID, err)
+			}
+		} else {
+			placed++
+			if !IsJSONOutput() {
+				fmt.Printf("Placed: %s -> #%d (%s, P%d)\n", task.ID, link.IssueNumber, task.Status, task.Priority)
+			}
+		}
+		results = append(results, result)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"success":  errors == 0,
+			"project":  project.Title,
+			"placed":   placed,
+			"errors":   errors,
+			"warnings": warnings,
+			"results":  results,
+		})
+		return nil
+	}
+	fmt.Printf("\nPlaced %d issue(s) on project '%s'\n", placed, project.Title)
+	if errors > 0 {
+		fmt.Printf("%d issue(s) failed\n", errors)
+	}
+	return nil
+}
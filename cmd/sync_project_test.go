@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"guardrails/internal/models"
+)
+
+func TestSyncTaskToProject(t *testing.T) {
+	var updates []map[string]interface{}
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/widgets/issues/7":
+			fmt.Fprint(w, `{"number":7,"node_id":"I_7"}`)
+		case "/graphql":
+			var req struct {
+				Query     string                 `json:"query"`
+				Variables map[string]interface{} `json:"variables"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			switch {
+			case strings.Contains(req.Query, "repositoryOwner"):
+				fmt.Fprint(w, `{"data":{"repositoryOwner":{"projectV2":{"id":"PVT_1","title":"Roadmap","fields":{"nodes":[
+					{"id":"F_status","name":"Status","dataType":"SINGLE_SELECT","options":[{"id":"o_todo","name":"Todo"},{"id":"o_prog","name":"In Progress"},{"id":"o_done","name":"Done"}]},
+					{"id":"F_prio","name":"Priority","dataType":"SINGLE_SELECT","options":[{"id":"p_high","name":"High"},{"id":"p_p2","name":"P2"}]},
+					{"id":"F_title","name":"Title","dataType":"TITLE"}]}}}}}`)
+			case strings.Contains(req.Query, "addProjectV2ItemById"):
+				if req.Variables["content"] != "I_7" {
+					t.Errorf("content id = %v", req.Variables["content"])
+				}
+				fmt.Fprint(w, `{"data":{"addProjectV2ItemById":{"item":{"id":"PVTI_7"}}}}`)
+			case strings.Contains(req.Query, "updateProjectV2ItemFieldValue"):
+				updates = append(updates, req.Variables)
+				fmt.Fprint(w, `{"data":{"updateProjectV2ItemFieldValue":{"projectV2Item":{"id":"PVTI_7"}}}}`)
+			default:
+				fmt.Fprint(w, `{"errors":[{"message":"unexpected query"}]}`)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+	project, err := loadProjectV2(ctx, client, "acme", 3)
+	if err != nil {
+		t.Fatalf("loadProjectV2: %v", err)
+	}
+	if project.ID != "PVT_1" || len(project.Fields) != 3 || project.field("status") == nil {
+		t.Fatalf("project = %+v", project)
+	}
+
+	task := models.Task{ID: "gur-proj0001", Status: models.StatusInProgress, Priority: 1}
+	result, err := syncTaskToProject(ctx, client, "acme", "widgets", project, task, models.GitHubIssueLink{IssueNumber: 7})
+	if err != nil {
+		t.Fatalf("syncTaskToProject: %v", err)
+	}
+	if result["item_id"] != "PVTI_7" || result["priority"] != "P1" {
+		t.Errorf("result = %v", result)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("expected status and priority updates, got %v", updates)
+	}
+	if v := updates[0]["value"].(map[string]interface{}); updates[0]["field"] != "F_status" || v["singleSelectOptionId"] != "o_prog" {
+		t.Errorf("status update = %v", updates[0])
+	}
+	// P1 has no "P1" option, so the "High" alias is used
+	if v := updates[1]["value"].(map[string]interface{}); updates[1]["field"] != "F_prio" || v["singleSelectOptionId"] != "p_high" {
+		t.Errorf("priority update = %v", updates[1])
+	}
+}
+
+func TestProjectFieldValue(t *testing.T) {
+	number := &projectV2Field{DataType: "NUMBER"}
+	if v, ok := projectFieldValue(number, nil, 3, "P3"); !ok || v["number"] != 3 {
+		t.Errorf("number field = %v, %v", v, ok)
+	}
+	text := &projectV2Field{DataType: "TEXT"}
+	if v, ok := projectFieldValue(text, nil, 3, "P3"); !ok || v["text"] != "P3" {
+		t.Errorf("text field = %v, %v", v, ok)
+	}
+	blocked := &projectV2Field{DataType: "SINGLE_SELECT"}
+	blocked.Options = append(blocked.Options, struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}{"o_todo", "Todo"})
+	if v, ok := projectFieldValue(blocked, projectStatusOptions[models.StatusBlocked], 0, ""); !ok || v["singleSelectOptionId"] != "o_todo" {
+		t.Errorf("blocked without a Blocked option should fall back to Todo, got %v", v)
+	}
+	if _, ok := projectFieldValue(blocked, projectStatusOptions[models.StatusClosed], 0, ""); ok {
+		t.Error("no matching option should be skipped")
+	}
+}
+
+func TestGitHubGraphQLErrors(t *testing.T) {
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errors":[{"message":"Could not resolve to a ProjectV2"}]}`)
+	}))
+	if _, err := loadProjectV2(context.Background(), client, "acme", 99); err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("err = %v, want GraphQL error message", err)
+	}
+}
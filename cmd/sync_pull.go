@@ -7,14 +7,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"os"
-	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/go-github/v63/github"
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 
 	"guardrails/internal/db"
 	"guardrails/internal/models"
@@ -34,10 +34,23 @@ type SyncMarker struct {
 }
 
 var (
-	syncPullForce  bool
-	syncPullDryRun bool
-	syncPullLabel  string
-	syncPullAll    bool
+	syncPullForce   bool
+	syncPullDryRun  bool
+	syncPullLabel   string
+	syncPullAll     bool
+	syncPullWorkers int
+	syncPullResume  bool
+
+	syncPullState     string
+	syncPullAssignee  string
+	syncPullMilestone string
+	syncPullSince     string
+	syncPullIssues    []string
+
+	syncPullVotes        bool
+	syncPullMilestoneDue bool
+	syncPullProject      int
+	syncPullProjectOwner string
 )
 
 var syncPullCmd = &cobra.Command{
@@ -48,7 +61,49 @@ var syncPullCmd = &cobra.Command{
 By default, only pulls open issues that haven't been synced yet.
 Issues that were previously synced by another user will prompt for confirmation.
 
-The command posts a sync marker comment to GitHub to coordinate with other users.`,
+The command posts a sync marker comment to GitHub to coordinate with other users.
+
+On Ctrl+C (or SIGTERM) the issue being pulled is finished before stopping, and
+the remaining issues are saved; run 'gur sync pull --resume' to continue.
+
+Comments on linked issues can steer their tasks with /gur commands, one per
+line. Only repository owners, members and collaborators are obeyed; changes
+are recorded in task history as github:@user, and a rejected command gets a
+reply on the issue explaining why:
+  /gur close reason="fixed in #42" as=completed
+  /gur reopen
+  /gur priority 1
+  /gur assign alice
+  /gur label ux
+  /gur block reason="waiting on API"
+  /gur unblock
+
+Descriptions of linked tasks follow edits made on GitHub. When both the task
+description and the issue changed since the last sync, they are merged line
+by line against the description last synced; overlapping edits are kept
+between <<<<<<< local / >>>>>>> github markers and the task is labeled
+needs-attention. 'gur sync push' refuses to push a description until the
+markers are resolved.
+
+On large repositories, import only the slice you need with --label,
+--assignee, --milestone, --since, --state, or --issue.
+
+--votes stores each linked issue's +1 reaction count on its task; 'gur
+ready' ranks tasks with more votes first among equal priority, rank and due
+date. --milestone-due sets a new task's due date from its issue's milestone,
+the reverse of push, which files tasks under the milestone due that day.
+--project imports the fields of a Projects (v2) board (Status,
+single-select, text, number, date and iteration fields) into the custom
+fields of the same name, e.g. "Story Points" into story-points. Board fields
+with no such custom field are skipped with a warning, so define the ones you
+want first with 'gur field define'.
+
+Examples:
+  gur sync pull --assignee octocat --since 30d
+  gur sync pull --milestone "Q3 launch" --state all
+  gur sync pull --assignee none --label bug
+  gur sync pull --issue 12,15,31
+  gur sync pull --state all --votes --project 3`,
 	RunE: runSyncPull,
 }
 
@@ -58,14 +113,30 @@ func init() {
 	syncPullCmd.Flags().BoolVar(&syncPullForce, "force", false, "Skip confirmation prompts for already-synced issues")
 	syncPullCmd.Flags().BoolVar(&syncPullDryRun, "dry-run", false, "Show what would be pulled without actually pulling")
 	syncPullCmd.Flags().StringVar(&syncPullLabel, "label", "", "Only pull issues with this label")
-	syncPullCmd.Flags().BoolVar(&syncPullAll, "all", false, "Pull all issues (open and closed)")
+	syncPullCmd.Flags().BoolVar(&syncPullAll, "all", false, "Pull all issues (open and closed), same as --state all")
+	syncPullCmd.Flags().StringVar(&syncPullState, "state", "", "Only pull issues in this state (open/closed/all, default open)")
+	syncPullCmd.Flags().StringVar(&syncPullAssignee, "assignee", "", "Only pull issues assigned to this login ('none' for unassigned, '*' for any)")
+	syncPullCmd.Flags().StringVar(&syncPullMilestone, "milestone", "", "Only pull issues in this milestone, by title or number ('none' or '*')")
+	syncPullCmd.Flags().StringVar(&syncPullSince, "since", "", "Only pull issues updated since (e.g., 30d, 2w, 2025-07-01)")
+	syncPullCmd.Flags().StringSliceVar(&syncPullIssues, "issue", nil, "Only pull these issue numbers (e.g., 12,15)")
+	syncPullCmd.Flags().IntVar(&syncPullWorkers, "workers", defaultMarkerWorkers, "Concurrent comment lookups when checking sync markers")
+	syncPullCmd.Flags().BoolVar(&syncPullResume, "resume", false, "Pull the issues left over from an interrupted pull")
+	syncPullCmd.Flags().BoolVar(&syncPullVotes, "votes", false, "Store +1 reaction counts as task votes")
+	syncPullCmd.Flags().BoolVar(&syncPullMilestoneDue, "milestone-due", false, "Set new tasks' due dates from their issue's milestone due date")
+	syncPullCmd.Flags().IntVar(&syncPullProject, "project", 0, "Import field values from this Projects (v2) board number")
+	syncPullCmd.Flags().StringVar(&syncPullProjectOwner, "project-owner", "", "User or organization that owns the project (default: repository owner)")
 }
 
 func runSyncPull(cmd *cobra.Command, args []string) error {
+	filter, err := newPullFilter(time.Now())
+	if err != nil {
+		return err
+	}
+
 	// Get GitHub configuration
 	repo, err := db.GetConfig(models.ConfigGitHubRepo)
 	if err != nil || repo == "" {
-		return fmt.Errorf("GitHub not configured. Run 'gur config github' first")
+		return codedErrorf(ErrCodeNotConfigured, "GitHub not configured. Run 'gur config github' first")
 	}
 
 	token, err := GetGitHubToken()
@@ -80,26 +151,22 @@ func runSyncPull(cmd *cobra.Command, args []string) error {
 	}
 	owner, repoName := parts[0], parts[1]
 
-	// Create GitHub client
-	httpClient := &http.Client{
-		Timeout: githubAPITimeout,
-		Transport: &http.Transport{
-			MaxIdleConns:        100,
-			MaxIdleConnsPerHost: 10,
-			IdleConnTimeout:     90 * time.Second,
-		},
+	// Create GitHub client (github.com or the configured Enterprise server)
+	client, err := newGitHubClient(token)
+	if err != nil {
+		return err
 	}
-	client := github.NewClient(httpClient).WithAuthToken(token)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
 	// Get current user info for sync marker
-	currentUser, _, err := client.Users.Get(ctx, "")
+	// Check the token, repository access and rate limit up front
+	preflight, err := runGitHubPreflight(ctx, client, owner, repoName)
 	if err != nil {
-		return fmt.Errorf("failed to get current user: %w", err)
+		return fmt.Errorf("GitHub preflight failed: %w", err)
 	}
-	username := currentUser.GetLogin()
+	username := preflight.Login
 
 	hostname, _ := os.Hostname()
 	if hostname == "" {
@@ -116,43 +183,23 @@ func runSyncPull(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// List issues from GitHub
-	state := "open"
-	if syncPullAll {
-		state = "all"
+	labelMap, err := loadLabelMap()
+	if err != nil {
+		return err
 	}
-
-	opts := &github.IssueListByRepoOptions{
-		State:     state,
-		Sort:      "updated",
-		Direction: "desc",
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
+	userMap, err := loadUserMap()
+	if err != nil {
+		return err
 	}
-
-	if syncPullLabel != "" {
-		opts.Labels = []string{syncPullLabel}
+	issueTemplates, err := loadIssueTemplates(db.GetDB())
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
 	}
 
-	var allIssues []*github.Issue
-	for {
-		issues, resp, err := client.Issues.ListByRepo(ctx, owner, repoName, opts)
-		if err != nil {
-			return fmt.Errorf("failed to list issues: %w", err)
-		}
-
-		// Filter out pull requests (GitHub API returns PRs as issues)
-		for _, issue := range issues {
-			if issue.PullRequestLinks == nil {
-				allIssues = append(allIssues, issue)
-			}
-		}
-
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+	// List issues from GitHub
+	allIssues, err := fetchPullIssues(ctx, client, owner, repoName, filter)
+	if err != nil {
+		return err
 	}
 
 	if len(allIssues) == 0 {
@@ -169,22 +216,99 @@ func runSyncPull(cmd *cobra.Command, args []string) error {
 	skipped := 0
 	var results []map[string]interface{}
 
-	for _, issue := range allIssues {
-		issueNum := issue.GetNumber()
+	// With --resume, only consider the issues an interrupted pull didn't reach
+	var resumeIssues map[string]bool
+	if syncPullResume {
+		checkpoint, err := loadSyncCheckpoint("pull")
+		if err != nil {
+			return err
+		}
+		resumeIssues = make(map[string]bool, len(checkpoint.Remaining))
+		for _, num := range checkpoint.Remaining {
+			resumeIssues[num] = true
+		}
+	}
 
-		// Check if already linked locally
+	// Skip issues we already have locally before touching comments; linked
+	// issues with new comments are scanned for /gur commands instead
+	var candidates []*github.Issue
+	var commandLinks []models.GitHubIssueLink
+	var linkedIssues []linkedIssue
+	for _, issue := range allIssues {
+		if resumeIssues != nil && !resumeIssues[strconv.Itoa(issue.GetNumber())] {
+			continue
+		}
 		var existingLink models.GitHubIssueLink
-		if err := database.Where("issue_number = ? AND repository = ?", issueNum, repo).First(&existingLink).Error; err == nil {
-			// Already have this issue locally
+		if err := database.Where("issue_number = ? AND repository = ?", issue.GetNumber(), repo).First(&existingLink).Error; err == nil {
 			skipped++
+			linkedIssues = append(linkedIssues, linkedIssue{link: existingLink, issue: issue})
+			if issue.GetComments() > 0 && (existingLink.CommandsAt == nil || issue.GetUpdatedAt().Time.After(*existingLink.CommandsAt)) {
+				commandLinks = append(commandLinks, existingLink)
+			}
 			continue
 		}
+		candidates = append(candidates, issue)
+	}
 
-		// Check for sync marker in comments
-		marker, err := findSyncMarker(ctx, client, owner, repoName, issueNum)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to check comments for issue #%d: %v\n", issueNum, err)
+	hierarchy, err := loadIssueHierarchy()
+	if err != nil {
+		return err
+	}
+
+	if !syncPullDryRun {
+		calls := len(candidates)*pullCallsPerIssue + len(commandLinks)
+		if hierarchy != nil {
+			calls += (len(candidates)+len(linkedIssues))/issueRelationBatch + 1
+		}
+		if syncPullProject > 0 {
+			calls += 1 + len(allIssues)/100 + 1 // Board fields, then item pages
+		}
+		if err := preflight.requireRateBudget(calls); err != nil {
+			return err
 		}
+	}
+
+	// Read issue types and parents, so parents are pulled before their
+	// sub-issues
+	var relations map[int]issueRelation
+	if hierarchy != nil {
+		numbers := make([]int, 0, len(candidates)+len(linkedIssues))
+		for _, issue := range candidates {
+			numbers = append(numbers, issue.GetNumber())
+		}
+		for _, l := range linkedIssues {
+			numbers = append(numbers, l.link.IssueNumber)
+		}
+		if relations, err = fetchIssueRelations(ctx, client, owner, repoName, numbers); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read issue types and sub-issues: %v\n", err)
+		}
+		candidates = parentsFirst(candidates, relations)
+	}
+
+	// Check sync markers for all candidates concurrently
+	markers := scanSyncMarkers(ctx, database, client, owner, repoName, repo, candidates, syncPullWorkers)
+
+	interrupt := watchSyncInterrupt()
+	defer interrupt.Stop()
+
+	var remaining []string
+	for i, issue := range candidates {
+		if interrupt.Interrupted() {
+			if !syncPullDryRun {
+				for _, rest := range candidates[i:] {
+					remaining = append(remaining, strconv.Itoa(rest.GetNumber()))
+				}
+			}
+			break
+		}
+
+		issueNum := issue.GetNumber()
+
+		lookup := markers[issueNum]
+		if lookup.Err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to check comments for issue #%d: %v\n", issueNum, lookup.Err)
+		}
+		marker := lookup.Marker
 
 		if marker != nil && !syncPullForce {
 			// Issue was synced by someone else
@@ -224,19 +348,20 @@ func runSyncPull(cmd *cobra.Command, args []string) error {
 		}
 
 		// Create local task from GitHub issue
-		task, err := createTaskFromIssue(issue)
+		task, err := createTaskFromIssue(issue, labelMap)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating task for issue #%d: %v\n", issueNum, err)
 			continue
 		}
-
-		if err := database.Create(task).Error; err != nil {
-			fmt.Fprintf(os.Stderr, "Error saving task for issue #%d: %v\n", issueNum, err)
-			continue
+		task.Assignee = userMap.ToLocal(task.Assignee)
+		if syncPullMilestoneDue {
+			applyMilestoneDue(task, issue)
 		}
+		templateName := applyIssueTemplate(task, issue, issueTemplates)
 
 		// Create link
 		remoteUpdated := issue.GetUpdatedAt().Time
+		pulledAt := time.Now()
 		link := models.GitHubIssueLink{
 			TaskID:          task.ID,
 			IssueNumber:     issueNum,
@@ -247,9 +372,31 @@ func runSyncPull(cmd *cobra.Command, args []string) error {
 			SyncDirection:   models.SyncDirectionPull,
 			SyncedBy:        username,
 			SyncedMachine:   hostnameHash,
+			CommandsAt:      &pulledAt, // Commands posted before the pull are not replayed
+			SyncedBody:      issue.GetBody(),
+		}
+		link.SyncedDesc, _ = issueBodyDescription(issue.GetBody(), false)
+		link.IssueID = issue.GetID()
+		vector, _ := parseSyncVector(issue.GetBody())
+		link.SyncVector = vector.String()
+		if rel, ok := relations[issueNum]; ok {
+			if err := hierarchy.applyPulledRelation(database, task, &link, rel); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to place issue #%d under its parent: %v\n", issueNum, err)
+			}
 		}
-		if err := database.Create(&link).Error; err != nil {
-			fmt.Fprintf(os.Stderr, "Error saving link for issue #%d: %v\n", issueNum, err)
+		// Save the task and its link together so a failure can't leave an
+		// unlinked task that the next pull would duplicate
+		if err := database.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(task).Error; err != nil {
+				return fmt.Errorf("failed to save task: %w", err)
+			}
+			link.TaskID = task.ID
+			if err := tx.Create(&link).Error; err != nil {
+				return fmt.Errorf("failed to save link: %w", err)
+			}
+			return nil
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving issue #%d: %v\n", issueNum, err)
 			continue
 		}
 
@@ -259,37 +406,183 @@ func runSyncPull(cmd *cobra.Command, args []string) error {
 		}
 
 		pulled++
-		results = append(results, map[string]interface{}{
+		result := map[string]interface{}{
 			"issue_number": issueNum,
 			"task_id":      task.ID,
 			"title":        task.Title,
 			"action":       "pulled",
-		})
+		}
+		if templateName != "" {
+			result["template"] = templateName
+		}
+		results = append(results, result)
 
 		if !IsJSONOutput() {
-			fmt.Printf("Pulled: #%d -> %s \"%s\"\n", issueNum, task.ID, task.Title)
+			suffix := ""
+			if templateName != "" {
+				suffix = fmt.Sprintf(" (template %s)", templateName)
+			}
+			fmt.Printf("Pulled: #%d -> %s \"%s\"%s\n", issueNum, task.ID, task.Title, suffix)
+		}
+	}
+
+	// Bring descriptions of linked tasks up to date, then apply /gur
+	// commands from comments on linked issues
+	var descriptions []descriptionSync
+	var parents []parentSync
+	var commands []appliedCommand
+	if len(remaining) == 0 {
+		for i := range linkedIssues {
+			d, err := pullDescription(database, &linkedIssues[i].link, linkedIssues[i].issue, syncPullDryRun)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to update the description from issue #%d: %v\n", linkedIssues[i].link.IssueNumber, err)
+			}
+			if d != nil {
+				descriptions = append(descriptions, *d)
+			}
+			// Pushes merged by this pull no longer block this machine's pushes
+			if !syncPullDryRun {
+				if err := adoptSyncVector(database, &linkedIssues[i].link, linkedIssues[i].issue); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to record the sync state of issue #%d: %v\n", linkedIssues[i].link.IssueNumber, err)
+				}
+			}
+		}
+		for i := range linkedIssues {
+			rel, ok := relations[linkedIssues[i].link.IssueNumber]
+			if !ok {
+				continue
+			}
+			p, err := hierarchy.pullParent(database, &linkedIssues[i].link, rel, syncPullDryRun)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to update the parent from issue #%d: %v\n", linkedIssues[i].link.IssueNumber, err)
+			}
+			if p != nil {
+				parents = append(parents, *p)
+			}
+		}
+		for i := range commandLinks {
+			applied, err := runIssueCommands(ctx, client, database, owner, repoName, &commandLinks[i], syncPullDryRun)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to check commands on issue #%d: %v\n", commandLinks[i].IssueNumber, err)
+			}
+			commands = append(commands, applied...)
+		}
+	}
+	// Refresh votes and board fields of every linked issue, new or not
+	votesUpdated := 0
+	var imports []projectFieldImport
+	var warnings []string
+	if len(remaining) == 0 {
+		if syncPullVotes {
+			if votesUpdated, err = refreshVotes(database, repo, allIssues, syncPullDryRun); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+		if syncPullProject > 0 {
+			imports, warnings, err = pullProjectFields(ctx, client, database, owner, repo)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !IsJSONOutput() {
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+		for _, d := range descriptions {
+			fmt.Printf("%s: #%d description -> %s\n", d.verb(syncPullDryRun), d.IssueNumber, d.TaskID)
+		}
+		for _, p := range parents {
+			verb := "Moved"
+			if syncPullDryRun {
+				verb = "Would move"
+			}
+			where := "under " + p.NewParent
+			if p.NewParent == "" {
+				where = "to the top level"
+			}
+			fmt.Printf("%s: #%d %s %s\n", verb, p.IssueNumber, p.TaskID, where)
+		}
+		for _, c := range commands {
+			switch {
+			case c.Error != "":
+				fmt.Printf("Rejected: #%d %s by @%s: %s\n", c.IssueNumber, c.Command, c.Author, c.Error)
+			case syncPullDryRun:
+				fmt.Printf("Would apply: #%d %s by @%s -> %s\n", c.IssueNumber, c.Command, c.Author, c.TaskID)
+			default:
+				fmt.Printf("Applied: #%d %s by @%s -> %s %s\n", c.IssueNumber, c.Command, c.Author, c.TaskID, c.Result)
+			}
+		}
+	}
+	if !IsJSONOutput() {
+		verb := "Imported"
+		if syncPullDryRun {
+			verb = "Would import"
+		}
+		for _, im := range imports {
+			fmt.Printf("%s: #%d %s = %s -> %s\n", verb, im.IssueNumber, im.Field, diffValue(im.New), im.TaskID)
 		}
 	}
 
 	if IsJSONOutput() {
-		OutputJSON(map[string]interface{}{
-			"success": true,
+		result := map[string]interface{}{
+			"success": len(remaining) == 0,
 			"pulled":  pulled,
 			"skipped": skipped,
 			"results": results,
-		})
+		}
+		if len(commands) > 0 {
+			result["commands"] = commands
+		}
+		if len(descriptions) > 0 {
+			result["descriptions"] = descriptions
+		}
+		if len(parents) > 0 {
+			result["parents"] = parents
+		}
+		if syncPullVotes {
+			result["votes_updated"] = votesUpdated
+		}
+		if syncPullProject > 0 {
+			result["fields_imported"] = imports
+			result["warnings"] = warnings
+		}
+		if len(remaining) > 0 {
+			result["interrupted"] = true
+			result["remaining"] = remaining
+		}
+		OutputJSON(result)
 	} else if !syncPullDryRun {
 		fmt.Printf("\nPulled %d issue(s), skipped %d\n", pulled, skipped)
+		if conflicts := countDescriptionConflicts(descriptions); conflicts > 0 {
+			fmt.Printf("%d description(s) have conflicts: resolve the marked sections with 'gur update <id> --description ...', then remove the %s label\n", conflicts, syncConflictLabel)
+		}
+		if syncPullVotes {
+			fmt.Printf("Updated votes on %d task(s)\n", votesUpdated)
+		}
+		if syncPullProject > 0 {
+			fmt.Printf("Imported %d project field value(s)\n", len(imports))
+		}
 	}
 
+	if len(remaining) > 0 {
+		return finishInterruptedSync("pull", pulled, remaining)
+	}
+	if !syncPullDryRun {
+		clearSyncCheckpoint("pull")
+	}
 	return nil
 }
 
-func createTaskFromIssue(issue *github.Issue) (*models.Task, error) {
+func createTaskFromIssue(issue *github.Issue, labelMap models.LabelMap) (*models.Task, error) {
+	rank, body := parseRankMarker(issue.GetBody())
+	_, body = parseSyncVector(body)
 	task := &models.Task{
 		Title:       issue.GetTitle(),
-		Description: issue.GetBody(),
+		Description: body,
 		Priority:    models.PriorityMedium, // Default P2
+		Rank:        rank,
 		Type:        models.TypeTask,
 		Source:      models.SourceGitHub,
 		Synced:      true,
@@ -300,33 +593,19 @@ func createTaskFromIssue(issue *github.Issue) (*models.Task, error) {
 	case "closed":
 		task.Status = models.StatusClosed
 		task.CloseReason = "Closed on GitHub"
+		task.Resolution = models.ResolutionFromStateReason(issue.GetStateReason())
 		now := time.Now()
 		task.ClosedAt = &now
 	default:
 		task.Status = models.StatusOpen
 	}
 
-	// Map GitHub labels
-	for _, label := range issue.Labels {
-		name := strings.ToLower(label.GetName())
-		task.Labels = append(task.Labels, label.GetName())
-
-		// Infer type from labels
-		if name == "bug" {
-			task.Type = models.TypeBug
-		} else if name == "enhancement" || name == "feature" {
-			task.Type = models.TypeFeature
-		}
-
-		// Infer priority from labels
-		if strings.Contains(name, "critical") || strings.Contains(name, "p0") {
-			task.Priority = models.PriorityCritical
-		} else if strings.Contains(name, "high") || strings.Contains(name, "p1") {
-			task.Priority = models.PriorityHigh
-		} else if strings.Contains(name, "low") || strings.Contains(name, "p3") {
-			task.Priority = models.PriorityLow
-		}
+	// Map GitHub labels to type, priority, blocked status, and task labels
+	names := make([]string, len(issue.Labels))
+	for i, label := range issue.Labels {
+		names[i] = label.GetName()
 	}
+	labelMap.ApplyToTask(task, names)
 
 	// Map assignee
 	if issue.Assignee != nil {
@@ -336,35 +615,17 @@ func createTaskFromIssue(issue *github.Issue) (*models.Task, error) {
 	return task, nil
 }
 
-func findSyncMarker(ctx context.Context, client *github.Client, owner, repo string, issueNum int) (*SyncMarker, error) {
-	opts := &github.IssueListCommentsOptions{
-		Sort:      github.String("created"),
-		Direction: github.String("desc"),
-		ListOptions: github.ListOptions{
-			PerPage: 50,
-		},
+// applyMilestoneDue gives a task without a due date its issue's milestone
+// due date, as the end of that day like 'gur update --due'
+func applyMilestoneDue(task *models.Task, issue *github.Issue) {
+	dueOn := issue.GetMilestone().GetDueOn()
+	if task.DueAt != nil || dueOn.IsZero() {
+		return
 	}
-
-	comments, _, err := client.Issues.ListComments(ctx, owner, repo, issueNum, opts)
-	if err != nil {
-		return nil, err
+	due, err := parseDueDate(dueOn.UTC().Format(models.DateFormat), time.Now())
+	if err == nil {
+		task.DueAt = &due
 	}
-
-	// Look for sync marker in comments
-	markerRegex := regexp.MustCompile(regexp.QuoteMeta(syncMarkerPrefix) + `(.+?)` + regexp.QuoteMeta(syncMarkerSuffix))
-
-	for _, comment := range comments {
-		body := comment.GetBody()
-		matches := markerRegex.FindStringSubmatch(body)
-		if len(matches) >= 2 {
-			var marker SyncMarker
-			if err := json.Unmarshal([]byte(matches[1]), &marker); err == nil {
-				return &marker, nil
-			}
-		}
-	}
-
-	return nil, nil
 }
 
 func postSyncMarker(ctx context.Context, client *github.Client, owner, repo string, issueNum int, taskID, username, machine string) error {
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+)
+
+// pullFilter selects the slice of a repository's issues that 'gur sync pull'
+// imports
+type pullFilter struct {
+	State     string // open, closed or all
+	StateSet  bool   // --state or --all was given
+	Label     string
+	Assignee  string // login, "none" or "*"
+	Milestone string // title or number, "none" or "*"
+	Since     time.Time
+	Issues    []int
+}
+
+// newPullFilter builds the filter from the sync pull flags
+func newPullFilter(now time.Time) (*pullFilter, error) {
+	f := &pullFilter{State: "open", Label: syncPullLabel, Assignee: syncPullAssignee, Milestone: syncPullMilestone}
+	if syncPullState != "" {
+		switch syncPullState {
+		case "open", "closed", "all":
+		default:
+			return nil, fmt.Errorf("invalid --state '%s': must be open, closed, or all", syncPullState)
+		}
+		if syncPullAll && syncPullState != "all" {
+			return nil, fmt.Errorf("--all conflicts with --state %s", syncPullState)
+		}
+		f.State, f.StateSet = syncPullState, true
+	} else if syncPullAll {
+		f.State, f.StateSet = "all", true
+	}
+	if syncPullSince != "" {
+		since, err := parseSince(syncPullSince, now)
+		if err != nil {
+			return nil, err
+		}
+		f.Since = since
+	}
+	for _, s := range syncPullIssues {
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(s), "#"))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid --issue '%s': must be an issue number", s)
+		}
+		f.Issues = append(f.Issues, n)
+	}
+	return f, nil
+}
+
+// listOptions turns the filter into a ListByRepo query; milestone is the
+// resolved milestone number (or "none"/"*")
+func (f *pullFilter) listOptions(milestone string) *github.IssueListByRepoOptions {
+	opts := &github.IssueListByRepoOptions{
+		State:       f.State,
+		Milestone:   milestone,
+		Assignee:    f.Assignee,
+		Since:       f.Since,
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	if f.Label != "" {
+		opts.Labels = []string{f.Label}
+	}
+	return opts
+}
+
+// matches applies the filter to an issue fetched by number. Explicitly
+// requested issues are pulled whatever their state unless --state was given.
+func (f *pullFilter) matches(issue *github.Issue, milestone string) bool {
+	if f.StateSet && f.State != "all" && issue.GetState() != f.State {
+		return false
+	}
+	if f.Label != "" {
+		found := false
+		for _, l := range issue.Labels {
+			if strings.EqualFold(l.GetName(), f.Label) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	switch f.Assignee {
+	case "":
+	case "none":
+		if len(issue.Assignees) > 0 || issue.Assignee != nil {
+			return false
+		}
+	case "*":
+		if len(issue.Assignees) == 0 && issue.Assignee == nil {
+			return false
+		}
+	default:
+		found := issue.Assignee != nil && strings.EqualFold(issue.Assignee.GetLogin(), f.Assignee)
+		for _, a := range issue.Assignees {
+			found = found || strings.EqualFold(a.GetLogin(), f.Assignee)
+		}
+		if !found {
+			return false
+		}
+	}
+	switch milestone {
+	case "":
+	case "none":
+		if issue.Milestone != nil {
+			return false
+		}
+	case "*":
+		if issue.Milestone == nil {
+			return false
+		}
+	default:
+		if strconv.Itoa(issue.GetMilestone().GetNumber()) != milestone {
+			return false
+		}
+	}
+	return f.Since.IsZero() || !issue.GetUpdatedAt().Time.Before(f.Since)
+}
+
+// resolveMilestone turns a --milestone title into the milestone number the
+// issues API filters on; numbers, "none" and "*" are passed through
+func resolveMilestone(ctx context.Context, client *github.Client, owner, repo, milestone string) (string, error) {
+	if milestone == "" || milestone == "none" || milestone == "*" {
+		return milestone, nil
+	}
+	if _, err := strconv.Atoi(milestone); err == nil {
+		return milestone, nil
+	}
+
+	opts := &github.MilestoneListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			return "", fmt.Errorf("failed to list milestones: %w", err)
+		}
+		for _, m := range page {
+			if strings.EqualFold(m.GetTitle(), milestone) {
+				return strconv.Itoa(m.GetNumber()), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return "", codedErrorf(ErrCodeNotFound, "milestone '%s' not found in %s/%s", milestone, owner, repo)
+}
+
+// fetchPullIssues lists the issues matching the filter, skipping pull
+// requests. With --issue only those issues are fetched.
+func fetchPullIssues(ctx context.Context, client *github.Client, owner, repo string, f *pullFilter) ([]*github.Issue, error) {
+	milestone, err := resolveMilestone(ctx, client, owner, repo, f.Milestone)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*github.Issue
+	if len(f.Issues) > 0 {
+		for _, n := range f.Issues {
+			issue, _, err := client.Issues.Get(ctx, owner, repo, n)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue #%d: %w", n, err)
+			}
+			if issue.PullRequestLinks != nil {
+				return nil, fmt.Errorf("#%d is a pull request, not an issue", n)
+			}
+			if f.matches(issue, milestone) {
+				issues = append(issues, issue)
+			}
+		}
+		return issues, nil
+	}
+
+	opts := f.listOptions(milestone)
+	for {
+		page, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+		// Filter out pull requests (GitHub API returns PRs as issues)
+		for _, issue := range page {
+			if issue.PullRequestLinks == nil {
+				issues = append(issues, issue)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, nil
+}
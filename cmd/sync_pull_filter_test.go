@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewPullFilter(t *testing.T) {
+	defer func() {
+		syncPullState, syncPullAll, syncPullSince, syncPullIssues = "", false, "", nil
+	}()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	syncPullAll = true
+	f, err := newPullFilter(now)
+	if err != nil || f.State != "all" || !f.StateSet {
+		t.Errorf("--all filter = %+v, %v; want state all", f, err)
+	}
+	syncPullState = "closed"
+	if _, err := newPullFilter(now); err == nil {
+		t.Error("newPullFilter accepted --all with --state closed")
+	}
+	syncPullAll = false
+	syncPullState = "merged"
+	if _, err := newPullFilter(now); err == nil {
+		t.Error("newPullFilter accepted --state merged")
+	}
+
+	syncPullState, syncPullSince, syncPullIssues = "", "7d", []string{"12", "#15"}
+	f, err = newPullFilter(now)
+	if err != nil {
+		t.Fatalf("newPullFilter() error: %v", err)
+	}
+	if f.State != "open" || f.StateSet || !f.Since.Equal(now.Add(-7*24*time.Hour)) || len(f.Issues) != 2 || f.Issues[1] != 15 {
+		t.Errorf("filter = %+v, want open, since 7d ago, issues 12 and 15", f)
+	}
+	syncPullIssues = []string{"twelve"}
+	if _, err := newPullFilter(now); err == nil {
+		t.Error("newPullFilter accepted --issue twelve")
+	}
+}
+
+func TestFetchPullIssuesFilters(t *testing.T) {
+	var listQuery string
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/milestones":
+			fmt.Fprint(w, `[{"number": 3, "title": "Q3 launch"}]`)
+		case "/repos/o/r/issues":
+			listQuery = r.URL.RawQuery
+			fmt.Fprint(w, `[{"number": 1, "state": "open"}, {"number": 2, "pull_request": {"url": "x"}}]`)
+		case "/repos/o/r/issues/7":
+			fmt.Fprint(w, `{"number": 7, "state": "closed", "assignee": {"login": "octocat"}, "milestone": {"number": 3}, "updated_at": "2026-03-01T00:00:00Z"}`)
+		case "/repos/o/r/issues/8":
+			fmt.Fprint(w, `{"number": 8, "state": "open", "milestone": {"number": 4}, "updated_at": "2026-03-01T00:00:00Z"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	ctx := context.Background()
+
+	f := &pullFilter{State: "all", Assignee: "octocat", Milestone: "q3 launch", Label: "bug", Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	issues, err := fetchPullIssues(ctx, client, "o", "r", f)
+	if err != nil {
+		t.Fatalf("fetchPullIssues() error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].GetNumber() != 1 {
+		t.Errorf("issues = %v, want #1 only (pull requests skipped)", issues)
+	}
+	want := "assignee=octocat&direction=desc&labels=bug&milestone=3&per_page=100&since=2026-01-01T00%3A00%3A00Z&sort=updated&state=all"
+	if listQuery != want {
+		t.Errorf("list query = %s, want %s", listQuery, want)
+	}
+
+	// Explicit issues are fetched by number and filtered locally
+	f = &pullFilter{State: "open", Milestone: "3", Issues: []int{7, 8}}
+	issues, err = fetchPullIssues(ctx, client, "o", "r", f)
+	if err != nil {
+		t.Fatalf("fetchPullIssues(--issue) error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].GetNumber() != 7 {
+		t.Errorf("issues = %v, want closed #7 (state not given) and not #8 (other milestone)", issues)
+	}
+	f.StateSet = true
+	if issues, _ := fetchPullIssues(ctx, client, "o", "r", f); len(issues) != 0 {
+		t.Errorf("issues with --state open = %v, want none", issues)
+	}
+
+	if _, err := fetchPullIssues(ctx, client, "o", "r", &pullFilter{State: "open", Milestone: "Q4"}); err == nil {
+		t.Error("fetchPullIssues accepted an unknown milestone")
+	}
+}
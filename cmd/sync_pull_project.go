@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v63/github"
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// projectImportBy is recorded in task history for imported field values
+const projectImportBy = "github:project"
+
+// projectItem is an issue on a Projects (v2) board and its field values,
+// keyed by board field name
+type projectItem struct {
+	Repository  string
+	IssueNumber int
+	Values      map[string]string
+}
+
+// projectFieldImport is a custom field value changed from a board field
+type projectFieldImport struct {
+	TaskID      string `json:"task_id"`
+	IssueNumber int    `json:"issue_number"`
+	Field       string `json:"field"`
+	Old         string `json:"old,omitempty"`
+	New         string `json:"new"`
+}
+
+const projectItemsQuery = `query($project: ID!, $cursor: String) {
+  node(id: $project) {
+    ... on ProjectV2 {
+      items(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          content { ... on Issue { number repository { nameWithOwner } } }
+          fieldValues(first: 50) {
+            nodes {
+              ... on ProjectV2ItemFieldTextValue { text field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldNumberValue { number field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldDateValue { date field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldSingleSelectValue { name field { ... on ProjectV2FieldCommon { name } } }
+              ... on ProjectV2ItemFieldIterationValue { title field { ... on ProjectV2FieldCommon { name } } }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// loadProjectItems fetches every issue on a board with its field values.
+// Draft items and pull requests are skipped.
+func loadProjectItems(ctx context.Context, client *github.Client, projectID string) ([]projectItem, error) {
+	var items []projectItem
+	var cursor interface{}
+	for {
+		var data struct {
+			Node struct {
+				Items struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						Content struct {
+							Number     int `json:"number"`
+							Repository struct {
+								NameWithOwner string `json:"nameWithOwner"`
+							} `json:"repository"`
+						} `json:"content"`
+						FieldValues struct {
+							Nodes []struct {
+								Text   *string  `json:"text"`
+								Number *float64 `json:"number"`
+								Date   *string  `json:"date"`
+								Name   *string  `json:"name"`
+								Title  *string  `json:"title"`
+								Field  struct {
+									Name string `json:"name"`
+								} `json:"field"`
+							} `json:"nodes"`
+						} `json:"fieldValues"`
+					} `json:"nodes"`
+				} `json:"items"`
+			} `json:"node"`
+		}
+		if err := githubGraphQL(ctx, client, projectItemsQuery, map[string]interface{}{"project": projectID, "cursor": cursor}, &data); err != nil {
+			return nil, fmt.Errorf("failed to read project items: %w", err)
+		}
+		for _, n := range data.Node.Items.Nodes {
+			if n.Content.Number == 0 {
+				continue
+			}
+			item := projectItem{Repository: n.Content.Repository.NameWithOwner, IssueNumber: n.Content.Number, Values: make(map[string]string)}
+			for _, v := range n.FieldValues.Nodes {
+				if v.Field.Name == "" {
+					continue
+				}
+				switch {
+				case v.Text != nil:
+					item.Values[v.Field.Name] = *v.Text
+				case v.Number != nil:
+					item.Values[v.Field.Name] = strconv.FormatFloat(*v.Number, 'f', -1, 64)
+				case v.Date != nil:
+					item.Values[v.Field.Name] = *v.Date
+				case v.Name != nil:
+					item.Values[v.Field.Name] = *v.Name
+				case v.Title != nil:
+					item.Values[v.Field.Name] = *v.Title
+				}
+			}
+			items = append(items, item)
+		}
+		if !data.Node.Items.PageInfo.HasNextPage {
+			return items, nil
+		}
+		cursor = data.Node.Items.PageInfo.EndCursor
+	}
+}
+
+var projectFieldNameRegex = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// projectFieldName returns the custom field name a board field imports
+// into, e.g. "Story Points" -> "story-points"
+func projectFieldName(name string) string {
+	return strings.Trim(projectFieldNameRegex.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// importableProjectFields are the board field types imported into custom
+// fields; built-in ones (title, assignees, labels...) map to task columns
+var importableProjectFields = map[string]bool{
+	"TEXT":          true,
+	"NUMBER":        true,
+	"DATE":          true,
+	"SINGLE_SELECT": true,
+	"ITERATION":     true,
+}
+
+// importProjectFields copies board field values of linked issues into the
+// custom fields of the same name, clearing values unset on the board. Board
+// fields with no custom field to hold them are returned as warnings.
+func importProjectFields(database *gorm.DB, repo string, project *projectV2, items []projectItem, dryRun bool) ([]projectFieldImport, []string, error) {
+	var links []models.GitHubIssueLink
+	if err := database.Where("repository = ?", repo).Find(&links).Error; err != nil {
+		return nil, nil, err
+	}
+	taskByIssue := make(map[int]string, len(links))
+	for _, l := range links {
+		taskByIssue[l.IssueNumber] = l.TaskID
+	}
+
+	var warnings []string
+	fields := make(map[string]models.CustomField)
+	for _, f := range project.Fields {
+		if !importableProjectFields[f.DataType] {
+			continue
+		}
+		name := projectFieldName(f.Name)
+		var field models.CustomField
+		if err := database.Where("name = ?", name).First(&field).Error; err != nil {
+			warnings = append(warnings, fmt.Sprintf("project field '%s' not imported: no custom field '%s' (define it with 'gur field define %s')", f.Name, name, name))
+			continue
+		}
+		fields[f.Name] = field
+	}
+	boardFields := make([]string, 0, len(fields))
+	for name := range fields {
+		boardFields = append(boardFields, name)
+	}
+	sort.Strings(boardFields)
+
+	var imports []projectFieldImport
+	for _, item := range items {
+		taskID, ok := taskByIssue[item.IssueNumber]
+		if !ok || !strings.EqualFold(item.Repository, repo) {
+			continue
+		}
+		for _, boardField := range boardFields {
+			field := fields[boardField]
+			value := item.Values[boardField]
+			if value != "" {
+				normalized, err := field.Normalize(value)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("issue #%d: %v", item.IssueNumber, err))
+					continue
+				}
+				value = normalized
+			}
+			var existing models.TaskFieldValue
+			old := ""
+			if database.Where("task_id = ? AND field_id = ?", taskID, field.ID).First(&existing).Error == nil {
+				old = existing.Value
+			}
+			if old == value {
+				continue
+			}
+			if !dryRun {
+				if err := applyFieldAssignments(database, taskID, []fieldAssignment{{Field: field, Value: value}}, projectImportBy); err != nil {
+					return imports, warnings, err
+				}
+				noteAffected(taskID)
+			}
+			imports = append(imports, projectFieldImport{TaskID: taskID, IssueNumber: item.IssueNumber, Field: field.Name, Old: old, New: value})
+		}
+	}
+	return imports, warnings, nil
+}
+
+// pullProjectFields imports the --project board's fields into custom fields
+func pullProjectFields(ctx context.Context, client *github.Client, database *gorm.DB, owner, repo string) ([]projectFieldImport, []string, error) {
+	projectOwner := syncPullProjectOwner
+	if projectOwner == "" {
+		projectOwner = owner
+	}
+	project, err := loadProjectV2(ctx, client, projectOwner, syncPullProject)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := loadProjectItems(ctx, client, project.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	imports, warnings, err := importProjectFields(database, repo, project, items, syncPullDryRun)
+	if err != nil {
+		return imports, warnings, fmt.Errorf("failed to import project fields: %w", err)
+	}
+	return imports, warnings, nil
+}
+
+// refreshVotes stores the +1 reaction count of each linked issue on its
+// task, returning how many tasks changed
+func refreshVotes(database *gorm.DB, repo string, issues []*github.Issue, dryRun bool) (int, error) {
+	changed := 0
+	for _, issue := range issues {
+		var link models.GitHubIssueLink
+		if err := database.Where("issue_number = ? AND repository = ?", issue.GetNumber(), repo).First(&link).Error; err != nil {
+			continue
+		}
+		votes := issue.GetReactions().GetPlusOne()
+		result := database.Model(&models.Task{}).Where("id = ? AND votes != ?", link.TaskID, votes)
+		if dryRun {
+			var n int64
+			if err := result.Count(&n).Error; err != nil {
+				return changed, err
+			}
+			changed += int(n)
+			continue
+		}
+		result = result.UpdateColumn("votes", votes)
+		if result.Error != nil {
+			return changed, fmt.Errorf("failed to update votes of %s: database error: %w", link.TaskID, result.Error)
+		}
+		changed += int(result.RowsAffected)
+	}
+	return changed, nil
+}
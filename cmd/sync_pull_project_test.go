@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestImportProjectFields(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"node": {"items": {"pageInfo": {"hasNextPage": false}, "nodes": [
+			{"content": {"number": 1, "repository": {"nameWithOwner": "acme/app"}}, "fieldValues": {"nodes": [
+				{"name": "In Progress", "field": {"name": "Status"}},
+				{"number": 5, "field": {"name": "Story Points"}},
+				{"text": "ignored", "field": {"name": "Title"}}
+			]}},
+			{"content": {"number": 2, "repository": {"nameWithOwner": "acme/app"}}, "fieldValues": {"nodes": [
+				{"number": 2.5, "field": {"name": "Story Points"}}
+			]}},
+			{"content": {}, "fieldValues": {"nodes": []}}
+		]}}}}`))
+	}))
+	items, err := loadProjectItems(context.Background(), client, "PVT_1")
+	if err != nil {
+		t.Fatalf("loadProjectItems() error: %v", err)
+	}
+	if len(items) != 2 || items[0].Values["Story Points"] != "5" || items[0].Values["Status"] != "In Progress" {
+		t.Fatalf("items = %+v, want the two issues with their values", items)
+	}
+
+	database.Create(&models.Task{ID: "gur-proj0001", Title: "One", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-proj0002", Title: "Two", Status: models.StatusOpen})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-proj0001", IssueNumber: 1, Repository: "acme/app"})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-proj0002", IssueNumber: 2, Repository: "acme/app"})
+	database.Create(&models.CustomField{Name: "status", Type: models.FieldTypeString})
+	database.Create(&models.CustomField{Name: "story-points", Type: models.FieldTypeNumber})
+	var status models.CustomField
+	database.Where("name = ?", "status").First(&status)
+	database.Create(&models.TaskFieldValue{TaskID: "gur-proj0002", FieldID: status.ID, Value: "Todo"})
+
+	project := &projectV2{Fields: []projectV2Field{
+		{Name: "Title", DataType: "TITLE"},
+		{Name: "Status", DataType: "SINGLE_SELECT"},
+		{Name: "Story Points", DataType: "NUMBER"},
+		{Name: "Sprint", DataType: "ITERATION"},
+	}}
+	imports, warnings, err := importProjectFields(database, "acme/app", project, items, false)
+	if err != nil {
+		t.Fatalf("importProjectFields() error: %v", err)
+	}
+	got := make(map[string]string)
+	for _, im := range imports {
+		got[im.TaskID+" "+im.Field] = im.Old + "->" + im.New
+	}
+	want := map[string]string{
+		"gur-proj0001 status":       "->In Progress",
+		"gur-proj0001 story-points": "->5",
+		"gur-proj0002 status":       "Todo->", // unset on the board
+		"gur-proj0002 story-points": "->2.5",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("imports = %v, want %v", got, want)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want one for Sprint", warnings)
+	}
+	var history int64
+	database.Model(&models.TaskHistory{}).Where("changed_by = ?", projectImportBy).Count(&history)
+	if history != 4 {
+		t.Errorf("%d history entries, want 4", history)
+	}
+
+	// A second import changes nothing
+	if imports, _, _ := importProjectFields(database, "acme/app", project, items, false); len(imports) != 0 {
+		t.Errorf("re-import = %+v, want no changes", imports)
+	}
+}
+
+func TestRefreshVotes(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	database.Create(&models.Task{ID: "gur-vote0001", Title: "Popular", Status: models.StatusOpen, Priority: 2})
+	database.Create(&models.Task{ID: "gur-vote0002", Title: "Quiet", Status: models.StatusOpen, Priority: 2})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-vote0001", IssueNumber: 1, Repository: "acme/app"})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-vote0002", IssueNumber: 2, Repository: "acme/app"})
+
+	issues := []*github.Issue{
+		{Number: github.Int(1), Reactions: &github.Reactions{PlusOne: github.Int(12)}},
+		{Number: github.Int(2)},
+		{Number: github.Int(3), Reactions: &github.Reactions{PlusOne: github.Int(4)}}, // not linked
+	}
+	if n, err := refreshVotes(database, "acme/app", issues, true); err != nil || n != 1 {
+		t.Fatalf("dry run = %d (%v), want 1 change", n, err)
+	}
+	if task, _ := db.GetTaskByID("gur-vote0001"); task.Votes != 0 {
+		t.Fatal("dry run stored votes")
+	}
+	if n, err := refreshVotes(database, "acme/app", issues, false); err != nil || n != 1 {
+		t.Fatalf("refreshVotes() = %d (%v), want 1 change", n, err)
+	}
+
+	// Among equal priority, the task with more votes is ready first
+	if got := readyIDs(t); !reflect.DeepEqual(got, []string{"gur-vote0001", "gur-vote0002"}) {
+		t.Errorf("ready order = %v, want the voted task first", got)
+	}
+}
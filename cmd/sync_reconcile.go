@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var syncReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Find pushed GitHub issues with no local link",
+	Long: `Find orphan issues: issues in the configured repository whose title has the
+issue prefix but that no task is linked to. They are left behind when a push
+stops between creating an issue and linking it (e.g., the database write
+failed or the process was killed).
+
+'sync push' records each issue creation before making it and clears the
+record when the link is saved, so an orphan is matched to its task by that
+record, or else by title. Without flags orphans are only listed.
+  --adopt  link each matched orphan to its task (the next push updates it)
+  --close  close orphans that can't be adopted as not planned, with a comment
+
+Examples:
+  gur sync reconcile
+  gur sync reconcile --adopt
+  gur sync reconcile --adopt --close`,
+	Args: cobra.NoArgs,
+	RunE: runSyncReconcile,
+}
+
+var (
+	syncReconcileAdopt bool
+	syncReconcileClose bool
+)
+
+func init() {
+	syncCmd.AddCommand(syncReconcileCmd)
+	syncReconcileCmd.Flags().BoolVar(&syncReconcileAdopt, "adopt", false, "Link orphans that match a task")
+	syncReconcileCmd.Flags().BoolVar(&syncReconcileClose, "close", false, "Close orphans that match no unlinked task")
+}
+
+// orphanIssue is a prefixed issue with no local link
+type orphanIssue struct {
+	IssueNumber int    `json:"issue_number"`
+	Title       string `json:"title"`
+	URL         string `json:"issue_url"`
+	State       string `json:"state"`
+	TaskID      string `json:"task_id,omitempty"`
+	Match       string `json:"match,omitempty"` // pending or title
+	Action      string `json:"action"`          // adopt, close, adopted or closed
+	Error       string `json:"error,omitempty"`
+}
+
+// adoptIssue links an existing issue to a task, marking the task synced and
+// clearing its pending record. The link has no content hash, so the next
+// push brings the issue up to date.
+func adoptIssue(database *gorm.DB, taskID, repo string, issueNumber int, issueURL string) error {
+	return database.Transaction(func(tx *gorm.DB) error {
+		link := models.GitHubIssueLink{
+			TaskID:        taskID,
+			IssueNumber:   issueNumber,
+			IssueURL:      issueURL,
+			Repository:    repo,
+			LastSyncedAt:  time.Now(),
+			SyncDirection: models.SyncDirectionPush,
+		}
+		if err := tx.Create(&link).Error; err != nil {
+			return fmt.Errorf("failed to link issue #%d to %s: %w", issueNumber, taskID, err)
+		}
+		if err := tx.Model(&models.Task{}).Where("id = ?", taskID).Update("synced", true).Error; err != nil {
+			return fmt.Errorf("failed to mark task as synced: %w", err)
+		}
+		return tx.Where("task_id = ? AND repository = ?", taskID, repo).Delete(&models.GitHubPendingSync{}).Error
+	})
+}
+
+// listPrefixedIssues returns every issue (open or closed, not pull requests)
+// whose title starts with the push title prefix
+func listPrefixedIssues(ctx context.Context, client *github.Client, owner, repo, prefix string) ([]*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	var issues []*github.Issue
+	for {
+		page, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, issue := range page {
+			if !issue.IsPullRequest() && strings.HasPrefix(issue.GetTitle(), prefix+" - ") {
+				issues = append(issues, issue)
+			}
+		}
+		if resp.NextPage == 0 {
+			return issues, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// findOrphanIssues matches prefixed issues without a link to the tasks they
+// were pushed from: first by a pending record carrying the issue number,
+// then by a pending record or an unlinked task with the same title. Orphans
+// with no match, or whose task is linked already, are to be closed.
+func findOrphanIssues(database *gorm.DB, repo, prefix string, issues []*github.Issue) ([]orphanIssue, error) {
+	var links []models.GitHubIssueLink
+	if err := database.Where("repository = ?", repo).Find(&links).Error; err != nil {
+		return nil, err
+	}
+	linkedIssue := make(map[int]bool, len(links))
+	linkedTask := make(map[string]bool, len(links))
+	for _, l := range links {
+		linkedIssue[l.IssueNumber] = true
+		linkedTask[l.TaskID] = true
+	}
+
+	var pending []models.GitHubPendingSync
+	if err := database.Where("repository = ?", repo).Order("id ASC").Find(&pending).Error; err != nil {
+		return nil, err
+	}
+	byNumber := make(map[int]string)
+	byTitle := make(map[string]string)
+	for _, p := range pending {
+		if p.IssueNumber != 0 {
+			byNumber[p.IssueNumber] = p.TaskID
+		} else {
+			byTitle[p.Title] = p.TaskID
+		}
+	}
+
+	var orphans []orphanIssue
+	claimed := make(map[string]bool)
+	for _, issue := range issues {
+		if linkedIssue[issue.GetNumber()] {
+			continue
+		}
+		o := orphanIssue{IssueNumber: issue.GetNumber(), Title: issue.GetTitle(), URL: issue.GetHTMLURL(), State: issue.GetState(), Action: "close"}
+		if id, ok := byNumber[o.IssueNumber]; ok {
+			o.TaskID, o.Match = id, "pending"
+		} else if id, ok := byTitle[o.Title]; ok {
+			o.TaskID, o.Match = id, "pending"
+		} else {
+			var task models.Task
+			title := strings.TrimPrefix(o.Title, prefix+" - ")
+			if database.Where("title = ? AND synced = ?", title, false).Order("created_at ASC").First(&task).Error == nil {
+				o.TaskID, o.Match = task.ID, "title"
+			}
+		}
+		if o.TaskID != "" && !linkedTask[o.TaskID] && !claimed[o.TaskID] {
+			if _, err := db.GetTaskByID(o.TaskID); err == nil {
+				o.Action = "adopt"
+				claimed[o.TaskID] = true
+			}
+		}
+		orphans = append(orphans, o)
+	}
+	return orphans, nil
+}
+
+func runSyncReconcile(cmd *cobra.Command, args []string) error {
+	repo, err := db.GetConfig(models.ConfigGitHubRepo)
+	if err != nil || repo == "" {
+		return codedErrorf(ErrCodeNotConfigured, "GitHub sync not configured: repository not set (run 'gur config github' to configure)")
+	}
+	prefix, err := db.GetConfig(models.ConfigGitHubIssuePrefix)
+	if err != nil || prefix == "" {
+		prefix = models.DefaultGitHubIssuePrefix
+	}
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository format '%s': expected 'owner/repo' (run 'gur config github' to reconfigure)", repo)
+	}
+	owner, repoName := parts[0], parts[1]
+
+	token, err := GetGitHubToken()
+	if err != nil {
+		return err
+	}
+	client, err := newGitHubClient(token)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	database := db.GetDB()
+	issues, err := listPrefixedIssues(ctx, client, owner, repoName, prefix)
+	if err != nil {
+		return err
+	}
+	orphans, err := findOrphanIssues(database, repo, prefix, issues)
+	if err != nil {
+		return fmt.Errorf("failed to match issues: database error: %w", err)
+	}
+
+	for i := range orphans {
+		o := &orphans[i]
+		switch {
+		case o.Action == "adopt" && syncReconcileAdopt:
+			if err := adoptIssue(database, o.TaskID, repo, o.IssueNumber, o.URL); err != nil {
+				o.Error = err.Error()
+				continue
+			}
+			noteAffected(o.TaskID)
+			o.Action = "adopted"
+		case o.Action == "close" && syncReconcileClose:
+			if err := closeOrphanIssue(ctx, client, owner, repoName, o); err != nil {
+				o.Error = err.Error()
+				continue
+			}
+			o.Action = "closed"
+		}
+	}
+
+	// Pending records whose task is linked now, or that never got an issue
+	// and match no orphan, are leftovers of pushes that did finish or failed
+	// before GitHub created anything
+	cleared := int64(0)
+	if syncReconcileAdopt {
+		cleared = clearStalePending(database, repo, orphans)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "repository": repo, "count": len(orphans), "orphans": orphans, "cleared_pending": cleared})
+		return nil
+	}
+	if len(orphans) == 0 {
+		fmt.Printf("No orphan issues in %s\n", repo)
+		return nil
+	}
+	fmt.Printf("Orphan issues in %s (%d):\n", repo, len(orphans))
+	for _, o := range orphans {
+		match := "no unlinked task matches"
+		if o.Action == "adopt" || o.Action == "adopted" {
+			match = fmt.Sprintf("-> %s (by %s)", o.TaskID, o.Match)
+		}
+		action := o.Action
+		switch {
+		case o.Error != "":
+			action = "failed: " + o.Error
+		case o.Action == "adopt":
+			action = "adopt with --adopt"
+		case o.Action == "close":
+			action = "close with --close"
+		}
+		fmt.Printf("  #%d %s [%s] %s: %s\n", o.IssueNumber, o.Title, o.State, match, action)
+	}
+	return nil
+}
+
+// closeOrphanIssue explains and closes an orphan that no task will adopt
+func closeOrphanIssue(ctx context.Context, client *github.Client, owner, repo string, o *orphanIssue) error {
+	if o.State == "closed" {
+		return nil
+	}
+	body := "Closed by `gur sync reconcile`: no local task is linked to this issue."
+	if _, _, err := client.Issues.CreateComment(ctx, owner, repo, o.IssueNumber, &github.IssueComment{Body: &body}); err != nil {
+		return fmt.Errorf("failed to comment: %w", err)
+	}
+	state, reason := "closed", "not_planned"
+	if _, _, err := client.Issues.Edit(ctx, owner, repo, o.IssueNumber, &github.IssueRequest{State: &state, StateReason: &reason}); err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+	o.State = "closed"
+	return nil
+}
+
+// clearStalePending deletes pending records that no orphan is waiting on
+func clearStalePending(database *gorm.DB, repo string, orphans []orphanIssue) int64 {
+	waiting := []string{""}
+	for _, o := range orphans {
+		if o.Action == "adopt" && o.TaskID != "" {
+			waiting = append(waiting, o.TaskID)
+		}
+	}
+	result := database.Where("repository = ? AND task_id NOT IN ?", repo, waiting).Delete(&models.GitHubPendingSync{})
+	return result.RowsAffected
+}
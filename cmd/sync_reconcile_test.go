@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestSyncPushPendingRecord(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	var created atomic.Int32
+	var dropCreate atomic.Bool
+	dropCreate.Store(true)
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/issues":
+			created.Add(1)
+			if dropCreate.Load() {
+				// The issue is created but the response never arrives
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"number": 8, "html_url": "https://github.com/acme/app/issues/8"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/app/issues/7":
+			w.Write([]byte(`{"number": 7}`))
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/repos/acme/app/issues/"):
+			w.Write([]byte(`{"number": 7, "html_url": "https://github.com/acme/app/issues/7"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/labels"):
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-pend0001", Title: "Login", Status: models.StatusOpen, Type: models.TypeTask})
+	push := func() (map[string]interface{}, error) {
+		task, _ := db.GetTaskByID("gur-pend0001")
+		return syncTaskToGitHub(context.Background(), client, "acme", "app", "[Agent]", models.DefaultLabelMap(), nil, nil, nil, *task)
+	}
+
+	// A lost response leaves the pending record, and the next push refuses
+	// to create a possible duplicate
+	if _, err := push(); err == nil {
+		t.Fatal("push with a dropped connection succeeded")
+	}
+	var pending models.GitHubPendingSync
+	if err := database.Where("task_id = ?", "gur-pend0001").First(&pending).Error; err != nil || pending.Title != "[Agent] - Login" {
+		t.Fatalf("pending record = %+v (%v), want one for the issue title", pending, err)
+	}
+	if _, err := push(); err == nil || !strings.Contains(err.Error(), "sync reconcile") || created.Load() != 1 {
+		t.Fatalf("second push error = %v after %d creates, want a reconcile hint and no new issue", err, created.Load())
+	}
+
+	// Once the issue number is known the next push adopts and updates it
+	database.Model(&pending).Updates(map[string]interface{}{"issue_number": 7, "issue_url": "https://github.com/acme/app/issues/7"})
+	result, err := push()
+	if err != nil || result["action"] != "updated" || created.Load() != 1 {
+		t.Fatalf("push after recording the issue = %v (%v), want the issue adopted and updated", result, err)
+	}
+	var count int64
+	database.Model(&models.GitHubPendingSync{}).Count(&count)
+	if count != 0 {
+		t.Errorf("%d pending records left after adoption", count)
+	}
+
+	// A clean create leaves no pending record behind
+	dropCreate.Store(false)
+	database.Create(&models.Task{ID: "gur-pend0002", Title: "Logout", Status: models.StatusOpen, Type: models.TypeTask})
+	task, _ := db.GetTaskByID("gur-pend0002")
+	if _, err := syncTaskToGitHub(context.Background(), client, "acme", "app", "[Agent]", models.DefaultLabelMap(), nil, nil, nil, *task); err != nil {
+		t.Fatalf("push error: %v", err)
+	}
+	database.Model(&models.GitHubPendingSync{}).Count(&count)
+	if count != 0 {
+		t.Errorf("%d pending records left after a clean create", count)
+	}
+}
+
+func TestFindOrphanIssues(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+
+	database.Create(&models.Task{ID: "gur-orph0001", Title: "Linked", Status: models.StatusOpen, Synced: true})
+	database.Create(&models.Task{ID: "gur-orph0002", Title: "Lost response", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-orph0003", Title: "Same title", Status: models.StatusOpen})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-orph0001", IssueNumber: 1, Repository: "acme/app"})
+	database.Create(&models.GitHubPendingSync{TaskID: "gur-orph0002", Repository: "acme/app", Title: "[Agent] - Lost response"})
+
+	issue := func(n int, title string) *github.Issue {
+		return &github.Issue{Number: github.Int(n), Title: github.String(title), State: github.String("open")}
+	}
+	issues := []*github.Issue{
+		issue(1, "[Agent] - Linked"),
+		issue(2, "[Agent] - Lost response"),
+		issue(3, "[Agent] - Same title"),
+		issue(4, "[Agent] - Same title"), // duplicate: only one can be adopted
+		issue(5, "[Agent] - Deleted task"),
+	}
+	orphans, err := findOrphanIssues(database, "acme/app", "[Agent]", issues)
+	if err != nil {
+		t.Fatalf("findOrphanIssues() error: %v", err)
+	}
+	got := make(map[int]string)
+	for _, o := range orphans {
+		got[o.IssueNumber] = o.Action + ":" + o.TaskID + ":" + o.Match
+	}
+	want := map[int]string{
+		2: "adopt:gur-orph0002:pending",
+		3: "adopt:gur-orph0003:title",
+		4: "close:gur-orph0003:title",
+		5: "close::",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("orphans = %v, want %v", got, want)
+	}
+	for n, w := range want {
+		if got[n] != w {
+			t.Errorf("issue #%d = %s, want %s", n, got[n], w)
+		}
+	}
+
+	if err := adoptIssue(database, "gur-orph0002", "acme/app", 2, ""); err != nil {
+		t.Fatalf("adoptIssue() error: %v", err)
+	}
+	if task, _ := db.GetTaskByID("gur-orph0002"); !task.Synced {
+		t.Error("adopted task not marked synced")
+	}
+}
@@ -4,22 +4,58 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 
 	"guardrails/internal/db"
 	"guardrails/internal/models"
 )
 
 var syncStatusCmd = &cobra.Command{
-	Use:   "status",
-	Short: "Show sync status with GitHub",
-	Long:  `Show the current sync status between local gur database and GitHub.`,
-	RunE:  runSyncStatus,
+	Use:         "status",
+	Short:       "Show sync status with GitHub",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long:        `Show the current sync status between local gur database and GitHub.`,
+	RunE:        runSyncStatus,
 }
 
 func init() {
 	syncCmd.AddCommand(syncStatusCmd)
 }
 
+// syncStatus counts non-archived tasks and issue links by sync state
+type syncStatus struct {
+	Repository    string
+	TotalTasks    int64
+	SyncedTasks   int64
+	UnsyncedTasks int64
+	LocalTasks    int64
+	GitHubTasks   int64
+	TotalLinks    int64
+	PushLinks     int64
+	PullLinks     int64
+	RecentLinks   []models.GitHubIssueLink // Five most recently synced
+}
+
+func collectSyncStatus(database *gorm.DB) syncStatus {
+	var st syncStatus
+
+	// Count tasks by sync status
+	database.Model(&models.Task{}).Where("status != ?", models.StatusArchived).Count(&st.TotalTasks)
+	database.Model(&models.Task{}).Where("synced = ? AND status != ?", true, models.StatusArchived).Count(&st.SyncedTasks)
+	database.Model(&models.Task{}).Where("synced = ? AND status != ?", false, models.StatusArchived).Count(&st.UnsyncedTasks)
+	database.Model(&models.Task{}).Where("source = ? AND status != ?", models.SourceLocal, models.StatusArchived).Count(&st.LocalTasks)
+	database.Model(&models.Task{}).Where("source = ? AND status != ?", models.SourceGitHub, models.StatusArchived).Count(&st.GitHubTasks)
+
+	// Count links by direction
+	database.Model(&models.GitHubIssueLink{}).Count(&st.TotalLinks)
+	database.Model(&models.GitHubIssueLink{}).Where("sync_direction = ?", models.SyncDirectionPush).Count(&st.PushLinks)
+	database.Model(&models.GitHubIssueLink{}).Where("sync_direction = ?", models.SyncDirectionPull).Count(&st.PullLinks)
+
+	// Get recent syncs
+	database.Order("last_synced_at DESC").Limit(5).Find(&st.RecentLinks)
+	return st
+}
+
 func runSyncStatus(cmd *cobra.Command, args []string) error {
 	// Get GitHub configuration
 	repo, err := db.GetConfig(models.ConfigGitHubRepo)
@@ -35,47 +71,22 @@ func runSyncStatus(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	database := db.GetDB()
-
-	// Count tasks by sync status
-	var totalTasks int64
-	var syncedTasks int64
-	var unsyncedTasks int64
-	var localTasks int64
-	var githubTasks int64
-
-	database.Model(&models.Task{}).Where("status != ?", models.StatusArchived).Count(&totalTasks)
-	database.Model(&models.Task{}).Where("synced = ? AND status != ?", true, models.StatusArchived).Count(&syncedTasks)
-	database.Model(&models.Task{}).Where("synced = ? AND status != ?", false, models.StatusArchived).Count(&unsyncedTasks)
-	database.Model(&models.Task{}).Where("source = ? AND status != ?", models.SourceLocal, models.StatusArchived).Count(&localTasks)
-	database.Model(&models.Task{}).Where("source = ? AND status != ?", models.SourceGitHub, models.StatusArchived).Count(&githubTasks)
-
-	// Count links by direction
-	var totalLinks int64
-	var pushLinks int64
-	var pullLinks int64
-
-	database.Model(&models.GitHubIssueLink{}).Count(&totalLinks)
-	database.Model(&models.GitHubIssueLink{}).Where("sync_direction = ?", models.SyncDirectionPush).Count(&pushLinks)
-	database.Model(&models.GitHubIssueLink{}).Where("sync_direction = ?", models.SyncDirectionPull).Count(&pullLinks)
-
-	// Get recent syncs
-	var recentLinks []models.GitHubIssueLink
-	database.Order("last_synced_at DESC").Limit(5).Find(&recentLinks)
+	st := collectSyncStatus(db.GetDB())
+	st.Repository = repo
 
 	if IsJSONOutput() {
 		OutputJSON(map[string]interface{}{
 			"configured":     true,
 			"repository":     repo,
-			"total_tasks":    totalTasks,
-			"synced_tasks":   syncedTasks,
-			"unsynced_tasks": unsyncedTasks,
-			"local_tasks":    localTasks,
-			"github_tasks":   githubTasks,
-			"total_links":    totalLinks,
-			"push_links":     pushLinks,
-			"pull_links":     pullLinks,
-			"recent_syncs":   recentLinks,
+			"total_tasks":    st.TotalTasks,
+			"synced_tasks":   st.SyncedTasks,
+			"unsynced_tasks": st.UnsyncedTasks,
+			"local_tasks":    st.LocalTasks,
+			"github_tasks":   st.GitHubTasks,
+			"total_links":    st.TotalLinks,
+			"push_links":     st.PushLinks,
+			"pull_links":     st.PullLinks,
+			"recent_syncs":   st.RecentLinks,
 		})
 		return nil
 	}
@@ -85,20 +96,20 @@ func runSyncStatus(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Repository: %s\n\n", repo)
 
 	fmt.Printf("Tasks:\n")
-	fmt.Printf("  Total:    %d\n", totalTasks)
-	fmt.Printf("  Synced:   %d\n", syncedTasks)
-	fmt.Printf("  Unsynced: %d\n", unsyncedTasks)
-	fmt.Printf("  Local:    %d (created in gur)\n", localTasks)
-	fmt.Printf("  GitHub:   %d (pulled from GitHub)\n", githubTasks)
+	fmt.Printf("  Total:    %d\n", st.TotalTasks)
+	fmt.Printf("  Synced:   %d\n", st.SyncedTasks)
+	fmt.Printf("  Unsynced: %d\n", st.UnsyncedTasks)
+	fmt.Printf("  Local:    %d (created in gur)\n", st.LocalTasks)
+	fmt.Printf("  GitHub:   %d (pulled from GitHub)\n", st.GitHubTasks)
 
 	fmt.Printf("\nLinks:\n")
-	fmt.Printf("  Total:  %d\n", totalLinks)
-	fmt.Printf("  Pushed: %d (gur -> GitHub)\n", pushLinks)
-	fmt.Printf("  Pulled: %d (GitHub -> gur)\n", pullLinks)
+	fmt.Printf("  Total:  %d\n", st.TotalLinks)
+	fmt.Printf("  Pushed: %d (gur -> GitHub)\n", st.PushLinks)
+	fmt.Printf("  Pulled: %d (GitHub -> gur)\n", st.PullLinks)
 
-	if len(recentLinks) > 0 {
+	if len(st.RecentLinks) > 0 {
 		fmt.Printf("\nRecent Syncs:\n")
-		for _, link := range recentLinks {
+		for _, link := range st.RecentLinks {
 			direction := "→"
 			if link.SyncDirection == models.SyncDirectionPull {
 				direction = "←"
@@ -113,8 +124,8 @@ func runSyncStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if unsyncedTasks > 0 {
-		fmt.Printf("\nTip: Run 'gur sync push' to sync %d unsynced task(s) to GitHub.\n", unsyncedTasks)
+	if st.UnsyncedTasks > 0 {
+		fmt.Printf("\nTip: Run 'gur sync push' to sync %d unsynced task(s) to GitHub.\n", st.UnsyncedTasks)
 	}
 
 	return nil
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestSyncTaskToGitHubSkipsUnchanged(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	calls := make(map[string]int)
+	requests := 0
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method]++
+		requests++
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/issues":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"number": 7, "html_url": "https://github.com/acme/app/issues/7"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/app/issues/7":
+			w.Write([]byte(`{"number": 7}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/repos/acme/app/issues/7":
+			w.Write([]byte(`{"number": 7, "html_url": "https://github.com/acme/app/issues/7"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/issues/7/labels":
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-diff0001", Title: "Login", Status: models.StatusOpen, Type: models.TypeTask})
+	push := func() string {
+		t.Helper()
+		task, _ := db.GetTaskByID("gur-diff0001")
+		result, err := syncTaskToGitHub(context.Background(), client, "acme", "app", "[Agent]", models.DefaultLabelMap(), nil, nil, nil, *task)
+		if err != nil {
+			t.Fatalf("syncTaskToGitHub() error: %v", err)
+		}
+		return result["action"].(string)
+	}
+
+	if action := push(); action != "created" {
+		t.Fatalf("first push action = %s, want created", action)
+	}
+	var link models.GitHubIssueLink
+	database.Where("task_id = ?", "gur-diff0001").First(&link)
+	if link.ContentHash == "" {
+		t.Fatal("created link has no content hash")
+	}
+
+	before := requests
+	edits := calls[http.MethodPatch]
+	if action := push(); action != "skipped" || requests != before {
+		t.Errorf("unchanged push action = %s with %d requests, want skipped with none", action, requests-before)
+	}
+
+	database.Model(&models.Task{}).Where("id = ?", "gur-diff0001").Update("title", "Login v2")
+	if action := push(); action != "updated" || calls[http.MethodPatch] != edits+1 {
+		t.Errorf("changed push action = %s, want updated with one edit", action)
+	}
+	if action := push(); action != "skipped" {
+		t.Errorf("push after update action = %s, want skipped", action)
+	}
+
+	syncPushForce = true
+	defer func() { syncPushForce = false }()
+	if action := push(); action != "updated" {
+		t.Errorf("forced push action = %s, want updated", action)
+	}
+}
+
+func TestIssueContentHash(t *testing.T) {
+	base := issueContentHash("T", "B", "open", "completed", 0, []string{"bug", "P1"}, "")
+	if base != issueContentHash("T", "B", "open", "", 0, []string{"P1", "bug"}, "") {
+		t.Error("label order and the state reason of open issues should not change the hash")
+	}
+	for name, other := range map[string]string{
+		"body":      issueContentHash("T", "B2", "open", "", 0, []string{"bug", "P1"}, ""),
+		"state":     issueContentHash("T", "B", "closed", "completed", 0, []string{"bug", "P1"}, ""),
+		"milestone": issueContentHash("T", "B", "open", "", 3, []string{"bug", "P1"}, ""),
+		"labels":    issueContentHash("T", "B", "open", "", 0, []string{"bug"}, ""),
+		"assignee":  issueContentHash("T", "B", "open", "", 0, []string{"bug", "P1"}, "octocat"),
+	} {
+		if other == base {
+			t.Errorf("changing the %s did not change the hash", name)
+		}
+	}
+}
+
+func TestOrderForPush(t *testing.T) {
+	now := time.Now()
+	tasks := []models.Task{
+		{ID: "gur-00000001", Priority: 2, UpdatedAt: now},
+		{ID: "gur-00000002", Priority: 0, UpdatedAt: now.Add(-time.Hour)},
+		{ID: "gur-00000003", Priority: 2, UpdatedAt: now.Add(time.Minute)},
+		{ID: "gur-00000004", Priority: 0, UpdatedAt: now},
+	}
+	orderForPush(tasks)
+	got := deferredIDs(tasks)
+	want := []string{"gur-00000004", "gur-00000002", "gur-00000003", "gur-00000001"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("push order = %v, want %v", got, want)
+		}
+	}
+}
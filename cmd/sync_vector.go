@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"gorm.io/gorm"
+
+	"guardrails/internal/models"
+)
+
+// syncVectorRegex matches the hidden sync vector marker in an issue body
+var syncVectorRegex = regexp.MustCompile(`\n?<!-- gur-sync:(\{.*?\}) -->`)
+
+// syncVectorMarker carries the machines' push times through GitHub as a
+// hidden comment, so other machines can detect pushes they haven't seen
+func syncVectorMarker(vector models.SyncVector) string {
+	if len(vector) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n<!-- gur-sync:%s -->", vector)
+}
+
+// parseSyncVector returns the sync vector in an issue body (empty if none)
+// and the body without the marker
+func parseSyncVector(body string) (models.SyncVector, string) {
+	m := syncVectorRegex.FindStringSubmatch(body)
+	if m == nil {
+		return models.SyncVector{}, body
+	}
+	return models.ParseSyncVector(m[1]), syncVectorRegex.ReplaceAllString(body, "")
+}
+
+// syncMachineID identifies this machine in sync vectors: the hashed
+// hostname, as in sync markers
+func syncMachineID() string {
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "unknown"
+	}
+	return hashHostname(hostname)
+}
+
+// splitBrainError reports pushes by other machines that this one hasn't
+// pulled, which a push would overwrite
+func splitBrainError(link models.GitHubIssueLink, remote models.SyncVector, machines []string) error {
+	var seen []string
+	for _, m := range machines {
+		seen = append(seen, fmt.Sprintf("%s at %s", m, remote[m].Local().Format(models.DateTimeShortFormat)))
+	}
+	return fmt.Errorf("issue #%d was pushed from another machine (%s) since this one last synced it, and pushing would overwrite those changes: run 'gur sync pull' to merge them first, or push with --force-push to overwrite",
+		link.IssueNumber, strings.Join(seen, ", "))
+}
+
+// checkSplitBrain reads the issue's sync vector and fails if another
+// machine pushed it since this one last did or pulled, unless force is set.
+// It returns the vector to push: the remote one merged with the link's,
+// with this machine's entry set to the task's update time.
+func checkSplitBrain(ctx context.Context, client *github.Client, owner, repo string, link models.GitHubIssueLink, task models.Task, force bool) (models.SyncVector, error) {
+	issue, _, err := client.Issues.Get(ctx, owner, repo, link.IssueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue #%d: %w", link.IssueNumber, err)
+	}
+	remote, _ := parseSyncVector(issue.GetBody())
+	known := models.ParseSyncVector(link.SyncVector)
+	self := syncMachineID()
+	if diverged := remote.Diverged(known, self); len(diverged) > 0 && !force {
+		return nil, splitBrainError(link, remote, diverged)
+	}
+	vector := remote.Merge(known)
+	vector[self] = task.UpdatedAt.UTC().Truncate(time.Second)
+	return vector, nil
+}
+
+// adoptSyncVector records the pushes seen on a pulled issue, once its
+// changes are merged into the task
+func adoptSyncVector(database *gorm.DB, link *models.GitHubIssueLink, issue *github.Issue) error {
+	remote, _ := parseSyncVector(issue.GetBody())
+	if len(remote) == 0 {
+		return nil
+	}
+	vector := models.ParseSyncVector(link.SyncVector).Merge(remote).String()
+	if vector == link.SyncVector {
+		return nil
+	}
+	link.SyncVector = vector
+	return database.Model(link).Update("sync_vector", vector).Error
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestSyncPushDetectsSplitBrain(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	t1 := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	remoteBody := "Edited elsewhere" + syncVectorMarker(models.SyncVector{"other": t1})
+	client := newTestGitHubClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/app/issues/7":
+			json.NewEncoder(w).Encode(map[string]interface{}{"number": 7, "body": remoteBody})
+		case r.Method == http.MethodPatch && r.URL.Path == "/repos/acme/app/issues/7":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			remoteBody = req["body"].(string)
+			w.Write([]byte(`{"number": 7, "html_url": "https://github.com/acme/app/issues/7"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/issues/7/labels":
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-5b000001", Title: "Login", Status: models.StatusOpen, Type: models.TypeTask})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-5b000001", IssueNumber: 7, Repository: "acme/app"})
+	push := func() error {
+		t.Helper()
+		task, _ := db.GetTaskByID("gur-5b000001")
+		_, err := syncTaskToGitHub(context.Background(), client, "acme", "app", "[Agent]", models.DefaultLabelMap(), nil, nil, nil, *task)
+		return err
+	}
+	link := func() models.GitHubIssueLink {
+		var l models.GitHubIssueLink
+		database.Where("task_id = ?", "gur-5b000001").First(&l)
+		return l
+	}
+
+	if err := push(); err == nil || !strings.Contains(err.Error(), "--force-push") {
+		t.Fatalf("push over an unseen push from another machine = %v, want it refused", err)
+	}
+
+	// Pulling merges the other machine's push, after which pushing is fine
+	l := link()
+	if err := adoptSyncVector(database, &l, &github.Issue{Body: github.String(remoteBody)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := push(); err != nil {
+		t.Fatalf("push after pull = %v", err)
+	}
+	vector, body := parseSyncVector(remoteBody)
+	if vector["other"] != t1 || vector[syncMachineID()].IsZero() || strings.Contains(body, "gur-sync") {
+		t.Errorf("pushed vector = %v, want both machines recorded", vector)
+	}
+	if l := link(); l.SyncedBody != remoteBody || models.ParseSyncVector(l.SyncVector)["other"] != t1 {
+		t.Error("link does not record the pushed body and vector")
+	}
+
+	// The other machine pushes again; this machine's next push is refused
+	// until forced
+	vector["other"] = t1.Add(time.Hour)
+	remoteBody = body + syncVectorMarker(vector)
+	database.Model(&models.Task{}).Where("id = ?", "gur-5b000001").Update("description", "Login v2")
+	if err := push(); err == nil {
+		t.Fatal("push over a second unseen push succeeded")
+	}
+	syncPushOverwrite = true
+	defer func() { syncPushOverwrite = false }()
+	if err := push(); err != nil {
+		t.Fatalf("push with --force-push = %v", err)
+	}
+	if !strings.Contains(remoteBody, "Login v2") {
+		t.Error("forced push did not overwrite the issue")
+	}
+}
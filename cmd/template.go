@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -30,17 +31,34 @@ var templateCreateCmd = &cobra.Command{
 }
 
 var templateListCmd = &cobra.Command{
-	Use:     "list",
-	Aliases: []string{"ls"},
-	Short:   "List all templates",
-	RunE:    runTemplateList,
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List all templates",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runTemplateList,
 }
 
 var templateShowCmd = &cobra.Command{
-	Use:   "show <name>",
-	Short: "Show template details",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runTemplateShow,
+	Use:         "show <name>",
+	Short:       "Show template details",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runTemplateShow,
+}
+
+var templateVarsCmd = &cobra.Command{
+	Use:   "vars <name>",
+	Short: "List a template's {{placeholder}} variables",
+	Long: `List the {{placeholder}} variables a template uses in its title,
+description and labels. Fill them with 'gur create --template <name>
+--var name=value'.
+
+Example:
+  gur template create incident "[{{service}}] incident" -d "Severity {{severity}}" -l "svc:{{service}}"
+  gur template vars incident
+  gur create --template incident --var service=auth --var severity=2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateVars,
 }
 
 var templateDeleteCmd = &cobra.Command{
@@ -56,6 +74,7 @@ func init() {
 	templateCmd.AddCommand(templateCreateCmd)
 	templateCmd.AddCommand(templateListCmd)
 	templateCmd.AddCommand(templateShowCmd)
+	templateCmd.AddCommand(templateVarsCmd)
 	templateCmd.AddCommand(templateDeleteCmd)
 
 	templateCreateCmd.Flags().IntVarP(&tmplPriority, "priority", "p", models.PriorityMedium, "Default priority (0-4)")
@@ -89,6 +108,7 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 	if err := db.GetDB().Create(template).Error; err != nil {
 		return fmt.Errorf("failed to create template '%s': database error: %w", name, err)
 	}
+	noteAffected(template.ID)
 
 	if IsJSONOutput() {
 		OutputJSON(template)
@@ -128,7 +148,7 @@ func runTemplateShow(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	var template models.Template
 	if err := db.GetDB().Where("name = ? OR id = ?", name, name).First(&template).Error; err != nil {
-		return fmt.Errorf("template '%s' not found (use 'gur template list' to see available templates)", name)
+		return codedErrorf(ErrCodeNotFound, "template '%s' not found (use 'gur template list' to see available templates)", name)
 	}
 
 	if IsJSONOutput() {
@@ -149,6 +169,41 @@ func runTemplateShow(cmd *cobra.Command, args []string) error {
 	if len(template.Labels) > 0 {
 		fmt.Printf("Labels:      %v\n", template.Labels)
 	}
+	if vars := template.Vars(); len(vars) > 0 {
+		names := make([]string, len(vars))
+		for i, v := range vars {
+			names[i] = v.Name
+		}
+		fmt.Printf("Variables:   %s\n", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+func runTemplateVars(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	var template models.Template
+	if err := db.GetDB().Where("name = ? OR id = ?", name, name).First(&template).Error; err != nil {
+		return codedErrorf(ErrCodeNotFound, "template '%s' not found (use 'gur template list' to see available templates)", name)
+	}
+	vars := template.Vars()
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"template": template.Name, "count": len(vars), "vars": vars})
+		return nil
+	}
+
+	if len(vars) == 0 {
+		fmt.Printf("Template %s has no variables\n", template.Name)
+		return nil
+	}
+	for _, v := range vars {
+		fmt.Printf("%-20s %s\n", v.Name, strings.Join(v.Fields, ", "))
+	}
+	example := make([]string, len(vars))
+	for i, v := range vars {
+		example[i] = "--var " + v.Name + "=..."
+	}
+	fmt.Printf("\nUsage: gur create --template %s %s\n", template.Name, strings.Join(example, " "))
 	return nil
 }
 
@@ -159,7 +214,7 @@ func runTemplateDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to delete template '%s': database error: %w", name, result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("cannot delete template: template '%s' not found (use 'gur template list' to see available templates)", name)
+		return codedErrorf(ErrCodeNotFound, "cannot delete template: template '%s' not found (use 'gur template list' to see available templates)", name)
 	}
 
 	if IsJSONOutput() {
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// templateIssueLabelPrefix marks issues opened from an exported form; the
+// label names the gur template so pull can map the issue back to it
+const templateIssueLabelPrefix = "gur-template: "
+
+// defaultIssueTemplateDir is where GitHub looks for issue forms
+const defaultIssueTemplateDir = ".github/ISSUE_TEMPLATE"
+
+// Issue form field ids and the headings GitHub renders them under
+const (
+	issueFormDescription = "Description"
+	issueFormPriority    = "Priority"
+	issueFormNoResponse  = "_No response_"
+)
+
+var templateExportGitHubCmd = &cobra.Command{
+	Use:   "export-github [name...]",
+	Short: "Export templates as GitHub issue forms",
+	Long: `Write templates as GitHub issue forms (.github/ISSUE_TEMPLATE/<name>.yml).
+
+Each form pre-fills the template's title and description, offers a priority
+dropdown defaulting to the template's priority, and applies the template's
+type and labels (through the label mapping) plus a "gur-template: <name>"
+label. 'gur sync pull' uses that label to map issues opened from the form
+back to the template: type, labels, and the priority and description
+entered in the form.
+
+With no names, all templates are exported.
+
+Examples:
+  gur template export-github
+  gur template export-github bug-report --dir .github/ISSUE_TEMPLATE
+  gur template export-github --dry-run`,
+	RunE: runTemplateExportGitHub,
+}
+
+var (
+	tmplExportDir    string
+	tmplExportDryRun bool
+	tmplExportForce  bool
+)
+
+func init() {
+	templateCmd.AddCommand(templateExportGitHubCmd)
+	templateExportGitHubCmd.Flags().StringVar(&tmplExportDir, "dir", defaultIssueTemplateDir, "Directory to write issue forms to")
+	templateExportGitHubCmd.Flags().BoolVar(&tmplExportDryRun, "dry-run", false, "Print the forms without writing files")
+	templateExportGitHubCmd.Flags().BoolVar(&tmplExportForce, "force", false, "Overwrite forms that were not generated by gur")
+}
+
+// yamlString quotes s as a YAML double-quoted scalar (JSON strings are valid YAML)
+func yamlString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// issueFormHeader starts every generated form so re-exports can tell their
+// own files from hand-written ones
+const issueFormHeader = "# Generated by 'gur template export-github'"
+
+// renderIssueForm renders a template as a GitHub issue form
+func renderIssueForm(t models.Template, labelMap models.LabelMap) string {
+	labels := labelMap.LabelsForTask(*t.ToTask())
+	labels = append(labels, templateIssueLabelPrefix+t.Name)
+	quoted := make([]string, len(labels))
+	for i, l := range labels {
+		quoted[i] = yamlString(l)
+	}
+
+	description := t.Description
+	if line, _, _ := strings.Cut(description, "\n"); line != "" {
+		description = line
+	} else {
+		description = fmt.Sprintf("Create a %s from the %s template", t.Type, t.Name)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s from template %s; edit the template and re-export instead of this file.\n", issueFormHeader, yamlString(t.Name))
+	fmt.Fprintf(&b, "name: %s\n", yamlString(t.Name))
+	fmt.Fprintf(&b, "description: %s\n", yamlString(description))
+	if t.Title != "" {
+		fmt.Fprintf(&b, "title: %s\n", yamlString(t.Title))
+	}
+	fmt.Fprintf(&b, "labels: [%s]\n", strings.Join(quoted, ", "))
+	b.WriteString("body:\n")
+	b.WriteString("  - type: textarea\n")
+	b.WriteString("    id: description\n")
+	b.WriteString("    attributes:\n")
+	fmt.Fprintf(&b, "      label: %s\n", issueFormDescription)
+	if t.Description != "" {
+		fmt.Fprintf(&b, "      value: %s\n", yamlString(t.Description))
+	}
+	b.WriteString("    validations:\n")
+	b.WriteString("      required: true\n")
+	b.WriteString("  - type: dropdown\n")
+	b.WriteString("    id: priority\n")
+	b.WriteString("    attributes:\n")
+	fmt.Fprintf(&b, "      label: %s\n", issueFormPriority)
+	b.WriteString("      options:\n")
+	for p := models.PriorityCritical; p <= models.PriorityLowest; p++ {
+		fmt.Fprintf(&b, "        - %s\n", yamlString((&models.Task{Priority: p}).PriorityString()))
+	}
+	fmt.Fprintf(&b, "      default: %d\n", t.Priority)
+	return b.String()
+}
+
+func runTemplateExportGitHub(cmd *cobra.Command, args []string) error {
+	query := db.GetDB().Order("name ASC")
+	if len(args) > 0 {
+		query = query.Where("name IN ?", args)
+	}
+	var templates []models.Template
+	if err := query.Find(&templates).Error; err != nil {
+		return err
+	}
+	if len(args) > 0 && len(templates) != len(args) {
+		found := make(map[string]bool, len(templates))
+		for _, t := range templates {
+			found[t.Name] = true
+		}
+		for _, name := range args {
+			if !found[name] {
+				return codedErrorf(ErrCodeNotFound, "template '%s' not found (use 'gur template list' to see available templates)", name)
+			}
+		}
+	}
+	if len(templates) == 0 {
+		return fmt.Errorf("no templates to export (use 'gur template create' first)")
+	}
+
+	labelMap, err := loadLabelMap()
+	if err != nil {
+		return err
+	}
+
+	if !tmplExportDryRun {
+		if err := os.MkdirAll(tmplExportDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", tmplExportDir, err)
+		}
+	}
+
+	var files []string
+	for _, t := range templates {
+		form := renderIssueForm(t, labelMap)
+		path := filepath.Join(tmplExportDir, t.Name+".yml")
+
+		if tmplExportDryRun {
+			if !IsJSONOutput() {
+				fmt.Printf("--- %s\n%s", path, form)
+			}
+			files = append(files, path)
+			continue
+		}
+
+		if existing, err := os.ReadFile(path); err == nil && !tmplExportForce && !strings.HasPrefix(string(existing), issueFormHeader) {
+			return fmt.Errorf("%s exists and was not generated by gur (use --force to overwrite)", path)
+		}
+		if err := os.WriteFile(path, []byte(form), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+		if !IsJSONOutput() {
+			fmt.Printf("Wrote %s\n", path)
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"dry_run": tmplExportDryRun, "count": len(files), "files": files})
+	}
+	return nil
+}
+
+// issueFormSection matches the "### Heading" sections GitHub renders for
+// issue form fields
+var issueFormSection = regexp.MustCompile(`(?m)^### (.+)$`)
+
+// parseIssueFormBody splits an issue body rendered from a form into its
+// field values, keyed by label. Fields left empty are omitted.
+func parseIssueFormBody(body string) map[string]string {
+	fields := make(map[string]string)
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	matches := issueFormSection.FindAllStringSubmatchIndex(body, -1)
+	for i, m := range matches {
+		end := len(body)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		value := strings.TrimSpace(body[m[1]:end])
+		if value == "" || value == issueFormNoResponse {
+			continue
+		}
+		fields[strings.TrimSpace(body[m[2]:m[3]])] = value
+	}
+	return fields
+}
+
+// loadIssueTemplates returns templates keyed by name for mapping pulled issues
+func loadIssueTemplates(database *gorm.DB) (map[string]models.Template, error) {
+	var templates []models.Template
+	if err := database.Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.Template, len(templates))
+	for _, t := range templates {
+		byName[t.Name] = t
+	}
+	return byName, nil
+}
+
+// applyIssueTemplate maps an issue opened from an exported form back to its
+// template: the template's type and labels, plus the form's priority and
+// description. It returns the template name, or "" if the issue has no
+// known template label.
+func applyIssueTemplate(task *models.Task, issue *github.Issue, templates map[string]models.Template) string {
+	for _, label := range issue.Labels {
+		name, ok := strings.CutPrefix(label.GetName(), templateIssueLabelPrefix)
+		if !ok {
+			continue
+		}
+		task.RemoveLabel(label.GetName())
+		tmpl, ok := templates[strings.TrimSpace(name)]
+		if !ok {
+			continue
+		}
+
+		task.Type = tmpl.Type
+		task.Priority = tmpl.Priority
+		for _, l := range tmpl.Labels {
+			task.AddLabel(l)
+		}
+
+		fields := parseIssueFormBody(issue.GetBody())
+		if desc, ok := fields[issueFormDescription]; ok {
+			task.Description = desc
+		}
+		if p, ok := fields[issueFormPriority]; ok && len(p) >= 2 && (p[0] == 'P' || p[0] == 'p') {
+			if n, err := strconv.Atoi(p[1:2]); err == nil && n >= models.PriorityCritical && n <= models.PriorityLowest {
+				task.Priority = n
+			}
+		}
+		return tmpl.Name
+	}
+	return ""
+}
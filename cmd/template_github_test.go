@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+
+	"guardrails/internal/models"
+)
+
+func TestRenderIssueForm(t *testing.T) {
+	tmpl := models.Template{
+		Name:        "bug-report",
+		Title:       "Bug: ",
+		Description: "Steps to reproduce:\n1.",
+		Priority:    models.PriorityHigh,
+		Type:        models.TypeBug,
+		Labels:      models.StringSlice{"needs-triage"},
+	}
+	form := renderIssueForm(tmpl, models.DefaultLabelMap())
+
+	for _, want := range []string{
+		issueFormHeader,
+		`name: "bug-report"`,
+		`description: "Steps to reproduce:"`,
+		`title: "Bug: "`,
+		`labels: ["bug", "priority: high", "needs-triage", "gur-template: bug-report"]`,
+		`value: "Steps to reproduce:\n1."`,
+		`- "P1 (High)"`,
+		"default: 1",
+	} {
+		if !strings.Contains(form, want) {
+			t.Errorf("form missing %q:\n%s", want, form)
+		}
+	}
+}
+
+func TestParseIssueFormBody(t *testing.T) {
+	body := "### Description\r\n\r\nIt crashes\r\non start\r\n\r\n### Priority\r\n\r\nP0 (Critical)\r\n\r\n### Extra\r\n\r\n_No response_"
+	fields := parseIssueFormBody(body)
+	if fields["Description"] != "It crashes\non start" {
+		t.Errorf("Description = %q", fields["Description"])
+	}
+	if fields["Priority"] != "P0 (Critical)" {
+		t.Errorf("Priority = %q", fields["Priority"])
+	}
+	if _, ok := fields["Extra"]; ok {
+		t.Error("empty field should be omitted")
+	}
+}
+
+func TestApplyIssueTemplate(t *testing.T) {
+	templates := map[string]models.Template{
+		"bug-report": {Name: "bug-report", Type: models.TypeBug, Priority: models.PriorityMedium, Labels: models.StringSlice{"needs-triage"}},
+	}
+	issue := &github.Issue{
+		Title:  github.String("Bug: it crashes"),
+		Body:   github.String("### Description\n\nIt crashes\n\n### Priority\n\nP1 (High)"),
+		Labels: []*github.Label{{Name: github.String("bug")}, {Name: github.String("gur-template: bug-report")}},
+	}
+	task, err := createTaskFromIssue(issue, models.DefaultLabelMap())
+	if err != nil {
+		t.Fatalf("createTaskFromIssue: %v", err)
+	}
+
+	if name := applyIssueTemplate(task, issue, templates); name != "bug-report" {
+		t.Fatalf("applyIssueTemplate = %q, want bug-report", name)
+	}
+	if task.Type != models.TypeBug || task.Priority != models.PriorityHigh {
+		t.Errorf("type/priority = %s/P%d, want bug/P1", task.Type, task.Priority)
+	}
+	if task.Description != "It crashes" {
+		t.Errorf("description = %q", task.Description)
+	}
+	if strings.Join(task.Labels, ",") != "needs-triage" {
+		t.Errorf("labels = %v, want [needs-triage]", task.Labels)
+	}
+
+	plain := &github.Issue{Title: github.String("Plain"), Labels: []*github.Label{{Name: github.String("docs")}}}
+	task, _ = createTaskFromIssue(plain, models.DefaultLabelMap())
+	if name := applyIssueTemplate(task, plain, templates); name != "" {
+		t.Errorf("issue without template label mapped to %q", name)
+	}
+}
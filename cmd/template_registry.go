@@ -0,0 +1,586 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// templateManifestFile is the manifest name looked up in repositories and
+// directories, and written by publish
+const templateManifestFile = "guardrails-templates.yml"
+
+// templateManifestVersion is the manifest format this build reads and writes
+const templateManifestVersion = 1
+
+// templateFetchTimeout bounds downloading a manifest or cloning its repo
+const templateFetchTimeout = 2 * time.Minute
+
+var templateInstallCmd = &cobra.Command{
+	Use:   "install <url-or-org/repo>",
+	Short: "Install templates and gate packs from a shared manifest",
+	Long: `Install task templates and gate packs from a manifest written by
+'gur template publish'.
+
+The source can be:
+  org/repo              a GitHub repository with guardrails-templates.yml at its root
+  a git URL             cloned with git (--ref picks a branch or tag)
+  an https:// URL       to the manifest file itself
+  a local path          to a manifest or a directory containing one
+
+The manifest is verified against a SHA-256 checksum before anything is
+installed: the one given with --sha256, else the <manifest>.sha256 file
+published next to it. Pin --sha256 to guard against a changed upstream.
+
+Gate packs install as gates in the pack's category. Templates and gates
+that already exist (same name, or same title in the category) are skipped
+unless --force is given. Review the commands of installed automated gates
+before running them.
+
+Manifests are YAML (JSON, being YAML too, is also read):
+
+  version: 1
+  templates:
+    - name: bug
+      type: bug
+      priority: 1
+  gate_packs:
+    - name: security
+      gates:
+        - title: Secret scan
+          type: security
+          command: gitleaks detect
+
+Examples:
+  gur template install acme/guardrails-templates
+  gur template install acme/guardrails-templates --ref v1.2.0
+  gur template install https://example.com/guardrails-templates.yml --sha256 3f2a...
+  gur template install ../shared-templates --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateInstall,
+}
+
+var templatePublishCmd = &cobra.Command{
+	Use:   "publish [name...]",
+	Short: "Write a manifest of local templates and gate packs",
+	Long: `Write local templates, and the gates of each --gate-pack category, to a
+manifest that 'gur template install' can read, plus a .sha256 checksum file
+next to it. Commit both to a repository (or host them) to share them.
+
+With no names, all templates are published.
+
+Examples:
+  gur template publish
+  gur template publish bug incident --gate-pack security --gate-pack api
+  gur template publish -o shared/guardrails-templates.yml`,
+	RunE: runTemplatePublish,
+}
+
+var (
+	tmplInstallSHA256   string
+	tmplInstallRef      string
+	tmplInstallForce    bool
+	tmplInstallNoVerify bool
+	tmplPublishOutput   string
+	tmplPublishPacks    []string
+)
+
+func init() {
+	templateCmd.AddCommand(templateInstallCmd)
+	templateCmd.AddCommand(templatePublishCmd)
+	templateInstallCmd.Flags().StringVar(&tmplInstallSHA256, "sha256", "", "Expected SHA-256 of the manifest (default: its published .sha256 file)")
+	templateInstallCmd.Flags().StringVar(&tmplInstallRef, "ref", "", "Branch or tag to install from (git sources)")
+	templateInstallCmd.Flags().BoolVar(&tmplInstallForce, "force", false, "Overwrite existing templates and gates")
+	templateInstallCmd.Flags().BoolVar(&tmplInstallNoVerify, "no-verify", false, "Install without a checksum (not recommended)")
+	templatePublishCmd.Flags().StringVarP(&tmplPublishOutput, "output", "o", templateManifestFile, "Manifest file to write")
+	templatePublishCmd.Flags().StringSliceVar(&tmplPublishPacks, "gate-pack", nil, "Publish the gates in this category as a gate pack (repeatable)")
+}
+
+// templateManifest is the shared format for templates and gate packs
+type templateManifest struct {
+	Version   int                `json:"version" yaml:"version"`
+	Templates []manifestTemplate `json:"templates,omitempty" yaml:"templates,omitempty"`
+	GatePacks []manifestGatePack `json:"gate_packs,omitempty" yaml:"gate_packs,omitempty"`
+}
+
+type manifestTemplate struct {
+	Name        string   `json:"name" yaml:"name"`
+	Title       string   `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Priority    int      `json:"priority" yaml:"priority"`
+	Type        string   `json:"type" yaml:"type"`
+	Labels      []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// manifestGatePack is a set of gates installed into one category
+type manifestGatePack struct {
+	Name  string         `json:"name" yaml:"name"`
+	Gates []manifestGate `json:"gates" yaml:"gates"`
+}
+
+type manifestGate struct {
+	Title          string   `json:"title" yaml:"title"`
+	Description    string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Type           string   `json:"type" yaml:"type"`
+	Priority       int      `json:"priority" yaml:"priority"`
+	Preconditions  string   `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
+	Steps          string   `json:"steps,omitempty" yaml:"steps,omitempty"`
+	ExpectedResult string   `json:"expected_result,omitempty" yaml:"expected_result,omitempty"`
+	Command        string   `json:"command,omitempty" yaml:"command,omitempty"`
+	WorkDir        string   `json:"workdir,omitempty" yaml:"workdir,omitempty"`
+	EnvAllow       []string `json:"env_allow,omitempty" yaml:"env_allow,omitempty"`
+	Timeout        int      `json:"timeout_sec,omitempty" yaml:"timeout_sec,omitempty"`
+	Runner         string   `json:"runner,omitempty" yaml:"runner,omitempty"`
+	ExpectStatus   int      `json:"expect_status,omitempty" yaml:"expect_status,omitempty"`
+	ExpectJSON     string   `json:"expect_json,omitempty" yaml:"expect_json,omitempty"`
+	Labels         []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Approvers      []string `json:"approvers,omitempty" yaml:"approvers,omitempty"`
+}
+
+// manifestGateFrom returns the shareable definition of a gate
+func manifestGateFrom(g models.Gate) manifestGate {
+	return manifestGate{
+		Title: g.Title, Description: g.Description, Type: g.Type, Priority: g.Priority,
+		Preconditions: g.Preconditions, Steps: g.Steps, ExpectedResult: g.ExpectedResult,
+		Command: g.Command, WorkDir: g.WorkDir, EnvAllow: g.EnvAllow, Timeout: g.Timeout,
+		Runner: g.Runner, ExpectStatus: g.ExpectStatus, ExpectJSON: g.ExpectJSON, Labels: g.Labels, Approvers: g.Approvers,
+	}
+}
+
+// applyTo copies the definition onto gate, in the given category
+func (mg manifestGate) applyTo(gate *models.Gate, category string) {
+	gate.Title = mg.Title
+	gate.Category = category
+	gate.Description = mg.Description
+	gate.Type = mg.Type
+	gate.Priority = mg.Priority
+	gate.Preconditions = mg.Preconditions
+	gate.Steps = mg.Steps
+	gate.ExpectedResult = mg.ExpectedResult
+	gate.Command = mg.Command
+	gate.WorkDir = mg.WorkDir
+	gate.EnvAllow = mg.EnvAllow
+	gate.Timeout = mg.Timeout
+	gate.Runner = mg.Runner
+	gate.ExpectStatus = mg.ExpectStatus
+	gate.ExpectJSON = mg.ExpectJSON
+	gate.Labels = mg.Labels
+	gate.Approvers = mg.Approvers
+}
+
+// githubRepoSource matches the org/repo shorthand for install sources
+var githubRepoSource = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// fetchTemplateManifest reads the manifest from source, along with the
+// checksum published next to it ("" if there is none)
+func fetchTemplateManifest(source, ref string) (data []byte, published string, err error) {
+	if info, statErr := os.Stat(source); statErr == nil {
+		path := source
+		if info.IsDir() {
+			path = filepath.Join(source, templateManifestFile)
+		}
+		return readManifestFile(path)
+	}
+
+	isURL := strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+	ext := strings.ToLower(filepath.Ext(source))
+	if isURL && (ext == ".yml" || ext == ".yaml" || ext == ".json") {
+		return downloadManifest(source)
+	}
+
+	repoURL, ok := gitRepoURL(source)
+	if !ok {
+		return nil, "", fmt.Errorf("unknown template source '%s': use org/repo, a git or https:// URL, or a local path", source)
+	}
+	dir, err := os.MkdirTemp("", "gur-templates-*")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(dir)
+	if err := cloneTemplateRepo(repoURL, ref, dir); err != nil {
+		return nil, "", err
+	}
+	return readManifestFile(filepath.Join(dir, templateManifestFile))
+}
+
+// gitRepoURL returns the URL to clone for a source given as org/repo or a
+// git URL, or false if it is neither
+func gitRepoURL(source string) (string, bool) {
+	if githubRepoSource.MatchString(source) {
+		return "https://github.com/" + strings.TrimSuffix(source, ".git") + ".git", true
+	}
+	for _, prefix := range []string{"https://", "http://", "git@", "ssh://"} {
+		if strings.HasPrefix(source, prefix) {
+			return source, true
+		}
+	}
+	return "", false
+}
+
+func readManifestFile(path string) ([]byte, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+	sum, err := os.ReadFile(path + ".sha256")
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, "", fmt.Errorf("failed to read manifest checksum: %w", err)
+	}
+	return data, parseChecksumFile(string(sum)), nil
+}
+
+func downloadManifest(url string) ([]byte, string, error) {
+	client := &http.Client{Timeout: templateFetchTimeout}
+	get := func(url string) ([]byte, int, error) {
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+		return body, resp.StatusCode, err
+	}
+
+	data, status, err := get(url)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download manifest: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to download manifest: %s returned HTTP %d", url, status)
+	}
+	sum, status, err := get(url + ".sha256")
+	if err != nil || status != http.StatusOK {
+		return data, "", nil
+	}
+	return data, parseChecksumFile(string(sum)), nil
+}
+
+func cloneTemplateRepo(repoURL, ref, dir string) error {
+	if strings.HasPrefix(repoURL, "-") || strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid template source '%s'", repoURL)
+	}
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, "--", repoURL, dir)
+	ctx, cancel := context.WithTimeout(context.Background(), templateFetchTimeout)
+	defer cancel()
+	c := exec.CommandContext(ctx, "git", args...)
+	c.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if out, err := c.CombinedOutput(); err != nil {
+		return fmt.Errorf("git clone %s failed: %s", repoURL, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// parseChecksumFile returns the hash from sha256sum-style content
+// ("<hex>  <file>"), or "" if there is none
+func parseChecksumFile(content string) string {
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(fields[0], "sha256:"))
+}
+
+// verifyManifestChecksum checks data against the expected SHA-256
+func verifyManifestChecksum(data []byte, expected string) error {
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+	if strings.ToLower(strings.TrimPrefix(expected, "sha256:")) != actual {
+		return fmt.Errorf("manifest checksum mismatch: expected %s, got %s (the manifest changed or was tampered with)", expected, actual)
+	}
+	return nil
+}
+
+// decodeYAML decodes a YAML document, or JSON, into v by its yaml tags.
+// Manifests and shared gate packs share it.
+func decodeYAML(data []byte, v interface{}) error {
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return errors.New(strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+	return nil
+}
+
+// encodeYAML writes v as block-style YAML indented by two spaces
+func encodeYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseTemplateManifest decodes and validates a manifest
+func parseTemplateManifest(data []byte) (*templateManifest, error) {
+	var m templateManifest
+	if err := decodeYAML(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if m.Version < 1 || m.Version > templateManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d (this gur reads version %d)", m.Version, templateManifestVersion)
+	}
+	for _, t := range m.Templates {
+		if strings.TrimSpace(t.Name) == "" {
+			return nil, fmt.Errorf("invalid manifest: template without a name")
+		}
+	}
+	for _, p := range m.GatePacks {
+		if strings.TrimSpace(p.Name) == "" {
+			return nil, fmt.Errorf("invalid manifest: gate pack without a name")
+		}
+		for _, g := range p.Gates {
+			if strings.TrimSpace(g.Title) == "" {
+				return nil, fmt.Errorf("invalid manifest: gate without a title in pack '%s'", p.Name)
+			}
+			if err := models.ValidateRunner(g.Runner); err != nil {
+				return nil, fmt.Errorf("invalid manifest: gate '%s': %w", g.Title, err)
+			}
+		}
+	}
+	return &m, nil
+}
+
+// manifestInstallResult counts what an install did
+type manifestInstallResult struct {
+	Installed []string `json:"installed"`
+	Updated   []string `json:"updated"`
+	Skipped   []string `json:"skipped"`
+	Commands  []string `json:"commands,omitempty"` // Commands of installed automated gates
+}
+
+// installTemplateManifest creates (or with force, updates) the manifest's
+// templates and gates
+func installTemplateManifest(database *gorm.DB, m *templateManifest, force bool) (*manifestInstallResult, error) {
+	result := &manifestInstallResult{}
+	err := database.Transaction(func(tx *gorm.DB) error {
+		for _, mt := range m.Templates {
+			label := "template " + mt.Name
+			var tmpl models.Template
+			exists := tx.Where("name = ?", mt.Name).First(&tmpl).Error == nil
+			if exists && !force {
+				result.Skipped = append(result.Skipped, label)
+				continue
+			}
+			tmpl.Name = mt.Name
+			tmpl.Title = mt.Title
+			tmpl.Description = mt.Description
+			tmpl.Priority = mt.Priority
+			tmpl.Type = mt.Type
+			tmpl.Labels = mt.Labels
+			if err := saveOrCreate(tx, &tmpl, exists); err != nil {
+				return fmt.Errorf("failed to save template '%s': %w", mt.Name, err)
+			}
+			noteAffected(tmpl.ID)
+			if exists {
+				result.Updated = append(result.Updated, label)
+			} else {
+				result.Installed = append(result.Installed, label)
+			}
+		}
+
+		for _, pack := range m.GatePacks {
+			for _, mg := range pack.Gates {
+				label := fmt.Sprintf("gate %s/%s", pack.Name, mg.Title)
+				var gate models.Gate
+				exists := tx.Where("category = ? AND title = ?", pack.Name, mg.Title).First(&gate).Error == nil
+				if exists && !force {
+					result.Skipped = append(result.Skipped, label)
+					continue
+				}
+				mg.applyTo(&gate, pack.Name)
+				if err := saveOrCreate(tx, &gate, exists); err != nil {
+					return fmt.Errorf("failed to save gate '%s': %w", mg.Title, err)
+				}
+				noteAffected(gate.ID)
+				if exists {
+					result.Updated = append(result.Updated, label)
+				} else {
+					result.Installed = append(result.Installed, label)
+				}
+				if gate.Command != "" {
+					result.Commands = append(result.Commands, fmt.Sprintf("%s: %s", gate.ID, gate.Command))
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func saveOrCreate(tx *gorm.DB, value interface{}, exists bool) error {
+	if exists {
+		return tx.Save(value).Error
+	}
+	return tx.Create(value).Error
+}
+
+// buildTemplateManifest collects the named templates (all if none) and the
+// gates of each pack category
+func buildTemplateManifest(database *gorm.DB, names, packs []string) (*templateManifest, error) {
+	m := &templateManifest{Version: templateManifestVersion}
+
+	var templates []models.Template
+	query := database.Order("name ASC")
+	if len(names) > 0 {
+		query = query.Where("name IN ?", names)
+	}
+	if err := query.Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	if len(names) > 0 && len(templates) != len(names) {
+		found := make(map[string]bool, len(templates))
+		for _, t := range templates {
+			found[t.Name] = true
+		}
+		for _, n := range names {
+			if !found[n] {
+				return nil, codedErrorf(ErrCodeNotFound, "template '%s' not found (use 'gur template list' to see available templates)", n)
+			}
+		}
+	}
+	for _, t := range templates {
+		m.Templates = append(m.Templates, manifestTemplate{
+			Name: t.Name, Title: t.Title, Description: t.Description,
+			Priority: t.Priority, Type: t.Type, Labels: t.Labels,
+		})
+	}
+
+	for _, category := range packs {
+		var gates []models.Gate
+		if err := database.Where("category = ?", category).Order("priority ASC, title ASC").Find(&gates).Error; err != nil {
+			return nil, err
+		}
+		if len(gates) == 0 {
+			return nil, fmt.Errorf("no gates in category '%s' (use 'gur gate list' to see gate categories)", category)
+		}
+		pack := manifestGatePack{Name: category}
+		for _, g := range gates {
+			pack.Gates = append(pack.Gates, manifestGateFrom(g))
+		}
+		m.GatePacks = append(m.GatePacks, pack)
+	}
+	return m, nil
+}
+
+func runTemplateInstall(cmd *cobra.Command, args []string) error {
+	data, published, err := fetchTemplateManifest(args[0], tmplInstallRef)
+	if err != nil {
+		return fmt.Errorf("cannot install templates: %w", err)
+	}
+
+	expected := tmplInstallSHA256
+	if expected == "" {
+		expected = published
+	}
+	switch {
+	case expected != "":
+		if err := verifyManifestChecksum(data, expected); err != nil {
+			return fmt.Errorf("cannot install templates: %w", err)
+		}
+	case !tmplInstallNoVerify:
+		return fmt.Errorf("cannot install templates: no checksum for the manifest (pass --sha256, publish a %s.sha256 file, or use --no-verify)", templateManifestFile)
+	}
+
+	m, err := parseTemplateManifest(data)
+	if err != nil {
+		return fmt.Errorf("cannot install templates: %w", err)
+	}
+	result, err := installTemplateManifest(db.GetDB(), m, tmplInstallForce)
+	if err != nil {
+		return fmt.Errorf("failed to install templates: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "source": args[0], "verified": expected != "", "result": result})
+		return nil
+	}
+	for _, s := range result.Installed {
+		fmt.Printf("Installed: %s\n", s)
+	}
+	for _, s := range result.Updated {
+		fmt.Printf("Updated:   %s\n", s)
+	}
+	for _, s := range result.Skipped {
+		fmt.Printf("Skipped:   %s (exists; use --force to overwrite)\n", s)
+	}
+	if len(result.Commands) > 0 {
+		fmt.Println("\nReview the commands of installed automated gates before running them:")
+		for _, c := range result.Commands {
+			fmt.Printf("  %s\n", c)
+		}
+	}
+	if expected == "" {
+		fmt.Fprintf(os.Stderr, "Warning: installed without verifying a checksum\n")
+	}
+	return nil
+}
+
+func runTemplatePublish(cmd *cobra.Command, args []string) error {
+	m, err := buildTemplateManifest(db.GetDB(), args, tmplPublishPacks)
+	if err != nil {
+		return fmt.Errorf("cannot publish templates: %w", err)
+	}
+	if len(m.Templates) == 0 && len(m.GatePacks) == 0 {
+		return fmt.Errorf("cannot publish templates: nothing to publish (create one with 'gur template create')")
+	}
+
+	data, err := encodeYAML(m)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	if dir := filepath.Dir(tmplPublishOutput); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(tmplPublishOutput, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	sumLine := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(tmplPublishOutput))
+	if err := os.WriteFile(tmplPublishOutput+".sha256", []byte(sumLine), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest checksum: %w", err)
+	}
+
+	gates := 0
+	for _, p := range m.GatePacks {
+		gates += len(p.Gates)
+	}
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "path": tmplPublishOutput, "sha256": checksum, "templates": len(m.Templates), "gate_packs": len(m.GatePacks), "gates": gates})
+		return nil
+	}
+	fmt.Printf("Wrote %s: %d template(s), %d gate pack(s) with %d gate(s)\n", tmplPublishOutput, len(m.Templates), len(m.GatePacks), gates)
+	fmt.Printf("SHA-256: %s\n", checksum)
+	return nil
+}
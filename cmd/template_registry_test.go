@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestTemplatePublishInstallRoundTrip(t *testing.T) {
+	cleanup := setupTestDB(t)
+	database := db.GetDB()
+	database.Create(&models.Template{Name: "incident", Title: "[{{service}}] incident", Priority: 1, Type: models.TypeBug, Labels: []string{"oncall"}})
+	database.Create(&models.Template{Name: "spike", Type: models.TypeTask, Priority: 3})
+	database.Create(&models.Gate{ID: "gate-pub00001", Title: "Threat model", Category: "security", Type: "review"})
+	database.Create(&models.Gate{ID: "gate-pub00002", Title: "Dependency scan", Category: "security", Type: "test", Command: "make audit"})
+
+	dir := t.TempDir()
+	tmplPublishOutput = filepath.Join(dir, templateManifestFile)
+	tmplPublishPacks = []string{"security"}
+	defer func() { tmplPublishOutput, tmplPublishPacks = templateManifestFile, nil }()
+	if err := runTemplatePublish(templatePublishCmd, []string{"incident"}); err != nil {
+		t.Fatalf("publish error: %v", err)
+	}
+	cleanup()
+
+	// Install into a fresh project
+	cleanup = setupTestDB(t)
+	defer cleanup()
+	database = db.GetDB()
+
+	data, published, err := fetchTemplateManifest(dir, "")
+	if err != nil {
+		t.Fatalf("fetchTemplateManifest() error: %v", err)
+	}
+	if published == "" {
+		t.Fatal("published checksum file was not found next to the manifest")
+	}
+	if !strings.HasPrefix(string(data), "version: 1\n") {
+		t.Errorf("published manifest is not block-style YAML:\n%s", data)
+	}
+	if err := verifyManifestChecksum(data, published); err != nil {
+		t.Fatalf("published checksum does not match: %v", err)
+	}
+	m, err := parseTemplateManifest(data)
+	if err != nil {
+		t.Fatalf("parseTemplateManifest() error: %v", err)
+	}
+	if len(m.Templates) != 1 || len(m.GatePacks) != 1 || len(m.GatePacks[0].Gates) != 2 {
+		t.Fatalf("manifest = %+v, want 1 template and a 2-gate pack", m)
+	}
+
+	result, err := installTemplateManifest(database, m, false)
+	if err != nil {
+		t.Fatalf("install error: %v", err)
+	}
+	if len(result.Installed) != 3 || len(result.Commands) != 1 || !strings.HasSuffix(result.Commands[0], "make audit") {
+		t.Errorf("install result = %+v, want 3 installed and the scan command listed", result)
+	}
+	var tmpl models.Template
+	if err := database.Where("name = ?", "incident").First(&tmpl).Error; err != nil || tmpl.Type != models.TypeBug || len(tmpl.Labels) != 1 {
+		t.Errorf("installed template = %+v, %v", tmpl, err)
+	}
+	var gate models.Gate
+	if err := database.Where("category = ? AND title = ?", "security", "Dependency scan").First(&gate).Error; err != nil || gate.Command != "make audit" || gate.LastResult != models.GateLinkPending {
+		t.Errorf("installed gate = %+v, %v", gate, err)
+	}
+
+	// Re-installing skips what exists unless forced
+	database.Model(&tmpl).Update("title", "edited locally")
+	if result, _ := installTemplateManifest(database, m, false); len(result.Skipped) != 3 || len(result.Installed) != 0 {
+		t.Errorf("reinstall result = %+v, want all skipped", result)
+	}
+	if result, _ := installTemplateManifest(database, m, true); len(result.Updated) != 3 {
+		t.Errorf("forced reinstall result = %+v, want all updated", result)
+	}
+	database.First(&tmpl, "name = ?", "incident")
+	var gates int64
+	database.Model(&models.Gate{}).Where("category = ?", "security").Count(&gates)
+	if tmpl.Title != "[{{service}}] incident" || gates != 2 {
+		t.Errorf("after forced reinstall title = %q with %d gates, want manifest title and no duplicates", tmpl.Title, gates)
+	}
+}
+
+func TestTemplateManifestChecksumAndValidation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "custom.yml")
+	manifest := `{"version": 1, "templates": [{"name": "bug", "type": "bug", "priority": 2}]}`
+	os.WriteFile(path, []byte(manifest), 0644)
+	os.WriteFile(path+".sha256", []byte("0000  custom.yml\n"), 0644)
+
+	data, published, err := fetchTemplateManifest(path, "")
+	if err != nil || published != "0000" {
+		t.Fatalf("fetchTemplateManifest() = %q, %v", published, err)
+	}
+	if err := verifyManifestChecksum(data, published); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Errorf("tampered manifest error = %v, want checksum mismatch", err)
+	}
+
+	for name, bad := range map[string]string{
+		"future version": `{"version": 2}`,
+		"no version":     "templates:\n  - name: bug\n",
+		"not yaml":       "version: 1\ntemplates: [name: bug\n",
+		"unnamed":        `{"version": 1, "templates": [{"type": "bug"}]}`,
+		"bad runner":     `{"version": 1, "gate_packs": [{"name": "ci", "gates": [{"title": "x", "runner": "ssh:host"}]}]}`,
+	} {
+		if _, err := parseTemplateManifest([]byte(bad)); err == nil {
+			t.Errorf("%s: parseTemplateManifest() accepted %s", name, bad)
+		}
+	}
+
+	// Hand-written block YAML and JSON both parse
+	block := `version: 1
+templates:
+  - name: bug
+    type: bug
+    priority: 1
+gate_packs:
+  - name: security
+    gates:
+      - title: Secret scan
+        type: security
+        command: gitleaks detect
+        timeout_sec: 120
+`
+	for name, src := range map[string]string{"yaml": block, "json": `{"version": 1, "gate_packs": [{"name": "security", "gates": [{"title": "Secret scan", "command": "gitleaks detect", "timeout_sec": 120}]}]}`} {
+		m, err := parseTemplateManifest([]byte(src))
+		if err != nil {
+			t.Fatalf("%s manifest: %v", name, err)
+		}
+		if g := m.GatePacks[0].Gates[0]; g.Command != "gitleaks detect" || g.Timeout != 120 {
+			t.Errorf("%s manifest gate = %+v", name, g)
+		}
+	}
+
+	if _, _, err := fetchTemplateManifest("not a source", ""); err == nil || !strings.Contains(err.Error(), "unknown template source") {
+		t.Errorf("fetchTemplateManifest(invalid) error = %v", err)
+	}
+}
@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var (
+	testCategory    string
+	testType        string
+	testPriority    int
+	testDescription string
+	testSteps       string
+	testExpected    string
+	testCommand     string
+	testResult      string
+	testDuration    time.Duration
+	testHistoryMax  int
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Test case management",
+	Long: `Manage test cases: gates of type test with a test type.
+
+A test linked to a task blocks closing it until a run passes for that task,
+like any gate. 'gur gate' commands (show, waive, unlink, delete...) work on
+tests too.
+
+TEST TYPES: ` + strings.Join(models.TestTypes, ", ") + `
+RESULTS: passed, failed, skipped`,
+}
+
+var testCreateCmd = &cobra.Command{
+	Use:   "create \"title\"",
+	Short: "Create a test case",
+	Long: `Create a test case.
+
+Examples:
+  gur test create "Login works" -c auth -t e2e
+  gur test create "Parser handles empty input" -t unit --cmd "go test ./internal/parser"
+  gur test create "Checkout on mobile" -t manual --steps "1. Add an item  2. Pay" --expected "Order confirmed"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTestCreate,
+}
+
+var testListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List test cases",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Aliases:     []string{"ls"},
+	Args:        cobra.NoArgs,
+	RunE:        runTestList,
+}
+
+var testRunCmd = &cobra.Command{
+	Use:   "run <test-id> <passed|failed|skipped> [task-id...]",
+	Short: "Record the result of a test run",
+	Long: `Record the result of running a test, with how long it took.
+
+The result is recorded for the given tasks, or for every open task the test
+is linked to, so a passing run lets them close. A run with no linked tasks
+is kept in the test's history only.
+
+Examples:
+  gur test run gate-abc123 passed
+  gur test run gate-abc123 failed --notes "Times out on CI" --duration 42s
+  gur test run gate-abc123 passed gur-def456 --by agent`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runTestRun,
+}
+
+var testLinkCmd = &cobra.Command{
+	Use:   "link <test-id> <task-id>",
+	Short: "Link a test to a task (required to close)",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runTestLink,
+}
+
+var testHistoryCmd = &cobra.Command{
+	Use:         "history <test-id>",
+	Short:       "Show the runs of a test",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Show the runs of a test, newest first, with result, duration and who ran it.
+
+Examples:
+  gur test history gate-abc123
+  gur test history gate-abc123 -n 50 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTestHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+	testCmd.AddCommand(testCreateCmd)
+	testCmd.AddCommand(testListCmd)
+	testCmd.AddCommand(testRunCmd)
+	testCmd.AddCommand(testLinkCmd)
+	testCmd.AddCommand(testHistoryCmd)
+
+	testCreateCmd.Flags().StringVarP(&testCategory, "category", "c", "", "Category (e.g., auth, api, ui)")
+	testCreateCmd.Flags().StringVarP(&testType, "type", "t", "manual", "Test type: "+strings.Join(models.TestTypes, ", "))
+	testCreateCmd.Flags().IntVarP(&testPriority, "priority", "p", 2, "Priority (0-4)")
+	testCreateCmd.Flags().StringVarP(&testDescription, "description", "d", "", "Description")
+	testCreateCmd.Flags().StringVar(&testSteps, "steps", "", "Steps to run the test")
+	testCreateCmd.Flags().StringVar(&testExpected, "expected", "", "Expected result")
+	testCreateCmd.Flags().StringVar(&testCommand, "cmd", "", "Command that runs the test (see 'gur gate run')")
+
+	testListCmd.Flags().StringVarP(&testCategory, "category", "c", "", "Filter by category")
+	testListCmd.Flags().StringVarP(&testType, "type", "t", "", "Filter by test type")
+	testListCmd.Flags().StringVar(&testResult, "result", "", "Filter by last result")
+
+	testRunCmd.Flags().DurationVar(&testDuration, "duration", 0, "How long the run took (e.g., 850ms, 42s)")
+	testRunCmd.Flags().StringVar(&gateNotes, "notes", "", "Notes about the run")
+	testRunCmd.Flags().StringVar(&gateRunBy, "by", "human", "Who ran the test (human/agent/ci/name)")
+	testRunCmd.Flags().StringVar(&gateTrustOverride, "override-trust", "", "Record a pass even if you aren't trusted for tests, with this reason (needs a terminal)")
+
+	testHistoryCmd.Flags().IntVarP(&testHistoryMax, "limit", "n", 20, "Maximum number of runs to show")
+}
+
+// getTestByID returns the gate with the given ID if it is a test
+func getTestByID(id string) (*models.Gate, error) {
+	gate, err := db.GetGateByID(id)
+	if err != nil {
+		return nil, codedErrorf(ErrCodeNotFound, "test '%s' not found (use 'gur test list' to see available tests)", id)
+	}
+	if gate.Type != models.GateTypeTest {
+		return nil, fmt.Errorf("gate '%s' is not a test (type %s): use 'gur gate' commands for it", gate.ID, gate.TypeString())
+	}
+	return gate, nil
+}
+
+// testRunResult is the outcome of a test run for one linked task
+type testRunResult struct {
+	TaskID string `json:"task_id"`
+	Status string `json:"status"` // The link status stored: passed, failed, skipped or requested
+}
+
+// recordTestRun records a run of a test for the given tasks, or for all
+// open tasks it is linked to when none are given. Each task's link gets the
+// result and a run in the history; with no linked tasks the run is only
+// added to the history.
+func recordTestRun(database *gorm.DB, gate *models.Gate, result string, duration time.Duration, taskIDs []string) ([]testRunResult, error) {
+	run := models.GateRun{Duration: int(duration.Milliseconds())}
+
+	var links []models.GateTaskLink
+	if len(taskIDs) > 0 {
+		for _, id := range taskIDs {
+			var link models.GateTaskLink
+			if err := database.Where("gate_id = ? AND task_id = ?", gate.ID, id).First(&link).Error; err != nil {
+				return nil, fmt.Errorf("cannot record test run: test '%s' is not linked to task '%s'\nLink it first: gur test link %s %s", gate.ID, id, gate.ID, id)
+			}
+			links = append(links, link)
+		}
+	} else if err := database.Joins("JOIN tasks ON tasks.id = gate_task_links.task_id AND tasks.deleted_at IS NULL").
+		Where("gate_task_links.gate_id = ? AND tasks.status <> ?", gate.ID, models.StatusClosed).
+		Order("gate_task_links.task_id ASC").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to load tasks linked to test '%s': database error: %w", gate.ID, err)
+	}
+
+	if len(links) == 0 {
+		gate.RecordRun(result, gateRunBy, gateNotes)
+		if err := database.Save(gate).Error; err != nil {
+			return nil, fmt.Errorf("failed to update test stats: %w", err)
+		}
+		run.GateID, run.Result, run.RunBy, run.Notes = gate.ID, result, gateRunBy, gateNotes
+		if err := database.Create(&run).Error; err != nil {
+			return nil, fmt.Errorf("failed to save test run history: %w", err)
+		}
+		return nil, nil
+	}
+
+	results := make([]testRunResult, 0, len(links))
+	for i := range links {
+		link := &links[i]
+		saved, err := saveGateResult(database, gate, link, result, gateRunBy, gateNotes, run)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, testRunResult{TaskID: link.TaskID, Status: saved.Result})
+		if saved.Result == models.GateLinkFailed {
+			task, _ := db.GetTaskByID(link.TaskID)
+			if err := fireHook(hookOnGateFail, hookPayload{Task: task, Gate: gate, Link: link, Run: saved}); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+	return results, nil
+}
+
+func runTestCreate(cmd *cobra.Command, args []string) error {
+	if err := models.ValidateTestType(testType); err != nil {
+		return err
+	}
+	gate := &models.Gate{
+		Title:          args[0],
+		Description:    testDescription,
+		Category:       testCategory,
+		Type:           models.GateTypeTest,
+		TestType:       testType,
+		Priority:       testPriority,
+		Steps:          testSteps,
+		ExpectedResult: testExpected,
+		Command:        testCommand,
+		LastResult:     models.GatePending,
+	}
+	if err := db.GetDB().Create(gate).Error; err != nil {
+		return fmt.Errorf("failed to create test: database error: %w", err)
+	}
+	noteAffected(gate.ID)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "test": gate})
+		return nil
+	}
+	fmt.Printf("Created: %s - %s (%s test)\n", gate.ID, gate.Title, gate.TestType)
+	fmt.Printf("Link it to a task with: gur test link %s <task-id>\n", gate.ID)
+	return nil
+}
+
+func runTestList(cmd *cobra.Command, args []string) error {
+	query := db.GetDB().Where("type = ?", models.GateTypeTest)
+	if testCategory != "" {
+		query = query.Where("category = ?", testCategory)
+	}
+	if testType != "" {
+		query = query.Where("test_type = ?", testType)
+	}
+	if testResult != "" {
+		query = query.Where("last_result = ?", testResult)
+	}
+	var tests []models.Gate
+	if err := query.Order("priority ASC, category ASC, created_at DESC").Find(&tests).Error; err != nil {
+		return fmt.Errorf("failed to list tests: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(tests), "tests": tests})
+		return nil
+	}
+	if IsQuietOutput() {
+		for _, g := range tests {
+			printQuietIDs(g.ID)
+		}
+		return nil
+	}
+	if len(tests) == 0 {
+		fmt.Println("No tests found")
+		return nil
+	}
+
+	c := colors()
+	for _, g := range tests {
+		cat := ""
+		if g.Category != "" {
+			cat = "[" + g.Category + "] "
+		}
+		stats := "never run"
+		if g.RunCount > 0 {
+			stats = fmt.Sprintf("%d runs, %.0f%% pass", g.RunCount, g.PassRate())
+		}
+		fmt.Printf("[%s] %s%s - %s (%s, %s)\n", g.ID, cat, c.Result(g.ResultString()), g.Title, g.TestType, stats)
+	}
+	return nil
+}
+
+func runTestRun(cmd *cobra.Command, args []string) error {
+	gate, err := getTestByID(args[0])
+	if err != nil {
+		return err
+	}
+	result := args[1]
+	switch result {
+	case models.GatePassed, models.GateFailed, models.GateSkipped:
+	default:
+		return fmt.Errorf("invalid result '%s': must be passed, failed or skipped", result)
+	}
+	if testDuration < 0 {
+		return fmt.Errorf("invalid --duration %s: must not be negative", testDuration)
+	}
+	taskIDs := args[2:]
+	for _, id := range taskIDs {
+		if _, err := db.GetTaskByID(id); err != nil {
+			return codedErrorf(ErrCodeNotFound, "cannot record test run: task '%s' not found (use 'gur list' to see available tasks)", id)
+		}
+	}
+
+	results, err := recordTestRun(db.GetDB(), gate, result, testDuration, taskIDs)
+	for _, r := range results {
+		noteAffected(r.TaskID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "test": gate, "result": result, "duration_ms": testDuration.Milliseconds(), "tasks": results})
+		return nil
+	}
+	took := ""
+	if testDuration > 0 {
+		took = " in " + testDuration.String()
+	}
+	if len(results) == 0 {
+		fmt.Printf("Recorded: %s %s%s (no linked open tasks)\n", gate.Title, result, took)
+		return nil
+	}
+	fmt.Printf("Recorded: %s %s%s\n", gate.Title, result, took)
+	for _, r := range results {
+		if r.Status == models.GateLinkRequested {
+			fmt.Printf("  %s: pass requested, awaiting an approver (gur gate approve %s %s --by <approver>)\n", r.TaskID, gate.ID, r.TaskID)
+			continue
+		}
+		fmt.Printf("  %s: %s\n", r.TaskID, r.Status)
+	}
+	return nil
+}
+
+func runTestLink(cmd *cobra.Command, args []string) error {
+	if _, err := getTestByID(args[0]); err != nil {
+		return err
+	}
+	return runGateLink(cmd, args)
+}
+
+func runTestHistory(cmd *cobra.Command, args []string) error {
+	gate, err := getTestByID(args[0])
+	if err != nil {
+		return err
+	}
+	var runs []models.GateRun
+	if err := db.GetDB().Where("gate_id = ?", gate.ID).Order("created_at DESC, id DESC").Limit(testHistoryMax).Find(&runs).Error; err != nil {
+		return fmt.Errorf("failed to load runs of test '%s': database error: %w", gate.ID, err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"test": gate, "count": len(runs), "runs": runs})
+		return nil
+	}
+	fmt.Printf("%s - %s (%s test)\n", gate.ID, gate.Title, gate.TestType)
+	fmt.Printf("%d runs, %d passed, %d failed (%.0f%% pass rate)\n", gate.RunCount, gate.PassCount, gate.FailCount, gate.PassRate())
+	if len(runs) == 0 {
+		fmt.Println("\nNo runs recorded")
+		return nil
+	}
+	fmt.Println()
+	c := colors()
+	for _, r := range runs {
+		took := "-"
+		if r.Duration > 0 {
+			took = (time.Duration(r.Duration) * time.Millisecond).String()
+		}
+		fmt.Printf("  %s  %s %8s  by %s", r.CreatedAt.Format(models.DateTimeShortFormat), c.Result(fmt.Sprintf("%-9s", r.Result)), took, r.RunBy)
+		if r.Notes != "" {
+			fmt.Printf("  %s", r.Notes)
+		}
+		fmt.Println()
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestRecordTestRun(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	test := &models.Gate{ID: "gate-7e570001", Title: "Login works", Type: models.GateTypeTest, TestType: "e2e"}
+	database.Create(test)
+	database.Create(&models.Gate{ID: "gate-7e570002", Title: "Review", Type: "review"})
+	database.Create(&models.Task{ID: "gur-7e570001", Title: "Open", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-7e570002", Title: "Done", Status: models.StatusClosed})
+	database.Create(&models.Task{ID: "gur-7e570003", Title: "Unlinked", Status: models.StatusOpen})
+	database.Create(&models.GateTaskLink{GateID: test.ID, TaskID: "gur-7e570001", Status: models.GateLinkPending})
+	database.Create(&models.GateTaskLink{GateID: test.ID, TaskID: "gur-7e570002", Status: models.GateLinkPassed})
+
+	if _, err := getTestByID("gate-7e570002"); err == nil || !strings.Contains(err.Error(), "not a test") {
+		t.Errorf("getTestByID(review gate) = %v, want it rejected", err)
+	}
+
+	gateRunBy, gateNotes, gateTrustOverride = "ci", "", ""
+	results, err := recordTestRun(database, test, models.GatePassed, 1500*time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].TaskID != "gur-7e570001" || results[0].Status != models.GateLinkPassed {
+		t.Fatalf("results = %+v, want a pass for the open linked task only", results)
+	}
+	if err := CheckGatesBeforeClose("gur-7e570001"); err != nil {
+		t.Errorf("close after a passing run = %v", err)
+	}
+
+	if _, err := recordTestRun(database, test, models.GateFailed, 0, []string{"gur-7e570003"}); err == nil || !strings.Contains(err.Error(), "not linked") {
+		t.Errorf("run for an unlinked task = %v, want it refused", err)
+	}
+
+	var runs []models.GateRun
+	database.Where("gate_id = ?", test.ID).Find(&runs)
+	if len(runs) != 1 || runs[0].Duration != 1500 || runs[0].RunBy != "ci" {
+		t.Errorf("runs = %+v, want one run of 1500ms by ci", runs)
+	}
+
+	// A test linked to no open task still keeps the run in its history
+	database.Model(&models.Task{}).Where("id = ?", "gur-7e570001").Update("status", models.StatusClosed)
+	if results, err := recordTestRun(database, test, models.GateFailed, time.Second, nil); err != nil || len(results) != 0 {
+		t.Fatalf("run with no open tasks = %+v, %v", results, err)
+	}
+	gate, _ := db.GetGateByID(test.ID)
+	if gate.RunCount != 2 || gate.LastResult != models.GateFailed {
+		t.Errorf("test stats = %d runs, last %s; want 2 runs, last failed", gate.RunCount, gate.LastResult)
+	}
+}
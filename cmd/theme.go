@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+	"guardrails/internal/output"
+)
+
+var configThemeCmd = &cobra.Command{
+	Use:   "theme [name]",
+	Short: "Show or set the output color theme",
+	Long: `Show or set the theme used to color statuses, priorities and gate results.
+
+Colors are only written to a terminal: they are off when output is piped,
+with --no-color, or when the NO_COLOR environment variable is set.
+
+Examples:
+  gur config theme
+  gur config theme bright
+  gur config theme mono`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigTheme,
+}
+
+func init() {
+	configCmd.AddCommand(configThemeCmd)
+}
+
+// painter is built on first use from the configured theme
+var painter *output.Painter
+
+// colors returns the painter for stdout
+func colors() *output.Painter {
+	if painter == nil {
+		painter = output.NewPainter(configuredTheme(), output.ColorEnabled(noColor, os.Stdout))
+	}
+	return painter
+}
+
+// configuredTheme returns the project's theme, falling back to the default
+// when none (or an unknown one) is set
+func configuredTheme() *output.Theme {
+	name, _ := db.GetConfig(models.ConfigTheme)
+	if theme, err := output.LookupTheme(name); err == nil {
+		return theme
+	}
+	return output.Themes[output.DefaultTheme]
+}
+
+// printQuietIDs prints one ID per line for --quiet
+func printQuietIDs(ids ...string) {
+	for _, id := range ids {
+		fmt.Println(id)
+	}
+}
+
+func runConfigTheme(cmd *cobra.Command, args []string) error {
+	if len(args) == 1 {
+		if _, err := output.LookupTheme(args[0]); err != nil {
+			return err
+		}
+		if err := db.SetConfig(models.ConfigTheme, args[0]); err != nil {
+			return fmt.Errorf("failed to save theme: %w", err)
+		}
+		painter = nil
+	}
+	theme := configuredTheme()
+	enabled := output.ColorEnabled(noColor, os.Stdout)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"theme": theme.Name, "themes": output.ThemeNames(), "color": enabled})
+		return nil
+	}
+	if len(args) == 1 {
+		fmt.Printf("Theme set to %s\n", theme.Name)
+	}
+	for _, name := range output.ThemeNames() {
+		marker := " "
+		if name == theme.Name {
+			marker = "*"
+		}
+		p := output.NewPainter(output.Themes[name], enabled)
+		fmt.Printf("%s %-8s %s %s %s %s  %s %s\n", marker, name,
+			p.Priority(0), p.Status(models.StatusOpen), p.Status(models.StatusInProgress), p.Status(models.StatusBlocked),
+			p.Result("PASS"), p.Result("FAIL"))
+	}
+	if !enabled {
+		fmt.Println("\nColors are off (not a terminal, --no-color, or NO_COLOR is set)")
+	}
+	return nil
+}
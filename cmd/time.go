@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// noEstimate clears an estimate with 'gur update --estimate none'
+const noEstimate = "none"
+
+var (
+	timeLogBy   string
+	timeLogNote string
+)
+
+var timeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Track time spent on tasks",
+	Long: `Log time spent on tasks and compare it to their estimates.
+
+Durations use working time: 1d = 8h and 1w = 5d. Set estimates with
+'gur create/update --estimate'; 'gur stats calibration' compares estimates
+with logged time across closed tasks.`,
+}
+
+var timeLogCmd = &cobra.Command{
+	Use:   "log <task-id> <duration>",
+	Short: "Log time spent on a task",
+	Long: `Log time spent on a task, e.g. 90m, 2h, 1h30m or 1d (8h).
+
+Examples:
+  gur time log gur-abc123 2h
+  gur time log gur-abc123 1h30m --by agent --note "Wrote migration"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTimeLog,
+}
+
+var timeListCmd = &cobra.Command{
+	Use:         "list <task-id>",
+	Short:       "List time logged on a task",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
+	RunE:        runTimeList,
+}
+
+func init() {
+	rootCmd.AddCommand(timeCmd)
+	timeCmd.AddCommand(timeLogCmd)
+	timeCmd.AddCommand(timeListCmd)
+	timeLogCmd.Flags().StringVar(&timeLogBy, "by", "human", "Who did the work (human/agent/name)")
+	timeLogCmd.Flags().StringVar(&timeLogNote, "note", "", "What the time was spent on")
+}
+
+// parseEffort parses working time into minutes: Go-style hours and minutes
+// (90m, 1h30m) plus d (8h) and w (5d) units, which may be combined (1d4h)
+func parseEffort(s string) (int, error) {
+	invalid := fmt.Errorf("invalid duration '%s': use working time like 45m, 2h, 1h30m, 1d (8h) or 1w (5d)", s)
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return 0, invalid
+	}
+	total := 0
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return 0, invalid
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return 0, invalid
+		}
+		switch rest[i] {
+		case 'w':
+			total += n * models.MinutesPerWorkWeek
+		case 'd':
+			total += n * models.MinutesPerWorkDay
+		case 'h':
+			total += n * 60
+		case 'm':
+			total += n
+		default:
+			return 0, invalid
+		}
+		rest = rest[i+1:]
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("invalid duration '%s': must be positive", s)
+	}
+	return total, nil
+}
+
+// loggedMinutes returns the total time logged per task for taskIDs
+func loggedMinutes(database *gorm.DB, taskIDs []string) (map[string]int, error) {
+	var rows []struct {
+		TaskID  string
+		Minutes int
+	}
+	err := database.Model(&models.TimeEntry{}).
+		Select("task_id, SUM(minutes) AS minutes").
+		Where("task_id IN ?", taskIDs).
+		Group("task_id").
+		Scan(&rows).Error
+	totals := make(map[string]int, len(rows))
+	for _, r := range rows {
+		totals[r.TaskID] = r.Minutes
+	}
+	return totals, err
+}
+
+// effortVariance returns how far actual effort is off the estimate, in
+// percent (positive means over the estimate)
+func effortVariance(estimate, actual int) float64 {
+	return float64(actual-estimate) / float64(estimate) * 100
+}
+
+// effortSummary describes logged time against the estimate, e.g.
+// "4h estimated, 5h 30m logged (+38%)"
+func effortSummary(estimate, logged int) string {
+	switch {
+	case estimate > 0 && logged > 0:
+		return fmt.Sprintf("%s estimated, %s logged (%+.0f%%)", models.FormatEffort(estimate), models.FormatEffort(logged), effortVariance(estimate, logged))
+	case estimate > 0:
+		return fmt.Sprintf("%s estimated, none logged", models.FormatEffort(estimate))
+	case logged > 0:
+		return fmt.Sprintf("%s logged, no estimate", models.FormatEffort(logged))
+	}
+	return ""
+}
+
+// logTime records time spent on a task, with a history entry for undo
+func logTime(database *gorm.DB, taskID string, minutes int, by, note string) (*models.TimeEntry, error) {
+	entry := &models.TimeEntry{TaskID: taskID, Minutes: minutes, LoggedBy: by, Note: note}
+	err := database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		return models.RecordChange(tx, taskID, "time_logged", "", strconv.FormatUint(uint64(entry.ID), 10), by)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to log time: database error: %w", err)
+	}
+	return entry, nil
+}
+
+func runTimeLog(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot log time: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	minutes, err := parseEffort(args[1])
+	if err != nil {
+		return err
+	}
+
+	database := db.GetDB()
+	entry, err := logTime(database, task.ID, minutes, timeLogBy, timeLogNote)
+	if err != nil {
+		return err
+	}
+	totals, err := loggedMinutes(database, []string{task.ID})
+	if err != nil {
+		return fmt.Errorf("failed to total logged time: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "entry": entry, "logged_minutes": totals[task.ID], "estimate_minutes": task.Estimate})
+		return nil
+	}
+	fmt.Printf("Logged %s on %s (by %s)\n", models.FormatEffort(minutes), task.ID, timeLogBy)
+	fmt.Printf("  %s\n", effortSummary(task.Estimate, totals[task.ID]))
+	return nil
+}
+
+func runTimeList(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	var entries []models.TimeEntry
+	if err := db.GetDB().Where("task_id = ?", task.ID).Order("created_at ASC, id ASC").Find(&entries).Error; err != nil {
+		return fmt.Errorf("failed to list time entries: database error: %w", err)
+	}
+	total := 0
+	for _, e := range entries {
+		total += e.Minutes
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"entries": entries, "logged_minutes": total, "estimate_minutes": task.Estimate})
+		return nil
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No time logged on %s\n", task.ID)
+	}
+	for _, e := range entries {
+		fmt.Printf("%s  %-8s %s", e.CreatedAt.Format(models.DateTimeShortFormat), models.FormatEffort(e.Minutes), e.LoggedBy)
+		if e.Note != "" {
+			fmt.Printf(" - %s", e.Note)
+		}
+		fmt.Println()
+	}
+	if summary := effortSummary(task.Estimate, total); summary != "" {
+		fmt.Printf("\nTotal: %s\n", summary)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestParseEffort(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"45m", 45},
+		{"2h", 120},
+		{"1h30m", 90},
+		{"1d", 8 * 60},
+		{"1d4h", 12 * 60},
+		{"1w", 40 * 60},
+	}
+	for _, tt := range tests {
+		got, err := parseEffort(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseEffort(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "3", "h", "2x", "0h", "1.5h"} {
+		if _, err := parseEffort(in); err == nil {
+			t.Errorf("parseEffort(%q) should fail", in)
+		}
+	}
+}
+
+func TestLogTimeAndUndo(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-time0001", Title: "Tracked", Status: models.StatusOpen, Estimate: 240})
+
+	if _, err := logTime(database, "gur-time0001", 90, "alice", "spike"); err != nil {
+		t.Fatalf("logTime() error: %v", err)
+	}
+	entry, err := logTime(database, "gur-time0001", 240, "bob", "")
+	if err != nil {
+		t.Fatalf("logTime() error: %v", err)
+	}
+	totals, err := loggedMinutes(database, []string{"gur-time0001"})
+	if err != nil || totals["gur-time0001"] != 330 {
+		t.Fatalf("loggedMinutes() = %v, %v; want 330", totals, err)
+	}
+	if got := effortSummary(240, 330); got != "4h estimated, 5h 30m logged (+38%)" {
+		t.Errorf("effortSummary() = %q", got)
+	}
+
+	var h models.TaskHistory
+	if err := database.Where("task_id = ? AND field = ? AND changed_by = ?", "gur-time0001", "time_logged", "bob").First(&h).Error; err != nil {
+		t.Fatalf("time log not recorded in history: %v", err)
+	}
+	if err := revertChanges(database, []models.TaskHistory{h}); err != nil {
+		t.Fatalf("revertChanges() error: %v", err)
+	}
+	var count int64
+	database.Model(&models.TimeEntry{}).Where("id = ?", entry.ID).Count(&count)
+	if count != 0 {
+		t.Error("undo should delete the time entry")
+	}
+	if totals, _ := loggedMinutes(database, []string{"gur-time0001"}); totals["gur-time0001"] != 90 {
+		t.Errorf("logged after undo = %d, want 90", totals["gur-time0001"])
+	}
+}
+
+func TestBuildCalibration(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	closed := time.Now().Add(-24 * time.Hour)
+	tasks := []models.Task{
+		{ID: "gur-cal00001", Title: "A", Status: models.StatusClosed, Type: models.TypeBug, Assignee: "alice", Labels: models.StringSlice{"api"}, Estimate: 60, ClosedAt: &closed},
+		{ID: "gur-cal00002", Title: "B", Status: models.StatusClosed, Type: models.TypeBug, Assignee: "alice", Estimate: 120, ClosedAt: &closed},
+		{ID: "gur-cal00003", Title: "C", Status: models.StatusClosed, Type: models.TypeFeature, Labels: models.StringSlice{"api"}, Estimate: 60, ClosedAt: &closed},
+		{ID: "gur-cal00004", Title: "Open", Status: models.StatusOpen, Type: models.TypeBug, Estimate: 60},
+		{ID: "gur-cal00005", Title: "Unlogged", Status: models.StatusClosed, Type: models.TypeBug, Estimate: 60, ClosedAt: &closed},
+	}
+	for _, task := range tasks {
+		database.Create(&task)
+	}
+	logTime(database, "gur-cal00001", 90, "alice", "")
+	logTime(database, "gur-cal00002", 60, "alice", "")
+	logTime(database, "gur-cal00003", 60, "carol", "")
+	logTime(database, "gur-cal00004", 600, "carol", "")
+
+	report, err := buildCalibration(database, calibrationDimensions, time.Time{})
+	if err != nil {
+		t.Fatalf("buildCalibration() error: %v", err)
+	}
+	if report.Overall.Tasks != 3 || report.Overall.Estimated != 240 || report.Overall.Logged != 210 {
+		t.Errorf("overall = %+v, want 3 tasks, 240 estimated, 210 logged", report.Overall)
+	}
+	// Variances: +50%, -50%, 0%
+	if math.Abs(report.Overall.MeanError-100.0/3) > 0.01 {
+		t.Errorf("overall mean error = %.2f, want 33.33", report.Overall.MeanError)
+	}
+
+	byType := report.Groups["type"]
+	if len(byType) != 2 || byType[0].Key != models.TypeBug || byType[0].Tasks != 2 || byType[0].Ratio != 150.0/180 {
+		t.Errorf("by type = %+v, want bug first with 2 tasks at ratio 0.83", byType)
+	}
+	if byLabel := report.Groups["label"]; len(byLabel) != 1 || byLabel[0].Key != "api" || byLabel[0].Tasks != 2 {
+		t.Errorf("by label = %+v, want api with 2 tasks", byLabel)
+	}
+	if byAssignee := report.Groups["assignee"]; len(byAssignee) != 2 || byAssignee[1].Key != "(unassigned)" {
+		t.Errorf("by assignee = %+v, want alice then (unassigned)", byAssignee)
+	}
+
+	if recent, _ := buildCalibration(database, calibrationDimensions, time.Now()); recent.Overall.Tasks != 0 {
+		t.Errorf("--since now included %d tasks, want 0", recent.Overall.Tasks)
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage API tokens for 'gur serve'",
+	Long: `Issue and revoke the API tokens 'gur serve graphql' and 'gur serve web'
+accept. While no token exists the servers answer anyone who can reach
+them; once one is created, every request needs an active token with a
+scope the endpoint allows:
+
+  read    read-only endpoints (all current graphql and web endpoints)
+  write   endpoints that change tasks, and read
+  admin   administrative endpoints, and everything else
+
+Send the token as "Authorization: Bearer <token>". Browsers can open the
+dashboard once with ?token=<token>, which is then kept in a cookie.
+
+Tokens are stored hashed: the secret is shown only by 'token create'.
+
+Examples:
+  gur token create dashboard --scope read
+  gur token list
+  gur token revoke dashboard`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Issue a new API token",
+	Long: `Issue a new API token and print its secret. The secret can't be shown
+again; revoke the token and create another if it is lost. Without a name
+the token is named after the start of its secret.
+
+Examples:
+  gur token create dashboard --scope read
+  gur token create ci-agent --scope write --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTokenCreate,
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List API tokens",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runTokenList,
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <name|prefix>",
+	Short: "Revoke an API token",
+	Long: `Revoke an active API token by name or by the start of its secret (as
+'gur token list' shows it). Running servers refuse it from the next request.
+
+Examples:
+  gur token revoke dashboard
+  gur token revoke gur_3f9a01c2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTokenRevoke,
+}
+
+var (
+	tokenScope   string
+	tokenBy      string
+	tokenRevoked bool
+)
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+
+	tokenCreateCmd.Flags().StringVar(&tokenScope, "scope", models.TokenScopeRead, "What the token may do ("+strings.Join(models.TokenScopes, "/")+")")
+	tokenCreateCmd.Flags().StringVar(&tokenBy, "by", "user", "Who is issuing the token")
+	tokenListCmd.Flags().BoolVar(&tokenRevoked, "revoked", false, "Include revoked tokens")
+}
+
+// findActiveToken returns the active token with the given name or secret prefix
+func findActiveToken(ref string) (*models.APIToken, error) {
+	var tokens []models.APIToken
+	if err := db.GetDB().Where("revoked_at IS NULL AND (name = ? OR prefix = ?)", ref, ref).Find(&tokens).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up token: database error: %w", err)
+	}
+	switch len(tokens) {
+	case 0:
+		return nil, codedErrorf(ErrCodeNotFound, "no active token '%s' (use 'gur token list' to see tokens)", ref)
+	case 1:
+		return &tokens[0], nil
+	default:
+		return nil, fmt.Errorf("'%s' matches %d tokens: revoke by prefix instead (see 'gur token list')", ref, len(tokens))
+	}
+}
+
+func runTokenCreate(cmd *cobra.Command, args []string) error {
+	if err := models.ValidateTokenScope(tokenScope); err != nil {
+		return err
+	}
+	name := ""
+	if len(args) == 1 {
+		if name = strings.TrimSpace(args[0]); name == "" {
+			return fmt.Errorf("token name cannot be empty")
+		}
+		if _, err := findActiveToken(name); err == nil {
+			return fmt.Errorf("an active token is already named '%s' (revoke it first or pick another name)", name)
+		}
+	}
+
+	token, secret := models.NewAPIToken(name, tokenScope, tokenBy)
+	if token.Name == "" {
+		token.Name = token.Prefix
+	}
+	if err := db.GetDB().Create(token).Error; err != nil {
+		return fmt.Errorf("failed to save token: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"token": token, "secret": secret})
+		return nil
+	}
+	if IsQuietOutput() {
+		fmt.Println(secret)
+		return nil
+	}
+	fmt.Printf("Created %s token %s\n", token.Scope, token.Name)
+	fmt.Printf("  %s\n", secret)
+	fmt.Println("Store it now: it won't be shown again.")
+	return nil
+}
+
+func runTokenList(cmd *cobra.Command, args []string) error {
+	query := db.GetDB().Order("created_at ASC, id ASC")
+	if !tokenRevoked {
+		query = query.Where("revoked_at IS NULL")
+	}
+	var tokens []models.APIToken
+	if err := query.Find(&tokens).Error; err != nil {
+		return fmt.Errorf("failed to list tokens: database error: %w", err)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(tokens), "tokens": tokens})
+		return nil
+	}
+	if len(tokens) == 0 {
+		fmt.Println("No API tokens: 'gur serve' is open to anyone who can reach it (create one with 'gur token create <name>')")
+		return nil
+	}
+	for _, t := range tokens {
+		used := "never used"
+		if t.LastUsedAt != nil {
+			used = "used " + t.LastUsedAt.Format(models.DateTimeShortFormat)
+		}
+		line := fmt.Sprintf("%-20s %-14s %-6s created %s, %s", t.Name, t.Prefix, t.Scope, t.CreatedAt.Format(models.DateFormat), used)
+		if t.IsRevoked() {
+			line += ", revoked " + t.RevokedAt.Format(models.DateFormat)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runTokenRevoke(cmd *cobra.Command, args []string) error {
+	token, err := findActiveToken(args[0])
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := db.GetDB().Model(token).Update("revoked_at", now).Error; err != nil {
+		return fmt.Errorf("failed to revoke token '%s': database error: %w", token.Name, err)
+	}
+	token.RevokedAt = &now
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "token": token})
+		return nil
+	}
+	fmt.Printf("Revoked token %s (%s)\n", token.Name, token.Prefix)
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestRequireToken(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	read := requireToken(database, models.TokenScopeRead, ok)
+	write := requireToken(database, models.TokenScopeWrite, ok)
+	do := func(h http.Handler, target, secret string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if secret != "" {
+			req.Header.Set("Authorization", "Bearer "+secret)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(read, "/", ""); rec.Code != http.StatusOK {
+		t.Fatalf("without tokens the server answered %d, want it open", rec.Code)
+	}
+
+	token, secret := models.NewAPIToken("dashboard", models.TokenScopeRead, "alice")
+	database.Create(token)
+	if rec := do(read, "/", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("no token: %d, want 401", rec.Code)
+	}
+	if rec := do(read, "/", "gur_wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token: %d, want 401", rec.Code)
+	}
+	if rec := do(read, "/", secret); rec.Code != http.StatusOK {
+		t.Errorf("read token on a read endpoint: %d, want 200", rec.Code)
+	}
+	if rec := do(write, "/", secret); rec.Code != http.StatusForbidden {
+		t.Errorf("read token on a write endpoint: %d, want 403", rec.Code)
+	}
+	rec := do(read, "/tasks?status=open&token="+secret, "")
+	if rec.Code != http.StatusSeeOther || len(rec.Result().Cookies()) != 1 {
+		t.Errorf("?token= answered %d with cookies %v, want 303 and the token cookie", rec.Code, rec.Result().Cookies())
+	}
+	if loc := rec.Header().Get("Location"); loc != "/tasks?status=open" {
+		t.Errorf("?token= redirected to %q, want the same page without the token", loc)
+	}
+	database.First(token, token.ID)
+	if token.LastUsedAt == nil {
+		t.Fatal("last use was not recorded")
+	}
+
+	// Last use is written back at most once per interval
+	recent := time.Now().Add(-tokenUseInterval / 2)
+	database.Model(token).Update("last_used_at", recent)
+	do(read, "/", secret)
+	database.First(token, token.ID)
+	if !token.LastUsedAt.Equal(recent) {
+		t.Errorf("last use rewritten within the interval: %v, want %v", token.LastUsedAt, recent)
+	}
+
+	if err := runTokenCreate(tokenCreateCmd, []string{"dashboard"}); err == nil {
+		t.Error("created a second active token named dashboard")
+	}
+	if err := runTokenRevoke(tokenRevokeCmd, []string{"dashboard"}); err != nil {
+		t.Fatal(err)
+	}
+	if rec := do(read, "/", secret); rec.Code != http.StatusOK {
+		t.Errorf("with no active tokens left the server answered %d, want it open", rec.Code)
+	}
+	admin, adminSecret := models.NewAPIToken("ops", models.TokenScopeAdmin, "alice")
+	database.Create(admin)
+	if rec := do(read, "/", secret); rec.Code != http.StatusUnauthorized {
+		t.Errorf("revoked token: %d, want 401", rec.Code)
+	}
+	if rec := do(write, "/", adminSecret); rec.Code != http.StatusOK {
+		t.Errorf("admin token on a write endpoint: %d, want 200", rec.Code)
+	}
+}
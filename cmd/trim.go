@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// trimField is the history field for a trim; its values are the notes text
+// before and after, so undo can restore the dropped notes
+const trimField = "notes_trimmed"
+
+var (
+	trimKeepLast int
+	trimDryRun   bool
+	trimBy       string
+)
+
+var trimCmd = &cobra.Command{
+	Use:   "trim <task-id>",
+	Short: "Drop a task's oldest notes",
+	Long: `Drop a task's oldest notes, keeping the most recent ones, for tasks whose
+note history has grown too long to read or to fit in an agent's context.
+
+The dropped notes are removed from the task's notes text and from
+'gur note list'. The history keeps the notes from before the trim, so
+'gur undo' can restore them.
+
+Examples:
+  gur trim gur-abc123 --keep-last 20
+  gur trim gur-abc123 --keep-last 5 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrim,
+}
+
+func init() {
+	rootCmd.AddCommand(trimCmd)
+	trimCmd.Flags().IntVar(&trimKeepLast, "keep-last", 20, "Number of most recent notes to keep")
+	trimCmd.Flags().BoolVar(&trimDryRun, "dry-run", false, "Show what would be dropped without changing the task")
+	trimCmd.Flags().StringVar(&trimBy, "by", "user", "Who is trimming, for the history")
+}
+
+// renderNotes writes entries back in the Notes format AppendNotes produces
+func renderNotes(entries []models.NoteEntry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		if !e.CreatedAt.IsZero() {
+			b.WriteString("[" + e.CreatedAt.Format(models.DateTimeFormat) + "] ")
+		}
+		b.WriteString(e.Line() + "\n")
+	}
+	return b.String()
+}
+
+// trimNotes drops all but the last keep notes of a task, from both its
+// notes text and its note entries, and records the notes before and after
+// in the history.
+// It returns the number of notes dropped from the notes text.
+func trimNotes(tx *gorm.DB, task *models.Task, keep int, by string) (int, error) {
+	entries := models.ParseNotes(task.ID, task.Notes)
+	dropped := max(len(entries)-keep, 0)
+	if dropped == 0 {
+		return 0, nil
+	}
+
+	var stale []uint
+	if err := tx.Model(&models.NoteEntry{}).Where("task_id = ?", task.ID).
+		Order("created_at DESC, id DESC").Offset(keep).Pluck("id", &stale).Error; err != nil {
+		return 0, err
+	}
+	if len(stale) > 0 {
+		if err := tx.Delete(&models.NoteEntry{}, stale).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	before := task.Notes
+	task.Notes = renderNotes(entries[dropped:])
+	if err := models.RecordChange(tx, task.ID, trimField, before, task.Notes, by); err != nil {
+		return 0, err
+	}
+	return dropped, tx.Model(task).Update("notes", task.Notes).Error
+}
+
+func runTrim(cmd *cobra.Command, args []string) error {
+	if trimKeepLast < 0 {
+		return fmt.Errorf("invalid --keep-last %d: must be 0 or more", trimKeepLast)
+	}
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot trim notes: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+
+	total := len(models.ParseNotes(task.ID, task.Notes))
+	before := len(task.Notes)
+	dropped := max(total-trimKeepLast, 0)
+	if !trimDryRun && dropped > 0 {
+		if err := db.GetDB().Transaction(func(tx *gorm.DB) error {
+			_, err := trimNotes(tx, task, trimKeepLast, trimBy)
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to trim notes of task '%s': database error: %w", task.ID, err)
+		}
+		noteAffected(task.ID)
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{
+			"task_id":      task.ID,
+			"dropped":      dropped,
+			"kept":         total - dropped,
+			"bytes_before": before,
+			"bytes_after":  len(task.Notes),
+			"dry_run":      trimDryRun,
+		})
+		return nil
+	}
+	switch {
+	case dropped == 0:
+		fmt.Printf("%s has %d notes; nothing to trim\n", task.ID, total)
+	case trimDryRun:
+		fmt.Printf("Would drop %d of %d notes from %s, keeping the last %d\n", dropped, total, task.ID, total-dropped)
+	default:
+		fmt.Printf("Dropped %d of %d notes from %s (%s → %s)\n", dropped, total, task.ID,
+			formatByteSize(before), formatByteSize(len(task.Notes)))
+	}
+	return nil
+}
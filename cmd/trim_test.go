@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestTrimNotes(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	commandAffected = nil
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-7e000001", Title: "Chatty", Status: models.StatusInProgress}
+	database.Create(task)
+	for i := 1; i <= 5; i++ {
+		if _, err := addNote(database, task, models.NoteKindLog, "bot", fmt.Sprintf("step %d", i), "bot"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	database.Save(task)
+
+	trimKeepLast = 2
+	defer func() { trimKeepLast = 20 }()
+	if err := runTrim(trimCmd, []string{task.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	task, _ = db.GetTaskByID(task.ID)
+	if strings.Contains(task.Notes, "step 3") || !strings.Contains(task.Notes, "log: step 4") || !strings.Contains(task.Notes, "log: step 5") {
+		t.Errorf("notes = %q, want only steps 4 and 5", task.Notes)
+	}
+	var entries []models.NoteEntry
+	database.Where("task_id = ?", task.ID).Order("id").Find(&entries)
+	if len(entries) != 2 || entries[0].Body != "step 4" {
+		t.Errorf("note entries = %+v, want steps 4 and 5", entries)
+	}
+	var h models.TaskHistory
+	if err := database.Where("task_id = ? AND field = ?", task.ID, trimField).First(&h).Error; err != nil {
+		t.Fatal("no history entry for the trim")
+	}
+	if !strings.Contains(h.OldValue, "log: step 1") || h.NewValue != task.Notes {
+		t.Errorf("history = %q → %q, want the notes before and after", h.OldValue, h.NewValue)
+	}
+	if len(commandAffected) != 1 {
+		t.Errorf("affected = %v, want the trimmed task", commandAffected)
+	}
+}
@@ -0,0 +1,469 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// undoChangedBy is recorded in task history for reverted changes
+const undoChangedBy = "undo"
+
+var (
+	undoList   bool
+	undoWindow string
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the most recent mutating command",
+	Long: `Revert the most recent mutating command using the event log and task
+history: status changes, close/reopen, block/unblock, priority, title, type,
+description, assignee, parent, due date, estimate, rank, release, notes,
+custom fields, label/skill/agent changes, trimmed notes, logged time, added
+artifacts, gate waivers, trust overrides, and acceptance criteria.
+
+Only commands run within --window can be undone. Each undo reverts one
+command; run it again to step further back. Commands that don't record task
+history (e.g., create, gate pass, sync) cannot be undone; newer ones are
+skipped and listed so undo reaches the last command it can revert.
+
+Examples:
+  gur undo --list          # Preview what would be reverted
+  gur undo                 # Revert it
+  gur undo --window 24h    # Allow reverting older commands`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	undoCmd.Flags().BoolVar(&undoList, "list", false, "Show what would be reverted without changing anything")
+	undoCmd.Flags().StringVar(&undoWindow, "window", "1h", "Only undo commands run within this duration (e.g., 30m, 1h, 7d)")
+}
+
+// findUndoTarget returns the most recent successful command since cutoff
+// that has not been undone, along with the task history it recorded (oldest
+// first). Newer commands that recorded no task history are passed over and
+// returned as skipped so the caller can say what was not undone.
+func findUndoTarget(database *gorm.DB, cutoff time.Time) (*models.Event, []models.TaskHistory, []models.Event, error) {
+	reverted := database.Model(&models.Event{}).Select("reverts").Where("reverts > 0 AND success = ?", true)
+
+	var events []models.Event
+	err := database.
+		Where("success = ? AND reverts = 0 AND command NOT IN ?", true, []string{"undo"}).
+		Where("id NOT IN (?) AND finished_at >= ?", reverted, cutoff).
+		Order("id DESC").
+		Find(&events).Error
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read event log: database error: %w", err)
+	}
+
+	var skipped []models.Event
+	for i := range events {
+		event := &events[i]
+		var changes []models.TaskHistory
+		if len(event.AffectedIDs) > 0 {
+			if err := database.
+				Where("task_id IN ? AND changed_at >= ? AND changed_at <= ?", []string(event.AffectedIDs), event.StartedAt, event.FinishedAt).
+				Order("changed_at ASC").
+				Find(&changes).Error; err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to read task history: database error: %w", err)
+			}
+		}
+		if len(changes) > 0 {
+			return event, changes, skipped, nil
+		}
+		skipped = append(skipped, *event)
+	}
+	return nil, nil, skipped, nil
+}
+
+// describeChange renders a history entry as the change undo would make
+func describeChange(h models.TaskHistory) string {
+	switch {
+	case strings.HasSuffix(h.Field, "_added"):
+		return fmt.Sprintf("%s: remove %s %q", h.TaskID, strings.TrimSuffix(h.Field, "_added"), h.NewValue)
+	case strings.HasSuffix(h.Field, "_removed"):
+		return fmt.Sprintf("%s: restore %s %q", h.TaskID, strings.TrimSuffix(h.Field, "_removed"), h.OldValue)
+	case h.Field == "notes":
+		return fmt.Sprintf("%s: remove note %q", h.TaskID, h.NewValue)
+	case h.Field == priorityConfirmedField:
+		return fmt.Sprintf("%s: withdraw P%s priority confirmation", h.TaskID, h.NewValue)
+	case h.Field == trustOverrideField:
+		gateID, _, _ := strings.Cut(h.NewValue, ": ")
+		return fmt.Sprintf("%s: withdraw the trust override pass of %s", h.TaskID, gateID)
+	case h.Field == trimField:
+		dropped := len(models.ParseNotes(h.TaskID, h.OldValue)) - len(models.ParseNotes(h.TaskID, h.NewValue))
+		return fmt.Sprintf("%s: restore %d trimmed note(s)", h.TaskID, dropped)
+	default:
+		return fmt.Sprintf("%s: %s %q → %q", h.TaskID, h.Field, h.NewValue, h.OldValue)
+	}
+}
+
+// removeLastNote strips the most recent entry for note appended by AppendNotes
+func removeLastNote(notes, note string) string {
+	idx := strings.LastIndex(notes, "] "+note+"\n")
+	if idx < 0 {
+		return notes
+	}
+	start := strings.LastIndex(notes[:idx], "\n") + 1
+	return notes[:start] + notes[idx+len("] "+note+"\n"):]
+}
+
+// revertChanges applies history entries in reverse, recording each reversal
+func revertChanges(tx *gorm.DB, changes []models.TaskHistory) error {
+	tasks := make(map[string]*models.Task)
+	var order []string
+
+	for i := len(changes) - 1; i >= 0; i-- {
+		h := changes[i]
+		task, ok := tasks[h.TaskID]
+		if !ok {
+			task = &models.Task{}
+			if err := tx.Where("id = ?", h.TaskID).First(task).Error; err != nil {
+				return fmt.Errorf("task '%s' no longer exists", h.TaskID)
+			}
+			tasks[h.TaskID] = task
+			order = append(order, h.TaskID)
+		}
+
+		var err error
+		if name, ok := strings.CutPrefix(h.Field, fieldHistoryPrefix); ok {
+			var field models.CustomField
+			if err := tx.Where("name = ?", name).First(&field).Error; err != nil {
+				return fmt.Errorf("field '%s' no longer exists", name)
+			}
+			if _, err := setTaskFieldValue(tx, task.ID, field, h.OldValue); err != nil {
+				return err
+			}
+			if err := models.RecordChange(tx, task.ID, h.Field, h.NewValue, h.OldValue, undoChangedBy); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if h.Field == priorityConfirmedField {
+			// The confirmation time is read from history, so dropping the
+			// entry restores the previous one
+			if err := tx.Delete(&h).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch h.Field {
+		case "status":
+			task.Status = h.OldValue
+			if h.OldValue == models.StatusClosed {
+				closedAt := previousCloseTime(tx, task.ID, h.ChangedAt)
+				task.ClosedAt = &closedAt
+			} else if h.NewValue == models.StatusClosed {
+				task.ClosedAt = nil
+			}
+		case "close_reason":
+			task.CloseReason = h.OldValue
+		case "resolution":
+			task.Resolution = h.OldValue
+		case "block_reason":
+			task.BlockReason = h.OldValue
+		case "title":
+			task.Title = h.OldValue
+		case "description":
+			task.Description = h.OldValue
+		case "type":
+			task.Type = h.OldValue
+		case "assignee":
+			task.Assignee = h.OldValue
+		case "release":
+			task.Release = h.OldValue
+		case "parent_id":
+			if h.OldValue != "" {
+				if err := tx.Select("id").Where("id = ?", h.OldValue).First(&models.Task{}).Error; err != nil {
+					return fmt.Errorf("parent task '%s' no longer exists", h.OldValue)
+				}
+			}
+			task.ParentID = h.OldValue
+		case "priority":
+			p, convErr := strconv.Atoi(h.OldValue)
+			if convErr != nil {
+				return fmt.Errorf("invalid recorded priority '%s' for task '%s'", h.OldValue, task.ID)
+			}
+			task.Priority = p
+		case "due_at":
+			task.DueAt = nil
+			if h.OldValue != "" {
+				due, parseErr := parseDueDate(h.OldValue, h.ChangedAt)
+				if parseErr != nil {
+					return fmt.Errorf("invalid recorded due date '%s' for task '%s'", h.OldValue, task.ID)
+				}
+				task.DueAt = &due
+			}
+		case "notes":
+			task.Notes = removeLastNote(task.Notes, h.NewValue)
+			err = removeNoteEntry(tx, task.ID, h.NewValue)
+		case trimField:
+			task.Notes = h.OldValue
+			err = restoreTrimmedNotes(tx, task.ID, h)
+		case "label_added":
+			task.RemoveLabel(h.NewValue)
+		case "label_removed":
+			task.AddLabel(h.OldValue)
+		case "skill_added", "skill_removed":
+			err = revertSkillLink(tx, task.ID, h)
+		case "agent_added", "agent_removed":
+			err = revertAgentLink(tx, task.ID, h)
+		case "estimate":
+			estimate, convErr := strconv.Atoi(h.OldValue)
+			if convErr != nil {
+				return fmt.Errorf("invalid recorded estimate '%s' for task '%s'", h.OldValue, task.ID)
+			}
+			task.Estimate = estimate
+		case "rank":
+			rank, convErr := strconv.Atoi(h.OldValue)
+			if convErr != nil {
+				return fmt.Errorf("invalid recorded rank '%s' for task '%s'", h.OldValue, task.ID)
+			}
+			task.Rank = rank
+		case "time_logged":
+			err = tx.Where("task_id = ? AND id = ?", task.ID, h.NewValue).Delete(&models.TimeEntry{}).Error
+		case "artifact_added":
+			err = tx.Where("task_id = ? AND id = ?", task.ID, h.NewValue).Delete(&models.Artifact{}).Error
+		case "gate_waived":
+			err = revertGateWaiver(tx, task.ID, h)
+		case trustOverrideField:
+			err = revertTrustOverride(tx, task.ID, h)
+		case acceptanceField:
+			err = revertAcceptanceCriterion(tx, task.ID, h)
+		default:
+			return fmt.Errorf("cannot undo change to field '%s' on task '%s'", h.Field, task.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := models.RecordChange(tx, task.ID, h.Field, h.NewValue, h.OldValue, undoChangedBy); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range order {
+		if err := tx.Save(tasks[id]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// previousCloseTime finds when the task was last closed before the given time
+func previousCloseTime(tx *gorm.DB, taskID string, before time.Time) time.Time {
+	var h models.TaskHistory
+	if err := tx.Where("task_id = ? AND field = ? AND new_value = ? AND changed_at < ?", taskID, "status", models.StatusClosed, before).
+		Order("changed_at DESC").First(&h).Error; err == nil {
+		return h.ChangedAt
+	}
+	return time.Now()
+}
+
+func revertSkillLink(tx *gorm.DB, taskID string, h models.TaskHistory) error {
+	name := h.NewValue
+	if h.Field == "skill_removed" {
+		name = h.OldValue
+	}
+	var skill models.Skill
+	if err := tx.Where("name = ?", name).First(&skill).Error; err != nil {
+		return fmt.Errorf("skill '%s' no longer exists", name)
+	}
+	if h.Field == "skill_added" {
+		return tx.Where("task_id = ? AND skill_id = ?", taskID, skill.ID).Delete(&models.TaskSkillLink{}).Error
+	}
+	return tx.Create(&models.TaskSkillLink{TaskID: taskID, SkillID: skill.ID}).Error
+}
+
+func revertAgentLink(tx *gorm.DB, taskID string, h models.TaskHistory) error {
+	name := h.NewValue
+	if h.Field == "agent_removed" {
+		name = h.OldValue
+	}
+	var agent models.Agent
+	if err := tx.Where("name = ?", name).First(&agent).Error; err != nil {
+		return fmt.Errorf("agent '%s' no longer exists", name)
+	}
+	if h.Field == "agent_added" {
+		return tx.Where("task_id = ? AND agent_id = ?", taskID, agent.ID).Delete(&models.TaskAgentLink{}).Error
+	}
+	return tx.Create(&models.TaskAgentLink{TaskID: taskID, AgentID: agent.ID}).Error
+}
+
+// revertGateWaiver restores the link status recorded before a gate waiver
+func revertGateWaiver(tx *gorm.DB, taskID string, h models.TaskHistory) error {
+	status := h.OldValue
+	if status == "" {
+		status = models.GateLinkPending
+	}
+	result := tx.Model(&models.GateTaskLink{}).
+		Where("task_id = ? AND gate_id = ? AND status = ?", taskID, h.NewValue, models.GateLinkWaived).
+		Updates(map[string]interface{}{"status": status, "expires_at": nil})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("gate '%s' is no longer waived for task '%s'", h.NewValue, taskID)
+	}
+	return nil
+}
+
+// revertTrustOverride withdraws a pass recorded with --override-trust,
+// restoring the link status from before it
+func revertTrustOverride(tx *gorm.DB, taskID string, h models.TaskHistory) error {
+	gateID, _, _ := strings.Cut(h.NewValue, ": ")
+	status := h.OldValue
+	if status == "" {
+		status = models.GateLinkPending
+	}
+	result := tx.Model(&models.GateTaskLink{}).
+		Where("task_id = ? AND gate_id = ? AND status = ?", taskID, gateID, models.GateLinkPassed).
+		Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("gate '%s' is no longer passed for task '%s'", gateID, taskID)
+	}
+	return nil
+}
+
+// restoreTrimmedNotes recreates the note entries a trim dropped: the oldest
+// notes in the recorded text from before the trim
+func restoreTrimmedNotes(tx *gorm.DB, taskID string, h models.TaskHistory) error {
+	before := models.ParseNotes(taskID, h.OldValue)
+	dropped := len(before) - len(models.ParseNotes(taskID, h.NewValue))
+	for _, e := range before[:max(dropped, 0)] {
+		if err := tx.Create(&e).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revertAcceptanceCriterion undoes an 'ac' change: an added criterion is
+// deleted, a removed one restored at the end of the list, and a check or
+// uncheck reversed. Restored checks are attributed to undo, since who first
+// checked the criterion isn't recorded.
+func revertAcceptanceCriterion(tx *gorm.DB, taskID string, h models.TaskHistory) error {
+	now := time.Now()
+	oldText, oldDone, hadOld := models.ParseCheckbox(h.OldValue)
+	newText, newDone, hasNew := models.ParseCheckbox(h.NewValue)
+	if !hadOld && !hasNew {
+		return fmt.Errorf("invalid recorded acceptance criterion change on task '%s'", taskID)
+	}
+
+	if !hadOld {
+		criteria, err := loadAcceptanceCriteria(tx, taskID)
+		if err != nil {
+			return err
+		}
+		for i := len(criteria) - 1; i >= 0; i-- {
+			if criteria[i].Text != newText {
+				continue
+			}
+			if err := tx.Delete(&criteria[i]).Error; err != nil {
+				return err
+			}
+			// Keep the numbers contiguous
+			for _, c := range criteria[i+1:] {
+				if err := tx.Model(&c).Update("position", c.Position-1).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("acceptance criterion %q no longer exists on task '%s'", newText, taskID)
+	}
+
+	if !hasNew {
+		var last int
+		if err := tx.Model(&models.AcceptanceCriterion{}).Where("task_id = ?", taskID).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		c := models.AcceptanceCriterion{TaskID: taskID, Position: last + 1, Text: oldText, Done: oldDone}
+		if oldDone {
+			c.DoneAt, c.DoneBy = &now, undoChangedBy
+		}
+		return tx.Create(&c).Error
+	}
+
+	var c models.AcceptanceCriterion
+	if err := tx.Where("task_id = ? AND text = ? AND done = ?", taskID, newText, newDone).Order("position DESC").First(&c).Error; err != nil {
+		return fmt.Errorf("acceptance criterion %q no longer exists on task '%s'", newText, taskID)
+	}
+	c.Text, c.Done = oldText, oldDone
+	c.DoneAt, c.DoneBy = nil, ""
+	if oldDone {
+		c.DoneAt, c.DoneBy = &now, undoChangedBy
+	}
+	return tx.Save(&c).Error
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	window, err := parseAnyDuration(undoWindow)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("invalid --window '%s': use a duration like 30m, 1h, or 7d", undoWindow)
+	}
+
+	database := db.GetDB()
+	event, changes, skipped, err := findUndoTarget(database, time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		if len(skipped) > 0 {
+			return fmt.Errorf("nothing to undo: none of the %d command(s) in the last %s recorded task changes (use --window to reach older ones)", len(skipped), undoWindow)
+		}
+		return fmt.Errorf("nothing to undo: no commands in the last %s (use --window to extend)", undoWindow)
+	}
+
+	if undoList {
+		if IsJSONOutput() {
+			OutputJSON(map[string]interface{}{"event": event, "changes": changes, "skipped": skipped})
+			return nil
+		}
+		printUndoSkipped(skipped)
+		fmt.Printf("Would undo #%d %s (%s):\n", event.ID, event.Command, event.StartedAt.Format(models.DateTimeFormat))
+		for i := len(changes) - 1; i >= 0; i-- {
+			fmt.Printf("  %s\n", describeChange(changes[i]))
+		}
+		return nil
+	}
+
+	if err := database.Transaction(func(tx *gorm.DB) error {
+		return revertChanges(tx, changes)
+	}); err != nil {
+		return fmt.Errorf("failed to undo '%s' (#%d): %w", event.Command, event.ID, err)
+	}
+
+	commandReverts = event.ID
+	noteAffected(event.AffectedIDs...)
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "undone": event, "reverted": len(changes), "skipped": skipped})
+		return nil
+	}
+	printUndoSkipped(skipped)
+	fmt.Printf("Undid #%d %s (%d change(s))\n", event.ID, event.Command, len(changes))
+	return nil
+}
+
+// printUndoSkipped lists newer commands undo passed over
+func printUndoSkipped(skipped []models.Event) {
+	for _, e := range skipped {
+		fmt.Printf("Skipped #%d %s: it did not record any task changes\n", e.ID, e.Command)
+	}
+}
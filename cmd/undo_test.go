@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestRemoveLastNote(t *testing.T) {
+	notes := "[2024-01-01 10:00:00] keep\n[2024-01-01 11:00:00] drop\n"
+	if got := removeLastNote(notes, "drop"); got != "[2024-01-01 10:00:00] keep\n" {
+		t.Errorf("removeLastNote() = %q", got)
+	}
+	if got := removeLastNote(notes, "missing"); got != notes {
+		t.Errorf("removeLastNote() with unknown note changed notes to %q", got)
+	}
+}
+
+func TestUndoRevertsLastCommand(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	closedAt := time.Now()
+	task := models.Task{
+		ID:          "gur-undo0001",
+		Title:       "Undo me",
+		Status:      models.StatusClosed,
+		CloseReason: "done",
+		ClosedAt:    &closedAt,
+		Priority:    models.PriorityCritical,
+		Labels:      models.StringSlice{"urgent"},
+	}
+	database.Create(&task)
+
+	// Simulate "update -p 0 --label urgent" followed by "close"
+	record := func(command string, changes [][3]string) models.Event {
+		start := time.Now()
+		for _, c := range changes {
+			models.RecordChange(database, task.ID, c[0], c[1], c[2], "user")
+		}
+		e := models.Event{Command: command, AffectedIDs: models.StringSlice{task.ID}, Success: true, StartedAt: start, FinishedAt: time.Now()}
+		models.AppendEvent(database, &e)
+		time.Sleep(2 * time.Millisecond)
+		return e
+	}
+	update := record("update", [][3]string{{"priority", "2", "0"}, {"label_added", "", "urgent"}})
+	closeEvent := record("close", [][3]string{{"status", models.StatusOpen, models.StatusClosed}, {"close_reason", "", "done"}})
+	gatePass := record("gate pass", nil)
+
+	cutoff := time.Now().Add(-time.Hour)
+	event, changes, skipped, err := findUndoTarget(database, cutoff)
+	if err != nil {
+		t.Fatalf("findUndoTarget() error: %v", err)
+	}
+	if event == nil || event.ID != closeEvent.ID || len(changes) != 2 {
+		t.Fatalf("findUndoTarget() = %+v with %d changes, want #%d with 2", event, len(changes), closeEvent.ID)
+	}
+	if len(skipped) != 1 || skipped[0].ID != gatePass.ID {
+		t.Errorf("skipped = %+v, want the gate pass without history", skipped)
+	}
+	if err := revertChanges(database, changes); err != nil {
+		t.Fatalf("revertChanges() error: %v", err)
+	}
+	database.Create(&models.Event{Command: "undo", Success: true, Reverts: event.ID, StartedAt: time.Now(), FinishedAt: time.Now(), Hash: "x"})
+
+	var got models.Task
+	database.First(&got, "id = ?", task.ID)
+	if got.Status != models.StatusOpen || got.CloseReason != "" || got.ClosedAt != nil {
+		t.Errorf("after undoing close: status=%s reason=%q closed_at=%v", got.Status, got.CloseReason, got.ClosedAt)
+	}
+
+	// The next undo steps back to the update
+	event, changes, _, err = findUndoTarget(database, cutoff)
+	if err != nil || event == nil || event.ID != update.ID {
+		t.Fatalf("second findUndoTarget() = %+v, %v; want #%d", event, err, update.ID)
+	}
+	if err := revertChanges(database, changes); err != nil {
+		t.Fatalf("revertChanges() error: %v", err)
+	}
+	database.First(&got, "id = ?", task.ID)
+	if got.Priority != models.PriorityMedium || len(got.Labels) != 0 {
+		t.Errorf("after undoing update: priority=%d labels=%v", got.Priority, got.Labels)
+	}
+
+	var reverted []models.TaskHistory
+	database.Where("task_id = ? AND changed_by = ?", task.ID, undoChangedBy).Find(&reverted)
+	if len(reverted) != 4 {
+		t.Errorf("recorded %d undo history entries, want 4", len(reverted))
+	}
+
+	// Commands outside the window are out of reach
+	if event, _, _, err := findUndoTarget(database, time.Now().Add(time.Minute)); err != nil || event != nil {
+		t.Errorf("findUndoTarget() past the window = %+v, %v; want nothing", event, err)
+	}
+}
+
+func TestUndoAcceptanceCriteria(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-undo0ac1", Title: "SSO login", Status: models.StatusOpen})
+	acBy = "agent"
+	// undo reverts one command at a time; revert each criteria change in turn
+	undoLast := func() {
+		t.Helper()
+		var h models.TaskHistory
+		database.Where("task_id = ? AND field = ? AND changed_by <> ?", "gur-undo0ac1", acceptanceField, undoChangedBy).Order("changed_at DESC").First(&h)
+		if err := revertChanges(database, []models.TaskHistory{h}); err != nil {
+			t.Fatalf("revertChanges(%s → %s) error: %v", h.OldValue, h.NewValue, err)
+		}
+		database.Delete(&h)
+	}
+	texts := func() string {
+		criteria, _ := loadAcceptanceCriteria(database, "gur-undo0ac1")
+		var parts []string
+		for _, c := range criteria {
+			parts = append(parts, fmt.Sprintf("%d%s", c.Position, c.Checkbox()))
+		}
+		return strings.Join(parts, ",")
+	}
+
+	runAcAdd(acAddCmd, []string{"gur-undo0ac1", "Login works", "Logout works"})
+	setCriteriaDone("gur-undo0ac1", []string{"1"}, true)
+	runAcRemove(acRemoveCmd, []string{"gur-undo0ac1", "1"})
+
+	undoLast()
+	if got := texts(); got != "1- [ ] Logout works,2- [x] Login works" {
+		t.Errorf("after undoing the removal: %s, want the checked criterion restored", got)
+	}
+	undoLast()
+	if got := texts(); got != "1- [ ] Logout works,2- [ ] Login works" {
+		t.Errorf("after undoing the check: %s, want it unchecked", got)
+	}
+	undoLast()
+	undoLast()
+	if got := texts(); got != "" {
+		t.Errorf("after undoing the adds: %s, want no criteria", got)
+	}
+}
+
+func TestUndoTrimRestoresNotes(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := &models.Task{ID: "gur-undo0tr1", Title: "Chatty", Status: models.StatusInProgress}
+	database.Create(task)
+	for i := 1; i <= 4; i++ {
+		if _, err := addNote(database, task, models.NoteKindLog, "bot", fmt.Sprintf("step %d", i), "bot"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	database.Save(task)
+	notes := task.Notes
+
+	if _, err := trimNotes(database, task, 1, "user"); err != nil {
+		t.Fatal(err)
+	}
+	var changes []models.TaskHistory
+	database.Where("task_id = ? AND field = ?", task.ID, trimField).Find(&changes)
+	if err := revertChanges(database, changes); err != nil {
+		t.Fatalf("revertChanges() error: %v", err)
+	}
+
+	got, _ := db.GetTaskByID(task.ID)
+	if got.Notes != notes {
+		t.Errorf("notes after undo = %q, want %q", got.Notes, notes)
+	}
+	var entries []models.NoteEntry
+	database.Where("task_id = ?", task.ID).Order("created_at ASC, id ASC").Find(&entries)
+	if len(entries) != 4 || entries[0].Body != "step 1" || entries[0].Kind != models.NoteKindLog || entries[3].Body != "step 4" {
+		t.Errorf("note entries after undo = %+v, want steps 1 to 4", entries)
+	}
+}
+
+func TestUndoPriorityConfirmation(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := models.Task{ID: "gur-undo0pc1", Title: "Groomed", Status: models.StatusOpen, Priority: 2}
+	database.Create(&task)
+	models.RecordChange(database, task.ID, "priority", "1", "2", "user")
+	var set models.TaskHistory
+	database.Where("task_id = ? AND field = ?", task.ID, "priority").First(&set)
+	time.Sleep(2 * time.Millisecond)
+	models.RecordChange(database, task.ID, priorityConfirmedField, "", "2", "user")
+
+	var changes []models.TaskHistory
+	database.Where("task_id = ? AND field = ?", task.ID, priorityConfirmedField).Find(&changes)
+	if err := revertChanges(database, changes); err != nil {
+		t.Fatalf("revertChanges() error: %v", err)
+	}
+	if got := prioritySetAt(database, task); !got.Equal(set.ChangedAt) {
+		t.Errorf("priority set at %v after undo, want the earlier change at %v", got, set.ChangedAt)
+	}
+}
+
+func TestUndoPulledParent(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-undo0pp1", Title: "Parent", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-undo0pp2", Title: "Child", Status: models.StatusOpen})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-undo0pp1", IssueNumber: 1, Repository: "acme/app"})
+	database.Create(&models.GitHubIssueLink{TaskID: "gur-undo0pp2", IssueNumber: 2, Repository: "acme/app"})
+	var link models.GitHubIssueLink
+	database.Where("task_id = ?", "gur-undo0pp2").First(&link)
+	if _, err := (&issueHierarchy{subIssues: true}).pullParent(database, &link, issueRelation{Parent: 1}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []models.TaskHistory
+	database.Where("task_id = ? AND field = ?", "gur-undo0pp2", "parent_id").Find(&changes)
+	if err := revertChanges(database, changes); err != nil {
+		t.Fatalf("revertChanges() error: %v", err)
+	}
+	if task, _ := db.GetTaskByID("gur-undo0pp2"); task.ParentID != "" {
+		t.Errorf("parent after undo = %q, want none", task.ParentID)
+	}
+}
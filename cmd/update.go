@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -27,6 +29,10 @@ var (
 	updateRemoveSkill []string
 	updateAddAgent    []string
 	updateRemoveAgent []string
+	updateDue         string
+	updateEstimate    string
+	updateRelease     string
+	updateFields      []string
 )
 
 var updateCmd = &cobra.Command{
@@ -51,12 +57,16 @@ func init() {
 	updateCmd.Flags().StringArrayVar(&updateRemoveSkill, "remove-skill", nil, "Unlink skill from task")
 	updateCmd.Flags().StringArrayVar(&updateAddAgent, "agent", nil, "Link agent to task")
 	updateCmd.Flags().StringArrayVar(&updateRemoveAgent, "remove-agent", nil, "Unlink agent from task")
+	updateCmd.Flags().StringArrayVar(&updateFields, "field", nil, "Set custom field (name=value, or name= to clear)")
+	updateCmd.Flags().StringVar(&updateDue, "due", "", "Due date (e.g., 2025-07-01), duration from now (e.g., 7d), or 'none' to clear")
+	updateCmd.Flags().StringVar(&updateEstimate, "estimate", "", "Estimated effort in working time (e.g., 3h, 1d = 8h), or 'none' to clear")
+	updateCmd.Flags().StringVar(&updateRelease, "release", "", "Target release (see 'gur release'), or 'none' to clear")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
 	task, err := db.GetTaskByID(args[0])
 	if err != nil {
-		return fmt.Errorf("cannot update task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+		return codedErrorf(ErrCodeNotFound, "cannot update task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
 	}
 
 	// Prevent modifying closed tasks (except reopening via 'reopen' command)
@@ -68,6 +78,11 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	database := db.GetDB()
 	changedBy := "user" // Could be enhanced to track actual user
 
+	fields, err := resolveFieldAssignments(database, updateFields)
+	if err != nil {
+		return err
+	}
+
 	// Check if scope-changing fields are being modified and gates have passed
 	scopeChanging := cmd.Flags().Changed("title") || cmd.Flags().Changed("description") || cmd.Flags().Changed("type")
 	if scopeChanging {
@@ -110,8 +125,13 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		task.Title = updateTitle
 	}
 	if cmd.Flags().Changed("description") {
-		models.RecordChange(database, task.ID, "description", task.Description, updateDescription, changedBy)
-		task.Description = updateDescription
+		limits := loadSizeLimits()
+		description, err := limits.enforce(task.ID, "description", updateDescription, limits.Description)
+		if err != nil {
+			return err
+		}
+		models.RecordChange(database, task.ID, "description", task.Description, description, changedBy)
+		task.Description = description
 	}
 	if cmd.Flags().Changed("priority") {
 		// Validate priority range
@@ -133,18 +153,65 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 			models.StatusClosed:     true,
 		}
 		if !validStatuses[updateStatus] {
+			if updateStatus == models.StatusBlocked {
+				return fmt.Errorf("cannot set status '%s' on task '%s' directly: use 'gur block %s --reason <reason>'", updateStatus, task.ID, task.ID)
+			}
 			return fmt.Errorf("invalid status '%s' for task '%s': must be one of: open, in_progress, closed", updateStatus, task.ID)
 		}
 		models.RecordChange(database, task.ID, "status", task.Status, updateStatus, changedBy)
+		if task.IsBlocked() {
+			models.RecordChange(database, task.ID, "block_reason", task.BlockReason, "", changedBy)
+			task.BlockReason = ""
+		}
 		task.Status = updateStatus
 	}
 	if cmd.Flags().Changed("assignee") {
+		if err := checkAssignee(database, updateAssignee); err != nil {
+			return err
+		}
 		models.RecordChange(database, task.ID, "assignee", task.Assignee, updateAssignee, changedBy)
 		task.Assignee = updateAssignee
 	}
+	if cmd.Flags().Changed("due") {
+		var due *time.Time
+		if updateDue != noDueDate {
+			parsed, err := parseDueDate(updateDue, time.Now())
+			if err != nil {
+				return err
+			}
+			due = &parsed
+		}
+		old := task.DueString()
+		task.DueAt = due
+		models.RecordChange(database, task.ID, "due_at", old, task.DueString(), changedBy)
+	}
+	if cmd.Flags().Changed("estimate") {
+		estimate := 0
+		if updateEstimate != noEstimate {
+			parsed, err := parseEffort(updateEstimate)
+			if err != nil {
+				return err
+			}
+			estimate = parsed
+		}
+		models.RecordChange(database, task.ID, "estimate", strconv.Itoa(task.Estimate), strconv.Itoa(estimate), changedBy)
+		task.Estimate = estimate
+	}
+	if cmd.Flags().Changed("release") {
+		release := updateRelease
+		if release == noRelease {
+			release = ""
+		}
+		if err := targetRelease(database, release); err != nil {
+			return err
+		}
+		models.RecordChange(database, task.ID, "release", task.Release, release, changedBy)
+		task.Release = release
+	}
 	if cmd.Flags().Changed("notes") {
-		models.RecordChange(database, task.ID, "notes", "", updateNotes, changedBy)
-		task.AppendNotes(updateNotes)
+		if _, err := addNote(database, task, models.NoteKindNote, changedBy, updateNotes, changedBy); err != nil {
+			return err
+		}
 	}
 	for _, l := range updateAddLabel {
 		models.RecordChange(database, task.ID, "label_added", "", l, changedBy)
@@ -155,6 +222,10 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		task.RemoveLabel(l)
 	}
 
+	if err := applyFieldAssignments(database, task.ID, fields, changedBy); err != nil {
+		return err
+	}
+
 	// Link skills
 	for _, skillName := range updateAddSkill {
 		var skill models.Skill
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+var verifyBy string
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <task-id>",
+	Short: "Run all of a task's automated gates and report PASS/FAIL",
+	Long: `Run every automated gate linked to the task (those with a command, see
+'gur gate configure') and record each result as 'gur gate run' would, then
+print a PASS/FAIL table. Gates run after the gates they follow ('gur gate
+configure --after'); a gate following one that failed is skipped.
+
+The exit status is non-zero if any gate fails or is skipped, so agents can
+self-check before 'gur close'. Linked gates without a command are listed
+but not run.
+
+Examples:
+  gur verify gur-abc123
+  gur verify gur-abc123 --by agent
+  gur verify gur-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().StringVar(&verifyBy, "by", "human", "Who ran the gates (human/agent/ci/name)")
+}
+
+// Outcomes of a gate in 'gur verify' besides the link statuses passed,
+// failed and requested
+const (
+	verifySkipped   = "skipped"   // A gate it runs after didn't pass
+	verifyUntrusted = "untrusted" // The actor may not pass gates of its type
+	verifyError     = "error"     // The command couldn't be started
+)
+
+// verifyResult is the outcome of one automated gate
+type verifyResult struct {
+	GateID   string `json:"gate_id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"` // passed, failed, requested, skipped, untrusted or error
+	Duration int    `json:"duration_ms"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// ok reports whether the gate's command passed
+func (r verifyResult) ok() bool {
+	return r.Status == models.GateLinkPassed || r.Status == models.GateLinkRequested
+}
+
+// label is the result column of the table
+func (r verifyResult) label() string {
+	switch {
+	case r.ok():
+		return "PASS"
+	case r.Status == verifySkipped:
+		return "SKIP"
+	default:
+		return "FAIL"
+	}
+}
+
+// orderVerifyGates sorts gates so each comes after the gates it follows,
+// keeping link order otherwise. Followed gates not among gates are ignored.
+func orderVerifyGates(gates []GateLinkInfo) ([]GateLinkInfo, error) {
+	linked := make(map[string]bool, len(gates))
+	for _, info := range gates {
+		linked[info.Gate.ID] = true
+	}
+	done := make(map[string]bool, len(gates))
+	ordered := make([]GateLinkInfo, 0, len(gates))
+	for len(ordered) < len(gates) {
+		progressed := false
+		for _, info := range gates {
+			if done[info.Gate.ID] {
+				continue
+			}
+			ready := true
+			for _, id := range info.Gate.After {
+				if linked[id] && !done[id] {
+					ready = false
+				}
+			}
+			if ready {
+				ordered = append(ordered, info)
+				done[info.Gate.ID] = true
+				progressed = true
+			}
+		}
+		if !progressed {
+			var stuck []string
+			for _, info := range gates {
+				if !done[info.Gate.ID] {
+					stuck = append(stuck, info.Gate.ID)
+				}
+			}
+			return nil, fmt.Errorf("cannot order gates: %s run after each other (fix with 'gur gate configure <gate-id> --after ...')", strings.Join(stuck, ", "))
+		}
+	}
+	return ordered, nil
+}
+
+// verifyTask runs the task's automated gates in order and records their
+// results
+func verifyTask(database *gorm.DB, task *models.Task, gates []GateLinkInfo, root string) ([]verifyResult, error) {
+	results := make([]verifyResult, 0, len(gates))
+	failed := make(map[string]bool)
+	for _, info := range gates {
+		gate := info.Gate
+		res := verifyResult{GateID: gate.ID, Title: gate.Title}
+		for _, id := range gate.After {
+			if failed[id] {
+				res.Status, res.Detail = verifySkipped, "runs after "+id+", which didn't pass"
+				break
+			}
+		}
+		if res.Status == verifySkipped {
+			failed[gate.ID] = true
+			results = append(results, res)
+			continue
+		}
+
+		if !IsJSONOutput() {
+			fmt.Printf("Running %s (%s): %s\n", gate.ID, gate.RunnerString(), gate.Command)
+		}
+		exec, err := executeGateCommand(&gate, root)
+		if err != nil {
+			res.Status, res.Detail = verifyError, err.Error()
+			failed[gate.ID] = true
+			results = append(results, res)
+			continue
+		}
+		res.Duration = int(exec.Duration.Milliseconds())
+		status, summary := exec.status(&gate)
+		res.Detail = summary
+		link := info.Link
+		saved, err := saveGateResult(database, &gate, &link, status, verifyBy, summary+" (verify)", models.GateRun{
+			Output:   exec.Output,
+			Duration: res.Duration,
+			Runner:   gate.RunnerString(),
+		})
+		if errorCodeOf(err) == ErrCodeUntrusted {
+			res.Status, res.Detail = verifyUntrusted, err.Error()
+			failed[gate.ID] = true
+			results = append(results, res)
+			continue
+		}
+		if err != nil {
+			return results, err
+		}
+		res.Status = saved.Result
+		if saved.Result == models.GateLinkRequested {
+			res.Detail = "passed; awaiting approval by " + strings.Join(gate.Approvers, ", ")
+		}
+		if !res.ok() {
+			failed[gate.ID] = true
+			if !IsJSONOutput() && exec.Output != "" {
+				fmt.Print(tailOutput(exec.Output, 4<<10))
+				if !strings.HasSuffix(exec.Output, "\n") {
+					fmt.Println()
+				}
+			}
+			if err := fireHook(hookOnGateFail, hookPayload{Task: task, Gate: &gate, Link: &link, Run: saved}); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "cannot verify task: task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	links, err := GetGateLinksForTask(task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load gates for task '%s': database error: %w", task.ID, err)
+	}
+	var automated []GateLinkInfo
+	var manual []string
+	now := time.Now()
+	for _, info := range links {
+		if info.Gate.Command != "" {
+			automated = append(automated, info)
+		} else if !info.Link.Satisfied(now) {
+			manual = append(manual, info.Gate.ID)
+		}
+	}
+	automated, err = orderVerifyGates(automated)
+	if err != nil {
+		return err
+	}
+
+	root, err := db.FindProjectRoot()
+	if err != nil {
+		if root, err = os.Getwd(); err != nil {
+			return err
+		}
+	}
+
+	results, err := verifyTask(db.GetDB(), task, automated, root)
+	if err != nil {
+		return err
+	}
+	failures := 0
+	for _, r := range results {
+		if !r.ok() {
+			failures++
+		}
+	}
+
+	if IsJSONOutput() {
+		if manual == nil {
+			manual = []string{}
+		}
+		OutputJSON(map[string]interface{}{
+			"success": failures == 0,
+			"task_id": task.ID,
+			"gates":   results,
+			"passed":  len(results) - failures,
+			"failed":  failures,
+			"manual":  manual,
+		})
+	} else {
+		if len(results) == 0 {
+			fmt.Printf("No automated gates linked to %s\n", task.ID)
+		} else {
+			c := colors()
+			fmt.Printf("\n%-6s %-13s %-9s %s\n", "RESULT", "GATE", "TIME", "TITLE")
+			for _, r := range results {
+				duration := "-"
+				if r.Status != verifySkipped && r.Status != verifyError {
+					duration = (time.Duration(r.Duration) * time.Millisecond).Round(time.Millisecond).String()
+				}
+				fmt.Printf("%s%s %-13s %-9s %s\n", c.Result(r.label()), strings.Repeat(" ", 6-len(r.label())), r.GateID, duration, r.Title)
+				if r.Detail != "" && (!r.ok() || r.Status == models.GateLinkRequested) {
+					fmt.Printf("       %s\n", r.Detail)
+				}
+			}
+			fmt.Printf("\n%d of %d automated gate(s) passed\n", len(results)-failures, len(results))
+		}
+		if len(manual) > 0 {
+			fmt.Printf("Still to verify by hand: %s (gur gate pass <gate-id> %s)\n", strings.Join(manual, ", "), task.ID)
+		}
+	}
+
+	if failures > 0 {
+		return reportedErrorf(ErrCodeGatePending, "%d of %d automated gate(s) failed for task '%s'", failures, len(results), task.ID)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestOrderVerifyGates(t *testing.T) {
+	gate := func(id string, after ...string) GateLinkInfo {
+		return GateLinkInfo{Gate: models.Gate{ID: id, After: after}}
+	}
+	ordered, err := orderVerifyGates([]GateLinkInfo{gate("test", "build"), gate("lint"), gate("build", "gen-unlinked")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, info := range ordered {
+		ids = append(ids, info.Gate.ID)
+	}
+	if got := ids[0] + "," + ids[1] + "," + ids[2]; got != "lint,build,test" {
+		t.Errorf("order = %s, want lint,build,test", got)
+	}
+
+	if _, err := orderVerifyGates([]GateLinkInfo{gate("a", "b"), gate("b", "a")}); err == nil {
+		t.Error("expected an error for gates running after each other")
+	}
+}
+
+func TestVerifyTask(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	task := models.Task{ID: "gur-v1000000", Title: "Task", Status: models.StatusOpen}
+	database.Create(&task)
+	database.Create(&models.Gate{ID: "gate-v1000001", Title: "Test", Command: "true", After: models.StringSlice{"gate-v1000002"}})
+	database.Create(&models.Gate{ID: "gate-v1000002", Title: "Build", Command: "exit 2"})
+	database.Create(&models.Gate{ID: "gate-v1000003", Title: "Lint", Command: "true"})
+	database.Create(&models.Gate{ID: "gate-v1000004", Title: "Review"})
+	for _, id := range []string{"gate-v1000001", "gate-v1000002", "gate-v1000003", "gate-v1000004"} {
+		database.Create(&models.GateTaskLink{GateID: id, TaskID: task.ID, Status: models.GateLinkPending})
+	}
+
+	links, err := GetGateLinksForTask(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var automated []GateLinkInfo
+	for _, info := range links {
+		if info.Gate.Command != "" {
+			automated = append(automated, info)
+		}
+	}
+	automated, err = orderVerifyGates(automated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifyBy = "ci"
+	results, err := verifyTask(database, &task, automated, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"gate-v1000001": verifySkipped,
+		"gate-v1000002": models.GateLinkFailed,
+		"gate-v1000003": models.GateLinkPassed,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("results = %+v", results)
+	}
+	for _, r := range results {
+		if r.Status != want[r.GateID] {
+			t.Errorf("%s status = %s, want %s", r.GateID, r.Status, want[r.GateID])
+		}
+	}
+	if results[0].GateID != "gate-v1000002" {
+		t.Errorf("first gate run = %s, want the gate test runs after", results[0].GateID)
+	}
+
+	var link models.GateTaskLink
+	database.Where("gate_id = ? AND task_id = ?", "gate-v1000003", task.ID).First(&link)
+	if link.Status != models.GateLinkPassed || link.VerifiedBy != "ci" {
+		t.Errorf("lint link = %+v", link)
+	}
+	var skipped models.GateTaskLink
+	database.Where("gate_id = ? AND task_id = ?", "gate-v1000001", task.ID).First(&skipped)
+	if skipped.Status != models.GateLinkPending {
+		t.Errorf("skipped gate link status = %s, want it left pending", skipped.Status)
+	}
+}
+
+func TestGateOrderCycle(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Gate{ID: "gate-c1000001", Title: "Build"})
+	database.Create(&models.Gate{ID: "gate-c1000002", Title: "Test", After: models.StringSlice{"gate-c1000001"}})
+	database.Create(&models.Gate{ID: "gate-c1000003", Title: "Deploy", After: models.StringSlice{"gate-c1000002"}})
+
+	if cycle, err := gateOrderCycle("gate-c1000001", []string{"gate-c1000003"}); err != nil || cycle != "gate-c1000003" {
+		t.Errorf("gateOrderCycle(build after deploy) = %q, %v, want gate-c1000003", cycle, err)
+	}
+	if cycle, err := gateOrderCycle("gate-c1000003", []string{"gate-c1000001"}); err != nil || cycle != "" {
+		t.Errorf("gateOrderCycle(deploy after build) = %q, %v, want none", cycle, err)
+	}
+}
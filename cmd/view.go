@@ -0,0 +1,361 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// EnvViewsFile overrides the location of the user's saved views
+const EnvViewsFile = "GUR_VIEWS"
+
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Save and run named task queries",
+	Long: `Save a filter and sort order under a name, then list the matching tasks
+with 'gur view run <name>' instead of repeating long flag strings.
+
+A filter is a list of key=value or key!=value conditions joined by AND. Keys
+are status, priority, type, assignee, label, release and parent; any other
+key is matched against the custom field of that name. assignee=me matches
+the current actor (GUR_ACTOR, or the configured machine name). Archived
+tasks are left out unless the filter has a status condition.
+
+Views are stored in the project database, shared by everyone using it, or
+with --user in your own views file (~/.guardrails/views.json, or
+$GUR_VIEWS). A user view hides a project view of the same name.
+
+Examples:
+  gur view save myqueue --filter "assignee=me AND status!=closed" --sort priority
+  gur view save triage --filter "label=bug AND priority=P0" --user
+  gur view run myqueue
+  gur view run myqueue --limit 10 --json
+  gur view list
+  gur view remove triage --user`,
+}
+
+var viewSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save or replace a view",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runViewSave,
+}
+
+var viewRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "List the tasks matching a view",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runViewRun,
+}
+
+var viewListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List saved views",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Args:        cobra.NoArgs,
+	RunE:        runViewList,
+}
+
+var viewRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a view",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runViewRemove,
+}
+
+var (
+	viewFilter  string
+	viewSort    string
+	viewUser    bool
+	viewRunPage pageOptions
+)
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+	viewCmd.AddCommand(viewSaveCmd)
+	viewCmd.AddCommand(viewRunCmd)
+	viewCmd.AddCommand(viewListCmd)
+	viewCmd.AddCommand(viewRemoveCmd)
+	viewSaveCmd.Flags().StringVar(&viewFilter, "filter", "", `Conditions joined by AND (e.g., "assignee=me AND status!=closed")`)
+	viewSaveCmd.Flags().StringVar(&viewSort, "sort", "", "Default sort: created, due, priority, updated or votes")
+	viewSaveCmd.Flags().BoolVar(&viewUser, "user", false, "Save in your user views instead of the project")
+	viewRemoveCmd.Flags().BoolVar(&viewUser, "user", false, "Remove from your user views instead of the project")
+	addPageFlags(viewRunCmd, &viewRunPage, taskSorts)
+}
+
+// savedView is a named filter and sort order
+type savedView struct {
+	Name   string `json:"name,omitempty"`
+	Filter string `json:"filter"`
+	Sort   string `json:"sort,omitempty"`
+	Scope  string `json:"scope,omitempty"` // project or user; not stored
+}
+
+// userViews is the user views file
+type userViews struct {
+	Views map[string]savedView `json:"views"`
+}
+
+var viewAndRegex = regexp.MustCompile(`(?i)\s+AND\s+`)
+
+// splitViewFilter splits a filter into its AND-joined conditions
+func splitViewFilter(filter string) []string {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil
+	}
+	return viewAndRegex.Split(filter, -1)
+}
+
+// viewQuery builds the task query for a view, without ordering or paging
+func viewQuery(database *gorm.DB, v savedView) (*gorm.DB, error) {
+	conditions := splitViewFilter(v.Filter)
+	query := database.Model(&models.Task{})
+	hasStatus := false
+	for _, cond := range conditions {
+		key, _, _ := strings.Cut(strings.Replace(cond, "!=", "=", 1), "=")
+		if strings.EqualFold(strings.TrimSpace(key), "status") {
+			hasStatus = true
+		}
+	}
+	if !hasStatus {
+		query = query.Where("status != ?", models.StatusArchived)
+	}
+	return whereBatchConditions(database, query, conditions)
+}
+
+// userViewsPath returns the user views file path, honoring GUR_VIEWS
+func userViewsPath() (string, error) {
+	if p := os.Getenv(EnvViewsFile); p != "" {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, db.GuardrailsDir, "views.json"), nil
+}
+
+// loadUserViews reads the user views file. A missing file has no views.
+func loadUserViews() (*userViews, string, error) {
+	path, err := userViewsPath()
+	if err != nil {
+		return nil, "", err
+	}
+	views := &userViews{Views: make(map[string]savedView)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return views, path, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read views file: %w", err)
+	}
+	if err := json.Unmarshal(data, views); err != nil {
+		return nil, "", fmt.Errorf("invalid views file %s: %w", path, err)
+	}
+	if views.Views == nil {
+		views.Views = make(map[string]savedView)
+	}
+	return views, path, nil
+}
+
+// save writes the user views file, creating its directory if needed
+func (u *userViews) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create views directory: %w", err)
+	}
+	data, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// loadViews returns every saved view keyed by name, user views hiding
+// project views of the same name
+func loadViews() (map[string]savedView, error) {
+	var configs []models.Config
+	if err := db.GetDB().Where("key LIKE ?", models.ConfigViewPrefix+"%").Find(&configs).Error; err != nil {
+		return nil, err
+	}
+	views := make(map[string]savedView, len(configs))
+	for _, c := range configs {
+		var v savedView
+		if err := json.Unmarshal([]byte(c.Value), &v); err != nil {
+			return nil, fmt.Errorf("invalid view '%s': %w", strings.TrimPrefix(c.Key, models.ConfigViewPrefix), err)
+		}
+		v.Name, v.Scope = strings.TrimPrefix(c.Key, models.ConfigViewPrefix), "project"
+		views[v.Name] = v
+	}
+	user, _, err := loadUserViews()
+	if err != nil {
+		return nil, err
+	}
+	for name, v := range user.Views {
+		v.Name, v.Scope = name, "user"
+		views[name] = v
+	}
+	return views, nil
+}
+
+func runViewSave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !aliasNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid view name '%s': use lowercase letters, digits and dashes, starting with a letter", name)
+	}
+	v := savedView{Filter: strings.TrimSpace(viewFilter), Sort: viewSort}
+	if v.Filter == "" && v.Sort == "" {
+		return fmt.Errorf("nothing to save: give --filter and/or --sort")
+	}
+	check := pageOptions{sort: v.Sort, sorts: taskSorts}
+	if err := check.resolve(); err != nil {
+		return err
+	}
+	if _, err := viewQuery(db.GetDB(), v); err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+
+	scope := "project"
+	if viewUser {
+		scope = "user"
+		views, path, err := loadUserViews()
+		if err != nil {
+			return err
+		}
+		views.Views[name] = v
+		if err := views.save(path); err != nil {
+			return fmt.Errorf("failed to save view '%s': %w", name, err)
+		}
+	} else {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if err := db.SetConfig(models.ViewKey(name), string(data)); err != nil {
+			return fmt.Errorf("failed to save view '%s': %w", name, err)
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"name": name, "filter": v.Filter, "sort": v.Sort, "scope": scope})
+		return nil
+	}
+	fmt.Printf("Saved %s view: %s\n", scope, name)
+	return nil
+}
+
+func runViewRun(cmd *cobra.Command, args []string) error {
+	views, err := loadViews()
+	if err != nil {
+		return err
+	}
+	v, ok := views[args[0]]
+	if !ok {
+		return codedErrorf(ErrCodeNotFound, "view '%s' not found (use 'gur view list' to see views)", args[0])
+	}
+	if viewRunPage.sort == "" {
+		viewRunPage.sort = v.Sort
+	}
+	if err := viewRunPage.resolve(); err != nil {
+		return err
+	}
+	if err := viewRunPage.validateFields(models.Task{}); err != nil {
+		return err
+	}
+
+	query, err := viewQuery(db.GetDB(), v)
+	if err != nil {
+		return fmt.Errorf("view '%s' has an invalid filter: %w", v.Name, err)
+	}
+	query, total, err := viewRunPage.paginate(query, &models.Task{})
+	if err != nil {
+		return err
+	}
+	var tasks []models.Task
+	if err := query.Order(viewRunPage.order("priority ASC, rank = 0, rank ASC, created_at DESC")).Find(&tasks).Error; err != nil {
+		return err
+	}
+	return printTaskList(tasks, &viewRunPage, total, time.Now())
+}
+
+func runViewList(cmd *cobra.Command, args []string) error {
+	views, err := loadViews()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(views))
+	for name := range views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if IsJSONOutput() {
+		list := make([]savedView, 0, len(names))
+		for _, name := range names {
+			list = append(list, views[name])
+		}
+		OutputJSON(map[string]interface{}{"count": len(list), "views": list})
+		return nil
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No views saved (use 'gur view save <name> --filter ...')")
+		return nil
+	}
+	for _, name := range names {
+		v := views[name]
+		sortBy := ""
+		if v.Sort != "" {
+			sortBy = " (sort: " + v.Sort + ")"
+		}
+		fmt.Printf("%-16s %-8s %s%s\n", name, v.Scope, v.Filter, sortBy)
+	}
+	return nil
+}
+
+func runViewRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	scope := "project"
+	if viewUser {
+		scope = "user"
+		views, path, err := loadUserViews()
+		if err != nil {
+			return err
+		}
+		if _, ok := views.Views[name]; !ok {
+			return codedErrorf(ErrCodeNotFound, "user view '%s' not found (use 'gur view list' to see views)", name)
+		}
+		delete(views.Views, name)
+		if err := views.save(path); err != nil {
+			return fmt.Errorf("failed to remove view '%s': %w", name, err)
+		}
+	} else {
+		result := db.GetDB().Where("key = ?", models.ViewKey(name)).Delete(&models.Config{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to remove view '%s': %w", name, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return codedErrorf(ErrCodeNotFound, "project view '%s' not found (use 'gur view list' to see views, --user for your own)", name)
+		}
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"removed": name, "scope": scope})
+		return nil
+	}
+	fmt.Printf("Removed %s view: %s\n", scope, name)
+	return nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestViews(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+	database := db.GetDB()
+	t.Setenv(EnvActor, "alice")
+	t.Setenv(EnvViewsFile, filepath.Join(t.TempDir(), "views.json"))
+
+	database.Create(&models.Task{ID: "gur-view0001", Title: "Mine", Status: models.StatusOpen, Assignee: "alice", Priority: 2})
+	database.Create(&models.Task{ID: "gur-view0002", Title: "Mine, urgent", Status: models.StatusInProgress, Assignee: "alice", Priority: 0})
+	database.Create(&models.Task{ID: "gur-view0003", Title: "Mine, done", Status: models.StatusClosed, Assignee: "alice"})
+	database.Create(&models.Task{ID: "gur-view0004", Title: "Theirs", Status: models.StatusOpen, Assignee: "bob"})
+
+	viewFilter, viewSort, viewUser = "assignee=me AND status!=closed", "priority", false
+	if err := runViewSave(nil, []string{"myqueue"}); err != nil {
+		t.Fatalf("view save error: %v", err)
+	}
+	viewFilter, viewSort, viewUser = "assignee=bob", "", true
+	if err := runViewSave(nil, []string{"bobs"}); err != nil {
+		t.Fatalf("view save --user error: %v", err)
+	}
+
+	views, err := loadViews()
+	if err != nil {
+		t.Fatalf("loadViews() error: %v", err)
+	}
+	if views["myqueue"].Scope != "project" || views["bobs"].Scope != "user" {
+		t.Errorf("views = %+v, want myqueue in the project and bobs in user views", views)
+	}
+
+	query, err := viewQuery(database, views["myqueue"])
+	if err != nil {
+		t.Fatalf("viewQuery() error: %v", err)
+	}
+	var tasks []models.Task
+	query.Order(taskSorts[views["myqueue"].Sort]).Find(&tasks)
+	var got []string
+	for _, task := range tasks {
+		got = append(got, task.ID)
+	}
+	if want := []string{"gur-view0002", "gur-view0001"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("myqueue = %v, want %v", got, want)
+	}
+
+	viewFilter, viewSort, viewUser = "assignee=me AND cheese", "", false
+	if err := runViewSave(nil, []string{"broken"}); err == nil {
+		t.Error("view save accepted a malformed condition")
+	}
+	viewFilter, viewSort = "", "size"
+	if err := runViewSave(nil, []string{"broken"}); err == nil {
+		t.Error("view save accepted an unknown sort")
+	}
+}
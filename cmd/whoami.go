@@ -13,10 +13,11 @@ import (
 )
 
 var whoamiCmd = &cobra.Command{
-	Use:   "whoami",
-	Short: "Show current user and machine info",
-	Long:  `Display information about the current user, machine, and GitHub configuration.`,
-	RunE:  runWhoami,
+	Use:         "whoami",
+	Short:       "Show current user and machine info",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long:        `Display information about the current user, machine, and GitHub configuration.`,
+	RunE:        runWhoami,
 }
 
 func init() {
@@ -45,7 +46,7 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get database path
-	dbPath, _ := db.GetDefaultDBPath()
+	dbPath := db.DescribeDatabase()
 
 	if IsJSONOutput() {
 		result := map[string]interface{}{
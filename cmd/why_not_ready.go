@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+// Reasons a task is left out of 'gur ready'
+const (
+	notReadyStatus    = "status"         // Closed, archived or in the inbox
+	notReadyBlocked   = "blocked"        // Blocked status, waiting on something outside the graph
+	notReadyBlocker   = "open_blocker"   // A blocking dependency is still open
+	notReadyLag       = "dependency_lag" // A finish-to-start-after dependency's lag hasn't passed
+	notReadyClaimed   = "claimed"        // Another agent is the task's primary agent
+	notReadyUnmatched = "unmatched"      // The task's requirements don't match the agent
+)
+
+var whyNotReadyAgent string
+
+var whyNotReadyCmd = &cobra.Command{
+	Use:         "why-not-ready <id>",
+	Short:       "Explain why a task is not in 'gur ready'",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	Long: `Explain why a task is left out of 'gur ready': its status (closed,
+archived, blocked or in the inbox), blocking dependencies that are still
+open, and finish-to-start-after dependencies whose lag hasn't passed yet.
+
+With --agent, also explain why 'gur ready --agent <name> --strict' leaves it
+out: another agent is its primary agent, or its requirements don't match
+the agent's capabilities.
+
+With --json, reasons is an array of {code, message, ...} objects with codes
+status, blocked, open_blocker, dependency_lag, claimed and unmatched; it is
+empty when the task is ready.
+
+Examples:
+  gur why-not-ready gur-abc123
+  gur why-not-ready gur-abc123 --agent frontend-dev
+  gur why-not-ready gur-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWhyNotReady,
+}
+
+func init() {
+	rootCmd.AddCommand(whyNotReadyCmd)
+	whyNotReadyCmd.Flags().StringVar(&whyNotReadyAgent, "agent", "", "Also check the task against this agent, as 'ready --agent --strict' does")
+}
+
+// notReadyReason is one reason a task is left out of 'gur ready'
+type notReadyReason struct {
+	Code    string     `json:"code"`
+	Message string     `json:"message"`
+	TaskID  string     `json:"task_id,omitempty"` // The blocker, for open_blocker and dependency_lag
+	Agent   string     `json:"agent,omitempty"`   // The primary agent, for claimed
+	Until   *time.Time `json:"until,omitempty"`   // When a dependency lag ends
+}
+
+// explainNotReady returns why ready leaves out a task, empty if it is
+// ready. agent, if given, adds the reasons 'ready --agent --strict' has.
+func explainNotReady(database *gorm.DB, task models.Task, agent *models.Agent, now time.Time) ([]notReadyReason, error) {
+	var reasons []notReadyReason
+	switch task.Status {
+	case models.StatusOpen, models.StatusInProgress:
+	case models.StatusBlocked:
+		msg := "the task is blocked"
+		if task.BlockReason != "" {
+			msg += ": " + task.BlockReason
+		}
+		reasons = append(reasons, notReadyReason{Code: notReadyBlocked, Message: msg + fmt.Sprintf(" (unblock with 'gur unblock %s')", task.ID)})
+	case models.StatusInbox:
+		reasons = append(reasons, notReadyReason{Code: notReadyStatus, Message: fmt.Sprintf("the task is in the inbox (triage it with 'gur inbox triage' or 'gur update %s --status open')", task.ID)})
+	default:
+		reasons = append(reasons, notReadyReason{Code: notReadyStatus, Message: fmt.Sprintf("the task is %s", task.Status)})
+	}
+
+	var deps []models.Dependency
+	if err := database.Where("child_id = ? AND type IN ?", task.ID, []string{models.DepTypeBlocks, models.DepTypeFinishStart}).
+		Order("parent_id ASC").Find(&deps).Error; err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: database error: %w", err)
+	}
+	for _, d := range deps {
+		// Ready joins deleted blockers too, until 'gur cleanup' removes
+		// their dependencies
+		var blocker models.Task
+		if err := database.Unscoped().Where("id = ?", d.ParentID).First(&blocker).Error; err != nil {
+			continue
+		}
+		if blocker.DeletedAt.Valid && blocker.Status != models.StatusClosed {
+			reasons = append(reasons, notReadyReason{Code: notReadyBlocker, TaskID: blocker.ID,
+				Message: fmt.Sprintf("blocked by deleted task %s (run 'gur cleanup' to remove its dependencies)", blocker.ID)})
+			continue
+		}
+		if blocker.Status != models.StatusClosed {
+			reasons = append(reasons, notReadyReason{Code: notReadyBlocker, TaskID: blocker.ID,
+				Message: fmt.Sprintf("blocked by %s (%s): %s", blocker.ID, blocker.Status, blocker.Title)})
+			continue
+		}
+		if at, ok := d.ReadyAt(blocker.ClosedAt); ok && at.After(now) {
+			until := at
+			reasons = append(reasons, notReadyReason{Code: notReadyLag, TaskID: blocker.ID, Until: &until,
+				Message: fmt.Sprintf("not ready until %s (%s after %s closed)", at.Format(models.DateTimeShortFormat), formatLag(d.Lag), blocker.ID)})
+		}
+	}
+
+	if agent != nil {
+		matches, err := matchTasksToAgent(database, *agent, []models.Task{task})
+		if err != nil {
+			return nil, fmt.Errorf("failed to match the task to agent '%s': %w", agent.Name, err)
+		}
+		m := matches[0]
+		if m.OtherPrimary != "" {
+			reasons = append(reasons, notReadyReason{Code: notReadyClaimed, Agent: m.OtherPrimary,
+				Message: fmt.Sprintf("claimed by %s, its primary agent", m.OtherPrimary)})
+		}
+		if m.Unmatched {
+			reasons = append(reasons, notReadyReason{Code: notReadyUnmatched,
+				Message: fmt.Sprintf("its skills and labels don't match the capabilities of %s", agent.Name)})
+		}
+	}
+	return reasons, nil
+}
+
+func runWhyNotReady(cmd *cobra.Command, args []string) error {
+	task, err := db.GetTaskByID(args[0])
+	if err != nil {
+		return codedErrorf(ErrCodeNotFound, "task '%s' not found (use 'gur list' to see available tasks)", args[0])
+	}
+	database := db.GetDB()
+
+	var agent *models.Agent
+	if whyNotReadyAgent != "" {
+		agent = &models.Agent{}
+		if err := database.Where("name = ?", whyNotReadyAgent).First(agent).Error; err != nil {
+			return codedErrorf(ErrCodeNotFound, "agent '%s' not found (use 'gur agent list' to see registered agents)", whyNotReadyAgent)
+		}
+	}
+
+	reasons, err := explainNotReady(database, *task, agent, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		if reasons == nil {
+			reasons = []notReadyReason{}
+		}
+		OutputJSON(map[string]interface{}{"task_id": task.ID, "ready": len(reasons) == 0, "reasons": reasons})
+		return nil
+	}
+	if len(reasons) == 0 {
+		if agent != nil {
+			fmt.Printf("%s is ready for %s\n", task.ID, agent.Name)
+		} else {
+			fmt.Printf("%s is ready\n", task.ID)
+		}
+		return nil
+	}
+	fmt.Printf("%s is not ready:\n", task.ID)
+	for _, r := range reasons {
+		fmt.Printf("  - %s\n", r.Message)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+)
+
+func TestExplainNotReady(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	now := time.Now()
+	closedAt := now.Add(-30 * time.Minute)
+	database.Create(&models.Task{ID: "gur-3e000001", Title: "Schema", Status: models.StatusOpen})
+	database.Create(&models.Task{ID: "gur-3e000002", Title: "Deploy", Status: models.StatusClosed, ClosedAt: &closedAt})
+	database.Create(&models.Task{ID: "gur-3e000003", Title: "Migrate", Status: models.StatusBlocked, BlockReason: "waiting on DBA"})
+	database.Create(&models.Dependency{ParentID: "gur-3e000001", ChildID: "gur-3e000003", Type: models.DepTypeBlocks})
+	database.Create(&models.Dependency{ParentID: "gur-3e000002", ChildID: "gur-3e000003", Type: models.DepTypeFinishStart, Lag: 120})
+
+	task, _ := db.GetTaskByID("gur-3e000003")
+	reasons, err := explainNotReady(database, *task, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var codes []string
+	for _, r := range reasons {
+		codes = append(codes, r.Code)
+	}
+	want := []string{notReadyBlocked, notReadyBlocker, notReadyLag}
+	if len(codes) != len(want) {
+		t.Fatalf("reasons = %v, want %v", codes, want)
+	}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Fatalf("reasons = %v, want %v", codes, want)
+		}
+	}
+	if reasons[1].TaskID != "gur-3e000001" || reasons[2].Until == nil || !reasons[2].Until.Equal(closedAt.Add(2*time.Hour)) {
+		t.Errorf("reasons = %+v, want the open blocker and the lag's end", reasons)
+	}
+
+	// Once everything clears, the task is ready, as 'ready' agrees
+	database.Model(&models.Task{}).Where("id = ?", "gur-3e000001").Update("status", models.StatusClosed)
+	database.Model(&models.Task{}).Where("id = ?", "gur-3e000003").Update("status", models.StatusOpen)
+	task, _ = db.GetTaskByID("gur-3e000003")
+	if reasons, _ := explainNotReady(database, *task, nil, now.Add(3*time.Hour)); len(reasons) != 0 {
+		t.Errorf("reasons for a clear task = %+v, want none", reasons)
+	}
+}
+
+func TestExplainNotReadyForAgent(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	database := db.GetDB()
+	database.Create(&models.Task{ID: "gur-3e000011", Title: "Styles", Status: models.StatusOpen})
+	me := models.Agent{Name: "backend"}
+	other := models.Agent{Name: "frontend"}
+	database.Create(&me)
+	database.Create(&other)
+	database.Create(&models.TaskAgentLink{TaskID: "gur-3e000011", AgentID: other.ID, IsPrimary: true})
+
+	task, _ := db.GetTaskByID("gur-3e000011")
+	reasons, err := explainNotReady(database, *task, &me, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reasons) != 1 || reasons[0].Code != notReadyClaimed || reasons[0].Agent != "frontend" {
+		t.Errorf("reasons = %+v, want claimed by frontend", reasons)
+	}
+}
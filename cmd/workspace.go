@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"guardrails/internal/db"
+	"guardrails/internal/models"
+	"guardrails/internal/workspace"
+)
+
+var (
+	wsFile     string
+	wsName     string
+	wsStatus   string
+	wsPriority int
+)
+
+var wsCmd = &cobra.Command{
+	Use:     "ws",
+	Aliases: []string{"workspace"},
+	Short:   "Query several guardrails projects at once",
+	Long: `Aggregate tasks across multiple guardrails projects.
+
+A workspace file lists project roots (directories containing .guardrails/).
+Each project database is opened in turn and the results are merged.
+
+The workspace file defaults to ~/.guardrails/workspace.json and can be
+overridden with --file or the GUR_WORKSPACE environment variable.
+
+Examples:
+  gur ws add ~/src/api                 # Register a project
+  gur ws add . --name web              # Register the current project
+  gur ws list -s open -p 0             # All open P0s across projects
+  gur ws ready                         # Ready tasks across projects
+  gur ws stats                         # Combined statistics`,
+}
+
+var wsAddCmd = &cobra.Command{
+	Use:   "add <path>",
+	Short: "Add a project to the workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWsAdd,
+}
+
+var wsRemoveCmd = &cobra.Command{
+	Use:     "remove <name|path>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a project from the workspace",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runWsRemove,
+}
+
+var wsProjectsCmd = &cobra.Command{
+	Use:         "projects",
+	Short:       "List projects in the workspace",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runWsProjects,
+}
+
+var wsListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List tasks across all workspace projects",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runWsList,
+}
+
+var wsReadyCmd = &cobra.Command{
+	Use:         "ready",
+	Short:       "List ready tasks across all workspace projects",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runWsReady,
+}
+
+var wsStatsCmd = &cobra.Command{
+	Use:         "stats",
+	Short:       "Show combined statistics for all workspace projects",
+	Annotations: map[string]string{readOnlyAnnotation: "true"},
+	RunE:        runWsStats,
+}
+
+func init() {
+	rootCmd.AddCommand(wsCmd)
+	wsCmd.AddCommand(wsAddCmd)
+	wsCmd.AddCommand(wsRemoveCmd)
+	wsCmd.AddCommand(wsProjectsCmd)
+	wsCmd.AddCommand(wsListCmd)
+	wsCmd.AddCommand(wsReadyCmd)
+	wsCmd.AddCommand(wsStatsCmd)
+
+	wsCmd.PersistentFlags().StringVar(&wsFile, "file", "", "Workspace file (default ~/.guardrails/workspace.json)")
+	wsAddCmd.Flags().StringVar(&wsName, "name", "", "Project name (defaults to directory name)")
+	wsListCmd.Flags().StringVarP(&wsStatus, "status", "s", "", "Filter by status")
+	wsListCmd.Flags().IntVarP(&wsPriority, "priority", "p", -1, "Filter by priority")
+}
+
+// workspaceTask is a task annotated with the project it belongs to
+type workspaceTask struct {
+	Project string `json:"project"`
+	models.Task
+}
+
+func workspacePath() (string, error) {
+	if wsFile != "" {
+		return wsFile, nil
+	}
+	return workspace.DefaultPath()
+}
+
+func loadWorkspace() (*workspace.Workspace, string, error) {
+	path, err := workspacePath()
+	if err != nil {
+		return nil, "", err
+	}
+	ws, err := workspace.Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return ws, path, nil
+}
+
+// forEachProject opens each project database in turn and calls fn.
+// Projects that cannot be opened are reported on stderr and skipped.
+func forEachProject(ws *workspace.Workspace, fn func(p workspace.Project, database *gorm.DB) error) error {
+	if len(ws.Projects) == 0 {
+		return fmt.Errorf("workspace has no projects (add one with 'gur ws add <path>')")
+	}
+	for _, p := range ws.Projects {
+		if _, err := os.Stat(p.DBPath()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: no database at %s\n", p.Name, p.DBPath())
+			continue
+		}
+		database, err := db.OpenDB(p.DBPath())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", p.Name, err)
+			continue
+		}
+		err = fn(p, database)
+		db.CloseConn(database)
+		if err != nil {
+			return fmt.Errorf("project %s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+func runWsAdd(cmd *cobra.Command, args []string) error {
+	root, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid path '%s': %w", args[0], err)
+	}
+	if info, err := os.Stat(filepath.Join(root, db.GuardrailsDir)); err != nil || !info.IsDir() {
+		return fmt.Errorf("cannot add project: '%s' is not a guardrails project (no %s/ found)", root, db.GuardrailsDir)
+	}
+
+	name := wsName
+	if name == "" {
+		name = filepath.Base(root)
+	}
+
+	ws, path, err := loadWorkspace()
+	if err != nil {
+		return err
+	}
+	if err := ws.Add(workspace.Project{Name: name, Path: root}); err != nil {
+		return fmt.Errorf("cannot add project: %w", err)
+	}
+	if err := ws.Save(path); err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "name": name, "path": root, "workspace": path})
+	} else {
+		fmt.Printf("Added %s (%s) to workspace\n", name, root)
+	}
+	return nil
+}
+
+func runWsRemove(cmd *cobra.Command, args []string) error {
+	ws, path, err := loadWorkspace()
+	if err != nil {
+		return err
+	}
+	if !ws.Remove(args[0]) {
+		return fmt.Errorf("cannot remove project: '%s' not in workspace (use 'gur ws projects' to see projects)", args[0])
+	}
+	if err := ws.Save(path); err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"success": true, "removed": args[0]})
+	} else {
+		fmt.Printf("Removed %s from workspace\n", args[0])
+	}
+	return nil
+}
+
+func runWsProjects(cmd *cobra.Command, args []string) error {
+	ws, path, err := loadWorkspace()
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"workspace": path, "count": len(ws.Projects), "projects": ws.Projects})
+		return nil
+	}
+
+	if len(ws.Projects) == 0 {
+		fmt.Printf("No projects in workspace %s\n", path)
+		return nil
+	}
+	fmt.Printf("Workspace projects (%d):\n", len(ws.Projects))
+	for _, p := range ws.Projects {
+		fmt.Printf("  %s - %s\n", p.Name, p.Path)
+	}
+	return nil
+}
+
+func runWsList(cmd *cobra.Command, args []string) error {
+	ws, _, err := loadWorkspace()
+	if err != nil {
+		return err
+	}
+
+	var all []workspaceTask
+	err = forEachProject(ws, func(p workspace.Project, database *gorm.DB) error {
+		query := database.Order("priority ASC, created_at DESC")
+		if wsStatus != models.StatusArchived {
+			query = query.Where("status != ?", models.StatusArchived)
+		}
+		if wsStatus != "" {
+			query = query.Where("status = ?", wsStatus)
+		}
+		if wsPriority >= 0 {
+			query = query.Where("priority = ?", wsPriority)
+		}
+		var tasks []models.Task
+		if err := query.Find(&tasks).Error; err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			all = append(all, workspaceTask{Project: p.Name, Task: t})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return printWorkspaceTasks(all, "", "No tasks found")
+}
+
+func runWsReady(cmd *cobra.Command, args []string) error {
+	ws, _, err := loadWorkspace()
+	if err != nil {
+		return err
+	}
+
+	var all []workspaceTask
+	err = forEachProject(ws, func(p workspace.Project, database *gorm.DB) error {
+		tasks, err := findReadyTasks(database)
+		if err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			all = append(all, workspaceTask{Project: p.Name, Task: t})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return printWorkspaceTasks(all, "Ready tasks", "No ready tasks")
+}
+
+func printWorkspaceTasks(tasks []workspaceTask, title, empty string) error {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority < tasks[j].Priority
+		}
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+
+	if IsJSONOutput() {
+		OutputJSON(map[string]interface{}{"count": len(tasks), "tasks": tasks})
+		return nil
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println(empty)
+		return nil
+	}
+
+	if title != "" {
+		fmt.Printf("%s (%d):\n", title, len(tasks))
+	}
+	for _, t := range tasks {
+		fmt.Printf("%s: [%s] P%d %s - %s\n", t.Project, t.ID, t.Priority, t.Status, t.Title)
+	}
+	return nil
+}
+
+func runWsStats(cmd *cobra.Command, args []string) error {
+	ws, _, err := loadWorkspace()
+	if err != nil {
+		return err
+	}
+
+	var total taskStats
+	perProject := make(map[string]interface{})
+	var names []string
+	err = forEachProject(ws, func(p workspace.Project, database *gorm.DB) error {
+		stats := collectTaskStats(database)
+		total.Add(stats)
+		perProject[p.Name] = stats.ToMap()
+		names = append(names, p.Name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		result := total.ToMap()
+		result["projects"] = perProject
+		OutputJSON(result)
+		return nil
+	}
+
+	fmt.Printf("Workspace: %d project(s)\n\n", len(names))
+	total.Print()
+	return nil
+}
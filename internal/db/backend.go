@@ -0,0 +1,154 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	// BackendSQLite stores the project in a database file in GuardrailsDir
+	BackendSQLite = "sqlite"
+	// BackendPostgres stores the project in a shared Postgres database
+	BackendPostgres = "postgres"
+	// BackendFileName names the file in the guardrails directory selecting
+	// a backend other than SQLite
+	BackendFileName = "backend.json"
+	// EnvDSN overrides the stored connection string, keeping passwords out
+	// of BackendFileName
+	EnvDSN = "GUR_DSN"
+)
+
+// Backend selects where a project's data is stored
+type Backend struct {
+	Name string `json:"backend"`
+	DSN  string `json:"dsn,omitempty"` // Connection string; unused by SQLite
+}
+
+// dialectors opens a connection for each backend compiled in. Postgres
+// registers itself when built with -tags postgres.
+var dialectors = map[string]func(dsn string) gorm.Dialector{
+	BackendSQLite: sqlite.Open,
+}
+
+// RegisterBackend makes a backend available to 'gur init --backend'
+func RegisterBackend(name string, open func(dsn string) gorm.Dialector) {
+	dialectors[name] = open
+}
+
+// Backends returns the names of the backends compiled in
+func Backends() []string {
+	names := make([]string, 0, len(dialectors))
+	for name := range dialectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateBackend checks that a backend is known and compiled in
+func ValidateBackend(name string) error {
+	if _, ok := dialectors[name]; ok {
+		return nil
+	}
+	if name == BackendPostgres {
+		return fmt.Errorf("this gur was built without Postgres support: rebuild it with 'go build -tags postgres'")
+	}
+	return fmt.Errorf("unknown backend '%s': must be one of %v", name, Backends())
+}
+
+// LoadBackend returns the backend of the project at root, or nil for the
+// default SQLite database. GUR_DSN overrides the stored connection string.
+func LoadBackend(root string) (*Backend, error) {
+	data, err := os.ReadFile(filepath.Join(root, GuardrailsDir, BackendFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", BackendFileName, err)
+	}
+	var b Backend
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", BackendFileName, err)
+	}
+	if b.Name == "" || b.Name == BackendSQLite {
+		return nil, nil
+	}
+	if dsn := os.Getenv(EnvDSN); dsn != "" {
+		b.DSN = dsn
+	}
+	return &b, nil
+}
+
+// SaveBackend selects the backend of the project at root. The file may hold
+// a password, so only the owner can read it.
+func SaveBackend(root string, b Backend) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, GuardrailsDir, BackendFileName), append(data, '\n'), 0600)
+}
+
+// OpenBackend connects to a backend other than a SQLite file and runs
+// migrations
+func OpenBackend(b Backend) (*gorm.DB, error) {
+	if err := ValidateBackend(b.Name); err != nil {
+		return nil, err
+	}
+	if b.DSN == "" {
+		return nil, fmt.Errorf("no connection string for the %s backend: set %s or run 'gur init --backend %s --dsn ...'", b.Name, EnvDSN, b.Name)
+	}
+	database, err := gorm.Open(dialectors[b.Name](b.DSN), gormConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s database %s: %w", b.Name, RedactDSN(b.DSN), err)
+	}
+	if err := runMigrations(database); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return database, nil
+}
+
+// InitBackend connects to a backend and makes it the current connection
+func InitBackend(b Backend) (*gorm.DB, error) {
+	database, err := OpenBackend(b)
+	if err != nil {
+		return nil, err
+	}
+	dbMu.Lock()
+	db = database
+	dbMu.Unlock()
+	return database, nil
+}
+
+var dsnPasswordRegex = regexp.MustCompile(`(?i)(password\s*=\s*)('[^']*'|\S+)`)
+
+// RedactDSN hides the password in a connection string, in URL or
+// key=value form
+func RedactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" && u.Host != "" {
+		return u.Redacted()
+	}
+	return dsnPasswordRegex.ReplaceAllString(dsn, "${1}xxxxx")
+}
+
+// DescribeDatabase names the current project's database for display: its
+// file path, or its backend and redacted connection string
+func DescribeDatabase() string {
+	if dbPathOverride == "" {
+		if root, err := FindProjectRoot(); err == nil {
+			if b, err := LoadBackend(root); err == nil && b != nil {
+				return b.Name + ": " + RedactDSN(b.DSN)
+			}
+		}
+	}
+	path, _ := GetDefaultDBPath()
+	return path
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackendFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, GuardrailsDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvDSN, "")
+
+	if b, err := LoadBackend(root); err != nil || b != nil {
+		t.Fatalf("LoadBackend() without a file = %v, %v, want nil", b, err)
+	}
+
+	if err := SaveBackend(root, Backend{Name: BackendPostgres, DSN: "postgres://gur@db/backlog"}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(root, GuardrailsDir, BackendFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("backend file mode = %v, want 0600", info.Mode().Perm())
+	}
+	b, err := LoadBackend(root)
+	if err != nil || b == nil || b.Name != BackendPostgres || b.DSN != "postgres://gur@db/backlog" {
+		t.Fatalf("LoadBackend() = %+v, %v", b, err)
+	}
+
+	t.Setenv(EnvDSN, "postgres://other@db/backlog")
+	if b, _ := LoadBackend(root); b.DSN != "postgres://other@db/backlog" {
+		t.Errorf("LoadBackend() DSN = %q, want the %s override", b.DSN, EnvDSN)
+	}
+
+	if err := SaveBackend(root, Backend{Name: BackendSQLite}); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := LoadBackend(root); err != nil || b != nil {
+		t.Errorf("LoadBackend() for sqlite = %v, %v, want nil", b, err)
+	}
+}
+
+func TestValidateBackend(t *testing.T) {
+	if err := ValidateBackend(BackendSQLite); err != nil {
+		t.Errorf("ValidateBackend(sqlite) = %v", err)
+	}
+	if err := ValidateBackend("mysql"); err == nil {
+		t.Error("ValidateBackend(mysql) should fail")
+	}
+	if _, ok := dialectors[BackendPostgres]; !ok {
+		if err := ValidateBackend(BackendPostgres); err == nil {
+			t.Error("ValidateBackend(postgres) should fail without the postgres build tag")
+		}
+	}
+}
+
+func TestRedactDSN(t *testing.T) {
+	tests := []struct {
+		dsn, want string
+	}{
+		{"postgres://gur:secret@db:5432/backlog", "postgres://gur:xxxxx@db:5432/backlog"},
+		{"postgres://gur@db/backlog", "postgres://gur@db/backlog"},
+		{"host=db user=gur password=secret dbname=backlog", "host=db user=gur password=xxxxx dbname=backlog"},
+		{"host=db password='s e cret' dbname=backlog", "host=db password=xxxxx dbname=backlog"},
+	}
+	for _, tt := range tests {
+		if got := RedactDSN(tt.dsn); got != tt.want {
+			t.Errorf("RedactDSN(%q) = %q, want %q", tt.dsn, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -28,8 +29,25 @@ var (
 	dbOnce sync.Once
 )
 
+// ErrNotInitialized is returned when the project has no database yet
+var ErrNotInitialized = errors.New("guardrails not initialized. Run 'gur init' first")
+
 // InitDB initializes the database connection and runs migrations
 func InitDB(dbPath string) (*gorm.DB, error) {
+	database, err := OpenDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dbMu.Lock()
+	db = database
+	dbMu.Unlock()
+	return database, nil
+}
+
+// OpenDB opens a database and runs migrations without making it the current connection.
+// Used when reading several projects side by side (e.g., workspaces).
+func OpenDB(dbPath string) (*gorm.DB, error) {
 	// Ensure the directory exists
 	dir := filepath.Dir(dbPath)
 	if dir != "." && dir != "" {
@@ -38,13 +56,8 @@ func InitDB(dbPath string) (*gorm.DB, error) {
 		}
 	}
 
-	// Configure GORM with silent logger for production
-	config := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	}
-
 	// Open SQLite database
-	database, err := gorm.Open(sqlite.Open(dbPath), config)
+	database, err := gorm.Open(sqlite.Open(dbPath), gormConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -81,14 +94,20 @@ func InitDB(dbPath string) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	dbMu.Lock()
-	db = database
-	dbMu.Unlock()
 	return database, nil
 }
 
-// runMigrations runs all database migrations
+// gormConfig configures GORM with a silent logger for production
+func gormConfig() *gorm.Config {
+	return &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	}
+}
+
+// runMigrations runs all database migrations. They must work on every
+// backend: use GORM's schema API, and raw SQL only where it is portable.
 func runMigrations(database *gorm.DB) error {
+	indexReferences := !database.Migrator().HasTable(&models.Reference{})
 	err := database.AutoMigrate(
 		&models.Task{},
 		&models.Dependency{},
@@ -96,13 +115,28 @@ func runMigrations(database *gorm.DB) error {
 		&models.Gate{},
 		&models.GateTaskLink{},
 		&models.GateRun{},
+		&models.SharedGate{},
 		&models.Template{},
 		&models.TaskHistory{},
 		&models.GitHubIssueLink{},
+		&models.GitHubMarkerCache{},
+		&models.GitHubPendingSync{},
+		&models.GitHubOutboxEntry{},
+		&models.Event{},
 		&models.Skill{},
 		&models.Agent{},
 		&models.TaskSkillLink{},
 		&models.TaskAgentLink{},
+		&models.CustomField{},
+		&models.TaskFieldValue{},
+		&models.NoteEntry{},
+		&models.AcceptanceCriterion{},
+		&models.Artifact{},
+		&models.TimeEntry{},
+		&models.Person{},
+		&models.Release{},
+		&models.Reference{},
+		&models.APIToken{},
 	)
 	if err != nil {
 		return err
@@ -117,6 +151,53 @@ func runMigrations(database *gorm.DB) error {
 		return fmt.Errorf("failed to backfill synced field: %w", err)
 	}
 
+	if err := backfillNoteEntries(database); err != nil {
+		return fmt.Errorf("failed to backfill note entries: %w", err)
+	}
+
+	if indexReferences {
+		if err := backfillReferences(database); err != nil {
+			return fmt.Errorf("failed to index task references: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// backfillNoteEntries splits existing Notes text into typed entries the
+// first time the note_entries table is used
+func backfillNoteEntries(database *gorm.DB) error {
+	var count int64
+	if err := database.Model(&models.NoteEntry{}).Count(&count).Error; err != nil || count > 0 {
+		return err
+	}
+
+	var tasks []models.Task
+	if err := database.Unscoped().Select("id", "notes").Where("notes != ''").Find(&tasks).Error; err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		if entries := models.ParseNotes(t.ID, t.Notes); len(entries) > 0 {
+			if err := database.Create(&entries).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// backfillReferences indexes the references in existing tasks' text when
+// the references table is created
+func backfillReferences(database *gorm.DB) error {
+	var tasks []models.Task
+	if err := database.Select("id", "description", "notes").Where("description != '' OR notes != ''").Find(&tasks).Error; err != nil {
+		return err
+	}
+	for i := range tasks {
+		if err := models.IndexReferences(database, &tasks[i]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -153,6 +234,18 @@ func CloseDB() error {
 	return err
 }
 
+// CloseConn closes a connection obtained from OpenDB
+func CloseConn(database *gorm.DB) error {
+	if database == nil {
+		return nil
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	return sqlDB.Close()
+}
+
 // FindProjectRoot searches for a guardrails project root
 func FindProjectRoot() (string, error) {
 	cwd, err := os.Getwd()
@@ -175,8 +268,12 @@ func FindProjectRoot() (string, error) {
 	}
 }
 
-// GetDefaultDBPath returns the default database path for the current project
+// GetDefaultDBPath returns the database path for the current project: the
+// --db override if set, otherwise the active environment's database
 func GetDefaultDBPath() (string, error) {
+	if dbPathOverride != "" {
+		return dbPathOverride, nil
+	}
 	root, err := FindProjectRoot()
 	if err != nil {
 		cwd, cwdErr := os.Getwd()
@@ -185,7 +282,7 @@ func GetDefaultDBPath() (string, error) {
 		}
 		return filepath.Join(cwd, GuardrailsDir, DBFileName), nil
 	}
-	return filepath.Join(root, GuardrailsDir, DBFileName), nil
+	return EnvDBPath(root, CurrentEnv(root)), nil
 }
 
 // EnsureInitialized checks if the database is initialized
@@ -201,13 +298,26 @@ func EnsureInitialized() error {
 
 	var initErr error
 	dbOnce.Do(func() {
+		if dbPathOverride == "" {
+			if root, err := FindProjectRoot(); err == nil {
+				b, err := LoadBackend(root)
+				if err != nil {
+					initErr = err
+					return
+				}
+				if b != nil {
+					_, initErr = InitBackend(*b)
+					return
+				}
+			}
+		}
 		dbPath, err := GetDefaultDBPath()
 		if err != nil {
 			initErr = err
 			return
 		}
 		if _, err := os.Stat(dbPath); os.IsNotExist(err) {
-			initErr = fmt.Errorf("guardrails not initialized. Run 'gur init' first")
+			initErr = ErrNotInitialized
 			return
 		}
 		_, initErr = InitDB(dbPath)
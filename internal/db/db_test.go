@@ -167,3 +167,38 @@ func TestCloseDB(t *testing.T) {
 		t.Errorf("CloseDB() second call error: %v", err)
 	}
 }
+
+func TestBackfillNoteEntries(t *testing.T) {
+	cleanup := setupTestDB(t)
+	defer cleanup()
+
+	db := GetDB()
+	task := &models.Task{
+		ID:     "gur-notes001",
+		Title:  "Has notes",
+		Status: models.StatusOpen,
+		Notes:  "[2024-01-01 10:00:00] first\n[2024-01-02 10:00:00] decision: keep it\n",
+	}
+	if err := db.Create(task).Error; err != nil {
+		t.Fatalf("Failed to create test task: %v", err)
+	}
+
+	if err := backfillNoteEntries(db); err != nil {
+		t.Fatalf("backfillNoteEntries() error: %v", err)
+	}
+	var entries []models.NoteEntry
+	db.Where("task_id = ?", task.ID).Order("id ASC").Find(&entries)
+	if len(entries) != 2 || entries[1].Kind != models.NoteKindDecision {
+		t.Fatalf("backfilled entries = %+v", entries)
+	}
+
+	// Runs only once: a second call must not duplicate entries
+	if err := backfillNoteEntries(db); err != nil {
+		t.Fatalf("backfillNoteEntries() error: %v", err)
+	}
+	var count int64
+	db.Model(&models.NoteEntry{}).Count(&count)
+	if count != 2 {
+		t.Errorf("expected 2 entries after second backfill, got %d", count)
+	}
+}
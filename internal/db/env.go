@@ -0,0 +1,98 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultEnv is the environment stored in DBFileName
+	DefaultEnv = "default"
+	// EnvFileName names the file in the guardrails directory holding the active environment
+	EnvFileName = "env"
+	// envDBExt is the extension of environment database files
+	envDBExt = ".sqlite"
+)
+
+var envNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// dbPathOverride is set by --db and takes precedence over environments
+var dbPathOverride string
+
+// SetDBPath makes every later GetDefaultDBPath return path ("" to clear)
+func SetDBPath(path string) {
+	dbPathOverride = path
+}
+
+// DBPathOverride returns the path set with SetDBPath, if any
+func DBPathOverride() string {
+	return dbPathOverride
+}
+
+// ValidateEnvName returns an error for names that can't be used as environments
+func ValidateEnvName(name string) error {
+	if !envNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid environment name '%s': use lowercase letters, digits, '-' and '_'", name)
+	}
+	if name+envDBExt == DBFileName {
+		return fmt.Errorf("invalid environment name '%s': reserved for the default database", name)
+	}
+	return nil
+}
+
+// EnvDBPath returns the database file for an environment of the project at root
+func EnvDBPath(root, name string) string {
+	if name == "" || name == DefaultEnv {
+		return filepath.Join(root, GuardrailsDir, DBFileName)
+	}
+	return filepath.Join(root, GuardrailsDir, name+envDBExt)
+}
+
+// CurrentEnv returns the active environment of the project at root
+func CurrentEnv(root string) string {
+	data, err := os.ReadFile(filepath.Join(root, GuardrailsDir, EnvFileName))
+	if err != nil {
+		return DefaultEnv
+	}
+	if name := strings.TrimSpace(string(data)); ValidateEnvName(name) == nil {
+		return name
+	}
+	return DefaultEnv
+}
+
+// SetCurrentEnv makes name the active environment of the project at root
+func SetCurrentEnv(root, name string) error {
+	path := filepath.Join(root, GuardrailsDir, EnvFileName)
+	if name == DefaultEnv {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(name+"\n"), 0644)
+}
+
+// ListEnvs returns the environments that have a database in the project at root
+func ListEnvs(root string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(root, GuardrailsDir, "*"+envDBExt))
+	if err != nil {
+		return nil, err
+	}
+	var envs []string
+	for _, m := range matches {
+		base := filepath.Base(m)
+		if base == DBFileName {
+			envs = append(envs, DefaultEnv)
+			continue
+		}
+		if name := strings.TrimSuffix(base, envDBExt); ValidateEnvName(name) == nil && name != DefaultEnv {
+			envs = append(envs, name)
+		}
+	}
+	sort.Strings(envs)
+	return envs, nil
+}
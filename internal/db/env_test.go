@@ -0,0 +1,60 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEnvironments(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, GuardrailsDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{DBFileName, "staging.sqlite", "db.sqlite-wal", "Bad.sqlite"} {
+		os.WriteFile(filepath.Join(root, GuardrailsDir, name), nil, 0644)
+	}
+
+	if got := CurrentEnv(root); got != DefaultEnv {
+		t.Errorf("CurrentEnv = %q, want %q", got, DefaultEnv)
+	}
+	if err := SetCurrentEnv(root, "staging"); err != nil {
+		t.Fatalf("SetCurrentEnv: %v", err)
+	}
+	if got := CurrentEnv(root); got != "staging" {
+		t.Errorf("CurrentEnv = %q, want staging", got)
+	}
+	if got, want := EnvDBPath(root, "staging"), filepath.Join(root, GuardrailsDir, "staging.sqlite"); got != want {
+		t.Errorf("EnvDBPath = %q, want %q", got, want)
+	}
+	if err := SetCurrentEnv(root, DefaultEnv); err != nil {
+		t.Fatalf("SetCurrentEnv(default): %v", err)
+	}
+	if got := CurrentEnv(root); got != DefaultEnv {
+		t.Errorf("CurrentEnv after reset = %q", got)
+	}
+
+	envs, err := ListEnvs(root)
+	if err != nil {
+		t.Fatalf("ListEnvs: %v", err)
+	}
+	if want := []string{DefaultEnv, "staging"}; !reflect.DeepEqual(envs, want) {
+		t.Errorf("ListEnvs = %v, want %v", envs, want)
+	}
+
+	for _, bad := range []string{"", "Prod", "db", "../x"} {
+		if ValidateEnvName(bad) == nil {
+			t.Errorf("ValidateEnvName(%q) should fail", bad)
+		}
+	}
+}
+
+func TestDBPathOverride(t *testing.T) {
+	SetDBPath("/tmp/override.sqlite")
+	defer SetDBPath("")
+
+	if got, _ := GetDefaultDBPath(); got != "/tmp/override.sqlite" {
+		t.Errorf("GetDefaultDBPath = %q, want override", got)
+	}
+}
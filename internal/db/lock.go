@@ -0,0 +1,122 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// LockFileName is the advisory lock taken by destructive commands, in the
+// guardrails directory
+const LockFileName = "lock"
+
+// LockInfo describes the command holding the workspace lock
+type LockInfo struct {
+	Command   string    `json:"command"`
+	PID       int       `json:"pid"`
+	Host      string    `json:"host"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// LockedError reports that another command holds the workspace lock
+type LockedError struct {
+	Path string
+	Info LockInfo
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("'%s' is running (pid %d on %s, started %s ago); try again when it finishes, or remove %s if it is stale",
+		e.Info.Command, e.Info.PID, e.Info.Host, time.Since(e.Info.StartedAt).Round(time.Second), e.Path)
+}
+
+// LockPath returns the lock file of the project at root
+func LockPath(root string) string {
+	return filepath.Join(root, GuardrailsDir, LockFileName)
+}
+
+// AcquireLock takes the exclusive workspace lock for command. A lock left
+// behind by a process that is gone is replaced. The returned func releases
+// the lock.
+func AcquireLock(root, command string) (func(), error) {
+	path := LockPath(root)
+	host, _ := os.Hostname()
+	info := LockInfo{Command: command, PID: os.Getpid(), Host: host, StartedAt: time.Now().UTC()}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, werr := f.Write(data)
+			if cerr := f.Close(); werr == nil {
+				werr = cerr
+			}
+			if werr != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file: %w", werr)
+			}
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+		if held := readLock(path); held != nil && !held.stale() {
+			return nil, &LockedError{Path: path, Info: *held}
+		}
+		// Stale or unreadable: take it over
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove stale lock file: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("failed to acquire lock %s: it keeps being recreated", path)
+}
+
+// CheckLock returns a *LockedError if another live process holds the
+// workspace lock of the project at root
+func CheckLock(root string) error {
+	path := LockPath(root)
+	held := readLock(path)
+	if held == nil || held.stale() || held.ownedBySelf() {
+		return nil
+	}
+	return &LockedError{Path: path, Info: *held}
+}
+
+// readLock loads a lock file, or returns nil if there is none or it can't be
+// parsed
+func readLock(path string) *LockInfo {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var info LockInfo
+	if json.Unmarshal(data, &info) != nil || info.PID <= 0 {
+		return nil
+	}
+	return &info
+}
+
+func (l *LockInfo) ownedBySelf() bool {
+	host, _ := os.Hostname()
+	return l.Host == host && l.PID == os.Getpid()
+}
+
+// stale reports whether the lock holder is known to be gone. Locks taken on
+// another host can't be checked and are assumed live.
+func (l *LockInfo) stale() bool {
+	if host, _ := os.Hostname(); l.Host != host {
+		return false
+	}
+	p, err := os.FindProcess(l.PID)
+	if err != nil {
+		return true
+	}
+	err = p.Signal(syscall.Signal(0))
+	return errors.Is(err, os.ErrProcessDone) || errors.Is(err, syscall.ESRCH)
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestLock(t *testing.T, root string, info LockInfo) {
+	t.Helper()
+	data, _ := json.Marshal(info)
+	if err := os.WriteFile(LockPath(root), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorkspaceLock(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, GuardrailsDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := AcquireLock(root, "gur cleanup")
+	if err != nil {
+		t.Fatalf("AcquireLock() error: %v", err)
+	}
+	var locked *LockedError
+	if _, err := AcquireLock(root, "gur init --force"); !errors.As(err, &locked) || locked.Info.Command != "gur cleanup" {
+		t.Errorf("second AcquireLock() error = %v, want held by gur cleanup", err)
+	}
+	if err := CheckLock(root); err != nil {
+		t.Errorf("CheckLock() by the holder = %v, want nil", err)
+	}
+	release()
+	if _, err := os.Stat(LockPath(root)); !os.IsNotExist(err) {
+		t.Errorf("lock file still exists after release: %v", err)
+	}
+
+	// Another live process on this host holds the lock
+	host, _ := os.Hostname()
+	writeTestLock(t, root, LockInfo{Command: "gur cleanup", PID: os.Getppid(), Host: host, StartedAt: time.Now()})
+	if err := CheckLock(root); !errors.As(err, &locked) {
+		t.Errorf("CheckLock() = %v, want LockedError", err)
+	}
+
+	// A lock left behind by a process that is gone is taken over
+	writeTestLock(t, root, LockInfo{Command: "gur cleanup", PID: 1 << 30, Host: host, StartedAt: time.Now()})
+	if err := CheckLock(root); err != nil {
+		t.Errorf("CheckLock() with a stale lock = %v, want nil", err)
+	}
+	release, err = AcquireLock(root, "gur cleanup")
+	if err != nil {
+		t.Fatalf("AcquireLock() over a stale lock error: %v", err)
+	}
+	release()
+}
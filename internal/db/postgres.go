@@ -0,0 +1,11 @@
+//go:build postgres
+
+package db
+
+import "gorm.io/driver/postgres"
+
+// Postgres support is only compiled in with -tags postgres, keeping the
+// driver out of the default build
+func init() {
+	RegisterBackend(BackendPostgres, postgres.Open)
+}
@@ -0,0 +1,58 @@
+//go:build postgres
+
+package db
+
+import (
+	"os"
+	"testing"
+
+	"guardrails/internal/models"
+)
+
+// EnvTestPostgresDSN points the Postgres tests at a scratch database; they
+// are skipped without it
+const EnvTestPostgresDSN = "GUR_TEST_POSTGRES_DSN"
+
+func TestPostgresBackendRegistered(t *testing.T) {
+	if err := ValidateBackend(BackendPostgres); err != nil {
+		t.Fatalf("ValidateBackend(postgres) = %v, want it compiled in", err)
+	}
+}
+
+func TestPostgresBackendRoundTrip(t *testing.T) {
+	dsn := os.Getenv(EnvTestPostgresDSN)
+	if dsn == "" {
+		t.Skipf("set %s to run against a Postgres database", EnvTestPostgresDSN)
+	}
+	database, err := OpenBackend(Backend{Name: BackendPostgres, DSN: dsn})
+	if err != nil {
+		t.Fatalf("OpenBackend() error: %v", err)
+	}
+	defer func() {
+		database.Unscoped().Where("id = ?", "gur-9c000001").Delete(&models.Task{})
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+
+	task := &models.Task{ID: "gur-9c000001", Title: "Shared backlog", Status: models.StatusOpen, Labels: models.StringSlice{"infra"}}
+	if err := database.Create(task).Error; err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	var got models.Task
+	if err := database.First(&got, "id = ?", task.ID).Error; err != nil {
+		t.Fatalf("read task back: %v", err)
+	}
+	if got.Title != task.Title || len(got.Labels) != 1 || got.Labels[0] != "infra" {
+		t.Errorf("task = %+v, want the one created", got)
+	}
+
+	// Migrations run again when a later checkout joins the backlog
+	again, err := OpenBackend(Backend{Name: BackendPostgres, DSN: dsn})
+	if err != nil {
+		t.Fatalf("OpenBackend() on an existing schema: %v", err)
+	}
+	if sqlDB, err := again.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
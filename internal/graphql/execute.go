@@ -0,0 +1,361 @@
+// Package graphql is a small, read-only GraphQL executor: it parses query
+// documents (fields, aliases, arguments, variables, fragments) and resolves
+// them against a schema of Go resolvers. Mutations, subscriptions, directives
+// and introspection are not supported.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Object is a GraphQL object type
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// Field is a field on an object type. Type is set for object-valued fields
+// (single or list); scalar fields leave it nil. Without a Resolve function
+// the value is read from the source struct field with the matching json tag.
+type Field struct {
+	Type    *Object
+	Resolve func(p ResolveParams) (interface{}, error)
+}
+
+// ResolveParams are passed to a field resolver
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}
+	Args    Args
+}
+
+// Args holds a field's resolved argument values
+type Args map[string]interface{}
+
+// String returns a string argument, or "" if absent
+func (a Args) String(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+// Int returns an integer argument, or def if absent
+func (a Args) Int(name string, def int) int {
+	switch v := a[name].(type) {
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// Has reports whether the argument was given and not null
+func (a Args) Has(name string) bool {
+	return a[name] != nil
+}
+
+// Schema is the set of root types
+type Schema struct {
+	Query *Object
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Error is a GraphQL error with the path of the failing field
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Response is a GraphQL response
+type Response struct {
+	Data   interface{} `json:"data"`
+	Errors []Error     `json:"errors,omitempty"`
+}
+
+// Execute runs a request against the schema
+func Execute(ctx context.Context, schema *Schema, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	if op.Type != "query" {
+		return &Response{Errors: []Error{{Message: fmt.Sprintf("%s operations are not supported: the API is read-only", op.Type)}}}
+	}
+
+	vars := make(map[string]interface{})
+	for _, def := range op.Variables {
+		if v, ok := req.Variables[def.Name]; ok {
+			vars[def.Name] = v
+		} else if def.HasDefault {
+			vars[def.Name] = resolveValue(def.Default, nil)
+		}
+	}
+
+	e := &executor{ctx: ctx, doc: doc, vars: vars}
+	data := e.object(schema.Query, nil, op.Selections, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the document has several operations")
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation '%s'", name)
+}
+
+type executor struct {
+	ctx    context.Context
+	doc    *Document
+	vars   map[string]interface{}
+	errors []Error
+}
+
+func (e *executor) fail(path []interface{}, format string, args ...interface{}) {
+	e.errors = append(e.errors, Error{Message: fmt.Sprintf(format, args...), Path: path})
+}
+
+// collect flattens fragments into the fields to resolve on typ
+func (e *executor) collect(typ *Object, sels []Selection, out *[]Selection, seen map[string]bool) {
+	for _, sel := range sels {
+		switch {
+		case sel.Inline != nil:
+			if sel.Inline.On == "" || sel.Inline.On == typ.Name {
+				e.collect(typ, sel.Inline.Selections, out, seen)
+			}
+		case sel.FragmentName != "":
+			frag, ok := e.doc.Fragments[sel.FragmentName]
+			if !ok || seen[sel.FragmentName] || frag.On != typ.Name {
+				continue
+			}
+			seen[sel.FragmentName] = true
+			e.collect(typ, frag.Selections, out, seen)
+		default:
+			*out = append(*out, sel)
+		}
+	}
+}
+
+// object resolves the selections on a source value of type typ
+func (e *executor) object(typ *Object, source interface{}, sels []Selection, path []interface{}) *OrderedMap {
+	var fields []Selection
+	e.collect(typ, sels, &fields, make(map[string]bool))
+
+	result := &OrderedMap{}
+	for _, sel := range fields {
+		key := sel.ResponseKey()
+		fieldPath := appendPath(path, key)
+		if result.Has(key) {
+			continue
+		}
+		if sel.Name == "__typename" {
+			result.Set(key, typ.Name)
+			continue
+		}
+		field, ok := typ.Fields[sel.Name]
+		if !ok {
+			e.fail(fieldPath, "cannot query field '%s' on type '%s'", sel.Name, typ.Name)
+			result.Set(key, nil)
+			continue
+		}
+		if field.Type == nil && len(sel.Selections) > 0 {
+			e.fail(fieldPath, "field '%s' is a scalar and cannot have a selection set", sel.Name)
+			result.Set(key, nil)
+			continue
+		}
+		if field.Type != nil && len(sel.Selections) == 0 {
+			e.fail(fieldPath, "field '%s' of type '%s' must have a selection set", sel.Name, field.Type.Name)
+			result.Set(key, nil)
+			continue
+		}
+
+		args := make(Args, len(sel.Arguments))
+		for _, a := range sel.Arguments {
+			args[a.Name] = resolveValue(a.Value, e.vars)
+		}
+
+		var value interface{}
+		var err error
+		if field.Resolve != nil {
+			value, err = field.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args})
+		} else {
+			value = structField(source, sel.Name)
+		}
+		if err != nil {
+			e.fail(fieldPath, "%s", err.Error())
+			result.Set(key, nil)
+			continue
+		}
+		result.Set(key, e.complete(field, value, sel.Selections, fieldPath))
+	}
+	return result
+}
+
+// complete resolves sub-selections of an object-valued field
+func (e *executor) complete(field *Field, value interface{}, sels []Selection, path []interface{}) interface{} {
+	if field.Type == nil || isNil(value) {
+		return value
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice {
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			list[i] = e.object(field.Type, rv.Index(i).Interface(), sels, appendPath(path, i))
+		}
+		return list
+	}
+	return e.object(field.Type, value, sels, path)
+}
+
+// resolveValue converts a document value to plain Go values, substituting
+// variables and turning enums into strings
+func resolveValue(v Value, vars map[string]interface{}) interface{} {
+	switch val := v.(type) {
+	case Variable:
+		return vars[string(val)]
+	case Enum:
+		return string(val)
+	case []Value:
+		list := make([]interface{}, len(val))
+		for i, item := range val {
+			list[i] = resolveValue(item, vars)
+		}
+		return list
+	case map[string]Value:
+		obj := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			obj[k] = resolveValue(item, vars)
+		}
+		return obj
+	}
+	return v
+}
+
+// appendPath copies path so sibling fields never share a backing array
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	return append(append(make([]interface{}, 0, len(path)+1), path...), elem)
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// structField reads the field of a struct (or pointer to one) whose json
+// tag name matches name, including fields of embedded structs
+func structField(source interface{}, name string) interface{} {
+	rv := reflect.ValueOf(source)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous {
+			if v := structField(rv.Field(i).Interface(), name); v != nil {
+				return v
+			}
+			continue
+		}
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == name {
+			return rv.Field(i).Interface()
+		}
+	}
+	return nil
+}
+
+// OrderedMap is a JSON object that keeps its keys in selection order
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// Set adds or replaces a key
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if m.values == nil {
+		m.values = make(map[string]interface{})
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Has reports whether key is set
+func (m *OrderedMap) Has(key string) bool {
+	_, ok := m.values[key]
+	return ok
+}
+
+// Get returns the value for key
+func (m *OrderedMap) Get(key string) interface{} {
+	return m.values[key]
+}
+
+// Keys returns the keys in order
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+// MarshalJSON writes the keys in order
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
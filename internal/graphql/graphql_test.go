@@ -0,0 +1,139 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testItem struct {
+	ID    string   `json:"id"`
+	Title string   `json:"title"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+func testSchema() *Schema {
+	items := []testItem{{ID: "a", Title: "First", Tags: []string{"x"}}, {ID: "b", Title: "Second"}}
+	item := &Object{Name: "Item", Fields: map[string]*Field{"id": {}, "title": {}, "tags": {}}}
+	item.Fields["next"] = &Field{Type: item, Resolve: func(p ResolveParams) (interface{}, error) {
+		if p.Source.(testItem).ID == "a" {
+			return items[1], nil
+		}
+		return nil, nil
+	}}
+	item.Fields["broken"] = &Field{Resolve: func(p ResolveParams) (interface{}, error) {
+		return nil, fmt.Errorf("boom")
+	}}
+	query := &Object{Name: "Query", Fields: map[string]*Field{
+		"items": {Type: item, Resolve: func(p ResolveParams) (interface{}, error) {
+			return items[:p.Args.Int("limit", len(items))], nil
+		}},
+		"item": {Type: item, Resolve: func(p ResolveParams) (interface{}, error) {
+			for _, it := range items {
+				if it.ID == p.Args.String("id") {
+					return it, nil
+				}
+			}
+			return nil, nil
+		}},
+	}}
+	return &Schema{Query: query}
+}
+
+func execJSON(t *testing.T, req Request) string {
+	t.Helper()
+	out, err := json.Marshal(Execute(context.Background(), testSchema(), req))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(out)
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{
+			name: "nested fields keep selection order",
+			req:  Request{Query: `{ items { title id next { id } } }`},
+			want: `{"data":{"items":[{"title":"First","id":"a","next":{"id":"b"}},{"title":"Second","id":"b","next":null}]}}`,
+		},
+		{
+			name: "aliases, arguments and typename",
+			req:  Request{Query: `query { first: item(id: "a") { __typename tags } none: item(id: "zz") { id } }`},
+			want: `{"data":{"first":{"__typename":"Item","tags":["x"]},"none":null}}`,
+		},
+		{
+			name: "variables with defaults",
+			req:  Request{Query: `query Q($n: Int = 1, $id: String!) { items(limit: $n) { id } item(id: $id) { title } }`, Variables: map[string]interface{}{"id": "b"}},
+			want: `{"data":{"items":[{"id":"a"}],"item":{"title":"Second"}}}`,
+		},
+		{
+			name: "fragments",
+			req:  Request{Query: `{ item(id: "a") { ...F ... on Item { title } } } fragment F on Item { id }`},
+			want: `{"data":{"item":{"id":"a","title":"First"}}}`,
+		},
+		{
+			name: "resolver errors carry a path",
+			req:  Request{Query: `{ items(limit: 1) { id broken } }`},
+			want: `{"data":{"items":[{"id":"a","broken":null}]},"errors":[{"message":"boom","path":["items",0,"broken"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execJSON(t, tt.req); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`{ items { nope } }`, "cannot query field 'nope' on type 'Item'"},
+		{`{ items }`, "must have a selection set"},
+		{`{ items { id { x } } }`, "is a scalar"},
+		{`mutation { items { id } }`, "read-only"},
+		{`{ items { id }`, "unexpected end"},
+		{`{ items(limit: 1 @x) { id } }`, "unexpected"},
+		{`query A { items { id } } query B { items { id } }`, "operationName is required"},
+	}
+	for _, tt := range tests {
+		if got := execJSON(t, Request{Query: tt.query}); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: got %s, want error containing %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	doc, err := Parse(`# comment
+		{ f(s: "a\"b", b: """block""", i: -3, fl: 1.5e2, t: true, n: null, e: OPEN, l: [1, 2], o: {k: "v"}) { x } }`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	args := make(map[string]interface{})
+	for _, a := range doc.Operations[0].Selections[0].Arguments {
+		args[a.Name] = resolveValue(a.Value, nil)
+	}
+	want := map[string]interface{}{
+		"s": `a"b`, "b": "block", "i": int64(-3), "fl": 150.0, "t": true, "n": nil, "e": "OPEN",
+	}
+	for k, v := range want {
+		if args[k] != v {
+			t.Errorf("arg %s = %#v, want %#v", k, args[k], v)
+		}
+	}
+	if l, _ := args["l"].([]interface{}); len(l) != 2 {
+		t.Errorf("list arg = %#v", args["l"])
+	}
+	if o, _ := args["o"].(map[string]interface{}); o["k"] != "v" {
+		t.Errorf("object arg = %#v", args["o"])
+	}
+}
@@ -0,0 +1,579 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Document is a parsed GraphQL request document
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query (or unsupported mutation/subscription) definition
+type Operation struct {
+	Type       string // query, mutation or subscription
+	Name       string
+	Variables  []VariableDef
+	Selections []Selection
+}
+
+// VariableDef declares an operation variable and its default value
+type VariableDef struct {
+	Name       string
+	Default    Value
+	HasDefault bool
+}
+
+// Fragment is a named fragment definition
+type Fragment struct {
+	Name       string
+	On         string
+	Selections []Selection
+}
+
+// Selection is a field, a fragment spread (FragmentName set) or an inline
+// fragment (Inline set)
+type Selection struct {
+	Alias        string
+	Name         string
+	Arguments    []Argument
+	Selections   []Selection
+	FragmentName string
+	Inline       *Fragment
+}
+
+// ResponseKey is the key the field's value is returned under
+func (s Selection) ResponseKey() string {
+	if s.Alias != "" {
+		return s.Alias
+	}
+	return s.Name
+}
+
+// Argument is a name: value pair on a field
+type Argument struct {
+	Name  string
+	Value Value
+}
+
+// Value is a literal in a document: string, int64, float64, bool, nil,
+// Enum, Variable, []Value or map[string]Value
+type Value interface{}
+
+// Enum is an unquoted enum value such as OPEN
+type Enum string
+
+// Variable is a $name reference
+type Variable string
+
+// Parse parses a GraphQL document
+func Parse(source string) (*Document, error) {
+	p := &parser{lex: newLexer(source)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.tok.is(tokPunct, "{"):
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", Selections: sels})
+		case p.tok.is(tokName, "fragment"):
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			doc.Fragments[frag.Name] = frag
+		case p.tok.is(tokName, "query"), p.tok.is(tokName, "mutation"), p.tok.is(tokName, "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, fmt.Errorf("syntax error: document has no operations")
+	}
+	return doc, nil
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return fmt.Errorf("syntax error: unexpected end of document")
+	}
+	return fmt.Errorf("syntax error at line %d: unexpected %q", p.tok.line, p.tok.text)
+}
+
+// expect consumes a punctuator
+func (p *parser) expect(punct string) error {
+	if !p.tok.is(tokPunct, punct) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+// skip consumes a punctuator if present
+func (p *parser) skip(punct string) (bool, error) {
+	if !p.tok.is(tokPunct, punct) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.unexpected()
+	}
+	name := p.tok.text
+	return name, p.advance()
+}
+
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Type: p.tok.text}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokName {
+		op.Name = p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.tok.is(tokPunct, ")") {
+			def, err := p.variableDef()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = append(op.Variables, def)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = sels
+	return op, nil
+}
+
+func (p *parser) variableDef() (VariableDef, error) {
+	var def VariableDef
+	if err := p.expect("$"); err != nil {
+		return def, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return def, err
+	}
+	def.Name = name
+	if err := p.expect(":"); err != nil {
+		return def, err
+	}
+	if err := p.typeRef(); err != nil {
+		return def, err
+	}
+	if ok, err := p.skip("="); err != nil {
+		return def, err
+	} else if ok {
+		if def.Default, err = p.value(true); err != nil {
+			return def, err
+		}
+		def.HasDefault = true
+	}
+	return def, nil
+}
+
+// typeRef consumes a type such as [String!]!; variable types are not checked
+func (p *parser) typeRef() error {
+	if ok, err := p.skip("["); err != nil {
+		return err
+	} else if ok {
+		if err := p.typeRef(); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.name(); err != nil {
+		return err
+	}
+	_, err := p.skip("!")
+	return err
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if !p.tok.is(tokName, "on") {
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	on, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &Fragment{Name: name, On: on, Selections: sels}, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []Selection
+	for !p.tok.is(tokPunct, "}") {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, fmt.Errorf("syntax error at line %d: empty selection set", p.tok.line)
+	}
+	return sels, p.advance()
+}
+
+func (p *parser) selection() (Selection, error) {
+	var sel Selection
+	if ok, err := p.skip("..."); err != nil {
+		return sel, err
+	} else if ok {
+		if p.tok.kind == tokName && p.tok.text != "on" {
+			sel.FragmentName = p.tok.text
+			if err := p.advance(); err != nil {
+				return sel, err
+			}
+			return sel, p.directives()
+		}
+		frag := &Fragment{}
+		if p.tok.is(tokName, "on") {
+			if err := p.advance(); err != nil {
+				return sel, err
+			}
+			if frag.On, err = p.name(); err != nil {
+				return sel, err
+			}
+		}
+		if err := p.directives(); err != nil {
+			return sel, err
+		}
+		if frag.Selections, err = p.selectionSet(); err != nil {
+			return sel, err
+		}
+		sel.Inline = frag
+		return sel, nil
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return sel, err
+	}
+	sel.Name = name
+	if ok, err := p.skip(":"); err != nil {
+		return sel, err
+	} else if ok {
+		sel.Alias = name
+		if sel.Name, err = p.name(); err != nil {
+			return sel, err
+		}
+	}
+	if sel.Arguments, err = p.arguments(); err != nil {
+		return sel, err
+	}
+	if err := p.directives(); err != nil {
+		return sel, err
+	}
+	if p.tok.is(tokPunct, "{") {
+		if sel.Selections, err = p.selectionSet(); err != nil {
+			return sel, err
+		}
+	}
+	return sel, nil
+}
+
+func (p *parser) arguments() ([]Argument, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+	var args []Argument
+	for !p.tok.is(tokPunct, ")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		val, err := p.value(false)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, Argument{Name: name, Value: val})
+	}
+	return args, p.advance()
+}
+
+// directives rejects @directives, which are not supported
+func (p *parser) directives() error {
+	if p.tok.is(tokPunct, "@") {
+		return fmt.Errorf("line %d: directives are not supported", p.tok.line)
+	}
+	return nil
+}
+
+func (p *parser) value(constant bool) (Value, error) {
+	tok := p.tok
+	switch {
+	case tok.is(tokPunct, "$") && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return Variable(name), err
+	case tok.is(tokPunct, "["):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		list := []Value{}
+		for !p.tok.is(tokPunct, "]") {
+			v, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, p.advance()
+	case tok.is(tokPunct, "{"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		obj := map[string]Value{}
+		for !p.tok.is(tokPunct, "}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if obj[name], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		return obj, p.advance()
+	case tok.kind == tokString:
+		return tok.text, p.advance()
+	case tok.kind == tokInt:
+		n, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid int %s", tok.line, tok.text)
+		}
+		return n, p.advance()
+	case tok.kind == tokFloat:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid float %s", tok.line, tok.text)
+		}
+		return f, p.advance()
+	case tok.kind == tokName:
+		var v Value
+		switch tok.text {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = Enum(tok.text)
+		}
+		return v, p.advance()
+	}
+	return nil, p.unexpected()
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	line int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, line: l.line}, nil
+	}
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokPunct, text: "...", line: l.line}, nil
+	case strings.IndexByte("!$():=@[]{}|", c) >= 0:
+		l.pos++
+		return token{kind: tokPunct, text: string(c), line: l.line}, nil
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokName, text: l.src[start:l.pos], line: l.line}, nil
+	case c == '-' || isDigit(c):
+		return l.number()
+	case c == '"':
+		return l.string()
+	}
+	return token{}, fmt.Errorf("syntax error at line %d: unexpected character %q", l.line, c)
+}
+
+// skipIgnored skips whitespace, commas and # comments
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	kind := tokInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case isDigit(c):
+		case c == '.' || c == 'e' || c == 'E':
+			kind = tokFloat
+		case (c == '+' || c == '-') && (l.src[l.pos-1] == 'e' || l.src[l.pos-1] == 'E'):
+		default:
+			return token{kind: kind, text: l.src[start:l.pos], line: l.line}, nil
+		}
+		l.pos++
+	}
+	return token{kind: kind, text: l.src[start:l.pos], line: l.line}, nil
+}
+
+func (l *lexer) string() (token, error) {
+	line := l.line
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		end := strings.Index(l.src[l.pos+3:], `"""`)
+		if end < 0 {
+			return token{}, fmt.Errorf("syntax error at line %d: unterminated block string", line)
+		}
+		text := l.src[l.pos+3 : l.pos+3+end]
+		l.line += strings.Count(text, "\n")
+		l.pos += end + 6
+		return token{kind: tokString, text: strings.TrimSpace(text), line: line}, nil
+	}
+
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			l.pos += 2
+			continue
+		case '\n':
+			return token{}, fmt.Errorf("syntax error at line %d: unterminated string", line)
+		case '"':
+			l.pos++
+			text, err := strconv.Unquote(l.src[start:l.pos])
+			if err != nil {
+				return token{}, fmt.Errorf("syntax error at line %d: invalid string %s", line, l.src[start:l.pos])
+			}
+			return token{kind: tokString, text: text, line: line}, nil
+		}
+		l.pos++
+	}
+	return token{}, fmt.Errorf("syntax error at line %d: unterminated string", line)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
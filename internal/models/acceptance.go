@@ -0,0 +1,43 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// AcceptanceCriterion is a condition a task must meet to be done, checked
+// off as it is met. Unlike gates, criteria are specific to one task.
+type AcceptanceCriterion struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	TaskID    string     `gorm:"size:30;not null;index" json:"task_id"`
+	Position  int        `gorm:"not null" json:"position"` // Order within the task, from 1
+	Text      string     `gorm:"type:text;not null" json:"text"`
+	Done      bool       `gorm:"default:false" json:"done"`
+	DoneAt    *time.Time `json:"done_at,omitempty"`
+	DoneBy    string     `gorm:"size:100" json:"done_by,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for AcceptanceCriterion
+func (AcceptanceCriterion) TableName() string {
+	return "acceptance_criteria"
+}
+
+// Checkbox returns the criterion as a Markdown task list item
+func (c AcceptanceCriterion) Checkbox() string {
+	if c.Done {
+		return "- [x] " + c.Text
+	}
+	return "- [ ] " + c.Text
+}
+
+// ParseCheckbox reverses Checkbox, reporting false if s is not a task list item
+func ParseCheckbox(s string) (text string, done bool, ok bool) {
+	if text, ok := strings.CutPrefix(s, "- [x] "); ok {
+		return text, true, true
+	}
+	if text, ok := strings.CutPrefix(s, "- [ ] "); ok {
+		return text, false, true
+	}
+	return "", false, false
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Artifact kind constants
+const (
+	ArtifactKindPatch = "patch" // Unified diff captured from git
+)
+
+// Artifact is a stored blob tied to a task, such as the patch of an agent's
+// code changes, preserved even before a pull request exists
+type Artifact struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TaskID    string    `gorm:"size:30;not null;index" json:"task_id"`
+	Kind      string    `gorm:"size:20;not null;default:patch" json:"kind"`
+	Source    string    `gorm:"size:255" json:"source,omitempty"` // e.g. git:HEAD~1..HEAD
+	Content   string    `gorm:"type:text" json:"content,omitempty"`
+	Size      int       `json:"size"`
+	CreatedBy string    `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for Artifact
+func (Artifact) TableName() string {
+	return "artifacts"
+}
+
+// PatchFiles lists the files touched by a unified diff, in order
+func PatchFiles(patch string) []string {
+	var files []string
+	for _, line := range strings.Split(patch, "\n") {
+		rest, ok := strings.CutPrefix(line, "diff --git a/")
+		if !ok {
+			continue
+		}
+		if i := strings.Index(rest, " b/"); i >= 0 {
+			rest = rest[:i]
+		}
+		files = append(files, rest)
+	}
+	return files
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPatchFiles(t *testing.T) {
+	patch := "diff --git a/cmd/root.go b/cmd/root.go\n" +
+		"index 1111111..2222222 100644\n" +
+		"--- a/cmd/root.go\n" +
+		"+++ b/cmd/root.go\n" +
+		"@@ -1 +1 @@\n" +
+		"-old\n" +
+		"+new\n" +
+		"diff --git a/docs/new.md b/docs/new.md\n" +
+		"new file mode 100644\n"
+
+	want := []string{"cmd/root.go", "docs/new.md"}
+	if got := PatchFiles(patch); !reflect.DeepEqual(got, want) {
+		t.Errorf("PatchFiles() = %v, want %v", got, want)
+	}
+	if got := PatchFiles(""); len(got) != 0 {
+		t.Errorf("PatchFiles(\"\") = %v, want none", got)
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// checklistPattern matches a Markdown task list item: "- [ ] text" or
+// "* [x] text", optionally indented
+var checklistPattern = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\]\s+)(.*\S)\s*$`)
+
+// ChecklistItem is a "- [ ]" item in a task description
+type ChecklistItem struct {
+	Line    int    `json:"line"` // Zero-based line in the description
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+// ParseChecklist returns the checklist items of a description, in order
+func ParseChecklist(description string) []ChecklistItem {
+	var items []ChecklistItem
+	for i, line := range strings.Split(description, "\n") {
+		m := checklistPattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		items = append(items, ChecklistItem{Line: i, Text: m[4], Checked: m[2] != " "})
+	}
+	return items
+}
+
+// SetChecklistItem checks or unchecks the first item with text whose box
+// isn't already in that state. It returns the new description and whether
+// an item changed.
+func SetChecklistItem(description, text string, checked bool) (string, bool) {
+	lines := strings.Split(description, "\n")
+	for i, line := range lines {
+		m := checklistPattern.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		box, itemText := line[m[4]:m[5]], line[m[8]:m[9]]
+		if itemText != text || (box != " ") == checked {
+			continue
+		}
+		mark := " "
+		if checked {
+			mark = "x"
+		}
+		lines[i] = line[:m[4]] + mark + line[m[5]:]
+		return strings.Join(lines, "\n"), true
+	}
+	return description, false
+}
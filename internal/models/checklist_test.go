@@ -0,0 +1,34 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChecklist(t *testing.T) {
+	desc := "Plan:\n- [ ] Write migration\n  * [x] Review schema  \n- [] not an item\n+ [X] Ship\n- plain bullet"
+	want := []ChecklistItem{
+		{Line: 1, Text: "Write migration"},
+		{Line: 2, Text: "Review schema", Checked: true},
+		{Line: 4, Text: "Ship", Checked: true},
+	}
+	if got := ParseChecklist(desc); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseChecklist() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetChecklistItem(t *testing.T) {
+	desc := "- [ ] Docs\n  - [x] Tests\n- [ ] Tests"
+
+	got, changed := SetChecklistItem(desc, "Tests", true)
+	if !changed || got != "- [ ] Docs\n  - [x] Tests\n- [x] Tests" {
+		t.Errorf("check Tests = %q, %v; want the unchecked duplicate checked", got, changed)
+	}
+	got, changed = SetChecklistItem(got, "Tests", false)
+	if !changed || got != "- [ ] Docs\n  - [ ] Tests\n- [x] Tests" {
+		t.Errorf("uncheck Tests = %q, %v", got, changed)
+	}
+	if _, changed := SetChecklistItem(desc, "Release notes", true); changed {
+		t.Error("SetChecklistItem changed a description without the item")
+	}
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -31,6 +33,38 @@ const (
 	ConfigGitHubRepo        = "github_repo"         // owner/repo format
 	ConfigGitHubIssuePrefix = "github_issue_prefix" // e.g., "[Coding Agent]"
 	ConfigGitHubTokenSet    = "github_token_set"    // "true" if token stored in keyring
+	ConfigGitHubLabelMap    = "github_label_map"    // local=github[#color],... (see ParseLabelMap)
+	ConfigGitHubUserMap     = "github_user_map"     // login=assignee,... (see ParseUserMap)
+	ConfigGitHubBaseURL     = "github_base_url"     // GitHub Enterprise API URL; empty for github.com
+	ConfigGitHubUploadURL   = "github_upload_url"   // GitHub Enterprise upload URL; derived from base URL if empty
+
+	ConfigGitHubIssueBodyTemplate = "github_issue_body_template" // Go template for pushed issue bodies
+	ConfigGitHubIssueTypes        = "github_issue_types"         // task type=GitHub issue type,... (see ParseIssueTypeMap); empty leaves issue types alone
+	ConfigGitHubSubIssues         = "github_sub_issues"          // "true" to mirror subtasks as sub-issues
+)
+
+// Sync config keys
+const (
+	ConfigSyncCheckpointPush = "sync_checkpoint_push" // Remaining items of an interrupted push (JSON)
+	ConfigSyncCheckpointPull = "sync_checkpoint_pull" // Remaining items of an interrupted pull (JSON)
+	ConfigSyncAutoPush       = "sync_auto_push"       // on_close, on_change or off (default): push tasks after commands change them
+)
+
+// Auto push modes (ConfigSyncAutoPush)
+const (
+	AutoPushOnClose  = "on_close"
+	AutoPushOnChange = "on_change"
+	AutoPushOff      = "off"
+)
+
+// Display config keys
+const (
+	ConfigTheme = "theme" // Output color theme (see 'gur config theme')
+)
+
+// Performance log config keys
+const (
+	ConfigPerfLog = "perf_log" // "true" to time commands into .guardrails/perf.jsonl (see 'gur perf')
 )
 
 // Machine config keys
@@ -39,11 +73,135 @@ const (
 	ConfigMachineShare = "machine_share" // "true" to share name in sync markers
 )
 
+// Summarizer config keys
+const (
+	ConfigSummarizerBackend = "summarizer_backend" // openai, anthropic, or command
+	ConfigSummarizerModel   = "summarizer_model"
+	ConfigSummarizerURL     = "summarizer_url"     // API base URL override
+	ConfigSummarizerCommand = "summarizer_command" // Shell command for the command backend
+	ConfigSummarizerKeySet  = "summarizer_key_set" // "true" if API key stored in keyring
+)
+
+// Policy config keys
+const (
+	ConfigPolicyRequiredGatesPrefix = "policy_required_gates_p"   // + priority: gate types/IDs required to close
+	ConfigPolicyRequireArtifacts    = "policy_require_artifacts"  // Task types (or "all") that need a linked commit, PR or artifact to close
+	ConfigPolicyRequireAcceptance   = "policy_require_acceptance" // "true" if every acceptance criterion must be checked to close
+)
+
+// PolicyRequiredGatesKey returns the config key for gates required at a priority
+func PolicyRequiredGatesKey(priority int) string {
+	return fmt.Sprintf("%s%d", ConfigPolicyRequiredGatesPrefix, priority)
+}
+
+// Alias config keys
+const (
+	ConfigAliasPrefix = "alias_" // + alias name: command expansion (see 'gur alias')
+)
+
+// AliasKey returns the config key for a user-defined command alias
+func AliasKey(name string) string {
+	return ConfigAliasPrefix + name
+}
+
+// View config keys
+const (
+	ConfigViewPrefix = "view_" // + view name: JSON filter and sort of a saved view (see 'gur view')
+)
+
+// ViewKey returns the config key for a project-level saved view
+func ViewKey(name string) string {
+	return ConfigViewPrefix + name
+}
+
+// Trust config keys
+const (
+	ConfigTrustGateTypePrefix = "trust_gate_type_" // + gate type: comma-separated verifiers allowed to pass it (see 'gur config trust')
+)
+
+// TrustGateTypeKey returns the config key for the verifiers trusted to pass
+// gates of a type
+func TrustGateTypeKey(gateType string) string {
+	return ConfigTrustGateTypePrefix + strings.ToLower(gateType)
+}
+
+// Approval config keys
+const (
+	ConfigApprovalClosePrefix = "approval_close_" // + task ID: nonce of the outstanding force-close approval
+)
+
+// ApprovalCloseKey returns the config key for a task's outstanding force-close approval
+func ApprovalCloseKey(taskID string) string {
+	return ConfigApprovalClosePrefix + taskID
+}
+
+// Gate ownership config keys
+const (
+	ConfigGateOwnerPrefix   = "gate_owner_"   // + gate category: comma-separated owners (users or @org/team)
+	ConfigGateMentionPrefix = "gate_mention_" // + task ID: owner/gate pairs last @mentioned on its issue
+)
+
+// Shared gate config keys
+const (
+	ConfigGateRemoteSource = "gate_remote_source" // Repository or directory of shared gate definitions
+	ConfigGateRemoteRef    = "gate_remote_ref"    // Branch or tag of the shared gate repository
+)
+
+// GateOwnerKey returns the config key for a gate category's owners
+func GateOwnerKey(category string) string {
+	return ConfigGateOwnerPrefix + category
+}
+
+// GateMentionKey returns the config key for a task's last gate-owner mention
+func GateMentionKey(taskID string) string {
+	return ConfigGateMentionPrefix + taskID
+}
+
+// People config keys
+const (
+	ConfigAssigneeStrict = "assignee_strict" // "true" to reject assignees not in 'gur people'
+)
+
+// Hook config keys
+const (
+	ConfigHookTimeout       = "hook_timeout"        // Go duration, e.g. "30s"
+	ConfigHookFailurePolicy = "hook_failure_policy" // One of HookFailurePolicies
+)
+
+// Hook failure policies: what a failing hook does to the command that fired it
+const (
+	HookFailureWarn   = "warn"   // Print a warning and succeed (default)
+	HookFailureFail   = "fail"   // Make the command fail; the event itself is kept
+	HookFailureIgnore = "ignore" // Succeed silently
+)
+
+// HookFailurePolicies lists the valid hook failure policies
+var HookFailurePolicies = []string{HookFailureWarn, HookFailureFail, HookFailureIgnore}
+
+// Size limit config keys
+const (
+	ConfigLimitNoteBytes        = "limit_note_bytes"        // Max bytes in one note; unset or 0 for no limit
+	ConfigLimitDescriptionBytes = "limit_description_bytes" // Max bytes in a description; unset or 0 for no limit
+	ConfigLimitMode             = "limit_mode"              // One of SizeLimitModes
+)
+
+// Size limit modes: what happens to text over its configured limit
+const (
+	SizeLimitWarn     = "warn"     // Keep the text and print a warning (default)
+	SizeLimitTruncate = "truncate" // Keep the head of the text, marked as truncated
+	SizeLimitReject   = "reject"   // Refuse the change
+)
+
+// SizeLimitModes lists the valid size limit modes
+var SizeLimitModes = []string{SizeLimitWarn, SizeLimitTruncate, SizeLimitReject}
+
 // Default values
 const (
 	DefaultGitHubIssuePrefix = "[Coding Agent]"
 	KeyringServiceName       = "guardrails"
 	KeyringGitHubTokenKey    = "github_token"
+	KeyringSummarizerKey     = "summarizer_api_key"
+	KeyringApprovalKey       = "approval_signing_key" // HMAC key for force-close approval tokens
 )
 
 // Mode constants
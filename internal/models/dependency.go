@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,14 +13,30 @@ const (
 	DepTypeBlocks      = "blocks"
 	DepTypeRelated     = "related"
 	DepTypeParentChild = "parent-child"
+	DepTypeSoftBlocks  = "soft-blocks"           // Warns in ready while the parent is open, never hides the child
+	DepTypeFinishStart = "finish-to-start-after" // Child is ready only Lag after the parent closes
 )
 
+// DepTypes lists the valid dependency types
+var DepTypes = []string{DepTypeBlocks, DepTypeSoftBlocks, DepTypeFinishStart, DepTypeRelated, DepTypeParentChild}
+
+// ValidateDepType checks a dependency type
+func ValidateDepType(t string) error {
+	for _, v := range DepTypes {
+		if t == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid dependency type '%s': must be one of %s", t, strings.Join(DepTypes, ", "))
+}
+
 // Dependency represents a relationship between two tasks
 type Dependency struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	ParentID  string         `gorm:"size:20;not null;index:idx_parent;index:idx_type_parent,priority:2" json:"parent_id"`                        // The blocking task
 	ChildID   string         `gorm:"size:20;not null;index:idx_child;index:idx_child_type_parent,priority:1" json:"child_id"`                    // The blocked task
-	Type      string         `gorm:"size:20;default:blocks;index:idx_child_type_parent,priority:2;index:idx_type_parent,priority:1" json:"type"` // blocks, related, parent-child
+	Type      string         `gorm:"size:30;default:blocks;index:idx_child_type_parent,priority:2;index:idx_type_parent,priority:1" json:"type"` // blocks, soft-blocks, finish-to-start-after, related, parent-child
+	Lag       int            `json:"lag_minutes,omitempty"`                                                                                      // finish-to-start-after: minutes after the parent closes
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
@@ -40,7 +58,28 @@ func (d *Dependency) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-// IsBlocking returns true if this is a blocking dependency
+// IsBlocking returns true if this dependency can keep the child out of ready
 func (d *Dependency) IsBlocking() bool {
-	return d.Type == DepTypeBlocks
+	return d.Type == DepTypeBlocks || d.Type == DepTypeFinishStart
+}
+
+// LagDuration returns the finish-to-start lag
+func (d *Dependency) LagDuration() time.Duration {
+	return time.Duration(d.Lag) * time.Minute
+}
+
+// ReadyAt returns when the child stops being held back by this dependency,
+// given the parent's close time (nil while open). ok is false while the
+// parent is open and the dependency blocks.
+func (d *Dependency) ReadyAt(parentClosedAt *time.Time) (at time.Time, ok bool) {
+	if !d.IsBlocking() {
+		return time.Time{}, true
+	}
+	if parentClosedAt == nil {
+		return time.Time{}, false
+	}
+	if d.Type == DepTypeFinishStart {
+		return parentClosedAt.Add(d.LagDuration()), true
+	}
+	return *parentClosedAt, true
 }
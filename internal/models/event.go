@@ -0,0 +1,104 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrEventImmutable is returned when an update or delete is attempted on an event
+var ErrEventImmutable = errors.New("events are append-only")
+
+// Event is an append-only audit record of a mutating command.
+// Each event stores the hash of its predecessor, forming a chain that
+// VerifyEventChain can check for edits, deletions, or reordering.
+type Event struct {
+	ID          uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Command     string      `gorm:"size:100;index;not null" json:"command"`
+	Args        StringSlice `gorm:"type:text" json:"args,omitempty"`
+	Actor       string      `gorm:"size:100" json:"actor,omitempty"`
+	AffectedIDs StringSlice `gorm:"type:text" json:"affected_ids,omitempty"`
+	Success     bool        `json:"success"`
+	Error       string      `gorm:"type:text" json:"error,omitempty"`
+	Reverts     uint        `gorm:"index" json:"reverts,omitempty"` // ID of the event this one undid
+	StartedAt   time.Time   `gorm:"index" json:"started_at"`
+	FinishedAt  time.Time   `json:"finished_at"`
+	PrevHash    string      `gorm:"size:64" json:"prev_hash"`
+	Hash        string      `gorm:"size:64;not null" json:"hash"`
+}
+
+// TableName for Event
+func (Event) TableName() string {
+	return "events"
+}
+
+// BeforeUpdate rejects modification of recorded events
+func (e *Event) BeforeUpdate(tx *gorm.DB) error {
+	return ErrEventImmutable
+}
+
+// BeforeDelete rejects removal of recorded events
+func (e *Event) BeforeDelete(tx *gorm.DB) error {
+	return ErrEventImmutable
+}
+
+// ComputeHash returns the chain hash of the event given its PrevHash.
+// Slices are normalised so nil and empty hash the same after a database round trip.
+func (e *Event) ComputeHash() string {
+	payload, _ := json.Marshal(struct {
+		Command     string   `json:"command"`
+		Args        []string `json:"args"`
+		Actor       string   `json:"actor"`
+		AffectedIDs []string `json:"affected_ids"`
+		Success     bool     `json:"success"`
+		Error       string   `json:"error"`
+		Reverts     uint     `json:"reverts,omitempty"`
+		StartedAt   string   `json:"started_at"`
+		FinishedAt  string   `json:"finished_at"`
+	}{
+		Command:     e.Command,
+		Args:        append([]string{}, e.Args...),
+		Actor:       e.Actor,
+		AffectedIDs: append([]string{}, e.AffectedIDs...),
+		Success:     e.Success,
+		Error:       e.Error,
+		Reverts:     e.Reverts,
+		StartedAt:   e.StartedAt.UTC().Format(time.RFC3339Nano),
+		FinishedAt:  e.FinishedAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(append([]byte(e.PrevHash+"\n"), payload...))
+	return hex.EncodeToString(sum[:])
+}
+
+// AppendEvent links the event to the current end of the chain and stores it
+func AppendEvent(db *gorm.DB, e *Event) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var last Event
+		err := tx.Order("id DESC").Limit(1).Find(&last).Error
+		if err != nil {
+			return err
+		}
+		e.PrevHash = last.Hash
+		e.Hash = e.ComputeHash()
+		return tx.Create(e).Error
+	})
+}
+
+// VerifyEventChain checks events (in ID order) against their stored hashes.
+// It returns the ID of the first event that fails verification, or 0 if the
+// chain is intact.
+func VerifyEventChain(events []Event) uint {
+	prev := ""
+	for i := range events {
+		e := &events[i]
+		if e.PrevHash != prev || e.ComputeHash() != e.Hash {
+			return e.ID
+		}
+		prev = e.Hash
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerifyEventChain(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var events []Event
+	prev := ""
+	for i, cmd := range []string{"create", "update", "close"} {
+		e := Event{
+			ID:          uint(i + 1),
+			Command:     cmd,
+			AffectedIDs: StringSlice{"gur-a1b2c3d4"},
+			Success:     true,
+			StartedAt:   start.Add(time.Duration(i) * time.Minute),
+			FinishedAt:  start.Add(time.Duration(i) * time.Minute),
+			PrevHash:    prev,
+		}
+		e.Hash = e.ComputeHash()
+		prev = e.Hash
+		events = append(events, e)
+	}
+
+	if got := VerifyEventChain(events); got != 0 {
+		t.Fatalf("VerifyEventChain() on intact chain = %d, want 0", got)
+	}
+
+	// Editing a field breaks that event's hash
+	tampered := append([]Event{}, events...)
+	tampered[1].Actor = "mallory"
+	if got := VerifyEventChain(tampered); got != 2 {
+		t.Errorf("VerifyEventChain() after edit = %d, want 2", got)
+	}
+
+	// Removing an event breaks the link from its successor
+	removed := []Event{events[0], events[2]}
+	if got := VerifyEventChain(removed); got != 3 {
+		t.Errorf("VerifyEventChain() after delete = %d, want 3", got)
+	}
+}
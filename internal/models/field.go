@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Custom field type constants
+const (
+	FieldTypeString = "string"
+	FieldTypeNumber = "number"
+	FieldTypeBool   = "bool"
+	FieldTypeDate   = "date"
+	FieldTypeEnum   = "enum"
+)
+
+// FieldTypes lists the valid custom field types
+var FieldTypes = []string{FieldTypeString, FieldTypeNumber, FieldTypeBool, FieldTypeDate, FieldTypeEnum}
+
+var fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,49}$`)
+
+// ValidateFieldName validates a custom field name (lowercase, starts with a letter)
+func ValidateFieldName(name string) bool {
+	return fieldNamePattern.MatchString(name)
+}
+
+// CustomField defines a project-specific task attribute
+type CustomField struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	Name          string      `gorm:"size:50;uniqueIndex;not null" json:"name"`
+	Type          string      `gorm:"size:20;default:string" json:"type"`
+	AllowedValues StringSlice `gorm:"type:text" json:"allowed_values,omitempty"` // For enum fields
+	Description   string      `gorm:"type:text" json:"description,omitempty"`
+	CreatedAt     time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for CustomField
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
+
+// Normalize validates a value against the field type and returns it in
+// canonical form
+func (f *CustomField) Normalize(value string) (string, error) {
+	switch f.Type {
+	case FieldTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid value '%s' for field '%s': must be a number", value, f.Name)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case FieldTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value '%s' for field '%s': must be true or false", value, f.Name)
+		}
+		return strconv.FormatBool(b), nil
+	case FieldTypeDate:
+		if _, err := time.Parse(DateFormat, value); err != nil {
+			return "", fmt.Errorf("invalid value '%s' for field '%s': must be a date like 2025-07-01", value, f.Name)
+		}
+		return value, nil
+	case FieldTypeEnum:
+		for _, allowed := range f.AllowedValues {
+			if strings.EqualFold(allowed, value) {
+				return allowed, nil
+			}
+		}
+		return "", fmt.Errorf("invalid value '%s' for field '%s': must be one of: %s", value, f.Name, strings.Join(f.AllowedValues, ", "))
+	default:
+		return value, nil
+	}
+}
+
+// TaskFieldValue stores a custom field value for a task
+type TaskFieldValue struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TaskID    string    `gorm:"size:30;uniqueIndex:idx_task_field;not null" json:"task_id"`
+	FieldID   uint      `gorm:"uniqueIndex:idx_task_field;index;not null" json:"field_id"`
+	Value     string    `gorm:"type:text" json:"value"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Field CustomField `gorm:"foreignKey:FieldID" json:"field,omitempty"`
+}
+
+// TableName specifies the table name for TaskFieldValue
+func (TaskFieldValue) TableName() string {
+	return "task_field_values"
+}
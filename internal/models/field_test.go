@@ -0,0 +1,35 @@
+package models
+
+import "testing"
+
+func TestCustomFieldNormalize(t *testing.T) {
+	tests := []struct {
+		field   CustomField
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{CustomField{Name: "env", Type: FieldTypeEnum, AllowedValues: StringSlice{"prod", "staging"}}, "PROD", "prod", false},
+		{CustomField{Name: "env", Type: FieldTypeEnum, AllowedValues: StringSlice{"prod", "staging"}}, "dev", "", true},
+		{CustomField{Name: "points", Type: FieldTypeNumber}, "3.50", "3.5", false},
+		{CustomField{Name: "points", Type: FieldTypeNumber}, "three", "", true},
+		{CustomField{Name: "flag", Type: FieldTypeBool}, "1", "true", false},
+		{CustomField{Name: "ship", Type: FieldTypeDate}, "2025-07-01", "2025-07-01", false},
+		{CustomField{Name: "ship", Type: FieldTypeDate}, "July 1", "", true},
+		{CustomField{Name: "note", Type: FieldTypeString}, "anything goes", "anything goes", false},
+	}
+	for _, tt := range tests {
+		got, err := tt.field.Normalize(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s.Normalize(%q) = %q, %v; want %q (error %v)", tt.field.Type, tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateFieldName(t *testing.T) {
+	for name, want := range map[string]bool{"environment": true, "story-points": true, "x_1": true, "Env": false, "1st": false, "": false} {
+		if got := ValidateFieldName(name); got != want {
+			t.Errorf("ValidateFieldName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -21,6 +22,22 @@ const (
 // Common gate types (not enforced, just suggestions)
 // Users can use any type string they want: test, review, approval, manual, deploy, qa, doc, etc.
 
+// GateTypeTest is the type of the gates managed as test cases by 'gur test'
+const GateTypeTest = "test"
+
+// TestTypes are the kinds of test case a test gate can be
+var TestTypes = []string{"unit", "integration", "e2e", "manual", "smoke", "regression"}
+
+// ValidateTestType checks that t is one of TestTypes
+func ValidateTestType(t string) error {
+	for _, known := range TestTypes {
+		if t == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid test type '%s': must be one of %s", t, strings.Join(TestTypes, ", "))
+}
+
 // Gate ID constants
 const (
 	GateIDByteLength = 4
@@ -45,12 +62,21 @@ type Gate struct {
 	Description    string         `gorm:"type:text" json:"description,omitempty"`
 	Category       string         `gorm:"size:100;index" json:"category,omitempty"`   // e.g., "auth", "api", "ui"
 	Type           string         `gorm:"size:20;default:manual" json:"type"`         // test, review, approval, manual, deploy, qa, doc
+	TestType       string         `gorm:"size:20" json:"test_type,omitempty"`         // Kind of test for test gates: unit, e2e, ... (see TestTypes)
 	Priority       int            `gorm:"index" json:"priority"`                      // 0=critical, 4=lowest
 	Preconditions  string         `gorm:"type:text" json:"preconditions,omitempty"`   // Setup required
 	Steps          string         `gorm:"type:text" json:"steps,omitempty"`           // Instructions
 	ExpectedResult string         `gorm:"type:text" json:"expected_result,omitempty"` // What should happen
 	Command        string         `gorm:"type:text" json:"command,omitempty"`         // Command to run for automated gates
+	WorkDir        string         `gorm:"size:500" json:"workdir,omitempty"`          // Command working directory, relative to the project root
+	EnvAllow       StringSlice    `gorm:"type:text" json:"env_allow,omitempty"`       // Environment variables passed to Command (all if empty, local runner only)
+	Timeout        int            `json:"timeout_sec,omitempty"`                      // Command timeout in seconds (0 = default)
+	Runner         string         `gorm:"size:200" json:"runner,omitempty"`           // "local" (default), "docker:<image>" or "http"
+	ExpectStatus   int            `json:"expect_status,omitempty"`                    // HTTP runner: required response status (0 = any 2xx)
+	ExpectJSON     string         `gorm:"type:text" json:"expect_json,omitempty"`     // HTTP runner: "<path>=<value>" the JSON response must hold
+	After          StringSlice    `gorm:"type:text" json:"after,omitempty"`           // Gates 'gur verify' runs before this one
 	Labels         StringSlice    `gorm:"type:text" json:"labels,omitempty"`
+	Approvers      StringSlice    `gorm:"type:text" json:"approvers,omitempty"`       // Only these may pass the gate; others request approval
 	LastResult     string         `gorm:"size:20;default:pending" json:"last_result"` // pending, passed, failed, skipped
 	LastRunAt      *time.Time     `json:"last_run_at,omitempty"`
 	LastRunBy      string         `gorm:"size:100" json:"last_run_by,omitempty"`     // "human" or "agent" or specific name
@@ -70,21 +96,28 @@ func (Gate) TableName() string {
 
 // Gate link status constants
 const (
-	GateLinkPending = "pending"
-	GateLinkPassed  = "passed"
-	GateLinkFailed  = "failed"
+	GateLinkPending   = "pending"
+	GateLinkPassed    = "passed"
+	GateLinkFailed    = "failed"
+	GateLinkRequested = "requested" // Pass requested by a non-approver, awaiting approval
+	GateLinkWaived    = "waived"    // Formally waived; satisfies the gate until ExpiresAt
 )
 
+// TrustOverridePrefix marks the notes of a pass recorded by a verifier the
+// trust rules don't allow (see 'gur config trust')
+const TrustOverridePrefix = "[TRUST OVERRIDE] "
+
 // GateTaskLink links gates to tasks (many-to-many)
 // Each link has its own verification status - gates must be verified per-task
 type GateTaskLink struct {
 	ID         uint           `gorm:"primaryKey" json:"id"`
 	GateID     string         `gorm:"size:20;not null;index" json:"gate_id"`
-	TaskID     string         `gorm:"size:20;not null;index" json:"task_id"`
-	Status     string         `gorm:"size:20;default:pending" json:"status"` // pending, passed, failed
+	TaskID     string         `gorm:"size:20;not null;index;index:idx_gate_task_links_task_status,priority:1" json:"task_id"`
+	Status     string         `gorm:"size:20;default:pending;index:idx_gate_task_links_task_status,priority:2" json:"status"` // pending, passed, failed, requested, waived
 	VerifiedAt *time.Time     `json:"verified_at,omitempty"`
 	VerifiedBy string         `gorm:"size:100" json:"verified_by,omitempty"` // human, agent, or name
 	Notes      string         `gorm:"type:text" json:"notes,omitempty"`
+	ExpiresAt  *time.Time     `json:"expires_at,omitempty"` // Waiver expiry, nil for none
 	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 }
@@ -94,14 +127,33 @@ func (GateTaskLink) TableName() string {
 	return "gate_task_links"
 }
 
+// WaiverExpired returns true if the link is waived and the waiver has expired
+func (l GateTaskLink) WaiverExpired(now time.Time) bool {
+	return l.Status == GateLinkWaived && l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// Satisfied returns true if the link no longer blocks closing its task:
+// the gate passed, or it was waived and the waiver has not expired
+func (l GateTaskLink) Satisfied(now time.Time) bool {
+	switch l.Status {
+	case GateLinkPassed:
+		return true
+	case GateLinkWaived:
+		return !l.WaiverExpired(now)
+	}
+	return false
+}
+
 // GateRun records each execution of a gate
 type GateRun struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	GateID    string    `gorm:"size:20;not null;index" json:"gate_id"`
-	Result    string    `gorm:"size:20;not null" json:"result"` // passed, failed, skipped
-	RunBy     string    `gorm:"size:100" json:"run_by"`         // "human", "agent", or name
+	TaskID    string    `gorm:"size:30;index" json:"task_id,omitempty"` // The task the result was recorded for, if any
+	Result    string    `gorm:"size:20;not null" json:"result"`         // passed, failed, skipped
+	RunBy     string    `gorm:"size:100" json:"run_by"`                 // "human", "agent", or name
 	Notes     string    `gorm:"type:text" json:"notes,omitempty"`
 	Duration  int       `json:"duration_ms,omitempty"`             // Duration in milliseconds
+	Runner    string    `gorm:"size:200" json:"runner,omitempty"`  // Where an automated gate's command ran (local, docker:<image>)
 	Output    string    `gorm:"type:text" json:"output,omitempty"` // Command output for automated gates
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
@@ -111,6 +163,50 @@ func (GateRun) TableName() string {
 	return "gate_runs"
 }
 
+// SharedGate tracks a gate copied from an organization's shared gate
+// definitions by 'gur gate sync-remote'
+type SharedGate struct {
+	GateID   string    `gorm:"primaryKey;size:20" json:"gate_id"`
+	Source   string    `gorm:"size:500;not null;index" json:"source"`
+	Pack     string    `gorm:"size:100;not null" json:"pack"` // Pack file the gate came from; also the gate's category
+	Title    string    `gorm:"size:255;not null" json:"title"`
+	Version  int       `json:"version"`                           // Bumped each time a changed remote definition is copied
+	Revision string    `gorm:"size:64" json:"revision,omitempty"` // Commit of the source the copy was taken from
+	Hash     string    `gorm:"size:64" json:"hash"`               // Hash of the definition copied, to spot remote and local changes
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// TableName specifies the table name for SharedGate
+func (SharedGate) TableName() string {
+	return "shared_gates"
+}
+
+// Gate runner constants
+const (
+	RunnerLocal        = "local"
+	RunnerDockerPrefix = "docker:"
+	RunnerHTTP         = "http" // Command is a URL to check instead of a shell command
+)
+
+// ValidateRunner checks a gate runner spec: "local", "docker:<image>" or "http"
+func ValidateRunner(runner string) error {
+	if runner == "" || runner == RunnerLocal || runner == RunnerHTTP {
+		return nil
+	}
+	if image, ok := strings.CutPrefix(runner, RunnerDockerPrefix); ok && strings.TrimSpace(image) != "" && !strings.ContainsAny(image, " \t") {
+		return nil
+	}
+	return fmt.Errorf("invalid runner '%s': must be 'local', 'docker:<image>' (e.g., docker:golang:1.22) or 'http'", runner)
+}
+
+// RunnerString returns the gate's runner, defaulting to local
+func (g *Gate) RunnerString() string {
+	if g.Runner == "" {
+		return RunnerLocal
+	}
+	return g.Runner
+}
+
 // GenerateGateID creates a new hash-based gate ID like "gate-a1b2c3d4"
 func GenerateGateID() string {
 	bytes := make([]byte, GateIDByteLength)
@@ -144,6 +240,25 @@ func (g *Gate) RecordRun(result, runBy, notes string) {
 	}
 }
 
+// RequiresApproval returns true if only designated approvers may pass the gate
+func (g *Gate) RequiresApproval() bool {
+	return len(g.Approvers) > 0
+}
+
+// IsApprover returns true if name may pass the gate (case-insensitive).
+// Gates without approvers can be passed by anyone.
+func (g *Gate) IsApprover(name string) bool {
+	if !g.RequiresApproval() {
+		return true
+	}
+	for _, a := range g.Approvers {
+		if strings.EqualFold(a, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // PassRate returns the pass rate as a percentage
 func (g *Gate) PassRate() float64 {
 	if g.RunCount == 0 {
@@ -153,3 +153,34 @@ func TestGateIsPassing(t *testing.T) {
 		}
 	}
 }
+
+func TestGateIsApprover(t *testing.T) {
+	open := &Gate{}
+	if open.RequiresApproval() {
+		t.Error("RequiresApproval() with no approvers should be false")
+	}
+	if !open.IsApprover("agent") {
+		t.Error("IsApprover() with no approvers should allow anyone")
+	}
+
+	gate := &Gate{Approvers: StringSlice{"alice", "Bob"}}
+	if !gate.RequiresApproval() {
+		t.Error("RequiresApproval() with approvers should be true")
+	}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"alice", true},
+		{"bob", true},
+		{"ALICE", true},
+		{"agent", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := gate.IsApprover(tt.name); got != tt.want {
+			t.Errorf("IsApprover(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"encoding/json"
+	"sort"
 	"time"
 )
 
@@ -23,6 +25,15 @@ type GitHubIssueLink struct {
 	SyncDirection   string     `gorm:"size:10;default:push" json:"sync_direction"`
 	SyncedBy        string     `gorm:"size:100" json:"synced_by,omitempty"`      // username who synced
 	SyncedMachine   string     `gorm:"size:100" json:"synced_machine,omitempty"` // machine hostname
+	ContentHash     string     `gorm:"size:64" json:"content_hash,omitempty"`    // hash of the last pushed title/body/state/labels
+	CommandID       int64      `json:"command_comment_id,omitempty"`             // last issue comment scanned for /gur commands
+	CommandsAt      *time.Time `json:"commands_checked_at,omitempty"`            // when comments were last scanned for /gur commands
+	SyncedBody      string     `gorm:"type:text" json:"-"`                       // issue body as last pulled or pushed
+	SyncedDesc      string     `gorm:"type:text" json:"-"`                       // issue description as last pulled or pushed: the base of pull merges
+	IssueID         int64      `json:"issue_id,omitempty"`                       // GitHub's own ID of the issue, which sub-issue calls take
+	IssueType       string     `gorm:"size:100" json:"issue_type,omitempty"`     // issue type as last pushed or pulled
+	ParentIssue     int        `json:"parent_issue,omitempty"`                   // number of the issue it is a sub-issue of, as last synced
+	SyncVector      string     `gorm:"type:text" json:"-"`                       // machines' push times as last seen on the issue (see SyncVector)
 	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -31,3 +42,110 @@ type GitHubIssueLink struct {
 func (GitHubIssueLink) TableName() string {
 	return "github_issue_links"
 }
+
+// SyncVector records, per machine, when it last pushed an issue: the task's
+// local update time at the push. Each push carries the vector in the issue,
+// so a machine can tell that another one pushed since it last synced.
+type SyncVector map[string]time.Time
+
+// ParseSyncVector decodes a vector stored by String; empty if invalid
+func ParseSyncVector(s string) SyncVector {
+	v := SyncVector{}
+	if s != "" {
+		json.Unmarshal([]byte(s), &v)
+	}
+	return v
+}
+
+// String encodes the vector as JSON
+func (v SyncVector) String() string {
+	if len(v) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// Merge returns the vector with the later time of each machine
+func (v SyncVector) Merge(other SyncVector) SyncVector {
+	merged := SyncVector{}
+	for _, vec := range []SyncVector{v, other} {
+		for machine, t := range vec {
+			if t.After(merged[machine]) {
+				merged[machine] = t
+			}
+		}
+	}
+	return merged
+}
+
+// Diverged returns the machines other than self that pushed after the
+// times recorded in known, sorted
+func (v SyncVector) Diverged(known SyncVector, self string) []string {
+	var machines []string
+	for machine, t := range v {
+		if machine != self && t.After(known[machine]) {
+			machines = append(machines, machine)
+		}
+	}
+	sort.Strings(machines)
+	return machines
+}
+
+// GitHubMarkerCache caches the sync-marker lookup for an issue so that
+// 'sync pull' only re-reads comments when the issue has changed
+type GitHubMarkerCache struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	Repository     string    `gorm:"size:200;not null;uniqueIndex:idx_marker_cache_issue" json:"repository"`
+	IssueNumber    int       `gorm:"not null;uniqueIndex:idx_marker_cache_issue" json:"issue_number"`
+	CommentCount   int       `json:"comment_count"`
+	IssueUpdatedAt time.Time `json:"issue_updated_at"`
+	ETag           string    `gorm:"column:etag;size:200" json:"etag,omitempty"`
+	Marker         string    `gorm:"type:text" json:"marker,omitempty"` // JSON sync marker, empty if none found
+	CheckedAt      time.Time `json:"checked_at"`
+}
+
+// TableName specifies the table name for GitHubMarkerCache
+func (GitHubMarkerCache) TableName() string {
+	return "github_marker_cache"
+}
+
+// Matches returns true if the cached lookup is still valid for the issue state
+func (c *GitHubMarkerCache) Matches(commentCount int, updatedAt time.Time) bool {
+	return c.CommentCount == commentCount && c.IssueUpdatedAt.Equal(updatedAt)
+}
+
+// GitHubPendingSync is written before 'sync push' creates an issue and
+// removed in the same transaction that links the issue to its task. A row
+// left behind means the push stopped in between, so an issue may exist on
+// GitHub without a local link (see 'gur sync reconcile').
+type GitHubPendingSync struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	TaskID      string    `gorm:"size:30;not null;index" json:"task_id"`
+	Repository  string    `gorm:"size:200;not null" json:"repository"` // owner/repo format
+	Title       string    `gorm:"size:500" json:"title"`               // Title the issue was created with
+	IssueNumber int       `json:"issue_number,omitempty"`              // Set once GitHub has created the issue
+	IssueURL    string    `gorm:"size:500" json:"issue_url,omitempty"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GitHubPendingSync
+func (GitHubPendingSync) TableName() string {
+	return "github_pending_syncs"
+}
+
+// GitHubOutboxEntry is a task queued to be pushed automatically after a
+// command changed it (see ConfigSyncAutoPush). Entries that fail stay
+// queued and are retried later.
+type GitHubOutboxEntry struct {
+	TaskID      string    `gorm:"primaryKey;size:30" json:"task_id"`
+	QueuedAt    time.Time `json:"queued_at"`
+	NextAttempt time.Time `gorm:"index" json:"next_attempt"` // Not pushed before this, to rate-limit pushes
+	Attempts    int       `json:"attempts,omitempty"`
+	LastError   string    `gorm:"type:text" json:"last_error,omitempty"`
+}
+
+// TableName specifies the table name for GitHubOutboxEntry
+func (GitHubOutboxEntry) TableName() string {
+	return "github_outbox"
+}
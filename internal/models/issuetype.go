@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultIssueTypeMapSpec maps task types to the issue types GitHub gives
+// every organization. Epics have no default type.
+const DefaultIssueTypeMapSpec = "task=Task,bug=Bug,feature=Feature"
+
+// IssueTypeMap maps task types to GitHub issue type names
+type IssueTypeMap map[string]string
+
+// ParseIssueTypeMap parses "type=Issue Type" pairs separated by commas,
+// e.g. "bug=Bug,epic=Initiative". Keys are task types.
+func ParseIssueTypeMap(spec string) (IssueTypeMap, error) {
+	m := make(IssueTypeMap)
+	for _, part := range strings.Split(spec, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		local, name, ok := strings.Cut(part, "=")
+		local, name = strings.ToLower(strings.TrimSpace(local)), strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid issue type mapping '%s': expected type=Issue Type", part)
+		}
+		switch local {
+		case TypeTask, TypeBug, TypeFeature, TypeEpic:
+		default:
+			return nil, fmt.Errorf("invalid issue type mapping '%s': '%s' is not a task type (task, bug, feature, epic)", part, local)
+		}
+		if _, dup := m[local]; dup {
+			return nil, fmt.Errorf("duplicate issue type mapping for '%s'", local)
+		}
+		m[local] = name
+	}
+	if len(m) == 0 {
+		return nil, fmt.Errorf("empty issue type mapping")
+	}
+	return m, nil
+}
+
+// String formats the map as ParseIssueTypeMap reads it, in task type order
+func (m IssueTypeMap) String() string {
+	var parts []string
+	for _, t := range []string{TypeTask, TypeBug, TypeFeature, TypeEpic} {
+		if name, ok := m[t]; ok {
+			parts = append(parts, t+"="+name)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// FromGitHub returns the task type an issue type maps to, matched
+// case-insensitively. When several task types map to it, the first in
+// task type order wins.
+func (m IssueTypeMap) FromGitHub(name string) (string, bool) {
+	for _, t := range []string{TypeTask, TypeBug, TypeFeature, TypeEpic} {
+		if mapped, ok := m[t]; ok && strings.EqualFold(mapped, name) {
+			return t, true
+		}
+	}
+	return "", false
+}
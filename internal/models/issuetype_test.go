@@ -0,0 +1,28 @@
+package models
+
+import "testing"
+
+func TestParseIssueTypeMap(t *testing.T) {
+	m, err := ParseIssueTypeMap("Bug=Bug, epic = Initiative,feature=Feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.String(); got != "bug=Bug,feature=Feature,epic=Initiative" {
+		t.Errorf("String() = %q", got)
+	}
+	if taskType, ok := m.FromGitHub("initiative"); !ok || taskType != TypeEpic {
+		t.Errorf("FromGitHub(initiative) = %q, %v, want epic", taskType, ok)
+	}
+	if _, ok := m.FromGitHub("Task"); ok {
+		t.Error("FromGitHub(Task) matched an unmapped type")
+	}
+
+	for _, spec := range []string{"", "story=Story", "bug", "bug=", "bug=Bug,bug=Defect"} {
+		if _, err := ParseIssueTypeMap(spec); err == nil {
+			t.Errorf("ParseIssueTypeMap(%q) succeeded, want an error", spec)
+		}
+	}
+	if _, err := ParseIssueTypeMap(DefaultIssueTypeMapSpec); err != nil {
+		t.Errorf("default mapping: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"path"
+	"strings"
+)
+
+// LabelNamespaceSep separates a label's namespace from its name, as in
+// area/auth or team/platform
+const LabelNamespaceSep = "/"
+
+// SplitLabel returns a label's namespace and name; labels without a
+// namespace return "" and the label. Only the first separator counts:
+// team/platform/infra is platform/infra in the team namespace.
+func SplitLabel(label string) (namespace, name string) {
+	if ns, rest, ok := strings.Cut(label, LabelNamespaceSep); ok && ns != "" && rest != "" {
+		return ns, rest
+	}
+	return "", label
+}
+
+// IsLabelPattern reports whether a label filter uses glob syntax (* ? [ ])
+func IsLabelPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// MatchLabel reports whether label matches a glob pattern such as area/*.
+// As in file paths, * doesn't match across a namespace separator.
+func MatchLabel(pattern, label string) bool {
+	ok, err := path.Match(pattern, label)
+	return err == nil && ok
+}
+
+// ValidateLabelPattern checks a label filter's glob syntax
+func ValidateLabelPattern(pattern string) error {
+	_, err := path.Match(pattern, "")
+	return err
+}
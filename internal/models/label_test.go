@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestSplitLabel(t *testing.T) {
+	tests := []struct {
+		label, namespace, name string
+	}{
+		{"area/auth", "area", "auth"},
+		{"team/platform/infra", "team", "platform/infra"},
+		{"docs", "", "docs"},
+		{"/auth", "", "/auth"},
+		{"area/", "", "area/"},
+	}
+	for _, tt := range tests {
+		if ns, name := SplitLabel(tt.label); ns != tt.namespace || name != tt.name {
+			t.Errorf("SplitLabel(%q) = %q, %q, want %q, %q", tt.label, ns, name, tt.namespace, tt.name)
+		}
+	}
+}
+
+func TestMatchLabel(t *testing.T) {
+	tests := []struct {
+		pattern, label string
+		want           bool
+	}{
+		{"area/*", "area/auth", true},
+		{"area/*", "area", false},
+		{"area/*", "area/auth/oauth", false},
+		{"*/platform", "team/platform", true},
+		{"area/a*", "area/api", true},
+		{"area/a*", "area/ui", false},
+		{"[", "[", false},
+	}
+	for _, tt := range tests {
+		if got := MatchLabel(tt.pattern, tt.label); got != tt.want {
+			t.Errorf("MatchLabel(%q, %q) = %v, want %v", tt.pattern, tt.label, got, tt.want)
+		}
+	}
+	if err := ValidateLabelPattern("area/["); err == nil {
+		t.Error("ValidateLabelPattern(area/[) should fail")
+	}
+}
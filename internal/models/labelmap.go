@@ -0,0 +1,242 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultLabelMapSpec reproduces the labels push has always used
+const DefaultLabelMapSpec = "bug=bug#d73a4a,feature=enhancement#a2eeef,epic=epic#3e4b9e," +
+	"P0=priority: critical#b60205,P1=priority: high#d93f0b,blocked=blocked#e4e669"
+
+// LabelMapBlocked is the local key for the blocked status
+const LabelMapBlocked = "blocked"
+
+var (
+	labelPriorityKey = regexp.MustCompile(`^[Pp]([0-4])$`)
+	labelColorRegex  = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+)
+
+// LabelMapEntry maps one local key to a GitHub label.
+// Local keys are task types (bug, feature, epic, task), priorities (P0-P4),
+// "blocked", or any local label name. A key with * maps a whole label
+// namespace: area/*=area: * pushes area/auth as "area: auth", and */*=*: *
+// does so for every namespace. The GitHub side has as many * as the key.
+type LabelMapEntry struct {
+	Local  string `json:"local"`
+	GitHub string `json:"github"`
+	Color  string `json:"color,omitempty"` // Hex color used when creating the label on GitHub
+}
+
+// LabelMap translates between local task attributes/labels and GitHub labels
+type LabelMap struct {
+	Entries []LabelMapEntry `json:"entries"`
+}
+
+// ParseLabelMap parses a spec like "bug=bug,P0=priority: critical#b60205"
+func ParseLabelMap(spec string) (LabelMap, error) {
+	var m LabelMap
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		local, remote, ok := strings.Cut(part, "=")
+		local, remote = strings.TrimSpace(local), strings.TrimSpace(remote)
+		if !ok || local == "" || remote == "" {
+			return LabelMap{}, fmt.Errorf("invalid label mapping '%s': expected local=github", part)
+		}
+		entry := LabelMapEntry{Local: local, GitHub: remote}
+		if i := strings.LastIndex(remote, "#"); i >= 0 {
+			color := remote[i+1:]
+			if !labelColorRegex.MatchString(color) {
+				return LabelMap{}, fmt.Errorf("invalid color '%s' in mapping '%s': expected 6 hex digits", color, part)
+			}
+			entry.GitHub = strings.TrimSpace(remote[:i])
+			entry.Color = strings.ToLower(color)
+		}
+		if n := strings.Count(entry.Local, "*"); n > 0 {
+			if strings.Count(entry.GitHub, "*") != n {
+				return LabelMap{}, fmt.Errorf("invalid label mapping '%s': both sides need the same number of *", part)
+			}
+			if entry.Color != "" {
+				return LabelMap{}, fmt.Errorf("invalid label mapping '%s': a namespace mapping can't set a color", part)
+			}
+		}
+		key := strings.ToLower(entry.Local)
+		if seen[key] {
+			return LabelMap{}, fmt.Errorf("duplicate label mapping for '%s'", entry.Local)
+		}
+		seen[key] = true
+		m.Entries = append(m.Entries, entry)
+	}
+	return m, nil
+}
+
+// DefaultLabelMap returns the built-in mapping
+func DefaultLabelMap() LabelMap {
+	m, _ := ParseLabelMap(DefaultLabelMapSpec)
+	return m
+}
+
+// String renders the mapping in the spec format accepted by ParseLabelMap
+func (m LabelMap) String() string {
+	parts := make([]string, len(m.Entries))
+	for i, e := range m.Entries {
+		parts[i] = e.Local + "=" + e.GitHub
+		if e.Color != "" {
+			parts[i] += "#" + e.Color
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ToGitHub returns the entry for a local key, matched case-insensitively.
+// Exact keys win over namespace mappings, whose GitHub name is filled in.
+func (m LabelMap) ToGitHub(local string) (LabelMapEntry, bool) {
+	for _, e := range m.Entries {
+		if strings.EqualFold(e.Local, local) {
+			return e, true
+		}
+	}
+	for _, e := range m.Entries {
+		if parts, ok := matchWildcards(e.Local, local); ok {
+			return LabelMapEntry{Local: local, GitHub: fillWildcards(e.GitHub, parts)}, true
+		}
+	}
+	return LabelMapEntry{}, false
+}
+
+// FromGitHub returns the local key for a GitHub label, matched case-insensitively
+func (m LabelMap) FromGitHub(name string) (string, bool) {
+	for _, e := range m.Entries {
+		if strings.EqualFold(e.GitHub, name) {
+			return e.Local, true
+		}
+	}
+	for _, e := range m.Entries {
+		if parts, ok := matchWildcards(e.GitHub, name); ok {
+			return fillWildcards(e.Local, parts), true
+		}
+	}
+	return "", false
+}
+
+// matchWildcards matches s against a pattern whose * stand for non-empty
+// text, case-insensitively, and returns the text of each *. Earlier * match
+// as little as possible.
+func matchWildcards(pattern, s string) ([]string, bool) {
+	if !strings.Contains(pattern, "*") {
+		return nil, false
+	}
+	literals := strings.Split(pattern, "*")
+	expr := "(?i)^"
+	for i, lit := range literals {
+		expr += regexp.QuoteMeta(lit)
+		switch {
+		case i == len(literals)-1:
+		case i == len(literals)-2:
+			expr += "(.+)"
+		default:
+			expr += "(.+?)"
+		}
+	}
+	m := regexp.MustCompile(expr + "$").FindStringSubmatch(s)
+	if m == nil {
+		return nil, false
+	}
+	return m[1:], true
+}
+
+// fillWildcards replaces each * in pattern with the next of parts
+func fillWildcards(pattern string, parts []string) string {
+	for _, p := range parts {
+		pattern = strings.Replace(pattern, "*", p, 1)
+	}
+	return pattern
+}
+
+// isAttributeKey reports whether a local key maps a type, priority, or status
+// rather than a free-form label
+func isAttributeKey(key string) bool {
+	switch strings.ToLower(key) {
+	case TypeTask, TypeBug, TypeFeature, TypeEpic, LabelMapBlocked:
+		return true
+	}
+	return labelPriorityKey.MatchString(key)
+}
+
+// Managed returns the GitHub labels derived from task attributes; push adds
+// and removes these as the task changes
+func (m LabelMap) Managed() []string {
+	var names []string
+	for _, e := range m.Entries {
+		if isAttributeKey(e.Local) {
+			names = append(names, e.GitHub)
+		}
+	}
+	return names
+}
+
+// LabelsForTask returns the GitHub labels for a task: mapped type, priority,
+// and blocked status, followed by its own labels (mapped where configured)
+func (m LabelMap) LabelsForTask(t Task) []string {
+	var labels []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			labels = append(labels, name)
+		}
+	}
+
+	if e, ok := m.ToGitHub(t.Type); ok {
+		add(e.GitHub)
+	}
+	if e, ok := m.ToGitHub(fmt.Sprintf("P%d", t.Priority)); ok {
+		add(e.GitHub)
+	}
+	if t.IsBlocked() {
+		if e, ok := m.ToGitHub(LabelMapBlocked); ok {
+			add(e.GitHub)
+		}
+	}
+	for _, l := range t.Labels {
+		if e, ok := m.ToGitHub(l); ok && !isAttributeKey(e.Local) {
+			add(e.GitHub)
+		} else {
+			add(l)
+		}
+	}
+	return labels
+}
+
+// ApplyToTask sets type, priority, blocked status, and labels on a task from
+// its GitHub labels. Unmapped labels are kept as local labels unchanged.
+func (m LabelMap) ApplyToTask(t *Task, githubLabels []string) {
+	for _, name := range githubLabels {
+		local, ok := m.FromGitHub(name)
+		if !ok {
+			t.AddLabel(name)
+			continue
+		}
+
+		lower := strings.ToLower(local)
+		switch {
+		case lower == TypeTask || lower == TypeBug || lower == TypeFeature || lower == TypeEpic:
+			t.Type = lower
+		case labelPriorityKey.MatchString(local):
+			p, _ := strconv.Atoi(labelPriorityKey.FindStringSubmatch(local)[1])
+			t.Priority = p
+		case lower == LabelMapBlocked:
+			if !t.IsClosed() {
+				t.Block("Blocked on GitHub")
+			}
+		default:
+			t.AddLabel(local)
+		}
+	}
+}
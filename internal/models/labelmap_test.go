@@ -0,0 +1,92 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLabelMap(t *testing.T) {
+	m, err := ParseLabelMap("bug=bug, P0=priority: critical#B60205 ,frontend=area: ui")
+	if err != nil {
+		t.Fatalf("ParseLabelMap() error: %v", err)
+	}
+	want := []LabelMapEntry{
+		{Local: "bug", GitHub: "bug"},
+		{Local: "P0", GitHub: "priority: critical", Color: "b60205"},
+		{Local: "frontend", GitHub: "area: ui"},
+	}
+	if !reflect.DeepEqual(m.Entries, want) {
+		t.Errorf("ParseLabelMap() = %+v, want %+v", m.Entries, want)
+	}
+	if got := m.String(); got != "bug=bug,P0=priority: critical#b60205,frontend=area: ui" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, bad := range []string{"bug", "=bug", "P0=high#zzz", "bug=a,BUG=b"} {
+		if _, err := ParseLabelMap(bad); err == nil {
+			t.Errorf("ParseLabelMap(%q) should fail", bad)
+		}
+	}
+}
+
+func TestDefaultLabelMapMatchesLegacyLabels(t *testing.T) {
+	m := DefaultLabelMap()
+	task := Task{Type: TypeFeature, Priority: PriorityCritical, Status: StatusBlocked}
+	got := m.LabelsForTask(task)
+	want := []string{"enhancement", "priority: critical", "blocked"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LabelsForTask() = %v, want %v", got, want)
+	}
+}
+
+func TestLabelMapRoundTrip(t *testing.T) {
+	m, _ := ParseLabelMap("bug=type: bug,P1=prio-high,frontend=area: ui,blocked=on hold")
+	task := Task{Type: TypeBug, Priority: PriorityHigh, Labels: StringSlice{"frontend", "docs"}}
+
+	pushed := m.LabelsForTask(task)
+	want := []string{"type: bug", "prio-high", "area: ui", "docs"}
+	if !reflect.DeepEqual(pushed, want) {
+		t.Fatalf("LabelsForTask() = %v, want %v", pushed, want)
+	}
+
+	pulled := Task{Type: TypeTask, Priority: PriorityMedium, Status: StatusOpen}
+	m.ApplyToTask(&pulled, append(pushed, "On Hold"))
+	if pulled.Type != TypeBug || pulled.Priority != PriorityHigh {
+		t.Errorf("ApplyToTask() type=%s priority=%d, want bug/P1", pulled.Type, pulled.Priority)
+	}
+	if !reflect.DeepEqual([]string(pulled.Labels), []string{"frontend", "docs"}) {
+		t.Errorf("ApplyToTask() labels = %v, want [frontend docs]", pulled.Labels)
+	}
+	if !pulled.IsBlocked() {
+		t.Error("ApplyToTask() should block the task for the mapped blocked label")
+	}
+
+	if managed := m.Managed(); !reflect.DeepEqual(managed, []string{"type: bug", "prio-high", "on hold"}) {
+		t.Errorf("Managed() = %v", managed)
+	}
+}
+
+func TestLabelMapNamespaces(t *testing.T) {
+	m, err := ParseLabelMap("area/*=area: *,*/*=*: *,team/core=core")
+	if err != nil {
+		t.Fatalf("ParseLabelMap() error: %v", err)
+	}
+	task := Task{Type: TypeTask, Labels: StringSlice{"area/auth", "team/platform", "team/core", "docs"}}
+	want := []string{"area: auth", "team: platform", "core", "docs"}
+	if got := m.LabelsForTask(task); !reflect.DeepEqual(got, want) {
+		t.Errorf("LabelsForTask() = %v, want %v", got, want)
+	}
+
+	pulled := Task{}
+	m.ApplyToTask(&pulled, []string{"Area: Billing", "team: platform", "core", "help wanted"})
+	wantLabels := []string{"area/Billing", "team/platform", "team/core", "help wanted"}
+	if !reflect.DeepEqual([]string(pulled.Labels), wantLabels) {
+		t.Errorf("ApplyToTask() labels = %v, want %v", pulled.Labels, wantLabels)
+	}
+
+	for _, bad := range []string{"area/*=area", "area/*=area: *#ff0000"} {
+		if _, err := ParseLabelMap(bad); err == nil {
+			t.Errorf("ParseLabelMap(%q) should fail", bad)
+		}
+	}
+}
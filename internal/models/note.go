@@ -0,0 +1,82 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Note kind constants
+const (
+	NoteKindNote     = "note"     // General remark
+	NoteKindDecision = "decision" // A choice made and why
+	NoteKindBlocker  = "blocker"  // Something preventing progress
+	NoteKindLog      = "log"      // Progress/activity log
+)
+
+// NoteKinds lists the valid note kinds
+var NoteKinds = []string{NoteKindNote, NoteKindDecision, NoteKindBlocker, NoteKindLog}
+
+// IsValidNoteKind returns true if kind is a known note kind
+func IsValidNoteKind(kind string) bool {
+	for _, k := range NoteKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// NoteEntry is a single typed note on a task. Task.Notes keeps the rendered
+// text of all entries for display, search and compaction.
+type NoteEntry struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TaskID    string    `gorm:"size:30;not null;index:idx_note_task_kind,priority:1" json:"task_id"`
+	Kind      string    `gorm:"size:20;not null;default:note;index:idx_note_task_kind,priority:2" json:"kind"`
+	Author    string    `gorm:"size:100" json:"author,omitempty"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for NoteEntry
+func (NoteEntry) TableName() string {
+	return "note_entries"
+}
+
+// Line returns the text AppendNotes stores for this entry: the body,
+// prefixed with the kind unless it is a plain note
+func (n NoteEntry) Line() string {
+	if n.Kind == "" || n.Kind == NoteKindNote {
+		return n.Body
+	}
+	return n.Kind + ": " + n.Body
+}
+
+// ParseNotes splits a Notes blob written by AppendNotes back into entries.
+// Lines without a timestamp are continuation lines of the previous entry.
+func ParseNotes(taskID, notes string) []NoteEntry {
+	var entries []NoteEntry
+	for _, line := range strings.Split(strings.TrimRight(notes, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if len(line) > len(DateTimeFormat)+2 && line[0] == '[' && line[len(DateTimeFormat)+1] == ']' {
+			if ts, err := time.ParseInLocation(DateTimeFormat, line[1:len(DateTimeFormat)+1], time.Local); err == nil {
+				entry := NoteEntry{TaskID: taskID, Kind: NoteKindNote, Body: strings.TrimPrefix(line[len(DateTimeFormat)+2:], " "), CreatedAt: ts}
+				for _, kind := range NoteKinds {
+					if rest, ok := strings.CutPrefix(entry.Body, kind+": "); ok {
+						entry.Kind, entry.Body = kind, rest
+						break
+					}
+				}
+				entries = append(entries, entry)
+				continue
+			}
+		}
+		if len(entries) == 0 {
+			entries = append(entries, NoteEntry{TaskID: taskID, Kind: NoteKindNote, Body: line})
+			continue
+		}
+		entries[len(entries)-1].Body += "\n" + line
+	}
+	return entries
+}
@@ -0,0 +1,47 @@
+package models
+
+import "testing"
+
+func TestNoteEntryLine(t *testing.T) {
+	if got := (NoteEntry{Kind: NoteKindNote, Body: "plain"}).Line(); got != "plain" {
+		t.Errorf("Line() = %q, want plain", got)
+	}
+	if got := (NoteEntry{Kind: NoteKindDecision, Body: "use WAL"}).Line(); got != "decision: use WAL" {
+		t.Errorf("Line() = %q, want decision prefix", got)
+	}
+}
+
+func TestParseNotes(t *testing.T) {
+	notes := "loose text\n" +
+		"[2024-01-01 10:00:00] first\n" +
+		"[2024-01-02 11:30:00] decision: use WAL\n" +
+		"because readers\n" +
+		"[2024-01-03 09:00:00] blocker: waiting on creds\n"
+
+	entries := ParseNotes("gur-a1b2c3d4", notes)
+	if len(entries) != 4 {
+		t.Fatalf("ParseNotes() returned %d entries, want 4: %+v", len(entries), entries)
+	}
+
+	want := []struct{ kind, body string }{
+		{NoteKindNote, "loose text"},
+		{NoteKindNote, "first"},
+		{NoteKindDecision, "use WAL\nbecause readers"},
+		{NoteKindBlocker, "waiting on creds"},
+	}
+	for i, w := range want {
+		if entries[i].Kind != w.kind || entries[i].Body != w.body || entries[i].TaskID != "gur-a1b2c3d4" {
+			t.Errorf("entry %d = %+v, want %s %q", i, entries[i], w.kind, w.body)
+		}
+	}
+	if entries[2].CreatedAt.Format(DateTimeFormat) != "2024-01-02 11:30:00" {
+		t.Errorf("entry timestamp = %v", entries[2].CreatedAt)
+	}
+
+	// Round trip: each parsed entry renders back to its stored line
+	task := Task{}
+	task.AppendNotes(NoteEntry{Kind: NoteKindLog, Body: "ran tests"}.Line())
+	if got := ParseNotes("x", task.Notes); len(got) != 1 || got[0].Kind != NoteKindLog || got[0].Body != "ran tests" {
+		t.Errorf("round trip = %+v", got)
+	}
+}
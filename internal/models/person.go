@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Person kinds: who does the work an assignee stands for
+const (
+	PersonKindHuman = "human"
+	PersonKindAgent = "agent"
+)
+
+// PersonKinds lists the valid person kinds
+var PersonKinds = []string{PersonKindHuman, PersonKindAgent}
+
+// Person is a registered assignee
+type Person struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:100;uniqueIndex;not null" json:"name"` // Matches Task.Assignee
+	Kind      string    `gorm:"size:10;not null;index" json:"kind"`        // human or agent
+	Contact   string    `gorm:"size:255" json:"contact,omitempty"`         // Email, handle, etc.
+	Timezone  string    `gorm:"size:64" json:"timezone,omitempty"`         // IANA name, e.g. Europe/Berlin
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Person
+func (Person) TableName() string {
+	return "people"
+}
+
+// ValidatePersonKind checks a person kind
+func ValidatePersonKind(kind string) error {
+	for _, k := range PersonKinds {
+		if kind == k {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid kind '%s': must be one of %s", kind, strings.Join(PersonKinds, ", "))
+}
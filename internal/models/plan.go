@@ -0,0 +1,225 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PlanItem is an epic, task or subtask of a Markdown plan
+type PlanItem struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	Priority    int         `json:"priority"` // -1 without a [P0]-[P4] annotation
+	Labels      []string    `json:"labels,omitempty"`
+	Line        int         `json:"line"` // One-based line in the plan
+	Children    []*PlanItem `json:"children,omitempty"`
+}
+
+// Plan is a parsed Markdown plan. Headings become Sections (epics) and
+// their bullets tasks; bullets above the first heading are Tasks of their
+// own. Checked items ("- [x]") are already done and left out, with their
+// subitems, and counted in Done.
+type Plan struct {
+	Title    string      `json:"title,omitempty"`
+	Sections []*PlanItem `json:"sections"`
+	Tasks    []*PlanItem `json:"tasks,omitempty"`
+	Done     int         `json:"done,omitempty"`
+}
+
+var (
+	planHeadingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	planBulletPattern  = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(.*\S)\s*$`)
+	planBoxPattern     = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	planFencePattern   = regexp.MustCompile("^\\s*(```|~~~)")
+	planPriorityTag    = regexp.MustCompile(`(?i)\[P([0-4])\]`)
+	planLabelTag       = regexp.MustCompile(`(?:^|\s)#([A-Za-z][\w./-]*)`)
+)
+
+// ParsePlanAnnotations strips [P1] and #label annotations from a line and
+// returns what is left as the title. priority is -1 if none is given.
+func ParsePlanAnnotations(line string) (title string, priority int, labels []string) {
+	priority = -1
+	if m := planPriorityTag.FindStringSubmatch(line); m != nil {
+		priority, _ = strconv.Atoi(m[1])
+	}
+	line = planPriorityTag.ReplaceAllString(line, "")
+	for _, m := range planLabelTag.FindAllStringSubmatch(line, -1) {
+		labels = append(labels, strings.TrimRight(m[1], "./-"))
+	}
+	line = planLabelTag.ReplaceAllString(line, "")
+	return strings.Join(strings.Fields(line), " "), priority, labels
+}
+
+// ParsePlan parses a Markdown plan. Sections are the top heading level; a
+// lone top heading over deeper ones is the plan's title, and the next level
+// down makes the sections. Deeper headings and other text are kept in the
+// description of the section or, when indented under a bullet, the bullet's.
+func ParsePlan(text string) Plan {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	// Find the heading level that makes sections
+	counts := make(map[int]int)
+	inFence := false
+	for _, line := range lines {
+		if planFencePattern.MatchString(line) {
+			inFence = !inFence
+		}
+		if m := planHeadingPattern.FindStringSubmatch(line); m != nil && !inFence {
+			counts[len(m[1])]++
+		}
+	}
+	sectionLevel, titleLevel := 0, 0
+	for level := 1; level <= 6; level++ {
+		if counts[level] == 0 {
+			continue
+		}
+		if sectionLevel == 0 {
+			sectionLevel = level
+			continue
+		}
+		if counts[sectionLevel] == 1 {
+			titleLevel, sectionLevel = sectionLevel, level
+		}
+		break
+	}
+
+	var plan Plan
+	var section *PlanItem
+	type open struct {
+		item   *PlanItem
+		indent int
+	}
+	var stack []open // Bullets the next line may nest under
+	skipIndent := -1 // Indent of a checked bullet whose subitems are skipped
+	inFence = false
+
+	describe := func(item *PlanItem, line string) {
+		if item.Description == "" && strings.TrimSpace(line) == "" {
+			return
+		}
+		item.Description += line + "\n"
+	}
+	var fenceTarget *PlanItem // Where the lines of an open code block go
+	fenceIndent := ""
+	for i, line := range lines {
+		if inFence {
+			if planFencePattern.MatchString(line) {
+				inFence = false
+			}
+			if fenceTarget != nil {
+				describe(fenceTarget, strings.TrimPrefix(line, fenceIndent))
+			}
+			continue
+		}
+
+		if m := planHeadingPattern.FindStringSubmatch(line); m != nil {
+			level := len(m[1])
+			switch {
+			case level == titleLevel:
+				plan.Title = strings.TrimSpace(m[2])
+				section, stack, skipIndent = nil, nil, -1
+				continue
+			case level == sectionLevel:
+				title, priority, labels := ParsePlanAnnotations(m[2])
+				section = &PlanItem{Title: title, Priority: priority, Labels: labels, Line: i + 1}
+				plan.Sections = append(plan.Sections, section)
+				stack, skipIndent = nil, -1
+				continue
+			case level < sectionLevel:
+				// Headings above the sections, e.g. a second title
+				section, stack, skipIndent = nil, nil, -1
+				continue
+			}
+		}
+
+		m := planBulletPattern.FindStringSubmatch(line)
+		indent := len(strings.ReplaceAll(leadingSpace(line), "\t", "    "))
+		if m == nil {
+			// Text indented under a bullet belongs to it; the rest to the section
+			if strings.TrimSpace(line) != "" && skipIndent >= 0 && indent > skipIndent {
+				continue
+			}
+			for len(stack) > 0 && strings.TrimSpace(line) != "" && indent <= stack[len(stack)-1].indent {
+				stack = stack[:len(stack)-1]
+			}
+			target, text := section, line
+			if len(stack) > 0 {
+				target, text = stack[len(stack)-1].item, strings.TrimLeft(line, " \t")
+			}
+			if planFencePattern.MatchString(line) {
+				inFence, fenceTarget, fenceIndent = true, target, leadingSpace(line)
+				if target == section {
+					fenceIndent = ""
+				}
+			}
+			if target != nil {
+				describe(target, text)
+			}
+			continue
+		}
+
+		if skipIndent >= 0 && indent > skipIndent {
+			plan.Done++
+			continue
+		}
+		skipIndent = -1
+		for len(stack) > 0 && indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		text := m[2]
+		if box := planBoxPattern.FindStringSubmatch(text); box != nil {
+			if box[1] != " " {
+				plan.Done++
+				skipIndent = indent
+				continue
+			}
+			text = text[len(box[0]):]
+		}
+		title, priority, labels := ParsePlanAnnotations(text)
+		if title == "" {
+			continue
+		}
+		item := &PlanItem{Title: title, Priority: priority, Labels: labels, Line: i + 1}
+		switch {
+		case len(stack) > 0:
+			parent := stack[len(stack)-1].item
+			parent.Children = append(parent.Children, item)
+		case section != nil:
+			section.Children = append(section.Children, item)
+		default:
+			plan.Tasks = append(plan.Tasks, item)
+		}
+		stack = append(stack, open{item, indent})
+	}
+
+	trimDescriptions(plan.Sections)
+	trimDescriptions(plan.Tasks)
+	return plan
+}
+
+// leadingSpace returns the indentation of a line
+func leadingSpace(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// trimDescriptions drops trailing blank lines from the items' descriptions
+func trimDescriptions(items []*PlanItem) {
+	for _, item := range items {
+		item.Description = strings.TrimRight(item.Description, " \t\n")
+		trimDescriptions(item.Children)
+	}
+}
+
+// Count returns the number of items in the plan
+func (p Plan) Count() int {
+	var count func(items []*PlanItem) int
+	count = func(items []*PlanItem) int {
+		n := len(items)
+		for _, item := range items {
+			n += count(item.Children)
+		}
+		return n
+	}
+	return count(p.Sections) + count(p.Tasks)
+}
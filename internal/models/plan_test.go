@@ -0,0 +1,72 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePlanAnnotations(t *testing.T) {
+	title, priority, labels := ParsePlanAnnotations("Add login [P1] #area/auth form #frontend. See #12")
+	if title != "Add login form See #12" || priority != 1 || !reflect.DeepEqual(labels, []string{"area/auth", "frontend"}) {
+		t.Errorf("ParsePlanAnnotations() = %q, %d, %v", title, priority, labels)
+	}
+	if _, priority, _ := ParsePlanAnnotations("No annotations"); priority != -1 {
+		t.Errorf("priority without [P] = %d, want -1", priority)
+	}
+}
+
+func TestParsePlan(t *testing.T) {
+	plan := ParsePlan(`# Q3 auth rework
+
+- Write an RFC #docs
+
+## Sessions [P1] #area/auth
+Move sessions out of memory.
+
+- Store sessions in Redis
+  - Add a Redis client [P0]
+    Use go-redis.
+- [x] Pick a library
+  - Compare options
+- [ ] Expire old sessions
+
+` + "```" + `
+- not a bullet
+` + "```" + `
+
+## Login
+1. Login form
+2. Error states
+`)
+
+	if plan.Title != "Q3 auth rework" || len(plan.Sections) != 2 || len(plan.Tasks) != 1 || plan.Done != 2 {
+		t.Fatalf("ParsePlan() = %+v", plan)
+	}
+	if plan.Count() != 8 {
+		t.Errorf("Count() = %d, want 8", plan.Count())
+	}
+	sessions := plan.Sections[0]
+	if sessions.Title != "Sessions" || sessions.Priority != 1 || !reflect.DeepEqual(sessions.Labels, []string{"area/auth"}) {
+		t.Errorf("section = %+v", sessions)
+	}
+	if want := "Move sessions out of memory.\n\n```\n- not a bullet\n```"; sessions.Description != want {
+		t.Errorf("section description = %q, want %q", sessions.Description, want)
+	}
+	if len(sessions.Children) != 2 || sessions.Children[1].Title != "Expire old sessions" {
+		t.Fatalf("section tasks = %+v", sessions.Children)
+	}
+	redis := sessions.Children[0]
+	if len(redis.Children) != 1 || redis.Children[0].Priority != 0 || redis.Children[0].Description != "Use go-redis." {
+		t.Errorf("subtasks = %+v", redis.Children)
+	}
+	if login := plan.Sections[1]; len(login.Children) != 2 || login.Children[0].Title != "Login form" {
+		t.Errorf("numbered list = %+v", login.Children)
+	}
+}
+
+func TestParsePlanSingleHeading(t *testing.T) {
+	plan := ParsePlan("# Only section\n- A task\n")
+	if plan.Title != "" || len(plan.Sections) != 1 || len(plan.Sections[0].Children) != 1 {
+		t.Errorf("ParsePlan() = %+v, want a single section", plan)
+	}
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Reference kind constants
+const (
+	RefKindIssue = "issue" // #123 or owner/repo#45
+	RefKindURL   = "url"
+)
+
+// Reference source constants: the task text a reference was found in
+const (
+	RefSourceDescription = "description"
+	RefSourceNotes       = "notes"
+)
+
+// Reference is an issue or URL mentioned in a task's description or notes
+type Reference struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	TaskID     string    `gorm:"size:30;not null;index" json:"task_id"`
+	Kind       string    `gorm:"size:10;not null" json:"kind"`
+	Repository string    `gorm:"size:200" json:"repository,omitempty"` // owner/repo; empty for the project's own repo
+	Number     int       `json:"number,omitempty"`
+	URL        string    `gorm:"size:2000" json:"url,omitempty"`
+	Source     string    `gorm:"size:20;not null" json:"source"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for Reference
+func (Reference) TableName() string {
+	return "task_references"
+}
+
+// String returns the reference as it is written: #123, owner/repo#45 or
+// the URL
+func (r Reference) String() string {
+	if r.Kind == RefKindURL {
+		return r.URL
+	}
+	if r.Repository != "" {
+		return fmt.Sprintf("%s#%d", r.Repository, r.Number)
+	}
+	return fmt.Sprintf("#%d", r.Number)
+}
+
+// Link returns where the reference points. Issue references resolve
+// against webURL (e.g. https://github.com) and, without a repository of
+// their own, against repo; "" if there is no repo to resolve them in.
+func (r Reference) Link(webURL, repo string) string {
+	if r.Kind == RefKindURL {
+		return r.URL
+	}
+	if r.Repository != "" {
+		repo = r.Repository
+	}
+	if repo == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/issues/%d", strings.TrimRight(webURL, "/"), repo, r.Number)
+}
+
+// changeURLRegex matches the paths of pull requests (merge requests) and
+// commits on GitHub and similar forges
+var changeURLRegex = regexp.MustCompile(`/(?:pull|pulls|merge_requests)/\d+(?:[/?#]|$)|/commits?/[0-9a-fA-F]{7,40}(?:[/?#]|$)`)
+
+// IsChange reports whether the reference is a URL of a pull request or a
+// commit, i.e. it links the task to the code that did the work
+func (r Reference) IsChange() bool {
+	return r.Kind == RefKindURL && changeURLRegex.MatchString(r.URL)
+}
+
+var (
+	// refURLRegex matches http(s) URLs; trailing punctuation is trimmed
+	refURLRegex = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+	// refIssueRegex matches #123 and owner/repo#45, but not anchors in
+	// URLs, HTML entities (&#123;) or hex colors
+	refIssueRegex = regexp.MustCompile(`(?:^|[^\w/#&.-])((?:[A-Za-z0-9][\w.-]*/[\w.-]+)?)#(\d+)\b`)
+)
+
+// ExtractReferences returns the distinct references in text, in order
+func ExtractReferences(text, source string) []Reference {
+	var refs []Reference
+	seen := make(map[string]bool)
+	add := func(r Reference) {
+		if key := r.String(); !seen[key] {
+			seen[key] = true
+			refs = append(refs, r)
+		}
+	}
+
+	type match struct {
+		at  int
+		ref Reference
+	}
+	var matches []match
+	for _, loc := range refURLRegex.FindAllStringIndex(text, -1) {
+		u := strings.TrimRight(text[loc[0]:loc[1]], ".,;:!?")
+		matches = append(matches, match{loc[0], Reference{Kind: RefKindURL, URL: u, Source: source}})
+	}
+	// Blank out URLs so their fragments aren't taken for issue numbers
+	rest := refURLRegex.ReplaceAllStringFunc(text, func(u string) string { return strings.Repeat(" ", len(u)) })
+	for _, m := range refIssueRegex.FindAllStringSubmatchIndex(rest, -1) {
+		n, err := strconv.Atoi(rest[m[4]:m[5]])
+		if err != nil || n == 0 {
+			continue
+		}
+		matches = append(matches, match{m[2], Reference{Kind: RefKindIssue, Repository: rest[m[2]:m[3]], Number: n, Source: source}})
+	}
+
+	// URLs and issues were found in two passes; report them in text order
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].at < matches[j].at })
+	for _, m := range matches {
+		add(m.ref)
+	}
+	return refs
+}
+
+// TaskReferences returns the references in a task's description and notes
+func TaskReferences(t *Task) []Reference {
+	refs := ExtractReferences(t.Description, RefSourceDescription)
+	seen := make(map[string]bool, len(refs))
+	for _, r := range refs {
+		seen[r.String()] = true
+	}
+	for _, r := range ExtractReferences(t.Notes, RefSourceNotes) {
+		if !seen[r.String()] {
+			refs = append(refs, r)
+		}
+	}
+	return refs
+}
+
+// IndexReferences replaces the stored references of a task with those in
+// its current text, leaving them alone if they haven't changed
+func IndexReferences(tx *gorm.DB, t *Task) error {
+	found := TaskReferences(t)
+	var stored []Reference
+	if err := tx.Where("task_id = ?", t.ID).Order("id ASC").Find(&stored).Error; err != nil {
+		return err
+	}
+	if len(stored) == len(found) {
+		same := true
+		for i := range found {
+			if found[i].String() != stored[i].String() || found[i].Source != stored[i].Source {
+				same = false
+				break
+			}
+		}
+		if same {
+			return nil
+		}
+	}
+	if err := tx.Where("task_id = ?", t.ID).Delete(&Reference{}).Error; err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	for i := range found {
+		found[i].TaskID = t.ID
+	}
+	return tx.Create(&found).Error
+}
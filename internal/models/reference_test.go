@@ -0,0 +1,69 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractReferences(t *testing.T) {
+	text := "Fixes #12 and acme/api#45 (see https://example.com/docs#3.)\n" +
+		"Not refs: &#123; color #fff, ##9, https://github.com/acme/api/pull/7#issuecomment-1, #12 again"
+
+	var got []string
+	for _, r := range ExtractReferences(text, RefSourceDescription) {
+		got = append(got, r.Kind+":"+r.String())
+	}
+	want := []string{
+		"issue:#12",
+		"issue:acme/api#45",
+		"url:https://example.com/docs#3",
+		"url:https://github.com/acme/api/pull/7#issuecomment-1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractReferences() = %v, want %v", got, want)
+	}
+	if refs := ExtractReferences("", RefSourceNotes); len(refs) != 0 {
+		t.Errorf("ExtractReferences(\"\") = %v, want none", refs)
+	}
+}
+
+func TestReferenceLink(t *testing.T) {
+	tests := []struct {
+		ref  Reference
+		repo string
+		want string
+	}{
+		{Reference{Kind: RefKindIssue, Number: 12}, "acme/web", "https://github.com/acme/web/issues/12"},
+		{Reference{Kind: RefKindIssue, Repository: "acme/api", Number: 45}, "acme/web", "https://github.com/acme/api/issues/45"},
+		{Reference{Kind: RefKindIssue, Number: 12}, "", ""},
+		{Reference{Kind: RefKindURL, URL: "https://example.com"}, "", "https://example.com"},
+	}
+	for _, tt := range tests {
+		if got := tt.ref.Link("https://github.com/", tt.repo); got != tt.want {
+			t.Errorf("%s.Link() = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestReferenceIsChange(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://github.com/acme/app/pull/12", true},
+		{"https://github.com/acme/app/pull/12/files", true},
+		{"https://github.com/acme/app/commit/3f2a9c1", true},
+		{"https://gitlab.com/acme/app/-/merge_requests/4", true},
+		{"https://github.com/acme/app/issues/7", false},
+		{"https://github.com/acme/app/pulls", false},
+		{"https://github.com/acme/app/commit/main", false},
+	}
+	for _, tt := range tests {
+		if got := (Reference{Kind: RefKindURL, URL: tt.url}).IsChange(); got != tt.want {
+			t.Errorf("IsChange(%s) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+	if (Reference{Kind: RefKindIssue, Number: 12}).IsChange() {
+		t.Error("IsChange() for #12 should be false: it may be an issue")
+	}
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// Release statuses
+const (
+	ReleaseStatusOpen     = "open"
+	ReleaseStatusReleased = "released"
+)
+
+// ReleaseLabelPrefix tags tasks shipped in a release, e.g. "release:v1.3.0"
+const ReleaseLabelPrefix = "release:"
+
+// Release is a version that tasks are targeted at
+type Release struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Name        string     `gorm:"size:50;uniqueIndex;not null" json:"name"` // e.g. v1.3.0
+	Description string     `gorm:"type:text" json:"description,omitempty"`
+	Status      string     `gorm:"size:20;default:open;index" json:"status"`
+	TargetAt    *time.Time `json:"target_at,omitempty"`
+	ReleasedAt  *time.Time `json:"released_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Release
+func (Release) TableName() string {
+	return "releases"
+}
+
+// IsReleased returns true once the release has been cut
+func (r *Release) IsReleased() bool {
+	return r.Status == ReleaseStatusReleased
+}
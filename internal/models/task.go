@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -16,10 +17,58 @@ import (
 const (
 	StatusOpen       = "open"
 	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked" // Waiting on something outside the dependency graph
 	StatusClosed     = "closed"
 	StatusArchived   = "archived"
+	StatusInbox      = "inbox" // Captured but not yet triaged into a task
 )
 
+// Close resolution constants: why a task was closed
+const (
+	ResolutionCompleted  = "completed"
+	ResolutionWontfix    = "wontfix"
+	ResolutionDuplicate  = "duplicate"
+	ResolutionInvalid    = "invalid"
+	ResolutionSuperseded = "superseded"
+)
+
+// Resolutions lists the valid close resolutions in display order
+var Resolutions = []string{ResolutionCompleted, ResolutionWontfix, ResolutionDuplicate, ResolutionInvalid, ResolutionSuperseded}
+
+// ValidateResolution returns an error unless r is a known close resolution
+func ValidateResolution(r string) error {
+	for _, known := range Resolutions {
+		if r == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid close resolution '%s': must be one of %s", r, strings.Join(Resolutions, ", "))
+}
+
+// ResolutionStateReason returns the GitHub state_reason for a resolution.
+// GitHub only distinguishes finished work from work that won't be done.
+func ResolutionStateReason(r string) string {
+	if r == "" || r == ResolutionCompleted {
+		return "completed"
+	}
+	return "not_planned"
+}
+
+// ResolutionFromStateReason maps a GitHub state_reason to a resolution
+func ResolutionFromStateReason(reason string) string {
+	switch reason {
+	case "not_planned":
+		return ResolutionWontfix
+	case "duplicate":
+		return ResolutionDuplicate
+	default:
+		return ResolutionCompleted
+	}
+}
+
+// ForceClosePrefix marks the close reason of a task closed with --force
+const ForceClosePrefix = "[FORCE CLOSED] "
+
 // Task source constants
 const (
 	SourceLocal  = "local"
@@ -47,6 +96,7 @@ const (
 const (
 	DateTimeFormat      = "2006-01-02 15:04:05"
 	DateTimeShortFormat = "2006-01-02 15:04"
+	DateFormat          = "2006-01-02"
 )
 
 // ID generation constants
@@ -76,6 +126,8 @@ type Task struct {
 	Assignee    string         `gorm:"size:100;index" json:"assignee,omitempty"`
 	Notes       string         `gorm:"type:text" json:"notes,omitempty"`
 	CloseReason string         `gorm:"size:255" json:"close_reason,omitempty"`
+	Resolution  string         `gorm:"size:20;index" json:"resolution,omitempty"` // Why it was closed (see Resolutions)
+	BlockReason string         `gorm:"size:255" json:"block_reason,omitempty"`
 	Summary     string         `gorm:"type:text" json:"summary,omitempty"`
 	Compacted   bool           `gorm:"default:false" json:"compacted"`
 	Synced      bool           `gorm:"default:false;index" json:"synced"`
@@ -83,7 +135,19 @@ type Task struct {
 	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	ClosedAt    *time.Time     `json:"closed_at,omitempty"`
+	DueAt       *time.Time     `gorm:"index" json:"due_at,omitempty"`
+	Estimate    int            `gorm:"default:0" json:"estimate_minutes,omitempty"` // Estimated effort in minutes, 0 if none
+	Rank        int            `gorm:"default:0" json:"rank,omitempty"`             // Manual order within a priority, lowest first; 0 if unranked
+	Release     string         `gorm:"size:50;index" json:"release,omitempty"`      // Release the task is targeted at (see 'gur release')
+	Votes       int            `gorm:"default:0" json:"votes,omitempty"`            // +1 reactions on the linked GitHub issue (see 'gur sync pull --votes')
+	Checklist   string         `gorm:"type:text" json:"checklist_item,omitempty"`   // Parent checklist item this subtask was expanded from (see 'gur expand')
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Fields holds custom field values by name; loaded on demand, not stored on the task row
+	Fields map[string]string `gorm:"-" json:"fields,omitempty"`
+
+	// Criteria holds the acceptance criteria in order; loaded on demand like Fields
+	Criteria []AcceptanceCriterion `gorm:"-" json:"acceptance_criteria,omitempty"`
 }
 
 // StringSlice is a custom type for storing string slices as JSON in the database
@@ -186,6 +250,15 @@ func (t *Task) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// AfterSave hook to index the issues and URLs mentioned in the task's text.
+// Compacted tasks keep the references found before their text was cleared.
+func (t *Task) AfterSave(tx *gorm.DB) error {
+	if t.ID == "" || t.Compacted {
+		return nil
+	}
+	return IndexReferences(tx, t)
+}
+
 // AfterDelete hook to clean up orphaned dependencies when a task is deleted
 func (t *Task) AfterDelete(tx *gorm.DB) error {
 	// Soft-delete all dependencies where this task is the parent (blocker)
@@ -196,6 +269,10 @@ func (t *Task) AfterDelete(tx *gorm.DB) error {
 	if err := tx.Where("child_id = ?", t.ID).Delete(&Dependency{}).Error; err != nil {
 		return err
 	}
+	// References are rebuilt from the task's text if it is restored
+	if err := tx.Where("task_id = ?", t.ID).Delete(&Reference{}).Error; err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -204,6 +281,41 @@ func (t *Task) IsClosed() bool {
 	return t.Status == StatusClosed
 }
 
+// WasForceClosed returns true if the task was closed bypassing its checks
+func (t *Task) WasForceClosed() bool {
+	return strings.HasPrefix(t.CloseReason, ForceClosePrefix)
+}
+
+// IsBlocked returns true if the task has been manually marked as blocked
+func (t *Task) IsBlocked() bool {
+	return t.Status == StatusBlocked
+}
+
+// Block marks the task as blocked with the given reason
+func (t *Task) Block(reason string) {
+	t.Status = StatusBlocked
+	t.BlockReason = reason
+}
+
+// Unblock returns a blocked task to open status
+func (t *Task) Unblock() {
+	t.Status = StatusOpen
+	t.BlockReason = ""
+}
+
+// IsOverdue returns true if the task is still open past its due date
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.DueAt != nil && !t.IsClosed() && !t.IsArchived() && t.DueAt.Before(now)
+}
+
+// DueString returns the due date, or "" if the task has none
+func (t *Task) DueString() string {
+	if t.DueAt == nil {
+		return ""
+	}
+	return t.DueAt.Format(DateFormat)
+}
+
 // IsArchived returns true if the task is archived
 func (t *Task) IsArchived() bool {
 	return t.Status == StatusArchived
@@ -240,7 +352,13 @@ func (t *Task) Compact() {
 
 // Close marks the task as closed with the given reason
 func (t *Task) Close(reason string) {
+	t.CloseAs(ResolutionCompleted, reason)
+}
+
+// CloseAs marks the task as closed with a resolution and reason
+func (t *Task) CloseAs(resolution, reason string) {
 	t.Status = StatusClosed
+	t.Resolution = resolution
 	t.CloseReason = reason
 	now := time.Now()
 	t.ClosedAt = &now
@@ -250,9 +368,32 @@ func (t *Task) Close(reason string) {
 func (t *Task) Reopen() {
 	t.Status = StatusOpen
 	t.CloseReason = ""
+	t.Resolution = ""
 	t.ClosedAt = nil
 }
 
+// CompactWithSummary compacts the task using the given summary in place of
+// the generated one (e.g., a summary produced by an LLM)
+func (t *Task) CompactWithSummary(summary string) {
+	if t.Compacted {
+		return
+	}
+	t.Compact()
+	if summary != "" {
+		t.Summary = summary
+	}
+}
+
+// HasLabel reports whether the task carries the label
+func (t *Task) HasLabel(label string) bool {
+	for _, l := range t.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
 // AddLabel adds a label if it doesn't already exist
 func (t *Task) AddLabel(label string) {
 	for _, l := range t.Labels {
@@ -255,6 +255,53 @@ func TestTaskClose(t *testing.T) {
 	}
 }
 
+func TestTaskCloseAs(t *testing.T) {
+	task := &Task{ID: "gur-a1b2c3d4", Status: StatusOpen}
+
+	task.CloseAs(ResolutionWontfix, "out of scope")
+	if task.Status != StatusClosed || task.Resolution != ResolutionWontfix || task.CloseReason != "out of scope" {
+		t.Errorf("CloseAs() = status %s, resolution %s, reason %q", task.Status, task.Resolution, task.CloseReason)
+	}
+
+	task.Reopen()
+	if task.Resolution != "" {
+		t.Errorf("Reopen() resolution = %s, want empty", task.Resolution)
+	}
+}
+
+func TestResolutions(t *testing.T) {
+	for _, r := range Resolutions {
+		if err := ValidateResolution(r); err != nil {
+			t.Errorf("ValidateResolution(%s): %v", r, err)
+		}
+	}
+	if err := ValidateResolution("abandoned"); err == nil {
+		t.Error("expected error for unknown resolution")
+	}
+
+	tests := []struct {
+		resolution, stateReason string
+	}{
+		{"", "completed"},
+		{ResolutionCompleted, "completed"},
+		{ResolutionWontfix, "not_planned"},
+		{ResolutionDuplicate, "not_planned"},
+		{ResolutionSuperseded, "not_planned"},
+	}
+	for _, tt := range tests {
+		if got := ResolutionStateReason(tt.resolution); got != tt.stateReason {
+			t.Errorf("ResolutionStateReason(%q) = %s, want %s", tt.resolution, got, tt.stateReason)
+		}
+	}
+
+	if got := ResolutionFromStateReason("not_planned"); got != ResolutionWontfix {
+		t.Errorf("ResolutionFromStateReason(not_planned) = %s", got)
+	}
+	if got := ResolutionFromStateReason(""); got != ResolutionCompleted {
+		t.Errorf("ResolutionFromStateReason(\"\") = %s", got)
+	}
+}
+
 func TestTaskReopen(t *testing.T) {
 	now := time.Now()
 	task := &Task{
@@ -277,6 +324,26 @@ func TestTaskReopen(t *testing.T) {
 	}
 }
 
+func TestTaskBlockUnblock(t *testing.T) {
+	task := &Task{ID: "gur-a1b2c3d4", Status: StatusInProgress}
+
+	task.Block("waiting on infra")
+	if !task.IsBlocked() {
+		t.Errorf("Block() status = %s, want %s", task.Status, StatusBlocked)
+	}
+	if task.BlockReason != "waiting on infra" {
+		t.Errorf("Block() reason = %q, want %q", task.BlockReason, "waiting on infra")
+	}
+
+	task.Unblock()
+	if task.Status != StatusOpen {
+		t.Errorf("Unblock() status = %s, want %s", task.Status, StatusOpen)
+	}
+	if task.BlockReason != "" {
+		t.Errorf("Unblock() reason = %q, want empty", task.BlockReason)
+	}
+}
+
 func TestTaskIsClosed(t *testing.T) {
 	tests := []struct {
 		status string
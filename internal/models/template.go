@@ -4,6 +4,9 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -22,6 +25,9 @@ type Template struct {
 	UpdatedAt   time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
+// templateVarRegex matches {{name}} placeholders in template text
+var templateVarRegex = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
 // GenerateTemplateID creates a new template ID
 func GenerateTemplateID() string {
 	bytes := make([]byte, 4)
@@ -53,3 +59,78 @@ func (t *Template) ToTask() *Task {
 	copy(task.Labels, t.Labels)
 	return task
 }
+
+// TemplateVar is a placeholder used by a template and where it appears
+type TemplateVar struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"` // title, description, labels
+}
+
+// Vars returns the template's {{placeholders}} in order of first use
+func (t *Template) Vars() []TemplateVar {
+	var vars []TemplateVar
+	index := make(map[string]int)
+	add := func(field, text string) {
+		for _, m := range templateVarRegex.FindAllStringSubmatch(text, -1) {
+			i, ok := index[m[1]]
+			if !ok {
+				i = len(vars)
+				index[m[1]] = i
+				vars = append(vars, TemplateVar{Name: m[1]})
+			}
+			if f := vars[i].Fields; len(f) == 0 || f[len(f)-1] != field {
+				vars[i].Fields = append(vars[i].Fields, field)
+			}
+		}
+	}
+	add("title", t.Title)
+	add("description", t.Description)
+	for _, l := range t.Labels {
+		add("labels", l)
+	}
+	return vars
+}
+
+// Render creates a task from the template with {{placeholders}} replaced by
+// vars. Every placeholder must have a value and every value must be used.
+func (t *Template) Render(vars map[string]string) (*Task, error) {
+	used := t.Vars()
+	known := make(map[string]bool, len(used))
+	var missing []string
+	for _, v := range used {
+		known[v.Name] = true
+		if _, ok := vars[v.Name]; !ok {
+			missing = append(missing, v.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("template '%s' needs a value for %s (use --var name=value)", t.Name, strings.Join(missing, ", "))
+	}
+	var unknown []string
+	for name := range vars {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("template '%s' has no variable %s (see 'gur template vars %s')", t.Name, strings.Join(unknown, ", "), t.Name)
+	}
+
+	replace := func(text string) string {
+		return templateVarRegex.ReplaceAllStringFunc(text, func(m string) string {
+			return vars[templateVarRegex.FindStringSubmatch(m)[1]]
+		})
+	}
+	task := t.ToTask()
+	task.Title = replace(task.Title)
+	task.Description = replace(task.Description)
+	labels := task.Labels[:0]
+	for _, l := range task.Labels {
+		if l = replace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	task.Labels = labels
+	return task, nil
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTemplateVarsAndRender(t *testing.T) {
+	tmpl := Template{
+		Name:        "incident",
+		Title:       "[{{service}}] incident",
+		Description: "Severity {{ severity }} on {{service}}",
+		Labels:      StringSlice{"svc:{{service}}", "{{extra}}", "oncall"},
+		Type:        TypeBug,
+	}
+
+	want := []TemplateVar{
+		{Name: "service", Fields: []string{"title", "description", "labels"}},
+		{Name: "severity", Fields: []string{"description"}},
+		{Name: "extra", Fields: []string{"labels"}},
+	}
+	if got := tmpl.Vars(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vars() = %+v, want %+v", got, want)
+	}
+
+	task, err := tmpl.Render(map[string]string{"service": "auth", "severity": "2", "extra": ""})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if task.Title != "[auth] incident" || task.Description != "Severity 2 on auth" || task.Type != TypeBug {
+		t.Errorf("task = %+v", task)
+	}
+	if !reflect.DeepEqual(task.Labels, StringSlice{"svc:auth", "oncall"}) {
+		t.Errorf("labels = %v, want empty label dropped", task.Labels)
+	}
+	if tmpl.Labels[0] != "svc:{{service}}" {
+		t.Error("Render modified the template's labels")
+	}
+
+	if _, err := tmpl.Render(map[string]string{"service": "auth"}); err == nil {
+		t.Error("expected error for missing variables")
+	}
+	if _, err := tmpl.Render(map[string]string{"service": "a", "severity": "1", "extra": "", "typo": "x"}); err == nil {
+		t.Error("expected error for unknown variable")
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Effort units: estimates and logged time count working days and weeks
+const (
+	MinutesPerWorkDay  = 8 * 60
+	MinutesPerWorkWeek = 5 * MinutesPerWorkDay
+)
+
+// TimeEntry records time spent working on a task
+type TimeEntry struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TaskID    string    `gorm:"size:30;not null;index" json:"task_id"`
+	Minutes   int       `gorm:"not null" json:"minutes"`
+	LoggedBy  string    `gorm:"size:100" json:"logged_by,omitempty"`
+	Note      string    `gorm:"size:255" json:"note,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for TimeEntry
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
+
+// FormatEffort renders minutes of effort compactly, e.g. "1d 2h" or "45m"
+func FormatEffort(minutes int) string {
+	if minutes <= 0 {
+		return "0m"
+	}
+	days, rest := minutes/MinutesPerWorkDay, minutes%MinutesPerWorkDay
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if rest/60 > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", rest/60))
+	}
+	if rest%60 > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", rest%60))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// API token scopes, each including the ones before it
+const (
+	TokenScopeRead  = "read"  // Read-only endpoints
+	TokenScopeWrite = "write" // Endpoints that change tasks, gates, etc.
+	TokenScopeAdmin = "admin" // Administrative endpoints
+)
+
+// TokenScopes lists the valid token scopes, narrowest first
+var TokenScopes = []string{TokenScopeRead, TokenScopeWrite, TokenScopeAdmin}
+
+// Token secret format: the prefix, then TokenSecretBytes random bytes in hex
+const (
+	TokenSecretPrefix = "gur_"
+	TokenSecretBytes  = 24
+	tokenShownLength  = len(TokenSecretPrefix) + 8 // Kept in APIToken.Prefix
+)
+
+// APIToken is a credential for 'gur serve'. Only a hash of its secret is
+// stored; the secret itself is shown once, when the token is created.
+type APIToken struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	Name       string     `gorm:"size:100;not null;index" json:"name"`
+	Prefix     string     `gorm:"size:20;not null" json:"prefix"`        // Start of the secret, to recognize it by
+	Hash       string     `gorm:"size:64;uniqueIndex;not null" json:"-"` // SHA-256 of the secret, hex
+	Scope      string     `gorm:"size:10;not null" json:"scope"`         // read, write or admin
+	CreatedBy  string     `gorm:"size:100" json:"created_by,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// TableName specifies the table name for APIToken
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+// IsRevoked returns true if the token can no longer be used
+func (t *APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// ValidateTokenScope checks a token scope
+func ValidateTokenScope(scope string) error {
+	for _, s := range TokenScopes {
+		if scope == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid scope '%s': must be one of %s", scope, strings.Join(TokenScopes, ", "))
+}
+
+// TokenScopeAllows reports whether a token with scope may use an endpoint
+// that needs the need scope
+func TokenScopeAllows(scope, need string) bool {
+	rank := func(s string) int {
+		for i, v := range TokenScopes {
+			if s == v {
+				return i
+			}
+		}
+		return -1
+	}
+	return rank(need) >= 0 && rank(scope) >= rank(need)
+}
+
+// GenerateTokenSecret creates a new random token secret like "gur_3f9a..."
+func GenerateTokenSecret() string {
+	bytes := make([]byte, TokenSecretBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		// crypto/rand failure indicates serious system issues - fail fast
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return TokenSecretPrefix + hex.EncodeToString(bytes)
+}
+
+// HashTokenSecret returns the hash stored for a token secret
+func HashTokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewAPIToken returns a token with a fresh secret, and the secret
+func NewAPIToken(name, scope, createdBy string) (*APIToken, string) {
+	secret := GenerateTokenSecret()
+	return &APIToken{
+		Name:      name,
+		Prefix:    secret[:tokenShownLength],
+		Hash:      HashTokenSecret(secret),
+		Scope:     scope,
+		CreatedBy: createdBy,
+	}, secret
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenScopeAllows(t *testing.T) {
+	for _, tt := range []struct {
+		scope, need string
+		want        bool
+	}{
+		{TokenScopeRead, TokenScopeRead, true},
+		{TokenScopeRead, TokenScopeWrite, false},
+		{TokenScopeWrite, TokenScopeRead, true},
+		{TokenScopeAdmin, TokenScopeWrite, true},
+		{TokenScopeWrite, TokenScopeAdmin, false},
+		{"", TokenScopeRead, false},
+		{TokenScopeAdmin, "bogus", false},
+	} {
+		if got := TokenScopeAllows(tt.scope, tt.need); got != tt.want {
+			t.Errorf("TokenScopeAllows(%q, %q) = %v, want %v", tt.scope, tt.need, got, tt.want)
+		}
+	}
+}
+
+func TestNewAPIToken(t *testing.T) {
+	token, secret := NewAPIToken("dashboard", TokenScopeRead, "alice")
+	if !strings.HasPrefix(secret, TokenSecretPrefix) || len(secret) != len(TokenSecretPrefix)+2*TokenSecretBytes {
+		t.Errorf("secret = %q, want gur_ and %d hex characters", secret, 2*TokenSecretBytes)
+	}
+	if !strings.HasPrefix(secret, token.Prefix) || token.Hash != HashTokenSecret(secret) || strings.Contains(token.Hash, secret) {
+		t.Errorf("token = %+v, want the secret's prefix and hash only", token)
+	}
+	if _, other := NewAPIToken("dashboard", TokenScopeRead, "alice"); other == secret {
+		t.Error("two tokens got the same secret")
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserMapEntry maps a GitHub login to a local assignee name
+type UserMapEntry struct {
+	GitHub string `json:"github"`
+	Local  string `json:"local"`
+}
+
+// UserMap translates between GitHub logins and local assignee names.
+// Logins are matched case-insensitively, as GitHub does; local names exactly.
+type UserMap struct {
+	Entries []UserMapEntry `json:"entries"`
+}
+
+// ParseUserMap parses a spec like "octocat=alice,hubot=claude-backend"
+func ParseUserMap(spec string) (UserMap, error) {
+	var m UserMap
+	logins := make(map[string]bool)
+	locals := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		login, local, ok := strings.Cut(part, "=")
+		login, local = strings.TrimPrefix(strings.TrimSpace(login), "@"), strings.TrimSpace(local)
+		if !ok || login == "" || local == "" {
+			return UserMap{}, fmt.Errorf("invalid user mapping '%s': expected login=assignee", part)
+		}
+		if logins[strings.ToLower(login)] {
+			return UserMap{}, fmt.Errorf("duplicate user mapping for login '%s'", login)
+		}
+		if locals[local] {
+			return UserMap{}, fmt.Errorf("duplicate user mapping for assignee '%s'", local)
+		}
+		logins[strings.ToLower(login)], locals[local] = true, true
+		m.Entries = append(m.Entries, UserMapEntry{GitHub: login, Local: local})
+	}
+	return m, nil
+}
+
+// String renders the mapping in the spec format accepted by ParseUserMap
+func (m UserMap) String() string {
+	parts := make([]string, len(m.Entries))
+	for i, e := range m.Entries {
+		parts[i] = e.GitHub + "=" + e.Local
+	}
+	return strings.Join(parts, ",")
+}
+
+// ToLocal returns the assignee for a GitHub login, or the login itself if
+// it isn't mapped
+func (m UserMap) ToLocal(login string) string {
+	for _, e := range m.Entries {
+		if strings.EqualFold(e.GitHub, login) {
+			return e.Local
+		}
+	}
+	return login
+}
+
+// ToGitHub returns the login mapped to a local assignee
+func (m UserMap) ToGitHub(local string) (string, bool) {
+	for _, e := range m.Entries {
+		if e.Local == local {
+			return e.GitHub, true
+		}
+	}
+	return "", false
+}
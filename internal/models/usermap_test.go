@@ -0,0 +1,38 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUserMap(t *testing.T) {
+	m, err := ParseUserMap("octocat=alice, @Hubot = claude-backend")
+	if err != nil {
+		t.Fatalf("ParseUserMap() error: %v", err)
+	}
+	want := []UserMapEntry{{GitHub: "octocat", Local: "alice"}, {GitHub: "Hubot", Local: "claude-backend"}}
+	if !reflect.DeepEqual(m.Entries, want) {
+		t.Errorf("ParseUserMap() = %+v, want %+v", m.Entries, want)
+	}
+	if got := m.String(); got != "octocat=alice,Hubot=claude-backend" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := m.ToLocal("hubot"); got != "claude-backend" {
+		t.Errorf("ToLocal(hubot) = %q, want claude-backend", got)
+	}
+	if got := m.ToLocal("monalisa"); got != "monalisa" {
+		t.Errorf("ToLocal of an unmapped login = %q, want it unchanged", got)
+	}
+	if login, ok := m.ToGitHub("alice"); !ok || login != "octocat" {
+		t.Errorf("ToGitHub(alice) = %q, %v", login, ok)
+	}
+	if _, ok := m.ToGitHub("bob"); ok {
+		t.Error("ToGitHub of an unmapped assignee should not match")
+	}
+
+	for _, bad := range []string{"octocat", "=alice", "a=x,A=y", "a=x,b=x"} {
+		if _, err := ParseUserMap(bad); err == nil {
+			t.Errorf("ParseUserMap(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ANSI SGR codes used by the themes
+const (
+	reset     = "0"
+	bold      = "1"
+	dim       = "2"
+	red       = "31"
+	green     = "32"
+	yellow    = "33"
+	blue      = "34"
+	magenta   = "35"
+	cyan      = "36"
+	boldRed   = "1;31"
+	brightRed = "91"
+	brightGrn = "92"
+	brightYel = "93"
+	brightBlu = "94"
+	brightCyn = "96"
+)
+
+// Theme maps task statuses, priorities and gate results to ANSI colors.
+// Missing entries are printed uncolored.
+type Theme struct {
+	Name     string
+	Status   map[string]string // open, in_progress, blocked, closed, archived
+	Priority [5]string         // P0 (critical) .. P4 (lowest)
+	Result   map[string]string // pass, fail, pending, skip, waived, requested
+}
+
+// Themes are the built-in themes, by name
+var Themes = map[string]*Theme{
+	"default": {
+		Name:     "default",
+		Status:   map[string]string{"open": cyan, "in_progress": yellow, "blocked": red, "closed": green, "archived": dim},
+		Priority: [5]string{boldRed, red, yellow, blue, dim},
+		Result:   map[string]string{"pass": green, "fail": boldRed, "pending": yellow, "skip": dim, "waived": magenta, "requested": cyan},
+	},
+	"bright": {
+		Name:     "bright",
+		Status:   map[string]string{"open": brightCyn, "in_progress": brightYel, "blocked": brightRed, "closed": brightGrn, "archived": dim},
+		Priority: [5]string{bold + ";" + brightRed, brightRed, brightYel, brightBlu, dim},
+		Result:   map[string]string{"pass": brightGrn, "fail": bold + ";" + brightRed, "pending": brightYel, "skip": dim, "waived": magenta, "requested": brightCyn},
+	},
+	"mono": {
+		// Emphasis only, for terminals where colors are hard to read
+		Name:     "mono",
+		Status:   map[string]string{"blocked": bold, "archived": dim},
+		Priority: [5]string{bold, bold},
+		Result:   map[string]string{"fail": bold, "skip": dim},
+	},
+}
+
+// DefaultTheme is used when no theme is configured
+const DefaultTheme = "default"
+
+// ThemeNames returns the built-in theme names, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTheme returns the named theme
+func LookupTheme(name string) (*Theme, error) {
+	if theme, ok := Themes[name]; ok {
+		return theme, nil
+	}
+	return nil, fmt.Errorf("unknown theme '%s': must be one of %s", name, strings.Join(ThemeNames(), ", "))
+}
+
+// ColorEnabled reports whether colored output should be written to f: not
+// when NO_COLOR is set (https://no-color.org), --no-color was given, TERM
+// is dumb, or f isn't a terminal (e.g., piped output)
+func ColorEnabled(noColor bool, f *os.File) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Painter colors output with a theme; a nil theme or disabled painter
+// returns text unchanged
+type Painter struct {
+	theme   *Theme
+	enabled bool
+}
+
+// NewPainter returns a painter for theme, coloring only when enabled
+func NewPainter(theme *Theme, enabled bool) *Painter {
+	return &Painter{theme: theme, enabled: enabled}
+}
+
+// Enabled reports whether the painter writes colors
+func (p *Painter) Enabled() bool {
+	return p.enabled && p.theme != nil
+}
+
+func (p *Painter) paint(code, s string) string {
+	if !p.Enabled() || code == "" {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[" + reset + "m"
+}
+
+// Status colors a task status
+func (p *Painter) Status(status string) string {
+	if !p.Enabled() {
+		return status
+	}
+	return p.paint(p.theme.Status[status], status)
+}
+
+// Priority formats and colors a priority as P0..P4
+func (p *Painter) Priority(priority int) string {
+	return p.PriorityText(priority, fmt.Sprintf("P%d", priority))
+}
+
+// PriorityText colors text in the color of a priority
+func (p *Painter) PriorityText(priority int, text string) string {
+	if !p.Enabled() || priority < 0 || priority >= len(p.theme.Priority) {
+		return text
+	}
+	return p.paint(p.theme.Priority[priority], text)
+}
+
+// Result colors a gate result, either a gate's PASS/FAIL/SKIP/PENDING or a
+// link status like passed, failed or waived
+func (p *Painter) Result(result string) string {
+	if !p.Enabled() {
+		return result
+	}
+	key := strings.ToLower(result)
+	switch key {
+	case "passed":
+		key = "pass"
+	case "failed":
+		key = "fail"
+	case "skipped":
+		key = "skip"
+	}
+	return p.paint(p.theme.Result[key], result)
+}
+
+// Link makes text a terminal hyperlink to url (OSC 8). It is written
+// whenever colors are, since terminals without support show the text alone.
+func (p *Painter) Link(url, text string) string {
+	if !p.enabled || url == "" {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
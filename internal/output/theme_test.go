@@ -0,0 +1,56 @@
+package output
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPainter(t *testing.T) {
+	p := NewPainter(Themes["default"], true)
+	if got := p.Status("blocked"); got != "\x1b[31mblocked\x1b[0m" {
+		t.Errorf("Status(blocked) = %q", got)
+	}
+	if got := p.Priority(0); got != "\x1b[1;31mP0\x1b[0m" {
+		t.Errorf("Priority(0) = %q", got)
+	}
+	if got := p.Result("passed"); got != "\x1b[32mpassed\x1b[0m" {
+		t.Errorf("Result(passed) = %q", got)
+	}
+	if got := p.Status("someday"); got != "someday" {
+		t.Errorf("Status(unknown) = %q, want uncolored", got)
+	}
+
+	off := NewPainter(Themes["default"], false)
+	if got := off.Priority(0) + off.Status("open") + off.Result("FAIL"); got != "P0openFAIL" {
+		t.Errorf("disabled painter = %q, want plain text", got)
+	}
+	if got := NewPainter(Themes["mono"], true).Priority(3); got != "P3" {
+		t.Errorf("mono Priority(3) = %q, want plain", got)
+	}
+	if got := p.Link("https://example.com", "#1"); got != "\x1b]8;;https://example.com\x1b\\#1\x1b]8;;\x1b\\" {
+		t.Errorf("Link() = %q", got)
+	}
+	if got := off.Link("https://example.com", "#1"); got != "#1" {
+		t.Errorf("disabled Link() = %q, want plain text", got)
+	}
+
+	if _, err := LookupTheme("neon"); err == nil {
+		t.Error("LookupTheme accepted an unknown theme")
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if ColorEnabled(false, f) {
+		t.Error("ColorEnabled() = true for a regular file")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled(false, os.Stdout) {
+		t.Error("ColorEnabled() = true with NO_COLOR set")
+	}
+}
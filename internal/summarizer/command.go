@@ -0,0 +1,37 @@
+package summarizer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// command runs a local shell command with the prompt on stdin and reads the
+// summary from stdout (e.g., "ollama run llama3")
+type command struct {
+	cmdline string
+}
+
+func (c *command) Summarize(ctx context.Context, prompt string) (string, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.cmdline)
+	cmd.Stdin = strings.NewReader(prompt)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("summarizer command failed: %w", err)
+		}
+		return "", fmt.Errorf("summarizer command failed: %w: %s", err, msg)
+	}
+
+	summary := clean(stdout.String())
+	if summary == "" {
+		return "", fmt.Errorf("summarizer command produced no output")
+	}
+	return summary, nil
+}
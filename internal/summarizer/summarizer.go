@@ -0,0 +1,116 @@
+// Package summarizer produces natural-language summaries of task text using
+// a pluggable backend: an OpenAI-compatible API, the Anthropic API, or a local
+// command.
+package summarizer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Backend names
+const (
+	BackendOpenAI    = "openai"
+	BackendAnthropic = "anthropic"
+	BackendCommand   = "command"
+)
+
+// Default models and endpoints
+const (
+	DefaultOpenAIModel    = "gpt-4o-mini"
+	DefaultAnthropicModel = "claude-3-5-haiku-latest"
+	DefaultOpenAIURL      = "https://api.openai.com/v1"
+	DefaultAnthropicURL   = "https://api.anthropic.com/v1"
+	DefaultMaxTokens      = 300
+	requestTimeout        = 60 * time.Second
+)
+
+// Backends lists the supported backend names
+var Backends = []string{BackendOpenAI, BackendAnthropic, BackendCommand}
+
+// Summarizer turns a prompt into a summary
+type Summarizer interface {
+	Summarize(ctx context.Context, prompt string) (string, error)
+}
+
+// Config selects and configures a backend
+type Config struct {
+	Backend string
+	Model   string
+	BaseURL string // Overrides the API endpoint (e.g., a local OpenAI-compatible server)
+	APIKey  string
+	Command string // Shell command for the command backend; prompt on stdin, summary on stdout
+}
+
+// New returns the summarizer for cfg.Backend
+func New(cfg Config) (Summarizer, error) {
+	client := &http.Client{Timeout: requestTimeout}
+	switch cfg.Backend {
+	case BackendOpenAI:
+		if cfg.Model == "" {
+			cfg.Model = DefaultOpenAIModel
+		}
+		if cfg.BaseURL == "" {
+			cfg.BaseURL = DefaultOpenAIURL
+		}
+		return &openAI{cfg: cfg, client: client}, nil
+	case BackendAnthropic:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("anthropic backend requires an API key")
+		}
+		if cfg.Model == "" {
+			cfg.Model = DefaultAnthropicModel
+		}
+		if cfg.BaseURL == "" {
+			cfg.BaseURL = DefaultAnthropicURL
+		}
+		return &anthropic{cfg: cfg, client: client}, nil
+	case BackendCommand:
+		if strings.TrimSpace(cfg.Command) == "" {
+			return nil, fmt.Errorf("command backend requires a command")
+		}
+		return &command{cmdline: cfg.Command}, nil
+	case "":
+		return nil, fmt.Errorf("no summarizer configured")
+	default:
+		return nil, fmt.Errorf("unknown summarizer backend '%s': must be one of %s", cfg.Backend, strings.Join(Backends, ", "))
+	}
+}
+
+// TaskInput is the task text to be summarized before compaction
+type TaskInput struct {
+	Title       string
+	Type        string
+	Description string
+	Notes       string
+	CloseReason string
+}
+
+// TaskPrompt builds the prompt used to summarize a task before compaction
+func TaskPrompt(in TaskInput) string {
+	var sb strings.Builder
+	sb.WriteString("Summarize this completed software task in at most two sentences for a future engineer or AI agent. ")
+	sb.WriteString("Keep concrete details (what changed, key decisions, follow-ups); omit pleasantries. Reply with the summary only.\n\n")
+	fmt.Fprintf(&sb, "Title: %s\n", in.Title)
+	if in.Type != "" {
+		fmt.Fprintf(&sb, "Type: %s\n", in.Type)
+	}
+	if in.CloseReason != "" {
+		fmt.Fprintf(&sb, "Close reason: %s\n", in.CloseReason)
+	}
+	if in.Description != "" {
+		fmt.Fprintf(&sb, "\nDescription:\n%s\n", in.Description)
+	}
+	if in.Notes != "" {
+		fmt.Fprintf(&sb, "\nNotes:\n%s\n", in.Notes)
+	}
+	return sb.String()
+}
+
+// clean normalises backend output into a single summary string
+func clean(s string) string {
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,129 @@
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"openai", Config{Backend: BackendOpenAI}, false},
+		{"anthropic needs key", Config{Backend: BackendAnthropic}, true},
+		{"anthropic", Config{Backend: BackendAnthropic, APIKey: "k"}, false},
+		{"command needs command", Config{Backend: BackendCommand}, true},
+		{"command", Config{Backend: BackendCommand, Command: "cat"}, false},
+		{"unconfigured", Config{}, true},
+		{"unknown", Config{Backend: "carrier-pigeon"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New(%+v) error = %v, wantErr %v", tt.cfg, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTaskPrompt(t *testing.T) {
+	p := TaskPrompt(TaskInput{Title: "Fix login", CloseReason: "done", Notes: "root cause: stale cookie"})
+	for _, want := range []string{"Title: Fix login", "Close reason: done", "root cause: stale cookie"} {
+		if !strings.Contains(p, want) {
+			t.Errorf("TaskPrompt() missing %q", want)
+		}
+	}
+	if strings.Contains(p, "Description:") {
+		t.Error("TaskPrompt() should omit empty sections")
+	}
+}
+
+func TestOpenAIBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("path = %s, want /chat/completions", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var req struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != DefaultOpenAIModel || len(req.Messages) != 1 || req.Messages[0].Content != "prompt" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"content":"  A summary.\n"}}]}`))
+	}))
+	defer server.Close()
+
+	s, _ := New(Config{Backend: BackendOpenAI, BaseURL: server.URL, APIKey: "secret"})
+	got, err := s.Summarize(context.Background(), "prompt")
+	if err != nil {
+		t.Fatalf("Summarize() error: %v", err)
+	}
+	if got != "A summary." {
+		t.Errorf("Summarize() = %q, want %q", got, "A summary.")
+	}
+}
+
+func TestAnthropicBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" {
+			t.Errorf("path = %s, want /messages", r.URL.Path)
+		}
+		if r.Header.Get("x-api-key") != "secret" || r.Header.Get("anthropic-version") == "" {
+			t.Errorf("missing auth headers: %v", r.Header)
+		}
+		w.Write([]byte(`{"content":[{"type":"text","text":"Short "},{"type":"text","text":"summary."}]}`))
+	}))
+	defer server.Close()
+
+	s, _ := New(Config{Backend: BackendAnthropic, BaseURL: server.URL, APIKey: "secret"})
+	got, err := s.Summarize(context.Background(), "prompt")
+	if err != nil {
+		t.Fatalf("Summarize() error: %v", err)
+	}
+	if got != "Short summary." {
+		t.Errorf("Summarize() = %q, want %q", got, "Short summary.")
+	}
+}
+
+func TestAPIErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"rate limited"}`, http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	s, _ := New(Config{Backend: BackendOpenAI, BaseURL: server.URL})
+	if _, err := s.Summarize(context.Background(), "prompt"); err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("Summarize() error = %v, want rate limited error", err)
+	}
+}
+
+func TestCommandBackend(t *testing.T) {
+	s, _ := New(Config{Backend: BackendCommand, Command: "tr a-z A-Z"})
+	got, err := s.Summarize(context.Background(), "done\n")
+	if err != nil {
+		t.Fatalf("Summarize() error: %v", err)
+	}
+	if got != "DONE" {
+		t.Errorf("Summarize() = %q, want DONE", got)
+	}
+
+	s, _ = New(Config{Backend: BackendCommand, Command: "echo boom >&2; exit 3"})
+	if _, err := s.Summarize(context.Background(), "x"); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Summarize() error = %v, want stderr in error", err)
+	}
+}
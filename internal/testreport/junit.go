@@ -0,0 +1,75 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitSuite matches both <testsuites> and <testsuite> elements, which may nest
+type junitSuite struct {
+	XMLName xml.Name
+	Name    string          `xml:"name,attr"`
+	Suites  []junitSuite    `xml:"testsuite"`
+	Cases   []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Error     *junitFailure `xml:"error"`
+	Skipped   *struct{}     `xml:"skipped"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnit parses a JUnit XML report
+func ParseJUnit(r io.Reader) (*Report, error) {
+	var root junitSuite
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid JUnit XML: %w", err)
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, fmt.Errorf("invalid JUnit XML: unexpected root element <%s>", root.XMLName.Local)
+	}
+
+	report := &Report{Format: "junit"}
+	walkJUnit(report, root)
+	return report, nil
+}
+
+func walkJUnit(report *Report, suite junitSuite) {
+	for _, c := range suite.Cases {
+		report.Total++
+		name := c.Name
+		if c.Classname != "" {
+			name = c.Classname + "." + c.Name
+		}
+		switch {
+		case c.Failure != nil:
+			report.addFailure(name, firstNonEmpty(c.Failure.Message, c.Failure.Text))
+		case c.Error != nil:
+			report.addFailure(name, firstNonEmpty(c.Error.Message, c.Error.Text))
+		case c.Skipped != nil:
+			report.Skipped++
+		default:
+			report.Passed++
+		}
+	}
+	for _, s := range suite.Suites {
+		walkJUnit(report, s)
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
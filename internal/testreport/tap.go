@@ -0,0 +1,100 @@
+package testreport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	tapPlan   = regexp.MustCompile(`^1\.\.(\d+)`)
+	tapResult = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*(?:-\s*)?(.*)$`)
+)
+
+// ParseTAP parses a Test Anything Protocol stream. Tests marked # SKIP count
+// as skipped; failing tests marked # TODO don't count as failures. Planned
+// tests that never reported, and "Bail out!", are failures.
+func ParseTAP(r io.Reader) (*Report, error) {
+	report := &Report{Format: "tap"}
+	planned := -1
+	sawLine := false
+
+	// YAML diagnostics after a failing test carry its message
+	lastFailure := -1
+	inYAML := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		if inYAML {
+			if line == "..." {
+				inYAML = false
+			} else if msg, ok := strings.CutPrefix(line, "message:"); ok && lastFailure >= 0 && report.Failures[lastFailure].Message == "" {
+				report.Failures[lastFailure].Message = strings.Trim(strings.TrimSpace(msg), `"'`)
+			}
+			continue
+		}
+		if line == "---" && raw != line {
+			inYAML = true
+			continue
+		}
+
+		if m := tapPlan.FindStringSubmatch(line); m != nil {
+			planned, _ = strconv.Atoi(m[1])
+			sawLine = true
+			continue
+		}
+		if reason, ok := strings.CutPrefix(line, "Bail out!"); ok {
+			report.Total++
+			report.addFailure("Bail out!", reason)
+			lastFailure = -1
+			sawLine = true
+			continue
+		}
+
+		m := tapResult.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		sawLine = true
+		report.Total++
+		lastFailure = -1
+
+		desc, directive, _ := strings.Cut(m[3], "#")
+		desc = strings.TrimSpace(desc)
+		directive = strings.ToUpper(strings.TrimSpace(directive))
+		if desc == "" {
+			desc = "test " + m[2]
+		}
+
+		switch {
+		case strings.HasPrefix(directive, "SKIP"):
+			report.Skipped++
+		case m[1] == "ok" || strings.HasPrefix(directive, "TODO"):
+			report.Passed++
+		default:
+			report.addFailure(desc, "")
+			lastFailure = len(report.Failures) - 1
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read TAP: %w", err)
+	}
+	if !sawLine {
+		return nil, fmt.Errorf("invalid TAP: no plan or test lines found")
+	}
+
+	if planned > report.Total {
+		missing := planned - report.Total
+		report.Total = planned
+		report.addFailure("planned tests", fmt.Sprintf("%d of %d planned tests did not run", missing, planned))
+		report.Failed += missing - 1
+	}
+	return report, nil
+}
@@ -0,0 +1,67 @@
+// Package testreport parses JUnit XML and TAP test reports into pass/fail
+// counts and failing test names, so CI results can be recorded against gates.
+package testreport
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxFailureMessage caps each failure message kept in the report output
+const maxFailureMessage = 200
+
+// Failure is a failing (or erroring) test
+type Failure struct {
+	Name    string `json:"name"`
+	Message string `json:"message,omitempty"`
+}
+
+// Report summarizes a test run
+type Report struct {
+	Format   string    `json:"format"`
+	Total    int       `json:"total"`
+	Passed   int       `json:"passed"`
+	Failed   int       `json:"failed"`
+	Skipped  int       `json:"skipped"`
+	Failures []Failure `json:"failures,omitempty"`
+}
+
+// OK returns true if the run had tests and none failed
+func (r *Report) OK() bool {
+	return r.Total > 0 && r.Failed == 0
+}
+
+// Summary returns a one-line description of the counts
+func (r *Report) Summary() string {
+	return fmt.Sprintf("%s: %d tests, %d passed, %d failed, %d skipped", r.Format, r.Total, r.Passed, r.Failed, r.Skipped)
+}
+
+// Output lists the failing tests for storage in a gate run, or "" if none failed
+func (r *Report) Output() string {
+	if len(r.Failures) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("Failing tests:\n")
+	for _, f := range r.Failures {
+		sb.WriteString("  - " + f.Name)
+		if f.Message != "" {
+			sb.WriteString(": " + f.Message)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// addFailure records a failing test, trimming its message to one short line
+func (r *Report) addFailure(name, message string) {
+	r.Failed++
+	message = strings.TrimSpace(message)
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		message = message[:i]
+	}
+	if len(message) > maxFailureMessage {
+		message = message[:maxFailureMessage-3] + "..."
+	}
+	r.Failures = append(r.Failures, Failure{Name: name, Message: message})
+}
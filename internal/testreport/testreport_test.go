@@ -0,0 +1,84 @@
+package testreport
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseJUnit(t *testing.T) {
+	xml := `<?xml version="1.0"?>
+<testsuites>
+  <testsuite name="auth">
+    <testcase classname="auth.Login" name="valid"/>
+    <testcase classname="auth.Login" name="expired">
+      <failure message="token expired">stack trace here</failure>
+    </testcase>
+    <testcase classname="auth.Login" name="flaky"><skipped/></testcase>
+  </testsuite>
+  <testsuite name="api">
+    <testcase name="panics"><error>nil pointer
+at line 3</error></testcase>
+  </testsuite>
+</testsuites>`
+
+	report, err := ParseJUnit(strings.NewReader(xml))
+	if err != nil {
+		t.Fatalf("ParseJUnit() error: %v", err)
+	}
+	if report.Total != 4 || report.Passed != 1 || report.Failed != 2 || report.Skipped != 1 {
+		t.Errorf("ParseJUnit() counts = %+v", report)
+	}
+	if report.OK() {
+		t.Error("report with failures should not be OK")
+	}
+	want := []Failure{{"auth.Login.expired", "token expired"}, {"panics", "nil pointer"}}
+	if len(report.Failures) != 2 || report.Failures[0] != want[0] || report.Failures[1] != want[1] {
+		t.Errorf("ParseJUnit() failures = %+v, want %+v", report.Failures, want)
+	}
+
+	single := `<testsuite name="one"><testcase name="a"/></testsuite>`
+	if report, err := ParseJUnit(strings.NewReader(single)); err != nil || !report.OK() {
+		t.Errorf("ParseJUnit(single suite) = %+v, %v", report, err)
+	}
+
+	if _, err := ParseJUnit(strings.NewReader(`<html></html>`)); err == nil {
+		t.Error("ParseJUnit() should reject non-JUnit XML")
+	}
+}
+
+func TestParseTAP(t *testing.T) {
+	tap := `TAP version 13
+1..6
+ok 1 - login works
+not ok 2 - token refresh
+  ---
+  message: "expected 200, got 401"
+  ...
+ok 3 - cache # SKIP no redis
+not ok 4 - new parser # TODO not implemented
+ok 5
+`
+	report, err := ParseTAP(strings.NewReader(tap))
+	if err != nil {
+		t.Fatalf("ParseTAP() error: %v", err)
+	}
+	// Test 6 was planned but never reported
+	if report.Total != 6 || report.Passed != 3 || report.Skipped != 1 || report.Failed != 2 {
+		t.Errorf("ParseTAP() counts = %+v", report)
+	}
+	if report.Failures[0].Name != "token refresh" || report.Failures[0].Message != "expected 200, got 401" {
+		t.Errorf("ParseTAP() first failure = %+v", report.Failures[0])
+	}
+	if !strings.Contains(report.Output(), "1 of 6 planned tests did not run") {
+		t.Errorf("Output() = %q, want missing tests noted", report.Output())
+	}
+
+	if _, err := ParseTAP(strings.NewReader("hello\nworld\n")); err == nil {
+		t.Error("ParseTAP() should reject input without TAP lines")
+	}
+
+	bail, _ := ParseTAP(strings.NewReader("1..3\nok 1\nBail out! database down\n"))
+	if bail.OK() || bail.Failures[0].Message != "database down" {
+		t.Errorf("ParseTAP(bail out) = %+v", bail)
+	}
+}
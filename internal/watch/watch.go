@@ -0,0 +1,85 @@
+// Package watch detects file changes under a directory tree by polling.
+// Polling needs no platform support and naturally debounces bursts of
+// writes (e.g., a checkout) into a single change set per interval.
+package watch
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fileState is what a snapshot records about each file
+type fileState struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// Snapshot maps slash-separated paths, relative to the scanned root, to
+// their state
+type Snapshot map[string]fileState
+
+// Scan records every regular file under root. Hidden directories (such as
+// .git and .guardrails) are skipped.
+func Scan(root string) (Snapshot, error) {
+	snap := make(Snapshot)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files can vanish mid-walk; report what could be read
+			if path != root {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		snap[filepath.ToSlash(rel)] = fileState{ModTime: info.ModTime(), Size: info.Size()}
+		return nil
+	})
+	return snap, err
+}
+
+// Changed returns the sorted paths added, removed or modified between old
+// and cur
+func Changed(old, cur Snapshot) []string {
+	var paths []string
+	for path, state := range cur {
+		if prev, ok := old[path]; !ok || !prev.ModTime.Equal(state.ModTime) || prev.Size != state.Size {
+			paths = append(paths, path)
+		}
+	}
+	for path := range old {
+		if _, ok := cur[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Under reports whether path is dir or inside it. Both are slash-separated
+// and relative to the same root; an empty or "." dir matches everything.
+func Under(path, dir string) bool {
+	dir = strings.Trim(filepath.ToSlash(filepath.Clean(dir)), "/")
+	if dir == "" || dir == "." {
+		return true
+	}
+	return path == dir || strings.HasPrefix(path, dir+"/")
+}
@@ -0,0 +1,76 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanAndChanged(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "src", "a.go"), "package a")
+	writeFile(t, filepath.Join(root, "src", "b.go"), "package b")
+	writeFile(t, filepath.Join(root, ".git", "HEAD"), "ref")
+
+	before, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if _, ok := before[".git/HEAD"]; ok {
+		t.Error("Scan() should skip hidden directories")
+	}
+	if len(before) != 2 {
+		t.Fatalf("Scan() found %d files, want 2", len(before))
+	}
+
+	writeFile(t, filepath.Join(root, "src", "a.go"), "package a // changed")
+	later := time.Now().Add(time.Second)
+	os.Chtimes(filepath.Join(root, "src", "a.go"), later, later)
+	os.Remove(filepath.Join(root, "src", "b.go"))
+	writeFile(t, filepath.Join(root, "docs", "c.md"), "# c")
+
+	after, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	want := []string{"docs/c.md", "src/a.go", "src/b.go"}
+	if got := Changed(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Changed() = %v, want %v", got, want)
+	}
+	if got := Changed(after, after); len(got) != 0 {
+		t.Errorf("Changed() on identical snapshots = %v, want none", got)
+	}
+}
+
+func TestUnder(t *testing.T) {
+	tests := []struct {
+		path, dir string
+		want      bool
+	}{
+		{"src/a.go", "src", true},
+		{"src/a.go", "src/", true},
+		{"src/a.go", "./src", true},
+		{"src", "src", true},
+		{"srcx/a.go", "src", false},
+		{"docs/c.md", "src", false},
+		{"docs/c.md", ".", true},
+		{"docs/c.md", "", true},
+	}
+	for _, tt := range tests {
+		if got := Under(tt.path, tt.dir); got != tt.want {
+			t.Errorf("Under(%q, %q) = %v, want %v", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
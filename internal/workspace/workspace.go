@@ -0,0 +1,98 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"guardrails/internal/db"
+)
+
+const (
+	// EnvWorkspaceFile overrides the default workspace file location
+	EnvWorkspaceFile = "GUR_WORKSPACE"
+	// DefaultFileName is the workspace filename inside the user's home guardrails directory
+	DefaultFileName = "workspace.json"
+)
+
+// Project is a single guardrails project registered in a workspace
+type Project struct {
+	Name string `json:"name"`
+	Path string `json:"path"` // Project root (the directory containing .guardrails/)
+}
+
+// DBPath returns the path to the project's database file
+func (p Project) DBPath() string {
+	return filepath.Join(p.Path, db.GuardrailsDir, db.DBFileName)
+}
+
+// Workspace lists several guardrails projects that can be queried together
+type Workspace struct {
+	Projects []Project `json:"projects"`
+}
+
+// DefaultPath returns the workspace file path, honoring GUR_WORKSPACE
+func DefaultPath() (string, error) {
+	if p := os.Getenv(EnvWorkspaceFile); p != "" {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, db.GuardrailsDir, DefaultFileName), nil
+}
+
+// Load reads a workspace file. A missing file yields an empty workspace.
+func Load(path string) (*Workspace, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Workspace{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace file: %w", err)
+	}
+	var ws Workspace
+	if err := json.Unmarshal(data, &ws); err != nil {
+		return nil, fmt.Errorf("invalid workspace file %s: %w", path, err)
+	}
+	return &ws, nil
+}
+
+// Save writes the workspace file, creating its directory if needed
+func (w *Workspace) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create workspace directory: %w", err)
+	}
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Add registers a project. Names and paths must be unique.
+func (w *Workspace) Add(p Project) error {
+	for _, existing := range w.Projects {
+		if existing.Name == p.Name {
+			return fmt.Errorf("project '%s' already in workspace", p.Name)
+		}
+		if existing.Path == p.Path {
+			return fmt.Errorf("path '%s' already in workspace as '%s'", p.Path, existing.Name)
+		}
+	}
+	w.Projects = append(w.Projects, p)
+	return nil
+}
+
+// Remove unregisters a project by name or path
+func (w *Workspace) Remove(nameOrPath string) bool {
+	for i, p := range w.Projects {
+		if p.Name == nameOrPath || p.Path == nameOrPath {
+			w.Projects = append(w.Projects[:i], w.Projects[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package workspace
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	ws, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(ws.Projects) != 0 {
+		t.Errorf("Load() missing file returned %d projects, want 0", len(ws.Projects))
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "workspace.json")
+
+	ws := &Workspace{}
+	if err := ws.Add(Project{Name: "api", Path: "/src/api"}); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if err := ws.Add(Project{Name: "web", Path: "/src/web"}); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if err := ws.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(loaded.Projects) != 2 {
+		t.Fatalf("Load() returned %d projects, want 2", len(loaded.Projects))
+	}
+	if loaded.Projects[1].Name != "web" {
+		t.Errorf("Projects[1].Name = %q, want %q", loaded.Projects[1].Name, "web")
+	}
+}
+
+func TestAddDuplicate(t *testing.T) {
+	ws := &Workspace{}
+	ws.Add(Project{Name: "api", Path: "/src/api"})
+
+	if err := ws.Add(Project{Name: "api", Path: "/other"}); err == nil {
+		t.Error("Add() duplicate name should fail")
+	}
+	if err := ws.Add(Project{Name: "other", Path: "/src/api"}); err == nil {
+		t.Error("Add() duplicate path should fail")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	ws := &Workspace{}
+	ws.Add(Project{Name: "api", Path: "/src/api"})
+	ws.Add(Project{Name: "web", Path: "/src/web"})
+
+	if !ws.Remove("/src/api") {
+		t.Error("Remove() by path should succeed")
+	}
+	if ws.Remove("api") {
+		t.Error("Remove() of already removed project should return false")
+	}
+	if len(ws.Projects) != 1 || ws.Projects[0].Name != "web" {
+		t.Errorf("Remove() left %v, want only web", ws.Projects)
+	}
+}